@@ -1041,6 +1041,18 @@ func (s *mixCoordImpl) CheckQueryNodeDistribution(ctx context.Context, req *quer
 	return s.queryCoordServer.CheckQueryNodeDistribution(ctx, req)
 }
 
+func (s *mixCoordImpl) DecommissionNode(ctx context.Context, req *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error) {
+	return s.queryCoordServer.DecommissionNode(ctx, req)
+}
+
+func (s *mixCoordImpl) ListReplicaSuggestions(ctx context.Context, req *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error) {
+	return s.queryCoordServer.ListReplicaSuggestions(ctx, req)
+}
+
+func (s *mixCoordImpl) ApproveReplicaSuggestions(ctx context.Context, req *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error) {
+	return s.queryCoordServer.ApproveReplicaSuggestions(ctx, req)
+}
+
 func (s *mixCoordImpl) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest) (*commonpb.Status, error) {
 	return s.queryCoordServer.UpdateLoadConfig(ctx, req)
 }
@@ -1106,6 +1118,10 @@ func (s *mixCoordImpl) ManualCompaction(ctx context.Context, req *milvuspb.Manua
 	return s.datacoordServer.ManualCompaction(ctx, req)
 }
 
+func (s *mixCoordImpl) ReindexSegments(ctx context.Context, req *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error) {
+	return s.datacoordServer.ReindexSegments(ctx, req)
+}
+
 func (s *mixCoordImpl) GetCompactionState(ctx context.Context, req *milvuspb.GetCompactionStateRequest) (*milvuspb.GetCompactionStateResponse, error) {
 	return s.datacoordServer.GetCompactionState(ctx, req)
 }
@@ -1367,6 +1383,10 @@ func (s *mixCoordImpl) ListRestoreSnapshotJobs(ctx context.Context, req *datapb.
 	return s.datacoordServer.ListRestoreSnapshotJobs(ctx, req)
 }
 
+func (s *mixCoordImpl) CloneCollection(ctx context.Context, req *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error) {
+	return s.datacoordServer.CloneCollection(ctx, req)
+}
+
 func (s *mixCoordImpl) ListSnapshots(ctx context.Context, req *datapb.ListSnapshotsRequest) (*datapb.ListSnapshotsResponse, error) {
 	return s.datacoordServer.ListSnapshots(ctx, req)
 }
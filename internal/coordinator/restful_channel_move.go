@@ -0,0 +1,58 @@
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/balance"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// HandleMoveStreamingChannel moves the wal of the pchannel to the target streaming node.
+// The pchannel is pinned to the target node until it goes offline, a non-positive target_node_id removes the pin.
+func (s *mixCoordImpl) HandleMoveStreamingChannel(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeJSONError(w, "Method not allowed, use POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		ChannelName  string `json:"channel_name"`
+		TargetNodeID int64  `json:"target_node_id"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&requestBody); err != nil {
+		writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if requestBody.ChannelName == "" {
+		writeJSONError(w, "channel_name is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := req.Context()
+	b, err := balance.GetWithContext(ctx)
+	if err != nil {
+		writeJSONError(w, fmt.Sprintf("failed to get streaming balancer: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	if err := b.MoveChannel(ctx, requestBody.ChannelName, requestBody.TargetNodeID); err != nil {
+		mlog.Warn(ctx, "failed to move streaming channel",
+			mlog.String("channel", requestBody.ChannelName),
+			mlog.Int64("targetNodeID", requestBody.TargetNodeID),
+			mlog.Err(err))
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, merr.ErrParameterInvalid) {
+			statusCode = http.StatusBadRequest
+		}
+		writeJSONError(w, fmt.Sprintf("failed to move streaming channel: %s", err.Error()), statusCode)
+		return
+	}
+	mlog.Info(ctx, "move streaming channel success",
+		mlog.String("channel", requestBody.ChannelName),
+		mlog.Int64("targetNodeID", requestBody.TargetNodeID))
+	writeJSONResponse(w, http.StatusOK, map[string]string{"msg": "OK"})
+}
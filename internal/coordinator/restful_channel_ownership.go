@@ -0,0 +1,52 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+)
+
+// HandleChannelOwnership returns the channels and growing segments owned by each node.
+// The optional query parameter `node_id` limits the snapshot to a single node.
+func (s *mixCoordImpl) HandleChannelOwnership(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeJSONError(w, "Method not allowed, use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	var nodeID int64
+	if v := req.URL.Query().Get("node_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeJSONError(w, fmt.Sprintf("invalid node_id: %s", v), http.StatusBadRequest)
+			return
+		}
+		nodeID = id
+	}
+	snapshot, err := s.datacoordServer.GetChannelOwnershipSnapshot(ctx, nodeID)
+	if err != nil {
+		mlog.Warn(ctx, "failed to get channel ownership snapshot", mlog.Int64("nodeID", nodeID), mlog.Err(err))
+		writeJSONError(w, fmt.Sprintf("failed to get channel ownership: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, snapshot)
+}
@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// HandleChannelPause manages the vchannels whose consumption is paused.
+// GET lists the paused vchannels.
+// POST pauses the vchannel given by the `vchannel` query parameter.
+// DELETE resumes the vchannel given by the `vchannel` query parameter.
+func (s *mixCoordImpl) HandleChannelPause(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	vchannel := req.URL.Query().Get("vchannel")
+
+	switch req.Method {
+	case http.MethodGet:
+		channels, err := s.datacoordServer.ListPausedChannels(ctx)
+		if err != nil {
+			writeChannelPauseError(w, "list", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, map[string][]string{"paused_channels": channels})
+	case http.MethodPost, http.MethodDelete:
+		if vchannel == "" {
+			writeJSONError(w, "vchannel is required", http.StatusBadRequest)
+			return
+		}
+		op, alter := "pause", s.datacoordServer.PauseChannel
+		if req.Method == http.MethodDelete {
+			op, alter = "resume", s.datacoordServer.ResumeChannel
+		}
+		if err := alter(ctx, vchannel); err != nil {
+			mlog.Warn(ctx, "failed to alter channel pause", mlog.String("op", op), mlog.FieldVChannel(vchannel), mlog.Err(err))
+			writeChannelPauseError(w, op, err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, map[string]string{"msg": "OK"})
+	default:
+		writeJSONError(w, "Method not allowed, use GET, POST or DELETE", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeChannelPauseError(w http.ResponseWriter, op string, err error) {
+	statusCode := http.StatusInternalServerError
+	if errors.Is(err, merr.ErrParameterInvalid) || errors.Is(err, merr.ErrChannelNotFound) {
+		statusCode = http.StatusBadRequest
+	} else if errors.Is(err, merr.ErrServiceUnavailable) || errors.Is(err, merr.ErrServiceNotReady) {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeJSONError(w, fmt.Sprintf("failed to %s channel: %s", op, err.Error()), statusCode)
+}
@@ -0,0 +1,24 @@
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/balance"
+)
+
+// HandleStreamingChannelRecoveryReport returns the reconciliation report of the pchannels
+// when the streaming coord is recovered, used to verify the channels after restart.
+func (s *mixCoordImpl) HandleStreamingChannelRecoveryReport(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeJSONError(w, "Method not allowed, use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	b, err := balance.GetWithContext(req.Context())
+	if err != nil {
+		writeJSONError(w, fmt.Sprintf("failed to get streaming balancer: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, b.GetChannelRecoveryReport())
+}
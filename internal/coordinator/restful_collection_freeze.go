@@ -0,0 +1,99 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/rootcoord"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// CollectionFreezeRequest is the request body to freeze or unfreeze a collection.
+type CollectionFreezeRequest struct {
+	DbName         string `json:"db_name"`
+	CollectionName string `json:"collection_name"`
+	// Freeze marks the collection read-only if true, and resumes its writes if false.
+	Freeze bool `json:"freeze"`
+}
+
+// HandleCollectionFreeze returns the freeze state of the collection in db_name and collection_name on GET,
+// and freezes or unfreezes the collection on POST.
+func (s *mixCoordImpl) HandleCollectionFreeze(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	switch req.Method {
+	case http.MethodGet:
+		collectionName := req.URL.Query().Get("collection_name")
+		if collectionName == "" {
+			writeJSONError(w, "collection_name is required", http.StatusBadRequest)
+			return
+		}
+		state, err := s.rootcoordServer.GetCollectionFreezeState(ctx, req.URL.Query().Get("db_name"), collectionName)
+		if err != nil {
+			writeCollectionFreezeError(w, "get freeze state of", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, state)
+	case http.MethodPost:
+		var body CollectionFreezeRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if body.CollectionName == "" {
+			writeJSONError(w, "collection_name is required", http.StatusBadRequest)
+			return
+		}
+
+		var (
+			state *rootcoord.CollectionFreezeState
+			err   error
+			op    = "unfreeze"
+		)
+		if body.Freeze {
+			op = "freeze"
+			state, err = s.rootcoordServer.FreezeCollection(ctx, body.DbName, body.CollectionName)
+		} else {
+			state, err = s.rootcoordServer.UnfreezeCollection(ctx, body.DbName, body.CollectionName)
+		}
+		if err != nil {
+			mlog.Warn(ctx, "failed to "+op+" collection",
+				mlog.String("dbName", body.DbName),
+				mlog.String("collectionName", body.CollectionName),
+				mlog.Err(err))
+			writeCollectionFreezeError(w, op, err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, state)
+	default:
+		writeJSONError(w, "Method not allowed, use GET or POST", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeCollectionFreezeError(w http.ResponseWriter, op string, err error) {
+	statusCode := http.StatusInternalServerError
+	if errors.Is(err, merr.ErrParameterInvalid) || errors.Is(err, merr.ErrCollectionNotFound) || errors.Is(err, merr.ErrDatabaseNotFound) {
+		statusCode = http.StatusBadRequest
+	}
+	writeJSONError(w, fmt.Sprintf("failed to %s collection: %s", op, err.Error()), statusCode)
+}
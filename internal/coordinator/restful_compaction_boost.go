@@ -0,0 +1,81 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// CompactionPriorityBoostRequest is the request body to boost the compaction priority of a collection.
+type CompactionPriorityBoostRequest struct {
+	CollectionID int64 `json:"collection_id"`
+	// DurationSeconds is how long the boost lasts, 0 cancels the boost of the collection.
+	DurationSeconds int64 `json:"duration_seconds"`
+}
+
+// HandleCompactionPriorityBoost lists the compaction priority boosts on GET,
+// and boosts the compaction priority of a collection for a bounded duration on POST.
+func (s *mixCoordImpl) HandleCompactionPriorityBoost(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	switch req.Method {
+	case http.MethodGet:
+		boosts, err := s.datacoordServer.ListCompactionPriorityBoosts(ctx)
+		if err != nil {
+			writeJSONError(w, fmt.Sprintf("failed to list compaction priority boosts: %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, boosts)
+	case http.MethodPost:
+		var body CompactionPriorityBoostRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if body.CollectionID <= 0 {
+			writeJSONError(w, "collection_id must be positive", http.StatusBadRequest)
+			return
+		}
+
+		boost, err := s.datacoordServer.BoostCompactionPriority(ctx, body.CollectionID, time.Duration(body.DurationSeconds)*time.Second)
+		if err != nil {
+			mlog.Warn(ctx, "failed to boost compaction priority",
+				mlog.Int64("collectionID", body.CollectionID),
+				mlog.Int64("durationSeconds", body.DurationSeconds),
+				mlog.Err(err))
+			statusCode := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, merr.ErrCollectionNotFound):
+				statusCode = http.StatusNotFound
+			case errors.Is(err, merr.ErrParameterInvalid):
+				statusCode = http.StatusBadRequest
+			}
+			writeJSONError(w, fmt.Sprintf("failed to boost compaction priority: %s", err.Error()), statusCode)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, boost)
+	default:
+		writeJSONError(w, "Method not allowed, use GET or POST", http.StatusMethodNotAllowed)
+	}
+}
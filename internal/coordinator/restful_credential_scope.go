@@ -0,0 +1,110 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// CredentialScope is a collection with the operation classes a scoped credential is allowed on.
+type CredentialScope struct {
+	DbName           string   `json:"db_name"`
+	CollectionName   string   `json:"collection_name"`
+	OperationClasses []string `json:"operation_classes"`
+}
+
+// CredentialScopes is the scopes of a user, the user is not restricted if no scope is given.
+type CredentialScopes struct {
+	Username string             `json:"username"`
+	Scopes   []*CredentialScope `json:"scopes"`
+}
+
+// HandleCredentialScope manages the collection level scopes of the credentials.
+// GET returns the scopes of the user in the query parameter username.
+// POST replaces the scopes of the user with the request in the body, an empty scope list removes the restriction.
+func (s *mixCoordImpl) HandleCredentialScope(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	switch req.Method {
+	case http.MethodGet:
+		username := req.URL.Query().Get("username")
+		if username == "" {
+			writeJSONError(w, "username is required", http.StatusBadRequest)
+			return
+		}
+		scopes, err := s.rootcoordServer.GetCredentialScopes(ctx, username)
+		if err != nil {
+			writeCredentialScopeError(w, "get", err)
+			return
+		}
+		resp := &CredentialScopes{Username: username, Scopes: make([]*CredentialScope, 0, len(scopes))}
+		for _, scope := range scopes {
+			resp.Scopes = append(resp.Scopes, &CredentialScope{
+				DbName:           scope.GetDbName(),
+				CollectionName:   scope.GetCollectionName(),
+				OperationClasses: scope.GetOperationClasses(),
+			})
+		}
+		writeJSONResponse(w, http.StatusOK, resp)
+	case http.MethodPost:
+		var scopesReq CredentialScopes
+		if err := json.NewDecoder(req.Body).Decode(&scopesReq); err != nil {
+			writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if scopesReq.Username == "" {
+			writeJSONError(w, "username is required", http.StatusBadRequest)
+			return
+		}
+		scopes := make([]*internalpb.CredentialScope, 0, len(scopesReq.Scopes))
+		for _, scope := range scopesReq.Scopes {
+			scopes = append(scopes, &internalpb.CredentialScope{
+				DbName:           scope.DbName,
+				CollectionName:   scope.CollectionName,
+				OperationClasses: scope.OperationClasses,
+			})
+		}
+		if err := s.rootcoordServer.AlterCredentialScopes(ctx, scopesReq.Username, scopes); err != nil {
+			mlog.Warn(ctx, "failed to alter credential scopes",
+				mlog.String("username", scopesReq.Username),
+				mlog.Err(err))
+			writeCredentialScopeError(w, "alter", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, map[string]string{"msg": "OK"})
+	default:
+		writeJSONError(w, "Method not allowed, use GET or POST", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeCredentialScopeError(w http.ResponseWriter, op string, err error) {
+	statusCode := http.StatusInternalServerError
+	if errors.Is(err, merr.ErrParameterInvalid) {
+		statusCode = http.StatusBadRequest
+	} else if errors.Is(err, merr.ErrCollectionNotFound) || errors.Is(err, merr.ErrDatabaseNotFound) {
+		statusCode = http.StatusNotFound
+	}
+	writeJSONError(w, fmt.Sprintf("failed to %s credential scopes: %s", op, err.Error()), statusCode)
+}
@@ -0,0 +1,112 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/internal/datacoord"
+	"github.com/milvus-io/milvus/internal/querycoordv2"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// DataSkewReport is the data distribution of a collection with the skew of the rows over the channels,
+// the partitions and the query nodes. A skew is the ratio of the max to the mean, 1 means evenly distributed.
+type DataSkewReport struct {
+	*datacoord.DataDistribution
+	ChannelSkew   float64 `json:"channel_skew"`
+	PartitionSkew float64 `json:"partition_skew"`
+	// Loaded is false if the collection is not loaded, then there is no node distribution.
+	Loaded bool                           `json:"loaded"`
+	Nodes  []*querycoordv2.NodeLoadedRows `json:"nodes,omitempty"`
+	// NodeSkew is the max skew of the loaded rows over the nodes of a replica.
+	NodeSkew float64 `json:"node_skew"`
+}
+
+// skew returns the ratio of the max to the mean of the values, 0 if there are no rows.
+func skew(values []int64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := lo.Sum(values)
+	if total == 0 {
+		return 0
+	}
+	return float64(lo.Max(values)) * float64(len(values)) / float64(total)
+}
+
+func newDataSkewReport(dist *datacoord.DataDistribution, nodes []*querycoordv2.NodeLoadedRows) *DataSkewReport {
+	report := &DataSkewReport{
+		DataDistribution: dist,
+		ChannelSkew: skew(lo.Map(dist.Channels, func(stat *datacoord.ChannelDataStat, _ int) int64 {
+			return stat.NumRows
+		})),
+		PartitionSkew: skew(lo.Map(dist.Partitions, func(stat *datacoord.PartitionDataStat, _ int) int64 {
+			return stat.NumRows
+		})),
+		Loaded: nodes != nil,
+		Nodes:  nodes,
+	}
+	replicas := lo.GroupBy(nodes, func(rows *querycoordv2.NodeLoadedRows) int64 { return rows.ReplicaID })
+	for _, replicaNodes := range replicas {
+		report.NodeSkew = max(report.NodeSkew, skew(lo.Map(replicaNodes, func(rows *querycoordv2.NodeLoadedRows, _ int) int64 {
+			return rows.NumRows
+		})))
+	}
+	return report
+}
+
+// HandleDataSkew reports how the rows of a collection, which is given by the required query parameter `collection_id`,
+// spread over the channels, the partitions, the segment sizes and the query nodes,
+// to decide whether to reshard or repartition before the performance degrades.
+func (s *mixCoordImpl) HandleDataSkew(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeJSONError(w, "Method not allowed, use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	v := req.URL.Query().Get("collection_id")
+	collectionID, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || collectionID <= 0 {
+		writeJSONError(w, fmt.Sprintf("invalid collection_id: %s", v), http.StatusBadRequest)
+		return
+	}
+	dist, err := s.datacoordServer.GetDataDistribution(ctx, collectionID)
+	if err != nil {
+		mlog.Warn(ctx, "failed to get data distribution", mlog.Int64("collectionID", collectionID), mlog.Err(err))
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, merr.ErrCollectionNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		writeJSONError(w, fmt.Sprintf("failed to get data distribution: %s", err.Error()), statusCode)
+		return
+	}
+	nodes, err := s.queryCoordServer.GetLoadedRowsByNode(ctx, collectionID)
+	if err != nil && !errors.Is(err, merr.ErrCollectionNotLoaded) {
+		mlog.Warn(ctx, "failed to get loaded rows by node", mlog.Int64("collectionID", collectionID), mlog.Err(err))
+		writeJSONError(w, fmt.Sprintf("failed to get loaded rows by node: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, newDataSkewReport(dist, nodes))
+}
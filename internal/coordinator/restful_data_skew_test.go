@@ -0,0 +1,59 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/datacoord"
+	"github.com/milvus-io/milvus/internal/querycoordv2"
+)
+
+func TestNewDataSkewReport(t *testing.T) {
+	dist := &datacoord.DataDistribution{
+		CollectionID: 100,
+		Channels: []*datacoord.ChannelDataStat{
+			{Channel: "ch-1", DataStat: datacoord.DataStat{NumRows: 300}},
+			{Channel: "ch-2", DataStat: datacoord.DataStat{NumRows: 100}},
+		},
+		Partitions: []*datacoord.PartitionDataStat{
+			{PartitionID: 10, DataStat: datacoord.DataStat{NumRows: 200}},
+			{PartitionID: 11, DataStat: datacoord.DataStat{NumRows: 200}},
+		},
+	}
+
+	report := newDataSkewReport(dist, nil)
+	assert.InDelta(t, 1.5, report.ChannelSkew, 0.001)
+	assert.InDelta(t, 1, report.PartitionSkew, 0.001)
+	assert.False(t, report.Loaded)
+	assert.Zero(t, report.NodeSkew)
+
+	report = newDataSkewReport(dist, []*querycoordv2.NodeLoadedRows{
+		{ReplicaID: 1, NodeID: 1, NumRows: 400},
+		{ReplicaID: 1, NodeID: 2, NumRows: 0},
+		{ReplicaID: 2, NodeID: 3, NumRows: 200},
+		{ReplicaID: 2, NodeID: 4, NumRows: 200},
+	})
+	assert.True(t, report.Loaded)
+	assert.Len(t, report.Nodes, 4)
+	assert.InDelta(t, 2, report.NodeSkew, 0.001)
+
+	assert.Zero(t, skew(nil))
+	assert.Zero(t, skew([]int64{0, 0}))
+}
@@ -50,6 +50,8 @@ func RegisterMgrRoute(s *mixCoordImpl) {
 			{management.StreamingNodeStatusPath, s.HandleStreamingNodeStatus},
 			{management.StreamingNodeDistributionPath, s.GetStreamingNodeDistribution},
 			{management.StreamingTransferPath, s.TransferStreamingChannel},
+			{management.StreamingChannelMovePath, s.HandleMoveStreamingChannel},
+			{management.StreamingChannelRecoveryPath, s.HandleStreamingChannelRecoveryReport},
 			{management.DataGCPath, s.HandleDatacoordGC}, // This route is unique, so it's included here.
 			// WAL
 			{management.WALAlterPath, s.HandleAlterWAL},
@@ -58,6 +60,23 @@ func RegisterMgrRoute(s *mixCoordImpl) {
 			{management.ConfigGetPath, s.HandleGetConfig},
 			// ops
 			{management.ReplicaLoadConfigCompliancePath, s.HandleReplicaLoadConfigCompliance},
+			{management.ReplicaNumberAlterPath, s.HandleAlterReplicaNumber},
+			{management.ReplicaWeightAlterPath, s.HandleAlterReplicaWeight},
+			{management.ReplicaLoadProgressPath, s.HandleReplicaLoadProgress},
+			{management.StorageUsagePath, s.HandleStorageUsage},
+			{management.PropertyPresetPath, s.HandlePropertyPreset},
+			{management.QuotaExemptionPath, s.HandleQuotaExemption},
+			{management.RecycleBinPath, s.HandleRecycleBin},
+			{management.CredentialScopePath, s.HandleCredentialScope},
+			{management.CollectionFreezePath, s.HandleCollectionFreeze},
+			{management.ChannelOwnershipPath, s.HandleChannelOwnership},
+			{management.ChannelPausePath, s.HandleChannelPause},
+			{management.CompactionPriorityBoostPath, s.HandleCompactionPriorityBoost},
+			{management.SegmentAccessStatsPath, s.HandleSegmentAccessStats},
+			{management.OrphanSegmentPath, s.HandleOrphanSegments},
+			{management.SegmentReloadPath, s.HandleReloadSegments},
+			{management.LoadAuditPath, s.HandleLoadAudit},
+			{management.DataSkewPath, s.HandleDataSkew},
 		}
 
 		// Loop through the slice and register each route.
@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// HandleOrphanSegments reports the segments inconsistent between meta and object storage.
+// GET returns the report of the last check.
+// POST runs a check immediately, the orphan segments are repaired if the optional query parameter `repair` is true.
+// POST with the query parameter `reregister` set to a segment id registers the storage orphan of the last check again.
+func (s *mixCoordImpl) HandleOrphanSegments(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	switch req.Method {
+	case http.MethodGet:
+		report, err := s.datacoordServer.GetOrphanSegmentReport(ctx)
+		if err != nil {
+			writeOrphanSegmentError(w, "get", err)
+			return
+		}
+		if report == nil {
+			writeJSONError(w, "no orphan segment check has been run", http.StatusNotFound)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, report)
+	case http.MethodPost:
+		if v := req.URL.Query().Get("reregister"); v != "" {
+			segmentID, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				writeJSONError(w, fmt.Sprintf("invalid reregister: %s", v), http.StatusBadRequest)
+				return
+			}
+			jobID, err := s.datacoordServer.ReregisterOrphanSegment(ctx, segmentID)
+			if err != nil {
+				mlog.Warn(ctx, "failed to reregister orphan segment", mlog.Int64("segmentID", segmentID), mlog.Err(err))
+				writeOrphanSegmentError(w, "reregister", err)
+				return
+			}
+			writeJSONResponse(w, http.StatusOK, map[string]int64{"segment_id": segmentID, "job_id": jobID})
+			return
+		}
+		repair := false
+		if v := req.URL.Query().Get("repair"); v != "" {
+			var err error
+			if repair, err = strconv.ParseBool(v); err != nil {
+				writeJSONError(w, fmt.Sprintf("invalid repair: %s", v), http.StatusBadRequest)
+				return
+			}
+		}
+		report, err := s.datacoordServer.CheckOrphanSegments(ctx, repair)
+		if err != nil {
+			mlog.Warn(ctx, "failed to check orphan segments", mlog.Bool("repair", repair), mlog.Err(err))
+			writeOrphanSegmentError(w, "check", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, report)
+	default:
+		writeJSONError(w, "Method not allowed, use GET or POST", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeOrphanSegmentError(w http.ResponseWriter, op string, err error) {
+	statusCode := http.StatusInternalServerError
+	if errors.Is(err, merr.ErrServiceUnavailable) || errors.Is(err, merr.ErrServiceNotReady) {
+		statusCode = http.StatusServiceUnavailable
+	} else if errors.Is(err, merr.ErrSegmentNotFound) || errors.Is(err, merr.ErrCollectionNotFound) || errors.Is(err, merr.ErrPartitionNotFound) {
+		statusCode = http.StatusNotFound
+	} else if errors.Is(err, merr.ErrParameterInvalid) {
+		statusCode = http.StatusBadRequest
+	}
+	writeJSONError(w, fmt.Sprintf("failed to %s orphan segments: %s", op, err.Error()), statusCode)
+}
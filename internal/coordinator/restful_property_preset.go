@@ -0,0 +1,90 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/rootcoord"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// HandlePropertyPreset manages the collection property presets.
+// GET lists the presets, or returns a single one with the `name` query parameter.
+// POST creates or overwrites the preset in the request body.
+// DELETE removes the preset given by the `name` query parameter.
+func (s *mixCoordImpl) HandlePropertyPreset(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	name := req.URL.Query().Get("name")
+
+	switch req.Method {
+	case http.MethodGet:
+		if name == "" {
+			presets, err := s.rootcoordServer.ListPropertyPresets(ctx)
+			if err != nil {
+				writePropertyPresetError(w, "list", err)
+				return
+			}
+			writeJSONResponse(w, http.StatusOK, presets)
+			return
+		}
+		preset, err := s.rootcoordServer.GetPropertyPreset(ctx, name)
+		if err != nil {
+			writePropertyPresetError(w, "get", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, preset)
+	case http.MethodPost:
+		var preset rootcoord.PropertyPreset
+		if err := json.NewDecoder(req.Body).Decode(&preset); err != nil {
+			writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := s.rootcoordServer.SavePropertyPreset(ctx, &preset); err != nil {
+			mlog.Warn(ctx, "failed to save property preset", mlog.String("name", preset.Name), mlog.Err(err))
+			writePropertyPresetError(w, "save", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, map[string]string{"msg": "OK"})
+	case http.MethodDelete:
+		if name == "" {
+			writeJSONError(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		if err := s.rootcoordServer.DropPropertyPreset(ctx, name); err != nil {
+			mlog.Warn(ctx, "failed to drop property preset", mlog.String("name", name), mlog.Err(err))
+			writePropertyPresetError(w, "drop", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, map[string]string{"msg": "OK"})
+	default:
+		writeJSONError(w, "Method not allowed, use GET, POST or DELETE", http.StatusMethodNotAllowed)
+	}
+}
+
+func writePropertyPresetError(w http.ResponseWriter, op string, err error) {
+	statusCode := http.StatusInternalServerError
+	if errors.Is(err, merr.ErrParameterInvalid) {
+		statusCode = http.StatusBadRequest
+	}
+	writeJSONError(w, fmt.Sprintf("failed to %s property preset: %s", op, err.Error()), statusCode)
+}
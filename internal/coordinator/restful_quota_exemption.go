@@ -0,0 +1,56 @@
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/rootcoord"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// HandleQuotaExemption manages the quota exemption tokens.
+// GET lists the tokens not expired yet.
+// POST issues a token with the request in the body, the token is returned only once.
+func (s *mixCoordImpl) HandleQuotaExemption(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	switch req.Method {
+	case http.MethodGet:
+		exemptions, err := s.rootcoordServer.ListQuotaExemptions(ctx)
+		if err != nil {
+			writeQuotaExemptionError(w, "list", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, exemptions)
+	case http.MethodPost:
+		var exemptionReq rootcoord.QuotaExemptionRequest
+		if err := json.NewDecoder(req.Body).Decode(&exemptionReq); err != nil {
+			writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		exemption, err := s.rootcoordServer.IssueQuotaExemption(ctx, &exemptionReq)
+		if err != nil {
+			mlog.Warn(ctx, "failed to issue quota exemption",
+				mlog.String("collection", exemptionReq.CollectionName),
+				mlog.String("issuer", exemptionReq.Issuer),
+				mlog.Err(err))
+			writeQuotaExemptionError(w, "issue", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, exemption)
+	default:
+		writeJSONError(w, "Method not allowed, use GET or POST", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeQuotaExemptionError(w http.ResponseWriter, op string, err error) {
+	statusCode := http.StatusInternalServerError
+	if errors.Is(err, merr.ErrParameterInvalid) || errors.Is(err, merr.ErrCollectionNotFound) || errors.Is(err, merr.ErrDatabaseNotFound) {
+		statusCode = http.StatusBadRequest
+	}
+	writeJSONError(w, fmt.Sprintf("failed to %s quota exemption: %s", op, err.Error()), statusCode)
+}
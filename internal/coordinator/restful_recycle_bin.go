@@ -0,0 +1,83 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// undropCollectionRequest is the request body to undrop a soft-deleted collection.
+type undropCollectionRequest struct {
+	DbName         string `json:"db_name"`
+	CollectionName string `json:"collection_name"`
+}
+
+// HandleRecycleBin manages the soft-deleted collections.
+// GET lists the collections in the recycle bin, filtered by the optional `db_name` query parameter.
+// POST undrops the latest dropped collection with the name in the request body.
+func (s *mixCoordImpl) HandleRecycleBin(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	switch req.Method {
+	case http.MethodGet:
+		collections, err := s.rootcoordServer.ListRecycledCollections(ctx, req.URL.Query().Get("db_name"))
+		if err != nil {
+			writeRecycleBinError(w, "list", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, collections)
+	case http.MethodPost:
+		var undropReq undropCollectionRequest
+		if err := json.NewDecoder(req.Body).Decode(&undropReq); err != nil {
+			writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if undropReq.CollectionName == "" {
+			writeJSONError(w, "collection_name is required", http.StatusBadRequest)
+			return
+		}
+		recycled, err := s.rootcoordServer.UndropCollection(ctx, undropReq.DbName, undropReq.CollectionName)
+		if err != nil {
+			mlog.Warn(ctx, "failed to undrop collection",
+				mlog.String("dbName", undropReq.DbName),
+				mlog.String("collectionName", undropReq.CollectionName),
+				mlog.Err(err))
+			writeRecycleBinError(w, "undrop", err)
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, recycled)
+	default:
+		writeJSONError(w, "Method not allowed, use GET or POST", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeRecycleBinError(w http.ResponseWriter, op string, err error) {
+	statusCode := http.StatusInternalServerError
+	if errors.Is(err, merr.ErrCollectionNotFound) {
+		statusCode = http.StatusNotFound
+	} else if errors.Is(err, merr.ErrParameterInvalid) || errors.Is(err, merr.ErrDatabaseNotFound) {
+		statusCode = http.StatusBadRequest
+	}
+	writeJSONError(w, fmt.Sprintf("failed to %s recycled collection: %s", op, err.Error()), statusCode)
+}
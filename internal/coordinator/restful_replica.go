@@ -19,8 +19,12 @@ package coordinator
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/balance"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
@@ -197,3 +201,189 @@ func (s *mixCoordImpl) validateRGDistribution(
 	}
 	return ""
 }
+
+// AlterReplicaNumberRequest is the request body to alter the replica number of a loaded collection.
+type AlterReplicaNumberRequest struct {
+	CollectionID   int64    `json:"collection_id"`
+	ReplicaNumber  int32    `json:"replica_number"`
+	ResourceGroups []string `json:"resource_groups,omitempty"`
+}
+
+// HandleAlterReplicaNumber increases or decreases the replicas of a loaded collection without release and reload.
+func (s *mixCoordImpl) HandleAlterReplicaNumber(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeJSONError(w, "Method not allowed, use POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	var body AlterReplicaNumberRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.CollectionID <= 0 || body.ReplicaNumber <= 0 {
+		writeJSONError(w, "collection_id and replica_number must be positive", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.queryCoordServer.AlterReplicaNumber(ctx, body.CollectionID, body.ReplicaNumber, body.ResourceGroups); err != nil {
+		mlog.Warn(ctx, "failed to alter replica number",
+			mlog.Int64("collectionID", body.CollectionID),
+			mlog.Int32("replicaNumber", body.ReplicaNumber),
+			mlog.Err(err))
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, merr.ErrCollectionNotLoaded):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, merr.ErrParameterInvalid), errors.Is(err, merr.ErrResourceGroupNotFound),
+			errors.Is(err, merr.ErrResourceGroupNodeNotEnough):
+			statusCode = http.StatusBadRequest
+		}
+		writeJSONError(w, fmt.Sprintf("failed to alter replica number: %s", err.Error()), statusCode)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, map[string]string{"msg": "OK"})
+}
+
+// AlterReplicaWeightRequest is the request body to alter the routing weight of a replica.
+type AlterReplicaWeightRequest struct {
+	CollectionID int64 `json:"collection_id"`
+	ReplicaID    int64 `json:"replica_id"`
+	Weight       int32 `json:"weight"`
+}
+
+// HandleAlterReplicaWeight sets the routing weight of a replica, the search requests of the collection
+// are dispatched to the replicas in proportion to their weights.
+func (s *mixCoordImpl) HandleAlterReplicaWeight(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeJSONError(w, "Method not allowed, use POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	var body AlterReplicaWeightRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.CollectionID <= 0 || body.ReplicaID <= 0 {
+		writeJSONError(w, "collection_id and replica_id must be positive", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.queryCoordServer.SetReplicaWeight(ctx, body.CollectionID, body.ReplicaID, body.Weight); err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, merr.ErrReplicaNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, merr.ErrParameterInvalid):
+			statusCode = http.StatusBadRequest
+		}
+		writeJSONError(w, fmt.Sprintf("failed to alter replica weight: %s", err.Error()), statusCode)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, map[string]string{"msg": "OK"})
+}
+
+// HandleReplicaLoadProgress returns the loading progress of each replica of a collection,
+// which is given by the required query parameter `collection_id`, including the warm-up of the new replicas.
+func (s *mixCoordImpl) HandleReplicaLoadProgress(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeJSONError(w, "Method not allowed, use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	v := req.URL.Query().Get("collection_id")
+	collectionID, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || collectionID <= 0 {
+		writeJSONError(w, fmt.Sprintf("invalid collection_id: %s", v), http.StatusBadRequest)
+		return
+	}
+	progress, err := s.queryCoordServer.GetReplicaLoadProgress(ctx, collectionID)
+	if err != nil {
+		mlog.Warn(ctx, "failed to get replica load progress", mlog.Int64("collectionID", collectionID), mlog.Err(err))
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, merr.ErrCollectionNotLoaded) {
+			statusCode = http.StatusNotFound
+		}
+		writeJSONError(w, fmt.Sprintf("failed to get replica load progress: %s", err.Error()), statusCode)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, progress)
+}
+
+// HandleLoadAudit returns the load and release audit records of a collection in request order,
+// which is given by the required query parameter `collection_id`.
+func (s *mixCoordImpl) HandleLoadAudit(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeJSONError(w, "Method not allowed, use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	v := req.URL.Query().Get("collection_id")
+	collectionID, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || collectionID <= 0 {
+		writeJSONError(w, fmt.Sprintf("invalid collection_id: %s", v), http.StatusBadRequest)
+		return
+	}
+	records, err := s.queryCoordServer.ListLoadAuditRecords(ctx, collectionID)
+	if err != nil {
+		mlog.Warn(ctx, "failed to list load audit records", mlog.Int64("collectionID", collectionID), mlog.Err(err))
+		writeJSONError(w, fmt.Sprintf("failed to list load audit records: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, records)
+}
+
+// ReloadSegmentsRequest is the request body to reload the segments of a loaded collection.
+type ReloadSegmentsRequest struct {
+	CollectionID int64   `json:"collection_id"`
+	SegmentIDs   []int64 `json:"segment_ids,omitempty"`
+}
+
+// ReloadSegmentsResponse is the response of the segment reload request.
+type ReloadSegmentsResponse struct {
+	Msg   string `json:"msg"`
+	Total int    `json:"total"`
+}
+
+// HandleReloadSegments reopens the given segments, or all the loaded segments of the collection if none is given,
+// on their current nodes to pick up the rebuilt indexes. The reload runs in background replica by replica.
+func (s *mixCoordImpl) HandleReloadSegments(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeJSONError(w, "Method not allowed, use POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	var body ReloadSegmentsRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.CollectionID <= 0 {
+		writeJSONError(w, "collection_id must be positive", http.StatusBadRequest)
+		return
+	}
+
+	total, err := s.queryCoordServer.ReloadSegments(ctx, body.CollectionID, body.SegmentIDs)
+	if err != nil {
+		mlog.Warn(ctx, "failed to reload segments",
+			mlog.Int64("collectionID", body.CollectionID),
+			mlog.Int64s("segmentIDs", body.SegmentIDs),
+			mlog.Err(err))
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, merr.ErrCollectionNotLoaded), errors.Is(err, merr.ErrSegmentNotLoaded):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, merr.ErrParameterInvalid):
+			statusCode = http.StatusBadRequest
+		}
+		writeJSONError(w, fmt.Sprintf("failed to reload segments: %s", err.Error()), statusCode)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, ReloadSegmentsResponse{Msg: "OK", Total: total})
+}
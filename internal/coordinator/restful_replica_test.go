@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/bytedance/mockey"
@@ -34,6 +35,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/balance"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
@@ -864,3 +866,202 @@ func TestValidateRGDistribution(t *testing.T) {
 		assert.Contains(t, reason, "mismatch")
 	})
 }
+
+func TestHandleAlterReplicaNumber(t *testing.T) {
+	paramtable.Init()
+	coord := &mixCoordImpl{
+		queryCoordServer: &querycoordv2.Server{},
+	}
+	doRequest := func(method string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/management/replica/alter", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		coord.HandleAlterReplicaNumber(w, req)
+		return w
+	}
+
+	t.Run("wrong HTTP method should fail", func(t *testing.T) {
+		w := doRequest(http.MethodGet, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		w := doRequest(http.MethodPost, "not json")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doRequest(http.MethodPost, `{"collection_id": 100, "replica_number": 0}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("alter replica number", func(t *testing.T) {
+		var gotCollection int64
+		var gotReplicaNumber int32
+		var gotRGs []string
+		mocker := mockey.Mock((*querycoordv2.Server).AlterReplicaNumber).To(
+			func(_ *querycoordv2.Server, _ context.Context, collectionID int64, replicaNumber int32, resourceGroups []string) error {
+				gotCollection, gotReplicaNumber, gotRGs = collectionID, replicaNumber, resourceGroups
+				return nil
+			}).Build()
+		defer mocker.UnPatch()
+
+		w := doRequest(http.MethodPost, `{"collection_id": 100, "replica_number": 3, "resource_groups": ["rg1"]}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, int64(100), gotCollection)
+		assert.Equal(t, int32(3), gotReplicaNumber)
+		assert.Equal(t, []string{"rg1"}, gotRGs)
+	})
+
+	t.Run("collection not loaded", func(t *testing.T) {
+		mocker := mockey.Mock((*querycoordv2.Server).AlterReplicaNumber).Return(merr.WrapErrCollectionNotLoaded(100)).Build()
+		defer mocker.UnPatch()
+
+		w := doRequest(http.MethodPost, `{"collection_id": 100, "replica_number": 2}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandleAlterReplicaWeight(t *testing.T) {
+	paramtable.Init()
+	coord := &mixCoordImpl{
+		queryCoordServer: &querycoordv2.Server{},
+	}
+	doRequest := func(method string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/management/replica/weight", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		coord.HandleAlterReplicaWeight(w, req)
+		return w
+	}
+
+	t.Run("wrong HTTP method should fail", func(t *testing.T) {
+		w := doRequest(http.MethodGet, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		w := doRequest(http.MethodPost, "not json")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doRequest(http.MethodPost, `{"collection_id": 100, "weight": 10}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("alter replica weight", func(t *testing.T) {
+		var gotCollection, gotReplica int64
+		var gotWeight int32
+		mocker := mockey.Mock((*querycoordv2.Server).SetReplicaWeight).To(
+			func(_ *querycoordv2.Server, _ context.Context, collectionID int64, replicaID int64, weight int32) error {
+				gotCollection, gotReplica, gotWeight = collectionID, replicaID, weight
+				return nil
+			}).Build()
+		defer mocker.UnPatch()
+
+		w := doRequest(http.MethodPost, `{"collection_id": 100, "replica_id": 1, "weight": 10}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, int64(100), gotCollection)
+		assert.Equal(t, int64(1), gotReplica)
+		assert.Equal(t, int32(10), gotWeight)
+	})
+
+	t.Run("invalid weight", func(t *testing.T) {
+		mocker := mockey.Mock((*querycoordv2.Server).SetReplicaWeight).Return(merr.WrapErrParameterInvalidRange(1, 100, 0, "invalid replica weight")).Build()
+		defer mocker.UnPatch()
+
+		w := doRequest(http.MethodPost, `{"collection_id": 100, "replica_id": 1, "weight": 0}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("replica not found", func(t *testing.T) {
+		mocker := mockey.Mock((*querycoordv2.Server).SetReplicaWeight).Return(merr.WrapErrReplicaNotFound(1)).Build()
+		defer mocker.UnPatch()
+
+		w := doRequest(http.MethodPost, `{"collection_id": 100, "replica_id": 1, "weight": 10}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandleReloadSegments(t *testing.T) {
+	paramtable.Init()
+	coord := &mixCoordImpl{
+		queryCoordServer: &querycoordv2.Server{},
+	}
+	doRequest := func(method string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/management/querycoord/segment/reload", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		coord.HandleReloadSegments(w, req)
+		return w
+	}
+
+	t.Run("wrong HTTP method should fail", func(t *testing.T) {
+		w := doRequest(http.MethodGet, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		w := doRequest(http.MethodPost, "not json")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doRequest(http.MethodPost, `{"segment_ids": [1]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("reload segments", func(t *testing.T) {
+		var gotCollection int64
+		var gotSegments []int64
+		mocker := mockey.Mock((*querycoordv2.Server).ReloadSegments).To(
+			func(_ *querycoordv2.Server, _ context.Context, collectionID int64, segmentIDs []int64) (int, error) {
+				gotCollection, gotSegments = collectionID, segmentIDs
+				return 4, nil
+			}).Build()
+		defer mocker.UnPatch()
+
+		w := doRequest(http.MethodPost, `{"collection_id": 100, "segment_ids": [1, 2]}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, int64(100), gotCollection)
+		assert.Equal(t, []int64{1, 2}, gotSegments)
+		var resp ReloadSegmentsResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 4, resp.Total)
+	})
+
+	t.Run("collection not loaded", func(t *testing.T) {
+		mocker := mockey.Mock((*querycoordv2.Server).ReloadSegments).Return(0, merr.WrapErrCollectionNotLoaded(100)).Build()
+		defer mocker.UnPatch()
+
+		w := doRequest(http.MethodPost, `{"collection_id": 100}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("reload in progress", func(t *testing.T) {
+		mocker := mockey.Mock((*querycoordv2.Server).ReloadSegments).Return(0, merr.WrapErrParameterInvalidMsg("reloading")).Build()
+		defer mocker.UnPatch()
+
+		w := doRequest(http.MethodPost, `{"collection_id": 100}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandleLoadAudit(t *testing.T) {
+	coord := &mixCoordImpl{queryCoordServer: &querycoordv2.Server{}}
+
+	t.Run("invalid request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		coord.HandleLoadAudit(w, httptest.NewRequest(http.MethodPost, "/management/querycoord/load_audit?collection_id=100", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+		w = httptest.NewRecorder()
+		coord.HandleLoadAudit(w, httptest.NewRequest(http.MethodGet, "/management/querycoord/load_audit", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list records", func(t *testing.T) {
+		mocker := mockey.Mock((*querycoordv2.Server).ListLoadAuditRecords).Return([]*querycoordv2.LoadAuditRecord{
+			{CollectionID: 100, Operation: querycoordv2.LoadAuditReleaseCollection, Requester: "alice", Success: true},
+		}, nil).Build()
+		defer mocker.UnPatch()
+
+		w := httptest.NewRecorder()
+		coord.HandleLoadAudit(w, httptest.NewRequest(http.MethodGet, "/management/querycoord/load_audit?collection_id=100", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		var records []*querycoordv2.LoadAuditRecord
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &records))
+		assert.Len(t, records, 1)
+		assert.Equal(t, "alice", records[0].Requester)
+		assert.Equal(t, querycoordv2.LoadAuditReleaseCollection, records[0].Operation)
+	})
+}
@@ -0,0 +1,59 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// HandleSegmentAccessStats returns the read statistics of the flushed segments of a collection,
+// which is given by the required query parameter `collection_id`.
+func (s *mixCoordImpl) HandleSegmentAccessStats(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeJSONError(w, "Method not allowed, use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	v := req.URL.Query().Get("collection_id")
+	collectionID, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || collectionID <= 0 {
+		writeJSONError(w, fmt.Sprintf("invalid collection_id: %s", v), http.StatusBadRequest)
+		return
+	}
+	stats, err := s.datacoordServer.GetSegmentAccessStats(ctx, collectionID)
+	if err != nil {
+		mlog.Warn(ctx, "failed to get segment access stats", mlog.Int64("collectionID", collectionID), mlog.Err(err))
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, merr.ErrCollectionNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, merr.ErrServiceUnavailable):
+			statusCode = http.StatusServiceUnavailable
+		}
+		writeJSONError(w, fmt.Sprintf("failed to get segment access stats: %s", err.Error()), statusCode)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, stats)
+}
@@ -0,0 +1,50 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// HandleStorageUsage returns the per-database storage usage (binlog, delta log and index size).
+// The optional query parameter `db_name` limits the report to a single database.
+func (s *mixCoordImpl) HandleStorageUsage(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeJSONError(w, "Method not allowed, use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	dbName := req.URL.Query().Get("db_name")
+	report, err := s.rootcoordServer.GetStorageUsageReport(ctx, dbName)
+	if err != nil {
+		mlog.Warn(ctx, "failed to get storage usage report", mlog.String("dbName", dbName), mlog.Err(err))
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, merr.ErrDatabaseNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		writeJSONError(w, fmt.Sprintf("failed to get storage usage: %s", err.Error()), statusCode)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, report)
+}
@@ -0,0 +1,163 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer"
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/balance"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// VChannelOwnership is a vchannel and the growing segments on it.
+type VChannelOwnership struct {
+	Name            string  `json:"name"`
+	CollectionID    int64   `json:"collection_id"`
+	GrowingSegments []int64 `json:"growing_segments"`
+}
+
+// PChannelOwnership is a pchannel with its assignment state and the vchannels on it.
+type PChannelOwnership struct {
+	Name      string               `json:"name"`
+	Term      int64                `json:"term"`
+	State     string               `json:"state"`
+	VChannels []*VChannelOwnership `json:"vchannels"`
+}
+
+// NodeOwnership is the channels assigned to a node.
+type NodeOwnership struct {
+	NodeID   int64                `json:"node_id"`
+	Address  string               `json:"address"`
+	Channels []*PChannelOwnership `json:"channels"`
+}
+
+// ChannelOwnershipSnapshot is the snapshot of the channel and growing segment ownership of the nodes.
+type ChannelOwnershipSnapshot struct {
+	Nodes []*NodeOwnership `json:"nodes"`
+	// Unassigned holds the pchannels not assigned or assigning to any node.
+	Unassigned []*PChannelOwnership `json:"unassigned"`
+}
+
+// GetChannelOwnershipSnapshot returns the channels assigned to each node with the assignment state,
+// and the growing segments on the vchannels of these channels.
+// If nodeID is positive, only the channels of the node are returned.
+func (s *Server) GetChannelOwnershipSnapshot(ctx context.Context, nodeID int64) (*ChannelOwnershipSnapshot, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return nil, err
+	}
+	b, err := balance.GetWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignment, err := b.GetLatestChannelAssignment()
+	if err != nil {
+		return nil, err
+	}
+	growingSegments := s.meta.SelectSegments(ctx, SegmentFilterFunc(func(segment *SegmentInfo) bool {
+		return segment.GetState() == commonpb.SegmentState_Growing
+	}))
+	vchannelSegments := make(map[string][]int64)
+	for _, segment := range growingSegments {
+		vchannelSegments[segment.GetInsertChannel()] = append(vchannelSegments[segment.GetInsertChannel()], segment.GetID())
+	}
+	return buildChannelOwnershipSnapshot(assignment, vchannelSegments, nodeID), nil
+}
+
+// buildChannelOwnershipSnapshot builds the ownership snapshot from the channel assignment of the balancer
+// and the growing segments of the vchannels.
+func buildChannelOwnershipSnapshot(assignment *balancer.WatchChannelAssignmentsCallbackParam, vchannelSegments map[string][]int64, nodeID int64) *ChannelOwnershipSnapshot {
+	snapshot := &ChannelOwnershipSnapshot{
+		Nodes:      make([]*NodeOwnership, 0),
+		Unassigned: make([]*PChannelOwnership, 0),
+	}
+	if assignment == nil || assignment.PChannelView == nil {
+		return snapshot
+	}
+
+	// the vchannels come from the balancer stats and the growing segments,
+	// a vchannel with growing segments is always reported even if it's missing in the stats.
+	pchannelVChannels := make(map[string]map[string]struct{})
+	addVChannel := func(vchannel string) {
+		pchannel := funcutil.ToPhysicalChannel(vchannel)
+		if _, ok := pchannelVChannels[pchannel]; !ok {
+			pchannelVChannels[pchannel] = make(map[string]struct{})
+		}
+		pchannelVChannels[pchannel][vchannel] = struct{}{}
+	}
+	for _, stats := range assignment.PChannelView.Stats {
+		for vchannel := range stats.VChannels {
+			addVChannel(vchannel)
+		}
+	}
+	for vchannel := range vchannelSegments {
+		addVChannel(vchannel)
+	}
+
+	nodes := make(map[int64]*NodeOwnership)
+	for _, meta := range assignment.PChannelView.Channels {
+		channel := &PChannelOwnership{
+			Name:      meta.Name(),
+			Term:      meta.CurrentTerm(),
+			State:     strings.TrimPrefix(meta.State().String(), "PCHANNEL_META_STATE_"),
+			VChannels: make([]*VChannelOwnership, 0, len(pchannelVChannels[meta.Name()])),
+		}
+		for vchannel := range pchannelVChannels[meta.Name()] {
+			segments := append([]int64{}, vchannelSegments[vchannel]...)
+			sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+			channel.VChannels = append(channel.VChannels, &VChannelOwnership{
+				Name:            vchannel,
+				CollectionID:    funcutil.GetCollectionIDFromVChannel(vchannel),
+				GrowingSegments: segments,
+			})
+		}
+		sort.Slice(channel.VChannels, func(i, j int) bool { return channel.VChannels[i].Name < channel.VChannels[j].Name })
+
+		if !meta.IsAssignedOrAssigning() {
+			if nodeID <= 0 {
+				snapshot.Unassigned = append(snapshot.Unassigned, channel)
+			}
+			continue
+		}
+		serverID := meta.CurrentServerID()
+		if nodeID > 0 && serverID != nodeID {
+			continue
+		}
+		node, ok := nodes[serverID]
+		if !ok {
+			node = &NodeOwnership{
+				NodeID:   serverID,
+				Address:  meta.CurrentAssignment().Node.Address,
+				Channels: make([]*PChannelOwnership, 0),
+			}
+			nodes[serverID] = node
+			snapshot.Nodes = append(snapshot.Nodes, node)
+		}
+		node.Channels = append(node.Channels, channel)
+	}
+
+	sort.Slice(snapshot.Nodes, func(i, j int) bool { return snapshot.Nodes[i].NodeID < snapshot.Nodes[j].NodeID })
+	for _, node := range snapshot.Nodes {
+		sort.Slice(node.Channels, func(i, j int) bool { return node.Channels[i].Name < node.Channels[j].Name })
+	}
+	sort.Slice(snapshot.Unassigned, func(i, j int) bool { return snapshot.Unassigned[i].Name < snapshot.Unassigned[j].Name })
+	return snapshot
+}
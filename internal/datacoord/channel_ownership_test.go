@@ -0,0 +1,97 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer"
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+)
+
+func newTestPChannelMeta(name string, node *types.StreamingNodeInfo, done bool) *channel.PChannelMeta {
+	meta := channel.NewPChannelMeta(name, types.AccessModeRW)
+	if node == nil {
+		return meta
+	}
+	mutable := meta.CopyForWrite()
+	mutable.TryAssignToServerID(types.AccessModeRW, *node)
+	if done {
+		mutable.AssignToServerDone()
+	}
+	return mutable.PChannelMeta
+}
+
+func TestBuildChannelOwnershipSnapshot(t *testing.T) {
+	node1 := &types.StreamingNodeInfo{ServerID: 1, Address: "localhost:1"}
+	node2 := &types.StreamingNodeInfo{ServerID: 2, Address: "localhost:2"}
+	metas := []*channel.PChannelMeta{
+		newTestPChannelMeta("dml_0", node1, true),
+		newTestPChannelMeta("dml_1", node1, false),
+		newTestPChannelMeta("dml_2", node2, true),
+		newTestPChannelMeta("dml_3", nil, false),
+	}
+	view := &channel.PChannelView{
+		Channels: make(map[channel.ChannelID]*channel.PChannelMeta),
+		Stats:    make(map[channel.ChannelID]channel.PChannelStatsView),
+	}
+	for _, meta := range metas {
+		view.Channels[meta.ChannelID()] = meta
+	}
+	view.Stats[metas[0].ChannelID()] = channel.PChannelStatsView{
+		VChannels: map[string]int64{"dml_0_100v0": 100, "dml_0_101v0": 101},
+	}
+	assignment := &balancer.WatchChannelAssignmentsCallbackParam{PChannelView: view}
+	vchannelSegments := map[string][]int64{
+		"dml_0_100v0": {12, 11},
+		// missing in the balancer stats.
+		"dml_2_100v1": {13},
+	}
+
+	snapshot := buildChannelOwnershipSnapshot(assignment, vchannelSegments, 0)
+	assert.Len(t, snapshot.Nodes, 2)
+	assert.Equal(t, int64(1), snapshot.Nodes[0].NodeID)
+	assert.Equal(t, "localhost:1", snapshot.Nodes[0].Address)
+	assert.Len(t, snapshot.Nodes[0].Channels, 2)
+
+	ch0 := snapshot.Nodes[0].Channels[0]
+	assert.Equal(t, "dml_0", ch0.Name)
+	assert.Equal(t, "ASSIGNED", ch0.State)
+	assert.Len(t, ch0.VChannels, 2)
+	assert.Equal(t, &VChannelOwnership{Name: "dml_0_100v0", CollectionID: 100, GrowingSegments: []int64{11, 12}}, ch0.VChannels[0])
+	assert.Empty(t, ch0.VChannels[1].GrowingSegments)
+	assert.Equal(t, "ASSIGNING", snapshot.Nodes[0].Channels[1].State)
+
+	assert.Equal(t, int64(2), snapshot.Nodes[1].NodeID)
+	assert.Equal(t, []int64{13}, snapshot.Nodes[1].Channels[0].VChannels[0].GrowingSegments)
+
+	assert.Len(t, snapshot.Unassigned, 1)
+	assert.Equal(t, "dml_3", snapshot.Unassigned[0].Name)
+	assert.Equal(t, "UNINITIALIZED", snapshot.Unassigned[0].State)
+
+	// filter by node.
+	snapshot = buildChannelOwnershipSnapshot(assignment, vchannelSegments, 2)
+	assert.Len(t, snapshot.Nodes, 1)
+	assert.Equal(t, int64(2), snapshot.Nodes[0].NodeID)
+	assert.Empty(t, snapshot.Unassigned)
+
+	snapshot = buildChannelOwnershipSnapshot(nil, vchannelSegments, 0)
+	assert.Empty(t, snapshot.Nodes)
+}
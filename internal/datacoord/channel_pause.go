@@ -0,0 +1,106 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// channelPauseMu serializes the updates of the paused vchannels.
+var channelPauseMu sync.Mutex
+
+// PauseChannel pauses the consumption of the vchannel on its owning node, e.g. to debug a poison message.
+// The paused vchannels are kept in the etcd config watched by the nodes, so the pause survives the node restart
+// and the channel balance, the paused vchannel is excluded from the time tick delay of the quota center.
+func (s *Server) PauseChannel(ctx context.Context, vchannel string) error {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return err
+	}
+	collection := s.meta.GetCollection(funcutil.GetCollectionIDFromVChannel(vchannel))
+	if collection == nil || !lo.Contains(collection.VChannelNames, vchannel) {
+		return merr.WrapErrChannelNotFound(vchannel)
+	}
+	if err := alterPausedChannels(func(channels typeutil.Set[string]) { channels.Insert(vchannel) }); err != nil {
+		return err
+	}
+	mlog.Info(ctx, "channel consumption paused", mlog.FieldVChannel(vchannel))
+	return nil
+}
+
+// ResumeChannel resumes the consumption of the paused vchannel.
+func (s *Server) ResumeChannel(ctx context.Context, vchannel string) error {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return err
+	}
+	if !lo.Contains(getPausedChannels(), vchannel) {
+		return merr.WrapErrParameterInvalidMsg("channel %s is not paused", vchannel)
+	}
+	if err := alterPausedChannels(func(channels typeutil.Set[string]) { channels.Remove(vchannel) }); err != nil {
+		return err
+	}
+	mlog.Info(ctx, "channel consumption resumed", mlog.FieldVChannel(vchannel))
+	return nil
+}
+
+// ListPausedChannels returns the paused vchannels in order.
+func (s *Server) ListPausedChannels(ctx context.Context) ([]string, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return nil, err
+	}
+	channels := getPausedChannels()
+	sort.Strings(channels)
+	return channels, nil
+}
+
+func getPausedChannels() []string {
+	return lo.Filter(paramtable.Get().DataNodeCfg.DataSyncPausedVChannels.GetAsStrings(), func(channel string, _ int) bool {
+		return channel != ""
+	})
+}
+
+// alterPausedChannels updates the paused vchannels in the etcd config.
+func alterPausedChannels(alter func(channels typeutil.Set[string])) error {
+	channelPauseMu.Lock()
+	defer channelPauseMu.Unlock()
+
+	paramMgr := paramtable.GetBaseTable().Manager()
+	etcdSource, ok := paramMgr.GetEtcdSource()
+	if !ok {
+		return merr.WrapErrServiceUnavailable("etcd config source is not enabled")
+	}
+	channels := typeutil.NewSet(getPausedChannels()...)
+	alter(channels)
+
+	key := paramtable.Get().DataNodeCfg.DataSyncPausedVChannels.Key
+	if channels.Len() == 0 {
+		return paramMgr.AlterConfigsInEtcd(etcdSource, nil, []string{key})
+	}
+	value := channels.Collect()
+	sort.Strings(value)
+	return paramMgr.AlterConfigsInEtcd(etcdSource, map[string]string{key: strings.Join(value, ",")}, nil)
+}
@@ -0,0 +1,51 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestServer_PauseChannel(t *testing.T) {
+	ctx := context.Background()
+	key := paramtable.Get().DataNodeCfg.DataSyncPausedVChannels.Key
+	defer paramtable.Get().Reset(key)
+
+	s := &Server{meta: &meta{collections: typeutil.NewConcurrentMap[UniqueID, *collectionInfo]()}}
+	s.meta.AddCollection(&collectionInfo{ID: 100, VChannelNames: []string{"dml_0_100v0"}})
+
+	assert.ErrorIs(t, s.PauseChannel(ctx, "dml_0_100v0"), merr.ErrServiceNotReady)
+
+	s.stateCode.Store(commonpb.StateCode_Healthy)
+	assert.ErrorIs(t, s.PauseChannel(ctx, "dml_0_101v0"), merr.ErrChannelNotFound)
+	assert.ErrorIs(t, s.PauseChannel(ctx, "dml_1_100v1"), merr.ErrChannelNotFound)
+
+	paramtable.Get().Save(key, "dml_1_100v1,dml_0_100v0")
+	channels, err := s.ListPausedChannels(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dml_0_100v0", "dml_1_100v1"}, channels)
+
+	assert.ErrorIs(t, s.ResumeChannel(ctx, "dml_0_101v0"), merr.ErrParameterInvalid)
+}
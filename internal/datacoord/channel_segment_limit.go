@@ -0,0 +1,135 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+)
+
+const (
+	// channelSmallSegmentCheckInterval is the interval of checking the small segments on the channels,
+	// the quota metrics report the result of the last check.
+	channelSmallSegmentCheckInterval = 10 * time.Second
+	// channelSmallSegmentBoostDuration is the compaction priority boost of the collections with the channels exceeding
+	// the small segment soft limit, it's set again by the checks after it expires until the channels are back under the limit.
+	channelSmallSegmentBoostDuration = time.Minute
+)
+
+// channelSmallSegments is the number of the flushed small segments waiting for compaction on a channel.
+type channelSmallSegments struct {
+	collectionID int64
+	num          int
+}
+
+// getChannelSmallSegments returns the flushed small segments waiting for compaction on each channel,
+// the segments smaller than the expected segment size times dataCoord.segment.smallProportion are small.
+func (m *meta) getChannelSmallSegments(ctx context.Context) map[string]*channelSmallSegments {
+	smallProportion := Params.DataCoordCfg.SegmentSmallProportion.GetAsFloat()
+	expectedSizes := make(map[int64]int64)
+	getExpectedSize := func(collectionID int64) int64 {
+		size, ok := expectedSizes[collectionID]
+		if !ok {
+			size = Params.DataCoordCfg.SegmentMaxSize.GetAsInt64() * 1024 * 1024
+			if coll := m.GetCollection(collectionID); coll != nil {
+				size = getExpectedSegmentSize(m, collectionID, coll.Schema)
+			}
+			expectedSizes[collectionID] = size
+		}
+		return size
+	}
+
+	channels := make(map[string]*channelSmallSegments)
+	segments := m.SelectSegments(ctx, SegmentFilterFunc(func(segment *SegmentInfo) bool {
+		return isFlushed(segment) && !segment.GetIsImporting() &&
+			segment.GetLevel() != datapb.SegmentLevel_L0 && segment.GetLevel() != datapb.SegmentLevel_L2
+	}))
+	for _, segment := range segments {
+		if float64(segment.getSegmentSize()) >= float64(getExpectedSize(segment.GetCollectionID()))*smallProportion {
+			continue
+		}
+		channel, ok := channels[segment.GetInsertChannel()]
+		if !ok {
+			channel = &channelSmallSegments{collectionID: segment.GetCollectionID()}
+			channels[segment.GetInsertChannel()] = channel
+		}
+		channel.num++
+	}
+	return channels
+}
+
+func (s *Server) startChannelSmallSegmentCheckLoop(ctx context.Context) {
+	s.serverLoopWg.Add(1)
+	go s.channelSmallSegmentCheckLoop(ctx)
+}
+
+// channelSmallSegmentCheckLoop checks the small segments on the channels periodically and caches the pressures,
+// so that scanning all the segments is kept off the quota metrics requests.
+func (s *Server) channelSmallSegmentCheckLoop(ctx context.Context) {
+	defer s.serverLoopWg.Done()
+	ticker := time.NewTicker(channelSmallSegmentCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			mlog.Info(ctx, "channel small segment check loop exit")
+			return
+		case <-ticker.C:
+		}
+		pressures := s.checkChannelSmallSegmentSoftLimit(ctx)
+		s.smallSegmentPressures.Store(&pressures)
+	}
+}
+
+// checkChannelSmallSegmentSoftLimit returns the small segment pressure of the collections with the channels
+// exceeding dataCoord.segment.channelSmallSegmentSoftLimit, which is the ratio of the small segment number
+// of the most crowded channel to the soft limit. The compaction priority of these collections is boosted,
+// and the quota center reduces their insert rates by the pressure.
+func (s *Server) checkChannelSmallSegmentSoftLimit(ctx context.Context) map[int64]float64 {
+	softLimit := Params.DataCoordCfg.ChannelSmallSegmentSoftLimit.GetAsInt()
+	if softLimit <= 0 {
+		return nil
+	}
+
+	pressures := make(map[int64]float64)
+	for channel, segments := range s.meta.getChannelSmallSegments(ctx) {
+		if segments.num <= softLimit {
+			continue
+		}
+		pressure := float64(segments.num) / float64(softLimit)
+		if pressure > pressures[segments.collectionID] {
+			pressures[segments.collectionID] = pressure
+		}
+		mlog.RatedWarn(ctx, rate.Limit(0.1), "the small segments on the channel exceed the soft limit",
+			mlog.FieldCollectionID(segments.collectionID),
+			mlog.String("channel", channel),
+			mlog.Int("smallSegmentNum", segments.num),
+			mlog.Int("softLimit", softLimit))
+	}
+	for collectionID := range pressures {
+		// the longer boost set manually is kept.
+		if !s.priorityBoosts.IsBoosted(collectionID) {
+			s.priorityBoosts.Boost(collectionID, channelSmallSegmentBoostDuration)
+		}
+	}
+	return pressures
+}
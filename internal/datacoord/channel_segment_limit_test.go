@@ -0,0 +1,93 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestCheckChannelSmallSegmentSoftLimit(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	s := &Server{
+		meta: &meta{
+			segments:    NewSegmentsInfo(),
+			collections: typeutil.NewConcurrentMap[UniqueID, *collectionInfo](),
+		},
+		priorityBoosts: newCompactionPriorityBoosts(),
+	}
+	paramtable.Get().Save(Params.DataCoordCfg.SegmentMaxSize.Key, "100")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.SegmentMaxSize.Key)
+
+	segmentID := int64(0)
+	addSegment := func(collectionID int64, channel string, state commonpb.SegmentState, level datapb.SegmentLevel, sizeMB int64) {
+		segmentID++
+		s.meta.segments.SetSegment(segmentID, NewSegmentInfo(&datapb.SegmentInfo{
+			ID:            segmentID,
+			CollectionID:  collectionID,
+			InsertChannel: channel,
+			State:         state,
+			Level:         level,
+			Stats:         &datapb.Statistics{InsertBinlogSize: sizeMB * 1024 * 1024},
+		}))
+	}
+	for i := 0; i < 3; i++ {
+		addSegment(1, "ch1", commonpb.SegmentState_Flushed, datapb.SegmentLevel_L1, 10)
+		addSegment(1, "ch2", commonpb.SegmentState_Flushed, datapb.SegmentLevel_L1, 10)
+		addSegment(2, "ch3", commonpb.SegmentState_Flushed, datapb.SegmentLevel_L1, 10)
+	}
+	addSegment(1, "ch1", commonpb.SegmentState_Flushed, datapb.SegmentLevel_L1, 10)
+	// the segments not counted.
+	addSegment(2, "ch3", commonpb.SegmentState_Flushed, datapb.SegmentLevel_L1, 80)
+	addSegment(2, "ch3", commonpb.SegmentState_Growing, datapb.SegmentLevel_L1, 10)
+	addSegment(2, "ch3", commonpb.SegmentState_Flushed, datapb.SegmentLevel_L0, 10)
+	addSegment(2, "ch3", commonpb.SegmentState_Flushed, datapb.SegmentLevel_L2, 10)
+	addSegment(2, "ch3", commonpb.SegmentState_Dropped, datapb.SegmentLevel_L1, 10)
+
+	channels := s.meta.getChannelSmallSegments(ctx)
+	assert.Equal(t, map[string]*channelSmallSegments{
+		"ch1": {collectionID: 1, num: 4},
+		"ch2": {collectionID: 1, num: 3},
+		"ch3": {collectionID: 2, num: 3},
+	}, channels)
+
+	// disabled by default.
+	assert.Nil(t, s.checkChannelSmallSegmentSoftLimit(ctx))
+
+	paramtable.Get().Save(Params.DataCoordCfg.ChannelSmallSegmentSoftLimit.Key, "2")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.ChannelSmallSegmentSoftLimit.Key)
+	assert.Equal(t, map[int64]float64{1: 2, 2: 1.5}, s.checkChannelSmallSegmentSoftLimit(ctx))
+	assert.True(t, s.priorityBoosts.IsBoosted(1))
+	assert.True(t, s.priorityBoosts.IsBoosted(2))
+
+	paramtable.Get().Save(Params.DataCoordCfg.ChannelSmallSegmentSoftLimit.Key, "3")
+	pressures := s.checkChannelSmallSegmentSoftLimit(ctx)
+	assert.Equal(t, map[int64]float64{1: 4.0 / 3}, pressures)
+
+	// the quota metrics report the result of the last check.
+	assert.Nil(t, s.getQuotaMetrics().CollectionSmallSegmentPressure)
+	s.smallSegmentPressures.Store(&pressures)
+	assert.Equal(t, pressures, s.getQuotaMetrics().CollectionSmallSegmentPressure)
+}
@@ -0,0 +1,150 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const (
+	// cloneSnapshotPrefix is the name prefix of the temporary snapshots taken by CloneCollection.
+	// The snapshots are dropped once the clone finishes, the ones left by a coordinator restart
+	// could be found by the prefix and dropped by DropSnapshot.
+	cloneSnapshotPrefix = "_clone_"
+
+	cloneSnapshotWaitTimeout = 5 * time.Minute
+)
+
+var cloneCheckInterval = time.Second
+
+func cloneSnapshotName(id int64) string {
+	return fmt.Sprintf("%s%d", cloneSnapshotPrefix, id)
+}
+
+// createCloneSnapshot takes the temporary snapshot of the source collection and waits for it to be committed,
+// the snapshot is created by the ack callback of the broadcast, so it's not visible right after CreateSnapshot returns.
+func (s *Server) createCloneSnapshot(ctx context.Context, req *datapb.CloneCollectionRequest) (string, error) {
+	id, err := s.allocator.AllocID(ctx)
+	if err != nil {
+		return "", err
+	}
+	snapshotName := cloneSnapshotName(id)
+	status, err := s.CreateSnapshot(ctx, &datapb.CreateSnapshotRequest{
+		Name:         snapshotName,
+		Description:  fmt.Sprintf("temporary snapshot to clone collection to %s.%s", req.GetTargetDbName(), req.GetTargetCollectionName()),
+		CollectionId: req.GetSourceCollectionId(),
+	})
+	if err = merr.CheckRPCCall(status, err); err != nil {
+		return "", err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, cloneSnapshotWaitTimeout)
+	defer cancel()
+	ticker := time.NewTicker(cloneCheckInterval / 10)
+	defer ticker.Stop()
+	for {
+		snapshot, err := s.snapshotManager.GetSnapshot(waitCtx, req.GetSourceCollectionId(), snapshotName)
+		if err != nil && !errors.Is(err, merr.ErrSnapshotNotFound) {
+			s.dropCloneSnapshot(ctx, req.GetSourceCollectionId(), snapshotName)
+			return "", err
+		}
+		if snapshot.GetState() == datapb.SnapshotState_SnapshotStateCommitted {
+			return snapshotName, nil
+		}
+		select {
+		case <-waitCtx.Done():
+			s.dropCloneSnapshot(ctx, req.GetSourceCollectionId(), snapshotName)
+			return "", merr.WrapErrServiceInternalMsg("snapshot %s is not committed in time: %s", snapshotName, waitCtx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// dropCloneSnapshot drops the temporary snapshot, it fails if the snapshot is still pinned by the restore job.
+func (s *Server) dropCloneSnapshot(ctx context.Context, collectionID int64, snapshotName string) bool {
+	status, err := s.DropSnapshot(context.WithoutCancel(ctx), &datapb.DropSnapshotRequest{
+		Name:         snapshotName,
+		CollectionId: collectionID,
+	})
+	if err = merr.CheckRPCCall(status, err); err != nil {
+		mlog.Warn(ctx, "failed to drop clone snapshot",
+			mlog.FieldCollectionID(collectionID), mlog.String("snapshot", snapshotName), mlog.Err(err))
+		return false
+	}
+	return true
+}
+
+// watchCloneJob waits for the restore job of the clone to finish, then drops the temporary snapshot
+// and loads the target collection if requested.
+func (s *Server) watchCloneJob(jobID int64, req *datapb.CloneCollectionRequest, snapshotName string) {
+	defer s.serverLoopWg.Done()
+	ctx := s.serverLoopCtx
+	log := mlog.With(mlog.Int64("jobID", jobID), mlog.String("snapshot", snapshotName),
+		mlog.FieldCollectionID(req.GetSourceCollectionId()))
+
+	ticker := time.NewTicker(cloneCheckInterval)
+	defer ticker.Stop()
+	var info *datapb.RestoreSnapshotInfo
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info(ctx, "stop watching clone job")
+			return
+		case <-ticker.C:
+		}
+		if info == nil {
+			state, err := s.snapshotManager.GetRestoreState(ctx, jobID)
+			if err != nil {
+				log.Warn(ctx, "failed to get clone job state", mlog.Err(err))
+				continue
+			}
+			if state.GetState() != datapb.RestoreSnapshotState_RestoreSnapshotCompleted &&
+				state.GetState() != datapb.RestoreSnapshotState_RestoreSnapshotFailed {
+				continue
+			}
+			info = state
+			log.Info(ctx, "clone job finished", mlog.String("state", info.GetState().String()), mlog.String("reason", info.GetReason()))
+		}
+		// the pin of the snapshot is released after the job finishes, retry until the snapshot is dropped
+		if s.dropCloneSnapshot(ctx, req.GetSourceCollectionId(), snapshotName) {
+			break
+		}
+	}
+
+	if !req.GetLoad() || info.GetState() != datapb.RestoreSnapshotState_RestoreSnapshotCompleted {
+		return
+	}
+	status, err := s.mixCoord.LoadCollection(ctx, &querypb.LoadCollectionRequest{
+		DbID:          info.GetDbId(),
+		CollectionID:  info.GetCollectionId(),
+		ReplicaNumber: req.GetReplicaNumber(),
+	})
+	if err = merr.CheckRPCCall(status, err); err != nil {
+		log.Warn(ctx, "failed to load cloned collection", mlog.Int64("targetCollectionID", info.GetCollectionId()), mlog.Err(err))
+		return
+	}
+	log.Info(ctx, "cloned collection loaded", mlog.Int64("targetCollectionID", info.GetCollectionId()))
+}
@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	mocks2 "github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestServer_CloneCollection(t *testing.T) {
+	cloneCheckInterval = 10 * time.Millisecond
+	defer func() { cloneCheckInterval = time.Second }()
+
+	newCloneTestServer := func(t *testing.T) *Server {
+		server := &Server{
+			allocator:       newMockAllocator(t),
+			snapshotManager: NewSnapshotManager(nil, nil, nil, nil, nil, nil, nil, nil),
+		}
+		server.serverLoopCtx, server.serverLoopCancel = context.WithCancel(context.Background())
+		server.stateCode.Store(commonpb.StateCode_Healthy)
+		return server
+	}
+
+	t.Run("missing_target_collection_name", func(t *testing.T) {
+		server := newCloneTestServer(t)
+		defer server.serverLoopCancel()
+
+		resp, err := server.CloneCollection(context.Background(), &datapb.CloneCollectionRequest{SourceCollectionId: 100})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrParameterMissing)
+	})
+
+	t.Run("clone_and_load", func(t *testing.T) {
+		server := newCloneTestServer(t)
+		defer server.serverLoopCancel()
+		mixCoord := mocks2.NewMixCoord(t)
+		server.mixCoord = mixCoord
+
+		mockCreate := mockey.Mock((*Server).CreateSnapshot).To(func(s *Server, ctx context.Context, req *datapb.CreateSnapshotRequest) (*commonpb.Status, error) {
+			assert.Equal(t, int64(100), req.GetCollectionId())
+			return merr.Success(), nil
+		}).Build()
+		defer mockCreate.UnPatch()
+		mockGet := mockey.Mock((*snapshotManager).GetSnapshot).Return(&datapb.SnapshotInfo{State: datapb.SnapshotState_SnapshotStateCommitted}, nil).Build()
+		defer mockGet.UnPatch()
+		mockRestore := mockey.Mock((*Server).RestoreSnapshot).To(func(s *Server, ctx context.Context, req *datapb.RestoreSnapshotRequest) (*datapb.RestoreSnapshotResponse, error) {
+			assert.Equal(t, int64(100), req.GetSourceCollectionId())
+			assert.Equal(t, "staging", req.GetTargetCollectionName())
+			return &datapb.RestoreSnapshotResponse{Status: merr.Success(), JobId: 10}, nil
+		}).Build()
+		defer mockRestore.UnPatch()
+		mockState := mockey.Mock((*snapshotManager).GetRestoreState).Return(&datapb.RestoreSnapshotInfo{
+			JobId:        10,
+			CollectionId: 200,
+			State:        datapb.RestoreSnapshotState_RestoreSnapshotCompleted,
+		}, nil).Build()
+		defer mockState.UnPatch()
+		// the first drop fails as the snapshot is still pinned
+		dropped := atomic.NewInt32(0)
+		mockDrop := mockey.Mock((*Server).DropSnapshot).To(func(s *Server, ctx context.Context, req *datapb.DropSnapshotRequest) (*commonpb.Status, error) {
+			if dropped.Inc() == 1 {
+				return merr.Status(merr.WrapErrSnapshotPinned(req.GetName(), "active pins exist")), nil
+			}
+			return merr.Success(), nil
+		}).Build()
+		defer mockDrop.UnPatch()
+		loaded := make(chan struct{})
+		mixCoord.EXPECT().LoadCollection(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *querypb.LoadCollectionRequest) (*commonpb.Status, error) {
+			assert.Equal(t, int64(200), req.GetCollectionID())
+			assert.Equal(t, int32(2), req.GetReplicaNumber())
+			close(loaded)
+			return merr.Success(), nil
+		}).Once()
+
+		resp, err := server.CloneCollection(context.Background(), &datapb.CloneCollectionRequest{
+			SourceCollectionId:   100,
+			TargetCollectionName: "staging",
+			Load:                 true,
+			ReplicaNumber:        2,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, merr.Error(resp.GetStatus()))
+		assert.Equal(t, int64(10), resp.GetJobId())
+		assert.Contains(t, resp.GetSnapshotName(), cloneSnapshotPrefix)
+
+		select {
+		case <-loaded:
+		case <-time.After(10 * time.Second):
+			t.Fatal("cloned collection is not loaded")
+		}
+		server.serverLoopWg.Wait()
+		assert.Equal(t, int32(2), dropped.Load())
+	})
+
+	t.Run("restore_failed", func(t *testing.T) {
+		server := newCloneTestServer(t)
+		defer server.serverLoopCancel()
+
+		mockCreate := mockey.Mock((*Server).CreateSnapshot).Return(merr.Success(), nil).Build()
+		defer mockCreate.UnPatch()
+		mockGet := mockey.Mock((*snapshotManager).GetSnapshot).Return(&datapb.SnapshotInfo{State: datapb.SnapshotState_SnapshotStateCommitted}, nil).Build()
+		defer mockGet.UnPatch()
+		mockRestore := mockey.Mock((*Server).RestoreSnapshot).Return(&datapb.RestoreSnapshotResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("collection staging already exists")),
+		}, nil).Build()
+		defer mockRestore.UnPatch()
+		dropped := atomic.NewInt32(0)
+		mockDrop := mockey.Mock((*Server).DropSnapshot).To(func(s *Server, ctx context.Context, req *datapb.DropSnapshotRequest) (*commonpb.Status, error) {
+			dropped.Inc()
+			return merr.Success(), nil
+		}).Build()
+		defer mockDrop.UnPatch()
+
+		resp, err := server.CloneCollection(context.Background(), &datapb.CloneCollectionRequest{
+			SourceCollectionId:   100,
+			TargetCollectionName: "staging",
+		})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp.GetStatus()))
+		assert.Equal(t, int32(1), dropped.Load())
+	})
+}
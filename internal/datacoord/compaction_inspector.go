@@ -30,12 +30,14 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
 	"github.com/milvus-io/milvus/internal/datacoord/task"
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/util/conc"
 	"github.com/milvus-io/milvus/pkg/v3/util/lock"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
@@ -46,6 +48,7 @@ var maxCompactionTaskExecutionDuration = map[datapb.CompactionType]time.Duration
 	datapb.CompactionType_ClusteringCompaction:        60 * time.Minute,
 	datapb.CompactionType_SortCompaction:              20 * time.Minute,
 	datapb.CompactionType_BumpSchemaVersionCompaction: 30 * time.Minute,
+	datapb.CompactionType_LogMergeCompaction:          20 * time.Minute,
 }
 
 type CompactionInspector interface {
@@ -60,6 +63,8 @@ type CompactionInspector interface {
 	getCompactionInfo(ctx context.Context, signalID int64) *compactionInfo
 	removeTasksByChannel(channel string)
 	getCompactionTasksNum(filters ...compactionTaskFilter) int
+	// getCleaningTasksJSON returns the tasks waiting to be cleaned in json
+	getCleaningTasksJSON() string
 }
 
 var _ CompactionInspector = (*compactionInspector)(nil)
@@ -88,6 +93,10 @@ type compactionInspector struct {
 	handler          Handler
 	scheduler        task.GlobalScheduler
 	ievm             IndexEngineVersionManager
+	tracer           *compactionTracer
+	planHook         *compactionPlanHook
+	// priorityBoosts boosts the priority of the queued tasks of the collections on top of the prioritizer.
+	priorityBoosts *compactionPriorityBoosts
 
 	stopCh   chan struct{}
 	stopOnce sync.Once
@@ -180,10 +189,11 @@ func (c *compactionInspector) getCompactionTasksNumBySignalID(triggerID int64) i
 
 func newCompactionInspector(meta CompactionMeta,
 	allocator allocator.Allocator, handler Handler, scheduler task.GlobalScheduler, analyzeScheduler task.GlobalScheduler, ievm IndexEngineVersionManager,
+	priorityBoosts *compactionPriorityBoosts,
 ) *compactionInspector {
 	capacity := paramtable.Get().DataCoordCfg.CompactionTaskQueueCapacity.GetAsInt()
-	return &compactionInspector{
-		queueTasks:       NewCompactionQueue(capacity, getPrioritizer()),
+	c := &compactionInspector{
+		queueTasks:       NewCompactionQueue(capacity, priorityBoosts.prioritizer(getPrioritizer())),
 		meta:             meta,
 		allocator:        allocator,
 		stopCh:           make(chan struct{}),
@@ -193,7 +203,21 @@ func newCompactionInspector(meta CompactionMeta,
 		scheduler:        scheduler,
 		analyzeScheduler: analyzeScheduler,
 		ievm:             ievm,
+		tracer:           newCompactionTracer(),
+		planHook:         newCompactionPlanHook(),
+		priorityBoosts:   priorityBoosts,
 	}
+	c.queueTasks.databaseOf = c.getTaskDatabase
+	return c
+}
+
+// getTaskDatabase returns the database name of the task for the database fairness of the queue.
+func (c *compactionInspector) getTaskDatabase(t CompactionTask) string {
+	coll, err := c.handler.GetCollection(context.TODO(), t.GetTaskProto().GetCollectionID())
+	if err != nil || coll == nil {
+		return ""
+	}
+	return coll.DatabaseName
 }
 
 func (c *compactionInspector) checkSchedule() {
@@ -203,6 +227,28 @@ func (c *compactionInspector) checkSchedule() {
 	}
 	c.cleanFailedTasks()
 	c.schedule()
+	c.refreshQueueDepthMetrics()
+}
+
+// refreshQueueDepthMetrics refreshes the number of the queued tasks of each database.
+func (c *compactionInspector) refreshQueueDepthMetrics() {
+	metrics.DataCoordCompactionQueueDepth.Reset()
+	for database, depth := range c.queueTasks.DatabaseLens() {
+		metrics.DataCoordCompactionQueueDepth.WithLabelValues(database).Set(float64(depth))
+	}
+}
+
+// channelConcurrencyKey identifies the compaction tasks of a concurrency group on a channel.
+type channelConcurrencyKey struct {
+	channel string
+	group   string
+}
+
+func newChannelConcurrencyKey(t CompactionTask) channelConcurrencyKey {
+	return channelConcurrencyKey{
+		channel: t.GetTaskProto().GetChannel(),
+		group:   compactionConcurrencyGroup(t.GetTaskProto().GetType()),
+	}
 }
 
 func (c *compactionInspector) schedule() []CompactionTask {
@@ -216,13 +262,17 @@ func (c *compactionInspector) schedule() []CompactionTask {
 	clusterChannelExcludes := typeutil.NewSet[string]()
 	mixLabelExcludes := typeutil.NewSet[string]()
 	clusterLabelExcludes := typeutil.NewSet[string]()
+	// the number of the executing tasks by channel and concurrency group, limited by the per channel parallelism
+	channelRunning := make(map[channelConcurrencyKey]int)
 
 	c.executingGuard.RLock()
 	for _, t := range c.executingTasks {
+		channelRunning[newChannelConcurrencyKey(t)]++
 		switch t.GetTaskProto().GetType() {
 		case datapb.CompactionType_Level0DeleteCompaction:
 			l0ChannelExcludes.Insert(t.GetTaskProto().GetChannel())
-		case datapb.CompactionType_MixCompaction, datapb.CompactionType_SortCompaction, datapb.CompactionType_BumpSchemaVersionCompaction:
+		case datapb.CompactionType_MixCompaction, datapb.CompactionType_SortCompaction, datapb.CompactionType_BumpSchemaVersionCompaction,
+			datapb.CompactionType_LogMergeCompaction:
 			mixChannelExcludes.Insert(t.GetTaskProto().GetChannel())
 			mixLabelExcludes.Insert(t.GetLabel())
 		case datapb.CompactionType_ClusteringCompaction:
@@ -240,10 +290,11 @@ func (c *compactionInspector) schedule() []CompactionTask {
 		}
 	}()
 
-	p := getPrioritizer()
+	p := c.priorityBoosts.prioritizer(getPrioritizer())
 	if &c.queueTasks.prioritizer != &p {
 		c.queueTasks.UpdatePrioritizer(p)
 	}
+	c.queueTasks.UpdateDatabaseWeights(getDatabaseWeights())
 
 	// The schedule loop will stop if either:
 	// 1. no more task to schedule (the task queue is empty)
@@ -254,6 +305,12 @@ func (c *compactionInspector) schedule() []CompactionTask {
 			break // 1. no more task to schedule
 		}
 
+		key := newChannelConcurrencyKey(t)
+		if limit := compactionChannelConcurrencyLimit(key.group); limit > 0 && channelRunning[key] >= limit {
+			excluded = append(excluded, t)
+			continue
+		}
+
 		switch t.GetTaskProto().GetType() {
 		case datapb.CompactionType_Level0DeleteCompaction:
 			if mixChannelExcludes.Contain(t.GetTaskProto().GetChannel()) ||
@@ -263,8 +320,9 @@ func (c *compactionInspector) schedule() []CompactionTask {
 			}
 			l0ChannelExcludes.Insert(t.GetTaskProto().GetChannel())
 			selected = append(selected, t)
-		case datapb.CompactionType_MixCompaction, datapb.CompactionType_SortCompaction, datapb.CompactionType_BumpSchemaVersionCompaction:
-			// BumpSchemaVersionCompaction shares the same exclusion rules as Mix/Sort:
+		case datapb.CompactionType_MixCompaction, datapb.CompactionType_SortCompaction, datapb.CompactionType_BumpSchemaVersionCompaction,
+			datapb.CompactionType_LogMergeCompaction:
+			// BumpSchemaVersionCompaction and LogMergeCompaction share the same exclusion rules as Mix/Sort:
 			// - Channel-level mutual exclusion with L0 (L0 may write delta logs to any segment on the channel)
 			// - Label-level exclusion registered for Clustering awareness
 			if l0ChannelExcludes.Contain(t.GetTaskProto().GetChannel()) {
@@ -285,10 +343,14 @@ func (c *compactionInspector) schedule() []CompactionTask {
 			clusterLabelExcludes.Insert(t.GetLabel())
 			selected = append(selected, t)
 		}
+		channelRunning[key]++
+		metrics.DataCoordCompactionQueueLatency.WithLabelValues(t.GetTaskProto().GetType().String()).
+			Observe(float64(time.Since(time.Unix(t.GetTaskProto().GetStartTime(), 0)).Milliseconds()))
 
 		c.executingGuard.Lock()
 		c.executingTasks[t.GetTaskProto().GetPlanID()] = t
 		c.scheduler.Enqueue(t)
+		c.tracer.enterPhase(t.GetTaskProto(), compactionPhaseSchedule)
 		mlog.Info(context.TODO(), "compaction task enqueued",
 			mlog.Int64("planID", t.GetTaskProto().GetPlanID()),
 			mlog.String("type", t.GetTaskProto().GetType().String()),
@@ -493,6 +555,7 @@ func (c *compactionInspector) removeTasksByChannel(channel string) {
 				mlog.Int64("node", task.GetTaskProto().GetNodeID()),
 			)
 			metrics.DataCoordCompactionTaskNum.WithLabelValues(fmt.Sprintf("%d", task.GetTaskProto().GetNodeID()), task.GetTaskProto().GetType().String(), metrics.Pending).Dec()
+			c.tracer.finish(task.GetTaskProto())
 			return true
 		}
 		return false
@@ -510,6 +573,7 @@ func (c *compactionInspector) removeTasksByChannel(channel string) {
 			)
 			delete(c.executingTasks, id)
 			metrics.DataCoordCompactionTaskNum.WithLabelValues(fmt.Sprintf("%d", task.GetTaskProto().GetNodeID()), task.GetTaskProto().GetType().String(), metrics.Executing).Dec()
+			c.tracer.finish(task.GetTaskProto())
 		}
 	}
 	c.executingGuard.Unlock()
@@ -519,6 +583,7 @@ func (c *compactionInspector) submitTask(t CompactionTask) error {
 	if err := c.queueTasks.Enqueue(t); err != nil {
 		return err
 	}
+	c.tracer.start(t.GetTaskProto(), compactionPhaseEnqueue)
 	metrics.DataCoordCompactionTaskNum.WithLabelValues(fmt.Sprintf("%d", NullNodeID), t.GetTaskProto().GetType().String(), metrics.Pending).Inc()
 	return nil
 }
@@ -529,6 +594,8 @@ func (c *compactionInspector) restoreTask(t CompactionTask) {
 	c.executingTasks[t.GetTaskProto().GetPlanID()] = t
 	c.scheduler.Enqueue(t)
 	c.executingGuard.Unlock()
+	c.tracer.start(t.GetTaskProto(), compactionPhaseSchedule)
+	c.tracer.observe(t.GetTaskProto())
 	metrics.DataCoordCompactionTaskNum.WithLabelValues(fmt.Sprintf("%d", t.GetTaskProto().GetNodeID()), t.GetTaskProto().GetType().String(), metrics.Executing).Inc()
 }
 
@@ -552,6 +619,14 @@ func (c *compactionInspector) getCompactionTask(planID int64) CompactionTask {
 
 func (c *compactionInspector) enqueueCompaction(task *datapb.CompactionTask) error {
 	log := mlog.With(mlog.Int64("planID", task.GetPlanID()), mlog.Int64("triggerID", task.GetTriggerID()), mlog.FieldCollectionID(task.GetCollectionID()), mlog.String("type", task.GetType().String()))
+	if err := c.planHook.check(context.TODO(), task); err != nil {
+		log.Info(context.TODO(), "Compaction plan rejected by hook", mlog.Err(err))
+		return err
+	}
+	if err := c.checkSortedOutput(task); err != nil {
+		log.RatedInfo(context.TODO(), rate.Limit(60), "Compaction plan dropped, sorted output is not feasible", mlog.Err(err))
+		return err
+	}
 	t, err := c.createCompactTask(task)
 	if err != nil {
 		// Conflict is normal
@@ -579,6 +654,20 @@ func (c *compactionInspector) enqueueCompaction(task *datapb.CompactionTask) err
 	return nil
 }
 
+// checkSortedOutput drops the plan requiring sorted output with unsorted input segments,
+// such plan is merge sorted by the worker, the unsorted segments are compacted after the sort compaction.
+func (c *compactionInspector) checkSortedOutput(task *datapb.CompactionTask) error {
+	if !task.GetRequireSortedOutput() {
+		return nil
+	}
+	for _, segment := range c.meta.GetSegmentInfos(task.GetInputSegments()) {
+		if !segment.GetIsSorted() && !segment.GetIsSortedByNamespace() {
+			return merr.WrapErrIllegalCompactionPlanMsg("input segment %d is not sorted, the plan requires sorted output", segment.GetID())
+		}
+	}
+	return nil
+}
+
 // set segments compacting, one segment can only participate one compactionTask
 func (c *compactionInspector) createCompactTask(t *datapb.CompactionTask) (CompactionTask, error) {
 	var task CompactionTask
@@ -591,6 +680,8 @@ func (c *compactionInspector) createCompactTask(t *datapb.CompactionTask) (Compa
 		task = newClusteringCompactionTask(t, c.allocator, c.meta, c.handler, c.analyzeScheduler, c.ievm)
 	case datapb.CompactionType_BumpSchemaVersionCompaction:
 		task = newBumpSchemaVersionTask(t, c.allocator, c.meta, c.ievm)
+	case datapb.CompactionType_LogMergeCompaction:
+		task = newLogMergeTask(t, c.allocator, c.meta)
 	default:
 		return nil, merr.WrapErrIllegalCompactionPlan("illegal compaction type")
 	}
@@ -618,6 +709,7 @@ func (c *compactionInspector) checkCompaction() error {
 	for _, t := range c.executingTasks {
 		c.checkDelay(t)
 		finished := t.Process()
+		c.tracer.observe(t.GetTaskProto())
 		if finished {
 			finishedTasks = append(finishedTasks, t)
 		}
@@ -654,6 +746,8 @@ func (c *compactionInspector) checkCompaction() error {
 				mlog.Int64("planID", t.GetTaskProto().GetPlanID()),
 				mlog.String("state", t.GetTaskProto().GetState().String()))
 			c.cleaningTasks[t.GetTaskProto().GetPlanID()] = t
+		} else {
+			c.tracer.finish(t.GetTaskProto())
 		}
 	}
 	c.cleaningGuard.Unlock()
@@ -676,8 +770,76 @@ func (c *compactionInspector) cleanFailedTasks() {
 	c.cleaningGuard.Lock()
 	for _, t := range cleanedTasks {
 		delete(c.cleaningTasks, t.GetTaskProto().GetPlanID())
+		c.tracer.finish(t.GetTaskProto())
 	}
 	c.cleaningGuard.Unlock()
+	c.checkCleanStuck()
+}
+
+// isCleanStuck returns true if the task has failed to be cleaned longer than the threshold.
+func isCleanStuck(t CompactionTask, now time.Time) bool {
+	cleanStart, failures, _ := t.GetCleanState()
+	threshold := paramtable.Get().DataCoordCfg.CompactionCleanStuckThreshold.GetAsDuration(time.Second)
+	return failures > 0 && now.Sub(cleanStart) > threshold
+}
+
+// checkCleanStuck refreshes the cleaning task metrics and warns the tasks stuck in cleaning.
+func (c *compactionInspector) checkCleanStuck() {
+	c.cleaningGuard.RLock()
+	defer c.cleaningGuard.RUnlock()
+
+	metrics.DataCoordCompactionCleaningTaskNum.Reset()
+	now := time.Now()
+	for _, t := range c.cleaningTasks {
+		status := metrics.Cleaning
+		if isCleanStuck(t, now) {
+			status = metrics.Stuck
+			cleanStart, failures, lastErr := t.GetCleanState()
+			mlog.RatedWarn(context.TODO(), rate.Limit(60), "compaction task stuck in cleaning",
+				mlog.FieldCollectionID(t.GetTaskProto().GetCollectionID()),
+				mlog.Int64("planID", t.GetTaskProto().GetPlanID()),
+				mlog.String("type", t.GetTaskProto().GetType().String()),
+				mlog.String("state", t.GetTaskProto().GetState().String()),
+				mlog.Duration("cleaningDuration", now.Sub(cleanStart)),
+				mlog.Int("failures", failures),
+				mlog.Err(lastErr))
+		}
+		metrics.DataCoordCompactionCleaningTaskNum.WithLabelValues(t.GetTaskProto().GetType().String(), status).Inc()
+	}
+}
+
+func (c *compactionInspector) getCleaningTasksJSON() string {
+	c.cleaningGuard.RLock()
+	defer c.cleaningGuard.RUnlock()
+
+	now := time.Now()
+	tasks := make([]*metricsinfo.CompactionCleaningTask, 0, len(c.cleaningTasks))
+	for _, t := range c.cleaningTasks {
+		cleanStart, failures, lastErr := t.GetCleanState()
+		task := &metricsinfo.CompactionCleaningTask{
+			PlanID:        t.GetTaskProto().GetPlanID(),
+			CollectionID:  t.GetTaskProto().GetCollectionID(),
+			Type:          t.GetTaskProto().GetType().String(),
+			State:         t.GetTaskProto().GetState().String(),
+			CleanFailures: failures,
+			Stuck:         isCleanStuck(t, now),
+		}
+		if !cleanStart.IsZero() {
+			task.CleanStartTime = typeutil.TimestampToString(uint64(cleanStart.UnixMilli()))
+		}
+		if lastErr != nil {
+			task.LastError = lastErr.Error()
+		}
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].PlanID < tasks[j].PlanID
+	})
+	ret, err := json.Marshal(tasks)
+	if err != nil {
+		return ""
+	}
+	return string(ret)
 }
 
 // isFull return true if the task pool is full
@@ -31,12 +31,14 @@ import (
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
 	"github.com/milvus-io/milvus/internal/datacoord/session"
 	"github.com/milvus-io/milvus/internal/datacoord/task"
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
 	"github.com/milvus-io/milvus/internal/metastore/kv/datacoord"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	taskcommon "github.com/milvus-io/milvus/pkg/v3/taskcommon"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/metautil"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
@@ -59,7 +61,7 @@ func (s *CompactionPlanHandlerSuite) SetupTest() {
 	s.mockMeta.EXPECT().SaveCompactionTask(mock.Anything, mock.Anything).Return(nil).Maybe()
 	s.mockAlloc = allocator.NewMockAllocator(s.T())
 	mockScheduler := task.NewMockGlobalScheduler(s.T())
-	s.handler = newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager())
+	s.handler = newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager(), nil)
 	s.mockHandler = NewNMockHandler(s.T())
 	s.mockHandler.EXPECT().GetCollection(mock.Anything, mock.Anything).Return(&collectionInfo{}, nil).Maybe()
 }
@@ -422,6 +424,58 @@ func (s *CompactionPlanHandlerSuite) TestSchedule_BumpSchemaVersionBlocksQueuedL
 	s.Equal(1, s.handler.queueTasks.Len())
 }
 
+func (s *CompactionPlanHandlerSuite) TestSchedule_MaxParallelPerChannel() {
+	s.SetupTest()
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.CompactionMixMaxParallelPerChannel.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.CompactionMixMaxParallelPerChannel.Key)
+	s.handler.scheduler.(*task.MockGlobalScheduler).EXPECT().Enqueue(mock.Anything).Return().Twice()
+
+	newTask := func(planID int64, compactionType datapb.CompactionType, channel string) CompactionTask {
+		return newMixCompactionTask(&datapb.CompactionTask{
+			PlanID:  planID,
+			Type:    compactionType,
+			State:   datapb.CompactionTaskState_pipelining,
+			Channel: channel,
+			NodeID:  102,
+		}, nil, s.mockMeta, newMockVersionManager())
+	}
+	s.handler.executingTasks[1] = newTask(1, datapb.CompactionType_MixCompaction, "ch-1")
+	// the sort compaction shares the limit of the mix compaction
+	s.NoError(s.handler.submitTask(newTask(2, datapb.CompactionType_SortCompaction, "ch-1")))
+	s.NoError(s.handler.submitTask(newTask(3, datapb.CompactionType_MixCompaction, "ch-2")))
+	s.NoError(s.handler.submitTask(newTask(4, datapb.CompactionType_MixCompaction, "ch-2")))
+	s.NoError(s.handler.submitTask(newTask(5, datapb.CompactionType_MixCompaction, "ch-3")))
+
+	gotTasks := s.handler.schedule()
+	gotPlanIDs := lo.Map(gotTasks, func(t CompactionTask, _ int) int64 {
+		return t.GetTaskProto().GetPlanID()
+	})
+	s.Len(gotPlanIDs, 2)
+	s.Contains(gotPlanIDs, int64(5))
+	s.NotContains(gotPlanIDs, int64(2))
+	s.Equal(2, s.handler.queueTasks.Len())
+}
+
+func (s *CompactionPlanHandlerSuite) TestCompactionNodeConcurrencyLimit() {
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.CompactionL0MaxParallelPerNode.Key, "2")
+	defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.CompactionL0MaxParallelPerNode.Key)
+
+	group, limit := newL0CompactionTask(&datapb.CompactionTask{
+		Type: datapb.CompactionType_Level0DeleteCompaction,
+	}, nil, s.mockMeta).GetNodeConcurrencyLimit()
+	s.Equal(compactionConcurrencyGroupL0, group)
+	s.Equal(2, limit)
+
+	group, limit = newMixCompactionTask(&datapb.CompactionTask{
+		Type: datapb.CompactionType_SortCompaction,
+	}, nil, s.mockMeta, newMockVersionManager()).GetNodeConcurrencyLimit()
+	s.Equal(compactionConcurrencyGroupMix, group)
+	s.Equal(0, limit)
+
+	s.Equal(compactionConcurrencyGroupClustering, compactionConcurrencyGroup(datapb.CompactionType_ClusteringCompaction))
+	s.Equal(compactionConcurrencyGroupMix, compactionConcurrencyGroup(datapb.CompactionType_LogMergeCompaction))
+}
+
 func (s *CompactionPlanHandlerSuite) TestSchedule_BumpSchemaVersionBlocksClusteringSameLabel() {
 	s.SetupTest()
 	s.handler.scheduler.(*task.MockGlobalScheduler).EXPECT().Enqueue(mock.Anything).Return().Once()
@@ -544,7 +598,7 @@ func (s *CompactionPlanHandlerSuite) TestCompactionQueueFull() {
 			t.QueryTaskOnWorker(cluster)
 		}
 	}).Maybe()
-	s.handler = newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager())
+	s.handler = newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager(), nil)
 
 	t1 := newMixCompactionTask(&datapb.CompactionTask{
 		TriggerID: 1,
@@ -578,7 +632,7 @@ func (s *CompactionPlanHandlerSuite) TestExecCompactionPlan() {
 			t.QueryTaskOnWorker(cluster)
 		}
 	}).Maybe()
-	handler := newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager())
+	handler := newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager(), nil)
 
 	task := &datapb.CompactionTask{
 		TriggerID: 1,
@@ -595,6 +649,40 @@ func (s *CompactionPlanHandlerSuite) TestExecCompactionPlan() {
 	s.NoError(err)
 }
 
+func (s *CompactionPlanHandlerSuite) TestEnqueueOverlappingPlan() {
+	s.SetupTest()
+	s.mockMeta.EXPECT().CheckAndSetSegmentsCompacting(mock.Anything, []int64{1, 2}).Return(true, false).Once()
+
+	err := s.handler.enqueueCompaction(&datapb.CompactionTask{
+		TriggerID:     1,
+		PlanID:        1,
+		Channel:       "ch-1",
+		Type:          datapb.CompactionType_MixCompaction,
+		InputSegments: []int64{1, 2},
+	})
+	s.ErrorIs(err, merr.ErrCompactionPlanConflict)
+	s.Nil(s.handler.getCompactionTask(1))
+}
+
+func (s *CompactionPlanHandlerSuite) TestEnqueueUnsortedPlanRequiringSortedOutput() {
+	s.SetupTest()
+	s.mockMeta.EXPECT().GetSegmentInfos([]int64{1, 2}).Return([]*SegmentInfo{
+		NewSegmentInfo(&datapb.SegmentInfo{ID: 1, IsSorted: true}),
+		NewSegmentInfo(&datapb.SegmentInfo{ID: 2}),
+	}).Once()
+
+	err := s.handler.enqueueCompaction(&datapb.CompactionTask{
+		TriggerID:           1,
+		PlanID:              1,
+		Channel:             "ch-1",
+		Type:                datapb.CompactionType_MixCompaction,
+		InputSegments:       []int64{1, 2},
+		RequireSortedOutput: true,
+	})
+	s.ErrorIs(err, merr.ErrIllegalCompactionPlan)
+	s.Nil(s.handler.getCompactionTask(1))
+}
+
 func (s *CompactionPlanHandlerSuite) TestCheckCompaction() {
 	s.SetupTest()
 
@@ -895,6 +983,7 @@ func (s *CompactionPlanHandlerSuite) TestCleanCompaction() {
 		s.Equal(1, len(s.handler.cleaningTasks))
 		s.handler.cleanFailedTasks()
 		s.Equal(0, len(s.handler.cleaningTasks))
+		s.Equal("[]", s.handler.getCleaningTasksJSON())
 	}
 }
 
@@ -1027,6 +1116,25 @@ func (s *CompactionPlanHandlerSuite) TestKeepClean() {
 		s.Equal(1, len(s.handler.cleaningTasks))
 		s.handler.cleanFailedTasks()
 		s.Equal(1, len(s.handler.cleaningTasks))
+		cleanStart, failures, lastErr := task.GetCleanState()
+		s.False(cleanStart.IsZero())
+		s.Equal(1, failures)
+		s.Error(lastErr)
+
+		cleaningTasks := make([]*metricsinfo.CompactionCleaningTask, 0)
+		s.NoError(json.Unmarshal([]byte(s.handler.getCleaningTasksJSON()), &cleaningTasks))
+		s.Equal(1, len(cleaningTasks))
+		s.Equal(int64(1), cleaningTasks[0].PlanID)
+		s.Equal(1, cleaningTasks[0].CleanFailures)
+		s.Equal("mock error", cleaningTasks[0].LastError)
+		s.False(cleaningTasks[0].Stuck)
+
+		paramtable.Get().Save(paramtable.Get().DataCoordCfg.CompactionCleanStuckThreshold.Key, "0")
+		cleaningTasks = make([]*metricsinfo.CompactionCleaningTask, 0)
+		s.NoError(json.Unmarshal([]byte(s.handler.getCleaningTasksJSON()), &cleaningTasks))
+		s.True(cleaningTasks[0].Stuck)
+		paramtable.Get().Reset(paramtable.Get().DataCoordCfg.CompactionCleanStuckThreshold.Key)
+
 		s.mockMeta.EXPECT().CleanPartitionStatsInfo(mock.Anything, mock.Anything).Return(nil).Once()
 		s.handler.cleanFailedTasks()
 		s.Equal(0, len(s.handler.cleaningTasks))
@@ -1173,7 +1281,7 @@ func (s *CompactionPlanHandlerSuite) TestCreateCompactTask_BumpSchemaVersionComp
 
 	mockScheduler := task.NewMockGlobalScheduler(s.T())
 	mockScheduler.EXPECT().Enqueue(mock.Anything).Maybe()
-	handler := newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager())
+	handler := newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager(), nil)
 
 	t := &datapb.CompactionTask{
 		TriggerID: 1,
@@ -1192,7 +1300,7 @@ func (s *CompactionPlanHandlerSuite) TestCreateCompactTask_UnknownType() {
 	s.SetupTest()
 
 	mockScheduler := task.NewMockGlobalScheduler(s.T())
-	handler := newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager())
+	handler := newCompactionInspector(s.mockMeta, s.mockAlloc, nil, mockScheduler, mockScheduler, newMockVersionManager(), nil)
 
 	t := &datapb.CompactionTask{
 		TriggerID: 2,
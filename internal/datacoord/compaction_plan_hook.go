@@ -0,0 +1,152 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/util/hookutil"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// compactionPlanHookSymbol is the symbol looked up from the compaction plan hook plugin.
+const compactionPlanHookSymbol = "CompactionPlanHook"
+
+// compactionPlanEvent is the compaction plan passed to the compaction plan hook in json.
+type compactionPlanEvent struct {
+	PlanID       int64   `json:"plan_id"`
+	TriggerID    int64   `json:"trigger_id"`
+	CollectionID int64   `json:"collection_id"`
+	PartitionID  int64   `json:"partition_id"`
+	Channel      string  `json:"channel"`
+	Type         string  `json:"type"`
+	Segments     []int64 `json:"segments"`
+	TotalRows    int64   `json:"total_rows"`
+}
+
+// compactionPlanHook audits or vetoes the compaction plans before they are enqueued,
+// by a local go plugin or an http endpoint.
+type compactionPlanHook struct {
+	plugin func(ctx context.Context, plan []byte) error
+	client *http.Client
+}
+
+func newCompactionPlanHook() *compactionPlanHook {
+	h := &compactionPlanHook{client: &http.Client{}}
+	path := paramtable.Get().DataCoordCfg.CompactionPlanHookPluginPath.GetValue()
+	if path == "" {
+		return h
+	}
+	plugin, err := hookutil.LoadPlugin[func(context.Context, []byte) error](path, compactionPlanHookSymbol)
+	if err != nil {
+		mlog.Warn(context.TODO(), "failed to load compaction plan hook plugin", mlog.String("path", path), mlog.Err(err))
+		return h
+	}
+	h.plugin = plugin
+	return h
+}
+
+// check calls the hook with the compaction plan, the returned error means the plan should not be enqueued.
+func (h *compactionPlanHook) check(ctx context.Context, task *datapb.CompactionTask) error {
+	if h == nil {
+		return nil
+	}
+	url := paramtable.Get().DataCoordCfg.CompactionPlanHookURL.GetValue()
+	if h.plugin == nil && url == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(&compactionPlanEvent{
+		PlanID:       task.GetPlanID(),
+		TriggerID:    task.GetTriggerID(),
+		CollectionID: task.GetCollectionID(),
+		PartitionID:  task.GetPartitionID(),
+		Channel:      task.GetChannel(),
+		Type:         task.GetType().String(),
+		Segments:     task.GetInputSegments(),
+		TotalRows:    task.GetTotalRows(),
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().DataCoordCfg.CompactionPlanHookTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+	if h.plugin != nil {
+		err = h.callPlugin(ctx, body)
+	} else {
+		err = h.post(ctx, url, body)
+	}
+	if err == nil || errors.Is(err, merr.ErrIllegalCompactionPlan) {
+		return err
+	}
+
+	// the hook itself fails, the plan is enqueued or dropped by the fail-open configuration.
+	if paramtable.Get().DataCoordCfg.CompactionPlanHookFailOpen.GetAsBool() {
+		mlog.Warn(ctx, "compaction plan hook failed, enqueue the plan",
+			mlog.Int64("planID", task.GetPlanID()), mlog.Err(err))
+		return nil
+	}
+	return merr.Wrap(err, "compaction plan hook failed")
+}
+
+func (h *compactionPlanHook) callPlugin(ctx context.Context, body []byte) error {
+	// the plugin may not respect the context, so it's called asynchronously to apply the timeout.
+	plugin := h.plugin
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- plugin(ctx, body)
+	}()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return merr.WrapErrIllegalCompactionPlanMsg("vetoed by compaction plan hook: %s", err.Error())
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *compactionPlanHook) post(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError {
+		return merr.WrapErrIllegalCompactionPlanMsg("vetoed by compaction plan hook with status code %d", resp.StatusCode)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return merr.WrapErrServiceInternalMsg("unexpected status code %d from compaction plan hook", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestCompactionPlanHook(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	task := &datapb.CompactionTask{
+		PlanID:        1,
+		CollectionID:  100,
+		Type:          datapb.CompactionType_MixCompaction,
+		InputSegments: []int64{10, 11},
+	}
+
+	t.Run("no hook", func(t *testing.T) {
+		var h *compactionPlanHook
+		assert.NoError(t, h.check(ctx, task))
+		assert.NoError(t, newCompactionPlanHook().check(ctx, task))
+	})
+
+	t.Run("http", func(t *testing.T) {
+		status := http.StatusOK
+		var event compactionPlanEvent
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+			w.WriteHeader(status)
+		}))
+		defer server.Close()
+		paramtable.Get().Save(paramtable.Get().DataCoordCfg.CompactionPlanHookURL.Key, server.URL)
+		defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.CompactionPlanHookURL.Key)
+
+		h := newCompactionPlanHook()
+		assert.NoError(t, h.check(ctx, task))
+		assert.Equal(t, int64(100), event.CollectionID)
+		assert.Equal(t, []int64{10, 11}, event.Segments)
+		assert.Equal(t, datapb.CompactionType_MixCompaction.String(), event.Type)
+
+		status = http.StatusForbidden
+		assert.ErrorIs(t, h.check(ctx, task), merr.ErrIllegalCompactionPlan)
+
+		// fail open
+		status = http.StatusInternalServerError
+		assert.NoError(t, h.check(ctx, task))
+
+		// fail closed
+		paramtable.Get().Save(paramtable.Get().DataCoordCfg.CompactionPlanHookFailOpen.Key, "false")
+		defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.CompactionPlanHookFailOpen.Key)
+		assert.Error(t, h.check(ctx, task))
+	})
+
+	t.Run("plugin", func(t *testing.T) {
+		h := &compactionPlanHook{plugin: func(ctx context.Context, plan []byte) error {
+			return nil
+		}}
+		assert.NoError(t, h.check(ctx, task))
+
+		h.plugin = func(ctx context.Context, plan []byte) error {
+			return errors.New("mock veto")
+		}
+		assert.ErrorIs(t, h.check(ctx, task), merr.ErrIllegalCompactionPlan)
+
+		paramtable.Get().Save(paramtable.Get().DataCoordCfg.CompactionPlanHookTimeout.Key, "10")
+		defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.CompactionPlanHookTimeout.Key)
+		h.plugin = func(ctx context.Context, plan []byte) error {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			return errors.New("mock veto after timeout")
+		}
+		assert.NoError(t, h.check(ctx, task))
+
+		paramtable.Get().Save(paramtable.Get().DataCoordCfg.CompactionPlanHookFailOpen.Key, "false")
+		defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.CompactionPlanHookFailOpen.Key)
+		err := h.check(ctx, task)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, merr.ErrIllegalCompactionPlan)
+	})
+}
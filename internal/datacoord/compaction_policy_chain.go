@@ -0,0 +1,169 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+)
+
+// The orders of the builtin single compaction policies,
+// the registered policies are interleaved with them by order.
+const (
+	SingleCompactionOrderStrictExpiry   = 100
+	SingleCompactionOrderExpiredRatio   = 200
+	SingleCompactionOrderDeletions      = 300
+	SingleCompactionOrderIndexVersion   = 400
+	SingleCompactionOrderTTLFieldExpiry = 500
+)
+
+// SingleCompactionDecision is the result of a single compaction policy on a segment.
+type SingleCompactionDecision struct {
+	// Trigger means the segment should be compacted.
+	Trigger bool
+	// Veto forbids the compaction of the segment whatever the other policies decide.
+	Veto bool
+	// Reason describes why the segment is triggered or vetoed.
+	Reason string
+	// Priority picks the reason reported when several policies trigger the segment, the higher the first.
+	Priority int
+}
+
+// SingleCompactionPolicy decides whether a segment should be compacted by itself, see ShouldDoSingleCompaction.
+type SingleCompactionPolicy interface {
+	Name() string
+	Check(segment *SegmentInfo, compactTime *compactTime) SingleCompactionDecision
+}
+
+type singleCompactionPolicyEntry struct {
+	order  int
+	policy SingleCompactionPolicy
+}
+
+var (
+	registeredSingleCompactionPoliciesMu sync.RWMutex
+	registeredSingleCompactionPolicies   = make(map[string]singleCompactionPolicyEntry)
+)
+
+// RegisterSingleCompactionPolicy registers a policy into the single compaction policy chain of all compaction triggers,
+// the policy with the same name is replaced. The policies are evaluated by order, and the builtin ones keep
+// the order of SingleCompactionOrder* constants.
+func RegisterSingleCompactionPolicy(order int, policy SingleCompactionPolicy) {
+	registeredSingleCompactionPoliciesMu.Lock()
+	defer registeredSingleCompactionPoliciesMu.Unlock()
+	registeredSingleCompactionPolicies[policy.Name()] = singleCompactionPolicyEntry{order: order, policy: policy}
+}
+
+// UnregisterSingleCompactionPolicy removes the registered policy by name.
+func UnregisterSingleCompactionPolicy(name string) {
+	registeredSingleCompactionPoliciesMu.Lock()
+	defer registeredSingleCompactionPoliciesMu.Unlock()
+	delete(registeredSingleCompactionPolicies, name)
+}
+
+// singleCompactionPolicyFunc adapts a check function of the compaction trigger to SingleCompactionPolicy.
+type singleCompactionPolicyFunc struct {
+	name     string
+	priority int
+	check    func(segment *SegmentInfo, compactTime *compactTime) bool
+}
+
+func (p *singleCompactionPolicyFunc) Name() string {
+	return p.name
+}
+
+func (p *singleCompactionPolicyFunc) Check(segment *SegmentInfo, compactTime *compactTime) SingleCompactionDecision {
+	if !p.check(segment, compactTime) {
+		return SingleCompactionDecision{}
+	}
+	return SingleCompactionDecision{Trigger: true, Reason: p.name, Priority: p.priority}
+}
+
+// singleCompactionPolicies returns the builtin and registered single compaction policies sorted by order.
+func (t *compactionTrigger) singleCompactionPolicies() []SingleCompactionPolicy {
+	entries := []singleCompactionPolicyEntry{
+		{order: SingleCompactionOrderStrictExpiry, policy: &singleCompactionPolicyFunc{
+			name: "strict expiry", priority: 40, check: t.ShouldCompactStrictExpiry,
+		}},
+		{order: SingleCompactionOrderExpiredRatio, policy: &singleCompactionPolicyFunc{
+			name: "expired ratio", priority: 30, check: t.ShouldCompactExpiredRatio,
+		}},
+		{order: SingleCompactionOrderDeletions, policy: &singleCompactionPolicyFunc{
+			name: "too many deletions", priority: 20, check: func(segment *SegmentInfo, _ *compactTime) bool {
+				return hasTooManyDeletions(segment)
+			},
+		}},
+		{order: SingleCompactionOrderIndexVersion, policy: &singleCompactionPolicyFunc{
+			name: "index version", priority: 10, check: func(segment *SegmentInfo, _ *compactTime) bool {
+				return t.ShouldRebuildSegmentIndex(segment)
+			},
+		}},
+		{order: SingleCompactionOrderTTLFieldExpiry, policy: &singleCompactionPolicyFunc{
+			name: "ttl field expiry", priority: 30, check: func(segment *SegmentInfo, compactTime *compactTime) bool {
+				return t.ShouldCompactExpiryWithTTLField(compactTime, segment)
+			},
+		}},
+	}
+
+	registeredSingleCompactionPoliciesMu.RLock()
+	for _, entry := range registeredSingleCompactionPolicies {
+		entries = append(entries, entry)
+	}
+	registeredSingleCompactionPoliciesMu.RUnlock()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].order != entries[j].order {
+			return entries[i].order < entries[j].order
+		}
+		return entries[i].policy.Name() < entries[j].policy.Name()
+	})
+	policies := make([]SingleCompactionPolicy, 0, len(entries))
+	for _, entry := range entries {
+		policies = append(policies, entry.policy)
+	}
+	return policies
+}
+
+// evaluateSingleCompactionPolicies evaluates the policy chain by order, a veto stops the chain and forbids the compaction,
+// otherwise the triggering decision with the highest priority is returned.
+func (t *compactionTrigger) evaluateSingleCompactionPolicies(segment *SegmentInfo, compactTime *compactTime) SingleCompactionDecision {
+	var result SingleCompactionDecision
+	for _, policy := range t.singleCompactionPolicies() {
+		decision := policy.Check(segment, compactTime)
+		if decision.Veto {
+			mlog.Info(context.TODO(), "single compaction is vetoed by policy",
+				mlog.FieldSegmentID(segment.GetID()),
+				mlog.String("policy", policy.Name()),
+				mlog.String("reason", decision.Reason))
+			return SingleCompactionDecision{Veto: true, Reason: decision.Reason}
+		}
+		if decision.Trigger && (!result.Trigger || decision.Priority > result.Priority) {
+			result = decision
+		}
+	}
+	if result.Trigger {
+		mlog.Info(context.TODO(), "single compaction is triggered by policy",
+			mlog.FieldSegmentID(segment.GetID()),
+			mlog.FieldCollectionID(segment.GetCollectionID()),
+			mlog.String("reason", result.Reason),
+			mlog.Int("priority", result.Priority))
+	}
+	return result
+}
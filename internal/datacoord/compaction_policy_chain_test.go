@@ -0,0 +1,81 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+)
+
+type testSingleCompactionPolicy struct {
+	name     string
+	decision SingleCompactionDecision
+}
+
+func (p *testSingleCompactionPolicy) Name() string {
+	return p.name
+}
+
+func (p *testSingleCompactionPolicy) Check(segment *SegmentInfo, compactTime *compactTime) SingleCompactionDecision {
+	return p.decision
+}
+
+func TestSingleCompactionPolicyChain(t *testing.T) {
+	trigger := &compactionTrigger{}
+	segment := &SegmentInfo{
+		SegmentInfo: &datapb.SegmentInfo{
+			ID:        1,
+			NumOfRows: 100,
+			MaxRowNum: 300,
+		},
+	}
+	assert.False(t, trigger.ShouldDoSingleCompaction(segment, &compactTime{}))
+
+	RegisterSingleCompactionPolicy(600, &testSingleCompactionPolicy{
+		name:     "low",
+		decision: SingleCompactionDecision{Trigger: true, Reason: "low", Priority: 1},
+	})
+	defer UnregisterSingleCompactionPolicy("low")
+	RegisterSingleCompactionPolicy(50, &testSingleCompactionPolicy{
+		name:     "high",
+		decision: SingleCompactionDecision{Trigger: true, Reason: "high", Priority: 100},
+	})
+	defer UnregisterSingleCompactionPolicy("high")
+
+	policies := trigger.singleCompactionPolicies()
+	assert.Equal(t, "high", policies[0].Name())
+	assert.Equal(t, "low", policies[len(policies)-1].Name())
+	decision := trigger.evaluateSingleCompactionPolicies(segment, &compactTime{})
+	assert.True(t, decision.Trigger)
+	assert.Equal(t, "high", decision.Reason)
+
+	// the veto wins whatever its order is.
+	RegisterSingleCompactionPolicy(700, &testSingleCompactionPolicy{
+		name:     "veto",
+		decision: SingleCompactionDecision{Veto: true, Reason: "veto"},
+	})
+	decision = trigger.evaluateSingleCompactionPolicies(segment, &compactTime{})
+	assert.False(t, decision.Trigger)
+	assert.True(t, decision.Veto)
+	assert.False(t, trigger.ShouldDoSingleCompaction(segment, &compactTime{}))
+
+	UnregisterSingleCompactionPolicy("veto")
+	assert.True(t, trigger.ShouldDoSingleCompaction(segment, &compactTime{}))
+}
@@ -19,6 +19,7 @@ package datacoord
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/samber/lo"
@@ -32,19 +33,35 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
 type clusteringCompactionPolicy struct {
 	meta      *meta
 	allocator allocator.Allocator
 	handler   Handler
+
+	skewMu sync.Mutex
+	// the last time of the skew triggered clustering compaction of each partition and channel, guarded by skewMu.
+	skewTriggeredAt map[clusteringSkewKey]time.Time
+}
+
+type clusteringSkewKey struct {
+	collectionID int64
+	partitionID  int64
+	channel      string
 }
 
 // Ensure clusteringCompactionPolicy implements CompactionPolicy interface
 var _ CompactionPolicy = (*clusteringCompactionPolicy)(nil)
 
 func newClusteringCompactionPolicy(meta *meta, allocator allocator.Allocator, handler Handler) *clusteringCompactionPolicy {
-	return &clusteringCompactionPolicy{meta: meta, allocator: allocator, handler: handler}
+	return &clusteringCompactionPolicy{
+		meta:            meta,
+		allocator:       allocator,
+		handler:         handler,
+		skewTriggeredAt: make(map[clusteringSkewKey]time.Time),
+	}
 }
 
 func (policy *clusteringCompactionPolicy) Enable() bool {
@@ -165,9 +182,9 @@ func (policy *clusteringCompactionPolicy) triggerOneCollection(ctx context.Conte
 			continue
 		}
 
-		collectionTTL, err := common.GetCollectionTTLFromMap(collection.Properties)
+		collectionTTL, err := common.GetPartitionsTTLFromMap(collection.Properties, group.partitionID)
 		if err != nil {
-			log.Warn(ctx, "get collection ttl failed, skip to handle compaction")
+			log.Warn(ctx, "get partition ttl failed, skip to handle compaction")
 			return make([]CompactionView, 0), 0, err
 		}
 
@@ -182,7 +199,7 @@ func (policy *clusteringCompactionPolicy) triggerOneCollection(ctx context.Conte
 				log.Warn(ctx, "failed to trigger clustering compaction", mlog.Err(err))
 				continue
 			}
-			if !execute {
+			if !execute && !policy.triggerBySkew(ctx, group) {
 				continue
 			}
 		}
@@ -325,6 +342,67 @@ func triggerClusteringCompactionPolicy(ctx context.Context, meta *meta, collecti
 	return false, nil
 }
 
+// triggerBySkew returns whether to trigger the clustering compaction of the partition and channel because of the clustering key skew.
+// The rows of the segments not clustered by the last clustering compaction are outside the cluster ranges of the partition stats,
+// the compaction is triggered once their ratio reaches the threshold, capped by the min intervals.
+func (policy *clusteringCompactionPolicy) triggerBySkew(ctx context.Context, group *chanPartSegments) bool {
+	if !Params.DataCoordCfg.ClusteringCompactionSkewTriggerEnable.GetAsBool() {
+		return false
+	}
+	log := mlog.With(mlog.FieldCollectionID(group.collectionID), mlog.FieldPartitionID(group.partitionID), mlog.String("channel", group.channelName))
+	currentVersion := policy.meta.partitionStatsMeta.GetCurrentPartitionStatsVersion(group.collectionID, group.partitionID, group.channelName)
+	if currentVersion == 0 {
+		// never clustered, left to the new data size threshold
+		return false
+	}
+	partitionStats := policy.meta.GetPartitionStatsMeta().GetPartitionStats(group.collectionID, group.partitionID, group.channelName, currentVersion)
+	if partitionStats == nil {
+		return false
+	}
+	if time.Since(time.Unix(partitionStats.GetCommitTime(), 0)) < Params.DataCoordCfg.ClusteringCompactionMinInterval.GetAsDuration(time.Second) {
+		return false
+	}
+
+	key := clusteringSkewKey{collectionID: group.collectionID, partitionID: group.partitionID, channel: group.channelName}
+	minInterval := Params.DataCoordCfg.ClusteringCompactionSkewTriggerMinInterval.GetAsDuration(time.Second)
+	policy.skewMu.Lock()
+	defer policy.skewMu.Unlock()
+	for k, triggeredAt := range policy.skewTriggeredAt {
+		if time.Since(triggeredAt) >= minInterval {
+			delete(policy.skewTriggeredAt, k)
+		}
+	}
+	if _, ok := policy.skewTriggeredAt[key]; ok {
+		log.Info(ctx, "Too short time before last skew triggered clustering compaction, skip compaction")
+		return false
+	}
+
+	ratio := clusteringKeySkewRatio(partitionStats, group.segments)
+	threshold := Params.DataCoordCfg.ClusteringCompactionSkewRatioThreshold.GetAsFloat()
+	if ratio < threshold {
+		return false
+	}
+	log.Info(ctx, "Clustering key skew is larger than threshold, do compaction", mlog.Float64("skewRatio", ratio), mlog.Float64("threshold", threshold))
+	policy.skewTriggeredAt[key] = time.Now()
+	return true
+}
+
+// clusteringKeySkewRatio returns the ratio of the rows of the segments not clustered by the partition stats.
+func clusteringKeySkewRatio(partitionStats *datapb.PartitionStatsInfo, segments []*SegmentInfo) float64 {
+	clustered := typeutil.NewUniqueSet(partitionStats.GetSegmentIDs()...)
+	var totalRows, skewedRows int64
+	for _, seg := range segments {
+		totalRows += seg.GetNumOfRows()
+		if !clustered.Contain(seg.GetID()) {
+			skewedRows += seg.GetNumOfRows()
+		}
+	}
+	if totalRows == 0 {
+		return 0
+	}
+	return float64(skewedRows) / float64(totalRows)
+}
+
 var _ CompactionView = (*ClusteringSegmentsView)(nil)
 
 type ClusteringSegmentsView struct {
@@ -522,3 +522,55 @@ func (s *ClusteringCompactionPolicySuite) TestTimeIntervalLogic() {
 		})
 	}
 }
+
+func (s *ClusteringCompactionPolicySuite) TestTriggerBySkew() {
+	ctx := context.TODO()
+	collectionID := int64(100)
+	partitionID := int64(101)
+	channel := "ch1"
+
+	enableKey := paramtable.Get().DataCoordCfg.ClusteringCompactionSkewTriggerEnable.Key
+	paramtable.Get().Save(enableKey, "true")
+	defer paramtable.Get().Reset(enableKey)
+
+	group := &chanPartSegments{
+		collectionID: collectionID,
+		partitionID:  partitionID,
+		channelName:  channel,
+		segments: []*SegmentInfo{
+			{SegmentInfo: &datapb.SegmentInfo{ID: 1, NumOfRows: 700}},
+			{SegmentInfo: &datapb.SegmentInfo{ID: 2, NumOfRows: 300}},
+		},
+	}
+	// never clustered
+	s.False(s.clusteringCompactionPolicy.triggerBySkew(ctx, group))
+
+	seedPartitionStatsInfo(s.meta.partitionStatsMeta, &datapb.PartitionStatsInfo{
+		CollectionID: collectionID,
+		PartitionID:  partitionID,
+		VChannel:     channel,
+		CommitTime:   time.Now().Add(-3 * time.Hour).Unix(),
+		SegmentIDs:   []int64{1},
+		Version:      100,
+	})
+	s.meta.partitionStatsMeta.partitionStatsInfos[channel][partitionID].currentVersion = 100
+
+	// 30% rows outside the cluster ranges
+	s.InDelta(0.3, clusteringKeySkewRatio(s.meta.partitionStatsMeta.GetPartitionStats(collectionID, partitionID, channel, 100), group.segments), 0.001)
+	thresholdKey := paramtable.Get().DataCoordCfg.ClusteringCompactionSkewRatioThreshold.Key
+	paramtable.Get().Save(thresholdKey, "0.5")
+	s.False(s.clusteringCompactionPolicy.triggerBySkew(ctx, group))
+	paramtable.Get().Reset(thresholdKey)
+	s.True(s.clusteringCompactionPolicy.triggerBySkew(ctx, group))
+	// capped by the skew trigger min interval
+	s.False(s.clusteringCompactionPolicy.triggerBySkew(ctx, group))
+
+	intervalKey := paramtable.Get().DataCoordCfg.ClusteringCompactionSkewTriggerMinInterval.Key
+	paramtable.Get().Save(intervalKey, "0")
+	defer paramtable.Get().Reset(intervalKey)
+	s.True(s.clusteringCompactionPolicy.triggerBySkew(ctx, group))
+
+	// disabled
+	paramtable.Get().Save(enableKey, "false")
+	s.False(s.clusteringCompactionPolicy.triggerBySkew(ctx, group))
+}
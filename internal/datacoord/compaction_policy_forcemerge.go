@@ -83,12 +83,6 @@ func (policy *forceMergeCompactionPolicy) triggerOneCollection(
 		return nil, 0, err
 	}
 
-	collectionTTL, err := common.GetCollectionTTLFromMap(collection.Properties)
-	if err != nil {
-		log.Warn(ctx, "failed to get collection ttl, use default", mlog.Err(err))
-		collectionTTL = 0
-	}
-
 	// Convert targetSize from MB to bytes (per design doc: targetSize is in MB)
 	// Handle overflow: when targetSize is very large (e.g., max_int64 for auto-calculate mode)
 	var targetSizeBytes int64
@@ -120,6 +114,11 @@ func (policy *forceMergeCompactionPolicy) triggerOneCollection(
 
 	views := []CompactionView{}
 	for label, groups := range groupByPartitionChannel(GetViewsByInfo(segments...)) {
+		collectionTTL, err := common.GetPartitionsTTLFromMap(collection.Properties, label.PartitionID)
+		if err != nil {
+			log.Warn(ctx, "failed to get partition ttl, use default", mlog.Err(err))
+			collectionTTL = 0
+		}
 		view := &ForceMergeSegmentView{
 			label:         label,
 			segments:      groups,
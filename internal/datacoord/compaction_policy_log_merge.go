@@ -0,0 +1,185 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/datacoord/allocator"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// logMergePolicy triggers the log merge compaction for the segments with too many small deltalogs
+// and pk statslogs, e.g. the segments of the wide schema collections which are hit by many L0 compactions.
+// It merges the logs without rewriting the insert binlogs, so it's much lighter than the mix compaction.
+type logMergePolicy struct {
+	meta      *meta
+	allocator allocator.Allocator
+}
+
+var _ CompactionPolicy = (*logMergePolicy)(nil)
+
+func newLogMergePolicy(meta *meta, allocator allocator.Allocator) *logMergePolicy {
+	return &logMergePolicy{meta: meta, allocator: allocator}
+}
+
+func (policy *logMergePolicy) Enable() bool {
+	return paramtable.Get().DataCoordCfg.LogMergeCompactionEnabled.GetAsBool()
+}
+
+func (policy *logMergePolicy) Name() string {
+	return "LogMerge"
+}
+
+// mergeableLogNum returns the number of the log files which could be merged by the log merge compaction.
+func mergeableLogNum(segment *SegmentInfo, pkFieldID int64) int {
+	num := int(segment.EnsureStats().GetDeltaBinlogCount())
+	for _, fieldBinlog := range segment.GetStatslogs() {
+		if fieldBinlog.GetFieldID() == pkFieldID {
+			num += len(fieldBinlog.GetBinlogs())
+		}
+	}
+	return num
+}
+
+func (policy *logMergePolicy) candidateSegments(collectionID, pkFieldID int64) []*chanPartSegments {
+	threshold := paramtable.Get().DataCoordCfg.LogMergeCompactionFileNumThreshold.GetAsInt()
+	return GetSegmentsChanPart(policy.meta, collectionID, SegmentFilterFunc(func(segment *SegmentInfo) bool {
+		return isSegmentHealthy(segment) &&
+			isFlushed(segment) &&
+			!segment.GetIsImporting() &&
+			!segment.GetIsInvisible() &&
+			segment.GetLevel() != datapb.SegmentLevel_L0 &&
+			!segment.isCompacting &&
+			!policy.meta.isSegmentCompactionProtected(segment.GetID()) &&
+			segment.GetManifestPath() == "" &&
+			mergeableLogNum(segment, pkFieldID) > threshold
+	}))
+}
+
+func (policy *logMergePolicy) Trigger(ctx context.Context) (map[CompactionTriggerType][]CompactionView, error) {
+	events := make(map[CompactionTriggerType][]CompactionView)
+	for _, collection := range policy.meta.GetCollections() {
+		if collection.Schema == nil || collection.IsExternal() {
+			continue
+		}
+		if policy.meta.isCollectionCompactionBlocked(collection.ID) {
+			mlog.Info(ctx, "skip log merge compaction for collection due to snapshot compaction block",
+				mlog.FieldCollectionID(collection.ID))
+			continue
+		}
+		pkField, err := typeutil.GetPrimaryFieldSchema(collection.Schema)
+		if err != nil {
+			mlog.Warn(ctx, "skip log merge compaction for collection without primary key",
+				mlog.FieldCollectionID(collection.ID), mlog.Err(err))
+			continue
+		}
+		partSegments := policy.candidateSegments(collection.ID, pkField.GetFieldID())
+		if len(partSegments) == 0 {
+			continue
+		}
+
+		triggerID, err := policy.allocator.AllocID(ctx)
+		if err != nil {
+			mlog.Warn(ctx, "fail to allocate triggerID for log merge compaction, skip the collection",
+				mlog.FieldCollectionID(collection.ID), mlog.Err(err))
+			continue
+		}
+		schema := proto.Clone(collection.Schema).(*schemapb.CollectionSchema)
+		for _, group := range partSegments {
+			for _, segment := range group.segments {
+				segmentViews := GetViewsByInfo(segment)
+				mlog.Info(ctx, "found segment needing log merge",
+					mlog.FieldSegmentID(segment.GetID()),
+					mlog.FieldCollectionID(collection.ID),
+					mlog.Int("mergeableLogNum", mergeableLogNum(segment, pkField.GetFieldID())))
+				events[TriggerTypeLogMerge] = append(events[TriggerTypeLogMerge], &LogMergeView{
+					label:     segmentViews[0].label,
+					segments:  segmentViews,
+					triggerID: triggerID,
+					schema:    schema,
+				})
+			}
+		}
+	}
+	return events, nil
+}
+
+type LogMergeView struct {
+	label     *CompactionGroupLabel
+	segments  []*SegmentView
+	triggerID int64
+	schema    *schemapb.CollectionSchema
+}
+
+var _ CompactionView = (*LogMergeView)(nil)
+
+func (v *LogMergeView) GetGroupLabel() *CompactionGroupLabel {
+	return v.label
+}
+
+func (v *LogMergeView) GetSegmentsView() []*SegmentView {
+	return v.segments
+}
+
+func (v *LogMergeView) GetTotalSize() float64 {
+	if v == nil {
+		return 0
+	}
+	return sumSegmentSize(v.segments)
+}
+
+func (v *LogMergeView) GetCollectionTTL() time.Duration {
+	return 0
+}
+
+func (v *LogMergeView) Append(segments ...*SegmentView) {
+	v.segments = append(v.segments, segments...)
+}
+
+func (v *LogMergeView) String() string {
+	label := "<nil>"
+	if v.label != nil {
+		label = v.label.Key()
+	}
+	return fmt.Sprintf("LogMergeView: label=%s, segments=%d, triggerID=%d", label, len(v.segments), v.triggerID)
+}
+
+func (v *LogMergeView) Trigger() (CompactionView, string) {
+	return v, "too many deltalogs or statslogs in segment"
+}
+
+func (v *LogMergeView) ForceTrigger() (CompactionView, string) {
+	return v.Trigger()
+}
+
+func (v *LogMergeView) ForceTriggerAll() ([]CompactionView, string) {
+	view, reason := v.Trigger()
+	return []CompactionView{view}, reason
+}
+
+func (v *LogMergeView) GetTriggerID() int64 {
+	return v.triggerID
+}
@@ -0,0 +1,144 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func newLogMergeTestSegment(segmentID int64, deltalogNum, statslogNum int, manifestPath string) *SegmentInfo {
+	deltalogs := make([]*datapb.Binlog, 0, deltalogNum)
+	for i := 0; i < deltalogNum; i++ {
+		deltalogs = append(deltalogs, &datapb.Binlog{LogID: int64(1000 + i), EntriesNum: 10, MemorySize: 100})
+	}
+	statslogs := make([]*datapb.Binlog, 0, statslogNum)
+	for i := 0; i < statslogNum; i++ {
+		statslogs = append(statslogs, &datapb.Binlog{LogID: int64(2000 + i), EntriesNum: 100, MemorySize: 10})
+	}
+	segment := NewSegmentInfo(&datapb.SegmentInfo{
+		ID:            segmentID,
+		CollectionID:  1,
+		PartitionID:   10,
+		InsertChannel: "ch-1",
+		Level:         datapb.SegmentLevel_L1,
+		State:         commonpb.SegmentState_Flushed,
+		NumOfRows:     1000,
+		ManifestPath:  manifestPath,
+		Binlogs: []*datapb.FieldBinlog{{
+			FieldID: 100,
+			Binlogs: []*datapb.Binlog{{LogID: 1, EntriesNum: 1000, MemorySize: 1000}},
+		}},
+		Statslogs: []*datapb.FieldBinlog{{FieldID: 100, Binlogs: statslogs}},
+		Deltalogs: []*datapb.FieldBinlog{{Binlogs: deltalogs}},
+	})
+	return segment
+}
+
+func newLogMergeTestMeta(t *testing.T, segments ...*SegmentInfo) *meta {
+	m := &meta{
+		segments:    NewSegmentsInfo(),
+		collections: typeutil.NewConcurrentMap[UniqueID, *collectionInfo](),
+		catalog:     mocks.NewDataCoordCatalog(t),
+	}
+	m.collections.Insert(1, &collectionInfo{
+		ID: 1,
+		Schema: &schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			},
+		},
+	})
+	for _, segment := range segments {
+		m.segments.SetSegment(segment.GetID(), segment)
+	}
+	return m
+}
+
+func TestLogMergePolicy_Trigger(t *testing.T) {
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.LogMergeCompactionFileNumThreshold.Key, "10")
+	defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.LogMergeCompactionFileNumThreshold.Key)
+
+	manyLogs := newLogMergeTestSegment(100, 8, 4, "")
+	fewLogs := newLogMergeTestSegment(101, 4, 4, "")
+	withManifest := newLogMergeTestSegment(102, 8, 4, "manifest")
+	compacting := newLogMergeTestSegment(103, 8, 4, "")
+	compacting.isCompacting = true
+
+	policy := newLogMergePolicy(newLogMergeTestMeta(t, manyLogs, fewLogs, withManifest, compacting), newMockAllocator(t))
+	events, err := policy.Trigger(context.Background())
+	assert.NoError(t, err)
+	views := events[TriggerTypeLogMerge]
+	assert.Len(t, views, 1)
+	assert.Equal(t, int64(100), views[0].GetSegmentsView()[0].ID)
+	assert.NotNil(t, views[0].(*LogMergeView).schema)
+	assert.NotZero(t, views[0].GetTriggerID())
+}
+
+func TestMeta_CompleteLogMergeCompactionMutation(t *testing.T) {
+	segment := newLogMergeTestSegment(100, 8, 4, "")
+	m := newLogMergeTestMeta(t, segment)
+	m.catalog.(*mocks.DataCoordCatalog).EXPECT().AlterSegments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	task := &datapb.CompactionTask{
+		PlanID:        1,
+		Type:          datapb.CompactionType_LogMergeCompaction,
+		InputSegments: []int64{100},
+	}
+	result := &datapb.CompactionPlanResult{
+		PlanID: 1,
+		Segments: []*datapb.CompactionSegment{{
+			SegmentID:  100,
+			NumOfRows:  1000,
+			InsertLogs: segment.GetBinlogs(),
+			Deltalogs: []*datapb.FieldBinlog{{Binlogs: []*datapb.Binlog{
+				{LogID: 3000, EntriesNum: 80, MemorySize: 500, TimestampFrom: 1, TimestampTo: 2},
+			}}},
+			Field2StatslogPaths: []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []*datapb.Binlog{
+				{LogID: 1, EntriesNum: 1000, MemorySize: 30},
+			}}},
+		}},
+	}
+
+	segments, _, err := m.completeLogMergeCompactionMutation(task, result)
+	assert.NoError(t, err)
+	assert.Len(t, segments, 1)
+	merged := m.segments.GetSegment(100)
+	assert.Equal(t, int64(1000), merged.GetNumOfRows())
+	assert.Equal(t, segment.GetBinlogs(), merged.GetBinlogs())
+	assert.Equal(t, 1, GetBinlogCount(merged.GetDeltalogs()))
+	assert.Equal(t, 1, GetBinlogCount(merged.GetStatslogs()))
+	assert.Equal(t, int64(1), merged.GetStats().GetDeltaBinlogCount())
+	assert.Equal(t, int64(80), merged.GetStats().GetDeleteNumRows())
+	assert.Equal(t, int64(30), merged.GetStats().GetStatsBinlogSize())
+	assert.Equal(t, segment.EnsureStats().GetInsertBinlogSize(), merged.GetStats().GetInsertBinlogSize())
+
+	// the result of another segment is rejected
+	result.Segments[0].SegmentID = 101
+	_, _, err = m.completeLogMergeCompactionMutation(task, result)
+	assert.Error(t, err)
+}
@@ -133,24 +133,16 @@ func (policy *singleCompactionPolicy) triggerSegmentSortCompaction(
 		return nil
 	}
 
-	collectionTTL, err := common.GetCollectionTTLFromMap(collection.Properties)
-	if err != nil {
-		log.Warn(ctx, "failed to apply triggerSegmentSortCompaction, get collection ttl failed")
-		return nil
-	}
-
 	newTriggerID, err := policy.allocator.AllocID(ctx)
 	if err != nil {
 		log.Warn(ctx, "fail to apply triggerSegmentSortCompaction, unable to allocate triggerID", mlog.Err(err))
 		return nil
 	}
 
-	segmentViews := GetViewsByInfo(segment)
-	view := &MixSegmentView{
-		label:         segmentViews[0].label,
-		segments:      segmentViews,
-		collectionTTL: collectionTTL,
-		triggerID:     newTriggerID,
+	view, err := newSingleSegmentMixView(collection, segment, newTriggerID)
+	if err != nil {
+		log.Warn(ctx, "failed to apply triggerSegmentSortCompaction, get partition ttl failed", mlog.Err(err))
+		return nil
 	}
 
 	log.Info(ctx, "succeeded to apply triggerSegmentSortCompaction",
@@ -162,7 +154,6 @@ func (policy *singleCompactionPolicy) triggerSortCompaction(
 	ctx context.Context,
 	triggerID int64,
 	collectionID int64,
-	collectionTTL time.Duration,
 ) ([]CompactionView, error) {
 	log := mlog.With(mlog.FieldCollectionID(collectionID))
 	if !Params.DataCoordCfg.EnableSortCompaction.GetAsBool() {
@@ -201,12 +192,10 @@ func (policy *singleCompactionPolicy) triggerSortCompaction(
 	invisibleSegments, ok := gbSegments[true]
 	if ok {
 		for _, segment := range invisibleSegments {
-			segmentViews := GetViewsByInfo(segment)
-			view := &MixSegmentView{
-				label:         segmentViews[0].label,
-				segments:      segmentViews,
-				collectionTTL: collectionTTL,
-				triggerID:     triggerID,
+			view, err := newSingleSegmentMixView(collection, segment, triggerID)
+			if err != nil {
+				log.Warn(ctx, "failed to apply triggerSortCompaction, get partition ttl failed", mlog.Err(err))
+				return nil, err
 			}
 			views = append(views, view)
 		}
@@ -218,12 +207,10 @@ func (policy *singleCompactionPolicy) triggerSortCompaction(
 			if i > Params.DataCoordCfg.SortCompactionTriggerCount.GetAsInt() {
 				break
 			}
-			segmentViews := GetViewsByInfo(segment)
-			view := &MixSegmentView{
-				label:         segmentViews[0].label,
-				segments:      segmentViews,
-				collectionTTL: collectionTTL,
-				triggerID:     triggerID,
+			view, err := newSingleSegmentMixView(collection, segment, triggerID)
+			if err != nil {
+				log.Warn(ctx, "failed to apply triggerSortCompaction, get partition ttl failed", mlog.Err(err))
+				return nil, err
 			}
 			views = append(views, view)
 		}
@@ -252,19 +239,13 @@ func (policy *singleCompactionPolicy) triggerOneCollection(ctx context.Context,
 		return nil, nil, 0, nil
 	}
 
-	collectionTTL, err := common.GetCollectionTTLFromMap(collection.Properties)
-	if err != nil {
-		log.Warn(ctx, "failed to apply singleCompactionPolicy, get collection ttl failed")
-		return nil, nil, 0, err
-	}
-
 	newTriggerID, err := policy.allocator.AllocID(ctx)
 	if err != nil {
 		log.Warn(ctx, "fail to apply singleCompactionPolicy, unable to allocate triggerID", mlog.Err(err))
 		return nil, nil, 0, err
 	}
 
-	sortViews, err := policy.triggerSortCompaction(ctx, newTriggerID, collectionID, collectionTTL)
+	sortViews, err := policy.triggerSortCompaction(ctx, newTriggerID, collectionID)
 	if err != nil {
 		log.Warn(ctx, "failed to apply singleCompactionPolicy, trigger sort compaction failed", mlog.Err(err))
 		return nil, nil, 0, err
@@ -273,6 +254,10 @@ func (policy *singleCompactionPolicy) triggerOneCollection(ctx context.Context,
 		log.RatedInfo(ctx, rate.Limit(20), "collection auto compaction disabled")
 		return nil, sortViews, 0, nil
 	}
+	if !manual && !isCollectionInCompactionWindow(collection, time.Now()) {
+		log.RatedInfo(ctx, rate.Limit(20), "collection is out of compaction window")
+		return nil, sortViews, 0, nil
+	}
 
 	views := make([]CompactionView, 0)
 	partSegments := GetSegmentsChanPart(policy.meta, collectionID, SegmentFilterFunc(func(segment *SegmentInfo) bool {
@@ -292,12 +277,10 @@ func (policy *singleCompactionPolicy) triggerOneCollection(ctx context.Context,
 
 		for _, segment := range group.segments {
 			if hasTooManyDeletions(segment) {
-				segmentViews := GetViewsByInfo(segment)
-				view := &MixSegmentView{
-					label:         segmentViews[0].label,
-					segments:      segmentViews,
-					collectionTTL: collectionTTL,
-					triggerID:     newTriggerID,
+				view, err := newSingleSegmentMixView(collection, segment, newTriggerID)
+				if err != nil {
+					log.Warn(ctx, "failed to apply singleCompactionPolicy, get partition ttl failed", mlog.Err(err))
+					return nil, nil, 0, err
 				}
 				views = append(views, view)
 			}
@@ -312,6 +295,22 @@ func (policy *singleCompactionPolicy) triggerOneCollection(ctx context.Context,
 	return views, sortViews, newTriggerID, nil
 }
 
+// newSingleSegmentMixView builds a view compacting one segment on its own,
+// carrying the ttl of the partition the segment belongs to.
+func newSingleSegmentMixView(collection *collectionInfo, segment *SegmentInfo, triggerID int64) (*MixSegmentView, error) {
+	collectionTTL, err := common.GetPartitionsTTLFromMap(collection.Properties, segment.GetPartitionID())
+	if err != nil {
+		return nil, err
+	}
+	segmentViews := GetViewsByInfo(segment)
+	return &MixSegmentView{
+		label:         segmentViews[0].label,
+		segments:      segmentViews,
+		collectionTTL: collectionTTL,
+		triggerID:     triggerID,
+	}, nil
+}
+
 var _ CompactionView = (*MixSegmentView)(nil)
 
 type MixSegmentView struct {
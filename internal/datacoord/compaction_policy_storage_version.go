@@ -25,7 +25,6 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
 	"github.com/milvus-io/milvus/internal/storage"
-	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
@@ -151,12 +150,6 @@ func (policy *storageVersionUpgradePolicy) triggerOneCollection(ctx context.Cont
 		return nil, nil
 	}
 
-	collectionTTL, err := common.GetCollectionTTLFromMap(collection.Properties)
-	if err != nil {
-		mlog.Warn(ctx, "failed to apply storageVersionUpgradePolicy, get collection ttl failed")
-		return nil, err
-	}
-
 	newTriggerID, err := policy.allocator.AllocID(ctx)
 	if err != nil {
 		mlog.Warn(ctx, "fail to apply storageVersionUpgradePolicy, unable to allocate triggerID", mlog.Err(err))
@@ -201,12 +194,10 @@ func (policy *storageVersionUpgradePolicy) triggerOneCollection(ctx context.Cont
 		if policy.currentCount >= maxCount {
 			break
 		}
-		segmentViews := GetViewsByInfo(segment)
-		view := &MixSegmentView{
-			label:         segmentViews[0].label,
-			segments:      segmentViews,
-			collectionTTL: collectionTTL,
-			triggerID:     newTriggerID,
+		view, err := newSingleSegmentMixView(collection, segment, newTriggerID)
+		if err != nil {
+			mlog.Warn(ctx, "failed to apply storageVersionUpgradePolicy, get partition ttl failed", mlog.Err(err))
+			return nil, err
 		}
 		views = append(views, view)
 		policy.currentCount++
@@ -0,0 +1,153 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// compactionPriorityBoostOffset is subtracted from the priority of the tasks of the boosted collections,
+// so that they are dequeued before all the others while keeping the order given by the prioritizer among themselves.
+const compactionPriorityBoostOffset = math.MaxInt64 / 2
+
+// compactionPriorityBoosts holds the collections whose compaction tasks are temporarily boosted,
+// for example right before a large query campaign on the collection.
+type compactionPriorityBoosts struct {
+	mu       sync.RWMutex
+	expireAt map[int64]time.Time
+}
+
+func newCompactionPriorityBoosts() *compactionPriorityBoosts {
+	return &compactionPriorityBoosts{
+		expireAt: make(map[int64]time.Time),
+	}
+}
+
+// Boost boosts the compaction priority of the collection until now + duration, it replaces the previous boost if any.
+func (b *compactionPriorityBoosts) Boost(collectionID int64, duration time.Duration) time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	expireAt := time.Now().Add(duration)
+	b.expireAt[collectionID] = expireAt
+	return expireAt
+}
+
+// Cancel removes the boost of the collection.
+func (b *compactionPriorityBoosts) Cancel(collectionID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.expireAt, collectionID)
+}
+
+// IsBoosted returns whether the compaction priority of the collection is boosted and not expired yet.
+func (b *compactionPriorityBoosts) IsBoosted(collectionID int64) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	expireAt, ok := b.expireAt[collectionID]
+	return ok && time.Now().Before(expireAt)
+}
+
+// List returns the unexpired boosts ordered by collection ID, the expired ones are removed.
+func (b *compactionPriorityBoosts) List() []*CompactionPriorityBoost {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	boosts := make([]*CompactionPriorityBoost, 0, len(b.expireAt))
+	for collectionID, expireAt := range b.expireAt {
+		if !now.Before(expireAt) {
+			delete(b.expireAt, collectionID)
+			continue
+		}
+		boosts = append(boosts, &CompactionPriorityBoost{
+			CollectionID:     collectionID,
+			ExpireAt:         expireAt,
+			RemainingSeconds: int64(expireAt.Sub(now).Seconds()),
+		})
+	}
+	sort.Slice(boosts, func(i, j int) bool { return boosts[i].CollectionID < boosts[j].CollectionID })
+	return boosts
+}
+
+// prioritizer returns the prioritizer applying the boosts on top of the given one,
+// the given prioritizer is returned as is if there are no boosts.
+func (b *compactionPriorityBoosts) prioritizer(p Prioritizer) Prioritizer {
+	if b == nil {
+		return p
+	}
+	return func(task CompactionTask) int {
+		priority := p(task)
+		if b.IsBoosted(task.GetTaskProto().GetCollectionID()) {
+			return priority - compactionPriorityBoostOffset
+		}
+		return priority
+	}
+}
+
+// CompactionPriorityBoost is the compaction priority boost of a collection.
+type CompactionPriorityBoost struct {
+	CollectionID     int64     `json:"collection_id"`
+	ExpireAt         time.Time `json:"expire_at"`
+	RemainingSeconds int64     `json:"remaining_seconds"`
+}
+
+// BoostCompactionPriority boosts the compaction priority of the collection for the duration,
+// the boost is bounded by dataCoord.compaction.priorityBoost.maxDuration. A zero duration cancels the boost.
+// The queued tasks are reprioritized in the next round of scheduling.
+func (s *Server) BoostCompactionPriority(ctx context.Context, collectionID int64, duration time.Duration) (*CompactionPriorityBoost, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return nil, err
+	}
+	maxDuration := Params.DataCoordCfg.CompactionPriorityBoostMaxDuration.GetAsDuration(time.Second)
+	if duration < 0 || duration > maxDuration {
+		return nil, merr.WrapErrParameterInvalidMsg(fmt.Sprintf("the boost duration %s is out of range [0, %s]", duration, maxDuration))
+	}
+	if s.meta.GetCollection(collectionID) == nil {
+		return nil, merr.WrapErrCollectionNotFound(collectionID)
+	}
+
+	if duration == 0 {
+		s.priorityBoosts.Cancel(collectionID)
+		mlog.Info(ctx, "compaction priority boost canceled", mlog.Int64("collectionID", collectionID))
+		return &CompactionPriorityBoost{CollectionID: collectionID}, nil
+	}
+	expireAt := s.priorityBoosts.Boost(collectionID, duration)
+	mlog.Info(ctx, "compaction priority boosted",
+		mlog.Int64("collectionID", collectionID),
+		mlog.Duration("duration", duration),
+		mlog.Time("expireAt", expireAt))
+	return &CompactionPriorityBoost{
+		CollectionID:     collectionID,
+		ExpireAt:         expireAt,
+		RemainingSeconds: int64(duration.Seconds()),
+	}, nil
+}
+
+// ListCompactionPriorityBoosts returns the unexpired compaction priority boosts.
+func (s *Server) ListCompactionPriorityBoosts(ctx context.Context) ([]*CompactionPriorityBoost, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return nil, err
+	}
+	return s.priorityBoosts.List(), nil
+}
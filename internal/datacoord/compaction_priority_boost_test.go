@@ -0,0 +1,126 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestCompactionPriorityBoost(t *testing.T) {
+	priorityBoosts := newCompactionPriorityBoosts()
+
+	newTask := func(planID, collectionID int64, tp datapb.CompactionType) CompactionTask {
+		task := &mixCompactionTask{}
+		task.SetTask(&datapb.CompactionTask{PlanID: planID, CollectionID: collectionID, Type: tp})
+		return task
+	}
+	t1 := newTask(1, 100, datapb.CompactionType_Level0DeleteCompaction)
+	t2 := newTask(2, 200, datapb.CompactionType_ClusteringCompaction)
+	t3 := newTask(3, 200, datapb.CompactionType_MixCompaction)
+
+	dequeueAll := func(cq *CompactionQueue) []int64 {
+		planIDs := make([]int64, 0)
+		for {
+			task, err := cq.Dequeue()
+			if err != nil {
+				return planIDs
+			}
+			planIDs = append(planIDs, task.GetTaskProto().GetPlanID())
+		}
+	}
+
+	priorityBoosts.Boost(200, time.Hour)
+	assert.True(t, priorityBoosts.IsBoosted(200))
+	assert.False(t, priorityBoosts.IsBoosted(100))
+
+	for _, prioritizer := range []Prioritizer{DefaultPrioritizer, LevelPrioritizer} {
+		cq := NewCompactionQueue(0, priorityBoosts.prioritizer(prioritizer))
+		for _, task := range []CompactionTask{t1, t2, t3} {
+			assert.NoError(t, cq.Enqueue(task))
+		}
+		// the tasks of the boosted collection go first, in the order of the prioritizer
+		planIDs := dequeueAll(cq)
+		assert.Equal(t, int64(1), planIDs[2])
+	}
+
+	cq := NewCompactionQueue(0, priorityBoosts.prioritizer(LevelPrioritizer))
+	for _, task := range []CompactionTask{t1, t2, t3} {
+		assert.NoError(t, cq.Enqueue(task))
+	}
+	assert.Equal(t, []int64{3, 2, 1}, dequeueAll(cq))
+
+	// the queued tasks are reprioritized after the boost is canceled
+	for _, task := range []CompactionTask{t1, t2, t3} {
+		assert.NoError(t, cq.Enqueue(task))
+	}
+	priorityBoosts.Cancel(200)
+	cq.UpdatePrioritizer(priorityBoosts.prioritizer(LevelPrioritizer))
+	assert.Equal(t, []int64{1, 3, 2}, dequeueAll(cq))
+
+	// the expired boosts are ignored and removed on listing
+	priorityBoosts.Boost(100, time.Hour)
+	priorityBoosts.Boost(200, -time.Second)
+	assert.False(t, priorityBoosts.IsBoosted(200))
+	boosts := priorityBoosts.List()
+	assert.Len(t, boosts, 1)
+	assert.Equal(t, int64(100), boosts[0].CollectionID)
+	assert.Len(t, priorityBoosts.expireAt, 1)
+}
+
+func TestServer_BoostCompactionPriority(t *testing.T) {
+	ctx := context.Background()
+
+	s := &Server{
+		meta:           &meta{collections: typeutil.NewConcurrentMap[UniqueID, *collectionInfo]()},
+		priorityBoosts: newCompactionPriorityBoosts(),
+	}
+	s.meta.AddCollection(&collectionInfo{ID: 100})
+
+	_, err := s.BoostCompactionPriority(ctx, 100, time.Hour)
+	assert.ErrorIs(t, err, merr.ErrServiceNotReady)
+
+	s.stateCode.Store(commonpb.StateCode_Healthy)
+	_, err = s.BoostCompactionPriority(ctx, 100, -time.Second)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	_, err = s.BoostCompactionPriority(ctx, 100, Params.DataCoordCfg.CompactionPriorityBoostMaxDuration.GetAsDuration(time.Second)+time.Second)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	_, err = s.BoostCompactionPriority(ctx, 200, time.Hour)
+	assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+
+	boost, err := s.BoostCompactionPriority(ctx, 100, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), boost.CollectionID)
+	assert.Equal(t, int64(3600), boost.RemainingSeconds)
+	boosts, err := s.ListCompactionPriorityBoosts(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, boosts, 1)
+
+	_, err = s.BoostCompactionPriority(ctx, 100, 0)
+	assert.NoError(t, err)
+	boosts, err = s.ListCompactionPriorityBoosts(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, boosts)
+}
@@ -18,10 +18,14 @@ package datacoord
 
 import (
 	"container/heap"
+	"context"
+	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
 
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/util/lock"
 )
@@ -84,30 +88,56 @@ var (
 
 type Prioritizer func(t CompactionTask) int
 
+// CompactionQueue holds the compaction tasks waiting to be scheduled.
+// The tasks are partitioned by database with the database fairness, otherwise all of them are in one partition.
 type CompactionQueue struct {
-	pq          PriorityQueue[CompactionTask]
+	pqs         map[string]*PriorityQueue[CompactionTask] // database name -> tasks of the database
+	size        int
 	lock        lock.RWMutex
 	prioritizer Prioritizer
 	capacity    int
+
+	// databaseOf resolves the database of the task for the database fairness.
+	databaseOf func(CompactionTask) string
+	// weights are the database weights of the weighted round robin, nil if the database fairness is disabled.
+	weights map[string]int
+	// credits are the current credits of the databases in the smooth weighted round robin.
+	credits map[string]int
 }
 
 func NewCompactionQueue(capacity int, prioritizer Prioritizer) *CompactionQueue {
 	return &CompactionQueue{
-		pq:          make(PriorityQueue[CompactionTask], 0),
+		pqs:         make(map[string]*PriorityQueue[CompactionTask]),
 		lock:        lock.RWMutex{},
 		prioritizer: prioritizer,
 		capacity:    capacity,
+		databaseOf:  func(CompactionTask) string { return "" },
+		credits:     make(map[string]int),
+	}
+}
+
+func (q *CompactionQueue) push(t CompactionTask) {
+	database := ""
+	if q.weights != nil {
+		database = q.databaseOf(t)
+	}
+	pq, ok := q.pqs[database]
+	if !ok {
+		pq = &PriorityQueue[CompactionTask]{}
+		q.pqs[database] = pq
 	}
+	heap.Push(pq, &Item[CompactionTask]{value: t, priority: q.prioritizer(t)})
+	q.size++
 }
 
 func (q *CompactionQueue) Enqueue(t CompactionTask) error {
 	q.lock.Lock()
 	defer q.lock.Unlock()
-	if q.capacity > 0 && len(q.pq) >= q.capacity {
+	if q.capacity > 0 && q.size >= q.capacity {
 		return errFull
 	}
 
-	heap.Push(&q.pq, &Item[CompactionTask]{value: t, priority: q.prioritizer(t)})
+	q.push(t)
 	return nil
 }
 
@@ -115,47 +145,132 @@ func (q *CompactionQueue) Dequeue() (CompactionTask, error) {
 	q.lock.Lock()
 	defer q.lock.Unlock()
 
-	if len(q.pq) == 0 {
+	if q.size == 0 {
 		return nil, errNoSuchElement
 	}
 
-	item := heap.Pop(&q.pq).(*Item[CompactionTask])
+	database := q.nextDatabase()
+	pq := q.pqs[database]
+	item := heap.Pop(pq).(*Item[CompactionTask])
+	q.size--
+	if pq.Len() == 0 {
+		delete(q.pqs, database)
+		delete(q.credits, database)
+	}
 	return item.value, nil
 }
 
+// nextDatabase returns the database to dequeue from, it's picked by the smooth weighted round robin
+// with the database fairness, otherwise it's the one holding the task of the highest priority.
+func (q *CompactionQueue) nextDatabase() string {
+	selected, first := "", true
+	if q.weights == nil {
+		for database, pq := range q.pqs {
+			if first || (*pq)[0].priority < (*q.pqs[selected])[0].priority {
+				selected, first = database, false
+			}
+		}
+		return selected
+	}
+
+	total := 0
+	for database := range q.pqs {
+		weight := q.weights[database]
+		if weight <= 0 {
+			weight = 1
+		}
+		q.credits[database] += weight
+		total += weight
+		if first || q.credits[database] > q.credits[selected] ||
+			(q.credits[database] == q.credits[selected] && database < selected) {
+			selected, first = database, false
+		}
+	}
+	q.credits[selected] -= total
+	return selected
+}
+
 func (q *CompactionQueue) UpdatePrioritizer(prioritizer Prioritizer) {
 	q.prioritizer = prioritizer
 	q.lock.Lock()
 	defer q.lock.Unlock()
-	for i := range q.pq {
-		q.pq[i].priority = q.prioritizer(q.pq[i].value)
+	for _, pq := range q.pqs {
+		for i := range *pq {
+			(*pq)[i].priority = q.prioritizer((*pq)[i].value)
+		}
+		heap.Init(pq)
+	}
+}
+
+// UpdateDatabaseWeights updates the database weights of the database fairness, nil disables the database fairness.
+// The databases without weight are weighted 1.
+func (q *CompactionQueue) UpdateDatabaseWeights(weights map[string]int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	toggled := (q.weights == nil) != (weights == nil)
+	q.weights = weights
+	if !toggled {
+		return
+	}
+
+	// repartition the tasks since the database fairness is toggled.
+	items := make([]*Item[CompactionTask], 0, q.size)
+	for _, pq := range q.pqs {
+		items = append(items, *pq...)
+	}
+	q.pqs = make(map[string]*PriorityQueue[CompactionTask])
+	q.credits = make(map[string]int)
+	q.size = 0
+	for _, item := range items {
+		q.push(item.value)
 	}
-	heap.Init(&q.pq)
 }
 
 func (q *CompactionQueue) RemoveAll(predicate func(CompactionTask) bool) {
 	q.lock.Lock()
 	defer q.lock.Unlock()
-	f := lo.Filter[*Item[CompactionTask]](q.pq, func(i1 *Item[CompactionTask], _ int) bool {
-		return !predicate(i1.value)
-	})
-	q.pq = f
-	heap.Init(&q.pq)
+	for database, pq := range q.pqs {
+		f := lo.Filter[*Item[CompactionTask]](*pq, func(i1 *Item[CompactionTask], _ int) bool {
+			return !predicate(i1.value)
+		})
+		q.size -= pq.Len() - len(f)
+		if len(f) == 0 {
+			delete(q.pqs, database)
+			delete(q.credits, database)
+			continue
+		}
+		*pq = f
+		heap.Init(pq)
+	}
 }
 
 // ForEach calls f on each item in the queue.
 func (q *CompactionQueue) ForEach(f func(CompactionTask)) {
 	q.lock.RLock()
 	defer q.lock.RUnlock()
-	lo.ForEach[*Item[CompactionTask]](q.pq, func(i *Item[CompactionTask], _ int) {
-		f(i.value)
-	})
+	for _, pq := range q.pqs {
+		lo.ForEach[*Item[CompactionTask]](*pq, func(i *Item[CompactionTask], _ int) {
+			f(i.value)
+		})
+	}
 }
 
 func (q *CompactionQueue) Len() int {
 	q.lock.RLock()
 	defer q.lock.RUnlock()
-	return len(q.pq)
+	return q.size
+}
+
+// DatabaseLens returns the number of the tasks of each database, it's nil if the database fairness is disabled.
+func (q *CompactionQueue) DatabaseLens() map[string]int {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	if q.weights == nil {
+		return nil
+	}
+	return lo.MapValues(q.pqs, func(pq *PriorityQueue[CompactionTask], _ string) int {
+		return pq.Len()
+	})
 }
 
 var (
@@ -201,7 +316,33 @@ func getPrioritizer() Prioritizer {
 		return LevelPrioritizer
 	case "mix":
 		return MixFirstPrioritizer
+	case "database":
+		// the tasks of each database are prioritized by level, the databases are scheduled fairly by the queue.
+		return LevelPrioritizer
 	default:
 		return DefaultPrioritizer
 	}
 }
+
+// getDatabaseWeights returns the database weights of the database prioritizer,
+// it's nil if the database prioritizer isn't used.
+func getDatabaseWeights() map[string]int {
+	if Params.DataCoordCfg.CompactionTaskPrioritizer.GetValue() != "database" {
+		return nil
+	}
+	weights := make(map[string]int)
+	for _, v := range Params.DataCoordCfg.CompactionDatabaseWeights.GetAsStrings() {
+		kv := strings.SplitN(v, ":", 2)
+		if len(kv) != 2 {
+			mlog.RatedWarn(context.TODO(), 0.1, "invalid compaction database weight", mlog.String("value", v))
+			continue
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil || weight <= 0 {
+			mlog.RatedWarn(context.TODO(), 0.1, "invalid compaction database weight", mlog.String("value", v))
+			continue
+		}
+		weights[strings.TrimSpace(kv[0])] = weight
+	}
+	return weights
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
 func TestCompactionQueue(t *testing.T) {
@@ -175,3 +176,93 @@ func TestConcurrency(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestDatabaseFairness(t *testing.T) {
+	newTask := func(planID, collectionID int64, compactionType datapb.CompactionType) CompactionTask {
+		task := &mixCompactionTask{}
+		task.SetTask(&datapb.CompactionTask{
+			PlanID:       planID,
+			CollectionID: collectionID,
+			Type:         compactionType,
+		})
+		return task
+	}
+	// collection 1 is in db1, the others are in db2.
+	databaseOf := func(t CompactionTask) string {
+		if t.GetTaskProto().GetCollectionID() == 1 {
+			return "db1"
+		}
+		return "db2"
+	}
+
+	t.Run("round robin", func(t *testing.T) {
+		cq := NewCompactionQueue(0, LevelPrioritizer)
+		cq.databaseOf = databaseOf
+		// db1 monopolizes the queue with the tasks of higher priority.
+		for i := int64(0); i < 10; i++ {
+			assert.NoError(t, cq.Enqueue(newTask(i, 1, datapb.CompactionType_Level0DeleteCompaction)))
+		}
+		assert.NoError(t, cq.Enqueue(newTask(10, 2, datapb.CompactionType_MixCompaction)))
+		assert.NoError(t, cq.Enqueue(newTask(11, 3, datapb.CompactionType_Level0DeleteCompaction)))
+		assert.Nil(t, cq.DatabaseLens())
+
+		cq.UpdateDatabaseWeights(map[string]int{})
+		assert.Equal(t, 12, cq.Len())
+		assert.Equal(t, map[string]int{"db1": 10, "db2": 2}, cq.DatabaseLens())
+
+		databases := make([]string, 0)
+		for i := 0; i < 4; i++ {
+			task, err := cq.Dequeue()
+			assert.NoError(t, err)
+			databases = append(databases, databaseOf(task))
+			if i == 1 {
+				// the tasks of each database are still prioritized by the prioritizer.
+				assert.Equal(t, int64(11), task.GetTaskProto().GetPlanID())
+			}
+		}
+		assert.Equal(t, []string{"db1", "db2", "db1", "db2"}, databases)
+		assert.Equal(t, map[string]int{"db1": 8}, cq.DatabaseLens())
+
+		// disabling the fairness merges the tasks back.
+		cq.UpdateDatabaseWeights(nil)
+		assert.Equal(t, 8, cq.Len())
+		assert.Nil(t, cq.DatabaseLens())
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		cq := NewCompactionQueue(0, DefaultPrioritizer)
+		cq.databaseOf = databaseOf
+		cq.UpdateDatabaseWeights(map[string]int{"db1": 3})
+		for i := int64(0); i < 8; i++ {
+			assert.NoError(t, cq.Enqueue(newTask(i, 1, datapb.CompactionType_MixCompaction)))
+			assert.NoError(t, cq.Enqueue(newTask(100+i, 2, datapb.CompactionType_MixCompaction)))
+		}
+
+		counts := make(map[string]int)
+		for i := 0; i < 8; i++ {
+			task, err := cq.Dequeue()
+			assert.NoError(t, err)
+			counts[databaseOf(task)]++
+		}
+		assert.Equal(t, map[string]int{"db1": 6, "db2": 2}, counts)
+
+		cq.RemoveAll(func(task CompactionTask) bool {
+			return databaseOf(task) == "db1"
+		})
+		assert.Equal(t, 6, cq.Len())
+		assert.Equal(t, map[string]int{"db2": 6}, cq.DatabaseLens())
+	})
+}
+
+func TestGetDatabaseWeights(t *testing.T) {
+	paramtable.Init()
+	assert.Nil(t, getDatabaseWeights())
+
+	paramtable.Get().Save(Params.DataCoordCfg.CompactionTaskPrioritizer.Key, "database")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.CompactionTaskPrioritizer.Key)
+	assert.Equal(t, map[string]int{}, getDatabaseWeights())
+
+	paramtable.Get().Save(Params.DataCoordCfg.CompactionDatabaseWeights.Key, "db1:3, db2 : 2,db3,db4:-1,db5:x")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.CompactionDatabaseWeights.Key)
+	assert.Equal(t, map[string]int{"db1": 3, "db2": 2}, getDatabaseWeights())
+}
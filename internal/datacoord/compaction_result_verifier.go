@@ -0,0 +1,141 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// binlogEntriesNum returns the row count recorded by the binlogs of the segment.
+// Every field (or column group) binlog should hold the same number of rows,
+// the binlogs without entries num are skipped since the count is unknown.
+func binlogEntriesNum(segment *datapb.CompactionSegment) (int64, bool, error) {
+	entriesNum, known := int64(0), false
+	for _, fieldBinlog := range segment.GetInsertLogs() {
+		sum, complete := int64(0), len(fieldBinlog.GetBinlogs()) > 0
+		for _, b := range fieldBinlog.GetBinlogs() {
+			if b.GetEntriesNum() <= 0 {
+				complete = false
+				break
+			}
+			sum += b.GetEntriesNum()
+		}
+		if !complete {
+			continue
+		}
+		if known && sum != entriesNum {
+			return 0, false, merr.WrapErrIllegalCompactionPlanMsg(
+				"segment %d has inconsistent binlog entries num, field %d has %d rows, expected %d",
+				segment.GetSegmentID(), fieldBinlog.GetFieldID(), sum, entriesNum)
+		}
+		entriesNum, known = sum, true
+	}
+	return entriesNum, known, nil
+}
+
+// verifyCompactionResult validates the row counts of the compaction result against
+// the binlogs reported by the worker and the input segments before the meta mutation.
+// The row count of an output segment is repaired if it's inconsistent with its binlogs,
+// other inconsistencies reject the result.
+// It should be called with segMu held.
+func (m *meta) verifyCompactionResult(t *datapb.CompactionTask, result *datapb.CompactionPlanResult) error {
+	logger := mlog.With(mlog.Int64("planID", t.GetPlanID()), mlog.String("type", t.GetType().String()))
+	inputRows := int64(0)
+	for _, segmentID := range t.GetInputSegments() {
+		if segment := m.segments.GetSegment(segmentID); segment != nil {
+			inputRows += segment.GetNumOfRows()
+		}
+	}
+
+	outputRows := int64(0)
+	for _, segment := range result.GetSegments() {
+		if segment.GetNumOfRows() < 0 {
+			return merr.WrapErrIllegalCompactionPlanMsg("segment %d has negative num of rows %d",
+				segment.GetSegmentID(), segment.GetNumOfRows())
+		}
+		entriesNum, known, err := binlogEntriesNum(segment)
+		if err != nil {
+			return err
+		}
+		if known && entriesNum != segment.GetNumOfRows() {
+			logger.Warn(context.TODO(), "num of rows of compaction result is inconsistent with binlogs, repair it",
+				mlog.Int64("segmentID", segment.GetSegmentID()),
+				mlog.Int64("reported", segment.GetNumOfRows()),
+				mlog.Int64("binlogEntriesNum", entriesNum))
+			segment.NumOfRows = entriesNum
+		}
+		outputRows += segment.GetNumOfRows()
+	}
+
+	// compaction only removes the deleted and expired rows.
+	if outputRows > inputRows {
+		return merr.WrapErrIllegalCompactionPlanMsg(
+			"compaction result has %d rows, more than %d rows of input segments", outputRows, inputRows)
+	}
+	return nil
+}
+
+// verifyCompactionSortedOutput rejects the unsorted output segments of the task requiring sorted output,
+// the empty segments are skipped since they are dropped right away.
+func verifyCompactionSortedOutput(t *datapb.CompactionTask, result *datapb.CompactionPlanResult) error {
+	if !t.GetRequireSortedOutput() {
+		return nil
+	}
+	for _, segment := range result.GetSegments() {
+		if segment.GetNumOfRows() > 0 && !segment.GetIsSorted() && !segment.GetIsSortedByNamespace() {
+			return merr.WrapErrIllegalCompactionPlanMsg("segment %d is not sorted, the compaction requires sorted output",
+				segment.GetSegmentID())
+		}
+	}
+	return nil
+}
+
+// verifyCompactionStatslogs checks the statslogs of the compaction result exist
+// in the object storage with the reported sizes.
+func (m *meta) verifyCompactionStatslogs(ctx context.Context, t *datapb.CompactionTask, result *datapb.CompactionPlanResult) error {
+	for _, segment := range result.GetSegments() {
+		for _, fieldBinlog := range segment.GetField2StatslogPaths() {
+			for _, b := range fieldBinlog.GetBinlogs() {
+				logPath := b.GetLogPath()
+				if logPath == "" {
+					var err error
+					logPath, err = binlog.BuildLogPath(storage.StatsBinlog, t.GetCollectionID(), t.GetPartitionID(),
+						segment.GetSegmentID(), fieldBinlog.GetFieldID(), b.GetLogID())
+					if err != nil {
+						return err
+					}
+				}
+				size, err := m.chunkManager.Size(ctx, logPath)
+				if err != nil {
+					return merr.WrapErrIllegalCompactionPlanMsg("statslog %s of segment %d is not readable: %s",
+						logPath, segment.GetSegmentID(), err.Error())
+				}
+				if b.GetLogSize() > 0 && size != b.GetLogSize() {
+					return merr.WrapErrIllegalCompactionPlanMsg("statslog %s of segment %d has size %d, reported %d",
+						logPath, segment.GetSegmentID(), size, b.GetLogSize())
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestVerifyCompactionResult(t *testing.T) {
+	segments := NewSegmentsInfo()
+	for _, id := range []int64{1, 2} {
+		segments.SetSegment(id, NewSegmentInfo(&datapb.SegmentInfo{
+			ID:        id,
+			State:     commonpb.SegmentState_Flushed,
+			NumOfRows: 100,
+		}))
+	}
+	m := &meta{segments: segments}
+	task := &datapb.CompactionTask{PlanID: 1, InputSegments: []int64{1, 2}}
+
+	newResult := func(numOfRows int64, insertLogs ...*datapb.FieldBinlog) *datapb.CompactionPlanResult {
+		return &datapb.CompactionPlanResult{Segments: []*datapb.CompactionSegment{{
+			SegmentID:  3,
+			NumOfRows:  numOfRows,
+			InsertLogs: insertLogs,
+		}}}
+	}
+
+	t.Run("consistent", func(t *testing.T) {
+		result := newResult(150, getFieldBinlogIDsWithEntry(100, 150, 1), getFieldBinlogIDsWithEntry(101, 150, 2))
+		assert.NoError(t, m.verifyCompactionResult(task, result))
+		assert.EqualValues(t, 150, result.GetSegments()[0].GetNumOfRows())
+	})
+
+	t.Run("unknown entries num", func(t *testing.T) {
+		result := newResult(150, getFieldBinlogIDs(100, 1))
+		assert.NoError(t, m.verifyCompactionResult(task, result))
+		assert.EqualValues(t, 150, result.GetSegments()[0].GetNumOfRows())
+	})
+
+	t.Run("repair num of rows", func(t *testing.T) {
+		result := newResult(120, getFieldBinlogIDsWithEntry(100, 150, 1), getFieldBinlogIDsWithEntry(101, 150, 2))
+		assert.NoError(t, m.verifyCompactionResult(task, result))
+		assert.EqualValues(t, 150, result.GetSegments()[0].GetNumOfRows())
+	})
+
+	t.Run("inconsistent fields", func(t *testing.T) {
+		result := newResult(150, getFieldBinlogIDsWithEntry(100, 150, 1), getFieldBinlogIDsWithEntry(101, 140, 2))
+		assert.ErrorIs(t, m.verifyCompactionResult(task, result), merr.ErrIllegalCompactionPlan)
+	})
+
+	t.Run("negative num of rows", func(t *testing.T) {
+		assert.ErrorIs(t, m.verifyCompactionResult(task, newResult(-1)), merr.ErrIllegalCompactionPlan)
+	})
+
+	t.Run("more rows than input", func(t *testing.T) {
+		assert.ErrorIs(t, m.verifyCompactionResult(task, newResult(201)), merr.ErrIllegalCompactionPlan)
+	})
+}
+
+func TestVerifyCompactionSortedOutput(t *testing.T) {
+	result := &datapb.CompactionPlanResult{Segments: []*datapb.CompactionSegment{
+		{SegmentID: 3, NumOfRows: 100, IsSorted: true},
+		{SegmentID: 4, NumOfRows: 100, IsSortedByNamespace: true},
+		{SegmentID: 5, NumOfRows: 0},
+	}}
+	assert.NoError(t, verifyCompactionSortedOutput(&datapb.CompactionTask{PlanID: 1, RequireSortedOutput: true}, result))
+
+	result.Segments = append(result.Segments, &datapb.CompactionSegment{SegmentID: 6, NumOfRows: 100})
+	assert.NoError(t, verifyCompactionSortedOutput(&datapb.CompactionTask{PlanID: 1}, result))
+	assert.ErrorIs(t, verifyCompactionSortedOutput(&datapb.CompactionTask{PlanID: 1, RequireSortedOutput: true}, result),
+		merr.ErrIllegalCompactionPlan)
+}
+
+func TestVerifyCompactionStatslogs(t *testing.T) {
+	ctx := context.Background()
+	task := &datapb.CompactionTask{PlanID: 1, CollectionID: 10, PartitionID: 20}
+	result := &datapb.CompactionPlanResult{Segments: []*datapb.CompactionSegment{{
+		SegmentID: 3,
+		Field2StatslogPaths: []*datapb.FieldBinlog{{
+			FieldID: 100,
+			Binlogs: []*datapb.Binlog{{LogID: 1, LogSize: 1024}},
+		}},
+	}}}
+
+	t.Run("normal", func(t *testing.T) {
+		cm := mocks.NewChunkManager(t)
+		cm.EXPECT().Size(mock.Anything, mock.Anything).Return(1024, nil).Once()
+		m := &meta{chunkManager: cm}
+		assert.NoError(t, m.verifyCompactionStatslogs(ctx, task, result))
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		cm := mocks.NewChunkManager(t)
+		cm.EXPECT().Size(mock.Anything, mock.Anything).Return(512, nil).Once()
+		m := &meta{chunkManager: cm}
+		assert.ErrorIs(t, m.verifyCompactionStatslogs(ctx, task, result), merr.ErrIllegalCompactionPlan)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cm := mocks.NewChunkManager(t)
+		cm.EXPECT().Size(mock.Anything, mock.Anything).Return(0, errors.New("mock")).Once()
+		m := &meta{chunkManager: cm}
+		assert.ErrorIs(t, m.verifyCompactionStatslogs(ctx, task, result), merr.ErrIllegalCompactionPlan)
+	})
+}
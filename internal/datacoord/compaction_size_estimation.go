@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"math"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// compactionSizeEstimationHeadroom leaves some room for the estimation error when spreading the result size
+// over the result segments, so that an underestimation doesn't produce an extra tiny segment.
+const compactionSizeEstimationHeadroom = 1.1
+
+// estimateRowSize returns the average row size of the segments as the sum of the per field averages
+// of the binlog memory sizes. The variable length fields are sized by the actual data written,
+// and the fields only present in part of the segments, e.g. added by schema change, are not diluted
+// by the rows of the segments without them.
+// It returns 0 if the segments have no binlog metadata, e.g. the segments with manifest.
+func estimateRowSize(segments []*SegmentInfo) float64 {
+	fieldSizes := make(map[int64]int64)
+	fieldRows := make(map[int64]int64)
+	for _, segment := range segments {
+		for _, fieldBinlog := range segment.GetBinlogs() {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				fieldSizes[fieldBinlog.GetFieldID()] += binlog.GetMemorySize()
+				fieldRows[fieldBinlog.GetFieldID()] += binlog.GetEntriesNum()
+			}
+		}
+	}
+
+	var rowSize float64
+	for fieldID, size := range fieldSizes {
+		if rows := fieldRows[fieldID]; rows > 0 && size > 0 {
+			rowSize += float64(size) / float64(rows)
+		}
+	}
+	return rowSize
+}
+
+// estimateCompactionOutputSize estimates the size of the compaction result by the rows surviving the deletions.
+func estimateCompactionOutputSize(segments []*SegmentInfo) float64 {
+	rowSize := estimateRowSize(segments)
+	var outputSize float64
+	for _, segment := range segments {
+		stats := segment.EnsureStats()
+		numRows := segment.GetNumOfRows()
+		if numRows <= 0 {
+			continue
+		}
+		liveRows := max(numRows-stats.GetDeleteNumRows(), 0)
+		if rowSize > 0 {
+			outputSize += rowSize * float64(liveRows)
+		} else {
+			outputSize += float64(stats.GetInsertBinlogSize()) * float64(liveRows) / float64(numRows)
+		}
+	}
+	return outputSize
+}
+
+// estimateCompactionMaxSize returns the max size of the result segments, it spreads the estimated result size
+// evenly over the result segments, so that the compaction doesn't produce full segments plus a tiny one.
+// It's never larger than the expected segment size.
+func estimateCompactionMaxSize(segments []*SegmentInfo, expectedSize int64) (outputSize float64, maxSize int64) {
+	outputSize = estimateCompactionOutputSize(segments)
+	if !paramtable.Get().DataCoordCfg.CompactionSizeEstimationEnabled.GetAsBool() || outputSize <= 0 {
+		return outputSize, expectedSize
+	}
+	count := estimateResultSegmentCount(outputSize, float64(expectedSize))
+	if count <= 1 {
+		return outputSize, expectedSize
+	}
+	balancedSize := int64(math.Ceil(outputSize / float64(count) * compactionSizeEstimationHeadroom))
+	return outputSize, min(balancedSize, expectedSize)
+}
@@ -0,0 +1,101 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func newSizeEstimationTestSegment(segmentID, numRows, deleteRows int64, fieldSizes map[int64]int64) *SegmentInfo {
+	binlogs := make([]*datapb.FieldBinlog, 0, len(fieldSizes))
+	for fieldID, size := range fieldSizes {
+		binlogs = append(binlogs, &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []*datapb.Binlog{{EntriesNum: numRows, MemorySize: size}},
+		})
+	}
+	var deltalogs []*datapb.FieldBinlog
+	if deleteRows > 0 {
+		deltalogs = []*datapb.FieldBinlog{{Binlogs: []*datapb.Binlog{{EntriesNum: deleteRows, MemorySize: deleteRows}}}}
+	}
+	return NewSegmentInfo(&datapb.SegmentInfo{
+		ID:        segmentID,
+		NumOfRows: numRows,
+		Binlogs:   binlogs,
+		Deltalogs: deltalogs,
+	})
+}
+
+func TestEstimateRowSize(t *testing.T) {
+	// the varchar field 101 is only in the segment 2, it's not diluted by the rows of segment 1
+	segments := []*SegmentInfo{
+		newSizeEstimationTestSegment(1, 100, 0, map[int64]int64{100: 800}),
+		newSizeEstimationTestSegment(2, 300, 0, map[int64]int64{100: 2400, 101: 30000}),
+	}
+	assert.InDelta(t, 8+100, estimateRowSize(segments), 1e-6)
+
+	assert.Zero(t, estimateRowSize([]*SegmentInfo{NewSegmentInfo(&datapb.SegmentInfo{ID: 3, NumOfRows: 100})}))
+}
+
+func TestEstimateCompactionOutputSize(t *testing.T) {
+	segments := []*SegmentInfo{
+		newSizeEstimationTestSegment(1, 100, 50, map[int64]int64{100: 1000}),
+		newSizeEstimationTestSegment(2, 100, 200, map[int64]int64{100: 1000}),
+		newSizeEstimationTestSegment(3, 0, 0, nil),
+	}
+	assert.InDelta(t, 500, estimateCompactionOutputSize(segments), 1e-6)
+
+	// fallback to the insert binlog size without binlog metadata
+	noBinlog := NewSegmentInfo(&datapb.SegmentInfo{
+		ID:        4,
+		NumOfRows: 100,
+		Stats:     &datapb.Statistics{InsertBinlogSize: 1000, DeleteNumRows: 20},
+	})
+	assert.InDelta(t, 800, estimateCompactionOutputSize([]*SegmentInfo{noBinlog}), 1e-6)
+}
+
+func TestEstimateCompactionMaxSize(t *testing.T) {
+	expectedSize := int64(1000)
+	segments := []*SegmentInfo{
+		newSizeEstimationTestSegment(1, 100, 0, map[int64]int64{100: 1000}),
+		newSizeEstimationTestSegment(2, 50, 0, map[int64]int64{100: 500}),
+	}
+
+	outputSize, maxSize := estimateCompactionMaxSize(segments, expectedSize)
+	assert.InDelta(t, 1500, outputSize, 1e-6)
+	assert.Equal(t, int64(825), maxSize)
+
+	// single result segment keeps the expected size
+	_, maxSize = estimateCompactionMaxSize(segments[:1], expectedSize)
+	assert.Equal(t, expectedSize, maxSize)
+
+	// the headroom never exceeds the expected size
+	_, maxSize = estimateCompactionMaxSize([]*SegmentInfo{
+		newSizeEstimationTestSegment(1, 100, 0, map[int64]int64{100: 1990}),
+	}, expectedSize)
+	assert.Equal(t, expectedSize, maxSize)
+
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.CompactionSizeEstimationEnabled.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.CompactionSizeEstimationEnabled.Key)
+	_, maxSize = estimateCompactionMaxSize(segments, expectedSize)
+	assert.Equal(t, expectedSize, maxSize)
+}
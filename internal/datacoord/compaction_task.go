@@ -17,8 +17,18 @@
 package datacoord
 
 import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
 	"github.com/milvus-io/milvus/internal/datacoord/task"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
 type CompactionTask interface {
@@ -34,6 +44,8 @@ type CompactionTask interface {
 	Process() bool
 	// Clean performs clean logic for a fail/timeout task
 	Clean() bool
+	// GetCleanState returns when the task started to be cleaned, the failed clean attempts and the last error.
+	GetCleanState() (time.Time, int, error)
 	BuildCompactionRequest() (*datapb.CompactionPlan, error)
 	GetSlotUsage() int64
 	GetLabel() string
@@ -108,3 +120,78 @@ func setAnalyzeTaskID(id int64) compactionTaskOpt {
 		task.AnalyzeTaskID = id
 	}
 }
+
+// workerRetryTracker records the workers that a compaction task failed on. The task is
+// re-scheduled onto the other workers with the same plan until the retry times are exhausted,
+// so that a restarting worker does not waste the whole compaction.
+type workerRetryTracker struct {
+	mu          sync.Mutex
+	retries     int
+	failedNodes []int64
+}
+
+var _ task.NodeExcluder = (*workerRetryTracker)(nil)
+
+// ExcludedNodes returns the workers that the task failed on.
+func (r *workerRetryTracker) ExcludedNodes() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.failedNodes)
+}
+
+// isWorkerLost returns whether the error means the worker of the task is gone,
+// either removed from the cluster or restarted with another server id.
+func isWorkerLost(err error) bool {
+	return errors.Is(err, merr.ErrNodeNotFound) || errors.Is(err, merr.ErrNodeNotMatch)
+}
+
+// onWorkerFailure records the failure of the task on the worker, and returns the options to
+// put the task back to pipelining, or to fail the task if the retry times are exhausted.
+// Only the lost workers are counted, the task is put back to pipelining on the transient errors.
+func (r *workerRetryTracker) onWorkerFailure(nodeID int64, err error) []compactionTaskOpt {
+	if !isWorkerLost(err) {
+		return []compactionTaskOpt{setState(datapb.CompactionTaskState_pipelining), setNodeID(NullNodeID)}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if nodeID != NullNodeID && !lo.Contains(r.failedNodes, nodeID) {
+		r.failedNodes = append(r.failedNodes, nodeID)
+	}
+	r.retries++
+	if maxRetries := paramtable.Get().DataCoordCfg.CompactionWorkerRetryTimes.GetAsInt(); r.retries > maxRetries {
+		return []compactionTaskOpt{
+			setState(datapb.CompactionTaskState_failed),
+			setFailReason(fmt.Sprintf("compaction task failed on workers %v after %d retries, last error: %v", r.failedNodes, maxRetries, err)),
+		}
+	}
+	return []compactionTaskOpt{setState(datapb.CompactionTaskState_pipelining), setNodeID(NullNodeID)}
+}
+
+// cleanTracker records the clean attempts of a compaction task, the task keeps in the cleaning tasks
+// of the inspector and is cleaned again until the clean succeeds.
+type cleanTracker struct {
+	cleanMu       sync.Mutex
+	cleanStart    time.Time
+	cleanFailures int
+	lastCleanErr  error
+}
+
+// onClean records the result of a clean attempt, and returns whether the task is cleaned.
+func (r *cleanTracker) onClean(err error) bool {
+	r.cleanMu.Lock()
+	defer r.cleanMu.Unlock()
+	if r.cleanStart.IsZero() {
+		r.cleanStart = time.Now()
+	}
+	r.lastCleanErr = err
+	if err != nil {
+		r.cleanFailures++
+	}
+	return err == nil
+}
+
+func (r *cleanTracker) GetCleanState() (time.Time, int, error) {
+	r.cleanMu.Lock()
+	defer r.cleanMu.Unlock()
+	return r.cleanStart, r.cleanFailures, r.lastCleanErr
+}
@@ -45,6 +45,8 @@ type bumpSchemaVersionTask struct {
 	meta      CompactionMeta
 	ievm      IndexEngineVersionManager
 	times     *taskcommon.Times
+	workerRetryTracker
+	cleanTracker
 }
 
 func newBumpSchemaVersionTask(t *datapb.CompactionTask, allocator allocator.Allocator, meta CompactionMeta, ievm IndexEngineVersionManager) *bumpSchemaVersionTask {
@@ -78,6 +80,14 @@ func (t *bumpSchemaVersionTask) GetTaskSlot() int64 {
 	return paramtable.Get().DataCoordCfg.BumpSchemaVersionCompactionSlotUsage.GetAsInt64()
 }
 
+func (t *bumpSchemaVersionTask) GetIOBudget() (int64, float64) {
+	return t.GetTaskProto().GetCollectionID(), compactionTaskIOBudget()
+}
+
+func (t *bumpSchemaVersionTask) GetNodeConcurrencyLimit() (string, int) {
+	return compactionNodeConcurrencyLimit(t.GetTaskProto().GetType())
+}
+
 func (t *bumpSchemaVersionTask) SetTaskTime(timeType taskcommon.TimeType, time time.Time) {
 	t.times.SetTaskTime(timeType, time)
 }
@@ -112,6 +122,7 @@ func (t *bumpSchemaVersionTask) BuildCompactionRequest() (*datapb.CompactionPlan
 		MaxSize:                   taskProto.GetMaxSize(),
 		JsonParams:                compactionParams,
 		CurrentScalarIndexVersion: t.ievm.GetCurrentScalarIndexEngineVersion(),
+		IoBudgetMb:                compactionTaskIOBudget(),
 	}
 	segments := make([]*SegmentInfo, 0, len(taskProto.GetInputSegments()))
 	for _, segID := range taskProto.GetInputSegments() {
@@ -184,7 +195,7 @@ func (t *bumpSchemaVersionTask) SaveTaskMeta() error {
 }
 
 func (t *bumpSchemaVersionTask) Clean() bool {
-	return t.doClean() == nil
+	return t.onClean(t.doClean())
 }
 
 func (t *bumpSchemaVersionTask) doClean() error {
@@ -258,7 +269,7 @@ func (t *bumpSchemaVersionTask) QueryTaskOnWorker(cluster session.Cluster) {
 	})
 	if err != nil || result == nil {
 		if errors.Is(err, merr.ErrNodeNotFound) {
-			if err := t.updateAndSaveTaskMeta(setState(datapb.CompactionTaskState_pipelining), setNodeID(NullNodeID)); err != nil {
+			if err := t.updateAndSaveTaskMeta(t.onWorkerFailure(t.GetTaskProto().GetNodeID(), err)...); err != nil {
 				log.Warn(context.TODO(), "bumpSchemaVersionTask failed to updateAndSaveTaskMeta", mlog.Err(err))
 			}
 		}
@@ -39,6 +39,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	taskcommon "github.com/milvus-io/milvus/pkg/v3/taskcommon"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
 func TestBumpSchemaVersionCompactionTaskSuite(t *testing.T) {
@@ -182,11 +183,18 @@ func (s *BumpSchemaVersionCompactionTaskSuite) TestBuildCompactionRequest() {
 	s.NoError(err)
 
 	task := s.generateBasicTask()
+	pt := paramtable.Get()
+	pt.Save(pt.DataCoordCfg.CompactionTaskIOBudget.Key, "64")
+	defer pt.Reset(pt.DataCoordCfg.CompactionTaskIOBudget.Key)
 
 	// Build compaction request
 	plan, err := task.BuildCompactionRequest()
 	s.NoError(err)
 	s.NotNil(plan)
+	s.Equal(float64(64), plan.GetIoBudgetMb())
+	collectionID, budget := task.GetIOBudget()
+	s.Equal(int64(1), collectionID)
+	s.Equal(float64(64), budget)
 
 	// Verify plan
 	s.Equal(int64(1), plan.GetPlanID())
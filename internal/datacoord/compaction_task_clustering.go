@@ -63,6 +63,8 @@ type clusteringCompactionTask struct {
 	maxRetryTimes int32
 
 	times *taskcommon.Times
+	workerRetryTracker
+	cleanTracker
 }
 
 func (t *clusteringCompactionTask) GetTaskID() int64 {
@@ -81,6 +83,14 @@ func (t *clusteringCompactionTask) GetTaskSlot() int64 {
 	return paramtable.Get().DataCoordCfg.ClusteringCompactionSlotUsage.GetAsInt64()
 }
 
+func (t *clusteringCompactionTask) GetIOBudget() (int64, float64) {
+	return t.GetTaskProto().GetCollectionID(), compactionTaskIOBudget()
+}
+
+func (t *clusteringCompactionTask) GetNodeConcurrencyLimit() (string, int) {
+	return compactionNodeConcurrencyLimit(t.GetTaskProto().GetType())
+}
+
 func (t *clusteringCompactionTask) SetTaskTime(timeType taskcommon.TimeType, time time.Time) {
 	t.times.SetTaskTime(timeType, time)
 }
@@ -153,7 +163,7 @@ func (t *clusteringCompactionTask) QueryTaskOnWorker(cluster session.Cluster) {
 	})
 	if err != nil || result == nil {
 		mlog.Warn(context.TODO(), "clusteringCompactionTask failed to get compaction result", mlog.Err(err))
-		err = t.updateAndSaveTaskMeta(setState(datapb.CompactionTaskState_pipelining), setNodeID(NullNodeID))
+		err = t.updateAndSaveTaskMeta(t.onWorkerFailure(t.GetTaskProto().GetNodeID(), err)...)
 		if err != nil {
 			mlog.Warn(context.TODO(), "update clustering compaction task meta failed", mlog.Err(err))
 		}
@@ -188,6 +198,16 @@ func (t *clusteringCompactionTask) QueryTaskOnWorker(cluster session.Cluster) {
 			return
 		}
 
+		if getClusteringCompactionCommitBatchSize(len(resultSegmentIDs)) > 0 {
+			// the result segments committed in batches are recorded in advance,
+			// so that they are dropped by clean if the task fails before all of them are committed.
+			err = t.updateAndSaveTaskMeta(setTmpSegments(resultSegmentIDs))
+			if err != nil {
+				mlog.Warn(context.TODO(), "update clustering compaction task meta failed", mlog.Err(err))
+				return
+			}
+		}
+
 		var metricMutation *segMetricMutation
 		_, metricMutation, err = t.meta.CompleteCompactionMutation(context.TODO(), t.GetTaskProto(), t.result)
 		if err != nil {
@@ -333,7 +353,7 @@ func (t *clusteringCompactionTask) retryableProcess(ctx context.Context) error {
 
 func (t *clusteringCompactionTask) Clean() bool {
 	mlog.Info(context.TODO(), "clean task", mlog.Int64("planID", t.GetTaskProto().GetPlanID()), mlog.String("type", t.GetTaskProto().GetType().String()))
-	return t.doClean() == nil
+	return t.onClean(t.doClean())
 }
 
 func (t *clusteringCompactionTask) BuildCompactionRequest() (*datapb.CompactionPlan, error) {
@@ -366,6 +386,7 @@ func (t *clusteringCompactionTask) BuildCompactionRequest() (*datapb.CompactionP
 		MaxSize:                   taskProto.GetMaxSize(),
 		JsonParams:                compactionParams,
 		CurrentScalarIndexVersion: t.ievm.ResolveScalarIndexVersion(),
+		IoBudgetMb:                compactionTaskIOBudget(),
 	}
 
 	// set analyzer resource for text match index if use ref mode.
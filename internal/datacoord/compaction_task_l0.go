@@ -48,6 +48,8 @@ type l0CompactionTask struct {
 
 	times                *taskcommon.Times
 	committedV3Manifests map[int64]string
+	workerRetryTracker
+	cleanTracker
 }
 
 func (t *l0CompactionTask) GetTaskID() int64 {
@@ -72,6 +74,14 @@ func (t *l0CompactionTask) GetTaskSlot() int64 {
 	return slot
 }
 
+func (t *l0CompactionTask) GetIOBudget() (int64, float64) {
+	return t.GetTaskProto().GetCollectionID(), compactionTaskIOBudget()
+}
+
+func (t *l0CompactionTask) GetNodeConcurrencyLimit() (string, int) {
+	return compactionNodeConcurrencyLimit(t.GetTaskProto().GetType())
+}
+
 func (t *l0CompactionTask) SetTaskTime(timeType taskcommon.TimeType, time time.Time) {
 	t.times.SetTaskTime(timeType, time)
 }
@@ -152,7 +162,7 @@ func (t *l0CompactionTask) QueryTaskOnWorker(cluster session.Cluster) {
 	})
 	if err != nil || result == nil {
 		log.Warn(context.TODO(), "l0CompactionTask failed to get compaction result", mlog.Err(err))
-		err = t.updateAndSaveTaskMeta(setState(datapb.CompactionTaskState_pipelining), setNodeID(NullNodeID))
+		err = t.updateAndSaveTaskMeta(t.onWorkerFailure(t.GetTaskProto().GetNodeID(), err)...)
 		if err != nil {
 			log.Warn(context.TODO(), "update l0 compaction task meta failed", mlog.Err(err))
 		}
@@ -278,7 +288,7 @@ func (t *l0CompactionTask) doClean() error {
 }
 
 func (t *l0CompactionTask) Clean() bool {
-	return t.doClean() == nil
+	return t.onClean(t.doClean())
 }
 
 func (t *l0CompactionTask) SetTask(task *datapb.CompactionTask) {
@@ -354,6 +364,7 @@ func (t *l0CompactionTask) BuildCompactionRequest() (*datapb.CompactionPlan, err
 		Schema:        taskProto.GetSchema(),
 		SlotUsage:     t.GetSlotUsage(),
 		JsonParams:    compactionParams,
+		IoBudgetMb:    compactionTaskIOBudget(),
 	}
 
 	log := mlog.With(mlog.FieldTaskID(taskProto.GetTriggerID()), mlog.Int64("planID", plan.GetPlanID()))
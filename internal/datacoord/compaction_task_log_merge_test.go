@@ -0,0 +1,315 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/datacoord/allocator"
+	"github.com/milvus-io/milvus/internal/datacoord/broker"
+	"github.com/milvus-io/milvus/internal/datacoord/session"
+	"github.com/milvus-io/milvus/internal/metastore/kv/datacoord"
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestLogMergeCompactionTaskSuite(t *testing.T) {
+	suite.Run(t, new(LogMergeCompactionTaskSuite))
+}
+
+type LogMergeCompactionTaskSuite struct {
+	suite.Suite
+
+	mockID    atomic.Int64
+	mockAlloc *allocator.MockAllocator
+	meta      *meta
+}
+
+func (s *LogMergeCompactionTaskSuite) SetupTest() {
+	ctx := context.Background()
+	cm := storage.NewLocalChunkManager(objectstorage.RootPath(""))
+	catalog := datacoord.NewCatalog(NewMetaMemoryKV(), "", "")
+	broker := broker.NewMockBroker(s.T())
+	broker.EXPECT().ShowCollectionIDs(mock.Anything).Return(nil, nil)
+	meta, err := newMeta(ctx, catalog, cm, broker)
+	s.NoError(err)
+	s.meta = meta
+
+	s.mockID.Store(time.Now().UnixMilli())
+	s.mockAlloc = allocator.NewMockAllocator(s.T())
+	s.mockAlloc.EXPECT().AllocN(mock.Anything).RunAndReturn(func(x int64) (int64, int64, error) {
+		start := s.mockID.Load()
+		end := s.mockID.Add(x)
+		return start, end, nil
+	}).Maybe()
+}
+
+func (s *LogMergeCompactionTaskSuite) SetupSubTest() {
+	s.SetupTest()
+}
+
+func (s *LogMergeCompactionTaskSuite) addSegment(segmentID int64) {
+	err := s.meta.AddSegment(context.TODO(), &SegmentInfo{
+		SegmentInfo: &datapb.SegmentInfo{
+			ID:            segmentID,
+			CollectionID:  1,
+			PartitionID:   10,
+			InsertChannel: "ch-1",
+			Level:         datapb.SegmentLevel_L1,
+			State:         commonpb.SegmentState_Flushed,
+			NumOfRows:     1000,
+			Binlogs:       []*datapb.FieldBinlog{getFieldBinlogIDsWithEntry(100, 1000, 1)},
+			Bm25Statslogs: []*datapb.FieldBinlog{getFieldBinlogIDs(102, 2)},
+			Statslogs:     []*datapb.FieldBinlog{getFieldBinlogIDs(100, 3, 4)},
+			Deltalogs:     []*datapb.FieldBinlog{getFieldBinlogIDsWithEntry(0, 1, 5, 6)},
+		},
+		isCompacting: true,
+	})
+	s.NoError(err)
+}
+
+func (s *LogMergeCompactionTaskSuite) generateBasicTask() *logMergeTask {
+	schema := &schemapb.CollectionSchema{
+		Name: "test_log_merge_collection",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+		},
+	}
+	return newLogMergeTask(&datapb.CompactionTask{
+		PlanID:        1,
+		TriggerID:     19530,
+		CollectionID:  1,
+		PartitionID:   10,
+		Type:          datapb.CompactionType_LogMergeCompaction,
+		NodeID:        1,
+		State:         datapb.CompactionTaskState_pipelining,
+		Schema:        schema,
+		InputSegments: []int64{101},
+		Channel:       "ch-1",
+	}, s.mockAlloc, s.meta)
+}
+
+func (s *LogMergeCompactionTaskSuite) completedResult() *datapb.CompactionPlanResult {
+	return &datapb.CompactionPlanResult{
+		PlanID: 1,
+		State:  datapb.CompactionTaskState_completed,
+		Type:   datapb.CompactionType_LogMergeCompaction,
+		Segments: []*datapb.CompactionSegment{{
+			SegmentID:           101,
+			Deltalogs:           []*datapb.FieldBinlog{getFieldBinlogIDsWithEntry(0, 2, 7)},
+			Field2StatslogPaths: []*datapb.FieldBinlog{getFieldBinlogIDs(100, int64(storage.CompoundStatsType))},
+		}},
+	}
+}
+
+func (s *LogMergeCompactionTaskSuite) TestBuildCompactionRequest() {
+	s.Run("normal", func() {
+		s.addSegment(101)
+		task := s.generateBasicTask()
+		plan, err := task.BuildCompactionRequest()
+		s.NoError(err)
+		s.Equal(datapb.CompactionType_LogMergeCompaction, plan.GetType())
+		s.Require().Len(plan.GetSegmentBinlogs(), 1)
+		s.Len(plan.GetSegmentBinlogs()[0].GetDeltalogs()[0].GetBinlogs(), 2)
+		s.NotNil(plan.GetPreAllocatedLogIDs())
+	})
+
+	s.Run("segment not found", func() {
+		task := s.generateBasicTask()
+		_, err := task.BuildCompactionRequest()
+		s.ErrorIs(err, merr.ErrSegmentNotFound)
+	})
+
+	s.Run("segment with manifest", func() {
+		s.addSegment(101)
+		s.meta.segments.GetSegment(101).ManifestPath = "manifest"
+		task := s.generateBasicTask()
+		_, err := task.BuildCompactionRequest()
+		s.ErrorIs(err, merr.ErrIllegalCompactionPlan)
+	})
+}
+
+func (s *LogMergeCompactionTaskSuite) TestCreateTaskOnWorker() {
+	s.Run("build request failed", func() {
+		task := s.generateBasicTask()
+		cluster := session.NewMockCluster(s.T())
+		task.CreateTaskOnWorker(1, cluster)
+		s.Equal(datapb.CompactionTaskState_failed, task.GetTaskProto().GetState())
+	})
+
+	s.Run("create compaction failed", func() {
+		s.addSegment(101)
+		task := s.generateBasicTask()
+		cluster := session.NewMockCluster(s.T())
+		cluster.EXPECT().CreateCompaction(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("mock")).Once()
+		task.CreateTaskOnWorker(1, cluster)
+		s.Equal(datapb.CompactionTaskState_pipelining, task.GetTaskProto().GetState())
+		s.Equal(int64(NullNodeID), task.GetTaskProto().GetNodeID())
+		s.True(task.NeedReAssignNodeID())
+	})
+
+	s.Run("normal", func() {
+		s.addSegment(101)
+		task := s.generateBasicTask()
+		cluster := session.NewMockCluster(s.T())
+		cluster.EXPECT().CreateCompaction(int64(2), mock.Anything, mock.Anything).Return(nil).Once()
+		task.CreateTaskOnWorker(2, cluster)
+		s.Equal(datapb.CompactionTaskState_executing, task.GetTaskProto().GetState())
+		s.Equal(int64(2), task.GetTaskProto().GetNodeID())
+	})
+}
+
+func (s *LogMergeCompactionTaskSuite) TestQueryTaskOnWorker() {
+	executingTask := func() *logMergeTask {
+		task := s.generateBasicTask()
+		task.SetTask(task.ShadowClone(setState(datapb.CompactionTaskState_executing), setNodeID(1)))
+		return task
+	}
+
+	s.Run("completed", func() {
+		s.addSegment(101)
+		task := executingTask()
+		cluster := session.NewMockCluster(s.T())
+		cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(s.completedResult(), nil).Once()
+		task.QueryTaskOnWorker(cluster)
+		// meta_saved moves on to completed directly.
+		s.Equal(datapb.CompactionTaskState_completed, task.GetTaskProto().GetState())
+		s.Equal([]int64{101}, task.GetTaskProto().GetResultSegments())
+
+		segment := s.meta.GetSegment(context.TODO(), 101)
+		s.Len(segment.GetDeltalogs()[0].GetBinlogs(), 1)
+		s.Len(segment.GetStatslogs()[0].GetBinlogs(), 1)
+		s.Len(segment.GetBinlogs()[0].GetBinlogs(), 1)
+		s.Len(segment.GetBm25Statslogs(), 1)
+	})
+
+	s.Run("completed with empty segments", func() {
+		task := executingTask()
+		cluster := session.NewMockCluster(s.T())
+		cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(&datapb.CompactionPlanResult{
+			State: datapb.CompactionTaskState_completed,
+		}, nil).Once()
+		task.QueryTaskOnWorker(cluster)
+		s.Equal(datapb.CompactionTaskState_failed, task.GetTaskProto().GetState())
+	})
+
+	s.Run("completed with mismatched segment", func() {
+		s.addSegment(101)
+		task := executingTask()
+		result := s.completedResult()
+		result.Segments[0].SegmentID = 102
+		cluster := session.NewMockCluster(s.T())
+		cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(result, nil).Once()
+		task.QueryTaskOnWorker(cluster)
+		s.Equal(datapb.CompactionTaskState_failed, task.GetTaskProto().GetState())
+		s.Len(s.meta.GetSegment(context.TODO(), 101).GetDeltalogs()[0].GetBinlogs(), 2)
+	})
+
+	s.Run("node not found", func() {
+		task := executingTask()
+		cluster := session.NewMockCluster(s.T())
+		cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(nil, merr.WrapErrNodeNotFound(1)).Once()
+		task.QueryTaskOnWorker(cluster)
+		s.Equal(datapb.CompactionTaskState_pipelining, task.GetTaskProto().GetState())
+		s.Equal(int64(NullNodeID), task.GetTaskProto().GetNodeID())
+	})
+
+	s.Run("executing", func() {
+		task := executingTask()
+		cluster := session.NewMockCluster(s.T())
+		cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(&datapb.CompactionPlanResult{
+			State: datapb.CompactionTaskState_executing,
+		}, nil).Once()
+		task.QueryTaskOnWorker(cluster)
+		s.Equal(datapb.CompactionTaskState_executing, task.GetTaskProto().GetState())
+	})
+
+	s.Run("timeout", func() {
+		task := executingTask()
+		cluster := session.NewMockCluster(s.T())
+		cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(&datapb.CompactionPlanResult{
+			State: datapb.CompactionTaskState_timeout,
+		}, nil).Once()
+		task.QueryTaskOnWorker(cluster)
+		s.Equal(datapb.CompactionTaskState_timeout, task.GetTaskProto().GetState())
+	})
+
+	s.Run("failed", func() {
+		task := executingTask()
+		cluster := session.NewMockCluster(s.T())
+		cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(&datapb.CompactionPlanResult{
+			State: datapb.CompactionTaskState_failed,
+		}, nil).Once()
+		task.QueryTaskOnWorker(cluster)
+		s.Equal(datapb.CompactionTaskState_failed, task.GetTaskProto().GetState())
+		s.Equal("compaction failed in datanode", task.GetTaskProto().GetFailReason())
+	})
+}
+
+func (s *LogMergeCompactionTaskSuite) TestProcess() {
+	for _, tc := range []struct {
+		state    datapb.CompactionTaskState
+		expected datapb.CompactionTaskState
+		done     bool
+	}{
+		{datapb.CompactionTaskState_meta_saved, datapb.CompactionTaskState_completed, true},
+		{datapb.CompactionTaskState_completed, datapb.CompactionTaskState_completed, true},
+		{datapb.CompactionTaskState_failed, datapb.CompactionTaskState_failed, true},
+		{datapb.CompactionTaskState_timeout, datapb.CompactionTaskState_timeout, true},
+		{datapb.CompactionTaskState_pipelining, datapb.CompactionTaskState_pipelining, false},
+		{datapb.CompactionTaskState_executing, datapb.CompactionTaskState_executing, false},
+	} {
+		task := s.generateBasicTask()
+		task.SetTask(task.ShadowClone(setState(tc.state)))
+		s.Equal(tc.done, task.Process(), "state %s", tc.state.String())
+		s.Equal(tc.expected, task.GetTaskProto().GetState())
+	}
+}
+
+func (s *LogMergeCompactionTaskSuite) TestClean() {
+	s.Run("normal", func() {
+		s.addSegment(101)
+		task := s.generateBasicTask()
+		task.SetTask(task.ShadowClone(setState(datapb.CompactionTaskState_completed)))
+		s.True(task.Clean())
+		s.Equal(datapb.CompactionTaskState_cleaned, task.GetTaskProto().GetState())
+		s.False(s.meta.GetSegment(context.TODO(), 101).isCompacting)
+	})
+
+	s.Run("save task failed", func() {
+		s.addSegment(101)
+		catalog := mocks.NewDataCoordCatalog(s.T())
+		catalog.EXPECT().SaveCompactionTask(mock.Anything, mock.Anything).Return(errors.New("mock"))
+		s.meta.compactionTaskMeta.catalog = catalog
+		task := s.generateBasicTask()
+		s.False(task.Clean())
+		// the segment stays locked until the task is cleaned.
+		s.True(s.meta.GetSegment(context.TODO(), 101).isCompacting)
+	})
+}
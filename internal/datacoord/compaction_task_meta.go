@@ -63,6 +63,8 @@ type compactionTaskMeta struct {
 	// currently only clustering compaction task is stored in persist meta
 	compactionTasks map[int64]map[int64]*datapb.CompactionTask // triggerID -> planID
 	taskStats       *expirable.LRU[UniqueID, *metricsinfo.CompactionTask]
+	// lastCompletedTimes is the end time in seconds of the last completed compaction producing segments of each partition.
+	lastCompletedTimes map[int64]int64
 }
 
 func newCompactionTaskMeta(ctx context.Context, catalog metastore.DataCoordCatalog) (*compactionTaskMeta, error) {
@@ -72,6 +74,8 @@ func newCompactionTaskMeta(ctx context.Context, catalog metastore.DataCoordCatal
 		catalog:         catalog,
 		compactionTasks: make(map[int64]map[int64]*datapb.CompactionTask, 0),
 		taskStats:       expirable.NewLRU[UniqueID, *metricsinfo.CompactionTask](512, nil, time.Minute*15),
+
+		lastCompletedTimes: make(map[int64]int64),
 	}
 	if err := csm.reloadFromKV(); err != nil {
 		return nil, err
@@ -178,6 +182,23 @@ func (csm *compactionTaskMeta) saveCompactionTaskMemory(task *datapb.CompactionT
 	}
 	csm.compactionTasks[task.TriggerID][task.PlanID] = task
 	csm.taskStats.Add(task.PlanID, newCompactionTaskStats(task))
+	if task.GetState() == datapb.CompactionTaskState_completed &&
+		task.GetType() != datapb.CompactionType_Level0DeleteCompaction &&
+		task.GetEndTime() > csm.lastCompletedTimes[task.GetPartitionID()] {
+		csm.lastCompletedTimes[task.GetPartitionID()] = task.GetEndTime()
+	}
+}
+
+// GetLastCompletedTime returns the end time of the last completed compaction producing segments of the partition,
+// it's zero if no such compaction is known.
+func (csm *compactionTaskMeta) GetLastCompletedTime(partitionID int64) time.Time {
+	csm.RLock()
+	defer csm.RUnlock()
+	endTime, ok := csm.lastCompletedTimes[partitionID]
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(endTime, 0)
 }
 
 func (csm *compactionTaskMeta) DropCompactionTask(ctx context.Context, task *datapb.CompactionTask) error {
@@ -34,6 +34,8 @@ type mixCompactionTask struct {
 	ievm IndexEngineVersionManager
 
 	times *taskcommon.Times
+	workerRetryTracker
+	cleanTracker
 
 	slotUsage atomic.Int64
 }
@@ -68,6 +70,14 @@ func (t *mixCompactionTask) GetTaskSlot() int64 {
 	return slotUsage
 }
 
+func (t *mixCompactionTask) GetIOBudget() (int64, float64) {
+	return t.GetTaskProto().GetCollectionID(), compactionTaskIOBudget()
+}
+
+func (t *mixCompactionTask) GetNodeConcurrencyLimit() (string, int) {
+	return compactionNodeConcurrencyLimit(t.GetTaskProto().GetType())
+}
+
 func (t *mixCompactionTask) SetTaskTime(timeType taskcommon.TimeType, time time.Time) {
 	t.times.SetTaskTime(timeType, time)
 }
@@ -123,7 +133,7 @@ func (t *mixCompactionTask) QueryTaskOnWorker(cluster session.Cluster) {
 	})
 	if err != nil || result == nil {
 		mlog.Warn(context.TODO(), "mixCompactionTask failed to get compaction result", mlog.Err(err))
-		if err := t.updateAndSaveTaskMeta(setState(datapb.CompactionTaskState_pipelining), setNodeID(NullNodeID)); err != nil {
+		if err := t.updateAndSaveTaskMeta(t.onWorkerFailure(t.GetTaskProto().GetNodeID(), err)...); err != nil {
 			mlog.Warn(context.TODO(), "mixCompactionTask failed to updateAndSaveTaskMeta", mlog.Err(err))
 		}
 		return
@@ -301,7 +311,7 @@ func (t *mixCompactionTask) processFailed() bool {
 }
 
 func (t *mixCompactionTask) Clean() bool {
-	return t.doClean() == nil
+	return t.onClean(t.doClean())
 }
 
 func (t *mixCompactionTask) doClean() error {
@@ -368,6 +378,8 @@ func (t *mixCompactionTask) BuildCompactionRequest() (*datapb.CompactionPlan, er
 		MaxSize:                   taskProto.GetMaxSize(),
 		JsonParams:                compactionParams,
 		CurrentScalarIndexVersion: t.ievm.ResolveScalarIndexVersion(),
+		IoBudgetMb:                compactionTaskIOBudget(),
+		RequireSortedOutput:       taskProto.GetRequireSortedOutput(),
 	}
 
 	// set analyzer resource for text match index if use ref mode.
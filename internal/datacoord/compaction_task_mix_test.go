@@ -302,3 +302,44 @@ func (s *MixCompactionTaskSuite) TestQueryTaskOnWorker() {
 
 	s.Equal(taskcommon.Retry, t1.GetTaskState())
 }
+
+func (s *MixCompactionTaskSuite) TestQueryTaskOnWorkerRetryOnAnotherNode() {
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.CompactionWorkerRetryTimes.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.CompactionWorkerRetryTimes.Key)
+
+	cluster := session.NewMockCluster(s.T())
+	t1 := newMixCompactionTask(&datapb.CompactionTask{
+		PlanID:                 1,
+		Type:                   datapb.CompactionType_MixCompaction,
+		StartTime:              time.Now().Unix(),
+		Channel:                "ch-1",
+		State:                  datapb.CompactionTaskState_executing,
+		NodeID:                 111,
+		PreAllocatedSegmentIDs: &datapb.IDRange{Begin: 100, End: 200},
+	}, nil, s.mockMeta, newMockVersionManager())
+
+	s.mockMeta.EXPECT().SaveCompactionTask(mock.Anything, mock.Anything).Return(nil)
+	// the transient errors are not counted.
+	for i := 0; i < 3; i++ {
+		s.NoError(t1.updateAndSaveTaskMeta(setState(datapb.CompactionTaskState_executing), setNodeID(111)))
+		cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(nil, merr.WrapErrServiceUnavailable("timeout")).Once()
+		t1.QueryTaskOnWorker(cluster)
+		s.Equal(datapb.CompactionTaskState_pipelining, t1.GetTaskProto().GetState())
+		s.Empty(t1.ExcludedNodes())
+	}
+
+	s.NoError(t1.updateAndSaveTaskMeta(setState(datapb.CompactionTaskState_executing), setNodeID(111)))
+	cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(nil, merr.WrapErrNodeNotFound(111)).Once()
+	t1.QueryTaskOnWorker(cluster)
+	s.Equal(datapb.CompactionTaskState_pipelining, t1.GetTaskProto().GetState())
+	s.EqualValues(NullNodeID, t1.GetTaskProto().GetNodeID())
+	s.Equal([]int64{111}, t1.ExcludedNodes())
+	s.EqualValues(100, t1.GetTaskProto().GetPreAllocatedSegmentIDs().GetBegin())
+
+	// the retry times are exhausted.
+	s.NoError(t1.updateAndSaveTaskMeta(setState(datapb.CompactionTaskState_executing), setNodeID(222)))
+	cluster.EXPECT().QueryCompaction(mock.Anything, mock.Anything).Return(nil, merr.WrapErrNodeNotMatch(222, 223)).Once()
+	t1.QueryTaskOnWorker(cluster)
+	s.Equal(datapb.CompactionTaskState_failed, t1.GetTaskProto().GetState())
+	s.Equal([]int64{111, 222}, t1.ExcludedNodes())
+}
@@ -0,0 +1,192 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// phases of the compaction task lifecycle.
+const (
+	compactionPhaseEnqueue      = "enqueue"
+	compactionPhaseSchedule     = "schedule"
+	compactionPhaseExecute      = "execute"
+	compactionPhaseAnalyze      = "analyze"
+	compactionPhaseMetaMutation = "metaMutation"
+	compactionPhaseCleanup      = "cleanup"
+)
+
+// compactionTriggerSpans keeps the span contexts of the recent trigger signals by triggerID,
+// the span of a compaction task links to the span of the trigger signal which generates it.
+var compactionTriggerSpans = expirable.NewLRU[int64, trace.SpanContext](4096, nil, time.Hour)
+
+// startCompactionTriggerSpan starts the span of a compaction trigger signal.
+func startCompactionTriggerSpan(ctx context.Context, triggerID int64, triggerType string) (context.Context, trace.Span) {
+	ctx, sp := otel.Tracer(typeutil.DataCoordRole).Start(ctx, "CompactionTrigger", trace.WithAttributes(
+		attribute.Int64("triggerID", triggerID),
+		attribute.String("triggerType", triggerType),
+	))
+	compactionTriggerSpans.Add(triggerID, sp.SpanContext())
+	return ctx, sp
+}
+
+type compactionTaskTrace struct {
+	root      trace.Span
+	phase     trace.Span
+	phaseName string
+}
+
+// compactionTracer records the lifecycle of the compaction tasks as spans,
+// one root span per task with a child span for each phase the task goes through.
+// A nil tracer records nothing.
+type compactionTracer struct {
+	mu     sync.Mutex
+	traces map[int64]*compactionTaskTrace // planID -> trace
+}
+
+func newCompactionTracer() *compactionTracer {
+	return &compactionTracer{
+		traces: make(map[int64]*compactionTaskTrace),
+	}
+}
+
+// start starts the root span of the task and enters the given phase.
+func (ct *compactionTracer) start(task *datapb.CompactionTask, phase string) {
+	if ct == nil {
+		return
+	}
+	opts := []trace.SpanStartOption{
+		trace.WithNewRoot(),
+		trace.WithAttributes(
+			attribute.Int64("planID", task.GetPlanID()),
+			attribute.Int64("triggerID", task.GetTriggerID()),
+			attribute.Int64("collectionID", task.GetCollectionID()),
+			attribute.String("channel", task.GetChannel()),
+			attribute.String("type", task.GetType().String()),
+		),
+	}
+	if triggerSpan, ok := compactionTriggerSpans.Get(task.GetTriggerID()); ok && triggerSpan.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: triggerSpan}))
+	}
+	_, root := otel.Tracer(typeutil.DataCoordRole).Start(context.Background(), "CompactionTask", opts...)
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if old, ok := ct.traces[task.GetPlanID()]; ok {
+		old.end()
+	}
+	t := &compactionTaskTrace{root: root}
+	t.enterPhase(phase)
+	ct.traces[task.GetPlanID()] = t
+}
+
+// enterPhase ends the current phase span of the task and starts a new one.
+func (ct *compactionTracer) enterPhase(task *datapb.CompactionTask, phase string) {
+	if ct == nil {
+		return
+	}
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if t, ok := ct.traces[task.GetPlanID()]; ok && t.phaseName != phase {
+		t.enterPhase(phase, attribute.Int64("nodeID", task.GetNodeID()))
+	}
+}
+
+// observe moves the task to the phase implied by its state.
+func (ct *compactionTracer) observe(task *datapb.CompactionTask) {
+	if ct == nil {
+		return
+	}
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	t, ok := ct.traces[task.GetPlanID()]
+	if !ok {
+		return
+	}
+	var phase string
+	switch task.GetState() {
+	case datapb.CompactionTaskState_pipelining:
+		if t.phaseName == compactionPhaseEnqueue {
+			return
+		}
+		phase = compactionPhaseSchedule
+	case datapb.CompactionTaskState_executing:
+		phase = compactionPhaseExecute
+	case datapb.CompactionTaskState_analyzing:
+		phase = compactionPhaseAnalyze
+	case datapb.CompactionTaskState_meta_saved, datapb.CompactionTaskState_statistic, datapb.CompactionTaskState_indexing:
+		phase = compactionPhaseMetaMutation
+	case datapb.CompactionTaskState_completed, datapb.CompactionTaskState_failed, datapb.CompactionTaskState_timeout:
+		phase = compactionPhaseCleanup
+	default:
+		return
+	}
+	if t.phaseName == phase {
+		return
+	}
+	if phase == compactionPhaseExecute {
+		// the worker has been notified and accepted the plan.
+		t.root.AddEvent("notify", trace.WithAttributes(attribute.Int64("nodeID", task.GetNodeID())))
+	}
+	t.enterPhase(phase, attribute.Int64("nodeID", task.GetNodeID()))
+}
+
+// finish ends all the spans of the task.
+func (ct *compactionTracer) finish(task *datapb.CompactionTask) {
+	if ct == nil {
+		return
+	}
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	t, ok := ct.traces[task.GetPlanID()]
+	if !ok {
+		return
+	}
+	delete(ct.traces, task.GetPlanID())
+	t.root.SetAttributes(attribute.String("state", task.GetState().String()))
+	if task.GetState() == datapb.CompactionTaskState_failed || task.GetState() == datapb.CompactionTaskState_timeout {
+		t.root.SetStatus(codes.Error, task.GetFailReason())
+	}
+	t.end()
+}
+
+func (t *compactionTaskTrace) enterPhase(phase string, attrs ...attribute.KeyValue) {
+	if t.phase != nil {
+		t.phase.End()
+	}
+	ctx := trace.ContextWithSpan(context.Background(), t.root)
+	_, t.phase = otel.Tracer(typeutil.DataCoordRole).Start(ctx, "CompactionTask-"+phase, trace.WithAttributes(attrs...))
+	t.phaseName = phase
+}
+
+func (t *compactionTaskTrace) end() {
+	if t.phase != nil {
+		t.phase.End()
+	}
+	t.root.End()
+}
@@ -0,0 +1,98 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+)
+
+func TestCompactionTracer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(prev)
+
+	_, triggerSpan := startCompactionTriggerSpan(context.Background(), 100, "Mix")
+	triggerSpan.End()
+
+	tracer := newCompactionTracer()
+	task := &datapb.CompactionTask{
+		PlanID:    1,
+		TriggerID: 100,
+		Type:      datapb.CompactionType_MixCompaction,
+		State:     datapb.CompactionTaskState_pipelining,
+	}
+	tracer.start(task, compactionPhaseEnqueue)
+	// still waiting in the queue.
+	tracer.observe(task)
+	tracer.enterPhase(task, compactionPhaseSchedule)
+	task.State = datapb.CompactionTaskState_executing
+	task.NodeID = 2
+	tracer.observe(task)
+	tracer.observe(task)
+	task.State = datapb.CompactionTaskState_meta_saved
+	tracer.observe(task)
+	task.State = datapb.CompactionTaskState_failed
+	task.FailReason = "mock failure"
+	tracer.observe(task)
+	tracer.finish(task)
+	// finish is idempotent.
+	tracer.finish(task)
+
+	spans := exporter.GetSpans()
+	names := lo.Map(spans, func(s tracetest.SpanStub, _ int) string { return s.Name })
+	assert.Equal(t, []string{
+		"CompactionTrigger",
+		"CompactionTask-enqueue",
+		"CompactionTask-schedule",
+		"CompactionTask-execute",
+		"CompactionTask-metaMutation",
+		"CompactionTask-cleanup",
+		"CompactionTask",
+	}, names)
+
+	root := spans[len(spans)-1]
+	assert.Equal(t, codes.Error, root.Status.Code)
+	assert.Equal(t, "mock failure", root.Status.Description)
+	assert.Len(t, root.Links, 1)
+	assert.Equal(t, spans[0].SpanContext.SpanID(), root.Links[0].SpanContext.SpanID())
+	assert.Len(t, root.Events, 1)
+	assert.Equal(t, "notify", root.Events[0].Name)
+	for _, s := range spans[1 : len(spans)-1] {
+		assert.Equal(t, root.SpanContext.SpanID(), s.Parent.SpanID())
+	}
+
+	// a nil tracer records nothing.
+	var nilTracer *compactionTracer
+	nilTracer.start(task, compactionPhaseEnqueue)
+	nilTracer.observe(task)
+	nilTracer.finish(task)
+}
@@ -64,6 +64,7 @@ type compactionSignal struct {
 	pos          *msgpb.MsgPosition
 	resultCh     chan error
 	waitResult   bool
+	isReindex    bool // compact each segment alone to rebuild it, bypassing the size heuristics
 }
 
 func NewCompactionSignal() *compactionSignal {
@@ -108,6 +109,11 @@ func (cs *compactionSignal) WithWaitResult(waitResult bool) *compactionSignal {
 	return cs
 }
 
+func (cs *compactionSignal) WithReindex(isReindex bool) *compactionSignal {
+	cs.isReindex = isReindex
+	return cs
+}
+
 func (cs *compactionSignal) Notify(result error) {
 	select {
 	case cs.resultCh <- result:
@@ -129,6 +135,8 @@ type compactionTrigger struct {
 	closeWaiter   sync.WaitGroup
 
 	indexEngineVersionManager IndexEngineVersionManager
+	// accessStats is the read statistics of the segments, the plans of the cold segments are preferred
+	accessStats *segmentAccessStats
 
 	// A sloopy hack, so we can test with different segment row count without worrying that
 	// they are re-calculated in every compaction.
@@ -246,8 +254,22 @@ func isCollectionAutoCompactionEnabled(coll *collectionInfo) bool {
 	return enabled
 }
 
-func getCompactTime(ts Timestamp, coll *collectionInfo) (*compactTime, error) {
-	collectionTTL, err := common.GetCollectionTTLFromMap(coll.Properties)
+// isCollectionInCompactionWindow returns whether the auto compaction of the collection is allowed at the time
+// by its maintenance windows. The invalid window is rejected when it's set, it never blocks the compaction.
+func isCollectionInCompactionWindow(coll *collectionInfo, now time.Time) bool {
+	inWindow, err := common.IsInCompactionWindow(coll.Properties, now)
+	if err != nil {
+		mlog.RatedWarn(context.TODO(), rate.Limit(1), "collection compaction window not valid, ignore it",
+			mlog.FieldCollectionID(coll.ID), mlog.Err(err))
+		return true
+	}
+	return inWindow
+}
+
+// getCompactTime returns the compact time of the partition, the partition ttl overrides the collection ttl
+// so the partitions of a collection may expire differently.
+func getCompactTime(ts Timestamp, coll *collectionInfo, partitionID int64) (*compactTime, error) {
+	collectionTTL, err := common.GetPartitionsTTLFromMap(coll.Properties, partitionID)
 	if err != nil {
 		return nil, err
 	}
@@ -350,6 +372,8 @@ func (t *compactionTrigger) handleSignal(signal *compactionSignal) error {
 		return nil
 	}
 
+	_, sp := startCompactionTriggerSpan(context.TODO(), signal.id, "Mix")
+	defer sp.End()
 	for _, group := range groups {
 		log := mlog.With(
 			mlog.Int64("group.partitionID", group.partitionID),
@@ -379,14 +403,25 @@ func (t *compactionTrigger) handleSignal(signal *compactionSignal) error {
 			return nil
 		}
 
-		ct, err := getCompactTime(tsoutil.ComposeTSByTime(time.Now()), coll)
+		if !signal.isForce && !isCollectionInCompactionWindow(coll, time.Now()) {
+			log.RatedInfo(context.TODO(), rate.Limit(20), "collection is out of compaction window, skip handling compaction",
+				mlog.FieldCollectionID(coll.ID))
+			continue
+		}
+
+		ct, err := getCompactTime(tsoutil.ComposeTSByTime(time.Now()), coll, group.partitionID)
 		if err != nil {
 			log.Warn(context.TODO(), "get compact time failed, skip to handle compaction")
 			return err
 		}
 
 		expectedSize := getExpectedSegmentSize(t.meta, coll.ID, coll.Schema)
-		plans := t.generatePlans(group.segments, signal, ct, expectedSize)
+		var plans []*typeutil.Pair[int64, []int64]
+		if signal.isReindex {
+			plans = t.generateReindexPlans(group.segments)
+		} else {
+			plans = t.generatePlans(group.segments, signal, ct, expectedSize)
+		}
 		for _, plan := range plans {
 			if !signal.isForce && t.inspector.isFull() {
 				log.Warn(context.TODO(), "skip to generate compaction plan due to handler full")
@@ -395,13 +430,14 @@ func (t *compactionTrigger) handleSignal(signal *compactionSignal) error {
 			totalRows, inputSegmentIDs := plan.A, plan.B
 
 			inputs := typeutil.NewSet[int64](inputSegmentIDs...)
-			totalSize := lo.SumBy(group.segments, func(s *SegmentInfo) int64 {
-				if inputs.Contain(s.GetID()) {
-					return s.getSegmentSize()
-				}
-				return 0
+			inputSegments := lo.Filter(group.segments, func(s *SegmentInfo, _ int) bool {
+				return inputs.Contain(s.GetID())
 			})
-			planID, preAllocatedSegmentIDs, err := allocCompactionPlanIDs(t.allocator, float64(totalSize), float64(expectedSize))
+			totalSize := lo.SumBy(inputSegments, func(s *SegmentInfo) int64 {
+				return s.getSegmentSize()
+			})
+			outputSize, maxSize := estimateCompactionMaxSize(inputSegments, expectedSize)
+			planID, preAllocatedSegmentIDs, err := allocCompactionPlanIDs(t.allocator, float64(totalSize), float64(maxSize))
 			if err != nil {
 				log.Warn(context.TODO(), "fail to allocate id", mlog.Err(err))
 				return err
@@ -422,8 +458,9 @@ func (t *compactionTrigger) handleSignal(signal *compactionSignal) error {
 				ResultSegments:         []int64{},
 				TotalRows:              totalRows,
 				Schema:                 coll.Schema,
-				MaxSize:                expectedSize,
+				MaxSize:                maxSize,
 				PreAllocatedSegmentIDs: preAllocatedSegmentIDs,
+				RequireSortedOutput:    common.IsCollectionCompactionSortedOutput(coll.Properties),
 			}
 			err = t.inspector.enqueueCompaction(task)
 			if err != nil {
@@ -438,12 +475,22 @@ func (t *compactionTrigger) handleSignal(signal *compactionSignal) error {
 				mlog.Int64("planID", task.GetPlanID()),
 				mlog.Int64("time cost", time.Since(start).Milliseconds()),
 				mlog.Int64("target size", task.GetMaxSize()),
+				mlog.Float64("estimated output size", outputSize),
 				mlog.Int64s("inputSegments", inputSegmentIDs))
 		}
 	}
 	return nil
 }
 
+// generateReindexPlans generates a plan for each segment, so the segment is rebuilt alone
+// (e.g. to rebuild its index after an index bug fix) without being merged with the others.
+func (t *compactionTrigger) generateReindexPlans(segments []*SegmentInfo) []*typeutil.Pair[int64, []int64] {
+	return lo.Map(segments, func(segment *SegmentInfo, _ int) *typeutil.Pair[int64, []int64] {
+		pair := typeutil.NewPair(segment.GetNumOfRows(), []int64{segment.GetID()})
+		return &pair
+	})
+}
+
 func (t *compactionTrigger) generatePlans(segments []*SegmentInfo, signal *compactionSignal, compactTime *compactTime, expectedSize int64) []*typeutil.Pair[int64, []int64] {
 	if len(segments) == 0 {
 		mlog.Warn(context.TODO(), "the number of candidate segments is 0, skip to generate compaction plan")
@@ -455,10 +502,16 @@ func (t *compactionTrigger) generatePlans(segments []*SegmentInfo, signal *compa
 	var prioritizedCandidates []*SegmentInfo
 	var smallCandidates []*SegmentInfo
 	var nonPlannedSegments []*SegmentInfo
+	var coolingSegments int
 
 	// TODO, currently we lack of the measurement of data distribution, there should be another compaction help on redistributing segment based on scalar/vector field distribution
 	for _, segment := range segments {
 		segment := segment.ShadowClone()
+		// recently flushed segments are left to the next trigger, they may still be merged with the upcoming flushes.
+		if !signal.isForce && isInFlushCooldown(segment, time.Now()) {
+			coolingSegments++
+			continue
+		}
 		// TODO should we trigger compaction periodically even if the segment has no obvious reason to be compacted?
 		if signal.isForce || t.ShouldDoSingleCompaction(segment, compactTime) {
 			prioritizedCandidates = append(prioritizedCandidates, segment)
@@ -529,6 +582,7 @@ func (t *compactionTrigger) generatePlans(segments []*SegmentInfo, signal *compa
 		pair := typeutil.NewPair(totalRows, segmentIDs)
 		tasks[i] = &pair
 	}
+	t.accessStats.sortPlansColdFirst(tasks)
 
 	if len(tasks) > 0 {
 		mlog.Info(context.TODO(), "generated nontrivial compaction tasks",
@@ -536,6 +590,7 @@ func (t *compactionTrigger) generatePlans(segments []*SegmentInfo, signal *compa
 			mlog.Int("prioritizedCandidates", len(prioritizedCandidates)),
 			mlog.Int("smallCandidates", len(smallCandidates)),
 			mlog.Int("nonPlannedSegments", len(nonPlannedSegments)),
+			mlog.Int("coolingSegments", coolingSegments),
 			mlog.Strings("reasons", reasons))
 	}
 	if len(smallRemaining) > 0 {
@@ -548,6 +603,40 @@ func (t *compactionTrigger) generatePlans(segments []*SegmentInfo, signal *compa
 	return tasks
 }
 
+// isInFlushCooldown returns whether the segment is flushed within the cooldown window.
+func isInFlushCooldown(segment *SegmentInfo, now time.Time) bool {
+	cooldown := Params.DataCoordCfg.MixCompactionFlushCooldown.GetAsDuration(time.Second)
+	if cooldown <= 0 {
+		return false
+	}
+	flushedTime := getSegmentFlushedTime(segment)
+	if flushedTime.IsZero() {
+		return false
+	}
+	return now.Sub(flushedTime) < cooldown
+}
+
+// getSegmentFlushedTime returns the time the segment is flushed. The flush time is kept in memory only,
+// so the time of the last data persisted, the dml position or the end of the binlogs, is used for the
+// segments recovered after restart. It returns zero time if none is known.
+func getSegmentFlushedTime(segment *SegmentInfo) time.Time {
+	if !segment.flushedTime.IsZero() {
+		return segment.flushedTime
+	}
+	ts := segmentEffectiveDmlTs(segment.SegmentInfo)
+	if ts == 0 {
+		for _, fieldBinlog := range segment.GetBinlogs() {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				ts = max(ts, binlog.GetTimestampTo())
+			}
+		}
+	}
+	if ts == 0 {
+		return time.Time{}
+	}
+	return tsoutil.PhysicalTime(ts)
+}
+
 // getCandidates converts signal criterion into corresponding compaction candidate groups
 // since non-major compaction happens under channel+partition level
 // the selected segments are grouped into these categories.
@@ -736,22 +825,27 @@ func (t *compactionTrigger) ShouldCompactExpiryWithTTLField(compactTime *compact
 	return startTs.UnixMicro() >= expirationTime && expirationTime > 0
 }
 
+// ShouldDoSingleCompaction evaluates the single compaction policy chain on the segment.
 func (t *compactionTrigger) ShouldDoSingleCompaction(segment *SegmentInfo, compactTime *compactTime) bool {
-	// no longer restricted binlog numbers because this is now related to field numbers
-
-	stats := segment.EnsureStats()
-	commitTs := segment.GetCommitTimestamp()
+	return t.evaluateSingleCompactionPolicies(segment, compactTime).Trigger
+}
 
+// ShouldCompactStrictExpiry checks the exact earliest timestamp of the segment against the expiry tolerance.
+func (t *compactionTrigger) ShouldCompactStrictExpiry(segment *SegmentInfo, compactTime *compactTime) bool {
 	// Strict-tolerance path: exact min via Stats.TimestampFrom. For import
 	// segments commit_timestamp overrides every row's effective timestamp.
-	earliestFromTs := tsoutil.EffectiveTimestamp(stats.GetTimestampFrom(), commitTs)
-	if t.ShouldCompactExpiry(earliestFromTs, compactTime, segment) {
-		return true
-	}
+	earliestFromTs := tsoutil.EffectiveTimestamp(segment.EnsureStats().GetTimestampFrom(), segment.GetCommitTimestamp())
+	return t.ShouldCompactExpiry(earliestFromTs, compactTime, segment)
+}
+
+// ShouldCompactExpiredRatio checks the approximate expired rows of the segment against the ratio and size thresholds.
+func (t *compactionTrigger) ShouldCompactExpiredRatio(segment *SegmentInfo, compactTime *compactTime) bool {
+	stats := segment.EnsureStats()
+	commitTs := segment.GetCommitTimestamp()
 
 	// Ratio + size path: derive an expired-row fraction from the quantile
 	// distribution (20%-bucket granularity). Approximate; the strict-
-	// tolerance check above covers the precise edges.
+	// tolerance policy covers the precise edges.
 	//
 	// We deliberately UNDER-estimate. Q[i] < expireTime guarantees
 	// percentiles[i] of rows are expired; that fraction times
@@ -793,24 +887,6 @@ func (t *compactionTrigger) ShouldDoSingleCompaction(segment *SegmentInfo, compa
 			mlog.Int64s("compactionFrom", segment.CompactionFrom))
 		return true
 	}
-
-	// check if deltalog count, size, and deleted rowcount ratio exceeds threshold
-	if hasTooManyDeletions(segment) {
-		return true
-	}
-
-	if t.ShouldRebuildSegmentIndex(segment) {
-		return true
-	}
-
-	if t.ShouldCompactExpiryWithTTLField(compactTime, segment) {
-		mlog.Info(context.TODO(), "ttl field is expired, trigger compaction", mlog.FieldSegmentID(segment.ID),
-			mlog.FieldCollectionID(segment.CollectionID),
-			mlog.FieldPartitionID(segment.PartitionID),
-			mlog.String("channel", segment.InsertChannel))
-		return true
-	}
-
 	return false
 }
 
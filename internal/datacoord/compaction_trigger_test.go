@@ -69,6 +69,10 @@ var _ CompactionInspector = (*spyCompactionInspector)(nil)
 
 func (h *spyCompactionInspector) removeTasksByChannel(channel string) {}
 
+func (h *spyCompactionInspector) getCleaningTasksJSON() string {
+	return ""
+}
+
 // enqueueCompaction start to execute plan and return immediately
 func (h *spyCompactionInspector) enqueueCompaction(task *datapb.CompactionTask) error {
 	t := newMixCompactionTask(task, nil, h.meta, newMockVersionManager())
@@ -2180,9 +2184,29 @@ func Test_compactionTrigger_getCompactTime(t *testing.T) {
 		},
 	}
 	now := tsoutil.ComposeTSByTime(time.Now())
-	ct, err := getCompactTime(now, coll)
+	ct, err := getCompactTime(now, coll, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, ct)
+	assert.Equal(t, 10*time.Second, ct.collectionTTL)
+
+	// the partition ttl overrides the collection ttl
+	coll.Properties[common.PartitionTTLKey(1)] = "20"
+	coll.Properties[common.PartitionTTLKey(2)] = "0"
+	ct, err = getCompactTime(now, coll, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 20*time.Second, ct.collectionTTL)
+	assert.NotZero(t, ct.expireTime)
+	ct, err = getCompactTime(now, coll, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), ct.collectionTTL)
+	assert.Zero(t, ct.expireTime)
+	ct, err = getCompactTime(now, coll, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, ct.collectionTTL)
+
+	coll.Properties[common.PartitionTTLKey(1)] = "invalid"
+	_, err = getCompactTime(now, coll, 1)
+	assert.Error(t, err)
 }
 
 func Test_TirggerCompaction_WaitResult(t *testing.T) {
@@ -2653,6 +2677,22 @@ func TestIsCollectionAutoCompactionEnabledExternal(t *testing.T) {
 	assert.False(t, isCollectionAutoCompactionEnabled(coll))
 }
 
+func TestIsCollectionInCompactionWindow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+	coll := &collectionInfo{ID: 1, Properties: map[string]string{}}
+	assert.True(t, isCollectionInCompactionWindow(coll, now))
+
+	coll.Properties[common.CollectionCompactionWindowKey] = "22-4"
+	assert.True(t, isCollectionInCompactionWindow(coll, now))
+
+	coll.Properties[common.CollectionCompactionWindowKey] = "13-15"
+	assert.False(t, isCollectionInCompactionWindow(coll, now))
+
+	// the invalid window never blocks the compaction
+	coll.Properties[common.CollectionCompactionWindowKey] = "13"
+	assert.True(t, isCollectionInCompactionWindow(coll, now))
+}
+
 func (s *CompactionTriggerSuite) TestHandleGlobalSignal() {
 	schema := &schemapb.CollectionSchema{
 		Fields: []*schemapb.FieldSchema{
@@ -3018,6 +3058,21 @@ func Test_compactionTrigger_generatePlans(t *testing.T) {
 	}
 }
 
+func Test_compactionTrigger_generateReindexPlans(t *testing.T) {
+	tr := &compactionTrigger{}
+	segments := []*SegmentInfo{
+		{SegmentInfo: &datapb.SegmentInfo{ID: 1, NumOfRows: 10}},
+		{SegmentInfo: &datapb.SegmentInfo{ID: 2, NumOfRows: 20}},
+	}
+	// each segment is compacted alone even if they are small enough to be merged
+	plans := tr.generateReindexPlans(segments)
+	assert.Len(t, plans, 2)
+	assert.Equal(t, int64(10), plans[0].A)
+	assert.Equal(t, []int64{1}, plans[0].B)
+	assert.Equal(t, int64(20), plans[1].A)
+	assert.Equal(t, []int64{2}, plans[1].B)
+}
+
 func Test_compactionTrigger_generatePlansByTime(t *testing.T) {
 	catalog := mocks.NewDataCoordCatalog(t)
 	catalog.EXPECT().AlterSegments(mock.Anything, mock.Anything).Return(nil).Maybe()
@@ -3698,3 +3753,55 @@ func TestHasTooManyDeletions(t *testing.T) {
 		})
 	}
 }
+
+func Test_compactionTrigger_generatePlansWithFlushCooldown(t *testing.T) {
+	paramtable.Get().Save(Params.DataCoordCfg.MixCompactionFlushCooldown.Key, "600")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.MixCompactionFlushCooldown.Key)
+
+	now := time.Now()
+	newSegment := func(id int64, flushTime time.Time) *SegmentInfo {
+		return &SegmentInfo{
+			SegmentInfo: &datapb.SegmentInfo{
+				ID:            id,
+				CollectionID:  2,
+				PartitionID:   1,
+				NumOfRows:     100,
+				MaxRowNum:     300,
+				InsertChannel: "ch1",
+				State:         commonpb.SegmentState_Flushed,
+			},
+			flushedTime: flushTime,
+		}
+	}
+	recent := newSegment(1, now.Add(-time.Minute))
+	old := newSegment(2, now.Add(-time.Hour))
+	assert.True(t, isInFlushCooldown(recent, now))
+	assert.False(t, isInFlushCooldown(old, now))
+	assert.False(t, isInFlushCooldown(&SegmentInfo{SegmentInfo: &datapb.SegmentInfo{ID: 3}}, now))
+
+	// the segments recovered after restart fall back to the persisted timestamps.
+	recovered := newSegment(4, time.Time{})
+	recovered.DmlPosition = &msgpb.MsgPosition{Timestamp: tsoutil.ComposeTSByTime(now.Add(-time.Minute))}
+	assert.True(t, isInFlushCooldown(recovered, now))
+	recovered.DmlPosition.Timestamp = tsoutil.ComposeTSByTime(now.Add(-time.Hour))
+	assert.False(t, isInFlushCooldown(recovered, now))
+	recovered.DmlPosition = nil
+	recovered.Binlogs = []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []*datapb.Binlog{
+		{TimestampTo: tsoutil.ComposeTSByTime(now.Add(-time.Hour))},
+		{TimestampTo: tsoutil.ComposeTSByTime(now.Add(-time.Minute))},
+	}}}
+	assert.True(t, isInFlushCooldown(recovered, now))
+
+	tr := &compactionTrigger{closeCh: lifetime.NewSafeChan(), testingOnly: true}
+	signal := &compactionSignal{collectionID: 2, partitionID: 1, channel: "ch1"}
+	got := tr.generatePlans([]*SegmentInfo{recent}, signal, nil, 1024*1024*1024)
+	assert.Empty(t, got)
+
+	// force trigger ignores the cooldown.
+	signal.isForce = true
+	got = tr.generatePlans([]*SegmentInfo{recent}, signal, nil, 1024*1024*1024)
+	assert.Equal(t, []*typeutil.Pair[int64, []int64]{{A: 100, B: []int64{1}}}, got)
+
+	paramtable.Get().Save(Params.DataCoordCfg.MixCompactionFlushCooldown.Key, "0")
+	assert.False(t, isInFlushCooldown(recent, now))
+}
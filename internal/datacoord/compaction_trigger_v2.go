@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -52,6 +54,7 @@ const (
 	TriggerTypeForceMerge
 	TriggerTypeStorageVersionUpgrade
 	TriggerTypeBumpSchemaVersion
+	TriggerTypeLogMerge
 )
 
 type TickerType int8
@@ -62,6 +65,7 @@ const (
 	SingleTicker
 	BumpSchemaVersionTicker
 	StorageVersionTicker
+	LogMergeTicker
 )
 
 func (t CompactionTriggerType) GetCompactionType() datapb.CompactionType {
@@ -78,6 +82,8 @@ func (t CompactionTriggerType) GetCompactionType() datapb.CompactionType {
 		return datapb.CompactionType_MixCompaction
 	case TriggerTypeBumpSchemaVersion:
 		return datapb.CompactionType_BumpSchemaVersionCompaction
+	case TriggerTypeLogMerge:
+		return datapb.CompactionType_LogMergeCompaction
 	default:
 		return datapb.CompactionType_MixCompaction
 	}
@@ -105,6 +111,8 @@ func (t CompactionTriggerType) String() string {
 		return "StorageVersionUpgrade"
 	case TriggerTypeBumpSchemaVersion:
 		return "BumpSchemaVersion"
+	case TriggerTypeLogMerge:
+		return "LogMerge"
 	default:
 		return ""
 	}
@@ -148,6 +156,7 @@ type CompactionTriggerManager struct {
 	forceMergePolicy            *forceMergeCompactionPolicy
 	upgradeStorageVersionPolicy *storageVersionUpgradePolicy
 	bumpSchemaVersionPolicy     *bumpSchemaVersionPolicy
+	logMergePolicy              *logMergePolicy
 
 	cancel  context.CancelFunc
 	closeWg sync.WaitGroup
@@ -172,6 +181,7 @@ func NewCompactionTriggerManager(alloc allocator.Allocator, handler Handler, ins
 	m.forceMergePolicy = newForceMergeCompactionPolicy(meta, m.allocator, m.handler)
 	m.upgradeStorageVersionPolicy = newStorageVersionUpgradePolicy(meta, m.allocator, m.handler, versionManager)
 	m.bumpSchemaVersionPolicy = newBumpSchemaVersionPolicy(meta, m.allocator, m.handler)
+	m.logMergePolicy = newLogMergePolicy(meta, m.allocator)
 
 	// Initialize policies map for ticker handling
 	m.policies[L0Ticker] = m.l0Policy
@@ -179,6 +189,7 @@ func NewCompactionTriggerManager(alloc allocator.Allocator, handler Handler, ins
 	m.policies[SingleTicker] = m.singlePolicy
 	m.policies[BumpSchemaVersionTicker] = m.bumpSchemaVersionPolicy
 	m.policies[StorageVersionTicker] = m.upgradeStorageVersionPolicy
+	m.policies[LogMergeTicker] = m.logMergePolicy
 	return m
 }
 
@@ -227,6 +238,8 @@ func (m *CompactionTriggerManager) loop(ctx context.Context) {
 	defer storageVersionTicker.Stop()
 	bumpSchemaVersionTicker := time.NewTicker(Params.DataCoordCfg.BumpSchemaVersionCompactionTriggerInterval.GetAsDuration(time.Second))
 	defer bumpSchemaVersionTicker.Stop()
+	logMergeTicker := time.NewTicker(Params.DataCoordCfg.LogMergeCompactionTriggerInterval.GetAsDuration(time.Second))
+	defer logMergeTicker.Stop()
 	mlog.Info(ctx, "Compaction trigger manager start")
 	for {
 		select {
@@ -243,6 +256,8 @@ func (m *CompactionTriggerManager) loop(ctx context.Context) {
 			m.handleTicker(ctx, StorageVersionTicker)
 		case <-bumpSchemaVersionTicker.C:
 			m.handleTicker(ctx, BumpSchemaVersionTicker)
+		case <-logMergeTicker.C:
+			m.handleTicker(ctx, LogMergeTicker)
 		case segID := <-getStatsTaskChSingleton():
 			log.Info(ctx, "receive new segment to trigger sort compaction", mlog.Int64("segmentID", segID))
 			view := m.singlePolicy.triggerSegmentSortCompaction(ctx, segID)
@@ -363,18 +378,22 @@ func (m *CompactionTriggerManager) notify(ctx context.Context, eventType Compact
 					mlog.String("output view", outView.String()),
 					mlog.Int64("triggerID", outView.GetTriggerID()))
 
+				spanCtx, sp := startCompactionTriggerSpan(ctx, outView.GetTriggerID(), eventType.String())
 				switch eventType {
 				case TriggerTypeLevelZeroViewChange, TriggerTypeLevelZeroViewIDLE, TriggerTypeLevelZeroViewManual:
-					m.SubmitL0ViewToScheduler(ctx, outView)
+					m.SubmitL0ViewToScheduler(spanCtx, outView)
 				case TriggerTypeClustering:
-					m.SubmitClusteringViewToScheduler(ctx, outView)
+					m.SubmitClusteringViewToScheduler(spanCtx, outView)
 				case TriggerTypeSingle, TriggerTypeSort, TriggerTypeStorageVersionUpgrade:
-					m.SubmitSingleViewToScheduler(ctx, outView, eventType)
+					m.SubmitSingleViewToScheduler(spanCtx, outView, eventType)
 				case TriggerTypeForceMerge:
-					m.SubmitForceMergeViewToScheduler(ctx, outView)
+					m.SubmitForceMergeViewToScheduler(spanCtx, outView)
 				case TriggerTypeBumpSchemaVersion:
-					m.SubmitBumpSchemaVersionViewToScheduler(ctx, outView)
+					m.SubmitBumpSchemaVersionViewToScheduler(spanCtx, outView)
+				case TriggerTypeLogMerge:
+					m.SubmitLogMergeViewToScheduler(spanCtx, outView)
 				}
+				sp.End()
 			}
 		}
 	}
@@ -528,8 +547,13 @@ func (m *CompactionTriggerManager) SubmitSingleViewToScheduler(ctx context.Conte
 	}
 
 	expectedSize := getExpectedSegmentSize(m.meta, collection.ID, collection.Schema)
+	segments := lo.FilterMap(view.GetSegmentsView(), func(segmentView *SegmentView, _ int) (*SegmentInfo, bool) {
+		segment := m.meta.GetHealthySegment(ctx, segmentView.ID)
+		return segment, segment != nil
+	})
+	outputSize, maxSize := estimateCompactionMaxSize(segments, expectedSize)
 	totalSize := view.GetTotalSize()
-	planID, preAllocatedSegmentIDs, err := allocCompactionPlanIDs(m.allocator, totalSize, float64(expectedSize))
+	planID, preAllocatedSegmentIDs, err := allocCompactionPlanIDs(m.allocator, totalSize, float64(maxSize))
 	if err != nil {
 		log.Warn(ctx, "Failed to submit compaction view to scheduler because allocate id fail", mlog.Err(err))
 		return
@@ -556,8 +580,9 @@ func (m *CompactionTriggerManager) SubmitSingleViewToScheduler(ctx context.Conte
 		ResultSegments:         []int64{},
 		TotalRows:              totalRows,
 		LastStateStartTime:     now,
-		MaxSize:                expectedSize,
+		MaxSize:                maxSize,
 		PreAllocatedSegmentIDs: preAllocatedSegmentIDs,
+		RequireSortedOutput:    triggerType.GetCompactionType() == datapb.CompactionType_MixCompaction && common.IsCollectionCompactionSortedOutput(collection.Properties),
 	}
 	err = m.inspector.enqueueCompaction(task)
 	if err != nil {
@@ -573,6 +598,7 @@ func (m *CompactionTriggerManager) SubmitSingleViewToScheduler(ctx context.Conte
 		mlog.Int64("planID", task.GetPlanID()),
 		mlog.String("type", task.GetType().String()),
 		mlog.Int64("targetSize", task.GetMaxSize()),
+		mlog.Float64("estimatedOutputSize", outputSize),
 	)
 }
 
@@ -719,6 +745,7 @@ func (m *CompactionTriggerManager) SubmitForceMergeViewToScheduler(ctx context.C
 		LastStateStartTime:     now,
 		MaxSize:                int64(view.(*ForceMergeSegmentView).GetTargetSegmentSize()),
 		PreAllocatedSegmentIDs: preAllocatedSegmentIDs,
+		RequireSortedOutput:    common.IsCollectionCompactionSortedOutput(collection.Properties),
 	}
 
 	err = m.inspector.enqueueCompaction(task)
@@ -756,7 +783,7 @@ func (m *CompactionTriggerManager) SubmitBumpSchemaVersionViewToScheduler(ctx co
 		log.Info(ctx, "skip submitting schema bump compaction for external collection", mlog.Int64("collectionID", collection.ID))
 		return
 	}
-	collectionTTL, err := common.GetCollectionTTLFromMap(collection.Properties)
+	collectionTTL, err := common.GetPartitionsTTLFromMap(collection.Properties, view.GetGroupLabel().PartitionID)
 	if err != nil {
 		log.Warn(ctx, "Failed to submit schema bump compaction because get collection ttl failed", mlog.Err(err))
 		return
@@ -806,14 +833,103 @@ func (m *CompactionTriggerManager) SubmitBumpSchemaVersionViewToScheduler(ctx co
 	)
 }
 
+func (m *CompactionTriggerManager) SubmitLogMergeViewToScheduler(ctx context.Context, view CompactionView) {
+	log := mlog.With(mlog.String("view", view.String()))
+	mergeView, ok := view.(*LogMergeView)
+	if !ok {
+		log.Warn(ctx, "unexpected view type for log merge trigger, expected *LogMergeView",
+			mlog.String("actualType", fmt.Sprintf("%T", view)))
+		return
+	}
+	planID, err := m.allocator.AllocID(ctx)
+	if err != nil {
+		log.Warn(ctx, "Failed to submit compaction view to scheduler because allocate id fail", mlog.Err(err))
+		return
+	}
+	var totalRows int64 = 0
+	for _, s := range view.GetSegmentsView() {
+		totalRows += s.NumOfRows
+	}
+	now := time.Now().Unix()
+	task := &datapb.CompactionTask{
+		PlanID:             planID,
+		TriggerID:          mergeView.triggerID,
+		State:              datapb.CompactionTaskState_pipelining,
+		StartTime:          now,
+		Type:               datapb.CompactionType_LogMergeCompaction,
+		CollectionID:       view.GetGroupLabel().CollectionID,
+		PartitionID:        view.GetGroupLabel().PartitionID,
+		Channel:            view.GetGroupLabel().Channel,
+		Schema:             mergeView.schema,
+		InputSegments:      lo.Map(view.GetSegmentsView(), func(segmentView *SegmentView, _ int) int64 { return segmentView.ID }),
+		ResultSegments:     []int64{},
+		TotalRows:          totalRows,
+		LastStateStartTime: now,
+	}
+	err = m.inspector.enqueueCompaction(task)
+	if err != nil {
+		log.Warn(ctx, "Failed to execute compaction task",
+			mlog.Int64("triggerID", task.GetTriggerID()),
+			mlog.Int64("planID", task.GetPlanID()),
+			mlog.Int64s("segmentIDs", task.GetInputSegments()),
+			mlog.Err(err))
+		return
+	}
+	log.Info(ctx, "Finish to submit a log merge compaction task",
+		mlog.Int64("triggerID", task.GetTriggerID()),
+		mlog.Int64("planID", task.GetPlanID()),
+		mlog.String("type", task.GetType().String()),
+	)
+}
+
 func getExpectedSegmentSize(meta *meta, collectionID int64, schema *schemapb.CollectionSchema) int64 {
+	var expectedSize int64
 	allDiskIndex := meta.indexMeta.AllDenseWithDiskIndex(collectionID, schema)
 	if allDiskIndex {
 		// Only if all dense vector fields index type are DiskANN, recalc segment max size here.
-		return Params.DataCoordCfg.DiskSegmentMaxSize.GetAsInt64() * 1024 * 1024
+		expectedSize = Params.DataCoordCfg.DiskSegmentMaxSize.GetAsInt64() * 1024 * 1024
+	} else {
+		// If some dense vector fields index type are not DiskANN, recalc segment max size using default policy.
+		expectedSize = Params.DataCoordCfg.SegmentMaxSize.GetAsInt64() * 1024 * 1024
+	}
+
+	// Shrink the segment size by the memory factor of the indexes, so that the index built on it fits the memory model.
+	factor := getIndexMemoryFactor(meta.indexMeta.GetDenseVectorIndexTypes(collectionID, schema))
+	if factor > 1 {
+		expectedSize = int64(float64(expectedSize) / factor)
+	}
+	return expectedSize
+}
+
+// getIndexMemoryFactor returns the max configured memory factor of the index types,
+// index types not configured have factor 1.
+func getIndexMemoryFactor(indexTypes []string) float64 {
+	value := Params.DataCoordCfg.SegmentIndexMemoryFactors.GetValue()
+	if value == "" || len(indexTypes) == 0 {
+		return 1
+	}
+	factors := make(map[string]float64)
+	for _, kv := range strings.Split(value, ",") {
+		indexType, factorStr, ok := strings.Cut(strings.TrimSpace(kv), ":")
+		if !ok {
+			mlog.RatedWarn(context.TODO(), rate.Limit(1), "invalid index memory factor", mlog.String("value", kv))
+			continue
+		}
+		factor, err := strconv.ParseFloat(strings.TrimSpace(factorStr), 64)
+		if err != nil || factor <= 0 {
+			mlog.RatedWarn(context.TODO(), rate.Limit(1), "invalid index memory factor", mlog.String("value", kv))
+			continue
+		}
+		factors[strings.ToUpper(strings.TrimSpace(indexType))] = factor
+	}
+
+	maxFactor := 1.0
+	for _, indexType := range indexTypes {
+		if factor, ok := factors[strings.ToUpper(indexType)]; ok && factor > maxFactor {
+			maxFactor = factor
+		}
 	}
-	// If some dense vector fields index type are not DiskANN, recalc segment max size using default policy.
-	return Params.DataCoordCfg.SegmentMaxSize.GetAsInt64() * 1024 * 1024
+	return maxFactor
 }
 
 // chanPartSegments is an internal result struct, which is aggregates of SegmentInfos with same collectionID, partitionID and channelName
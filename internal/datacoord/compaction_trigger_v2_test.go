@@ -506,6 +506,30 @@ func (s *CompactionTriggerManagerSuite) TestGetExpectedSegmentSize() {
 
 		s.Equal(int64(100*1024*1024), getExpectedSegmentSize(s.triggerManager.meta, collection.ID, collection.Schema))
 	})
+
+	s.Run("index memory factors", func() {
+		paramtable.Get().Save(paramtable.Get().DataCoordCfg.SegmentIndexMemoryFactors.Key, "hnsw:2, DISKANN:1.5,invalid,IVF_FLAT:x")
+		defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.SegmentIndexMemoryFactors.Key)
+
+		collection := &collectionInfo{
+			ID: collectionID,
+			Schema: &schemapb.CollectionSchema{
+				Name: "coll1",
+				Fields: []*schemapb.FieldSchema{
+					{FieldID: fieldID, Name: "field0", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+					{FieldID: fieldID + 1, Name: "field1", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "8"}}},
+					{FieldID: fieldID + 2, Name: "field2", DataType: schemapb.DataType_Float16Vector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "8"}}},
+				},
+			},
+		}
+		// HNSW on field1 only, the max factor 2 of HNSW applies
+		s.Equal(int64(50*1024*1024), getExpectedSegmentSize(s.triggerManager.meta, collection.ID, collection.Schema))
+
+		s.Equal(1.5, getIndexMemoryFactor([]string{"DISKANN"}))
+		s.Equal(2.0, getIndexMemoryFactor([]string{"DISKANN", "HNSW"}))
+		s.Equal(1.0, getIndexMemoryFactor([]string{"IVF_FLAT"}))
+		s.Equal(1.0, getIndexMemoryFactor(nil))
+	})
 }
 
 func (s *CompactionTriggerManagerSuite) TestManualTriggerL0Compaction() {
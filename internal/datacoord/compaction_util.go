@@ -141,3 +141,58 @@ func isCompactionTaskCleaned(t *datapb.CompactionTask) bool {
 		return false
 	}
 }
+
+// compactionTaskIOBudget returns the object storage bandwidth budget in MB/s of a compaction task, 0 means unlimited.
+// The scheduler defers the dispatch of the tasks once the budget of the collection or the workers is exhausted,
+// and the worker throttles the io of the task by it.
+func compactionTaskIOBudget() float64 {
+	return paramtable.Get().DataCoordCfg.CompactionTaskIOBudget.GetAsFloat()
+}
+
+const (
+	compactionConcurrencyGroupL0         = "l0"
+	compactionConcurrencyGroupMix        = "mix"
+	compactionConcurrencyGroupClustering = "clustering"
+)
+
+// compactionConcurrencyGroup returns the group sharing the concurrency limits of the compaction type,
+// the sort, bump schema version and log merge compactions go with the mix ones.
+func compactionConcurrencyGroup(compactionType datapb.CompactionType) string {
+	switch compactionType {
+	case datapb.CompactionType_Level0DeleteCompaction:
+		return compactionConcurrencyGroupL0
+	case datapb.CompactionType_ClusteringCompaction, datapb.CompactionType_ClusteringPartitionKeySortCompaction:
+		return compactionConcurrencyGroupClustering
+	default:
+		return compactionConcurrencyGroupMix
+	}
+}
+
+// compactionChannelConcurrencyLimit returns the max number of the executing compaction tasks of the group
+// on a channel, 0 means unlimited.
+func compactionChannelConcurrencyLimit(group string) int {
+	params := &paramtable.Get().DataCoordCfg
+	switch group {
+	case compactionConcurrencyGroupL0:
+		return params.CompactionL0MaxParallelPerChannel.GetAsInt()
+	case compactionConcurrencyGroupClustering:
+		return params.CompactionClusteringMaxParallelPerChannel.GetAsInt()
+	default:
+		return params.CompactionMixMaxParallelPerChannel.GetAsInt()
+	}
+}
+
+// compactionNodeConcurrencyLimit returns the group of the compaction type and the max number of
+// the running compaction tasks of the group on a worker, 0 means unlimited.
+func compactionNodeConcurrencyLimit(compactionType datapb.CompactionType) (string, int) {
+	params := &paramtable.Get().DataCoordCfg
+	group := compactionConcurrencyGroup(compactionType)
+	switch group {
+	case compactionConcurrencyGroupL0:
+		return group, params.CompactionL0MaxParallelPerNode.GetAsInt()
+	case compactionConcurrencyGroupClustering:
+		return group, params.CompactionClusteringMaxParallelPerNode.GetAsInt()
+	default:
+		return group, params.CompactionMixMaxParallelPerNode.GetAsInt()
+	}
+}
@@ -0,0 +1,136 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sort"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// segmentSizeBucketRatios are the upper bounds of the segment size histogram buckets
+// in the ratio of dataCoord.segment.maxSize, the last bucket holds the oversized segments.
+var segmentSizeBucketRatios = []float64{0.1, 0.25, 0.5, 0.75, 1}
+
+// DataStat is the row and segment statistics of a group of segments.
+type DataStat struct {
+	NumRows     int64 `json:"num_rows"`
+	NumSegments int   `json:"num_segments"`
+	Size        int64 `json:"size"`
+}
+
+func (s *DataStat) add(segment *SegmentInfo) {
+	s.NumRows += segment.GetNumOfRows()
+	s.NumSegments++
+	s.Size += segment.getSegmentSize()
+}
+
+// ChannelDataStat is the data statistics of a channel.
+type ChannelDataStat struct {
+	Channel string `json:"channel"`
+	DataStat
+}
+
+// PartitionDataStat is the data statistics of a partition.
+type PartitionDataStat struct {
+	PartitionID int64 `json:"partition_id"`
+	DataStat
+}
+
+// SegmentSizeBucket is a bucket of the segment size histogram.
+type SegmentSizeBucket struct {
+	// UpperBound is the max segment size of the bucket in bytes, -1 means unbounded.
+	UpperBound  int64 `json:"upper_bound"`
+	NumSegments int   `json:"num_segments"`
+}
+
+// DataDistribution is how the data of a collection spreads over the channels, the partitions and the segment sizes.
+type DataDistribution struct {
+	CollectionID         int64                `json:"collection_id"`
+	NumRows              int64                `json:"num_rows"`
+	Channels             []*ChannelDataStat   `json:"channels"`
+	Partitions           []*PartitionDataStat `json:"partitions"`
+	SegmentSizeHistogram []*SegmentSizeBucket `json:"segment_size_histogram"`
+}
+
+// GetDataDistribution returns the data distribution of the healthy segments of the collection, the L0 segments are ignored.
+func (s *Server) GetDataDistribution(ctx context.Context, collectionID int64) (*DataDistribution, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return nil, err
+	}
+	collection := s.meta.GetCollection(collectionID)
+	if collection == nil {
+		return nil, merr.WrapErrCollectionNotFound(collectionID)
+	}
+
+	maxSize := Params.DataCoordCfg.SegmentMaxSize.GetAsFloat() * 1024 * 1024
+	histogram := make([]*SegmentSizeBucket, 0, len(segmentSizeBucketRatios)+1)
+	for _, ratio := range segmentSizeBucketRatios {
+		histogram = append(histogram, &SegmentSizeBucket{UpperBound: int64(maxSize * ratio)})
+	}
+	histogram = append(histogram, &SegmentSizeBucket{UpperBound: -1})
+
+	dist := &DataDistribution{
+		CollectionID:         collectionID,
+		SegmentSizeHistogram: histogram,
+	}
+	channels := make(map[string]*ChannelDataStat)
+	partitions := make(map[int64]*PartitionDataStat)
+	for _, channel := range collection.VChannelNames {
+		channels[channel] = &ChannelDataStat{Channel: channel}
+	}
+	for _, partitionID := range collection.Partitions {
+		partitions[partitionID] = &PartitionDataStat{PartitionID: partitionID}
+	}
+
+	segments := s.meta.SelectSegments(ctx, WithCollection(collectionID), SegmentFilterFunc(func(segment *SegmentInfo) bool {
+		return isSegmentHealthy(segment) && segment.GetLevel() != datapb.SegmentLevel_L0
+	}))
+	for _, segment := range segments {
+		dist.NumRows += segment.GetNumOfRows()
+		if _, ok := channels[segment.GetInsertChannel()]; !ok {
+			channels[segment.GetInsertChannel()] = &ChannelDataStat{Channel: segment.GetInsertChannel()}
+		}
+		channels[segment.GetInsertChannel()].add(segment)
+		if _, ok := partitions[segment.GetPartitionID()]; !ok {
+			partitions[segment.GetPartitionID()] = &PartitionDataStat{PartitionID: segment.GetPartitionID()}
+		}
+		partitions[segment.GetPartitionID()].add(segment)
+
+		size := segment.getSegmentSize()
+		bucket := histogram[len(histogram)-1]
+		for _, b := range histogram[:len(histogram)-1] {
+			if size <= b.UpperBound {
+				bucket = b
+				break
+			}
+		}
+		bucket.NumSegments++
+	}
+
+	for _, stat := range channels {
+		dist.Channels = append(dist.Channels, stat)
+	}
+	sort.Slice(dist.Channels, func(i, j int) bool { return dist.Channels[i].Channel < dist.Channels[j].Channel })
+	for _, stat := range partitions {
+		dist.Partitions = append(dist.Partitions, stat)
+	}
+	sort.Slice(dist.Partitions, func(i, j int) bool { return dist.Partitions[i].PartitionID < dist.Partitions[j].PartitionID })
+	return dist, nil
+}
@@ -0,0 +1,83 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestServer_GetDataDistribution(t *testing.T) {
+	ctx := context.Background()
+	Params.Save(Params.DataCoordCfg.SegmentMaxSize.Key, "100")
+	defer Params.Reset(Params.DataCoordCfg.SegmentMaxSize.Key)
+
+	const mb = 1024 * 1024
+	segments := NewSegmentsInfo()
+	for _, segment := range []*datapb.SegmentInfo{
+		{ID: 1, CollectionID: 100, PartitionID: 10, InsertChannel: "ch-1", State: commonpb.SegmentState_Flushed, NumOfRows: 1000, Stats: &datapb.Statistics{InsertBinlogSize: 5 * mb}},
+		{ID: 2, CollectionID: 100, PartitionID: 10, InsertChannel: "ch-1", State: commonpb.SegmentState_Flushed, NumOfRows: 3000, Stats: &datapb.Statistics{InsertBinlogSize: 90 * mb}},
+		{ID: 3, CollectionID: 100, PartitionID: 11, InsertChannel: "ch-2", State: commonpb.SegmentState_Growing, NumOfRows: 500, Stats: &datapb.Statistics{InsertBinlogSize: 200 * mb}},
+		{ID: 4, CollectionID: 100, PartitionID: 10, InsertChannel: "ch-2", State: commonpb.SegmentState_Dropped, NumOfRows: 8000},
+		{ID: 5, CollectionID: 100, PartitionID: 10, InsertChannel: "ch-2", State: commonpb.SegmentState_Flushed, Level: datapb.SegmentLevel_L0},
+	} {
+		segments.SetSegment(segment.GetID(), NewSegmentInfo(segment))
+	}
+	s := &Server{
+		meta: &meta{
+			collections: typeutil.NewConcurrentMap[UniqueID, *collectionInfo](),
+			segments:    segments,
+		},
+	}
+	s.meta.AddCollection(&collectionInfo{ID: 100, VChannelNames: []string{"ch-1", "ch-2", "ch-3"}, Partitions: []int64{10, 11}})
+	s.stateCode.Store(commonpb.StateCode_Healthy)
+
+	dist, err := s.GetDataDistribution(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4500), dist.NumRows)
+	assert.Len(t, dist.Channels, 3)
+	assert.Equal(t, "ch-1", dist.Channels[0].Channel)
+	assert.Equal(t, int64(4000), dist.Channels[0].NumRows)
+	assert.Equal(t, 2, dist.Channels[0].NumSegments)
+	assert.Equal(t, int64(95*mb), dist.Channels[0].Size)
+	assert.Equal(t, int64(500), dist.Channels[1].NumRows)
+	assert.Equal(t, 0, dist.Channels[2].NumSegments)
+	assert.Len(t, dist.Partitions, 2)
+	assert.Equal(t, int64(4000), dist.Partitions[0].NumRows)
+	assert.Equal(t, int64(500), dist.Partitions[1].NumRows)
+
+	assert.Len(t, dist.SegmentSizeHistogram, len(segmentSizeBucketRatios)+1)
+	assert.Equal(t, int64(10*mb), dist.SegmentSizeHistogram[0].UpperBound)
+	assert.Equal(t, 1, dist.SegmentSizeHistogram[0].NumSegments)
+	assert.Equal(t, 1, dist.SegmentSizeHistogram[4].NumSegments)
+	assert.Equal(t, int64(-1), dist.SegmentSizeHistogram[5].UpperBound)
+	assert.Equal(t, 1, dist.SegmentSizeHistogram[5].NumSegments)
+
+	_, err = s.GetDataDistribution(ctx, 200)
+	assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+
+	s.stateCode.Store(commonpb.StateCode_Abnormal)
+	_, err = s.GetDataDistribution(ctx, 100)
+	assert.Error(t, err)
+}
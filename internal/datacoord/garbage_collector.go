@@ -81,6 +81,13 @@ type garbageCollector struct {
 	controlChannels  map[string]chan gcCmd
 
 	systemMetricsListener *hardware.SystemMetricsListener
+
+	orphanSegmentMu     sync.Mutex
+	orphanSegmentReport atomic.Pointer[OrphanSegmentReport]
+	// consecutive checks finding the meta orphans, guarded by orphanSegmentMu
+	metaOrphanConfirmations map[int64]int
+	// storage orphans being registered again, their files are kept until the time
+	reregisteringOrphans *typeutil.ConcurrentMap[int64, time.Time]
 }
 
 type gcCmd struct {
@@ -241,6 +248,7 @@ func newGarbageCollector(meta *meta, handler Handler, opt GcOption) *garbageColl
 		pauseUntil:            NewGCPauseRecords(),
 		pausedCollection:      typeutil.NewConcurrentMap[int64, *gcPauseRecords](),
 		controlChannels:       controlChannels,
+		reregisteringOrphans:  typeutil.NewConcurrentMap[int64, time.Time](),
 	}
 }
 
@@ -327,7 +335,7 @@ func (gc *garbageCollector) Resume(ctx context.Context, collectionID int64, tick
 func (gc *garbageCollector) work(ctx context.Context) {
 	// TODO: fast cancel for gc when closing.
 	// Run gc tasks in parallel.
-	gc.wg.Add(4)
+	gc.wg.Add(5)
 	go func() {
 		defer gc.wg.Done()
 		gc.runRecycleTaskWithPauser(ctx, "meta", gc.option.checkInterval, func(ctx context.Context, signal <-chan gcCmd) {
@@ -359,6 +367,19 @@ func (gc *garbageCollector) work(ctx context.Context) {
 			gc.recycleUnusedLOBFiles(ctx)
 		})
 	}()
+	go func() {
+		defer gc.wg.Done()
+		// the orphan segment check is a consistency check between meta and object storage, which is off by default
+		interval := Params.DataCoordCfg.GCOrphanSegmentCheckInterval.GetAsDuration(time.Second)
+		gc.runRecycleTaskWithPauser(ctx, "orphan_segment", interval, func(ctx context.Context, signal <-chan gcCmd) {
+			if !Params.DataCoordCfg.GCOrphanSegmentCheckEnabled.GetAsBool() {
+				return
+			}
+			if _, err := gc.checkOrphanSegments(ctx, Params.DataCoordCfg.GCOrphanSegmentRepairEnabled.GetAsBool()); err != nil {
+				mlog.Warn(ctx, "failed to check orphan segments", mlog.Err(err))
+			}
+		})
+	}()
 	go func() {
 		defer gc.wg.Done()
 		gc.startControlLoop(ctx)
@@ -698,6 +719,12 @@ func (gc *garbageCollector) recycleUnusedBinLogWithChecker(ctx context.Context,
 			valid++
 			return true
 		}
+		if segment == nil && gc.isReregisteringOrphan(segmentID) {
+			logger.Info(ctx, "skip GC binlog files since orphan segment is being registered again",
+				mlog.Int64("segmentID", segmentID))
+			valid++
+			return true
+		}
 
 		// ignore error since it could be cleaned up next time
 		file := chunkInfo.FilePath
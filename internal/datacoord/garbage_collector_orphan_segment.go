@@ -0,0 +1,431 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	storageOrphanLabel = "storage"
+	metaOrphanLabel    = "meta"
+)
+
+// OrphanSegment is a segment found inconsistent between meta and object storage.
+type OrphanSegment struct {
+	CollectionID int64 `json:"collection_id"`
+	PartitionID  int64 `json:"partition_id"`
+	SegmentID    int64 `json:"segment_id"`
+	// Channel and NumRows are only known for the segments in meta.
+	Channel string `json:"channel,omitempty"`
+	NumRows int64  `json:"num_rows,omitempty"`
+	// FileNum is the number of the files on object storage of a segment missing from meta.
+	FileNum int `json:"file_num,omitempty"`
+	// MissingFile is the first file of a segment in meta found missing from object storage.
+	MissingFile string `json:"missing_file,omitempty"`
+	// Confirmations is the number of the consecutive checks finding the segment in meta missing its files.
+	Confirmations int  `json:"confirmations,omitempty"`
+	Repaired      bool `json:"repaired"`
+
+	files      []string
+	modifyTime time.Time
+}
+
+// OrphanSegmentReport is the result of an orphan segment check.
+type OrphanSegmentReport struct {
+	StartTime       time.Time `json:"start_time"`
+	Cost            string    `json:"cost"`
+	Repair          bool      `json:"repair"`
+	ScannedFiles    int       `json:"scanned_files"`
+	CheckedSegments int       `json:"checked_segments"`
+	// StorageOrphans are the segments whose files are on object storage but missing from meta.
+	StorageOrphans []*OrphanSegment `json:"storage_orphans"`
+	// MetaOrphans are the flushed segments whose files are missing from object storage.
+	MetaOrphans []*OrphanSegment `json:"meta_orphans"`
+}
+
+// parseSegmentKeyFromLogPath parses the collection, partition and segment ids from the binlog path,
+// which is {root}/{insert_log|stats_log|delta_log}/{collection}/{partition}/{segment}/...
+func parseSegmentKeyFromLogPath(rootPath, filePath string) (int64, int64, int64, error) {
+	p := strings.TrimPrefix(strings.TrimPrefix(filePath, rootPath), "/")
+	parts := strings.Split(p, "/")
+	if len(parts) < 5 {
+		return 0, 0, 0, merr.WrapErrParameterInvalidMsg("not a binlog path: %s", filePath)
+	}
+	ids := make([]int64, 3)
+	for i := range ids {
+		id, err := strconv.ParseInt(parts[i+1], 10, 64)
+		if err != nil {
+			return 0, 0, 0, merr.WrapErrParameterInvalidMsg("not a binlog path: %s", filePath)
+		}
+		ids[i] = id
+	}
+	return ids[0], ids[1], ids[2], nil
+}
+
+// isOrphanCheckCandidate returns whether the segment in meta is expected to have its binlogs on object storage.
+// The segments managed by the manifest are skipped, their files are not referenced by the binlogs in meta,
+// and so are the compacting segments, their files may be replaced by the compaction.
+func isOrphanCheckCandidate(segment *SegmentInfo) bool {
+	return segment.GetState() == commonpb.SegmentState_Flushed &&
+		!segment.isCompacting &&
+		!segment.GetIsImporting() &&
+		segment.GetStorageVersion() != storage.StorageV3 &&
+		segment.GetNumOfRows() > 0 &&
+		len(segment.GetBinlogs()) > 0
+}
+
+// GetOrphanSegmentReport returns the report of the last orphan segment check, nil if never checked.
+func (gc *garbageCollector) GetOrphanSegmentReport() *OrphanSegmentReport {
+	return gc.orphanSegmentReport.Load()
+}
+
+// checkOrphanSegments checks the consistency between the segments in meta and the segment files on object storage.
+// The segments missing from meta are only reported, their files are removed by recycleUnusedBinlogFiles after the missing tolerance
+// unless they're registered again by ReregisterOrphanSegment. The flushed segments whose files are lost are marked as dropped if repair is set,
+// see repairMetaOrphans. Only one check runs at a time.
+func (gc *garbageCollector) checkOrphanSegments(ctx context.Context, repair bool) (*OrphanSegmentReport, error) {
+	if !gc.orphanSegmentMu.TryLock() {
+		return nil, merr.WrapErrServiceUnavailable("orphan segment check is running")
+	}
+	defer gc.orphanSegmentMu.Unlock()
+
+	report := &OrphanSegmentReport{
+		StartTime:      time.Now(),
+		Repair:         repair,
+		StorageOrphans: make([]*OrphanSegment, 0),
+		MetaOrphans:    make([]*OrphanSegment, 0),
+	}
+	log := mlog.With(mlog.String("gcName", "checkOrphanSegments"), mlog.Bool("repair", repair))
+	log.Info(ctx, "start to check orphan segments")
+
+	// the candidates are selected before scanning the storage, their files are always uploaded before the scan.
+	candidates := gc.meta.SelectSegments(ctx, SegmentFilterFunc(isOrphanCheckCandidate))
+	if err := gc.findStorageOrphans(ctx, report); err != nil {
+		log.Warn(ctx, "failed to scan segment files", mlog.Err(err))
+		return nil, err
+	}
+	if err := gc.findMetaOrphans(ctx, candidates, report); err != nil {
+		log.Warn(ctx, "failed to check segment files", mlog.Err(err))
+		return nil, err
+	}
+	gc.confirmMetaOrphans(report)
+	if repair {
+		gc.repairMetaOrphans(ctx, report)
+	}
+
+	report.Cost = time.Since(report.StartTime).String()
+	gc.orphanSegmentReport.Store(report)
+	nodeID := paramtable.GetStringNodeID()
+	metrics.GarbageCollectorOrphanSegmentNum.WithLabelValues(nodeID, storageOrphanLabel).Set(float64(len(report.StorageOrphans)))
+	metrics.GarbageCollectorOrphanSegmentNum.WithLabelValues(nodeID, metaOrphanLabel).Set(float64(len(report.MetaOrphans)))
+	log.Info(ctx, "check orphan segments done",
+		mlog.Int("scannedFiles", report.ScannedFiles),
+		mlog.Int("checkedSegments", report.CheckedSegments),
+		mlog.Int("storageOrphans", len(report.StorageOrphans)),
+		mlog.Int("metaOrphans", len(report.MetaOrphans)),
+		mlog.String("cost", report.Cost))
+	return report, nil
+}
+
+// findStorageOrphans scans the binlog files and finds the segments missing from meta,
+// the segments with files modified within the missing tolerance are skipped since they may be registered later.
+func (gc *garbageCollector) findStorageOrphans(ctx context.Context, report *OrphanSegmentReport) error {
+	rootPath := gc.option.cli.RootPath()
+	snapshotMeta := gc.meta.GetSnapshotMeta()
+	orphans := make(map[int64]*OrphanSegment)
+	for _, prefix := range []string{common.SegmentInsertLogPath, common.SegmentStatslogPath, common.SegmentDeltaLogPath} {
+		err := gc.option.cli.WalkWithPrefix(ctx, path.Join(rootPath, prefix), true, func(chunkInfo *storage.ChunkObjectInfo) bool {
+			report.ScannedFiles++
+			collectionID, partitionID, segmentID, err := parseSegmentKeyFromLogPath(rootPath, chunkInfo.FilePath)
+			if err != nil {
+				mlog.Warn(ctx, "skip unrecognized segment file", mlog.String("filePath", chunkInfo.FilePath))
+				return true
+			}
+			if gc.meta.GetSegment(ctx, segmentID) != nil {
+				return true
+			}
+			orphan, ok := orphans[segmentID]
+			if !ok {
+				orphan = &OrphanSegment{CollectionID: collectionID, PartitionID: partitionID, SegmentID: segmentID}
+				orphans[segmentID] = orphan
+			}
+			orphan.files = append(orphan.files, chunkInfo.FilePath)
+			if chunkInfo.ModifyTime.After(orphan.modifyTime) {
+				orphan.modifyTime = chunkInfo.ModifyTime
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, orphan := range orphans {
+		if time.Since(orphan.modifyTime) <= gc.option.missingTolerance {
+			continue
+		}
+		if snapshotMeta != nil && snapshotMeta.IsSegmentGCBlocked(orphan.CollectionID, orphan.SegmentID) {
+			continue
+		}
+		orphan.FileNum = len(orphan.files)
+		report.StorageOrphans = append(report.StorageOrphans, orphan)
+	}
+	sort.Slice(report.StorageOrphans, func(i, j int) bool {
+		return report.StorageOrphans[i].SegmentID < report.StorageOrphans[j].SegmentID
+	})
+	return nil
+}
+
+// findMetaOrphans checks the first binlog of each field of the candidates exists on object storage.
+func (gc *garbageCollector) findMetaOrphans(ctx context.Context, candidates []*SegmentInfo, report *OrphanSegmentReport) error {
+	for _, segment := range candidates {
+		report.CheckedSegments++
+		missingFile := ""
+		for _, fieldBinlog := range segment.GetBinlogs() {
+			if len(fieldBinlog.GetBinlogs()) == 0 || fieldBinlog.GetBinlogs()[0].GetLogPath() == "" {
+				continue
+			}
+			logPath := fieldBinlog.GetBinlogs()[0].GetLogPath()
+			exist, err := gc.option.cli.Exist(ctx, logPath)
+			if err != nil {
+				return err
+			}
+			if !exist {
+				missingFile = logPath
+				break
+			}
+		}
+		if missingFile == "" {
+			continue
+		}
+		// the files of a flushed segment are only removed after it's dropped, so skip the segments not flushed any more.
+		if current := gc.meta.GetSegment(ctx, segment.GetID()); current == nil || !isOrphanCheckCandidate(current) {
+			continue
+		}
+		report.MetaOrphans = append(report.MetaOrphans, &OrphanSegment{
+			CollectionID: segment.GetCollectionID(),
+			PartitionID:  segment.GetPartitionID(),
+			SegmentID:    segment.GetID(),
+			Channel:      segment.GetInsertChannel(),
+			NumRows:      segment.GetNumOfRows(),
+			MissingFile:  missingFile,
+		})
+	}
+	return nil
+}
+
+// confirmMetaOrphans counts the consecutive checks finding each meta orphan,
+// the segments found consistent again start over.
+func (gc *garbageCollector) confirmMetaOrphans(report *OrphanSegmentReport) {
+	confirmations := make(map[int64]int, len(report.MetaOrphans))
+	for _, orphan := range report.MetaOrphans {
+		orphan.Confirmations = gc.metaOrphanConfirmations[orphan.SegmentID] + 1
+		confirmations[orphan.SegmentID] = orphan.Confirmations
+	}
+	gc.metaOrphanConfirmations = confirmations
+}
+
+// repairMetaOrphans drops the meta orphans confirmed by enough consecutive checks, at most the max repair number per check.
+// The missing file is checked again right before the drop, and the segments compacting or not flushed any more are skipped,
+// the failures are logged and left to the next check.
+func (gc *garbageCollector) repairMetaOrphans(ctx context.Context, report *OrphanSegmentReport) {
+	confirmTimes := Params.DataCoordCfg.GCOrphanSegmentRepairConfirmTimes.GetAsInt()
+	maxRepair := Params.DataCoordCfg.GCOrphanSegmentMaxRepairPerCheck.GetAsInt()
+	repaired := 0
+	for _, orphan := range report.MetaOrphans {
+		if repaired >= maxRepair {
+			mlog.Warn(ctx, "too many segments missing files, the rest are left to the next check",
+				mlog.Int("maxRepair", maxRepair),
+				mlog.Int("metaOrphans", len(report.MetaOrphans)))
+			return
+		}
+		if orphan.Confirmations < confirmTimes {
+			continue
+		}
+		if current := gc.meta.GetSegment(ctx, orphan.SegmentID); current == nil || !isOrphanCheckCandidate(current) {
+			continue
+		}
+		exist, err := gc.option.cli.Exist(ctx, orphan.MissingFile)
+		if err != nil || exist {
+			mlog.Warn(ctx, "skip dropping segment whose missing file is not confirmed",
+				mlog.Int64("segmentID", orphan.SegmentID),
+				mlog.String("missingFile", orphan.MissingFile),
+				mlog.Bool("exist", exist),
+				mlog.Err(err))
+			continue
+		}
+		if err := gc.meta.SetState(ctx, orphan.SegmentID, commonpb.SegmentState_Dropped); err != nil {
+			mlog.Warn(ctx, "failed to drop segment whose files are missing",
+				mlog.Int64("collectionID", orphan.CollectionID),
+				mlog.Int64("segmentID", orphan.SegmentID),
+				mlog.Err(err))
+			continue
+		}
+		mlog.Warn(ctx, "segment dropped since its files are missing from object storage",
+			mlog.Int64("collectionID", orphan.CollectionID),
+			mlog.Int64("segmentID", orphan.SegmentID),
+			mlog.Int64("numRows", orphan.NumRows),
+			mlog.String("missingFile", orphan.MissingFile),
+			mlog.Int("confirmations", orphan.Confirmations))
+		orphan.Repaired = true
+		repaired++
+	}
+}
+
+// getStorageOrphan returns the storage orphan of the last check, nil if not found.
+func (gc *garbageCollector) getStorageOrphan(segmentID int64) *OrphanSegment {
+	report := gc.GetOrphanSegmentReport()
+	if report == nil {
+		return nil
+	}
+	for _, orphan := range report.StorageOrphans {
+		if orphan.SegmentID == segmentID {
+			return orphan
+		}
+	}
+	return nil
+}
+
+// protectReregisteringOrphan keeps the files of the storage orphan from recycleUnusedBinlogFiles until the missing tolerance passes,
+// which is long enough for the import registering the segment again to read them.
+func (gc *garbageCollector) protectReregisteringOrphan(segmentID int64) {
+	gc.reregisteringOrphans.Insert(segmentID, time.Now().Add(gc.option.missingTolerance))
+}
+
+func (gc *garbageCollector) unprotectReregisteringOrphan(segmentID int64) {
+	gc.reregisteringOrphans.Remove(segmentID)
+}
+
+func (gc *garbageCollector) isReregisteringOrphan(segmentID int64) bool {
+	protectUntil, ok := gc.reregisteringOrphans.Get(segmentID)
+	if ok && time.Now().After(protectUntil) {
+		gc.reregisteringOrphans.Remove(segmentID)
+		return false
+	}
+	return ok
+}
+
+// CheckOrphanSegments runs an orphan segment check immediately, see garbageCollector.checkOrphanSegments.
+func (s *Server) CheckOrphanSegments(ctx context.Context, repair bool) (*OrphanSegmentReport, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return nil, err
+	}
+	if s.garbageCollector == nil || s.garbageCollector.option.cli == nil {
+		return nil, merr.WrapErrServiceUnavailable("object storage is not available for garbage collector")
+	}
+	return s.garbageCollector.checkOrphanSegments(ctx, repair)
+}
+
+// ReregisterOrphanSegment registers a storage orphan of the last check again, its binlogs are imported into its partition
+// by a binlog import, and the files are kept from the gc while importing. Returns the id of the import job.
+func (s *Server) ReregisterOrphanSegment(ctx context.Context, segmentID int64) (int64, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return 0, err
+	}
+	if s.garbageCollector == nil || s.garbageCollector.option.cli == nil {
+		return 0, merr.WrapErrServiceUnavailable("object storage is not available for garbage collector")
+	}
+	orphan := s.garbageCollector.getStorageOrphan(segmentID)
+	if orphan == nil {
+		return 0, merr.WrapErrSegmentNotFound(segmentID, "not a storage orphan of the last orphan segment check")
+	}
+	if s.meta.GetSegment(ctx, segmentID) != nil {
+		return 0, merr.WrapErrParameterInvalidMsg("segment %d is registered already", segmentID)
+	}
+	collection, err := s.handler.GetCollection(ctx, orphan.CollectionID)
+	if err != nil {
+		return 0, err
+	}
+	if collection == nil {
+		return 0, merr.WrapErrCollectionNotFound(orphan.CollectionID)
+	}
+	if !lo.Contains(collection.Partitions, orphan.PartitionID) {
+		return 0, merr.WrapErrPartitionNotFound(orphan.PartitionID)
+	}
+
+	rootPath := s.garbageCollector.option.cli.RootPath()
+	segmentPath := path.Join(fmt.Sprint(orphan.CollectionID), fmt.Sprint(orphan.PartitionID), fmt.Sprint(orphan.SegmentID))
+	insertPath := path.Join(rootPath, common.SegmentInsertLogPath, segmentPath)
+	deltaPath := path.Join(rootPath, common.SegmentDeltaLogPath, segmentPath)
+	paths := make([]string, 0, 2)
+	for _, prefix := range []string{insertPath, deltaPath} {
+		if lo.ContainsBy(orphan.files, func(file string) bool { return strings.HasPrefix(file, prefix+"/") }) {
+			paths = append(paths, prefix)
+		}
+	}
+	if len(paths) == 0 || paths[0] != insertPath {
+		return 0, merr.WrapErrParameterInvalidMsg("storage orphan %d has no insert binlog to register", segmentID)
+	}
+
+	s.garbageCollector.protectReregisteringOrphan(segmentID)
+	resp, err := s.ImportV2(ctx, &internalpb.ImportRequestInternal{
+		CollectionID:   orphan.CollectionID,
+		CollectionName: collection.Schema.GetName(),
+		PartitionIDs:   []int64{orphan.PartitionID},
+		ChannelNames:   collection.VChannelNames,
+		Schema:         collection.Schema,
+		Files:          []*internalpb.ImportFile{{Paths: paths}},
+		Options: []*commonpb.KeyValuePair{
+			{Key: importutilv2.BackupFlag, Value: "true"},
+			{Key: importutilv2.SegmentPathsFlag, Value: "true"},
+		},
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		s.garbageCollector.unprotectReregisteringOrphan(segmentID)
+		return 0, err
+	}
+	jobID, err := strconv.ParseInt(resp.GetJobID(), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	mlog.Info(ctx, "storage orphan segment is registered again by import",
+		mlog.Int64("collectionID", orphan.CollectionID),
+		mlog.Int64("partitionID", orphan.PartitionID),
+		mlog.Int64("segmentID", segmentID),
+		mlog.Int64("jobID", jobID))
+	return jobID, nil
+}
+
+// GetOrphanSegmentReport returns the report of the last orphan segment check.
+func (s *Server) GetOrphanSegmentReport(ctx context.Context) (*OrphanSegmentReport, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return nil, err
+	}
+	if s.garbageCollector == nil {
+		return nil, merr.WrapErrServiceUnavailable("garbage collector is not initialized")
+	}
+	return s.garbageCollector.GetOrphanSegmentReport(), nil
+}
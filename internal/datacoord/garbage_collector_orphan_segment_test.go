@@ -0,0 +1,201 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	catalogmocks "github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestParseSegmentKeyFromLogPath(t *testing.T) {
+	collectionID, partitionID, segmentID, err := parseSegmentKeyFromLogPath("root", "root/insert_log/100/10/1/101/1001")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{100, 10, 1}, []int64{collectionID, partitionID, segmentID})
+	_, _, segmentID, err = parseSegmentKeyFromLogPath("root", "root/delta_log/100/10/2/1001")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), segmentID)
+
+	_, _, _, err = parseSegmentKeyFromLogPath("root", "root/insert_log/100/10")
+	assert.Error(t, err)
+	_, _, _, err = parseSegmentKeyFromLogPath("root", "root/insert_log/100/abc/1/101/1001")
+	assert.Error(t, err)
+}
+
+func TestGarbageCollector_checkOrphanSegments(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	catalog := catalogmocks.NewDataCoordCatalog(t)
+	catalog.EXPECT().AlterSegments(mock.Anything, mock.Anything).Return(nil)
+	m := &meta{
+		catalog:  catalog,
+		segments: NewSegmentsInfo(),
+	}
+	for _, segment := range []*datapb.SegmentInfo{
+		{
+			ID: 1, CollectionID: 100, PartitionID: 10, InsertChannel: "ch1", NumOfRows: 100, State: commonpb.SegmentState_Flushed,
+			Binlogs: []*datapb.FieldBinlog{{FieldID: 101, Binlogs: []*datapb.Binlog{{LogPath: "root/insert_log/100/10/1/101/1001"}}}},
+		},
+		{
+			ID: 2, CollectionID: 100, PartitionID: 10, InsertChannel: "ch1", NumOfRows: 200, State: commonpb.SegmentState_Flushed,
+			Binlogs: []*datapb.FieldBinlog{{FieldID: 101, Binlogs: []*datapb.Binlog{{LogPath: "root/insert_log/100/10/2/101/1002"}}}},
+		},
+		// the growing segment is not checked.
+		{ID: 5, CollectionID: 100, PartitionID: 10, InsertChannel: "ch1", NumOfRows: 10, State: commonpb.SegmentState_Growing},
+	} {
+		m.segments.SetSegment(segment.GetID(), NewSegmentInfo(segment))
+	}
+
+	now := time.Now()
+	files := map[string]time.Time{
+		"root/insert_log/100/10/1/101/1001": now.Add(-48 * time.Hour),
+		"root/insert_log/100/10/3/101/1003": now.Add(-48 * time.Hour),
+		"root/delta_log/100/10/3/1004":      now.Add(-48 * time.Hour),
+		// the files within the missing tolerance may be registered later.
+		"root/insert_log/100/10/4/101/1005": now,
+		"root/insert_log/unknown":           now.Add(-48 * time.Hour),
+		"root/insert_log/100/10/5/101/1006": now.Add(-48 * time.Hour),
+	}
+	cm := mocks.NewChunkManager(t)
+	cm.EXPECT().RootPath().Return("root")
+	cm.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, true, mock.Anything).RunAndReturn(
+		func(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc) error {
+			for file, modifyTime := range files {
+				if strings.HasPrefix(file, prefix) {
+					walkFunc(&storage.ChunkObjectInfo{FilePath: file, ModifyTime: modifyTime})
+				}
+			}
+			return nil
+		})
+	cm.EXPECT().Exist(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, file string) (bool, error) {
+		_, ok := files[file]
+		return ok, nil
+	})
+	gc := newGarbageCollector(m, newMockHandler(), GcOption{
+		cli:              cm,
+		missingTolerance: 24 * time.Hour,
+	})
+	assert.Nil(t, gc.GetOrphanSegmentReport())
+
+	report, err := gc.checkOrphanSegments(ctx, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, report.ScannedFiles)
+	assert.Equal(t, 2, report.CheckedSegments)
+	assert.Len(t, report.StorageOrphans, 1)
+	assert.Equal(t, int64(3), report.StorageOrphans[0].SegmentID)
+	assert.Equal(t, 2, report.StorageOrphans[0].FileNum)
+	assert.False(t, report.StorageOrphans[0].Repaired)
+	assert.Len(t, report.MetaOrphans, 1)
+	assert.Equal(t, int64(2), report.MetaOrphans[0].SegmentID)
+	assert.Equal(t, "root/insert_log/100/10/2/101/1002", report.MetaOrphans[0].MissingFile)
+	assert.Equal(t, report, gc.GetOrphanSegmentReport())
+	assert.Equal(t, commonpb.SegmentState_Flushed, m.GetSegment(ctx, 2).GetState())
+
+	assert.Equal(t, 1, report.MetaOrphans[0].Confirmations)
+
+	// the meta orphans are dropped only after the consecutive confirmations, the storage orphans are left to the gc.
+	paramtable.Get().Save(Params.DataCoordCfg.GCOrphanSegmentRepairConfirmTimes.Key, "3")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.GCOrphanSegmentRepairConfirmTimes.Key)
+	report, err = gc.checkOrphanSegments(ctx, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.MetaOrphans[0].Confirmations)
+	assert.False(t, report.MetaOrphans[0].Repaired)
+	assert.False(t, report.StorageOrphans[0].Repaired)
+	assert.Equal(t, commonpb.SegmentState_Flushed, m.GetSegment(ctx, 2).GetState())
+
+	// the compacting segments are skipped and confirmed again from scratch.
+	m.SetSegmentsCompacting(ctx, []int64{2}, true)
+	report, err = gc.checkOrphanSegments(ctx, true)
+	assert.NoError(t, err)
+	assert.Empty(t, report.MetaOrphans)
+	m.SetSegmentsCompacting(ctx, []int64{2}, false)
+	for i := 1; i < 3; i++ {
+		report, err = gc.checkOrphanSegments(ctx, true)
+		assert.NoError(t, err)
+		assert.Equal(t, i, report.MetaOrphans[0].Confirmations)
+		assert.False(t, report.MetaOrphans[0].Repaired)
+	}
+
+	// at most the max repair number of segments are dropped by a check.
+	paramtable.Get().Save(Params.DataCoordCfg.GCOrphanSegmentMaxRepairPerCheck.Key, "0")
+	report, err = gc.checkOrphanSegments(ctx, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.MetaOrphans[0].Confirmations)
+	assert.False(t, report.MetaOrphans[0].Repaired)
+	paramtable.Get().Reset(Params.DataCoordCfg.GCOrphanSegmentMaxRepairPerCheck.Key)
+
+	report, err = gc.checkOrphanSegments(ctx, true)
+	assert.NoError(t, err)
+	assert.True(t, report.MetaOrphans[0].Repaired)
+	assert.False(t, report.StorageOrphans[0].Repaired)
+	assert.Equal(t, commonpb.SegmentState_Dropped, m.GetSegment(ctx, 2).GetState())
+
+	// the files of the storage orphans being registered again are kept from the gc.
+	assert.Equal(t, report.StorageOrphans[0], gc.getStorageOrphan(3))
+	assert.Nil(t, gc.getStorageOrphan(2))
+	assert.False(t, gc.isReregisteringOrphan(3))
+	gc.protectReregisteringOrphan(3)
+	assert.True(t, gc.isReregisteringOrphan(3))
+	gc.reregisteringOrphans.Insert(3, time.Now().Add(-time.Second))
+	assert.False(t, gc.isReregisteringOrphan(3))
+
+	// only one check runs at a time.
+	gc.orphanSegmentMu.Lock()
+	_, err = gc.checkOrphanSegments(ctx, false)
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+	gc.orphanSegmentMu.Unlock()
+}
+
+func TestServer_ReregisterOrphanSegment(t *testing.T) {
+	ctx := context.Background()
+	m := &meta{segments: NewSegmentsInfo()}
+	cm := mocks.NewChunkManager(t)
+	gc := newGarbageCollector(m, newMockHandler(), GcOption{cli: cm})
+	s := &Server{meta: m, garbageCollector: gc}
+	s.stateCode.Store(commonpb.StateCode_Healthy)
+
+	// only the storage orphans of the last check can be registered again.
+	_, err := s.ReregisterOrphanSegment(ctx, 3)
+	assert.ErrorIs(t, err, merr.ErrSegmentNotFound)
+
+	gc.orphanSegmentReport.Store(&OrphanSegmentReport{StorageOrphans: []*OrphanSegment{
+		{CollectionID: 100, PartitionID: 10, SegmentID: 3, files: []string{"root/delta_log/100/10/3/1004"}},
+	}})
+	s.handler = newMockHandlerWithMeta(m)
+	m.collections = typeutil.NewConcurrentMap[UniqueID, *collectionInfo]()
+	_, err = s.ReregisterOrphanSegment(ctx, 3)
+	assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+
+	m.collections.Insert(100, &collectionInfo{ID: 100, Partitions: []int64{10}})
+	cm.EXPECT().RootPath().Return("root")
+	_, err = s.ReregisterOrphanSegment(ctx, 3)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	assert.False(t, gc.isReregisteringOrphan(3))
+}
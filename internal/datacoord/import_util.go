@@ -775,7 +775,7 @@ func ListBinlogImportRequestFiles(ctx context.Context, cm storage.ChunkManager,
 	reqFiles []*internalpb.ImportFile, options []*commonpb.KeyValuePair,
 ) ([]*internalpb.ImportFile, error) {
 	isBackup := importutilv2.IsBackup(options)
-	if !isBackup {
+	if !isBackup || importutilv2.IsSegmentPaths(options) {
 		return reqFiles, nil
 	}
 	resFiles := make([]*internalpb.ImportFile, 0)
@@ -892,7 +892,7 @@ func createSortCompactionTask(ctx context.Context,
 		return nil, err
 	}
 
-	collectionTTL, err := common.GetCollectionTTLFromMap(collection.Properties)
+	collectionTTL, err := common.GetPartitionsTTLFromMap(collection.Properties, originSegment.GetPartitionID())
 	if err != nil {
 		log.Warn(ctx, "Failed to create sort compaction task because get collection ttl failed")
 		return nil, err
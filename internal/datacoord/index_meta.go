@@ -1174,6 +1174,18 @@ func (m *indexMeta) GetAllSegIndexes() map[int64]*model.SegmentIndex {
 	return segIndexes
 }
 
+// GetCollectionIndexSize returns the serialized size of the built segment indexes of each collection.
+func (m *indexMeta) GetCollectionIndexSize() map[int64]int64 {
+	ret := make(map[int64]int64)
+	for _, segIdx := range m.segmentBuildInfo.List() {
+		if segIdx.IsDeleted || !m.IsIndexExist(segIdx.CollectionID, segIdx.IndexID) {
+			continue
+		}
+		ret[segIdx.CollectionID] += int64(segIdx.IndexSerializedSize)
+	}
+	return ret
+}
+
 func (m *indexMeta) RemoveSegmentIndex(ctx context.Context, buildID UniqueID) error {
 	m.keyLock.Lock(buildID)
 	defer m.keyLock.Unlock(buildID)
@@ -1354,6 +1366,20 @@ func (m *indexMeta) AllDenseWithDiskIndex(collectionID int64, schema *schemapb.C
 	return len(vectorFields) == len(vectorFieldsWithDiskIndex)
 }
 
+// GetDenseVectorIndexTypes returns the index types of the indexed dense vector fields of the collection.
+func (m *indexMeta) GetDenseVectorIndexTypes(collectionID int64, schema *schemapb.CollectionSchema) []indexparamcheck.IndexType {
+	fieldIndexTypes := lo.SliceToMap(m.GetIndexesForCollection(collectionID, ""), func(t *model.Index) (int64, indexparamcheck.IndexType) {
+		return t.FieldID, GetIndexType(t.IndexParams)
+	})
+	indexTypes := make([]indexparamcheck.IndexType, 0, len(fieldIndexTypes))
+	for _, field := range typeutil.GetDenseVectorFieldSchemas(schema) {
+		if indexType, ok := fieldIndexTypes[field.FieldID]; ok {
+			indexTypes = append(indexTypes, indexType)
+		}
+	}
+	return indexTypes
+}
+
 func (m *indexMeta) HasIndex(collectionID int64) bool {
 	m.fieldIndexLock.RLock()
 	defer m.fieldIndexLock.RUnlock()
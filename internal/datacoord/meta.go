@@ -597,6 +597,7 @@ func (m *meta) GetQuotaInfo() *metricsinfo.DataCoordQuotaMetrics {
 	collectionRowsNum := make(map[UniqueID]map[commonpb.SegmentState]int64)
 	// collection id => l0 delta entry count
 	collectionL0RowCounts := make(map[UniqueID]int64)
+	collectionDeltaLogSize := make(map[UniqueID]int64)
 
 	segments := m.segments.GetSegments()
 	var total int64
@@ -609,6 +610,7 @@ func (m *meta) GetQuotaInfo() *metricsinfo.DataCoordQuotaMetrics {
 		if isSegmentHealthy(segment) && !segment.GetIsImporting() {
 			total += segmentSize
 			collectionBinlogSize[segment.GetCollectionID()] += segmentSize
+			collectionDeltaLogSize[segment.GetCollectionID()] += segment.EnsureStats().GetDeltaBinlogSize()
 
 			partBinlogSize, ok := partitionBinlogSize[segment.GetCollectionID()]
 			if !ok {
@@ -675,6 +677,7 @@ func (m *meta) GetQuotaInfo() *metricsinfo.DataCoordQuotaMetrics {
 	info.CollectionBinlogSize = collectionBinlogSize
 	info.PartitionsBinlogSize = partitionBinlogSize
 	info.CollectionL0RowCount = collectionL0RowCounts
+	info.CollectionDeltaLogSize = collectionDeltaLogSize
 
 	return info
 }
@@ -2401,15 +2404,81 @@ func getCompactionFallbackPositions(compactFromSegInfos []*SegmentInfo) (fallbac
 }
 
 func (m *meta) completeClusterCompactionMutation(t *datapb.CompactionTask, result *datapb.CompactionPlanResult) ([]*SegmentInfo, *segMetricMutation, error) {
-	metricMutation := &segMetricMutation{stateChange: make(segmentMetricStateChange)}
-	compactFromSegIDs := make([]int64, 0)
-	compactFromSegInfos := make([]*SegmentInfo, 0)
-	compactToSegInfos := make([]*SegmentInfo, 0)
+	compactToSegInfos, metricMutation, err := m.prepareClusterCompactionMutation(t, result)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := m.commitClusterCompactionSegments(compactToSegInfos); err != nil {
+		return nil, nil, err
+	}
+	mlog.Info(context.TODO(), "meta update: alter in memory meta after compaction - complete")
+	return compactToSegInfos, metricMutation, nil
+}
+
+// getClusteringCompactionCommitBatchSize returns the batch size to commit the output segments of a clustering compaction,
+// 0 means the output segments are committed at once.
+func getClusteringCompactionCommitBatchSize(segmentNum int) int {
+	batchSize := paramtable.Get().DataCoordCfg.ClusteringCompactionCommitBatchSize.GetAsInt()
+	if batchSize <= 0 || segmentNum <= batchSize {
+		return 0
+	}
+	return batchSize
+}
+
+// streamCompleteClusterCompactionMutation commits the output segments of a clustering compaction in batches of batchSize,
+// the meta lock is released between the batches so that the huge plans don't block the meta.
+// The output segments are invisible until they are all committed and indexed, and then they are made visible at once
+// by the clustering compaction task, so the batches committed before a failure are never visible and are retried
+// or dropped with the task.
+func (m *meta) streamCompleteClusterCompactionMutation(ctx context.Context, t *datapb.CompactionTask, result *datapb.CompactionPlanResult, verify bool, batchSize int) ([]*SegmentInfo, *segMetricMutation, error) {
+	compactToSegInfos, metricMutation, err := func() ([]*SegmentInfo, *segMetricMutation, error) {
+		m.segMu.Lock()
+		defer m.segMu.Unlock()
+		if verify {
+			if err := m.verifyCompactionResult(t, result); err != nil {
+				mlog.Warn(ctx, "compaction result rejected", mlog.Int64("planID", t.GetPlanID()), mlog.Err(err))
+				return nil, nil, err
+			}
+		}
+		return m.prepareClusterCompactionMutation(t, result)
+	}()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, batch := range lo.Chunk(compactToSegInfos, batchSize) {
+		err := func() error {
+			m.segMu.Lock()
+			defer m.segMu.Unlock()
+			// the input segments may be dropped with the collection between the batches.
+			if _, err := m.getClusterCompactionInputSegments(t); err != nil {
+				return err
+			}
+			return m.commitClusterCompactionSegments(batch)
+		}()
+		if err != nil {
+			mlog.Warn(ctx, "meta update: fail to commit the batch of compactTo segments",
+				mlog.Int64("planID", t.GetPlanID()),
+				mlog.Int("batchSize", len(batch)),
+				mlog.Err(err))
+			return nil, nil, err
+		}
+	}
+	mlog.Info(ctx, "meta update: commit compactTo segments in batches - complete",
+		mlog.Int64("planID", t.GetPlanID()),
+		mlog.Int("segmentNum", len(compactToSegInfos)),
+		mlog.Int("batchSize", batchSize))
+	return compactToSegInfos, metricMutation, nil
+}
 
+// getClusterCompactionInputSegments returns the clones of the input segments of the clustering compaction,
+// it fails if any of them is missing or dropped.
+func (m *meta) getClusterCompactionInputSegments(t *datapb.CompactionTask) ([]*SegmentInfo, error) {
+	compactFromSegInfos := make([]*SegmentInfo, 0, len(t.GetInputSegments()))
 	for _, segmentID := range t.GetInputSegments() {
 		segment := m.segments.GetSegment(segmentID)
 		if segment == nil {
-			return nil, nil, merr.WrapErrSegmentNotFound(segmentID)
+			return nil, merr.WrapErrSegmentNotFound(segmentID)
 		}
 
 		// Re-validate segment health to prevent race condition with drop collection
@@ -2419,14 +2488,26 @@ func (m *meta) completeClusterCompactionMutation(t *datapb.CompactionTask, resul
 				mlog.Int64("planID", t.GetPlanID()),
 				mlog.Int64("segmentID", segmentID),
 				mlog.String("state", segment.GetState().String()))
-			return nil, nil, merr.WrapErrSegmentNotFound(segmentID, "input segment was dropped")
+			return nil, merr.WrapErrSegmentNotFound(segmentID, "input segment was dropped")
 		}
 
-		cloned := segment.Clone()
+		compactFromSegInfos = append(compactFromSegInfos, segment.Clone())
+	}
+	return compactFromSegInfos, nil
+}
 
-		compactFromSegInfos = append(compactFromSegInfos, cloned)
-		compactFromSegIDs = append(compactFromSegIDs, cloned.GetID())
+// prepareClusterCompactionMutation builds the output segments of the clustering compaction without altering the meta.
+func (m *meta) prepareClusterCompactionMutation(t *datapb.CompactionTask, result *datapb.CompactionPlanResult) ([]*SegmentInfo, *segMetricMutation, error) {
+	metricMutation := &segMetricMutation{stateChange: make(segmentMetricStateChange)}
+	compactToSegInfos := make([]*SegmentInfo, 0)
+
+	compactFromSegInfos, err := m.getClusterCompactionInputSegments(t)
+	if err != nil {
+		return nil, nil, err
 	}
+	compactFromSegIDs := lo.Map(compactFromSegInfos, func(info *SegmentInfo, _ int) int64 {
+		return info.GetID()
+	})
 
 	fallbackStart, fallbackDml := getCompactionFallbackPositions(compactFromSegInfos)
 
@@ -2467,7 +2548,11 @@ func (m *meta) completeClusterCompactionMutation(t *datapb.CompactionTask, resul
 	}
 
 	mlog.Debug(context.TODO(), "meta update: prepare for meta mutation - complete")
+	return compactToSegInfos, metricMutation, nil
+}
 
+// commitClusterCompactionSegments saves the output segments of the clustering compaction to the catalog and the memory.
+func (m *meta) commitClusterCompactionSegments(compactToSegInfos []*SegmentInfo) error {
 	compactToInfos := lo.Map(compactToSegInfos, func(info *SegmentInfo, _ int) *datapb.SegmentInfo {
 		return info.SegmentInfo
 	})
@@ -2479,13 +2564,12 @@ func (m *meta) completeClusterCompactionMutation(t *datapb.CompactionTask, resul
 	// only add new segments
 	if err := m.catalog.AlterSegments(m.ctx, compactToInfos, binlogs...); err != nil {
 		mlog.Warn(context.TODO(), "fail to alter compactTo segments", mlog.Err(err))
-		return nil, nil, err
+		return err
 	}
 	lo.ForEach(compactToSegInfos, func(info *SegmentInfo, _ int) {
 		m.segments.SetSegment(info.GetID(), info)
 	})
-	mlog.Info(context.TODO(), "meta update: alter in memory meta after compaction - complete")
-	return compactToSegInfos, metricMutation, nil
+	return nil
 }
 
 func (m *meta) completeMixCompactionMutation(
@@ -2694,8 +2778,34 @@ func (m *meta) ValidateSegmentStateBeforeCompleteCompactionMutation(t *datapb.Co
 }
 
 func (m *meta) CompleteCompactionMutation(ctx context.Context, t *datapb.CompactionTask, result *datapb.CompactionPlanResult) ([]*SegmentInfo, *segMetricMutation, error) {
+	// the sorted output is required by the collection, it's checked even if the result verification is disabled.
+	if err := verifyCompactionSortedOutput(t, result); err != nil {
+		mlog.Warn(ctx, "compaction result rejected", mlog.Int64("planID", t.GetPlanID()), mlog.Err(err))
+		return nil, nil, err
+	}
+	verify := paramtable.Get().DataCoordCfg.CompactionVerifyResult.GetAsBool()
+	// the statslogs are checked before locking the meta since it reads the object storage.
+	if verify && paramtable.Get().DataCoordCfg.CompactionVerifyStatslogs.GetAsBool() {
+		if err := m.verifyCompactionStatslogs(ctx, t, result); err != nil {
+			mlog.Warn(ctx, "compaction result rejected", mlog.Int64("planID", t.GetPlanID()), mlog.Err(err))
+			return nil, nil, err
+		}
+	}
+
+	if t.GetType() == datapb.CompactionType_ClusteringCompaction {
+		if batchSize := getClusteringCompactionCommitBatchSize(len(result.GetSegments())); batchSize > 0 {
+			return m.streamCompleteClusterCompactionMutation(ctx, t, result, verify, batchSize)
+		}
+	}
+
 	m.segMu.Lock()
 	defer m.segMu.Unlock()
+	if verify {
+		if err := m.verifyCompactionResult(t, result); err != nil {
+			mlog.Warn(ctx, "compaction result rejected", mlog.Int64("planID", t.GetPlanID()), mlog.Err(err))
+			return nil, nil, err
+		}
+	}
 	switch t.GetType() {
 	case datapb.CompactionType_MixCompaction:
 		return m.completeMixCompactionMutation(t, result)
@@ -2705,6 +2815,8 @@ func (m *meta) CompleteCompactionMutation(ctx context.Context, t *datapb.Compact
 		return m.completeSortCompactionMutation(t, result)
 	case datapb.CompactionType_BumpSchemaVersionCompaction:
 		return m.completeBumpSchemaVersionCompactionMutation(t, result)
+	case datapb.CompactionType_LogMergeCompaction:
+		return m.completeLogMergeCompactionMutation(t, result)
 	}
 	return nil, nil, merr.WrapErrIllegalCompactionPlan("illegal compaction type")
 }
@@ -2972,6 +3084,14 @@ func (m *meta) GetChannelCheckpoint(vChannel string) *msgpb.MsgPosition {
 	return proto.Clone(cp).(*msgpb.MsgPosition)
 }
 
+// IsChannelCheckpointDropped returns whether the channel checkpoint is marked as dropped,
+// no data should be written into the channel after that.
+func (m *meta) IsChannelCheckpointDropped(vChannel string) bool {
+	m.channelCPs.RLock()
+	defer m.channelCPs.RUnlock()
+	return funcutil.IsDroppedChannelCheckpoint(m.channelCPs.checkpoints[vChannel])
+}
+
 func (m *meta) DropChannelCheckpoint(vChannel string) error {
 	m.channelCPs.channelLocks.Lock(vChannel)
 	defer m.channelCPs.channelLocks.Unlock(vChannel)
@@ -3174,6 +3294,9 @@ func updateSegStateAndPrepareMetrics(segToUpdate *SegmentInfo, targetState commo
 		mlog.String("new state", targetState.String()),
 		mlog.Int64("# of rows", segToUpdate.GetNumOfRows()))
 	metricMutation.append(segToUpdate.GetState(), targetState, segToUpdate.GetLevel(), segToUpdate.GetIsSorted(), segToUpdate.GetStorageVersion(), segmentMetricFormatLabel(segToUpdate), segToUpdate.GetNumOfRows())
+	if targetState == commonpb.SegmentState_Flushed && segToUpdate.GetState() != commonpb.SegmentState_Flushed {
+		segToUpdate.flushedTime = time.Now()
+	}
 	segToUpdate.State = targetState
 	if targetState == commonpb.SegmentState_Dropped {
 		segToUpdate.DroppedAt = uint64(time.Now().UnixNano())
@@ -3606,6 +3729,72 @@ func (m *meta) completeBumpSchemaVersionReplacementMutation(
 	return []*SegmentInfo{newSegment}, metricMutation, nil
 }
 
+// completeLogMergeCompactionMutation replaces the deltalogs and statslogs of the input segment
+// with the merged ones in place, the insert binlogs and the row count are kept.
+func (m *meta) completeLogMergeCompactionMutation(
+	t *datapb.CompactionTask,
+	result *datapb.CompactionPlanResult,
+) ([]*SegmentInfo, *segMetricMutation, error) {
+	metricMutation := &segMetricMutation{stateChange: make(segmentMetricStateChange)}
+
+	if len(t.GetInputSegments()) != 1 {
+		return nil, nil, merr.WrapErrIllegalCompactionPlan("log merge compaction should have exactly one input segment")
+	}
+	if len(result.GetSegments()) != 1 {
+		return nil, nil, merr.WrapErrIllegalCompactionPlan("log merge compaction result should have exactly one segment")
+	}
+
+	segmentID := t.GetInputSegments()[0]
+	resultSegment := result.GetSegments()[0]
+	if resultSegment.GetSegmentID() != segmentID {
+		return nil, nil, merr.WrapErrIllegalCompactionPlanMsg("log merge compaction result segment %d doesn't match the input segment %d",
+			resultSegment.GetSegmentID(), segmentID)
+	}
+	oldSegment := m.segments.GetSegment(segmentID)
+	if oldSegment == nil || !isSegmentHealthy(oldSegment) {
+		return nil, nil, merr.WrapErrSegmentNotFound(segmentID, "input segment was dropped")
+	}
+	if oldSegment.GetManifestPath() != "" {
+		return nil, nil, merr.WrapErrIllegalCompactionPlan("log merge compaction input segment should not have manifest")
+	}
+
+	cloned := oldSegment.Clone()
+	cloned.Deltalogs = resultSegment.GetDeltalogs()
+	cloned.Statslogs = resultSegment.GetField2StatslogPaths()
+	// only the delta and stats part of the statistics changes, the insert part is kept
+	// since the insert binlogs are not rewritten.
+	merged := storage.BuildStatsFromFieldBinlogs(nil, cloned.GetStatslogs(), cloned.GetBm25Statslogs(), cloned.GetDeltalogs())
+	stats := proto.Clone(oldSegment.EnsureStats()).(*datapb.Statistics)
+	stats.StatsBinlogSize = merged.GetStatsBinlogSize()
+	stats.DeltaBinlogSize = merged.GetDeltaBinlogSize()
+	stats.DeleteNumRows = merged.GetDeleteNumRows()
+	stats.DeltaBinlogCount = merged.GetDeltaBinlogCount()
+	stats.DeltaTimestampFrom = merged.GetDeltaTimestampFrom()
+	stats.DeltaTimestampTo = merged.GetDeltaTimestampTo()
+	cloned.Stats = stats
+
+	binlogsIncrement := metastore.BinlogsIncrement{
+		Segment: cloned.SegmentInfo,
+		UpdateMask: metastore.BinlogsUpdateMask{
+			WithoutBinlogs:       true,
+			WithoutDeltalogs:     false,
+			WithoutStatslogs:     false,
+			WithoutBm25Statslogs: true,
+		},
+	}
+	if err := m.catalog.AlterSegments(m.ctx, []*datapb.SegmentInfo{cloned.SegmentInfo}, binlogsIncrement); err != nil {
+		mlog.Warn(m.ctx, "fail to alter segment for log merge compaction", mlog.Err(err))
+		return nil, nil, err
+	}
+
+	m.segments.SetSegment(segmentID, cloned)
+	mlog.Info(m.ctx, "meta update: alter in memory meta after log merge compaction - complete",
+		mlog.Int64("segmentID", segmentID),
+		mlog.Int("deltalogNum", int(stats.GetDeltaBinlogCount())),
+		mlog.Int("statslogNum", GetBinlogCount(cloned.GetStatslogs())))
+	return []*SegmentInfo{cloned}, metricMutation, nil
+}
+
 func (m *meta) getSegmentsMetrics(collectionID int64) []*metricsinfo.Segment {
 	m.segMu.RLock()
 	defer m.segMu.RUnlock()
@@ -691,6 +691,60 @@ func (suite *MetaBasicSuite) TestCompleteCompactionMutation() {
 		suite.EqualValues(9, infos[0].GetSchemaVersion())
 	})
 
+	suite.Run("clustering compaction commits in batches", func() {
+		paramtable.Get().Save(paramtable.Get().DataCoordCfg.ClusteringCompactionCommitBatchSize.Key, "2")
+		defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.ClusteringCompactionCommitBatchSize.Key)
+
+		newResult := func() *datapb.CompactionPlanResult {
+			return &datapb.CompactionPlanResult{
+				Segments: lo.Map([]int64{3, 4, 5, 6}, func(segmentID int64, _ int) *datapb.CompactionSegment {
+					return &datapb.CompactionSegment{
+						SegmentID:           segmentID,
+						InsertLogs:          []*datapb.FieldBinlog{getFieldBinlogIDs(0, 50000+segmentID)},
+						Field2StatslogPaths: []*datapb.FieldBinlog{getFieldBinlogIDs(0, 60000+segmentID)},
+						NumOfRows:           1,
+					}
+				}),
+			}
+		}
+		task := &datapb.CompactionTask{
+			InputSegments: []UniqueID{1, 2},
+			Type:          datapb.CompactionType_ClusteringCompaction,
+			Schema:        &schemapb.CollectionSchema{Version: 1},
+		}
+
+		catalog := mocks2.NewDataCoordCatalog(suite.T())
+		catalog.EXPECT().AlterSegments(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+		m := &meta{
+			catalog:      catalog,
+			segments:     getLatestSegments(),
+			chunkManager: mockChMgr,
+		}
+		infos, mutation, err := m.CompleteCompactionMutation(context.TODO(), task, newResult())
+		suite.NoError(err)
+		suite.Len(infos, 4)
+		suite.NotNil(mutation)
+		for _, info := range infos {
+			segment := m.GetSegment(context.TODO(), info.GetID())
+			suite.Require().NotNil(segment)
+			suite.True(segment.GetIsInvisible())
+		}
+
+		// the batches committed before the failure stay invisible.
+		catalog = mocks2.NewDataCoordCatalog(suite.T())
+		catalog.EXPECT().AlterSegments(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		catalog.EXPECT().AlterSegments(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("mock")).Once()
+		m = &meta{
+			catalog:      catalog,
+			segments:     getLatestSegments(),
+			chunkManager: mockChMgr,
+		}
+		_, _, err = m.CompleteCompactionMutation(context.TODO(), task, newResult())
+		suite.Error(err)
+		suite.True(m.GetSegment(context.TODO(), 4).GetIsInvisible())
+		suite.Nil(m.GetSegment(context.TODO(), 5))
+	})
+
 	suite.Run("test mix complete rejects nil task schema", func() {
 		latestSegments := getLatestSegments()
 		compactToSeg := &datapb.CompactionSegment{
@@ -3239,6 +3293,99 @@ func (suite *MetaBasicSuite) TestCompleteCompactionMutation_DispatchesBumpSchema
 	suite.EqualValues(task.GetSchema().GetVersion(), infos[0].GetSchemaVersion())
 }
 
+func (suite *MetaBasicSuite) TestCompleteLogMergeCompactionMutation() {
+	insertLogs := []*datapb.FieldBinlog{getFieldBinlogIDsWithEntry(100, 5, 1, 2)}
+	bm25Logs := []*datapb.FieldBinlog{getFieldBinlogIDs(102, 30)}
+	makeSegments := func(manifest string) *SegmentsInfo {
+		segs := NewSegmentsInfo()
+		segs.SetSegment(1, &SegmentInfo{SegmentInfo: &datapb.SegmentInfo{
+			ID:            1,
+			CollectionID:  100,
+			PartitionID:   10,
+			State:         commonpb.SegmentState_Flushed,
+			Level:         datapb.SegmentLevel_L1,
+			NumOfRows:     10,
+			Binlogs:       insertLogs,
+			Bm25Statslogs: bm25Logs,
+			Statslogs:     []*datapb.FieldBinlog{getFieldBinlogIDs(100, 20, 21, 22)},
+			Deltalogs: []*datapb.FieldBinlog{{Binlogs: []*datapb.Binlog{
+				{LogID: 40, EntriesNum: 1, LogSize: 10, TimestampFrom: 100, TimestampTo: 100},
+				{LogID: 41, EntriesNum: 2, LogSize: 10, TimestampFrom: 101, TimestampTo: 102},
+			}}},
+			ManifestPath: manifest,
+		}})
+		return segs
+	}
+	task := &datapb.CompactionTask{
+		InputSegments: []int64{1},
+		Type:          datapb.CompactionType_LogMergeCompaction,
+	}
+	mergedDeltalogs := []*datapb.FieldBinlog{{Binlogs: []*datapb.Binlog{
+		{LogID: 50, EntriesNum: 3, LogSize: 15, TimestampFrom: 100, TimestampTo: 102},
+	}}}
+	mergedStatslogs := []*datapb.FieldBinlog{getFieldBinlogIDs(100, int64(storage.CompoundStatsType))}
+	result := &datapb.CompactionPlanResult{
+		Segments: []*datapb.CompactionSegment{{
+			SegmentID: 1,
+			// the insert binlogs returned by the worker are ignored.
+			InsertLogs:          []*datapb.FieldBinlog{getFieldBinlogIDs(100, 60)},
+			Deltalogs:           mergedDeltalogs,
+			Field2StatslogPaths: mergedStatslogs,
+		}},
+	}
+
+	suite.Run("replace only deltalogs and statslogs", func() {
+		catalog := mocks2.NewDataCoordCatalog(suite.T())
+		catalog.EXPECT().AlterSegments(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, segments []*datapb.SegmentInfo, increments ...metastore.BinlogsIncrement) error {
+				suite.Require().Len(increments, 1)
+				suite.True(increments[0].UpdateMask.WithoutBinlogs)
+				suite.True(increments[0].UpdateMask.WithoutBm25Statslogs)
+				suite.False(increments[0].UpdateMask.WithoutDeltalogs)
+				suite.False(increments[0].UpdateMask.WithoutStatslogs)
+				return nil
+			}).Once()
+		m := &meta{ctx: context.TODO(), catalog: catalog, segments: makeSegments("")}
+
+		infos, mutation, err := m.CompleteCompactionMutation(context.TODO(), task, result)
+		suite.NoError(err)
+		suite.NotNil(mutation)
+		suite.Require().Len(infos, 1)
+
+		segment := m.segments.GetSegment(1)
+		suite.Equal(insertLogs, segment.GetBinlogs())
+		suite.Equal(bm25Logs, segment.GetBm25Statslogs())
+		suite.Equal(mergedDeltalogs, segment.GetDeltalogs())
+		suite.Equal(mergedStatslogs, segment.GetStatslogs())
+		suite.EqualValues(10, segment.GetNumOfRows())
+		suite.EqualValues(1, segment.GetStats().GetDeltaBinlogCount())
+		suite.EqualValues(3, segment.GetStats().GetDeleteNumRows())
+	})
+
+	suite.Run("alter segments failed", func() {
+		catalog := mocks2.NewDataCoordCatalog(suite.T())
+		catalog.EXPECT().AlterSegments(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("mock")).Once()
+		segs := makeSegments("")
+		m := &meta{ctx: context.TODO(), catalog: catalog, segments: segs}
+
+		_, _, err := m.CompleteCompactionMutation(context.TODO(), task, result)
+		suite.Error(err)
+		suite.Len(m.segments.GetSegment(1).GetDeltalogs()[0].GetBinlogs(), 2)
+	})
+
+	suite.Run("illegal result", func() {
+		m := &meta{ctx: context.TODO(), catalog: mocks2.NewDataCoordCatalog(suite.T()), segments: makeSegments("")}
+		_, _, err := m.CompleteCompactionMutation(context.TODO(), task, &datapb.CompactionPlanResult{
+			Segments: []*datapb.CompactionSegment{{SegmentID: 2}},
+		})
+		suite.ErrorIs(err, merr.ErrIllegalCompactionPlan)
+
+		m = &meta{ctx: context.TODO(), catalog: mocks2.NewDataCoordCatalog(suite.T()), segments: makeSegments("manifest")}
+		_, _, err = m.CompleteCompactionMutation(context.TODO(), task, result)
+		suite.ErrorIs(err, merr.ErrIllegalCompactionPlan)
+	})
+}
+
 func TestMeta(t *testing.T) {
 	suite.Run(t, new(MetaBasicSuite))
 	suite.Run(t, new(MetaReloadSuite))
@@ -3325,12 +3472,14 @@ func TestMeta_Basic(t *testing.T) {
 
 		err = meta.SetState(context.TODO(), segID0_0, commonpb.SegmentState_Sealed)
 		assert.NoError(t, err)
+		assert.True(t, meta.GetHealthySegment(context.TODO(), segID0_0).flushedTime.IsZero())
 		err = meta.SetState(context.TODO(), segID0_0, commonpb.SegmentState_Flushed)
 		assert.NoError(t, err)
 
 		info0_0 = meta.GetHealthySegment(context.TODO(), segID0_0)
 		assert.NotNil(t, info0_0)
 		assert.EqualValues(t, commonpb.SegmentState_Flushed, info0_0.State)
+		assert.False(t, info0_0.flushedTime.IsZero())
 	})
 
 	t.Run("Test segment with kv fails", func(t *testing.T) {
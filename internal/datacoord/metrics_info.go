@@ -41,6 +41,22 @@ import (
 // getQuotaMetrics returns DataCoordQuotaMetrics.
 func (s *Server) getQuotaMetrics() *metricsinfo.DataCoordQuotaMetrics {
 	info := s.meta.GetQuotaInfo()
+	if indexMeta := s.meta.GetIndexMeta(); indexMeta != nil {
+		info.CollectionIndexSize = indexMeta.GetCollectionIndexSize()
+	}
+	if s.importMeta != nil {
+		// the requested disk size is released once the import job completes or fails
+		info.CollectionImportReservedSize = make(map[int64]int64)
+		for _, job := range s.importMeta.GetJobBy(context.TODO()) {
+			if size := job.GetRequestedDiskSize(); size > 0 {
+				info.TotalImportReservedSize += size
+				info.CollectionImportReservedSize[job.GetCollectionID()] += size
+			}
+		}
+	}
+	if pressures := s.smallSegmentPressures.Load(); pressures != nil {
+		info.CollectionSmallSegmentPressure = *pressures
+	}
 	return info
 }
 
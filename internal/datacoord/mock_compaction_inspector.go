@@ -68,6 +68,51 @@ func (_c *MockCompactionInspector_enqueueCompaction_Call) RunAndReturn(run func(
 	return _c
 }
 
+// getCleaningTasksJSON provides a mock function with no fields
+func (_m *MockCompactionInspector) getCleaningTasksJSON() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for getCleaningTasksJSON")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockCompactionInspector_getCleaningTasksJSON_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'getCleaningTasksJSON'
+type MockCompactionInspector_getCleaningTasksJSON_Call struct {
+	*mock.Call
+}
+
+// getCleaningTasksJSON is a helper method to define mock.On call
+func (_e *MockCompactionInspector_Expecter) getCleaningTasksJSON() *MockCompactionInspector_getCleaningTasksJSON_Call {
+	return &MockCompactionInspector_getCleaningTasksJSON_Call{Call: _e.mock.On("getCleaningTasksJSON")}
+}
+
+func (_c *MockCompactionInspector_getCleaningTasksJSON_Call) Run(run func()) *MockCompactionInspector_getCleaningTasksJSON_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCompactionInspector_getCleaningTasksJSON_Call) Return(_a0 string) *MockCompactionInspector_getCleaningTasksJSON_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCompactionInspector_getCleaningTasksJSON_Call) RunAndReturn(run func() string) *MockCompactionInspector_getCleaningTasksJSON_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // getCompactionInfo provides a mock function with given fields: ctx, signalID
 func (_m *MockCompactionInspector) getCompactionInfo(ctx context.Context, signalID int64) *compactionInfo {
 	ret := _m.Called(ctx, signalID)
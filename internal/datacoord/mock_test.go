@@ -817,6 +817,18 @@ func (s *mockMixCoord) CheckQueryNodeDistribution(ctx context.Context, req *quer
 	panic("implement me")
 }
 
+func (s *mockMixCoord) DecommissionNode(ctx context.Context, req *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error) {
+	panic("implement me")
+}
+
+func (s *mockMixCoord) ListReplicaSuggestions(ctx context.Context, req *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error) {
+	panic("implement me")
+}
+
+func (s *mockMixCoord) ApproveReplicaSuggestions(ctx context.Context, req *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error) {
+	panic("implement me")
+}
+
 func (s *mockMixCoord) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest) (*commonpb.Status, error) {
 	panic("implement me")
 }
@@ -882,6 +894,10 @@ func (s *mockMixCoord) ManualCompaction(ctx context.Context, req *milvuspb.Manua
 	panic("implement me")
 }
 
+func (s *mockMixCoord) ReindexSegments(ctx context.Context, req *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error) {
+	panic("implement me")
+}
+
 func (s *mockMixCoord) GetCompactionState(ctx context.Context, req *milvuspb.GetCompactionStateRequest) (*milvuspb.GetCompactionStateResponse, error) {
 	panic("implement me")
 }
@@ -1070,6 +1086,10 @@ func (s *mockMixCoord) ListRestoreSnapshotJobs(ctx context.Context, req *datapb.
 	panic("implement me")
 }
 
+func (s *mockMixCoord) CloneCollection(ctx context.Context, req *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error) {
+	panic("implement me")
+}
+
 func (s *mockMixCoord) ClientHeartbeat(ctx context.Context, req *milvuspb.ClientHeartbeatRequest) (*milvuspb.ClientHeartbeatResponse, error) {
 	panic("implement me")
 }
@@ -0,0 +1,214 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// segmentReadRateWeight is the weight of the latest sample in the exponentially weighted read rate.
+const segmentReadRateWeight = 0.5
+
+// segmentAccess is the read statistics of a loaded sealed segment.
+type segmentAccess struct {
+	count        int64     // the accumulated read count reported by the query coord
+	rate         float64   // the exponentially weighted read rate in reads per minute
+	sampleTime   time.Time // the time of the latest sample
+	lastReadTime time.Time // the time of the latest sample with new reads
+}
+
+// segmentAccessStats aggregates the read counts of the sealed segments fed by the query nodes through the query coord.
+// The read frequency is used to prefer compacting the cold segments, and exposed for the tiering decisions.
+// All the methods are no-op on nil.
+type segmentAccessStats struct {
+	mu    sync.RWMutex
+	stats map[int64]*segmentAccess
+}
+
+func newSegmentAccessStats() *segmentAccessStats {
+	return &segmentAccessStats{
+		stats: make(map[int64]*segmentAccess),
+	}
+}
+
+// update updates the read rates by the accumulated read counts of the loaded segments,
+// the segments not loaded any more are removed.
+func (s *segmentAccessStats) update(counts map[int64]int64, now time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := make(map[int64]*segmentAccess, len(counts))
+	for segmentID, count := range counts {
+		prev, ok := s.stats[segmentID]
+		if !ok {
+			// the first sample is taken as the baseline, the read rate is unknown yet
+			stats[segmentID] = &segmentAccess{count: count, sampleTime: now}
+			continue
+		}
+		delta := count - prev.count
+		if delta < 0 {
+			// the counts are reset, e.g. the query coord is restarted
+			delta = count
+		}
+		access := &segmentAccess{count: count, rate: prev.rate, sampleTime: now, lastReadTime: prev.lastReadTime}
+		if elapsed := now.Sub(prev.sampleTime).Minutes(); elapsed > 0 {
+			access.rate = segmentReadRateWeight*float64(delta)/elapsed + (1-segmentReadRateWeight)*prev.rate
+		}
+		if delta > 0 {
+			access.lastReadTime = now
+		}
+		stats[segmentID] = access
+	}
+	s.stats = stats
+}
+
+func (s *segmentAccessStats) reset() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats = make(map[int64]*segmentAccess)
+}
+
+// readRate returns the read rate of the segment in reads per minute,
+// the segments not loaded are never read so their read rate is zero.
+func (s *segmentAccessStats) readRate(segmentID int64) float64 {
+	access, _ := s.get(segmentID)
+	return access.rate
+}
+
+// get returns a copy of the read statistics of the segment, false if the segment is not loaded.
+func (s *segmentAccessStats) get(segmentID int64) (segmentAccess, bool) {
+	if s == nil {
+		return segmentAccess{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	access, ok := s.stats[segmentID]
+	if !ok {
+		return segmentAccess{}, false
+	}
+	return *access, true
+}
+
+// sortPlansColdFirst orders the compaction plans by the hottest input segment of each plan,
+// so the plans of the cold segments are submitted first and the hot ones are left to the next trigger if the queue is full.
+func (s *segmentAccessStats) sortPlansColdFirst(plans []*typeutil.Pair[int64, []int64]) {
+	if s == nil || len(plans) < 2 {
+		return
+	}
+	hotness := make(map[*typeutil.Pair[int64, []int64]]float64, len(plans))
+	for _, plan := range plans {
+		for _, segmentID := range plan.B {
+			if rate := s.readRate(segmentID); rate > hotness[plan] {
+				hotness[plan] = rate
+			}
+		}
+	}
+	sort.SliceStable(plans, func(i, j int) bool { return hotness[plans[i]] < hotness[plans[j]] })
+}
+
+// SegmentAccessStat is the read statistics of a sealed segment.
+type SegmentAccessStat struct {
+	SegmentID   int64 `json:"segment_id"`
+	PartitionID int64 `json:"partition_id"`
+	Loaded      bool  `json:"loaded"`
+	ReadCount   int64 `json:"read_count"`
+	// ReadRate is the exponentially weighted read rate in reads per minute
+	ReadRate     float64   `json:"read_rate"`
+	LastReadTime time.Time `json:"last_read_time,omitempty"`
+	Cold         bool      `json:"cold"`
+}
+
+// GetSegmentAccessStats returns the read statistics of the flushed segments of the collection,
+// a segment is cold if its read rate is not greater than dataCoord.segmentAccessStats.coldReadRate.
+func (s *Server) GetSegmentAccessStats(ctx context.Context, collectionID int64) ([]*SegmentAccessStat, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return nil, err
+	}
+	if !Params.DataCoordCfg.SegmentAccessStatsEnabled.GetAsBool() {
+		return nil, merr.WrapErrServiceUnavailable("segment access stats is disabled")
+	}
+	if s.meta.GetCollection(collectionID) == nil {
+		return nil, merr.WrapErrCollectionNotFound(collectionID)
+	}
+
+	coldReadRate := Params.DataCoordCfg.SegmentAccessColdReadRate.GetAsFloat()
+	segments := s.meta.SelectSegments(ctx, WithCollection(collectionID), SegmentFilterFunc(isFlushed))
+	stats := make([]*SegmentAccessStat, 0, len(segments))
+	for _, segment := range segments {
+		stat := &SegmentAccessStat{
+			SegmentID:   segment.GetID(),
+			PartitionID: segment.GetPartitionID(),
+		}
+		if access, ok := s.segmentAccessStats.get(segment.GetID()); ok {
+			stat.Loaded = true
+			stat.ReadCount = access.count
+			stat.ReadRate = access.rate
+			stat.LastReadTime = access.lastReadTime
+		}
+		stat.Cold = stat.ReadRate <= coldReadRate
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].SegmentID < stats[j].SegmentID })
+	return stats, nil
+}
+
+func (s *Server) startSegmentAccessStatsLoop(ctx context.Context) {
+	s.serverLoopWg.Add(1)
+	go s.segmentAccessStatsLoop(ctx)
+}
+
+// segmentAccessStatsLoop collects the read counts of the loaded segments from the query coord periodically.
+func (s *Server) segmentAccessStatsLoop(ctx context.Context) {
+	defer s.serverLoopWg.Done()
+	ticker := time.NewTicker(Params.DataCoordCfg.SegmentAccessStatsInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			mlog.Info(ctx, "segment access stats loop exit")
+			return
+		case <-ticker.C:
+		}
+		if !Params.DataCoordCfg.SegmentAccessStatsEnabled.GetAsBool() {
+			s.segmentAccessStats.reset()
+			continue
+		}
+		s.collectSegmentAccessStats(ctx, time.Now())
+	}
+}
+
+func (s *Server) collectSegmentAccessStats(ctx context.Context, now time.Time) {
+	resp, err := s.mixCoord.ListLoadedSegments(ctx, &querypb.ListLoadedSegmentsRequest{WithAccessCounts: true})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		mlog.Warn(ctx, "failed to collect segment access stats", mlog.Err(err))
+		return
+	}
+	s.segmentAccessStats.update(resp.GetAccessCounts(), now)
+}
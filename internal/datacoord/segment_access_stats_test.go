@@ -0,0 +1,143 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	mocks2 "github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestSegmentAccessStats(t *testing.T) {
+	stats := newSegmentAccessStats()
+	now := time.Now()
+
+	// the first sample is the baseline
+	stats.update(map[int64]int64{1: 100, 2: 100}, now)
+	assert.Zero(t, stats.readRate(1))
+
+	now = now.Add(time.Minute)
+	stats.update(map[int64]int64{1: 160, 2: 100, 3: 10}, now)
+	assert.InDelta(t, 30, stats.readRate(1), 0.01)
+	assert.Zero(t, stats.readRate(2))
+	assert.Zero(t, stats.readRate(3))
+	access, ok := stats.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, now, access.lastReadTime)
+	access, ok = stats.get(2)
+	assert.True(t, ok)
+	assert.True(t, access.lastReadTime.IsZero())
+
+	// the counts are reset, and the released segments are removed
+	now = now.Add(time.Minute)
+	stats.update(map[int64]int64{1: 20}, now)
+	assert.InDelta(t, 25, stats.readRate(1), 0.01)
+	_, ok = stats.get(2)
+	assert.False(t, ok)
+
+	plans := []*typeutil.Pair[int64, []int64]{
+		{A: 1, B: []int64{1, 4}},
+		{A: 2, B: []int64{5, 6}},
+		{A: 3, B: []int64{7}},
+	}
+	stats.sortPlansColdFirst(plans)
+	assert.Equal(t, []int64{2, 3, 1}, []int64{plans[0].A, plans[1].A, plans[2].A})
+
+	stats.reset()
+	assert.Zero(t, stats.readRate(1))
+
+	var nilStats *segmentAccessStats
+	nilStats.update(map[int64]int64{1: 1}, now)
+	nilStats.sortPlansColdFirst(plans)
+	assert.Zero(t, nilStats.readRate(1))
+}
+
+func TestServer_GetSegmentAccessStats(t *testing.T) {
+	ctx := context.Background()
+	Params.Save(Params.DataCoordCfg.SegmentAccessStatsEnabled.Key, "true")
+	defer Params.Reset(Params.DataCoordCfg.SegmentAccessStatsEnabled.Key)
+
+	segments := NewSegmentsInfo()
+	for _, segment := range []*datapb.SegmentInfo{
+		{ID: 1, CollectionID: 100, PartitionID: 10, State: commonpb.SegmentState_Flushed},
+		{ID: 2, CollectionID: 100, PartitionID: 10, State: commonpb.SegmentState_Flushed},
+		{ID: 3, CollectionID: 100, PartitionID: 10, State: commonpb.SegmentState_Growing},
+	} {
+		segments.SetSegment(segment.GetID(), NewSegmentInfo(segment))
+	}
+	mixCoord := mocks2.NewMixCoord(t)
+	s := &Server{
+		meta: &meta{
+			collections: typeutil.NewConcurrentMap[UniqueID, *collectionInfo](),
+			segments:    segments,
+		},
+		mixCoord:           mixCoord,
+		segmentAccessStats: newSegmentAccessStats(),
+	}
+	s.meta.AddCollection(&collectionInfo{ID: 100})
+	s.stateCode.Store(commonpb.StateCode_Healthy)
+
+	now := time.Now()
+	mixCoord.EXPECT().ListLoadedSegments(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *querypb.ListLoadedSegmentsRequest) (*querypb.ListLoadedSegmentsResponse, error) {
+			assert.True(t, req.GetWithAccessCounts())
+			return &querypb.ListLoadedSegmentsResponse{
+				Status:       merr.Success(),
+				SegmentIDs:   []int64{1},
+				AccessCounts: map[int64]int64{1: 10},
+			}, nil
+		}).Once()
+	s.collectSegmentAccessStats(ctx, now)
+	mixCoord.EXPECT().ListLoadedSegments(mock.Anything, mock.Anything).Return(&querypb.ListLoadedSegmentsResponse{
+		Status:       merr.Success(),
+		SegmentIDs:   []int64{1},
+		AccessCounts: map[int64]int64{1: 130},
+	}, nil).Once()
+	s.collectSegmentAccessStats(ctx, now.Add(time.Minute))
+	// the stats are kept if failed to collect
+	mixCoord.EXPECT().ListLoadedSegments(mock.Anything, mock.Anything).Return(nil, merr.ErrServiceNotReady).Once()
+	s.collectSegmentAccessStats(ctx, now.Add(2*time.Minute))
+
+	stats, err := s.GetSegmentAccessStats(ctx, 100)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, int64(1), stats[0].SegmentID)
+	assert.True(t, stats[0].Loaded)
+	assert.Equal(t, int64(130), stats[0].ReadCount)
+	assert.InDelta(t, 60, stats[0].ReadRate, 0.01)
+	assert.False(t, stats[0].Cold)
+	assert.Equal(t, int64(2), stats[1].SegmentID)
+	assert.False(t, stats[1].Loaded)
+	assert.True(t, stats[1].Cold)
+
+	_, err = s.GetSegmentAccessStats(ctx, 200)
+	assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+
+	Params.Save(Params.DataCoordCfg.SegmentAccessStatsEnabled.Key, "false")
+	_, err = s.GetSegmentAccessStats(ctx, 100)
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+}
@@ -301,6 +301,37 @@ func sealByBlockingL0(meta *meta) channelSealPolicy {
 	}
 }
 
+// sealByGrowingAge seals the growing segments older than the configured max age
+// once the channel checkpoint lags behind the max age, since the old growing segments
+// keep the checkpoint from moving forward until they are flushed.
+func sealByGrowingAge(meta *meta) channelSealPolicy {
+	return func(channel string, segments []*SegmentInfo, ts Timestamp) ([]*SegmentInfo, string) {
+		maxAge := paramtable.Get().DataCoordCfg.GrowingSegmentMaxAge.GetAsDuration(time.Second)
+		if maxAge < 0 || len(segments) == 0 {
+			return nil, ""
+		}
+		cp := meta.GetChannelCheckpoint(channel)
+		if cp == nil {
+			return nil, ""
+		}
+
+		now, _ := tsoutil.ParseTS(ts)
+		cpTime, _ := tsoutil.ParseTS(cp.GetTimestamp())
+		if now.Sub(cpTime) < maxAge {
+			return nil, ""
+		}
+
+		result := lo.Filter(segments, func(segment *SegmentInfo, _ int) bool {
+			if segment.GetState() != commonpb.SegmentState_Growing || segment.GetStartPosition() == nil {
+				return false
+			}
+			startTime, _ := tsoutil.ParseTS(segment.GetStartPosition().GetTimestamp())
+			return now.Sub(startTime) >= maxAge
+		})
+		return result, fmt.Sprintf("seal segments due to growing age, checkpoint: %v, now: %v, max age: %v", cpTime, now, maxAge)
+	}
+}
+
 // sortSegmentsByLastExpires sort segmentStatus with lastExpireTime ascending order
 func sortSegmentsByLastExpires(segs []*SegmentInfo) {
 	sort.Slice(segs, func(i, j int) bool {
@@ -373,3 +373,48 @@ func Test_sealByBlockingL0(t *testing.T) {
 		})
 	}
 }
+
+func Test_sealByGrowingAge(t *testing.T) {
+	paramtable.Init()
+	pt := paramtable.Get()
+
+	now := time.Now()
+	tsOf := func(d time.Duration) uint64 {
+		return tsoutil.ComposeTSByTime(now.Add(-d))
+	}
+	newGrowing := func(id int64, age time.Duration) *SegmentInfo {
+		return &SegmentInfo{SegmentInfo: &datapb.SegmentInfo{
+			ID:            id,
+			InsertChannel: "channel_1",
+			State:         commonpb.SegmentState_Growing,
+			StartPosition: &msgpb.MsgPosition{Timestamp: tsOf(age)},
+		}}
+	}
+	growing := []*SegmentInfo{
+		newGrowing(1, 2*time.Hour),
+		newGrowing(2, 10*time.Minute),
+		{SegmentInfo: &datapb.SegmentInfo{ID: 3, State: commonpb.SegmentState_Growing}},
+	}
+
+	m := &meta{channelCPs: newChannelCps()}
+	m.channelCPs.checkpoints["channel_1"] = &msgpb.MsgPosition{ChannelName: "channel_1", Timestamp: tsOf(2 * time.Hour)}
+	ts := tsoutil.ComposeTSByTime(now)
+
+	sealedIDs := func(channel string) []int64 {
+		result, _ := sealByGrowingAge(m)(channel, growing, ts)
+		return lo.Map(result, func(segment *SegmentInfo, _ int) int64 { return segment.GetID() })
+	}
+
+	// disabled by default
+	assert.Empty(t, sealedIDs("channel_1"))
+
+	pt.Save(pt.DataCoordCfg.GrowingSegmentMaxAge.Key, "3600")
+	defer pt.Reset(pt.DataCoordCfg.GrowingSegmentMaxAge.Key)
+	assert.ElementsMatch(t, []int64{1}, sealedIDs("channel_1"))
+	// no checkpoint of the channel
+	assert.Empty(t, sealedIDs("channel_2"))
+
+	// checkpoint is not lagging behind
+	m.channelCPs.checkpoints["channel_1"].Timestamp = tsOf(time.Minute)
+	assert.Empty(t, sealedIDs("channel_1"))
+}
@@ -54,6 +54,8 @@ type SegmentInfo struct {
 	lastFlushTime   time.Time
 	isCompacting    bool
 	lastWrittenTime time.Time
+	// the time the segment turned flushed, not persisted, zero for the segments recovered from the catalog
+	flushedTime time.Time
 }
 
 // EnsureStats returns a non-nil Statistics view for read-only aggregate
@@ -381,6 +383,7 @@ func (s *SegmentInfo) Clone(opts ...SegmentInfoOption) *SegmentInfo {
 		lastFlushTime:   s.lastFlushTime,
 		isCompacting:    s.isCompacting,
 		lastWrittenTime: s.lastWrittenTime,
+		flushedTime:     s.flushedTime,
 	}
 	for _, opt := range opts {
 		opt(cloned)
@@ -396,6 +399,7 @@ func (s *SegmentInfo) ShadowClone(opts ...SegmentInfoOption) *SegmentInfo {
 		lastFlushTime:   s.lastFlushTime,
 		isCompacting:    s.isCompacting,
 		lastWrittenTime: s.lastWrittenTime,
+		flushedTime:     s.flushedTime,
 	}
 	for _, opt := range opts {
 		opt(cloned)
@@ -25,6 +25,7 @@ import (
 
 	"github.com/samber/lo"
 	"go.opentelemetry.io/otel"
+	"golang.org/x/time/rate"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
@@ -135,6 +136,8 @@ type SegmentManager struct {
 	channelLock     *lock.KeyLock[string]
 	channel2Growing *typeutil.ConcurrentMap[string, typeutil.UniqueSet]
 	channel2Sealed  *typeutil.ConcurrentMap[string, typeutil.UniqueSet]
+	// sealDeferredTimes is the time the seal of the growing segments is first deferred by the seal compaction barrier.
+	sealDeferredTimes *typeutil.ConcurrentMap[int64, time.Time]
 
 	// Policies
 	estimatePolicy      calUpperLimitPolicy
@@ -225,6 +228,7 @@ func defaultChannelSealPolicy(meta *meta) []channelSealPolicy {
 	return []channelSealPolicy{
 		sealByTotalGrowingSegmentsSize(),
 		sealByBlockingL0(meta),
+		sealByGrowingAge(meta),
 	}
 }
 
@@ -241,6 +245,7 @@ func newSegmentManager(meta *meta, allocator allocator.Allocator, opts ...allocO
 		channelLock:         lock.NewKeyLock[string](),
 		channel2Growing:     typeutil.NewConcurrentMap[string, typeutil.UniqueSet](),
 		channel2Sealed:      typeutil.NewConcurrentMap[string, typeutil.UniqueSet](),
+		sealDeferredTimes:   typeutil.NewConcurrentMap[int64, time.Time](),
 		estimatePolicy:      defaultCalUpperLimitPolicy(),
 		allocPolicy:         defaultAllocatePolicy(),
 		segmentSealPolicies: defaultSegmentSealPolicy(),
@@ -478,6 +483,7 @@ func (s *SegmentManager) DropSegment(ctx context.Context, channel string, segmen
 	if sealed, ok := s.channel2Sealed.Get(channel); ok {
 		sealed.Remove(segmentID)
 	}
+	s.sealDeferredTimes.Remove(segmentID)
 
 	segment := s.meta.GetHealthySegment(ctx, segmentID)
 	if segment == nil {
@@ -652,6 +658,11 @@ func (s *SegmentManager) tryToSealSegment(ctx context.Context, ts Timestamp, cha
 		// change shouldSeal to segment seal policy logic
 		for _, policy := range s.segmentSealPolicies {
 			if shouldSeal, reason := policy.ShouldSeal(info, ts); shouldSeal {
+				if s.deferSealByCompactionBarrier(info, time.Now()) {
+					mlog.RatedInfo(context.TODO(), rate.Every(time.Minute), "seal segment deferred by compaction barrier",
+						mlog.Int64("segmentID", info.GetID()), mlog.String("reason", reason))
+					break
+				}
 				mlog.Info(context.TODO(), "Seal Segment for policy matched", mlog.Int64("segmentID", info.GetID()), mlog.String("reason", reason))
 				if err := s.meta.SetState(ctx, id, commonpb.SegmentState_Sealed); err != nil {
 					setStateErr = err
@@ -660,6 +671,7 @@ func (s *SegmentManager) tryToSealSegment(ctx context.Context, ts Timestamp, cha
 				sealedSegments[id] = struct{}{}
 				sealed.Insert(id)
 				growing.Remove(id)
+				s.sealDeferredTimes.Remove(id)
 				break
 			}
 		}
@@ -684,11 +696,31 @@ func (s *SegmentManager) tryToSealSegment(ctx context.Context, ts Timestamp, cha
 			sealedSegments[info.GetID()] = struct{}{}
 			sealed.Insert(info.GetID())
 			growing.Remove(info.GetID())
+			s.sealDeferredTimes.Remove(info.GetID())
 		}
 	}
 	return nil
 }
 
+// deferSealByCompactionBarrier returns whether the seal of the tiny growing segment is deferred, since a compaction of
+// the same partition completed just now and the sealed segment would become a small segment to compact immediately.
+// The deferred segment keeps taking the following allocations, and its seal is deferred for at most the max wait.
+func (s *SegmentManager) deferSealByCompactionBarrier(segment *SegmentInfo, now time.Time) bool {
+	window := Params.DataCoordCfg.SegmentSealCompactionBarrierWindow.GetAsDuration(time.Second)
+	if window <= 0 || s.meta.compactionTaskMeta == nil {
+		return false
+	}
+	sizeRatio := Params.DataCoordCfg.SegmentSealCompactionBarrierSizeRatio.GetAsFloat()
+	if float64(segment.GetNumOfRows()) >= sizeRatio*float64(segment.GetMaxRowNum()) {
+		return false
+	}
+	if now.Sub(s.meta.compactionTaskMeta.GetLastCompletedTime(segment.GetPartitionID())) > window {
+		return false
+	}
+	deferredAt, _ := s.sealDeferredTimes.GetOrInsert(segment.GetID(), now)
+	return now.Sub(deferredAt) < Params.DataCoordCfg.SegmentSealCompactionBarrierMaxWait.GetAsDuration(time.Second)
+}
+
 // DropSegmentsOfChannel drops all segments in a channel
 func (s *SegmentManager) DropSegmentsOfChannel(ctx context.Context, channel string) {
 	s.channelLock.Lock(channel)
@@ -1176,3 +1176,47 @@ func TestAllocNewGrowingSegment_ManifestPath(t *testing.T) {
 		assert.Empty(t, segment.ManifestPath)
 	})
 }
+
+func TestDeferSealByCompactionBarrier(t *testing.T) {
+	paramtable.Init()
+	catalog := mocks.NewDataCoordCatalog(t)
+	catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveCompactionTask(mock.Anything, mock.Anything).Return(nil)
+	compactionTaskMeta, err := newCompactionTaskMeta(context.TODO(), catalog)
+	assert.NoError(t, err)
+	segmentManager := &SegmentManager{
+		meta:              &meta{compactionTaskMeta: compactionTaskMeta},
+		sealDeferredTimes: typeutil.NewConcurrentMap[int64, time.Time](),
+	}
+
+	now := time.Now()
+	tiny := NewSegmentInfo(&datapb.SegmentInfo{ID: 1, PartitionID: 10, NumOfRows: 10, MaxRowNum: 1000})
+	assert.False(t, segmentManager.deferSealByCompactionBarrier(tiny, now))
+
+	err = compactionTaskMeta.SaveCompactionTask(context.TODO(), &datapb.CompactionTask{
+		PlanID:      100,
+		PartitionID: 10,
+		Type:        datapb.CompactionType_MixCompaction,
+		State:       datapb.CompactionTaskState_completed,
+		EndTime:     now.Unix(),
+	})
+	assert.NoError(t, err)
+	assert.True(t, segmentManager.deferSealByCompactionBarrier(tiny, now))
+
+	// large segments and the segments of other partitions are never deferred
+	large := NewSegmentInfo(&datapb.SegmentInfo{ID: 2, PartitionID: 10, NumOfRows: 500, MaxRowNum: 1000})
+	assert.False(t, segmentManager.deferSealByCompactionBarrier(large, now))
+	other := NewSegmentInfo(&datapb.SegmentInfo{ID: 3, PartitionID: 11, NumOfRows: 10, MaxRowNum: 1000})
+	assert.False(t, segmentManager.deferSealByCompactionBarrier(other, now))
+
+	// the seal is deferred for at most the max wait
+	paramtable.Get().Save(Params.DataCoordCfg.SegmentSealCompactionBarrierMaxWait.Key, "0")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.SegmentSealCompactionBarrierMaxWait.Key)
+	assert.False(t, segmentManager.deferSealByCompactionBarrier(tiny, now))
+
+	// the barrier is disabled by a zero window
+	paramtable.Get().Reset(Params.DataCoordCfg.SegmentSealCompactionBarrierMaxWait.Key)
+	paramtable.Get().Save(Params.DataCoordCfg.SegmentSealCompactionBarrierWindow.Key, "0")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.SegmentSealCompactionBarrierWindow.Key)
+	assert.False(t, segmentManager.deferSealByCompactionBarrier(tiny, now))
+}
@@ -132,6 +132,13 @@ type Server struct {
 	compactionTrigger        trigger
 	compactionInspector      CompactionInspector
 	compactionTriggerManager TriggerManager
+	segmentAccessStats       *segmentAccessStats
+	// priorityBoosts is the compaction priority boosts of the collections shared with the compaction inspector.
+	priorityBoosts *compactionPriorityBoosts
+	// smallSegmentPressures caches the result of the last channel small segment soft limit check.
+	smallSegmentPressures atomic.Pointer[map[int64]float64]
+	// manualTriggerLock serializes the manual compaction triggers of the same collection.
+	manualTriggerLock *lock.KeyLock[int64]
 
 	metricsCacheManager *metricsinfo.MetricsCacheManager
 
@@ -213,8 +220,11 @@ func CreateServer(ctx context.Context, factory dependency.Factory, opts ...Optio
 		notifyIndexChan:     make(chan UniqueID, 1024),
 		dataNodeCreator:     defaultDataNodeCreatorFunc,
 		importJobLock:       lock.NewKeyLock[int64](),
+		manualTriggerLock:   lock.NewKeyLock[int64](),
 		metricsCacheManager: metricsinfo.NewMetricsCacheManager(),
 		metricsRequest:      metricsinfo.NewMetricsRequest(),
+		segmentAccessStats:  newSegmentAccessStats(),
+		priorityBoosts:      newCompactionPriorityBoosts(),
 	}
 
 	for _, opt := range opts {
@@ -674,12 +684,14 @@ func (s *Server) initExternalCollectionInspector(storageCli storage.ChunkManager
 }
 
 func (s *Server) initCompaction() {
-	cph := newCompactionInspector(s.meta, s.allocator, s.handler, s.globalScheduler, s.globalScheduler, s.indexEngineVersionManager)
+	cph := newCompactionInspector(s.meta, s.allocator, s.handler, s.globalScheduler, s.globalScheduler, s.indexEngineVersionManager, s.priorityBoosts)
 	cph.loadMeta()
 	s.compactionInspector = cph
 	s.compactionTriggerManager = NewCompactionTriggerManager(s.allocator, s.handler, s.compactionInspector, s.meta, s.indexEngineVersionManager)
 	s.compactionTriggerManager.InitForceMergeMemoryQuerier(s.nodeManager, s.mixCoord, s.session)
-	s.compactionTrigger = newCompactionTrigger(s.meta, s.compactionInspector, s.allocator, s.handler, s.indexEngineVersionManager)
+	compactionTrigger := newCompactionTrigger(s.meta, s.compactionInspector, s.allocator, s.handler, s.indexEngineVersionManager)
+	compactionTrigger.accessStats = s.segmentAccessStats
+	s.compactionTrigger = compactionTrigger
 }
 
 func (s *Server) stopCompaction() {
@@ -717,6 +729,8 @@ func (s *Server) startServerLoop() {
 	s.serverLoopWg.Add(2)
 	s.startWatchService(s.serverLoopCtx)
 	s.startFlushLoop(s.serverLoopCtx)
+	s.startSegmentAccessStatsLoop(s.serverLoopCtx)
+	s.startChannelSmallSegmentCheckLoop(s.serverLoopCtx)
 	s.globalScheduler.Start()
 	go s.importInspector.Start()
 	go s.importChecker.Start()
@@ -1140,6 +1154,11 @@ func (s *Server) registerMetricsRequest() {
 			return s.meta.compactionTaskMeta.TaskStatsJSON(), nil
 		})
 
+	s.metricsRequest.RegisterMetricsRequest(metricsinfo.CompactionCleaningTaskKey,
+		func(ctx context.Context, req *milvuspb.GetMetricsRequest, jsonReq gjson.Result) (string, error) {
+			return s.compactionInspector.getCleaningTasksJSON(), nil
+		})
+
 	s.metricsRequest.RegisterMetricsRequest(metricsinfo.BuildIndexTaskKey,
 		func(ctx context.Context, req *milvuspb.GetMetricsRequest, jsonReq gjson.Result) (string, error) {
 			return s.meta.indexMeta.TaskStatsJSON(), nil
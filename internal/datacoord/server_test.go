@@ -34,6 +34,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/atomic"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
@@ -63,6 +64,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/walimplstest"
 	"github.com/milvus-io/milvus/pkg/v3/util/etcd"
+	"github.com/milvus-io/milvus/pkg/v3/util/lock"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
@@ -1814,7 +1816,7 @@ func TestGetCompactionState(t *testing.T) {
 				{State: datapb.CompactionTaskState_timeout},
 				{State: datapb.CompactionTaskState_timeout},
 			})
-		mockHandler := newCompactionInspector(mockMeta, nil, nil, nil, nil, newMockVersionManager())
+		mockHandler := newCompactionInspector(mockMeta, nil, nil, nil, nil, newMockVersionManager(), nil)
 		svr.compactionInspector = mockHandler
 		resp, err := svr.GetCompactionState(context.Background(), &milvuspb.GetCompactionStateRequest{CompactionID: 1})
 		assert.NoError(t, err)
@@ -1840,7 +1842,7 @@ func TestManualCompaction(t *testing.T) {
 	paramtable.Get().Save(Params.DataCoordCfg.EnableCompaction.Key, "true")
 	defer paramtable.Get().Reset(Params.DataCoordCfg.EnableCompaction.Key)
 	t.Run("target size zero routes to ordinary manual compaction", func(t *testing.T) {
-		svr := &Server{allocator: allocator.NewMockAllocator(t)}
+		svr := &Server{allocator: allocator.NewMockAllocator(t), manualTriggerLock: lock.NewKeyLock[int64]()}
 		svr.stateCode.Store(commonpb.StateCode_Healthy)
 		svr.meta = &meta{collections: typeutil.NewConcurrentMap[UniqueID, *collectionInfo]()}
 		svr.meta.collections.Insert(1, &collectionInfo{
@@ -1870,7 +1872,7 @@ func TestManualCompaction(t *testing.T) {
 	})
 
 	t.Run("test manual l0 compaction successfully", func(t *testing.T) {
-		svr := &Server{allocator: allocator.NewMockAllocator(t)}
+		svr := &Server{allocator: allocator.NewMockAllocator(t), manualTriggerLock: lock.NewKeyLock[int64]()}
 		svr.stateCode.Store(commonpb.StateCode_Healthy)
 		svr.meta = &meta{collections: typeutil.NewConcurrentMap[UniqueID, *collectionInfo]()}
 		svr.meta.collections.Insert(1, &collectionInfo{
@@ -1894,7 +1896,7 @@ func TestManualCompaction(t *testing.T) {
 	})
 
 	t.Run("test manual compaction failure", func(t *testing.T) {
-		svr := &Server{allocator: allocator.NewMockAllocator(t)}
+		svr := &Server{allocator: allocator.NewMockAllocator(t), manualTriggerLock: lock.NewKeyLock[int64]()}
 		svr.stateCode.Store(commonpb.StateCode_Healthy)
 		svr.meta = &meta{collections: typeutil.NewConcurrentMap[UniqueID, *collectionInfo]()}
 		svr.meta.collections.Insert(1, &collectionInfo{
@@ -1913,6 +1915,38 @@ func TestManualCompaction(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.GetStatus().GetErrorCode())
 	})
 
+	t.Run("test manual compaction serialized by collection", func(t *testing.T) {
+		svr := &Server{allocator: allocator.NewMockAllocator(t), manualTriggerLock: lock.NewKeyLock[int64]()}
+		svr.stateCode.Store(commonpb.StateCode_Healthy)
+		mockTrigger := NewMockTrigger(t)
+		svr.compactionTrigger = mockTrigger
+		running := atomic.NewInt32(0)
+		mockTrigger.EXPECT().TriggerCompaction(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, signal *compactionSignal) (int64, error) {
+				assert.Equal(t, int32(1), running.Inc())
+				time.Sleep(10 * time.Millisecond)
+				running.Dec()
+				return 1, nil
+			}).Times(4)
+		mockHandler := NewMockCompactionInspector(t)
+		mockHandler.EXPECT().getCompactionTasksNumBySignalID(mock.Anything).Return(0)
+		svr.compactionInspector = mockHandler
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := svr.ManualCompaction(context.TODO(), &milvuspb.ManualCompactionRequest{
+					CollectionID: 1,
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, commonpb.ErrorCode_Success, resp.GetStatus().GetErrorCode())
+			}()
+		}
+		wg.Wait()
+	})
+
 	t.Run("test manual compaction with closed server", func(t *testing.T) {
 		svr := &Server{}
 		svr.stateCode.Store(commonpb.StateCode_Abnormal)
@@ -1929,6 +1963,69 @@ func TestManualCompaction(t *testing.T) {
 	})
 }
 
+func TestReindexSegments(t *testing.T) {
+	paramtable.Get().Save(Params.DataCoordCfg.EnableCompaction.Key, "true")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.EnableCompaction.Key)
+	t.Run("test reindex segments successfully", func(t *testing.T) {
+		svr := &Server{manualTriggerLock: lock.NewKeyLock[int64]()}
+		svr.stateCode.Store(commonpb.StateCode_Healthy)
+		mockTrigger := NewMockTrigger(t)
+		svr.compactionTrigger = mockTrigger
+		mockTrigger.EXPECT().TriggerCompaction(mock.Anything, mock.MatchedBy(func(signal *compactionSignal) bool {
+			return signal.collectionID == 1 && signal.isForce && signal.isReindex &&
+				assert.ElementsMatch(t, []int64{100, 101}, signal.segmentIDs)
+		})).Return(1, nil).Once()
+
+		mockHandler := NewMockCompactionInspector(t)
+		mockHandler.EXPECT().getCompactionTasksNumBySignalID(int64(1)).Return(2)
+		svr.compactionInspector = mockHandler
+		resp, err := svr.ReindexSegments(context.TODO(), &datapb.ReindexSegmentsRequest{
+			CollectionId: 1,
+			SegmentIds:   []int64{100, 101, 100},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.GetStatus().GetErrorCode())
+		assert.Equal(t, int64(1), resp.GetCompactionID())
+		assert.Equal(t, int32(2), resp.GetCompactionPlanCount())
+	})
+
+	t.Run("test reindex segments without segments", func(t *testing.T) {
+		svr := &Server{manualTriggerLock: lock.NewKeyLock[int64]()}
+		svr.stateCode.Store(commonpb.StateCode_Healthy)
+		resp, err := svr.ReindexSegments(context.TODO(), &datapb.ReindexSegmentsRequest{
+			CollectionId: 1,
+		})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrParameterInvalid)
+	})
+
+	t.Run("test reindex segments failure", func(t *testing.T) {
+		svr := &Server{manualTriggerLock: lock.NewKeyLock[int64]()}
+		svr.stateCode.Store(commonpb.StateCode_Healthy)
+		mockTrigger := NewMockTrigger(t)
+		svr.compactionTrigger = mockTrigger
+		mockTrigger.EXPECT().TriggerCompaction(mock.Anything, mock.Anything).Return(0, errors.New("mock error"))
+
+		resp, err := svr.ReindexSegments(context.TODO(), &datapb.ReindexSegmentsRequest{
+			CollectionId: 1,
+			SegmentIds:   []int64{100},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.GetStatus().GetErrorCode())
+	})
+
+	t.Run("test reindex segments with closed server", func(t *testing.T) {
+		svr := &Server{}
+		svr.stateCode.Store(commonpb.StateCode_Abnormal)
+		resp, err := svr.ReindexSegments(context.TODO(), &datapb.ReindexSegmentsRequest{
+			CollectionId: 1,
+			SegmentIds:   []int64{100},
+		})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrServiceNotReady)
+	})
+}
+
 func TestGetCompactionStateWithPlans(t *testing.T) {
 	t.Run("test get compaction state successfully", func(t *testing.T) {
 		svr := &Server{}
@@ -627,6 +627,14 @@ func (s *Server) SaveBinlogPaths(ctx context.Context, req *datapb.SaveBinlogPath
 			return merr.Status(err), nil
 		}
 	}
+	// the dropped channel checkpoint works as the write fence of the dropped collection,
+	// the in-flight flush after the channel is dropped should be ignored to avoid leaving garbage.
+	if len(channelName) != 0 && s.meta.IsChannelCheckpointDropped(channelName) {
+		mlog.Info(ctx, "save binlog paths to dropped channel, ignore this request",
+			mlog.String("channel", channelName),
+			mlog.Int64("segmentID", req.GetSegmentID()))
+		return merr.Success(), nil
+	}
 	// for compatibility issue, before 2.3.4, SaveBinlogPaths has only logpath
 	// try to parse path and fill logid
 	err := binlog.CompressSaveBinlogPaths(req)
@@ -1329,6 +1337,11 @@ func (s *Server) ManualCompaction(ctx context.Context, req *milvuspb.ManualCompa
 		return resp, nil
 	}
 
+	// the collection id is resolved from the alias by proxy, so the triggers via the aliases and the collection
+	// name are serialized, otherwise they may generate overlapping plans from the same segments.
+	s.manualTriggerLock.Lock(req.GetCollectionID())
+	defer s.manualTriggerLock.Unlock(req.GetCollectionID())
+
 	var id int64
 	var err error
 	if req.GetMajorCompaction() || req.GetL0Compaction() || req.GetTargetSize() != 0 {
@@ -1362,6 +1375,58 @@ func (s *Server) ManualCompaction(ctx context.Context, req *milvuspb.ManualCompa
 	return resp, nil
 }
 
+// ReindexSegments triggers a mix compaction of each given segment to rebuild it (e.g. to rebuild the index after
+// an index bug fix), the segments are never merged with each other since the size heuristics are bypassed.
+func (s *Server) ReindexSegments(ctx context.Context, req *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error) {
+	mlog.Info(ctx, "received reindex segments", mlog.FieldCollectionID(req.GetCollectionId()), mlog.Int64s("segmentIDs", req.GetSegmentIds()))
+
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &milvuspb.ManualCompactionResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	if !Params.DataCoordCfg.EnableCompaction.GetAsBool() {
+		return &milvuspb.ManualCompactionResponse{
+			Status: merr.Status(merr.WrapErrServiceUnavailable("compaction disabled")),
+		}, nil
+	}
+
+	if req.GetCollectionId() <= 0 || len(req.GetSegmentIds()) == 0 {
+		return &milvuspb.ManualCompactionResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("collection id and segment ids are required to reindex segments")),
+		}, nil
+	}
+
+	s.manualTriggerLock.Lock(req.GetCollectionId())
+	defer s.manualTriggerLock.Unlock(req.GetCollectionId())
+
+	id, err := s.compactionTrigger.TriggerCompaction(ctx, NewCompactionSignal().
+		WithIsForce(true).
+		WithReindex(true).
+		WithCollectionID(req.GetCollectionId()).
+		WithSegmentIDs(lo.Uniq(req.GetSegmentIds())...),
+	)
+	if err != nil {
+		mlog.Warn(ctx, "failed to trigger reindex segments", mlog.FieldCollectionID(req.GetCollectionId()), mlog.Err(err))
+		return &milvuspb.ManualCompactionResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	resp := &milvuspb.ManualCompactionResponse{
+		Status:       merr.Success(),
+		CompactionID: -1,
+	}
+	if taskCnt := s.compactionInspector.getCompactionTasksNumBySignalID(id); taskCnt > 0 {
+		resp.CompactionID = id
+		resp.CompactionPlanCount = int32(taskCnt)
+	}
+	mlog.Info(ctx, "success to trigger reindex segments", mlog.FieldCollectionID(req.GetCollectionId()),
+		mlog.Int64("compactionID", resp.GetCompactionID()), mlog.Int32("taskNum", resp.GetCompactionPlanCount()))
+	return resp, nil
+}
+
 // GetCompactionState gets the state of a compaction
 func (s *Server) GetCompactionState(ctx context.Context, req *milvuspb.GetCompactionStateRequest) (*milvuspb.GetCompactionStateResponse, error) {
 	mlog.Info(context.TODO(), "received get compaction state request")
@@ -1765,13 +1830,42 @@ func (s *Server) BroadcastAlteredCollection(ctx context.Context, req *datapb.Alt
 		return merr.Success(), nil
 	}
 
+	ttlDecreased := isCollectionTTLDecreased(clonedColl.Properties, properties)
 	clonedColl.Properties = properties
 	// add field will change the schema
 	clonedColl.Schema = req.GetSchema()
 	s.meta.AddCollection(clonedColl)
+
+	if ttlDecreased {
+		// the data expired by the new ttl is reclaimed without waiting for the periodic trigger.
+		mlog.Info(ctx, "collection ttl decreased, trigger compaction to reclaim expired data",
+			mlog.FieldCollectionID(req.GetCollectionID()),
+			mlog.String("ttl", properties[common.CollectionTTLConfigKey]))
+		if _, err := s.compactionTrigger.TriggerCompaction(ctx,
+			NewCompactionSignal().
+				WithWaitResult(false).
+				WithCollectionID(req.GetCollectionID())); err != nil {
+			mlog.Warn(ctx, "failed to trigger compaction after collection ttl decreased",
+				mlog.FieldCollectionID(req.GetCollectionID()), mlog.Err(err))
+		}
+	}
 	return merr.Success(), nil
 }
 
+// isCollectionTTLDecreased returns whether the collection ttl is decreased or set for the first time,
+// then more data may be expired.
+func isCollectionTTLDecreased(oldProperties, newProperties map[string]string) bool {
+	newTTL, err := common.GetCollectionTTLFromMap(newProperties)
+	if err != nil || newTTL <= 0 {
+		return false
+	}
+	oldTTL, err := common.GetCollectionTTLFromMap(oldProperties)
+	if err != nil || oldTTL <= 0 {
+		return true
+	}
+	return newTTL < oldTTL
+}
+
 func (s *Server) CheckHealth(ctx context.Context, req *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error) {
 	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
 		return &milvuspb.CheckHealthResponse{
@@ -2607,6 +2701,62 @@ func (s *Server) ListRestoreSnapshotJobs(ctx context.Context, req *datapb.ListRe
 	}, nil
 }
 
+// CloneCollection clones the source collection to a new collection with the same schema, indexes and data.
+// It takes a temporary snapshot of the source collection and restores it to the target collection,
+// the snapshot pin keeps the source segments from GC until the copy segment job finishes.
+// The temporary snapshot is dropped and the target collection is loaded if requested after the job finishes.
+func (s *Server) CloneCollection(ctx context.Context, req *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.CloneCollectionResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	log := mlog.With(mlog.FieldCollectionID(req.GetSourceCollectionId()),
+		mlog.String("targetDB", req.GetTargetDbName()),
+		mlog.String("targetCollection", req.GetTargetCollectionName()))
+	log.Info(ctx, "receive CloneCollection request", mlog.Bool("load", req.GetLoad()))
+
+	if req.GetTargetCollectionName() == "" {
+		err := merr.WrapErrParameterMissingMsg("target collection name is required")
+		log.Warn(ctx, "invalid request", mlog.Err(err))
+		return &datapb.CloneCollectionResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	snapshotName, err := s.createCloneSnapshot(ctx, req)
+	if err != nil {
+		log.Warn(ctx, "failed to create snapshot for clone collection", mlog.Err(err))
+		return &datapb.CloneCollectionResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	resp, err := s.RestoreSnapshot(ctx, &datapb.RestoreSnapshotRequest{
+		Name:                 snapshotName,
+		TargetDbName:         req.GetTargetDbName(),
+		TargetCollectionName: req.GetTargetCollectionName(),
+		SourceCollectionId:   req.GetSourceCollectionId(),
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		log.Warn(ctx, "failed to restore snapshot for clone collection", mlog.String("snapshot", snapshotName), mlog.Err(err))
+		s.dropCloneSnapshot(ctx, req.GetSourceCollectionId(), snapshotName)
+		return &datapb.CloneCollectionResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	s.serverLoopWg.Add(1)
+	go s.watchCloneJob(resp.GetJobId(), req, snapshotName)
+
+	log.Info(ctx, "clone collection started", mlog.String("snapshot", snapshotName), mlog.Int64("jobID", resp.GetJobId()))
+	return &datapb.CloneCollectionResponse{
+		Status:       merr.Success(),
+		JobId:        resp.GetJobId(),
+		SnapshotName: snapshotName,
+	}, nil
+}
+
 func (s *Server) ListSnapshots(ctx context.Context, req *datapb.ListSnapshotsRequest) (*datapb.ListSnapshotsResponse, error) {
 	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
 		return &datapb.ListSnapshotsResponse{
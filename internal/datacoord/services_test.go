@@ -43,6 +43,7 @@ import (
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/broadcaster/broadcast"
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/broadcaster/registry"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/kv"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
@@ -275,6 +276,31 @@ func (s *ServerSuite) TestSaveBinlogPath_SaveUnhealthySegment() {
 	}
 }
 
+func (s *ServerSuite) TestSaveBinlogPath_DroppedChannel() {
+	s.testServer.meta.AddCollection(&collectionInfo{ID: 0})
+	info := &datapb.SegmentInfo{
+		ID:            10,
+		InsertChannel: "ch1",
+		State:         commonpb.SegmentState_Growing,
+		Level:         datapb.SegmentLevel_L1,
+	}
+	err := s.testServer.meta.AddSegment(context.TODO(), NewSegmentInfo(info))
+	s.Require().NoError(err)
+	s.Require().NoError(s.testServer.meta.MarkChannelCheckpointDropped(context.TODO(), "ch1"))
+
+	resp, err := s.testServer.SaveBinlogPaths(context.Background(), &datapb.SaveBinlogPathsRequest{
+		Base: &commonpb.MsgBase{
+			Timestamp: uint64(time.Now().Unix()),
+		},
+		SegmentID: 10,
+		Channel:   "ch1",
+		Flushed:   true,
+	})
+	s.NoError(merr.CheckRPCCall(resp, err))
+	segment := s.testServer.meta.GetSegment(context.TODO(), 10)
+	s.Equal(commonpb.SegmentState_Growing, segment.GetState())
+}
+
 func (s *ServerSuite) TestSaveBinlogPath_StorageVersionImmutable() {
 	s.testServer.meta.AddCollection(&collectionInfo{ID: 0})
 	info := &datapb.SegmentInfo{
@@ -1086,6 +1112,45 @@ func TestBroadcastAlteredCollection(t *testing.T) {
 		assert.True(t, ok)
 		assert.NotNil(t, coll.Properties)
 	})
+
+	t.Run("test ttl decreased", func(t *testing.T) {
+		collections := typeutil.NewConcurrentMap[UniqueID, *collectionInfo]()
+		collections.Insert(1, &collectionInfo{ID: 1, Properties: map[string]string{common.CollectionTTLConfigKey: "100"}})
+		mockTrigger := NewMockTrigger(t)
+		s := &Server{meta: &meta{collections: collections}, compactionTrigger: mockTrigger}
+		s.stateCode.Store(commonpb.StateCode_Healthy)
+		ctx := context.Background()
+
+		// ttl increased, no compaction triggered
+		resp, err := s.BroadcastAlteredCollection(ctx, &datapb.AlterCollectionRequest{
+			CollectionID: 1,
+			Properties:   []*commonpb.KeyValuePair{{Key: common.CollectionTTLConfigKey, Value: "200"}},
+		})
+		assert.NoError(t, merr.CheckRPCCall(resp, err))
+
+		mockTrigger.EXPECT().TriggerCompaction(mock.Anything, mock.MatchedBy(func(signal *compactionSignal) bool {
+			return signal.collectionID == 1 && !signal.isForce && !signal.waitResult
+		})).Return(1, nil).Once()
+		resp, err = s.BroadcastAlteredCollection(ctx, &datapb.AlterCollectionRequest{
+			CollectionID: 1,
+			Properties:   []*commonpb.KeyValuePair{{Key: common.CollectionTTLConfigKey, Value: "50"}},
+		})
+		assert.NoError(t, merr.CheckRPCCall(resp, err))
+	})
+}
+
+func TestIsCollectionTTLDecreased(t *testing.T) {
+	ttl := func(v string) map[string]string {
+		return map[string]string{common.CollectionTTLConfigKey: v}
+	}
+	assert.True(t, isCollectionTTLDecreased(ttl("100"), ttl("50")))
+	assert.True(t, isCollectionTTLDecreased(nil, ttl("50")))
+	assert.True(t, isCollectionTTLDecreased(ttl("0"), ttl("50")))
+	assert.False(t, isCollectionTTLDecreased(ttl("50"), ttl("50")))
+	assert.False(t, isCollectionTTLDecreased(ttl("50"), ttl("100")))
+	assert.False(t, isCollectionTTLDecreased(ttl("50"), ttl("0")))
+	assert.False(t, isCollectionTTLDecreased(ttl("50"), nil))
+	assert.False(t, isCollectionTTLDecreased(ttl("50"), ttl("invalid")))
 }
 
 func TestServer_GcConfirm(t *testing.T) {
@@ -59,6 +59,10 @@ type globalTaskScheduler struct {
 	// throttled) is re-sent every TaskScheduleInterval (~100ms), which turns
 	// one bad task into a dispatch storm that keeps the store throttled.
 	backoffs *typeutil.ConcurrentMap[int64, *taskBackoff]
+	// ioBudget tracks the io budget consumed by the running tasks, see IOBudgetConsumer.
+	ioBudget *ioBudgetTracker
+	// nodeConcurrency tracks the running tasks by worker and group, see NodeConcurrencyLimited.
+	nodeConcurrency *nodeConcurrencyTracker
 }
 
 // taskBackoff records how often a task failed on a worker and when it may be
@@ -119,6 +123,9 @@ func (s *globalTaskScheduler) Enqueue(task Task) {
 	case taskcommon.InProgress, taskcommon.Retry:
 		task.SetTaskTime(taskcommon.TimeStart, time.Now())
 		s.runningTasks.Insert(task.GetTaskID(), task)
+		if collectionID, budget, ok := getIOBudget(task); ok {
+			s.ioBudget.acquire(task.GetTaskID(), collectionID, NullNodeID, budget)
+		}
 	}
 	mlog.Info(s.ctx, "task enqueued", WrapTaskLog(task)...)
 }
@@ -134,6 +141,8 @@ func (s *globalTaskScheduler) AbortAndRemoveTask(taskID int64) {
 		s.pendingTasks.Remove(taskID)
 	}
 	s.backoffs.Remove(taskID)
+	s.ioBudget.release(taskID)
+	s.nodeConcurrency.release(taskID)
 }
 
 func (s *globalTaskScheduler) Start() {
@@ -247,6 +256,87 @@ func (s *globalTaskScheduler) pickNode(slotHeap typeutil.Heap[*nodeSlotEntry], t
 	return entry.nodeID
 }
 
+// pickNodeForTask picks a node like pickNode, but skips the nodes excluded by the task.
+// The excluded nodes are still candidates if there is no other node.
+func (s *globalTaskScheduler) pickNodeForTask(slotHeap typeutil.Heap[*nodeSlotEntry], task Task) int64 {
+	taskSlot := task.GetTaskSlot()
+	excluder, ok := task.(NodeExcluder)
+	if !ok {
+		return s.pickNode(slotHeap, taskSlot)
+	}
+	excluded := typeutil.NewUniqueSet(excluder.ExcludedNodes()...)
+	skipped := make([]*nodeSlotEntry, 0, excluded.Len())
+	for slotHeap.Len() > 0 && excluded.Contain(slotHeap.Peek().nodeID) {
+		skipped = append(skipped, slotHeap.Pop())
+	}
+	if slotHeap.Len() == 0 {
+		for _, entry := range skipped {
+			slotHeap.Push(entry)
+		}
+		return s.pickNode(slotHeap, taskSlot)
+	}
+	nodeID := s.pickNode(slotHeap, taskSlot)
+	for _, entry := range skipped {
+		slotHeap.Push(entry)
+	}
+	return nodeID
+}
+
+// pickNodeWithinIOBudget picks a node for the task like pickNodeForTask, but only among the nodes having enough
+// io budget for the task. It returns false if the io budget of all the nodes is exhausted.
+func (s *globalTaskScheduler) pickNodeWithinIOBudget(slotHeap typeutil.Heap[*nodeSlotEntry], task Task, budget float64) (int64, bool) {
+	if slotHeap.Len() == 0 {
+		return NullNodeID, true
+	}
+	available := make([]*nodeSlotEntry, 0, slotHeap.Len())
+	exhausted := make([]*nodeSlotEntry, 0)
+	for slotHeap.Len() > 0 {
+		entry := slotHeap.Pop()
+		if s.ioBudget.nodeAvailable(entry.nodeID, budget) {
+			available = append(available, entry)
+		} else {
+			exhausted = append(exhausted, entry)
+		}
+	}
+	for _, entry := range available {
+		slotHeap.Push(entry)
+	}
+	defer func() {
+		for _, entry := range exhausted {
+			slotHeap.Push(entry)
+		}
+	}()
+	if len(available) == 0 {
+		return NullNodeID, false
+	}
+	return s.pickNodeForTask(slotHeap, task), true
+}
+
+// excludeNodes pops the given nodes out of the heap, the returned function pushes them back.
+func excludeNodes(slotHeap typeutil.Heap[*nodeSlotEntry], nodes typeutil.UniqueSet) func() {
+	if nodes.Len() == 0 {
+		return func() {}
+	}
+	kept := make([]*nodeSlotEntry, 0, slotHeap.Len())
+	excluded := make([]*nodeSlotEntry, 0, nodes.Len())
+	for slotHeap.Len() > 0 {
+		entry := slotHeap.Pop()
+		if nodes.Contain(entry.nodeID) {
+			excluded = append(excluded, entry)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+	for _, entry := range kept {
+		slotHeap.Push(entry)
+	}
+	return func() {
+		for _, entry := range excluded {
+			slotHeap.Push(entry)
+		}
+	}
+}
+
 func (s *globalTaskScheduler) schedule() {
 	pendingNum := len(s.pendingTasks.TaskIDs())
 	if pendingNum == 0 {
@@ -260,6 +350,8 @@ func (s *globalTaskScheduler) schedule() {
 	slotHeap := newNodeSlotHeap(nodeSlots)
 	futures := make([]*conc.Future[struct{}], 0)
 	var delayed []Task
+	ioBudgetDeferred := 0
+	concurrencyDeferred := 0
 	for {
 		task := s.pendingTasks.Pop()
 		if task == nil {
@@ -272,12 +364,50 @@ func (s *globalTaskScheduler) schedule() {
 			delayed = append(delayed, task)
 			continue
 		}
-		taskSlot := task.GetTaskSlot()
-		nodeID := s.pickNode(slotHeap, taskSlot)
+		// A task out of io budget gives way like the one in backoff,
+		// it's dispatched once the running tasks of its collection or on the workers finish.
+		collectionID, budget, limited := getIOBudget(task)
+		if limited && !s.ioBudget.collectionAvailable(collectionID, budget) {
+			delayed = append(delayed, task)
+			ioBudgetDeferred++
+			continue
+		}
+		// A task whose group reaches the concurrency limit on all the workers gives way like the one out of io budget.
+		group, concurrencyLimit, concurrencyLimited := getNodeConcurrencyLimit(task)
+		restoreNodes := func() {}
+		if concurrencyLimited {
+			total := slotHeap.Len()
+			restoreNodes = excludeNodes(slotHeap, s.nodeConcurrency.fullNodes(group, concurrencyLimit))
+			if total > 0 && slotHeap.Len() == 0 {
+				restoreNodes()
+				delayed = append(delayed, task)
+				concurrencyDeferred++
+				continue
+			}
+		}
+		var nodeID int64
+		if limited {
+			var ok bool
+			if nodeID, ok = s.pickNodeWithinIOBudget(slotHeap, task, budget); !ok {
+				restoreNodes()
+				delayed = append(delayed, task)
+				ioBudgetDeferred++
+				continue
+			}
+		} else {
+			nodeID = s.pickNodeForTask(slotHeap, task)
+		}
+		restoreNodes()
 		if nodeID == NullNodeID {
 			s.pendingTasks.Push(task)
 			break
 		}
+		if limited {
+			s.ioBudget.acquire(task.GetTaskID(), collectionID, nodeID, budget)
+		}
+		if concurrencyLimited {
+			s.nodeConcurrency.acquire(task.GetTaskID(), nodeID, group)
+		}
 		future := s.execPool.Submit(func() (struct{}, error) {
 			s.mu.RLock(task.GetTaskID())
 			defer s.mu.RUnlock(task.GetTaskID())
@@ -309,6 +439,11 @@ func (s *globalTaskScheduler) schedule() {
 					s.backoffs.Remove(task.GetTaskID())
 				}
 			}
+			// Only the task in flight keeps the io budget, it's released by check() once the task is done.
+			if task.GetTaskState() != taskcommon.InProgress {
+				s.ioBudget.release(task.GetTaskID())
+				s.nodeConcurrency.release(task.GetTaskID())
+			}
 			return struct{}{}, nil
 		})
 		futures = append(futures, future)
@@ -316,6 +451,12 @@ func (s *globalTaskScheduler) schedule() {
 	for _, task := range delayed {
 		s.pendingTasks.Push(task)
 	}
+	if ioBudgetDeferred > 0 {
+		mlog.Info(s.ctx, "tasks are deferred for the exhausted io budget", mlog.Int("num", ioBudgetDeferred))
+	}
+	if concurrencyDeferred > 0 {
+		mlog.Info(s.ctx, "tasks are deferred for the node concurrency limit", mlog.Int("num", concurrencyDeferred))
+	}
 	_ = conc.AwaitAll(futures...)
 }
 
@@ -336,15 +477,21 @@ func (s *globalTaskScheduler) check() {
 			case taskcommon.None:
 				s.runningTasks.Remove(task.GetTaskID())
 				s.backoffs.Remove(task.GetTaskID())
+				s.ioBudget.release(task.GetTaskID())
+				s.nodeConcurrency.release(task.GetTaskID())
 			case taskcommon.Init, taskcommon.Retry:
 				s.recordTaskFailure(task)
 				s.runningTasks.Remove(task.GetTaskID())
+				s.ioBudget.release(task.GetTaskID())
+				s.nodeConcurrency.release(task.GetTaskID())
 				s.pendingTasks.Push(task)
 			case taskcommon.Finished, taskcommon.Failed:
 				task.SetTaskTime(taskcommon.TimeEnd, time.Now())
 				task.DropTaskOnWorker(s.cluster)
 				s.runningTasks.Remove(task.GetTaskID())
 				s.backoffs.Remove(task.GetTaskID())
+				s.ioBudget.release(task.GetTaskID())
+				s.nodeConcurrency.release(task.GetTaskID())
 			}
 			return struct{}{}, nil
 		})
@@ -454,5 +601,8 @@ func NewGlobalTaskScheduler(ctx context.Context, cluster session.Cluster) Global
 		checkPool:    checkPool,
 		cluster:      cluster,
 		backoffs:     typeutil.NewConcurrentMap[int64, *taskBackoff](),
+		ioBudget:     newIOBudgetTracker(),
+
+		nodeConcurrency: newNodeConcurrencyTracker(),
 	}
 }
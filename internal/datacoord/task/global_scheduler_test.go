@@ -159,6 +159,40 @@ func TestGlobalScheduler_pickNode(t *testing.T) {
 	assert.Equal(t, int64(NullNodeID), scheduler.pickNode(newNodeSlotHeap(nil), 1))
 }
 
+type excludingTask struct {
+	*MockTask
+	excluded []int64
+}
+
+func (t *excludingTask) ExcludedNodes() []int64 {
+	return t.excluded
+}
+
+func TestGlobalScheduler_pickNodeForTask(t *testing.T) {
+	scheduler := NewGlobalTaskScheduler(context.TODO(), nil).(*globalTaskScheduler)
+	mockTask := NewMockTask(t)
+	mockTask.EXPECT().GetTaskSlot().Return(10)
+
+	slots := map[int64]*session.WorkerSlots{
+		1: {NodeID: 1, AvailableSlots: 80},
+		2: {NodeID: 2, AvailableSlots: 50},
+		3: {NodeID: 3, AvailableSlots: 20},
+	}
+	slotHeap := newNodeSlotHeap(slots)
+	assert.Equal(t, int64(1), scheduler.pickNodeForTask(slotHeap, mockTask))
+
+	// The excluded nodes are skipped even if they are the least-loaded ones.
+	task := &excludingTask{MockTask: mockTask, excluded: []int64{1, 2}}
+	assert.Equal(t, int64(3), scheduler.pickNodeForTask(slotHeap, task))
+	assert.Equal(t, int64(10), slots[3].AvailableSlots)
+	assert.Equal(t, 3, slotHeap.Len())
+
+	// Fall back to the excluded nodes if there is no other node.
+	task.excluded = []int64{1, 2, 3}
+	assert.Equal(t, int64(1), scheduler.pickNodeForTask(slotHeap, task))
+	assert.Equal(t, int64(60), slots[1].AvailableSlots)
+}
+
 // TestGlobalScheduler_pickNode_Balancing verifies that successive picks spread
 // tasks evenly across nodes (water-filling) instead of packing one node first.
 func TestGlobalScheduler_pickNode_Balancing(t *testing.T) {
@@ -492,3 +526,136 @@ func TestGlobalScheduler_TerminalTaskClearsBackoff(t *testing.T) {
 	assert.Equal(t, 0, scheduler.runningTasks.Len())
 	assert.Equal(t, 0, len(scheduler.pendingTasks.TaskIDs()))
 }
+
+type ioBudgetTask struct {
+	*MockTask
+	collectionID int64
+	budget       float64
+}
+
+func (t *ioBudgetTask) GetIOBudget() (int64, float64) {
+	return t.collectionID, t.budget
+}
+
+func TestGlobalScheduler_IOBudget(t *testing.T) {
+	pt := paramtable.Get()
+	pt.Save(pt.DataCoordCfg.CompactionCollectionIOBudget.Key, "100")
+	pt.Save(pt.DataCoordCfg.CompactionNodeIOBudget.Key, "150")
+	defer pt.Reset(pt.DataCoordCfg.CompactionCollectionIOBudget.Key)
+	defer pt.Reset(pt.DataCoordCfg.CompactionNodeIOBudget.Key)
+
+	scheduler := NewGlobalTaskScheduler(context.TODO(), nil).(*globalTaskScheduler)
+	tracker := scheduler.ioBudget
+
+	// A task is always allowed if nothing is running, even if its budget exceeds the limit.
+	assert.True(t, tracker.collectionAvailable(1, 200))
+	tracker.acquire(10, 1, 1, 60)
+	tracker.acquire(10, 1, 1, 60) // acquired once per task
+	assert.True(t, tracker.collectionAvailable(1, 40))
+	assert.False(t, tracker.collectionAvailable(1, 50))
+	assert.True(t, tracker.collectionAvailable(2, 50))
+
+	// The recovered task only counts for the collection.
+	tracker.acquire(11, 2, NullNodeID, 100)
+	assert.False(t, tracker.collectionAvailable(2, 10))
+	assert.True(t, tracker.nodeAvailable(1, 90))
+	assert.False(t, tracker.nodeAvailable(1, 100))
+
+	slots := map[int64]*session.WorkerSlots{
+		1: {NodeID: 1, AvailableSlots: 80},
+		2: {NodeID: 2, AvailableSlots: 50},
+	}
+	slotHeap := newNodeSlotHeap(slots)
+	mockTask := NewMockTask(t)
+	mockTask.EXPECT().GetTaskSlot().Return(10)
+	task := &ioBudgetTask{MockTask: mockTask, collectionID: 3, budget: 100}
+	collectionID, budget, limited := getIOBudget(task)
+	assert.True(t, limited)
+	assert.Equal(t, int64(3), collectionID)
+	assert.Equal(t, float64(100), budget)
+
+	// Node 1 has the most slots but is out of io budget.
+	nodeID, ok := scheduler.pickNodeWithinIOBudget(slotHeap, task, budget)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), nodeID)
+	assert.Equal(t, 2, slotHeap.Len())
+	tracker.acquire(12, collectionID, nodeID, budget)
+
+	nodeID, ok = scheduler.pickNodeWithinIOBudget(slotHeap, task, 100)
+	assert.False(t, ok)
+	assert.Equal(t, int64(NullNodeID), nodeID)
+	assert.Equal(t, 2, slotHeap.Len())
+
+	tracker.release(10)
+	tracker.release(10)
+	assert.True(t, tracker.collectionAvailable(1, 100))
+	nodeID, ok = scheduler.pickNodeWithinIOBudget(slotHeap, task, 100)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), nodeID)
+
+	tracker.release(11)
+	tracker.release(12)
+	assert.Empty(t, tracker.usages)
+	assert.Empty(t, tracker.collections)
+	assert.Empty(t, tracker.nodes)
+
+	// The task without io budget is not limited.
+	_, _, limited = getIOBudget(mockTask)
+	assert.False(t, limited)
+}
+
+type concurrencyLimitedTask struct {
+	*MockTask
+	group string
+	limit int
+}
+
+func (t *concurrencyLimitedTask) GetNodeConcurrencyLimit() (string, int) {
+	return t.group, t.limit
+}
+
+func TestGlobalScheduler_NodeConcurrency(t *testing.T) {
+	scheduler := NewGlobalTaskScheduler(context.TODO(), nil).(*globalTaskScheduler)
+	tracker := scheduler.nodeConcurrency
+
+	tracker.acquire(10, 1, "l0")
+	tracker.acquire(10, 1, "l0") // acquired once per task
+	tracker.acquire(11, 2, "l0")
+	tracker.acquire(12, 2, "mix")
+	assert.ElementsMatch(t, []int64{1, 2}, tracker.fullNodes("l0", 1).Collect())
+	assert.Empty(t, tracker.fullNodes("l0", 2).Collect())
+	assert.ElementsMatch(t, []int64{2}, tracker.fullNodes("mix", 1).Collect())
+
+	slots := map[int64]*session.WorkerSlots{
+		1: {NodeID: 1, AvailableSlots: 80},
+		2: {NodeID: 2, AvailableSlots: 50},
+		3: {NodeID: 3, AvailableSlots: 10},
+	}
+	slotHeap := newNodeSlotHeap(slots)
+	restore := excludeNodes(slotHeap, tracker.fullNodes("l0", 1))
+	assert.Equal(t, 1, slotHeap.Len())
+	assert.Equal(t, int64(3), slotHeap.Peek().nodeID)
+	restore()
+	assert.Equal(t, 3, slotHeap.Len())
+	assert.Equal(t, int64(1), slotHeap.Peek().nodeID)
+
+	tracker.release(10)
+	tracker.release(10)
+	tracker.release(11)
+	tracker.release(12)
+	assert.Empty(t, tracker.usages)
+	assert.Empty(t, tracker.counts)
+
+	task := &concurrencyLimitedTask{MockTask: NewMockTask(t), group: "l0", limit: 2}
+	group, limit, limited := getNodeConcurrencyLimit(task)
+	assert.True(t, limited)
+	assert.Equal(t, "l0", group)
+	assert.Equal(t, 2, limit)
+
+	// 0 means unlimited.
+	task.limit = 0
+	_, _, limited = getNodeConcurrencyLimit(task)
+	assert.False(t, limited)
+	_, _, limited = getNodeConcurrencyLimit(task.MockTask)
+	assert.False(t, limited)
+}
@@ -0,0 +1,118 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"sync"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// IOBudgetConsumer is implemented by the tasks consuming the object storage bandwidth, e.g. the compaction tasks.
+// The dispatch of the task is deferred once the io budget of its collection or of all the workers is exhausted.
+type IOBudgetConsumer interface {
+	// GetIOBudget returns the collection of the task and the bandwidth budget in MB/s of the task, 0 means unlimited.
+	GetIOBudget() (collectionID int64, budget float64)
+}
+
+// getIOBudget returns the io budget of the task, false if the task is not limited by the io budget.
+func getIOBudget(task Task) (int64, float64, bool) {
+	consumer, ok := task.(IOBudgetConsumer)
+	if !ok {
+		return 0, 0, false
+	}
+	collectionID, budget := consumer.GetIOBudget()
+	return collectionID, budget, budget > 0
+}
+
+type ioBudgetUsage struct {
+	collectionID int64
+	nodeID       int64
+	budget       float64
+}
+
+// ioBudgetTracker tracks the aggregate io budget of the running tasks by collection and by worker.
+type ioBudgetTracker struct {
+	mu          sync.Mutex
+	usages      map[int64]ioBudgetUsage // task id -> usage
+	collections map[int64]float64
+	nodes       map[int64]float64
+}
+
+func newIOBudgetTracker() *ioBudgetTracker {
+	return &ioBudgetTracker{
+		usages:      make(map[int64]ioBudgetUsage),
+		collections: make(map[int64]float64),
+		nodes:       make(map[int64]float64),
+	}
+}
+
+// withinLimit checks whether the budget can be added to the used one, a task is always allowed if nothing
+// is running, so the task with a budget larger than the limit won't starve.
+func withinLimit(used, budget, limit float64) bool {
+	return limit <= 0 || used <= 0 || used+budget <= limit
+}
+
+// collectionAvailable checks whether the collection has enough io budget for the task.
+func (t *ioBudgetTracker) collectionAvailable(collectionID int64, budget float64) bool {
+	limit := paramtable.Get().DataCoordCfg.CompactionCollectionIOBudget.GetAsFloat()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return withinLimit(t.collections[collectionID], budget, limit)
+}
+
+// nodeAvailable checks whether the worker has enough io budget for the task.
+func (t *ioBudgetTracker) nodeAvailable(nodeID int64, budget float64) bool {
+	limit := paramtable.Get().DataCoordCfg.CompactionNodeIOBudget.GetAsFloat()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return withinLimit(t.nodes[nodeID], budget, limit)
+}
+
+// acquire records the io budget of the task dispatched to the worker,
+// the worker of the recovered running task is unknown, it only counts for the collection.
+func (t *ioBudgetTracker) acquire(taskID, collectionID, nodeID int64, budget float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.usages[taskID]; ok {
+		return
+	}
+	t.usages[taskID] = ioBudgetUsage{collectionID: collectionID, nodeID: nodeID, budget: budget}
+	t.collections[collectionID] += budget
+	if nodeID != NullNodeID {
+		t.nodes[nodeID] += budget
+	}
+}
+
+// release gives back the io budget of the task.
+func (t *ioBudgetTracker) release(taskID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	usage, ok := t.usages[taskID]
+	if !ok {
+		return
+	}
+	delete(t.usages, taskID)
+	if t.collections[usage.collectionID] -= usage.budget; t.collections[usage.collectionID] <= 0 {
+		delete(t.collections, usage.collectionID)
+	}
+	if usage.nodeID != NullNodeID {
+		if t.nodes[usage.nodeID] -= usage.budget; t.nodes[usage.nodeID] <= 0 {
+			delete(t.nodes, usage.nodeID)
+		}
+	}
+}
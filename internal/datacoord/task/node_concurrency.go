@@ -0,0 +1,99 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"sync"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// NodeConcurrencyLimited is implemented by the tasks whose number running on a worker is limited by their group,
+// e.g. the compaction tasks of the same type.
+type NodeConcurrencyLimited interface {
+	// GetNodeConcurrencyLimit returns the group of the task and the max number of the running tasks
+	// of the group on a worker, 0 means unlimited.
+	GetNodeConcurrencyLimit() (group string, limit int)
+}
+
+// getNodeConcurrencyLimit returns the node concurrency limit of the task, false if the task is not limited.
+func getNodeConcurrencyLimit(task Task) (string, int, bool) {
+	limited, ok := task.(NodeConcurrencyLimited)
+	if !ok {
+		return "", 0, false
+	}
+	group, limit := limited.GetNodeConcurrencyLimit()
+	return group, limit, limit > 0
+}
+
+type nodeGroup struct {
+	nodeID int64
+	group  string
+}
+
+// nodeConcurrencyTracker tracks the number of the running tasks by worker and group.
+type nodeConcurrencyTracker struct {
+	mu     sync.Mutex
+	usages map[int64]nodeGroup // task id -> worker and group
+	counts map[nodeGroup]int
+}
+
+func newNodeConcurrencyTracker() *nodeConcurrencyTracker {
+	return &nodeConcurrencyTracker{
+		usages: make(map[int64]nodeGroup),
+		counts: make(map[nodeGroup]int),
+	}
+}
+
+// fullNodes returns the workers running the max number of the tasks of the group.
+func (t *nodeConcurrencyTracker) fullNodes(group string, limit int) typeutil.UniqueSet {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	nodes := typeutil.NewUniqueSet()
+	for key, count := range t.counts {
+		if key.group == group && count >= limit {
+			nodes.Insert(key.nodeID)
+		}
+	}
+	return nodes
+}
+
+// acquire records the task of the group dispatched to the worker.
+func (t *nodeConcurrencyTracker) acquire(taskID, nodeID int64, group string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.usages[taskID]; ok {
+		return
+	}
+	key := nodeGroup{nodeID: nodeID, group: group}
+	t.usages[taskID] = key
+	t.counts[key]++
+}
+
+// release removes the task once it's not running on the worker.
+func (t *nodeConcurrencyTracker) release(taskID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key, ok := t.usages[taskID]
+	if !ok {
+		return
+	}
+	delete(t.usages, taskID)
+	if t.counts[key]--; t.counts[key] <= 0 {
+		delete(t.counts, key)
+	}
+}
@@ -38,6 +38,12 @@ type Task interface {
 	DropTaskOnWorker(cluster session.Cluster)
 }
 
+// NodeExcluder is implemented by the tasks that should be scheduled onto other workers
+// than the given ones, e.g. the workers the task has failed on.
+type NodeExcluder interface {
+	ExcludedNodes() []int64
+}
+
 func WrapTaskLog(task Task, fields ...mlog.Field) []mlog.Field {
 	res := []mlog.Field{
 		mlog.Int64("ID", task.GetTaskID()),
@@ -92,6 +92,8 @@ func getTaskSlotUsage(task Compactor) int64 {
 			taskSlotUsage = paramtable.Get().DataCoordCfg.L0DeleteCompactionSlotUsage.GetAsInt64()
 		case datapb.CompactionType_BumpSchemaVersionCompaction:
 			taskSlotUsage = paramtable.Get().DataCoordCfg.BumpSchemaVersionCompactionSlotUsage.GetAsInt64()
+		case datapb.CompactionType_LogMergeCompaction:
+			taskSlotUsage = paramtable.Get().DataCoordCfg.LogMergeCompactionSlotUsage.GetAsInt64()
 		}
 		mlog.Warn(context.TODO(), "illegal task slot usage, change it to a default value",
 			mlog.Int64("illegalSlotUsage", task.GetSlotUsage()),
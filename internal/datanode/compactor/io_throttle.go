@@ -0,0 +1,149 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const ioBudgetUnit = 1024 * 1024 // the io budget is in MB/s
+
+var (
+	nodeIOLimiterOnce sync.Once
+	nodeIOLimiter     *rate.Limiter
+)
+
+// newIOLimiter creates a limiter of the budget in MB/s, the burst is one second of the budget.
+func newIOLimiter(budget float64) *rate.Limiter {
+	bytesPerSecond := budget * ioBudgetUnit
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), max(int(bytesPerSecond), 1))
+}
+
+// getNodeIOLimiter returns the limiter shared by all the compaction tasks of the node,
+// the limit is refreshed by the latest node io budget, nil if the node io is unlimited.
+func getNodeIOLimiter() *rate.Limiter {
+	budget := paramtable.Get().DataCoordCfg.CompactionNodeIOBudget.GetAsFloat()
+	if budget <= 0 {
+		return nil
+	}
+	nodeIOLimiterOnce.Do(func() {
+		nodeIOLimiter = newIOLimiter(budget)
+	})
+	bytesPerSecond := budget * ioBudgetUnit
+	if nodeIOLimiter.Limit() != rate.Limit(bytesPerSecond) {
+		nodeIOLimiter.SetLimit(rate.Limit(bytesPerSecond))
+		nodeIOLimiter.SetBurst(max(int(bytesPerSecond), 1))
+	}
+	return nodeIOLimiter
+}
+
+// throttledChunkManager throttles the bandwidth of the object storage io issued by the compaction task
+// with the io budget of the plan and the one of the node.
+// Only the whole object reads and writes are throttled, the streaming readers and the io of
+// the native storage writers are not.
+type throttledChunkManager struct {
+	storage.ChunkManager
+	limiters []*rate.Limiter
+}
+
+// NewThrottledChunkManager wraps the chunk manager with the io budget of the compaction plan,
+// the chunk manager is returned as is if neither the plan nor the node is limited.
+func NewThrottledChunkManager(cm storage.ChunkManager, planBudget float64) storage.ChunkManager {
+	limiters := make([]*rate.Limiter, 0, 2)
+	if planBudget > 0 {
+		limiters = append(limiters, newIOLimiter(planBudget))
+	}
+	if limiter := getNodeIOLimiter(); limiter != nil {
+		limiters = append(limiters, limiter)
+	}
+	if len(limiters) == 0 {
+		return cm
+	}
+	return &throttledChunkManager{ChunkManager: cm, limiters: limiters}
+}
+
+// wait blocks until the limiters allow n bytes, n larger than the burst is waited in batches.
+func (cm *throttledChunkManager) wait(ctx context.Context, n int) error {
+	for _, limiter := range cm.limiters {
+		for remain := n; remain > 0; {
+			batch := min(remain, limiter.Burst())
+			if err := limiter.WaitN(ctx, batch); err != nil {
+				return err
+			}
+			remain -= batch
+		}
+	}
+	return nil
+}
+
+func (cm *throttledChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	if err := cm.wait(ctx, len(content)); err != nil {
+		return err
+	}
+	return cm.ChunkManager.Write(ctx, filePath, content)
+}
+
+func (cm *throttledChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	size := 0
+	for _, content := range contents {
+		size += len(content)
+	}
+	if err := cm.wait(ctx, size); err != nil {
+		return err
+	}
+	return cm.ChunkManager.MultiWrite(ctx, contents)
+}
+
+// Read throttles after the object is read since its size is unknown before.
+func (cm *throttledChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	data, err := cm.ChunkManager.Read(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := cm.wait(ctx, len(data)); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (cm *throttledChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	contents, err := cm.ChunkManager.MultiRead(ctx, filePaths)
+	if err != nil {
+		return nil, err
+	}
+	size := 0
+	for _, content := range contents {
+		size += len(content)
+	}
+	if err := cm.wait(ctx, size); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+func (cm *throttledChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	if err := cm.wait(ctx, int(length)); err != nil {
+		return nil, err
+	}
+	return cm.ChunkManager.ReadAt(ctx, filePath, off, length)
+}
@@ -0,0 +1,71 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestThrottledChunkManager(t *testing.T) {
+	paramtable.Init()
+	pt := paramtable.Get()
+	ctx := context.Background()
+	cm := storage.NewLocalChunkManager(objectstorage.RootPath(t.TempDir()))
+
+	// Unlimited.
+	assert.Equal(t, cm, NewThrottledChunkManager(cm, 0))
+
+	// The node io budget is shared and refreshed.
+	pt.Save(pt.DataCoordCfg.CompactionNodeIOBudget.Key, "2")
+	defer pt.Reset(pt.DataCoordCfg.CompactionNodeIOBudget.Key)
+	throttled := NewThrottledChunkManager(cm, 0).(*throttledChunkManager)
+	assert.Len(t, throttled.limiters, 1)
+	assert.Equal(t, rate.Limit(2*ioBudgetUnit), throttled.limiters[0].Limit())
+	pt.Save(pt.DataCoordCfg.CompactionNodeIOBudget.Key, "4")
+	another := NewThrottledChunkManager(cm, 1).(*throttledChunkManager)
+	assert.Len(t, another.limiters, 2)
+	assert.Same(t, throttled.limiters[0], another.limiters[1])
+	assert.Equal(t, rate.Limit(4*ioBudgetUnit), another.limiters[1].Limit())
+	assert.Equal(t, 4*ioBudgetUnit, another.limiters[1].Burst())
+
+	// The io larger than the burst is waited in batches.
+	content := make([]byte, 2*ioBudgetUnit)
+	start := time.Now()
+	assert.NoError(t, another.Write(ctx, "a", content))
+	data, err := another.Read(ctx, "a")
+	assert.NoError(t, err)
+	assert.Len(t, data, len(content))
+	assert.GreaterOrEqual(t, time.Since(start), 2*time.Second)
+
+	// The canceled context stops waiting.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Error(t, another.MultiWrite(cancelCtx, map[string][]byte{"b": content}))
+	_, err = another.ReadAt(cancelCtx, "a", 0, int64(len(content)))
+	assert.Error(t, err)
+	_, err = another.MultiRead(cancelCtx, []string{"a"})
+	assert.Error(t, err)
+}
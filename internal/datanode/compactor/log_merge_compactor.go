@@ -0,0 +1,317 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/allocator"
+	"github.com/milvus-io/milvus/internal/compaction"
+	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/metautil"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// logMergeCompactionTask merges the small deltalogs and pk statslogs of a segment
+// into one file each, the insert binlogs are left untouched since the binlogs of
+// different fields must stay row aligned file by file.
+type logMergeCompactionTask struct {
+	ctx              context.Context
+	cancel           context.CancelFunc
+	plan             *datapb.CompactionPlan
+	compactionParams compaction.Params
+	done             chan struct{}
+	logIDAlloc       allocator.Interface
+	chunkManager     storage.ChunkManager
+}
+
+var _ Compactor = (*logMergeCompactionTask)(nil)
+
+func NewLogMergeCompactionTask(ctx context.Context, cm storage.ChunkManager, plan *datapb.CompactionPlan, compactionParams compaction.Params) *logMergeCompactionTask {
+	ctx, cancel := context.WithCancel(ctx)
+	return &logMergeCompactionTask{
+		ctx:              ctx,
+		cancel:           cancel,
+		plan:             plan,
+		compactionParams: compactionParams,
+		done:             make(chan struct{}, 1),
+		logIDAlloc:       allocator.NewLocalAllocator(plan.GetPreAllocatedLogIDs().GetBegin(), plan.GetPreAllocatedLogIDs().GetEnd()),
+		chunkManager:     cm,
+	}
+}
+
+func (t *logMergeCompactionTask) Compact() (*datapb.CompactionPlanResult, error) {
+	if !funcutil.CheckCtxValid(t.ctx) {
+		return nil, t.ctx.Err()
+	}
+	ctx, span := otel.Tracer(typeutil.DataNodeRole).Start(t.ctx, fmt.Sprintf("LogMergeCompact-%d", t.GetPlanID()))
+	defer span.End()
+
+	if len(t.plan.GetSegmentBinlogs()) != 1 {
+		return nil, merr.WrapErrServiceInternalMsg("log merge compaction plan is illegal, must have exactly one segment, but got %d segments, planID = %d",
+			len(t.plan.GetSegmentBinlogs()), t.GetPlanID())
+	}
+	segment := t.plan.GetSegmentBinlogs()[0]
+	if segment.GetManifest() != "" {
+		return nil, merr.WrapErrServiceInternalMsg("log merge compaction doesn't support the segment with manifest, planID = %d, segmentID = %d",
+			t.GetPlanID(), segment.GetSegmentID())
+	}
+	if err := binlog.DecompressCompactionBinlogsWithRootPath(t.compactionParams.StorageConfig.GetRootPath(), t.plan.GetSegmentBinlogs()); err != nil {
+		mlog.Warn(ctx, "log merge compaction failed to decompress binlogs", mlog.Err(err))
+		return nil, err
+	}
+	log := mlog.With(
+		mlog.Int64("planID", t.GetPlanID()),
+		mlog.FieldCollectionID(segment.GetCollectionID()),
+		mlog.FieldSegmentID(segment.GetSegmentID()),
+	)
+	start := time.Now()
+
+	pkField, err := typeutil.GetPrimaryFieldSchema(t.plan.GetSchema())
+	if err != nil {
+		return nil, err
+	}
+
+	deltalogs, err := t.mergeDeltalogs(ctx, segment, pkField.GetDataType())
+	if err != nil {
+		log.Warn(ctx, "log merge compaction failed to merge deltalogs", mlog.Err(err))
+		return nil, err
+	}
+	statslogs, err := t.mergeStatslogs(ctx, segment, pkField.GetFieldID())
+	if err != nil {
+		log.Warn(ctx, "log merge compaction failed to merge statslogs", mlog.Err(err))
+		return nil, err
+	}
+
+	log.Info(ctx, "log merge compaction done",
+		mlog.Int("deltalogNum", countBinlogs(segment.GetDeltalogs())),
+		mlog.Int("mergedDeltalogNum", countBinlogs(deltalogs)),
+		mlog.Int("statslogNum", countBinlogs(segment.GetField2StatslogPaths())),
+		mlog.Int("mergedStatslogNum", countBinlogs(statslogs)),
+		mlog.Duration("elapse", time.Since(start)))
+
+	numRows := int64(0)
+	if len(segment.GetFieldBinlogs()) > 0 {
+		for _, b := range segment.GetFieldBinlogs()[0].GetBinlogs() {
+			numRows += b.GetEntriesNum()
+		}
+	}
+	return &datapb.CompactionPlanResult{
+		PlanID: t.GetPlanID(),
+		State:  datapb.CompactionTaskState_completed,
+		Segments: []*datapb.CompactionSegment{
+			{
+				SegmentID:           segment.GetSegmentID(),
+				NumOfRows:           numRows,
+				InsertLogs:          segment.GetFieldBinlogs(),
+				Field2StatslogPaths: statslogs,
+				Deltalogs:           deltalogs,
+				Channel:             segment.GetInsertChannel(),
+				StorageVersion:      segment.GetStorageVersion(),
+			},
+		},
+		Type: t.plan.GetType(),
+	}, nil
+}
+
+func countBinlogs(fieldBinlogs []*datapb.FieldBinlog) int {
+	num := 0
+	for _, fieldBinlog := range fieldBinlogs {
+		num += len(fieldBinlog.GetBinlogs())
+	}
+	return num
+}
+
+// mergeDeltalogs rewrites all the deltalogs of the segment into one deltalog.
+func (t *logMergeCompactionTask) mergeDeltalogs(ctx context.Context, segment *datapb.CompactionSegmentBinlogs, pkType schemapb.DataType) ([]*datapb.FieldBinlog, error) {
+	if countBinlogs(segment.GetDeltalogs()) <= 1 {
+		return segment.GetDeltalogs(), nil
+	}
+	deleteData, err := compaction.ComposeDeleteDataFromDeltalogs(ctx, pkType, segment,
+		storage.WithDownloader(t.chunkManager.MultiRead),
+		storage.WithStorageConfig(t.compactionParams.StorageConfig))
+	if err != nil {
+		return nil, err
+	}
+	if deleteData.RowCount == 0 {
+		return nil, nil
+	}
+
+	logID, err := t.logIDAlloc.AllocOne()
+	if err != nil {
+		return nil, err
+	}
+	path := metautil.BuildDeltaLogPath(t.compactionParams.StorageConfig.GetRootPath(),
+		segment.GetCollectionID(), segment.GetPartitionID(), segment.GetSegmentID(), logID)
+	writer, err := storage.NewDeltalogWriter(ctx,
+		segment.GetCollectionID(), segment.GetPartitionID(), segment.GetSegmentID(),
+		logID, pkType, path,
+		storage.WithUploader(t.chunkManager.MultiWrite),
+		storage.WithStorageConfig(t.compactionParams.StorageConfig),
+		storage.WithVersion(storage.StorageV1),
+	)
+	if err != nil {
+		return nil, err
+	}
+	record, tsFrom, tsTo, err := storage.BuildDeleteRecord(deleteData.Pks, deleteData.Tss)
+	if err != nil {
+		return nil, err
+	}
+	defer record.Release()
+	if err := writer.Write(record); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return []*datapb.FieldBinlog{
+		{
+			Binlogs: []*datapb.Binlog{
+				{
+					LogPath:       path,
+					LogID:         logID,
+					LogSize:       int64(writer.GetWrittenUncompressed()),
+					MemorySize:    int64(writer.GetWrittenUncompressed()),
+					EntriesNum:    deleteData.RowCount,
+					TimestampFrom: tsFrom,
+					TimestampTo:   tsTo,
+				},
+			},
+		},
+	}, nil
+}
+
+// mergeStatslogs merges the pk statslogs of the segment into one compound statslog,
+// the statslogs of the other fields are kept as they are.
+func (t *logMergeCompactionTask) mergeStatslogs(ctx context.Context, segment *datapb.CompactionSegmentBinlogs, pkFieldID int64) ([]*datapb.FieldBinlog, error) {
+	statslogs := make([]*datapb.FieldBinlog, 0, len(segment.GetField2StatslogPaths()))
+	for _, fieldBinlog := range segment.GetField2StatslogPaths() {
+		if fieldBinlog.GetFieldID() != pkFieldID || len(fieldBinlog.GetBinlogs()) <= 1 {
+			statslogs = append(statslogs, fieldBinlog)
+			continue
+		}
+		merged, err := t.mergePkStatslog(ctx, segment, fieldBinlog)
+		if err != nil {
+			return nil, err
+		}
+		statslogs = append(statslogs, merged)
+	}
+	return statslogs, nil
+}
+
+func (t *logMergeCompactionTask) mergePkStatslog(ctx context.Context, segment *datapb.CompactionSegmentBinlogs, fieldBinlog *datapb.FieldBinlog) (*datapb.FieldBinlog, error) {
+	compoundLogID := int64(storage.CompoundStatsType)
+	// the compound statslog written on flush already covers all the other statslogs.
+	for _, b := range fieldBinlog.GetBinlogs() {
+		if b.GetLogID() == compoundLogID {
+			return &datapb.FieldBinlog{FieldID: fieldBinlog.GetFieldID(), Binlogs: []*datapb.Binlog{b}}, nil
+		}
+	}
+
+	paths := make([]string, 0, len(fieldBinlog.GetBinlogs()))
+	numRows := int64(0)
+	for _, b := range fieldBinlog.GetBinlogs() {
+		paths = append(paths, b.GetLogPath())
+		numRows += b.GetEntriesNum()
+	}
+	values, err := t.chunkManager.MultiRead(ctx, paths)
+	if err != nil {
+		return nil, err
+	}
+	blobs := make([]*storage.Blob, 0, len(values))
+	for _, v := range values {
+		blobs = append(blobs, &storage.Blob{Value: v})
+	}
+	stats, err := storage.DeserializeStats(blobs)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := storage.NewInsertCodec().SerializePkStatsList(stats, numRows)
+	if err != nil {
+		return nil, err
+	}
+
+	path := metautil.BuildStatsLogPath(t.compactionParams.StorageConfig.GetRootPath(),
+		segment.GetCollectionID(), segment.GetPartitionID(), segment.GetSegmentID(), fieldBinlog.GetFieldID(), compoundLogID)
+	if err := t.chunkManager.Write(ctx, path, blob.GetValue()); err != nil {
+		return nil, err
+	}
+	size := int64(len(blob.GetValue()))
+	return &datapb.FieldBinlog{
+		FieldID: fieldBinlog.GetFieldID(),
+		Binlogs: []*datapb.Binlog{
+			{
+				LogPath:    path,
+				LogID:      compoundLogID,
+				LogSize:    size,
+				MemorySize: size,
+				EntriesNum: numRows,
+			},
+		},
+	}, nil
+}
+
+func (t *logMergeCompactionTask) Complete() {
+	select {
+	case t.done <- struct{}{}:
+	default:
+	}
+}
+
+func (t *logMergeCompactionTask) Stop() {
+	t.cancel()
+	<-t.done
+}
+
+func (t *logMergeCompactionTask) GetPlanID() typeutil.UniqueID {
+	return t.plan.GetPlanID()
+}
+
+func (t *logMergeCompactionTask) GetCollection() typeutil.UniqueID {
+	if len(t.plan.GetSegmentBinlogs()) > 0 {
+		return t.plan.GetSegmentBinlogs()[0].GetCollectionID()
+	}
+	return 0
+}
+
+func (t *logMergeCompactionTask) GetChannelName() string {
+	return t.plan.GetChannel()
+}
+
+func (t *logMergeCompactionTask) GetCompactionType() datapb.CompactionType {
+	return t.plan.GetType()
+}
+
+func (t *logMergeCompactionTask) GetSlotUsage() int64 {
+	return t.plan.GetSlotUsage()
+}
+
+func (t *logMergeCompactionTask) GetStorageConfig() *indexpb.StorageConfig {
+	return t.compactionParams.StorageConfig
+}
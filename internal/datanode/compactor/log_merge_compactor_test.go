@@ -0,0 +1,197 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/compaction"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/metautil"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestLogMergeCompaction(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	rootPath := t.TempDir()
+	cm := storage.NewLocalChunkManager(objectstorage.RootPath(rootPath))
+	compactionParams := compaction.GenParams()
+	compactionParams.StorageConfig = &indexpb.StorageConfig{StorageType: "local", RootPath: rootPath}
+
+	writeDeltalog := func(logID int64, pks []int64, tss []uint64) *datapb.Binlog {
+		blob, err := getInt64DeltaBlobs(SegmentID, pks, tss)
+		require.NoError(t, err)
+		path := metautil.BuildDeltaLogPath(rootPath, CollectionID, PartitionID, SegmentID, logID)
+		require.NoError(t, cm.Write(ctx, path, blob.GetValue()))
+		return &datapb.Binlog{LogID: logID, LogPath: path, EntriesNum: int64(len(pks))}
+	}
+	writeStatslog := func(logID int64, pks []int64) *datapb.Binlog {
+		stats, err := storage.NewPrimaryKeyStats(Int64Field, int64(schemapb.DataType_Int64), int64(len(pks)))
+		require.NoError(t, err)
+		for _, pk := range pks {
+			stats.Update(storage.NewInt64PrimaryKey(pk))
+		}
+		blob, err := storage.NewInsertCodec().SerializePkStats(stats, int64(len(pks)))
+		require.NoError(t, err)
+		path := metautil.BuildStatsLogPath(rootPath, CollectionID, PartitionID, SegmentID, Int64Field, logID)
+		require.NoError(t, cm.Write(ctx, path, blob.GetValue()))
+		return &datapb.Binlog{LogID: logID, LogPath: path, EntriesNum: int64(len(pks))}
+	}
+	newPlan := func(segments ...*datapb.CompactionSegmentBinlogs) *datapb.CompactionPlan {
+		return &datapb.CompactionPlan{
+			PlanID:             1,
+			Type:               datapb.CompactionType_LogMergeCompaction,
+			Schema:             genTestCollectionMeta().GetSchema(),
+			SegmentBinlogs:     segments,
+			PreAllocatedLogIDs: &datapb.IDRange{Begin: 1000, End: 2000},
+		}
+	}
+
+	insertLogs := []*datapb.FieldBinlog{
+		{FieldID: Int64Field, Binlogs: []*datapb.Binlog{{LogID: 1, EntriesNum: 3}, {LogID: 2, EntriesNum: 2}}},
+	}
+	otherStatslog := &datapb.FieldBinlog{FieldID: Int32Field, Binlogs: []*datapb.Binlog{{LogID: 10}, {LogID: 11}}}
+
+	t.Run("merge deltalogs and statslogs", func(t *testing.T) {
+		segment := &datapb.CompactionSegmentBinlogs{
+			CollectionID: CollectionID,
+			PartitionID:  PartitionID,
+			SegmentID:    SegmentID,
+			FieldBinlogs: insertLogs,
+			Deltalogs: []*datapb.FieldBinlog{{Binlogs: []*datapb.Binlog{
+				writeDeltalog(20, []int64{1, 2}, []uint64{100, 101}),
+				writeDeltalog(21, []int64{3}, []uint64{102}),
+			}}},
+			Field2StatslogPaths: []*datapb.FieldBinlog{
+				{FieldID: Int64Field, Binlogs: []*datapb.Binlog{
+					writeStatslog(30, []int64{1, 2, 3}),
+					writeStatslog(31, []int64{4, 5}),
+				}},
+				otherStatslog,
+			},
+			InsertChannel: "ch-1",
+		}
+		task := NewLogMergeCompactionTask(ctx, cm, newPlan(segment), compactionParams)
+		result, err := task.Compact()
+		require.NoError(t, err)
+		assert.Equal(t, datapb.CompactionTaskState_completed, result.GetState())
+		require.Len(t, result.GetSegments(), 1)
+		merged := result.GetSegments()[0]
+		assert.EqualValues(t, SegmentID, merged.GetSegmentID())
+		assert.EqualValues(t, 5, merged.GetNumOfRows())
+		// the insert binlogs are never rewritten.
+		assert.Equal(t, insertLogs, merged.GetInsertLogs())
+
+		require.Len(t, merged.GetDeltalogs(), 1)
+		require.Len(t, merged.GetDeltalogs()[0].GetBinlogs(), 1)
+		deltalog := merged.GetDeltalogs()[0].GetBinlogs()[0]
+		assert.EqualValues(t, 3, deltalog.GetEntriesNum())
+		assert.EqualValues(t, 100, deltalog.GetTimestampFrom())
+		assert.EqualValues(t, 102, deltalog.GetTimestampTo())
+		deleteData, err := compaction.ComposeDeleteDataFromDeltalogs(ctx, schemapb.DataType_Int64,
+			&datapb.CompactionSegmentBinlogs{Deltalogs: merged.GetDeltalogs()},
+			storage.WithDownloader(cm.MultiRead),
+			storage.WithStorageConfig(compactionParams.StorageConfig))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []storage.PrimaryKey{
+			storage.NewInt64PrimaryKey(1), storage.NewInt64PrimaryKey(2), storage.NewInt64PrimaryKey(3),
+		}, deleteData.Pks)
+
+		require.Len(t, merged.GetField2StatslogPaths(), 2)
+		pkStatslog := merged.GetField2StatslogPaths()[0]
+		assert.EqualValues(t, Int64Field, pkStatslog.GetFieldID())
+		require.Len(t, pkStatslog.GetBinlogs(), 1)
+		assert.EqualValues(t, storage.CompoundStatsType, pkStatslog.GetBinlogs()[0].GetLogID())
+		assert.EqualValues(t, 5, pkStatslog.GetBinlogs()[0].GetEntriesNum())
+		value, err := cm.Read(ctx, pkStatslog.GetBinlogs()[0].GetLogPath())
+		require.NoError(t, err)
+		stats, err := storage.DeserializeStatsList(&storage.Blob{Value: value})
+		require.NoError(t, err)
+		assert.Len(t, stats, 2)
+		// the statslogs of the other fields are kept as they are.
+		assert.Equal(t, otherStatslog, merged.GetField2StatslogPaths()[1])
+	})
+
+	t.Run("keep the compound statslog", func(t *testing.T) {
+		compound := &datapb.Binlog{LogID: int64(storage.CompoundStatsType), LogPath: "compound"}
+		deltalog := &datapb.FieldBinlog{Binlogs: []*datapb.Binlog{{LogID: 20, LogPath: "deltalog"}}}
+		segment := &datapb.CompactionSegmentBinlogs{
+			CollectionID: CollectionID,
+			PartitionID:  PartitionID,
+			SegmentID:    SegmentID,
+			FieldBinlogs: insertLogs,
+			Deltalogs:    []*datapb.FieldBinlog{deltalog},
+			Field2StatslogPaths: []*datapb.FieldBinlog{
+				{FieldID: Int64Field, Binlogs: []*datapb.Binlog{{LogID: 30, LogPath: "stats"}, compound}},
+			},
+		}
+		task := NewLogMergeCompactionTask(ctx, cm, newPlan(segment), compactionParams)
+		result, err := task.Compact()
+		require.NoError(t, err)
+		merged := result.GetSegments()[0]
+		// a single deltalog is not rewritten.
+		assert.Equal(t, []*datapb.FieldBinlog{deltalog}, merged.GetDeltalogs())
+		assert.Equal(t, []*datapb.Binlog{compound}, merged.GetField2StatslogPaths()[0].GetBinlogs())
+	})
+
+	t.Run("illegal plan", func(t *testing.T) {
+		task := NewLogMergeCompactionTask(ctx, cm, newPlan(
+			&datapb.CompactionSegmentBinlogs{SegmentID: 1},
+			&datapb.CompactionSegmentBinlogs{SegmentID: 2},
+		), compactionParams)
+		_, err := task.Compact()
+		assert.Error(t, err)
+
+		task = NewLogMergeCompactionTask(ctx, cm, newPlan(
+			&datapb.CompactionSegmentBinlogs{SegmentID: 1, Manifest: "manifest"},
+		), compactionParams)
+		_, err = task.Compact()
+		assert.Error(t, err)
+	})
+
+	t.Run("missing deltalog", func(t *testing.T) {
+		segment := &datapb.CompactionSegmentBinlogs{
+			CollectionID: CollectionID,
+			PartitionID:  PartitionID,
+			SegmentID:    SegmentID,
+			Deltalogs: []*datapb.FieldBinlog{{Binlogs: []*datapb.Binlog{
+				{LogID: 20, LogPath: "not-exist-1"},
+				{LogID: 21, LogPath: "not-exist-2"},
+			}}},
+		}
+		task := NewLogMergeCompactionTask(ctx, cm, newPlan(segment), compactionParams)
+		_, err := task.Compact()
+		assert.Error(t, err)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		task := NewLogMergeCompactionTask(ctx, cm, newPlan(), compactionParams)
+		task.Complete()
+		task.Stop()
+		_, err := task.Compact()
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -485,6 +485,17 @@ func (t *mixCompactionTask) Compact() (*datapb.CompactionPlanResult, error) {
 		}
 	}
 
+	// the plan requiring sorted output is always merge sorted regardless of the merge sort params.
+	if t.plan.GetRequireSortedOutput() {
+		for _, segment := range t.plan.GetSegmentBinlogs() {
+			if !segment.GetIsSorted() && !segment.GetIsSortedByNamespace() {
+				mlog.Warn(context.TODO(), "compact wrong, sorted output requires sorted input segments", mlog.Int64("segmentID", segment.GetSegmentID()))
+				return nil, merr.WrapErrIllegalCompactionPlanMsg("input segment %d is not sorted, the plan requires sorted output", segment.GetSegmentID())
+			}
+		}
+		sortMergeAppicable = true
+	}
+
 	var res []*datapb.CompactionSegment
 	var err error
 	if sortMergeAppicable {
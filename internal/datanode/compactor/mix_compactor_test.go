@@ -43,6 +43,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/etcdpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
@@ -989,6 +990,17 @@ func (s *MixCompactionTaskStorageV1Suite) TestCompactFail() {
 		_, err := s.task.Compact()
 		s.Error(err)
 	})
+
+	s.Run("Test compact unsorted segment requiring sorted output", func() {
+		s.task.plan.RequireSortedOutput = true
+		s.task.plan.SegmentBinlogs = []*datapb.CompactionSegmentBinlogs{{
+			CollectionID: 1,
+			SegmentID:    100,
+			FieldBinlogs: []*datapb.FieldBinlog{{FieldID: 0, Binlogs: []*datapb.Binlog{{LogID: 1, EntriesNum: 1}}}},
+		}}
+		_, err := s.task.Compact()
+		s.ErrorIs(err, merr.ErrIllegalCompactionPlan)
+	})
 }
 
 func getRow(magic int64, ts int64) map[int64]interface{} {
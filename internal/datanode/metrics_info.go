@@ -19,6 +19,8 @@ package datanode
 import (
 	"context"
 
+	"github.com/samber/lo"
+
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/flushcommon/util"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
@@ -51,16 +53,20 @@ func (node *DataNode) getQuotaMetrics() (*metricsinfo.DataNodeQuotaMetrics, erro
 	}
 
 	minFGChannel, minFGTt := util.GetRateCollector().GetMinFlowGraphTt()
+	walBacklogs := util.GetRateCollector().GetCollectionWALBacklogs()
 	return &metricsinfo.DataNodeQuotaMetrics{
 		Hms: metricsinfo.HardwareMetrics{},
 		Rms: rms,
 		Fgm: metricsinfo.FlowGraphMetric{
 			MinFlowGraphChannel: minFGChannel,
 			MinFlowGraphTt:      minFGTt,
+			PausedChannels:      util.GetRateCollector().GetPausedFlowGraphChannels(),
 		},
 		Effect: metricsinfo.NodeEffect{
-			NodeID: node.GetSession().ServerID,
+			NodeID:        node.GetSession().ServerID,
+			CollectionIDs: lo.Keys(walBacklogs),
 		},
+		WALBacklogs: walBacklogs,
 	}, nil
 }
 
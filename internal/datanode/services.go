@@ -218,6 +218,7 @@ func (node *DataNode) CompactionV2(ctx context.Context, req *datapb.CompactionPl
 		)
 		return merr.Status(err), err
 	}
+	cm = compactor.NewThrottledChunkManager(cm, req.GetIoBudgetMb())
 	var task compactor.Compactor
 	binlogIO := io.NewBinlogIO(cm)
 	namespaceEnabled := req.GetSchema().GetEnableNamespace()
@@ -304,6 +305,8 @@ func (node *DataNode) CompactionV2(ctx context.Context, req *datapb.CompactionPl
 		)
 	case datapb.CompactionType_BumpSchemaVersionCompaction:
 		task = compactor.NewBumpSchemaVersionCompactionTask(taskCtx, cm, req, compactionParams)
+	case datapb.CompactionType_LogMergeCompaction:
+		task = compactor.NewLogMergeCompactionTask(taskCtx, cm, req, compactionParams)
 	default:
 		mlog.Warn(context.TODO(), "Unknown compaction type", mlog.String("type", req.GetType().String()))
 		return merr.Status(merr.WrapErrServiceInternalMsg("Unknown compaction type: %v", req.GetType().String())), nil
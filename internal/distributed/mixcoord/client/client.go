@@ -1227,6 +1227,18 @@ func (c *Client) ManualCompaction(ctx context.Context, req *milvuspb.ManualCompa
 	})
 }
 
+// ReindexSegments triggers the mix compaction of each given segment to rebuild it
+func (c *Client) ReindexSegments(ctx context.Context, req *datapb.ReindexSegmentsRequest, opts ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error) {
+	req = typeutil.Clone(req)
+	commonpbutil.UpdateMsgBase(
+		req.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.grpcClient.GetNodeID())),
+	)
+	return wrapGrpcCall(ctx, c, func(client MixCoordClient) (*milvuspb.ManualCompactionResponse, error) {
+		return client.ReindexSegments(ctx, req)
+	})
+}
+
 // GetCompactionState gets the state of a compaction
 func (c *Client) GetCompactionState(ctx context.Context, req *milvuspb.GetCompactionStateRequest, opts ...grpc.CallOption) (*milvuspb.GetCompactionStateResponse, error) {
 	return wrapGrpcCall(ctx, c, func(client MixCoordClient) (*milvuspb.GetCompactionStateResponse, error) {
@@ -1965,6 +1977,39 @@ func (c *Client) CheckQueryNodeDistribution(ctx context.Context, req *querypb.Ch
 	})
 }
 
+func (c *Client) DecommissionNode(ctx context.Context, req *querypb.DecommissionNodeRequest, opts ...grpc.CallOption) (*querypb.DecommissionNodeResponse, error) {
+	req = typeutil.Clone(req)
+	commonpbutil.UpdateMsgBase(
+		req.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.grpcClient.GetNodeID())),
+	)
+	return wrapGrpcCall(ctx, c, func(client MixCoordClient) (*querypb.DecommissionNodeResponse, error) {
+		return client.DecommissionNode(ctx, req)
+	})
+}
+
+func (c *Client) ListReplicaSuggestions(ctx context.Context, req *querypb.ListReplicaSuggestionsRequest, opts ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error) {
+	req = typeutil.Clone(req)
+	commonpbutil.UpdateMsgBase(
+		req.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.grpcClient.GetNodeID())),
+	)
+	return wrapGrpcCall(ctx, c, func(client MixCoordClient) (*querypb.ListReplicaSuggestionsResponse, error) {
+		return client.ListReplicaSuggestions(ctx, req)
+	})
+}
+
+func (c *Client) ApproveReplicaSuggestions(ctx context.Context, req *querypb.ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	req = typeutil.Clone(req)
+	commonpbutil.UpdateMsgBase(
+		req.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.grpcClient.GetNodeID())),
+	)
+	return wrapGrpcCall(ctx, c, func(client MixCoordClient) (*commonpb.Status, error) {
+		return client.ApproveReplicaSuggestions(ctx, req)
+	})
+}
+
 func (c *Client) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	req = typeutil.Clone(req)
 	commonpbutil.UpdateMsgBase(
@@ -2177,6 +2222,17 @@ func (c *Client) ListRestoreSnapshotJobs(ctx context.Context, req *datapb.ListRe
 	})
 }
 
+func (c *Client) CloneCollection(ctx context.Context, req *datapb.CloneCollectionRequest, opts ...grpc.CallOption) (*datapb.CloneCollectionResponse, error) {
+	req = typeutil.Clone(req)
+	commonpbutil.UpdateMsgBase(
+		req.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.grpcClient.GetNodeID())),
+	)
+	return wrapGrpcCall(ctx, c, func(client MixCoordClient) (*datapb.CloneCollectionResponse, error) {
+		return client.CloneCollection(ctx, req)
+	})
+}
+
 func (c *Client) PinSnapshotData(ctx context.Context, req *datapb.PinSnapshotDataRequest, opts ...grpc.CallOption) (*datapb.PinSnapshotDataResponse, error) {
 	req = typeutil.Clone(req)
 	commonpbutil.UpdateMsgBase(
@@ -732,6 +732,18 @@ func (s *Server) CheckQueryNodeDistribution(ctx context.Context, req *querypb.Ch
 	return s.mixCoord.CheckQueryNodeDistribution(ctx, req)
 }
 
+func (s *Server) DecommissionNode(ctx context.Context, req *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error) {
+	return s.mixCoord.DecommissionNode(ctx, req)
+}
+
+func (s *Server) ListReplicaSuggestions(ctx context.Context, req *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error) {
+	return s.mixCoord.ListReplicaSuggestions(ctx, req)
+}
+
+func (s *Server) ApproveReplicaSuggestions(ctx context.Context, req *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error) {
+	return s.mixCoord.ApproveReplicaSuggestions(ctx, req)
+}
+
 func (s *Server) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest) (*commonpb.Status, error) {
 	return s.mixCoord.UpdateLoadConfig(ctx, req)
 }
@@ -832,6 +844,11 @@ func (s *Server) ManualCompaction(ctx context.Context, req *milvuspb.ManualCompa
 	return s.mixCoord.ManualCompaction(ctx, req)
 }
 
+// ReindexSegments triggers the mix compaction of each given segment to rebuild it
+func (s *Server) ReindexSegments(ctx context.Context, req *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error) {
+	return s.mixCoord.ReindexSegments(ctx, req)
+}
+
 // GetCompactionState gets the state of a compaction
 func (s *Server) GetCompactionState(ctx context.Context, req *milvuspb.GetCompactionStateRequest) (*milvuspb.GetCompactionStateResponse, error) {
 	return s.mixCoord.GetCompactionState(ctx, req)
@@ -1044,6 +1061,10 @@ func (s *Server) ListRestoreSnapshotJobs(ctx context.Context, req *datapb.ListRe
 	return s.mixCoord.ListRestoreSnapshotJobs(ctx, req)
 }
 
+func (s *Server) CloneCollection(ctx context.Context, req *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error) {
+	return s.mixCoord.CloneCollection(ctx, req)
+}
+
 func (s *Server) PinSnapshotData(ctx context.Context, req *datapb.PinSnapshotDataRequest) (*datapb.PinSnapshotDataResponse, error) {
 	return s.mixCoord.PinSnapshotData(ctx, req)
 }
@@ -35,6 +35,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
 // make sure ttNode implements flowgraph.Node
@@ -42,6 +43,7 @@ var _ flowgraph.Node = (*ttNode)(nil)
 
 type ttNode struct {
 	BaseNode
+	collectionID       typeutil.UniqueID
 	vChannelName       string
 	metacache          metacache.MetaCache
 	writeBufferManager writebuffer.BufferManager
@@ -112,6 +114,7 @@ func (ttn *ttNode) Operate(in []Msg) []Msg {
 		}
 		return in
 	}
+	util.GetRateCollector().UpdateCollectionFlowGraphTt(ttn.collectionID, ttn.vChannelName, fgMsg.TimeRange.TimestampMax)
 
 	// Do not block and async updateCheckPoint
 	channelPos, needUpdate, err := ttn.writeBufferManager.GetCheckpoint(ttn.vChannelName)
@@ -213,6 +216,7 @@ func newTTNode(config *nodeConfig, wbManager writebuffer.BufferManager, cpUpdate
 
 	tt := &ttNode{
 		BaseNode:           baseNode,
+		collectionID:       config.collectionID,
 		vChannelName:       config.vChannelName,
 		metacache:          config.metacache,
 		writeBufferManager: wbManager,
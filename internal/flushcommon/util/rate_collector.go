@@ -38,6 +38,10 @@ type RateCollector struct {
 
 	flowGraphTtMu sync.Mutex
 	flowGraphTt   map[string]typeutil.Timestamp
+	// flowGraphCollection is the collection of the vchannels whose time ticks are updated with the collection.
+	flowGraphCollection map[string]typeutil.UniqueID
+	// pausedFlowGraphs are the vchannels whose flow graphs are paused, their time ticks are not reported as delayed.
+	pausedFlowGraphs typeutil.Set[string]
 }
 
 func initGlobalRateCollector() {
@@ -73,8 +77,10 @@ func newRateCollector() (*RateCollector, error) {
 		return nil, err
 	}
 	return &RateCollector{
-		RateCollector: rc,
-		flowGraphTt:   make(map[string]typeutil.Timestamp),
+		RateCollector:       rc,
+		flowGraphTt:         make(map[string]typeutil.Timestamp),
+		flowGraphCollection: make(map[string]typeutil.UniqueID),
+		pausedFlowGraphs:    typeutil.NewSet[string](),
 	}, nil
 }
 
@@ -90,15 +96,71 @@ func (r *RateCollector) RemoveFlowGraphChannel(channel string) {
 	r.flowGraphTtMu.Lock()
 	defer r.flowGraphTtMu.Unlock()
 	delete(r.flowGraphTt, channel)
+	delete(r.flowGraphCollection, channel)
+	r.pausedFlowGraphs.Remove(channel)
 }
 
-// GetMinFlowGraphTt returns the vchannel and minimal time tick of flow graphs.
+// PauseFlowGraphChannel marks the flow graph of the channel as paused.
+func (r *RateCollector) PauseFlowGraphChannel(channel string) {
+	r.flowGraphTtMu.Lock()
+	defer r.flowGraphTtMu.Unlock()
+	r.pausedFlowGraphs.Insert(channel)
+}
+
+// ResumeFlowGraphChannel marks the flow graph of the channel as resumed.
+func (r *RateCollector) ResumeFlowGraphChannel(channel string) {
+	r.flowGraphTtMu.Lock()
+	defer r.flowGraphTtMu.Unlock()
+	r.pausedFlowGraphs.Remove(channel)
+}
+
+// GetPausedFlowGraphChannels returns the channels whose flow graphs are paused.
+func (r *RateCollector) GetPausedFlowGraphChannels() []string {
+	r.flowGraphTtMu.Lock()
+	defer r.flowGraphTtMu.Unlock()
+	return r.pausedFlowGraphs.Collect()
+}
+
+// UpdateCollectionFlowGraphTt updates the time tick consumed by the flow graph of the collection's vchannel.
+func (r *RateCollector) UpdateCollectionFlowGraphTt(collectionID typeutil.UniqueID, channel string, t typeutil.Timestamp) {
+	r.flowGraphTtMu.Lock()
+	defer r.flowGraphTtMu.Unlock()
+	r.flowGraphTt[channel] = t
+	r.flowGraphCollection[channel] = collectionID
+}
+
+// GetCollectionWALBacklogs returns the vchannel with the minimal consumed time tick of each collection,
+// the paused vchannels are skipped.
+func (r *RateCollector) GetCollectionWALBacklogs() map[typeutil.UniqueID]metricsinfo.WALBacklog {
+	r.flowGraphTtMu.Lock()
+	defer r.flowGraphTtMu.Unlock()
+	backlogs := make(map[typeutil.UniqueID]metricsinfo.WALBacklog)
+	for channel, collectionID := range r.flowGraphCollection {
+		if r.pausedFlowGraphs.Contain(channel) {
+			continue
+		}
+		t := r.flowGraphTt[channel]
+		if backlog, ok := backlogs[collectionID]; ok && backlog.ConsumedTt <= t {
+			continue
+		}
+		backlogs[collectionID] = metricsinfo.WALBacklog{
+			Channel:    channel,
+			ConsumedTt: t,
+		}
+	}
+	return backlogs
+}
+
+// GetMinFlowGraphTt returns the vchannel and minimal time tick of flow graphs, the paused vchannels are skipped.
 func (r *RateCollector) GetMinFlowGraphTt() (string, typeutil.Timestamp) {
 	r.flowGraphTtMu.Lock()
 	defer r.flowGraphTtMu.Unlock()
 	minTt := typeutil.MaxTimestamp
 	var channel string
 	for c, t := range r.flowGraphTt {
+		if r.pausedFlowGraphs.Contain(c) {
+			continue
+		}
 		if minTt > t {
 			minTt = t
 			channel = c
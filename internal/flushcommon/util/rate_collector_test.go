@@ -39,4 +39,46 @@ func TestRateCollector(t *testing.T) {
 		assert.Equal(t, "channel3", c)
 		assert.Equal(t, typeutil.Timestamp(50), minTt)
 	})
+	t.Run("test collection WAL backlogs", func(t *testing.T) {
+		collector, err := newRateCollector()
+		assert.NoError(t, err)
+
+		assert.Empty(t, collector.GetCollectionWALBacklogs())
+		collector.UpdateCollectionFlowGraphTt(1, "channel1", 100)
+		collector.UpdateCollectionFlowGraphTt(1, "channel2", 50)
+		collector.UpdateCollectionFlowGraphTt(2, "channel3", 200)
+		backlogs := collector.GetCollectionWALBacklogs()
+		assert.Len(t, backlogs, 2)
+		assert.Equal(t, "channel2", backlogs[1].Channel)
+		assert.Equal(t, typeutil.Timestamp(50), backlogs[1].ConsumedTt)
+		assert.Equal(t, typeutil.Timestamp(200), backlogs[2].ConsumedTt)
+
+		collector.RemoveFlowGraphChannel("channel2")
+		backlogs = collector.GetCollectionWALBacklogs()
+		assert.Equal(t, "channel1", backlogs[1].Channel)
+		assert.Equal(t, typeutil.Timestamp(100), backlogs[1].ConsumedTt)
+	})
+	t.Run("test paused flow graphs", func(t *testing.T) {
+		collector, err := newRateCollector()
+		assert.NoError(t, err)
+
+		collector.UpdateFlowGraphTt("channel1", 100)
+		collector.UpdateCollectionFlowGraphTt(1, "channel1", 100)
+		collector.UpdateCollectionFlowGraphTt(1, "channel2", 50)
+		collector.PauseFlowGraphChannel("channel2")
+		assert.ElementsMatch(t, []string{"channel2"}, collector.GetPausedFlowGraphChannels())
+
+		// the paused channel is not reported as delayed
+		c, minTt := collector.GetMinFlowGraphTt()
+		assert.Equal(t, "channel1", c)
+		assert.Equal(t, typeutil.Timestamp(100), minTt)
+		backlogs := collector.GetCollectionWALBacklogs()
+		assert.Equal(t, "channel1", backlogs[1].Channel)
+
+		collector.ResumeFlowGraphChannel("channel2")
+		assert.Empty(t, collector.GetPausedFlowGraphChannels())
+		c, _ = collector.GetMinFlowGraphTt()
+		assert.Equal(t, "channel2", c)
+		assert.Equal(t, "channel2", collector.GetCollectionWALBacklogs()[1].Channel)
+	})
 }
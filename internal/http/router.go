@@ -58,6 +58,10 @@ const (
 
 	RouteCommitBackfill = "/management/datacoord/backfill/commit"
 
+	RouteCloneCollection = "/management/datacoord/collection/clone"
+
+	RouteReindexSegments = "/management/datacoord/compaction/reindex"
+
 	RouteSuspendQueryCoordBalance = "/management/querycoord/balance/suspend"
 	RouteResumeQueryCoordBalance  = "/management/querycoord/balance/resume"
 	RouteQueryCoordBalanceStatus  = "/management/querycoord/balance/status"
@@ -66,9 +70,12 @@ const (
 
 	RouteSuspendQueryNode           = "/management/querycoord/node/suspend"
 	RouteResumeQueryNode            = "/management/querycoord/node/resume"
+	RouteDecommissionQueryNode      = "/management/querycoord/node/decommission"
 	RouteListQueryNode              = "/management/querycoord/node/list"
 	RouteGetQueryNodeDistribution   = "/management/querycoord/distribution/get"
 	RouteCheckQueryNodeDistribution = "/management/querycoord/distribution/check"
+	RouteListReplicaSuggestions     = "/management/querycoord/replica/suggestion/list"
+	RouteApproveReplicaSuggestions  = "/management/querycoord/replica/suggestion/approve"
 	RouteClearReadTaskQueue         = "/management/query/task_queue/clear"
 )
 
@@ -84,6 +91,8 @@ const (
 	StreamingNodeStatusPath       = "/management/streaming/nodes/status"
 	StreamingNodeDistributionPath = "/management/streaming/nodes/distribution"
 	StreamingTransferPath         = "/management/streaming/transfer"
+	StreamingChannelMovePath      = "/management/streaming/channel/move"
+	StreamingChannelRecoveryPath  = "/management/streaming/channel/recovery"
 
 	WALAlterPath = "/management/wal/alter"
 
@@ -93,6 +102,28 @@ const (
 	DataGCPath = "/management/data_gc"
 
 	ReplicaLoadConfigCompliancePath = "/management/replica/loadconfig/compliance"
+	ReplicaNumberAlterPath          = "/management/replica/alter"
+	ReplicaWeightAlterPath          = "/management/replica/weight"
+	ReplicaLoadProgressPath         = "/management/replica/load_progress"
+
+	StorageUsagePath     = "/management/rootcoord/storage/usage"
+	PropertyPresetPath   = "/management/rootcoord/property_preset"
+	QuotaExemptionPath   = "/management/rootcoord/quota/exemption"
+	RecycleBinPath       = "/management/rootcoord/recycle_bin"
+	CredentialScopePath  = "/management/rootcoord/credential/scope"
+	CollectionFreezePath = "/management/rootcoord/collection/freeze"
+
+	ChannelOwnershipPath = "/management/datacoord/channel/ownership"
+	ChannelPausePath     = "/management/datacoord/channel/pause"
+
+	CompactionPriorityBoostPath = "/management/datacoord/compaction/priority_boost"
+	SegmentAccessStatsPath      = "/management/datacoord/segment/access_stats"
+	OrphanSegmentPath           = "/management/datacoord/segment/orphan"
+
+	SegmentReloadPath = "/management/querycoord/segment/reload"
+	LoadAuditPath     = "/management/querycoord/load_audit"
+
+	DataSkewPath = "/management/data_skew"
 )
 
 // for WebUI restful api root path
@@ -134,6 +165,8 @@ const (
 	DCImportTasksPath = "/_dc/tasks/import"
 	// DCCompactionTasksPath is the path to get compaction tasks in DataCoord.
 	DCCompactionTasksPath = "/_dc/tasks/compaction"
+	// DCCompactionCleaningTasksPath is the path to get the compaction tasks waiting to be cleaned in DataCoord.
+	DCCompactionCleaningTasksPath = "/_dc/tasks/compaction/cleaning"
 	// DCBuildIndexTasksPath is the path to get build index tasks in DataCoord.
 	DCBuildIndexTasksPath = "/_dc/tasks/build_index"
 	// DCSegmentsPath is the path to get segments in DataCoord.
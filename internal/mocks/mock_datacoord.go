@@ -449,6 +449,65 @@ func (_c *MockDataCoord_CheckHealth_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// CloneCollection provides a mock function with given fields: _a0, _a1
+func (_m *MockDataCoord) CloneCollection(_a0 context.Context, _a1 *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneCollection")
+	}
+
+	var r0 *datapb.CloneCollectionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CloneCollectionRequest) *datapb.CloneCollectionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.CloneCollectionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.CloneCollectionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoord_CloneCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloneCollection'
+type MockDataCoord_CloneCollection_Call struct {
+	*mock.Call
+}
+
+// CloneCollection is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.CloneCollectionRequest
+func (_e *MockDataCoord_Expecter) CloneCollection(_a0 interface{}, _a1 interface{}) *MockDataCoord_CloneCollection_Call {
+	return &MockDataCoord_CloneCollection_Call{Call: _e.mock.On("CloneCollection", _a0, _a1)}
+}
+
+func (_c *MockDataCoord_CloneCollection_Call) Run(run func(_a0 context.Context, _a1 *datapb.CloneCollectionRequest)) *MockDataCoord_CloneCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.CloneCollectionRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataCoord_CloneCollection_Call) Return(_a0 *datapb.CloneCollectionResponse, _a1 error) *MockDataCoord_CloneCollection_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoord_CloneCollection_Call) RunAndReturn(run func(context.Context, *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error)) *MockDataCoord_CloneCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CommitBackfillResult provides a mock function with given fields: _a0, _a1
 func (_m *MockDataCoord) CommitBackfillResult(_a0 context.Context, _a1 *datapb.CommitBackfillResultRequest) (*datapb.CommitBackfillResultResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -3430,6 +3489,65 @@ func (_c *MockDataCoord_Register_Call) RunAndReturn(run func() error) *MockDataC
 	return _c
 }
 
+// ReindexSegments provides a mock function with given fields: _a0, _a1
+func (_m *MockDataCoord) ReindexSegments(_a0 context.Context, _a1 *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReindexSegments")
+	}
+
+	var r0 *milvuspb.ManualCompactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ReindexSegmentsRequest) *milvuspb.ManualCompactionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*milvuspb.ManualCompactionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.ReindexSegmentsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoord_ReindexSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReindexSegments'
+type MockDataCoord_ReindexSegments_Call struct {
+	*mock.Call
+}
+
+// ReindexSegments is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.ReindexSegmentsRequest
+func (_e *MockDataCoord_Expecter) ReindexSegments(_a0 interface{}, _a1 interface{}) *MockDataCoord_ReindexSegments_Call {
+	return &MockDataCoord_ReindexSegments_Call{Call: _e.mock.On("ReindexSegments", _a0, _a1)}
+}
+
+func (_c *MockDataCoord_ReindexSegments_Call) Run(run func(_a0 context.Context, _a1 *datapb.ReindexSegmentsRequest)) *MockDataCoord_ReindexSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.ReindexSegmentsRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataCoord_ReindexSegments_Call) Return(_a0 *milvuspb.ManualCompactionResponse, _a1 error) *MockDataCoord_ReindexSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoord_ReindexSegments_Call) RunAndReturn(run func(context.Context, *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error)) *MockDataCoord_ReindexSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReportDataNodeTtMsgs provides a mock function with given fields: _a0, _a1
 func (_m *MockDataCoord) ReportDataNodeTtMsgs(_a0 context.Context, _a1 *datapb.ReportDataNodeTtMsgsRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
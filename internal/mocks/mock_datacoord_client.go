@@ -551,6 +551,80 @@ func (_c *MockDataCoordClient_CheckHealth_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// CloneCollection provides a mock function with given fields: ctx, in, opts
+func (_m *MockDataCoordClient) CloneCollection(ctx context.Context, in *datapb.CloneCollectionRequest, opts ...grpc.CallOption) (*datapb.CloneCollectionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneCollection")
+	}
+
+	var r0 *datapb.CloneCollectionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CloneCollectionRequest, ...grpc.CallOption) (*datapb.CloneCollectionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CloneCollectionRequest, ...grpc.CallOption) *datapb.CloneCollectionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.CloneCollectionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.CloneCollectionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoordClient_CloneCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloneCollection'
+type MockDataCoordClient_CloneCollection_Call struct {
+	*mock.Call
+}
+
+// CloneCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.CloneCollectionRequest
+//   - opts ...grpc.CallOption
+func (_e *MockDataCoordClient_Expecter) CloneCollection(ctx interface{}, in interface{}, opts ...interface{}) *MockDataCoordClient_CloneCollection_Call {
+	return &MockDataCoordClient_CloneCollection_Call{Call: _e.mock.On("CloneCollection",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDataCoordClient_CloneCollection_Call) Run(run func(ctx context.Context, in *datapb.CloneCollectionRequest, opts ...grpc.CallOption)) *MockDataCoordClient_CloneCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.CloneCollectionRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDataCoordClient_CloneCollection_Call) Return(_a0 *datapb.CloneCollectionResponse, _a1 error) *MockDataCoordClient_CloneCollection_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoordClient_CloneCollection_Call) RunAndReturn(run func(context.Context, *datapb.CloneCollectionRequest, ...grpc.CallOption) (*datapb.CloneCollectionResponse, error)) *MockDataCoordClient_CloneCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *MockDataCoordClient) Close() error {
 	ret := _m.Called()
@@ -4222,6 +4296,80 @@ func (_c *MockDataCoordClient_RefreshExternalCollection_Call) RunAndReturn(run f
 	return _c
 }
 
+// ReindexSegments provides a mock function with given fields: ctx, in, opts
+func (_m *MockDataCoordClient) ReindexSegments(ctx context.Context, in *datapb.ReindexSegmentsRequest, opts ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReindexSegments")
+	}
+
+	var r0 *milvuspb.ManualCompactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ReindexSegmentsRequest, ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ReindexSegmentsRequest, ...grpc.CallOption) *milvuspb.ManualCompactionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*milvuspb.ManualCompactionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.ReindexSegmentsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataCoordClient_ReindexSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReindexSegments'
+type MockDataCoordClient_ReindexSegments_Call struct {
+	*mock.Call
+}
+
+// ReindexSegments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.ReindexSegmentsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockDataCoordClient_Expecter) ReindexSegments(ctx interface{}, in interface{}, opts ...interface{}) *MockDataCoordClient_ReindexSegments_Call {
+	return &MockDataCoordClient_ReindexSegments_Call{Call: _e.mock.On("ReindexSegments",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDataCoordClient_ReindexSegments_Call) Run(run func(ctx context.Context, in *datapb.ReindexSegmentsRequest, opts ...grpc.CallOption)) *MockDataCoordClient_ReindexSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.ReindexSegmentsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDataCoordClient_ReindexSegments_Call) Return(_a0 *milvuspb.ManualCompactionResponse, _a1 error) *MockDataCoordClient_ReindexSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataCoordClient_ReindexSegments_Call) RunAndReturn(run func(context.Context, *datapb.ReindexSegmentsRequest, ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error)) *MockDataCoordClient_ReindexSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReportDataNodeTtMsgs provides a mock function with given fields: ctx, in, opts
 func (_m *MockDataCoordClient) ReportDataNodeTtMsgs(ctx context.Context, in *datapb.ReportDataNodeTtMsgsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
@@ -1037,6 +1037,65 @@ func (_c *MixCoord_AlterRole_Call) RunAndReturn(run func(context.Context, *milvu
 	return _c
 }
 
+// ApproveReplicaSuggestions provides a mock function with given fields: _a0, _a1
+func (_m *MixCoord) ApproveReplicaSuggestions(_a0 context.Context, _a1 *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveReplicaSuggestions")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MixCoord_ApproveReplicaSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveReplicaSuggestions'
+type MixCoord_ApproveReplicaSuggestions_Call struct {
+	*mock.Call
+}
+
+// ApproveReplicaSuggestions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *querypb.ApproveReplicaSuggestionsRequest
+func (_e *MixCoord_Expecter) ApproveReplicaSuggestions(_a0 interface{}, _a1 interface{}) *MixCoord_ApproveReplicaSuggestions_Call {
+	return &MixCoord_ApproveReplicaSuggestions_Call{Call: _e.mock.On("ApproveReplicaSuggestions", _a0, _a1)}
+}
+
+func (_c *MixCoord_ApproveReplicaSuggestions_Call) Run(run func(_a0 context.Context, _a1 *querypb.ApproveReplicaSuggestionsRequest)) *MixCoord_ApproveReplicaSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*querypb.ApproveReplicaSuggestionsRequest))
+	})
+	return _c
+}
+
+func (_c *MixCoord_ApproveReplicaSuggestions_Call) Return(_a0 *commonpb.Status, _a1 error) *MixCoord_ApproveReplicaSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MixCoord_ApproveReplicaSuggestions_Call) RunAndReturn(run func(context.Context, *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error)) *MixCoord_ApproveReplicaSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AssignSegmentID provides a mock function with given fields: _a0, _a1
 func (_m *MixCoord) AssignSegmentID(_a0 context.Context, _a1 *datapb.AssignSegmentIDRequest) (*datapb.AssignSegmentIDResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1627,6 +1686,65 @@ func (_c *MixCoord_ClientHeartbeat_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// CloneCollection provides a mock function with given fields: _a0, _a1
+func (_m *MixCoord) CloneCollection(_a0 context.Context, _a1 *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneCollection")
+	}
+
+	var r0 *datapb.CloneCollectionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CloneCollectionRequest) *datapb.CloneCollectionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.CloneCollectionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.CloneCollectionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MixCoord_CloneCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloneCollection'
+type MixCoord_CloneCollection_Call struct {
+	*mock.Call
+}
+
+// CloneCollection is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.CloneCollectionRequest
+func (_e *MixCoord_Expecter) CloneCollection(_a0 interface{}, _a1 interface{}) *MixCoord_CloneCollection_Call {
+	return &MixCoord_CloneCollection_Call{Call: _e.mock.On("CloneCollection", _a0, _a1)}
+}
+
+func (_c *MixCoord_CloneCollection_Call) Run(run func(_a0 context.Context, _a1 *datapb.CloneCollectionRequest)) *MixCoord_CloneCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.CloneCollectionRequest))
+	})
+	return _c
+}
+
+func (_c *MixCoord_CloneCollection_Call) Return(_a0 *datapb.CloneCollectionResponse, _a1 error) *MixCoord_CloneCollection_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MixCoord_CloneCollection_Call) RunAndReturn(run func(context.Context, *datapb.CloneCollectionRequest) (*datapb.CloneCollectionResponse, error)) *MixCoord_CloneCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CommitBackfillResult provides a mock function with given fields: _a0, _a1
 func (_m *MixCoord) CommitBackfillResult(_a0 context.Context, _a1 *datapb.CommitBackfillResultRequest) (*datapb.CommitBackfillResultResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2512,6 +2630,65 @@ func (_c *MixCoord_DeactivateChecker_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// DecommissionNode provides a mock function with given fields: _a0, _a1
+func (_m *MixCoord) DecommissionNode(_a0 context.Context, _a1 *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecommissionNode")
+	}
+
+	var r0 *querypb.DecommissionNodeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.DecommissionNodeRequest) *querypb.DecommissionNodeResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.DecommissionNodeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.DecommissionNodeRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MixCoord_DecommissionNode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecommissionNode'
+type MixCoord_DecommissionNode_Call struct {
+	*mock.Call
+}
+
+// DecommissionNode is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *querypb.DecommissionNodeRequest
+func (_e *MixCoord_Expecter) DecommissionNode(_a0 interface{}, _a1 interface{}) *MixCoord_DecommissionNode_Call {
+	return &MixCoord_DecommissionNode_Call{Call: _e.mock.On("DecommissionNode", _a0, _a1)}
+}
+
+func (_c *MixCoord_DecommissionNode_Call) Run(run func(_a0 context.Context, _a1 *querypb.DecommissionNodeRequest)) *MixCoord_DecommissionNode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*querypb.DecommissionNodeRequest))
+	})
+	return _c
+}
+
+func (_c *MixCoord_DecommissionNode_Call) Return(_a0 *querypb.DecommissionNodeResponse, _a1 error) *MixCoord_DecommissionNode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MixCoord_DecommissionNode_Call) RunAndReturn(run func(context.Context, *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error)) *MixCoord_DecommissionNode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteClientCommand provides a mock function with given fields: _a0, _a1
 func (_m *MixCoord) DeleteClientCommand(_a0 context.Context, _a1 *milvuspb.DeleteClientCommandRequest) (*milvuspb.DeleteClientCommandResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -7475,6 +7652,65 @@ func (_c *MixCoord_ListRefreshExternalCollectionJobs_Call) RunAndReturn(run func
 	return _c
 }
 
+// ListReplicaSuggestions provides a mock function with given fields: _a0, _a1
+func (_m *MixCoord) ListReplicaSuggestions(_a0 context.Context, _a1 *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReplicaSuggestions")
+	}
+
+	var r0 *querypb.ListReplicaSuggestionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ListReplicaSuggestionsRequest) *querypb.ListReplicaSuggestionsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.ListReplicaSuggestionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.ListReplicaSuggestionsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MixCoord_ListReplicaSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReplicaSuggestions'
+type MixCoord_ListReplicaSuggestions_Call struct {
+	*mock.Call
+}
+
+// ListReplicaSuggestions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *querypb.ListReplicaSuggestionsRequest
+func (_e *MixCoord_Expecter) ListReplicaSuggestions(_a0 interface{}, _a1 interface{}) *MixCoord_ListReplicaSuggestions_Call {
+	return &MixCoord_ListReplicaSuggestions_Call{Call: _e.mock.On("ListReplicaSuggestions", _a0, _a1)}
+}
+
+func (_c *MixCoord_ListReplicaSuggestions_Call) Run(run func(_a0 context.Context, _a1 *querypb.ListReplicaSuggestionsRequest)) *MixCoord_ListReplicaSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*querypb.ListReplicaSuggestionsRequest))
+	})
+	return _c
+}
+
+func (_c *MixCoord_ListReplicaSuggestions_Call) Return(_a0 *querypb.ListReplicaSuggestionsResponse, _a1 error) *MixCoord_ListReplicaSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MixCoord_ListReplicaSuggestions_Call) RunAndReturn(run func(context.Context, *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error)) *MixCoord_ListReplicaSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListResourceGroups provides a mock function with given fields: _a0, _a1
 func (_m *MixCoord) ListResourceGroups(_a0 context.Context, _a1 *milvuspb.ListResourceGroupsRequest) (*milvuspb.ListResourceGroupsResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -8474,6 +8710,65 @@ func (_c *MixCoord_RegisterStreamingCoordGRPCService_Call) RunAndReturn(run func
 	return _c
 }
 
+// ReindexSegments provides a mock function with given fields: _a0, _a1
+func (_m *MixCoord) ReindexSegments(_a0 context.Context, _a1 *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReindexSegments")
+	}
+
+	var r0 *milvuspb.ManualCompactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ReindexSegmentsRequest) *milvuspb.ManualCompactionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*milvuspb.ManualCompactionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.ReindexSegmentsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MixCoord_ReindexSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReindexSegments'
+type MixCoord_ReindexSegments_Call struct {
+	*mock.Call
+}
+
+// ReindexSegments is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.ReindexSegmentsRequest
+func (_e *MixCoord_Expecter) ReindexSegments(_a0 interface{}, _a1 interface{}) *MixCoord_ReindexSegments_Call {
+	return &MixCoord_ReindexSegments_Call{Call: _e.mock.On("ReindexSegments", _a0, _a1)}
+}
+
+func (_c *MixCoord_ReindexSegments_Call) Run(run func(_a0 context.Context, _a1 *datapb.ReindexSegmentsRequest)) *MixCoord_ReindexSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.ReindexSegmentsRequest))
+	})
+	return _c
+}
+
+func (_c *MixCoord_ReindexSegments_Call) Return(_a0 *milvuspb.ManualCompactionResponse, _a1 error) *MixCoord_ReindexSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MixCoord_ReindexSegments_Call) RunAndReturn(run func(context.Context, *datapb.ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error)) *MixCoord_ReindexSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReleaseCollection provides a mock function with given fields: _a0, _a1
 func (_m *MixCoord) ReleaseCollection(_a0 context.Context, _a1 *querypb.ReleaseCollectionRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1288,6 +1288,80 @@ func (_c *MockMixCoordClient_AlterRole_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// ApproveReplicaSuggestions provides a mock function with given fields: ctx, in, opts
+func (_m *MockMixCoordClient) ApproveReplicaSuggestions(ctx context.Context, in *querypb.ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveReplicaSuggestions")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest, ...grpc.CallOption) (*commonpb.Status, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest, ...grpc.CallOption) *commonpb.Status); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMixCoordClient_ApproveReplicaSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveReplicaSuggestions'
+type MockMixCoordClient_ApproveReplicaSuggestions_Call struct {
+	*mock.Call
+}
+
+// ApproveReplicaSuggestions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *querypb.ApproveReplicaSuggestionsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockMixCoordClient_Expecter) ApproveReplicaSuggestions(ctx interface{}, in interface{}, opts ...interface{}) *MockMixCoordClient_ApproveReplicaSuggestions_Call {
+	return &MockMixCoordClient_ApproveReplicaSuggestions_Call{Call: _e.mock.On("ApproveReplicaSuggestions",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockMixCoordClient_ApproveReplicaSuggestions_Call) Run(run func(ctx context.Context, in *querypb.ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption)) *MockMixCoordClient_ApproveReplicaSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*querypb.ApproveReplicaSuggestionsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockMixCoordClient_ApproveReplicaSuggestions_Call) Return(_a0 *commonpb.Status, _a1 error) *MockMixCoordClient_ApproveReplicaSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMixCoordClient_ApproveReplicaSuggestions_Call) RunAndReturn(run func(context.Context, *querypb.ApproveReplicaSuggestionsRequest, ...grpc.CallOption) (*commonpb.Status, error)) *MockMixCoordClient_ApproveReplicaSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AssignSegmentID provides a mock function with given fields: ctx, in, opts
 func (_m *MockMixCoordClient) AssignSegmentID(ctx context.Context, in *datapb.AssignSegmentIDRequest, opts ...grpc.CallOption) (*datapb.AssignSegmentIDResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -2028,6 +2102,80 @@ func (_c *MockMixCoordClient_ClientHeartbeat_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// CloneCollection provides a mock function with given fields: ctx, in, opts
+func (_m *MockMixCoordClient) CloneCollection(ctx context.Context, in *datapb.CloneCollectionRequest, opts ...grpc.CallOption) (*datapb.CloneCollectionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneCollection")
+	}
+
+	var r0 *datapb.CloneCollectionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CloneCollectionRequest, ...grpc.CallOption) (*datapb.CloneCollectionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.CloneCollectionRequest, ...grpc.CallOption) *datapb.CloneCollectionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.CloneCollectionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.CloneCollectionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMixCoordClient_CloneCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloneCollection'
+type MockMixCoordClient_CloneCollection_Call struct {
+	*mock.Call
+}
+
+// CloneCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.CloneCollectionRequest
+//   - opts ...grpc.CallOption
+func (_e *MockMixCoordClient_Expecter) CloneCollection(ctx interface{}, in interface{}, opts ...interface{}) *MockMixCoordClient_CloneCollection_Call {
+	return &MockMixCoordClient_CloneCollection_Call{Call: _e.mock.On("CloneCollection",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockMixCoordClient_CloneCollection_Call) Run(run func(ctx context.Context, in *datapb.CloneCollectionRequest, opts ...grpc.CallOption)) *MockMixCoordClient_CloneCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.CloneCollectionRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockMixCoordClient_CloneCollection_Call) Return(_a0 *datapb.CloneCollectionResponse, _a1 error) *MockMixCoordClient_CloneCollection_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMixCoordClient_CloneCollection_Call) RunAndReturn(run func(context.Context, *datapb.CloneCollectionRequest, ...grpc.CallOption) (*datapb.CloneCollectionResponse, error)) *MockMixCoordClient_CloneCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *MockMixCoordClient) Close() error {
 	ret := _m.Called()
@@ -3183,6 +3331,80 @@ func (_c *MockMixCoordClient_DeactivateChecker_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// DecommissionNode provides a mock function with given fields: ctx, in, opts
+func (_m *MockMixCoordClient) DecommissionNode(ctx context.Context, in *querypb.DecommissionNodeRequest, opts ...grpc.CallOption) (*querypb.DecommissionNodeResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecommissionNode")
+	}
+
+	var r0 *querypb.DecommissionNodeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.DecommissionNodeRequest, ...grpc.CallOption) (*querypb.DecommissionNodeResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.DecommissionNodeRequest, ...grpc.CallOption) *querypb.DecommissionNodeResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.DecommissionNodeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.DecommissionNodeRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMixCoordClient_DecommissionNode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecommissionNode'
+type MockMixCoordClient_DecommissionNode_Call struct {
+	*mock.Call
+}
+
+// DecommissionNode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *querypb.DecommissionNodeRequest
+//   - opts ...grpc.CallOption
+func (_e *MockMixCoordClient_Expecter) DecommissionNode(ctx interface{}, in interface{}, opts ...interface{}) *MockMixCoordClient_DecommissionNode_Call {
+	return &MockMixCoordClient_DecommissionNode_Call{Call: _e.mock.On("DecommissionNode",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockMixCoordClient_DecommissionNode_Call) Run(run func(ctx context.Context, in *querypb.DecommissionNodeRequest, opts ...grpc.CallOption)) *MockMixCoordClient_DecommissionNode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*querypb.DecommissionNodeRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockMixCoordClient_DecommissionNode_Call) Return(_a0 *querypb.DecommissionNodeResponse, _a1 error) *MockMixCoordClient_DecommissionNode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMixCoordClient_DecommissionNode_Call) RunAndReturn(run func(context.Context, *querypb.DecommissionNodeRequest, ...grpc.CallOption) (*querypb.DecommissionNodeResponse, error)) *MockMixCoordClient_DecommissionNode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteClientCommand provides a mock function with given fields: ctx, in, opts
 func (_m *MockMixCoordClient) DeleteClientCommand(ctx context.Context, in *milvuspb.DeleteClientCommandRequest, opts ...grpc.CallOption) (*milvuspb.DeleteClientCommandResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -8955,6 +9177,80 @@ func (_c *MockMixCoordClient_ListRefreshExternalCollectionJobs_Call) RunAndRetur
 	return _c
 }
 
+// ListReplicaSuggestions provides a mock function with given fields: ctx, in, opts
+func (_m *MockMixCoordClient) ListReplicaSuggestions(ctx context.Context, in *querypb.ListReplicaSuggestionsRequest, opts ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReplicaSuggestions")
+	}
+
+	var r0 *querypb.ListReplicaSuggestionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ListReplicaSuggestionsRequest, ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ListReplicaSuggestionsRequest, ...grpc.CallOption) *querypb.ListReplicaSuggestionsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.ListReplicaSuggestionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.ListReplicaSuggestionsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMixCoordClient_ListReplicaSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReplicaSuggestions'
+type MockMixCoordClient_ListReplicaSuggestions_Call struct {
+	*mock.Call
+}
+
+// ListReplicaSuggestions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *querypb.ListReplicaSuggestionsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockMixCoordClient_Expecter) ListReplicaSuggestions(ctx interface{}, in interface{}, opts ...interface{}) *MockMixCoordClient_ListReplicaSuggestions_Call {
+	return &MockMixCoordClient_ListReplicaSuggestions_Call{Call: _e.mock.On("ListReplicaSuggestions",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockMixCoordClient_ListReplicaSuggestions_Call) Run(run func(ctx context.Context, in *querypb.ListReplicaSuggestionsRequest, opts ...grpc.CallOption)) *MockMixCoordClient_ListReplicaSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*querypb.ListReplicaSuggestionsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockMixCoordClient_ListReplicaSuggestions_Call) Return(_a0 *querypb.ListReplicaSuggestionsResponse, _a1 error) *MockMixCoordClient_ListReplicaSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMixCoordClient_ListReplicaSuggestions_Call) RunAndReturn(run func(context.Context, *querypb.ListReplicaSuggestionsRequest, ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error)) *MockMixCoordClient_ListReplicaSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListResourceGroups provides a mock function with given fields: ctx, in, opts
 func (_m *MockMixCoordClient) ListResourceGroups(ctx context.Context, in *milvuspb.ListResourceGroupsRequest, opts ...grpc.CallOption) (*milvuspb.ListResourceGroupsResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -9991,6 +10287,80 @@ func (_c *MockMixCoordClient_RefreshExternalCollection_Call) RunAndReturn(run fu
 	return _c
 }
 
+// ReindexSegments provides a mock function with given fields: ctx, in, opts
+func (_m *MockMixCoordClient) ReindexSegments(ctx context.Context, in *datapb.ReindexSegmentsRequest, opts ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReindexSegments")
+	}
+
+	var r0 *milvuspb.ManualCompactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ReindexSegmentsRequest, ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ReindexSegmentsRequest, ...grpc.CallOption) *milvuspb.ManualCompactionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*milvuspb.ManualCompactionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.ReindexSegmentsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMixCoordClient_ReindexSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReindexSegments'
+type MockMixCoordClient_ReindexSegments_Call struct {
+	*mock.Call
+}
+
+// ReindexSegments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *datapb.ReindexSegmentsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockMixCoordClient_Expecter) ReindexSegments(ctx interface{}, in interface{}, opts ...interface{}) *MockMixCoordClient_ReindexSegments_Call {
+	return &MockMixCoordClient_ReindexSegments_Call{Call: _e.mock.On("ReindexSegments",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockMixCoordClient_ReindexSegments_Call) Run(run func(ctx context.Context, in *datapb.ReindexSegmentsRequest, opts ...grpc.CallOption)) *MockMixCoordClient_ReindexSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*datapb.ReindexSegmentsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockMixCoordClient_ReindexSegments_Call) Return(_a0 *milvuspb.ManualCompactionResponse, _a1 error) *MockMixCoordClient_ReindexSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMixCoordClient_ReindexSegments_Call) RunAndReturn(run func(context.Context, *datapb.ReindexSegmentsRequest, ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error)) *MockMixCoordClient_ReindexSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReleaseCollection provides a mock function with given fields: ctx, in, opts
 func (_m *MockMixCoordClient) ReleaseCollection(ctx context.Context, in *querypb.ReleaseCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
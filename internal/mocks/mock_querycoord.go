@@ -93,6 +93,65 @@ func (_c *MockQueryCoord_ActivateChecker_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// ApproveReplicaSuggestions provides a mock function with given fields: _a0, _a1
+func (_m *MockQueryCoord) ApproveReplicaSuggestions(_a0 context.Context, _a1 *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveReplicaSuggestions")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryCoord_ApproveReplicaSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveReplicaSuggestions'
+type MockQueryCoord_ApproveReplicaSuggestions_Call struct {
+	*mock.Call
+}
+
+// ApproveReplicaSuggestions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *querypb.ApproveReplicaSuggestionsRequest
+func (_e *MockQueryCoord_Expecter) ApproveReplicaSuggestions(_a0 interface{}, _a1 interface{}) *MockQueryCoord_ApproveReplicaSuggestions_Call {
+	return &MockQueryCoord_ApproveReplicaSuggestions_Call{Call: _e.mock.On("ApproveReplicaSuggestions", _a0, _a1)}
+}
+
+func (_c *MockQueryCoord_ApproveReplicaSuggestions_Call) Run(run func(_a0 context.Context, _a1 *querypb.ApproveReplicaSuggestionsRequest)) *MockQueryCoord_ApproveReplicaSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*querypb.ApproveReplicaSuggestionsRequest))
+	})
+	return _c
+}
+
+func (_c *MockQueryCoord_ApproveReplicaSuggestions_Call) Return(_a0 *commonpb.Status, _a1 error) *MockQueryCoord_ApproveReplicaSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryCoord_ApproveReplicaSuggestions_Call) RunAndReturn(run func(context.Context, *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error)) *MockQueryCoord_ApproveReplicaSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckBalanceStatus provides a mock function with given fields: _a0, _a1
 func (_m *MockQueryCoord) CheckBalanceStatus(_a0 context.Context, _a1 *querypb.CheckBalanceStatusRequest) (*querypb.CheckBalanceStatusResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -506,6 +565,65 @@ func (_c *MockQueryCoord_DeactivateChecker_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// DecommissionNode provides a mock function with given fields: _a0, _a1
+func (_m *MockQueryCoord) DecommissionNode(_a0 context.Context, _a1 *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecommissionNode")
+	}
+
+	var r0 *querypb.DecommissionNodeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.DecommissionNodeRequest) *querypb.DecommissionNodeResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.DecommissionNodeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.DecommissionNodeRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryCoord_DecommissionNode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecommissionNode'
+type MockQueryCoord_DecommissionNode_Call struct {
+	*mock.Call
+}
+
+// DecommissionNode is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *querypb.DecommissionNodeRequest
+func (_e *MockQueryCoord_Expecter) DecommissionNode(_a0 interface{}, _a1 interface{}) *MockQueryCoord_DecommissionNode_Call {
+	return &MockQueryCoord_DecommissionNode_Call{Call: _e.mock.On("DecommissionNode", _a0, _a1)}
+}
+
+func (_c *MockQueryCoord_DecommissionNode_Call) Run(run func(_a0 context.Context, _a1 *querypb.DecommissionNodeRequest)) *MockQueryCoord_DecommissionNode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*querypb.DecommissionNodeRequest))
+	})
+	return _c
+}
+
+func (_c *MockQueryCoord_DecommissionNode_Call) Return(_a0 *querypb.DecommissionNodeResponse, _a1 error) *MockQueryCoord_DecommissionNode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryCoord_DecommissionNode_Call) RunAndReturn(run func(context.Context, *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error)) *MockQueryCoord_DecommissionNode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DescribeResourceGroup provides a mock function with given fields: _a0, _a1
 func (_m *MockQueryCoord) DescribeResourceGroup(_a0 context.Context, _a1 *querypb.DescribeResourceGroupRequest) (*querypb.DescribeResourceGroupResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1200,6 +1318,65 @@ func (_c *MockQueryCoord_ListQueryNode_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// ListReplicaSuggestions provides a mock function with given fields: _a0, _a1
+func (_m *MockQueryCoord) ListReplicaSuggestions(_a0 context.Context, _a1 *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReplicaSuggestions")
+	}
+
+	var r0 *querypb.ListReplicaSuggestionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ListReplicaSuggestionsRequest) *querypb.ListReplicaSuggestionsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.ListReplicaSuggestionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.ListReplicaSuggestionsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryCoord_ListReplicaSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReplicaSuggestions'
+type MockQueryCoord_ListReplicaSuggestions_Call struct {
+	*mock.Call
+}
+
+// ListReplicaSuggestions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *querypb.ListReplicaSuggestionsRequest
+func (_e *MockQueryCoord_Expecter) ListReplicaSuggestions(_a0 interface{}, _a1 interface{}) *MockQueryCoord_ListReplicaSuggestions_Call {
+	return &MockQueryCoord_ListReplicaSuggestions_Call{Call: _e.mock.On("ListReplicaSuggestions", _a0, _a1)}
+}
+
+func (_c *MockQueryCoord_ListReplicaSuggestions_Call) Run(run func(_a0 context.Context, _a1 *querypb.ListReplicaSuggestionsRequest)) *MockQueryCoord_ListReplicaSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*querypb.ListReplicaSuggestionsRequest))
+	})
+	return _c
+}
+
+func (_c *MockQueryCoord_ListReplicaSuggestions_Call) Return(_a0 *querypb.ListReplicaSuggestionsResponse, _a1 error) *MockQueryCoord_ListReplicaSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryCoord_ListReplicaSuggestions_Call) RunAndReturn(run func(context.Context, *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error)) *MockQueryCoord_ListReplicaSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListResourceGroups provides a mock function with given fields: _a0, _a1
 func (_m *MockQueryCoord) ListResourceGroups(_a0 context.Context, _a1 *milvuspb.ListResourceGroupsRequest) (*milvuspb.ListResourceGroupsResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -105,6 +105,80 @@ func (_c *MockQueryCoordClient_ActivateChecker_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// ApproveReplicaSuggestions provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryCoordClient) ApproveReplicaSuggestions(ctx context.Context, in *querypb.ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveReplicaSuggestions")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest, ...grpc.CallOption) (*commonpb.Status, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest, ...grpc.CallOption) *commonpb.Status); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.ApproveReplicaSuggestionsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryCoordClient_ApproveReplicaSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveReplicaSuggestions'
+type MockQueryCoordClient_ApproveReplicaSuggestions_Call struct {
+	*mock.Call
+}
+
+// ApproveReplicaSuggestions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *querypb.ApproveReplicaSuggestionsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryCoordClient_Expecter) ApproveReplicaSuggestions(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryCoordClient_ApproveReplicaSuggestions_Call {
+	return &MockQueryCoordClient_ApproveReplicaSuggestions_Call{Call: _e.mock.On("ApproveReplicaSuggestions",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryCoordClient_ApproveReplicaSuggestions_Call) Run(run func(ctx context.Context, in *querypb.ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption)) *MockQueryCoordClient_ApproveReplicaSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*querypb.ApproveReplicaSuggestionsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryCoordClient_ApproveReplicaSuggestions_Call) Return(_a0 *commonpb.Status, _a1 error) *MockQueryCoordClient_ApproveReplicaSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryCoordClient_ApproveReplicaSuggestions_Call) RunAndReturn(run func(context.Context, *querypb.ApproveReplicaSuggestionsRequest, ...grpc.CallOption) (*commonpb.Status, error)) *MockQueryCoordClient_ApproveReplicaSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckBalanceStatus provides a mock function with given fields: ctx, in, opts
 func (_m *MockQueryCoordClient) CheckBalanceStatus(ctx context.Context, in *querypb.CheckBalanceStatusRequest, opts ...grpc.CallOption) (*querypb.CheckBalanceStatusResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -668,6 +742,80 @@ func (_c *MockQueryCoordClient_DeactivateChecker_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// DecommissionNode provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryCoordClient) DecommissionNode(ctx context.Context, in *querypb.DecommissionNodeRequest, opts ...grpc.CallOption) (*querypb.DecommissionNodeResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecommissionNode")
+	}
+
+	var r0 *querypb.DecommissionNodeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.DecommissionNodeRequest, ...grpc.CallOption) (*querypb.DecommissionNodeResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.DecommissionNodeRequest, ...grpc.CallOption) *querypb.DecommissionNodeResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.DecommissionNodeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.DecommissionNodeRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryCoordClient_DecommissionNode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecommissionNode'
+type MockQueryCoordClient_DecommissionNode_Call struct {
+	*mock.Call
+}
+
+// DecommissionNode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *querypb.DecommissionNodeRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryCoordClient_Expecter) DecommissionNode(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryCoordClient_DecommissionNode_Call {
+	return &MockQueryCoordClient_DecommissionNode_Call{Call: _e.mock.On("DecommissionNode",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryCoordClient_DecommissionNode_Call) Run(run func(ctx context.Context, in *querypb.DecommissionNodeRequest, opts ...grpc.CallOption)) *MockQueryCoordClient_DecommissionNode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*querypb.DecommissionNodeRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryCoordClient_DecommissionNode_Call) Return(_a0 *querypb.DecommissionNodeResponse, _a1 error) *MockQueryCoordClient_DecommissionNode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryCoordClient_DecommissionNode_Call) RunAndReturn(run func(context.Context, *querypb.DecommissionNodeRequest, ...grpc.CallOption) (*querypb.DecommissionNodeResponse, error)) *MockQueryCoordClient_DecommissionNode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DescribeResourceGroup provides a mock function with given fields: ctx, in, opts
 func (_m *MockQueryCoordClient) DescribeResourceGroup(ctx context.Context, in *querypb.DescribeResourceGroupRequest, opts ...grpc.CallOption) (*querypb.DescribeResourceGroupResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -1482,6 +1630,80 @@ func (_c *MockQueryCoordClient_ListQueryNode_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// ListReplicaSuggestions provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryCoordClient) ListReplicaSuggestions(ctx context.Context, in *querypb.ListReplicaSuggestionsRequest, opts ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReplicaSuggestions")
+	}
+
+	var r0 *querypb.ListReplicaSuggestionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ListReplicaSuggestionsRequest, ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.ListReplicaSuggestionsRequest, ...grpc.CallOption) *querypb.ListReplicaSuggestionsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.ListReplicaSuggestionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.ListReplicaSuggestionsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryCoordClient_ListReplicaSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReplicaSuggestions'
+type MockQueryCoordClient_ListReplicaSuggestions_Call struct {
+	*mock.Call
+}
+
+// ListReplicaSuggestions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *querypb.ListReplicaSuggestionsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryCoordClient_Expecter) ListReplicaSuggestions(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryCoordClient_ListReplicaSuggestions_Call {
+	return &MockQueryCoordClient_ListReplicaSuggestions_Call{Call: _e.mock.On("ListReplicaSuggestions",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryCoordClient_ListReplicaSuggestions_Call) Run(run func(ctx context.Context, in *querypb.ListReplicaSuggestionsRequest, opts ...grpc.CallOption)) *MockQueryCoordClient_ListReplicaSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*querypb.ListReplicaSuggestionsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryCoordClient_ListReplicaSuggestions_Call) Return(_a0 *querypb.ListReplicaSuggestionsResponse, _a1 error) *MockQueryCoordClient_ListReplicaSuggestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryCoordClient_ListReplicaSuggestions_Call) RunAndReturn(run func(context.Context, *querypb.ListReplicaSuggestionsRequest, ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error)) *MockQueryCoordClient_ListReplicaSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListResourceGroups provides a mock function with given fields: ctx, in, opts
 func (_m *MockQueryCoordClient) ListResourceGroups(ctx context.Context, in *milvuspb.ListResourceGroupsRequest, opts ...grpc.CallOption) (*milvuspb.ListResourceGroupsResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -7,6 +7,8 @@ import (
 
 	balancer "github.com/milvus-io/milvus/internal/streamingcoord/server/balancer"
 
+	channel "github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+
 	message "github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 
 	mock "github.com/stretchr/testify/mock"
@@ -286,6 +288,53 @@ func (_c *MockBalancer_GetAvailableStreamingNodes_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// GetChannelRecoveryReport provides a mock function with no fields
+func (_m *MockBalancer) GetChannelRecoveryReport() *channel.RecoveryReport {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelRecoveryReport")
+	}
+
+	var r0 *channel.RecoveryReport
+	if rf, ok := ret.Get(0).(func() *channel.RecoveryReport); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*channel.RecoveryReport)
+		}
+	}
+
+	return r0
+}
+
+// MockBalancer_GetChannelRecoveryReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannelRecoveryReport'
+type MockBalancer_GetChannelRecoveryReport_Call struct {
+	*mock.Call
+}
+
+// GetChannelRecoveryReport is a helper method to define mock.On call
+func (_e *MockBalancer_Expecter) GetChannelRecoveryReport() *MockBalancer_GetChannelRecoveryReport_Call {
+	return &MockBalancer_GetChannelRecoveryReport_Call{Call: _e.mock.On("GetChannelRecoveryReport")}
+}
+
+func (_c *MockBalancer_GetChannelRecoveryReport_Call) Run(run func()) *MockBalancer_GetChannelRecoveryReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBalancer_GetChannelRecoveryReport_Call) Return(_a0 *channel.RecoveryReport) *MockBalancer_GetChannelRecoveryReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBalancer_GetChannelRecoveryReport_Call) RunAndReturn(run func() *channel.RecoveryReport) *MockBalancer_GetChannelRecoveryReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLatestChannelAssignment provides a mock function with no fields
 func (_m *MockBalancer) GetLatestChannelAssignment() (*balancer.WatchChannelAssignmentsCallbackParam, error) {
 	ret := _m.Called()
@@ -447,6 +496,54 @@ func (_c *MockBalancer_MarkAsUnavailable_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// MoveChannel provides a mock function with given fields: ctx, pchannel, targetNodeID
+func (_m *MockBalancer) MoveChannel(ctx context.Context, pchannel string, targetNodeID int64) error {
+	ret := _m.Called(ctx, pchannel, targetNodeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, pchannel, targetNodeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBalancer_MoveChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveChannel'
+type MockBalancer_MoveChannel_Call struct {
+	*mock.Call
+}
+
+// MoveChannel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pchannel string
+//   - targetNodeID int64
+func (_e *MockBalancer_Expecter) MoveChannel(ctx interface{}, pchannel interface{}, targetNodeID interface{}) *MockBalancer_MoveChannel_Call {
+	return &MockBalancer_MoveChannel_Call{Call: _e.mock.On("MoveChannel", ctx, pchannel, targetNodeID)}
+}
+
+func (_c *MockBalancer_MoveChannel_Call) Run(run func(ctx context.Context, pchannel string, targetNodeID int64)) *MockBalancer_MoveChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockBalancer_MoveChannel_Call) Return(_a0 error) *MockBalancer_MoveChannel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBalancer_MoveChannel_Call) RunAndReturn(run func(context.Context, string, int64) error) *MockBalancer_MoveChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RegisterStreamingEnabledNotifier provides a mock function with given fields: notifier
 func (_m *MockBalancer) RegisterStreamingEnabledNotifier(notifier *syncutil.AsyncTaskNotifier[struct{}]) {
 	_m.Called(notifier)
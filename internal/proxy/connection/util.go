@@ -14,6 +14,15 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 )
 
+// AppNameKey is the key of the application name reserved in the client info,
+// which tags the requests of the connection for the app tag rate limits.
+const AppNameKey = "app_name"
+
+// GetAppTag returns the application name of the connection of the request, empty if not tagged.
+func GetAppTag(ctx context.Context) string {
+	return GetManager().Get(ctx).GetReserved()[AppNameKey]
+}
+
 func ClientInfoFields(info *commonpb.ClientInfo) []mlog.Field {
 	fields := []mlog.Field{
 		mlog.String("sdk_type", info.GetSdkType()),
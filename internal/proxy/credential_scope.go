@@ -0,0 +1,72 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/proxy/privilege"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+)
+
+// checkCredentialScopes checks the request against the credential scopes of the user,
+// a restricted user can only operate the collections in its scopes with the allowed operation classes,
+// the requests not referring a collection are only allowed by the scopes of all the collections.
+func checkCredentialScopes(username string, dbName string, objectType string, objectPrivilege string, objectNames []string) error {
+	scopes := privilege.GetPrivilegeCache().GetCredentialScopes(username)
+	if len(scopes) == 0 {
+		return nil
+	}
+	class := util.GetOperationClass(util.MetaStore2API(objectPrivilege))
+	collections := []string{util.AnyWord}
+	if objectType == commonpb.ObjectType_Collection.String() && len(objectNames) > 0 {
+		collections = objectNames
+	}
+	for _, collection := range collections {
+		if collection == "" {
+			collection = util.AnyWord
+		}
+		if !matchCredentialScopes(scopes, dbName, collection, class) {
+			return status.Error(codes.PermissionDenied,
+				fmt.Sprintf("%s: %s operation on collection %s in the `%s` database is out of the credential scopes of %s",
+					objectPrivilege, class, collection, dbName, username))
+		}
+	}
+	return nil
+}
+
+func matchCredentialScopes(scopes []*internalpb.CredentialScope, dbName string, collection string, class string) bool {
+	for _, scope := range scopes {
+		if scope.GetDbName() != util.AnyWord && scope.GetDbName() != dbName {
+			continue
+		}
+		if scope.GetCollectionName() != util.AnyWord && scope.GetCollectionName() != collection {
+			continue
+		}
+		for _, allowed := range scope.GetOperationClasses() {
+			if allowed == class {
+				return true
+			}
+		}
+	}
+	return false
+}
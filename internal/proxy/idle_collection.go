@@ -0,0 +1,125 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const idleCollectionLoadCheckInterval = 500 * time.Millisecond
+
+// idleCollectionLoadedKey marks the request retried after loading the collection released for idle.
+type idleCollectionLoadedKey struct{}
+
+// loadIdleReleasedCollection loads the collection again if the request failed for the collection is released for idle,
+// it returns true with the context for the retry if the collection is loaded. The request is retried at most once,
+// and the concurrent requests of the same collection wait for the same load.
+func (node *Proxy) loadIdleReleasedCollection(ctx context.Context, dbName, collectionName string, status *commonpb.Status) (context.Context, bool) {
+	if !errors.Is(merr.Error(status), merr.ErrCollectionNotLoaded) || ctx.Value(idleCollectionLoadedKey{}) != nil || globalMetaCache == nil {
+		return ctx, false
+	}
+	collInfo, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, 0)
+	if err != nil {
+		return ctx, false
+	}
+	// only the collections released for idle are loaded, the collections released by the users are not.
+	config, ok := common.GetIdleReleasedLoadConfig(funcutil.KeyValuePair2Map(collInfo.properties))
+	if !ok {
+		return ctx, false
+	}
+
+	start := time.Now()
+	_, err, _ = node.idleCollectionLoads.Do(dbName+"."+collectionName, func() (struct{}, error) {
+		return struct{}{}, node.loadIdleCollection(ctx, dbName, collectionName, config)
+	})
+	if err != nil {
+		mlog.Warn(ctx, "failed to load the collection released for idle",
+			mlog.String("dbName", dbName),
+			mlog.String("collectionName", collectionName),
+			mlog.Err(err))
+		return ctx, false
+	}
+	mlog.Info(ctx, "collection released for idle is loaded on access",
+		mlog.String("dbName", dbName),
+		mlog.String("collectionName", collectionName),
+		mlog.Duration("latency", time.Since(start)))
+	return context.WithValue(ctx, idleCollectionLoadedKey{}, true), true
+}
+
+// loadIdleCollection loads the collection with the load config saved before the idle release and waits until it's loaded,
+// the load is not canceled by the request which triggers it, since the other requests may wait for it.
+func (node *Proxy) loadIdleCollection(ctx context.Context, dbName, collectionName string, config *common.IdleReleasedLoadConfig) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), Params.ProxyCfg.IdleCollectionLoadTimeout.GetAsDuration(time.Second))
+	defer cancel()
+
+	var status *commonpb.Status
+	var err error
+	if len(config.Partitions) > 0 {
+		status, err = node.LoadPartitions(ctx, &milvuspb.LoadPartitionsRequest{
+			DbName:               dbName,
+			CollectionName:       collectionName,
+			PartitionNames:       config.Partitions,
+			ReplicaNumber:        config.ReplicaNumber,
+			ResourceGroups:       config.ResourceGroups,
+			LoadFields:           config.LoadFields,
+			SkipLoadDynamicField: config.SkipLoadDynamicField,
+		})
+	} else {
+		status, err = node.LoadCollection(ctx, &milvuspb.LoadCollectionRequest{
+			DbName:               dbName,
+			CollectionName:       collectionName,
+			ReplicaNumber:        config.ReplicaNumber,
+			ResourceGroups:       config.ResourceGroups,
+			LoadFields:           config.LoadFields,
+			SkipLoadDynamicField: config.SkipLoadDynamicField,
+		})
+	}
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(idleCollectionLoadCheckInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := node.GetLoadState(ctx, &milvuspb.GetLoadStateRequest{
+			DbName:         dbName,
+			CollectionName: collectionName,
+			PartitionNames: config.Partitions,
+		})
+		if err := merr.CheckRPCCall(resp, err); err != nil {
+			return err
+		}
+		if resp.GetState() == commonpb.LoadState_LoadStateLoaded {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return merr.WrapErrCollectionNotLoaded(collectionName, "timeout waiting for the collection released for idle loaded")
+		case <-ticker.C:
+		}
+	}
+}
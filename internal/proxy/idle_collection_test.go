@@ -0,0 +1,64 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestProxy_LoadIdleReleasedCollection(t *testing.T) {
+	paramtable.Init()
+	oldCache := globalMetaCache
+	defer func() { globalMetaCache = oldCache }()
+	ctx := context.Background()
+	node := &Proxy{}
+	notLoaded := merr.Status(merr.WrapErrCollectionNotLoaded("coll"))
+
+	cache := NewMockCache(t)
+	globalMetaCache = cache
+
+	// not failed for the collection not loaded
+	_, ok := node.loadIdleReleasedCollection(ctx, "db", "coll", merr.Success())
+	assert.False(t, ok)
+
+	// already retried
+	_, ok = node.loadIdleReleasedCollection(context.WithValue(ctx, idleCollectionLoadedKey{}, true), "db", "coll", notLoaded)
+	assert.False(t, ok)
+
+	// the collection is not released for idle
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db", "coll", int64(0)).Return(&collectionInfo{
+		properties: []*commonpb.KeyValuePair{},
+	}, nil).Once()
+	_, ok = node.loadIdleReleasedCollection(ctx, "db", "coll", notLoaded)
+	assert.False(t, ok)
+
+	// the collection with idle release is released by the users
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db", "coll", int64(0)).Return(&collectionInfo{
+		properties: []*commonpb.KeyValuePair{{Key: common.CollectionIdleReleaseSecondsKey, Value: "60"}},
+	}, nil).Once()
+	_, ok = node.loadIdleReleasedCollection(ctx, "db", "coll", notLoaded)
+	assert.False(t, ok)
+}
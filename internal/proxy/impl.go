@@ -83,14 +83,19 @@ import (
 
 const moduleName = "Proxy"
 
-// checkExternalCollectionBlockedForWrite checks if the collection is external and returns error if so.
-// External collections do not support write operations (insert, delete, upsert, flush, etc).
-func checkExternalCollectionBlockedForWrite(ctx context.Context, dbName, collName, operation string) error {
+// checkCollectionBlockedForWrite checks if the collection is external or in the recycle bin and returns error if so.
+// External collections and soft-deleted collections do not support write operations (insert, delete, upsert, flush, etc).
+func checkCollectionBlockedForWrite(ctx context.Context, dbName, collName, operation string) error {
 	collSchema, _ := globalMetaCache.GetCollectionSchema(ctx, dbName, collName)
 	if collSchema != nil && typeutil.IsExternalCollection(collSchema.CollectionSchema) {
 		return merr.WrapErrParameterInvalidMsg(
 			"%s operation is not supported for external collection %s", operation, collName)
 	}
+	// the schema carries the collection properties.
+	if collSchema != nil && common.IsCollectionRecycled(collSchema.GetProperties()...) {
+		return merr.WrapErrCollectionNotFoundWithDB(dbName, collName,
+			fmt.Sprintf("%s operation is not supported for the collection in the recycle bin, undrop it first", operation))
+	}
 	return nil
 }
 
@@ -1404,7 +1409,7 @@ func (node *Proxy) AlterCollectionField(ctx context.Context, request *milvuspb.A
 	tr := timerecord.NewTimeRecorder(method)
 
 	// Check for external collection - alter field is not supported
-	if err := checkExternalCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "alter field"); err != nil {
+	if err := checkCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "alter field"); err != nil {
 		return merr.Status(err), nil
 	}
 
@@ -1457,7 +1462,7 @@ func (node *Proxy) CreatePartition(ctx context.Context, request *milvuspb.Create
 	}
 
 	// Check for external collection - create partition is not supported
-	if err := checkExternalCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "create partition"); err != nil {
+	if err := checkCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "create partition"); err != nil {
 		return merr.Status(err), nil
 	}
 
@@ -1515,7 +1520,7 @@ func (node *Proxy) DropPartition(ctx context.Context, request *milvuspb.DropPart
 	}
 
 	// Check for external collection - drop partition is not supported
-	if err := checkExternalCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "drop partition"); err != nil {
+	if err := checkCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "drop partition"); err != nil {
 		return merr.Status(err), nil
 	}
 
@@ -2436,7 +2441,7 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 	}
 
 	// Check for external collection - insert is not supported
-	if err := checkExternalCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "insert"); err != nil {
+	if err := checkCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "insert"); err != nil {
 		return &milvuspb.MutationResult{
 			Status: merr.Status(err),
 		}, nil
@@ -2573,7 +2578,7 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 	}
 
 	// Check for external collection - delete is not supported
-	if err := checkExternalCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "delete"); err != nil {
+	if err := checkCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "delete"); err != nil {
 		return &milvuspb.MutationResult{
 			Status: merr.Status(err),
 		}, nil
@@ -2669,7 +2674,7 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 	}
 
 	// Check for external collection - upsert is not supported
-	if err := checkExternalCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "upsert"); err != nil {
+	if err := checkCollectionBlockedForWrite(ctx, request.GetDbName(), request.GetCollectionName(), "upsert"); err != nil {
 		return &milvuspb.MutationResult{
 			Status: merr.Status(err),
 		}, nil
@@ -2812,6 +2817,30 @@ func GetCollectionRateSubLabel(req any) string {
 	return ratelimitutil.GetCollectionSubLabel(dbName.(string), collectionName.(string))
 }
 
+// recordSlowDQL counts the slow dql requests of the collection for the slow query protection of the quota center,
+// the max latency in the collection properties takes precedence over proxy.slowQuerySpanInSeconds.
+func recordSlowDQL(ctx context.Context, dbName, collectionName string, latency time.Duration, subLabel string) {
+	if subLabel == "" {
+		return
+	}
+	maxLatency := paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second)
+	if globalMetaCache != nil {
+		if collInfo, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, 0); err == nil {
+			for _, kv := range collInfo.properties {
+				if kv.GetKey() != common.CollectionDQLMaxLatencyKey {
+					continue
+				}
+				if ms, err := strconv.ParseInt(kv.GetValue(), 10, 64); err == nil && ms > 0 {
+					maxLatency = time.Duration(ms) * time.Millisecond
+				}
+			}
+		}
+	}
+	if latency >= maxLatency {
+		rateCol.Add(metricsinfo.DQLSlowQuery, 1, subLabel)
+	}
+}
+
 // Search searches the most similar records of requests.
 func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
 	var err error
@@ -2819,6 +2848,8 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 		Status: merr.Success(),
 	}
 
+	rateCol.Add(internalpb.RateType_DQLSearch.String(), float64(request.GetNq()), GetCollectionRateSubLabel(request))
+
 	optimizedSearch := true
 	resultSizeInsufficient := false
 	isTopkReduce := false
@@ -2876,6 +2907,9 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 	if err != nil {
 		rsp.Status = merr.Status(err)
 	}
+	if retryCtx, ok := node.loadIdleReleasedCollection(ctx, request.GetDbName(), request.GetCollectionName(), rsp.GetStatus()); ok {
+		return node.Search(retryCtx, request)
+	}
 	return rsp, nil
 }
 
@@ -2893,6 +2927,8 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 		request.GetCollectionName(),
 	).Add(float64(request.GetNq()))
 
+	subLabel := GetCollectionRateSubLabel(request)
+
 	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
 		return &milvuspb.SearchResults{
 			Status: merr.Status(err),
@@ -2961,6 +2997,7 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 		if qt.GetNq() > 0 {
 			spanPerNq = span / time.Duration(qt.GetNq())
 		}
+		recordSlowDQL(ctx, request.GetDbName(), request.GetCollectionName(), spanPerNq, subLabel)
 		if spanPerNq >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second) {
 			mlog.Info(ctx, rpcSlow(method), mlog.Uint64("guarantee_timestamp", qt.GetGuaranteeTimestamp()),
 				mlog.Int64("nq", qt.GetNq()), mlog.Duration("duration", span), mlog.Duration("durationPerNq", spanPerNq))
@@ -3088,6 +3125,12 @@ func (node *Proxy) HybridSearch(ctx context.Context, request *milvuspb.HybridSea
 	rsp := &milvuspb.SearchResults{
 		Status: merr.Success(),
 	}
+	var totalNq int64
+	for _, subReq := range request.GetRequests() {
+		totalNq += subReq.GetNq()
+	}
+	rateCol.Add(internalpb.RateType_DQLSearch.String(), float64(totalNq), GetCollectionRateSubLabel(request))
+
 	optimizedSearch := true
 	resultSizeInsufficient := false
 	isTopkReduce := false
@@ -3121,6 +3164,9 @@ func (node *Proxy) HybridSearch(ctx context.Context, request *milvuspb.HybridSea
 	if err2 != nil {
 		rsp.Status = merr.Status(err2)
 	}
+	if retryCtx, ok := node.loadIdleReleasedCollection(ctx, request.GetDbName(), request.GetCollectionName(), rsp.GetStatus()); ok {
+		return node.HybridSearch(retryCtx, request)
+	}
 	return rsp, err
 }
 
@@ -3146,6 +3192,12 @@ func (node *Proxy) hybridSearch(ctx context.Context, request *milvuspb.HybridSea
 		SetDatabaseName(request.GetDbName()).
 		SetCollectionName(request.GetCollectionName())
 
+	subLabel := GetCollectionRateSubLabel(request)
+	var totalNq int64
+	for _, subReq := range request.GetRequests() {
+		totalNq += subReq.GetNq()
+	}
+
 	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
 		return &milvuspb.SearchResults{
 			Status: merr.Status(err),
@@ -3186,13 +3238,10 @@ func (node *Proxy) hybridSearch(ctx context.Context, request *milvuspb.HybridSea
 		}
 		span := tr.ElapseSpan()
 		spanPerNq := span
-		var totalNq int64
-		for _, subReq := range request.GetRequests() {
-			totalNq += subReq.GetNq()
-		}
 		if totalNq > 0 {
 			spanPerNq = span / time.Duration(totalNq)
 		}
+		recordSlowDQL(ctx, request.GetDbName(), request.GetCollectionName(), spanPerNq, subLabel)
 		if spanPerNq >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second) {
 			mlog.Info(ctx, rpcSlow(method), mlog.Uint64("guarantee_timestamp", qt.GetGuaranteeTimestamp()),
 				mlog.Int64("totalNq", totalNq), mlog.Duration("duration", span), mlog.Duration("durationPerNq", spanPerNq))
@@ -3574,7 +3623,7 @@ func (node *Proxy) Flush(ctx context.Context, request *milvuspb.FlushRequest) (*
 
 	// Check for external collection - flush is not supported
 	for _, collName := range request.GetCollectionNames() {
-		if err := checkExternalCollectionBlockedForWrite(ctx, request.GetDbName(), collName, "flush"); err != nil {
+		if err := checkCollectionBlockedForWrite(ctx, request.GetDbName(), collName, "flush"); err != nil {
 			resp.Status = merr.Status(err)
 			return resp, nil
 		}
@@ -3659,6 +3708,9 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask, sp trace.Span) (*mi
 			return
 		}
 		span := tr.ElapseSpan()
+		if queryLabel == metrics.QueryLabel {
+			recordSlowDQL(ctx, request.GetDbName(), request.GetCollectionName(), span, GetCollectionRateSubLabel(request))
+		}
 		if span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second) {
 			mlog.Info(ctx,
 				rpcSlow(method),
@@ -3769,7 +3821,7 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 		request.GetCollectionName(),
 	).Add(float64(1))
 
-	rateCol.Add(internalpb.RateType_DQLQuery.String(), 1, subLabel)
+	rateCol.Add(getQueryRateType(ctx, request).String(), 1, subLabel)
 
 	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
 		return &milvuspb.QueryResults{
@@ -3800,6 +3852,9 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 	}
 
 	if err != nil || !merr.Ok(res.Status) {
+		if retryCtx, ok := node.loadIdleReleasedCollection(ctx, request.GetDbName(), request.GetCollectionName(), res.GetStatus()); ok {
+			return node.Query(retryCtx, request)
+		}
 		return res, err
 	}
 
@@ -6330,7 +6385,7 @@ func (node *Proxy) ImportV2(ctx context.Context, req *internalpb.ImportRequest)
 	}
 
 	// Check for external collection - import is not supported
-	if err := checkExternalCollectionBlockedForWrite(ctx, req.GetDbName(), req.GetCollectionName(), "import"); err != nil {
+	if err := checkCollectionBlockedForWrite(ctx, req.GetDbName(), req.GetCollectionName(), "import"); err != nil {
 		return &internalpb.ImportResponse{Status: merr.Status(err)}, nil
 	}
 
@@ -6452,7 +6507,9 @@ func (node *Proxy) AbortImport(ctx context.Context, req *datapb.AbortImportReque
 // DeregisterSubLabel must add the sub-labels here if using other labels for the sub-labels
 func DeregisterSubLabel(subLabel string) {
 	rateCol.DeregisterSubLabel(internalpb.RateType_DQLQuery.String(), subLabel)
+	rateCol.DeregisterSubLabel(internalpb.RateType_DQLGet.String(), subLabel)
 	rateCol.DeregisterSubLabel(internalpb.RateType_DQLSearch.String(), subLabel)
+	rateCol.DeregisterSubLabel(metricsinfo.DQLSlowQuery, subLabel)
 }
 
 // RegisterRestRouter registers the router for the proxy
@@ -6484,6 +6541,7 @@ func (node *Proxy) RegisterRestRouter(router gin.IRouter) {
 	// DataCoord requests that are forwarded from proxy
 	router.GET(http.DCDistPath, getDataComponentMetrics(node, metricsinfo.DistKey))
 	router.GET(http.DCCompactionTasksPath, getDataComponentMetrics(node, metricsinfo.CompactionTaskKey))
+	router.GET(http.DCCompactionCleaningTasksPath, getDataComponentMetrics(node, metricsinfo.CompactionCleaningTaskKey))
 	router.GET(http.DCImportTasksPath, getDataComponentMetrics(node, metricsinfo.ImportTaskKey))
 	router.GET(http.DCBuildIndexTasksPath, getDataComponentMetrics(node, metricsinfo.BuildIndexTaskKey))
 	router.GET(http.IndexListPath, getDataComponentMetrics(node, metricsinfo.IndexKey))
@@ -1632,7 +1632,7 @@ func TestProxy_ImportV2(t *testing.T) {
 		node.chMgr = chMgr
 
 		// no such collection
-		// checkExternalCollectionBlockedForWrite skips error when GetCollectionSchema fails,
+		// checkCollectionBlockedForWrite skips error when GetCollectionSchema fails,
 		// so task will be enqueued and PreExecute will call GetCollectionID which returns error.
 		mc := NewMockCache(t)
 		mc.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything, mock.Anything).Return(nil, mockErr).Once()
@@ -1644,7 +1644,7 @@ func TestProxy_ImportV2(t *testing.T) {
 
 		// get schema failed in PreExecute
 		mc = NewMockCache(t)
-		// checkExternalCollectionBlockedForWrite skips error, task enqueued.
+		// checkCollectionBlockedForWrite skips error, task enqueued.
 		// PreExecute calls GetCollectionID (succeeds), then GetCollectionSchema (fails).
 		mc.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything, mock.Anything).Return(nil, mockErr).Once()
 		mc.EXPECT().GetCollectionID(mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
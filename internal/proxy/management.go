@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -53,6 +54,14 @@ func RegisterMgrRoute(proxy *Proxy) {
 			Path:        management.RouteCommitBackfill,
 			HandlerFunc: proxy.CommitBackfillResult,
 		})
+		management.Register(&management.Handler{
+			Path:        management.RouteCloneCollection,
+			HandlerFunc: proxy.CloneCollection,
+		})
+		management.Register(&management.Handler{
+			Path:        management.RouteReindexSegments,
+			HandlerFunc: proxy.ReindexSegments,
+		})
 		management.Register(&management.Handler{
 			Path:        management.RouteListQueryNode,
 			HandlerFunc: proxy.ListQueryNode,
@@ -77,6 +86,18 @@ func RegisterMgrRoute(proxy *Proxy) {
 			Path:        management.RouteResumeQueryNode,
 			HandlerFunc: proxy.ResumeQueryNode,
 		})
+		management.Register(&management.Handler{
+			Path:        management.RouteDecommissionQueryNode,
+			HandlerFunc: proxy.DecommissionQueryNode,
+		})
+		management.Register(&management.Handler{
+			Path:        management.RouteListReplicaSuggestions,
+			HandlerFunc: proxy.ListReplicaSuggestions,
+		})
+		management.Register(&management.Handler{
+			Path:        management.RouteApproveReplicaSuggestions,
+			HandlerFunc: proxy.ApproveReplicaSuggestions,
+		})
 		management.Register(&management.Handler{
 			Path:        management.RouteTransferSegment,
 			HandlerFunc: proxy.TransferSegment,
@@ -219,6 +240,119 @@ func (node *Proxy) CommitBackfillResult(w http.ResponseWriter, req *http.Request
 	})
 }
 
+func (node *Proxy) CloneCollection(w http.ResponseWriter, req *http.Request) {
+	writeJSON := func(status int, payload map[string]interface{}) {
+		w.WriteHeader(status)
+		bs, _ := json.Marshal(payload)
+		w.Write(bs)
+	}
+
+	err := req.ParseForm() //nolint:gosec // internal admin endpoint
+	if err != nil {
+		writeJSON(http.StatusBadRequest, map[string]interface{}{
+			"msg": fmt.Sprintf("failed to clone collection, %s", err.Error()),
+		})
+		return
+	}
+
+	request := &datapb.CloneCollectionRequest{
+		Base:                 commonpbutil.NewMsgBase(),
+		TargetDbName:         req.FormValue("target_db_name"),         //nolint:gosec // internal admin endpoint
+		TargetCollectionName: req.FormValue("target_collection_name"), //nolint:gosec // internal admin endpoint
+	}
+	request.SourceCollectionId, err = strconv.ParseInt(req.FormValue("collection_id"), 10, 64) //nolint:gosec // internal admin endpoint
+	if err != nil {
+		writeJSON(http.StatusBadRequest, map[string]interface{}{
+			"msg": fmt.Sprintf("failed to clone collection, %s", err.Error()),
+		})
+		return
+	}
+	if load := req.FormValue("load"); len(load) > 0 { //nolint:gosec // internal admin endpoint
+		request.Load, err = strconv.ParseBool(load)
+		if err != nil {
+			writeJSON(http.StatusBadRequest, map[string]interface{}{
+				"msg": fmt.Sprintf("failed to clone collection, %s", err.Error()),
+			})
+			return
+		}
+	}
+	if replicaNumber := req.FormValue("replica_number"); len(replicaNumber) > 0 { //nolint:gosec // internal admin endpoint
+		value, err := strconv.ParseInt(replicaNumber, 10, 32)
+		if err != nil {
+			writeJSON(http.StatusBadRequest, map[string]interface{}{
+				"msg": fmt.Sprintf("failed to clone collection, %s", err.Error()),
+			})
+			return
+		}
+		request.ReplicaNumber = int32(value)
+	}
+
+	resp, err := node.mixCoord.CloneCollection(req.Context(), request)
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		writeJSON(http.StatusInternalServerError, map[string]interface{}{
+			"msg": fmt.Sprintf("failed to clone collection, %s", err.Error()),
+		})
+		return
+	}
+	writeJSON(http.StatusOK, map[string]interface{}{
+		"msg":           "OK",
+		"job_id":        resp.GetJobId(),
+		"snapshot_name": resp.GetSnapshotName(),
+	})
+}
+
+// ReindexSegments triggers the mix compaction of each given segment to rebuild it,
+// segment_ids is a comma separated list of the segments of the collection.
+func (node *Proxy) ReindexSegments(w http.ResponseWriter, req *http.Request) {
+	writeJSON := func(status int, payload map[string]interface{}) {
+		w.WriteHeader(status)
+		bs, _ := json.Marshal(payload)
+		w.Write(bs)
+	}
+
+	err := req.ParseForm() //nolint:gosec // internal admin endpoint
+	if err != nil {
+		writeJSON(http.StatusBadRequest, map[string]interface{}{
+			"msg": fmt.Sprintf("failed to reindex segments, %s", err.Error()),
+		})
+		return
+	}
+
+	request := &datapb.ReindexSegmentsRequest{
+		Base: commonpbutil.NewMsgBase(),
+	}
+	request.CollectionId, err = strconv.ParseInt(req.FormValue("collection_id"), 10, 64) //nolint:gosec // internal admin endpoint
+	if err != nil {
+		writeJSON(http.StatusBadRequest, map[string]interface{}{
+			"msg": fmt.Sprintf("failed to reindex segments, %s", err.Error()),
+		})
+		return
+	}
+	for _, value := range strings.Split(req.FormValue("segment_ids"), ",") { //nolint:gosec // internal admin endpoint
+		segmentID, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			writeJSON(http.StatusBadRequest, map[string]interface{}{
+				"msg": fmt.Sprintf("failed to reindex segments, %s", err.Error()),
+			})
+			return
+		}
+		request.SegmentIds = append(request.SegmentIds, segmentID)
+	}
+
+	resp, err := node.mixCoord.ReindexSegments(req.Context(), request)
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		writeJSON(http.StatusInternalServerError, map[string]interface{}{
+			"msg": fmt.Sprintf("failed to reindex segments, %s", err.Error()),
+		})
+		return
+	}
+	writeJSON(http.StatusOK, map[string]interface{}{
+		"msg":                   "OK",
+		"compaction_id":         resp.GetCompactionID(),
+		"compaction_plan_count": resp.GetCompactionPlanCount(),
+	})
+}
+
 func (node *Proxy) ResumeDatacoordGC(w http.ResponseWriter, req *http.Request) {
 	ticket := req.URL.Query().Get("ticket")
 	var collectionID string
@@ -488,6 +622,136 @@ func (node *Proxy) ResumeQueryNode(w http.ResponseWriter, req *http.Request) {
 	w.Write([]byte(`{"msg": "OK"}`))
 }
 
+func (node *Proxy) DecommissionQueryNode(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm() //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to decommission node, %s"}`, err.Error()) //nolint:gosec // internal admin endpoint
+		return
+	}
+
+	nodeID, err := strconv.ParseInt(req.FormValue("node_id"), 10, 64) //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to decommission node, %s"}`, err.Error())
+		return
+	}
+	resp, err := node.mixCoord.DecommissionNode(req.Context(), &querypb.DecommissionNodeRequest{
+		Base:   commonpbutil.NewMsgBase(),
+		NodeID: nodeID,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to decommission node, %s"}`, err.Error())
+		return
+	}
+
+	if !merr.Ok(resp.GetStatus()) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to decommission node, %s"}`, resp.GetStatus().GetReason())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"msg": "OK", "drained": %v, "remaining_segment_num": %d, "remaining_channel_num": %d}`,
+		resp.GetDrained(), resp.GetRemainingSegmentNum(), resp.GetRemainingChannelNum())
+}
+
+func (node *Proxy) ListReplicaSuggestions(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm() //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to list replica suggestions, %s"}`, err.Error()) //nolint:gosec // internal admin endpoint
+		return
+	}
+
+	request := &querypb.ListReplicaSuggestionsRequest{
+		Base: commonpbutil.NewMsgBase(),
+	}
+	if collectionID := req.FormValue("collection_id"); len(collectionID) > 0 { //nolint:gosec // internal admin endpoint
+		request.CollectionID, err = strconv.ParseInt(collectionID, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"msg": "failed to list replica suggestions, %s"}`, err.Error())
+			return
+		}
+	}
+
+	resp, err := node.mixCoord.ListReplicaSuggestions(req.Context(), request)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to list replica suggestions, %s"}`, err.Error())
+		return
+	}
+
+	if !merr.Ok(resp.GetStatus()) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to list replica suggestions, %s"}`, resp.GetStatus().GetReason())
+		return
+	}
+
+	// skip marshal status to output
+	resp.Status = nil
+	bytes, err := json.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to list replica suggestions, %s"}`, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+func (node *Proxy) ApproveReplicaSuggestions(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm() //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to approve replica suggestions, %s"}`, err.Error()) //nolint:gosec // internal admin endpoint
+		return
+	}
+
+	request := &querypb.ApproveReplicaSuggestionsRequest{
+		Base: commonpbutil.NewMsgBase(),
+	}
+	request.CollectionID, err = strconv.ParseInt(req.FormValue("collection_id"), 10, 64) //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to approve replica suggestions, %s"}`, err.Error())
+		return
+	}
+	if replicaID := req.FormValue("replica_id"); len(replicaID) > 0 { //nolint:gosec // internal admin endpoint
+		request.ReplicaID, err = strconv.ParseInt(replicaID, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"msg": "failed to approve replica suggestions, %s"}`, err.Error())
+			return
+		}
+	}
+	if nodeID := req.FormValue("node_id"); len(nodeID) > 0 { //nolint:gosec // internal admin endpoint
+		value, err := strconv.ParseInt(nodeID, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"msg": "failed to approve replica suggestions, %s"}`, err.Error())
+			return
+		}
+		request.NodeIDs = []int64{value}
+	}
+
+	resp, err := node.mixCoord.ApproveReplicaSuggestions(req.Context(), request)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to approve replica suggestions, %s"}`, err.Error())
+		return
+	}
+
+	if !merr.Ok(resp) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to approve replica suggestions, %s"}`, resp.GetReason())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
+
 func (node *Proxy) TransferSegment(w http.ResponseWriter, req *http.Request) {
 	err := req.ParseForm() //nolint:gosec // internal admin endpoint
 	if err != nil {
@@ -30,6 +30,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	management "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
@@ -491,6 +492,140 @@ func (s *ProxyManagementSuite) TestSuspendQueryNode() {
 	})
 }
 
+func (s *ProxyManagementSuite) TestDecommissionQueryNode() {
+	s.Run("normal", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.mixcoord.EXPECT().DecommissionNode(mock.Anything, mock.Anything).Return(&querypb.DecommissionNodeResponse{
+			Status:              merr.Success(),
+			NodeID:              1,
+			RemainingSegmentNum: 2,
+		}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteDecommissionQueryNode, strings.NewReader("node_id=1"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		recorder := httptest.NewRecorder()
+		s.proxy.DecommissionQueryNode(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Equal(`{"msg": "OK", "drained": false, "remaining_segment_num": 2, "remaining_channel_num": 0}`, recorder.Body.String())
+	})
+
+	s.Run("return_error", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		// test miss requested param
+		req, err := http.NewRequest(http.MethodPost, management.RouteDecommissionQueryNode, strings.NewReader(""))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.DecommissionQueryNode(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		// test rpc return error
+		s.mixcoord.EXPECT().DecommissionNode(mock.Anything, mock.Anything).Return(nil, errors.New("mocked error"))
+		req, err = http.NewRequest(http.MethodPost, management.RouteDecommissionQueryNode, strings.NewReader("node_id=1"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder = httptest.NewRecorder()
+		s.proxy.DecommissionQueryNode(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	s.Run("return_failure", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.mixcoord.EXPECT().DecommissionNode(mock.Anything, mock.Anything).Return(&querypb.DecommissionNodeResponse{
+			Status: merr.Status(merr.ErrServiceNotReady),
+		}, nil)
+		req, err := http.NewRequest(http.MethodPost, management.RouteDecommissionQueryNode, strings.NewReader("node_id=1"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.DecommissionQueryNode(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func (s *ProxyManagementSuite) TestReplicaSuggestions() {
+	s.Run("list", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.mixcoord.EXPECT().ListReplicaSuggestions(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *querypb.ListReplicaSuggestionsRequest, opts ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error) {
+				s.Equal(int64(1), req.GetCollectionID())
+				return &querypb.ListReplicaSuggestionsResponse{
+					Status: merr.Success(),
+					Suggestions: []*querypb.ReplicaSuggestion{
+						{CollectionID: 1, ReplicaID: 2, NodeID: 3},
+					},
+				}, nil
+			})
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteListReplicaSuggestions, strings.NewReader("collection_id=1"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.ListReplicaSuggestions(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Contains(recorder.Body.String(), `"nodeID":3`)
+	})
+
+	s.Run("approve", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.mixcoord.EXPECT().ApproveReplicaSuggestions(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *querypb.ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+				s.Equal(int64(1), req.GetCollectionID())
+				s.Equal([]int64{3}, req.GetNodeIDs())
+				return merr.Success(), nil
+			})
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteApproveReplicaSuggestions, strings.NewReader("collection_id=1&node_id=3"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.ApproveReplicaSuggestions(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Equal(`{"msg": "OK"}`, recorder.Body.String())
+	})
+
+	s.Run("return_error", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		// test miss requested param
+		req, err := http.NewRequest(http.MethodPost, management.RouteApproveReplicaSuggestions, strings.NewReader(""))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.ApproveReplicaSuggestions(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		// test rpc return error
+		s.mixcoord.EXPECT().ListReplicaSuggestions(mock.Anything, mock.Anything).Return(nil, errors.New("mocked error"))
+		req, err = http.NewRequest(http.MethodPost, management.RouteListReplicaSuggestions, nil)
+		s.Require().NoError(err)
+		recorder = httptest.NewRecorder()
+		s.proxy.ListReplicaSuggestions(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+
+		s.mixcoord.EXPECT().ApproveReplicaSuggestions(mock.Anything, mock.Anything).Return(merr.Status(merr.ErrServiceNotReady), nil)
+		req, err = http.NewRequest(http.MethodPost, management.RouteApproveReplicaSuggestions, strings.NewReader("collection_id=1"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder = httptest.NewRecorder()
+		s.proxy.ApproveReplicaSuggestions(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
 func (s *ProxyManagementSuite) TestResumeQueryNode() {
 	s.Run("normal", func() {
 		s.SetupTest()
@@ -865,6 +1000,125 @@ func (s *ProxyManagementSuite) TestCommitBackfillResult() {
 	})
 }
 
+func (s *ProxyManagementSuite) TestCloneCollection() {
+	s.Run("normal", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.mixcoord.EXPECT().CloneCollection(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *datapb.CloneCollectionRequest, opts ...grpc.CallOption) (*datapb.CloneCollectionResponse, error) {
+				s.Equal(int64(1), req.GetSourceCollectionId())
+				s.Equal("staging", req.GetTargetCollectionName())
+				s.True(req.GetLoad())
+				s.Equal(int32(2), req.GetReplicaNumber())
+				return &datapb.CloneCollectionResponse{
+					Status:       merr.Success(),
+					JobId:        10,
+					SnapshotName: "_clone_100",
+				}, nil
+			})
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteCloneCollection,
+			strings.NewReader("collection_id=1&target_collection_name=staging&load=true&replica_number=2"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.CloneCollection(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Contains(recorder.Body.String(), `"job_id":10`)
+	})
+
+	s.Run("return_error", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		// test miss requested param
+		req, err := http.NewRequest(http.MethodPost, management.RouteCloneCollection, strings.NewReader("target_collection_name=staging"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.CloneCollection(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		req, err = http.NewRequest(http.MethodPost, management.RouteCloneCollection, strings.NewReader("collection_id=1&load=yes please"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder = httptest.NewRecorder()
+		s.proxy.CloneCollection(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		// test rpc return error
+		s.mixcoord.EXPECT().CloneCollection(mock.Anything, mock.Anything).Return(&datapb.CloneCollectionResponse{
+			Status: merr.Status(merr.ErrServiceNotReady),
+		}, nil)
+		req, err = http.NewRequest(http.MethodPost, management.RouteCloneCollection, strings.NewReader("collection_id=1&target_collection_name=staging"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder = httptest.NewRecorder()
+		s.proxy.CloneCollection(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func (s *ProxyManagementSuite) TestReindexSegments() {
+	s.Run("normal", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.mixcoord.EXPECT().ReindexSegments(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *datapb.ReindexSegmentsRequest, opts ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error) {
+				s.Equal(int64(1), req.GetCollectionId())
+				s.Equal([]int64{100, 101}, req.GetSegmentIds())
+				return &milvuspb.ManualCompactionResponse{
+					Status:              merr.Success(),
+					CompactionID:        10,
+					CompactionPlanCount: 2,
+				}, nil
+			})
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteReindexSegments,
+			strings.NewReader("collection_id=1&segment_ids=100,101"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.ReindexSegments(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Contains(recorder.Body.String(), `"compaction_id":10`)
+		s.Contains(recorder.Body.String(), `"compaction_plan_count":2`)
+	})
+
+	s.Run("return_error", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		// test miss requested param
+		req, err := http.NewRequest(http.MethodPost, management.RouteReindexSegments, strings.NewReader("segment_ids=100"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		s.proxy.ReindexSegments(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		req, err = http.NewRequest(http.MethodPost, management.RouteReindexSegments, strings.NewReader("collection_id=1"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder = httptest.NewRecorder()
+		s.proxy.ReindexSegments(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		// test rpc return error
+		s.mixcoord.EXPECT().ReindexSegments(mock.Anything, mock.Anything).Return(&milvuspb.ManualCompactionResponse{
+			Status: merr.Status(merr.ErrServiceNotReady),
+		}, nil)
+		req, err = http.NewRequest(http.MethodPost, management.RouteReindexSegments, strings.NewReader("collection_id=1&segment_ids=100"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder = httptest.NewRecorder()
+		s.proxy.ReindexSegments(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
 func TestProxyManagement(t *testing.T) {
 	suite.Run(t, new(ProxyManagementSuite))
 }
@@ -132,7 +132,6 @@ type collectionInfo struct {
 	partitionKeyIsolation bool
 	queryMode             string
 	updateTimestamp       uint64
-	collectionTTL         uint64
 	numPartitions         int64
 	vChannels             []string
 	pChannels             []string
@@ -695,7 +694,6 @@ func newCollectionInfo(collection *milvuspb.DescribeCollectionResponse, schemaIn
 		partitionKeyIsolation: isolation,
 		queryMode:             queryMode,
 		updateTimestamp:       collection.UpdateTimestamp,
-		collectionTTL:         getCollectionTTL(schemaInfo.GetProperties()),
 		vChannels:             collection.VirtualChannelNames,
 		pChannels:             collection.PhysicalChannelNames,
 		numPartitions:         collection.NumPartitions,
@@ -70,14 +70,21 @@ func getQuotaMetrics(node *Proxy) (*metricsinfo.ProxyQuotaMetrics, error) {
 	getSubLabelRateMetric(internalpb.RateType_DQLSearch.String())
 	getRateMetric(internalpb.RateType_DQLQuery.String())
 	getSubLabelRateMetric(internalpb.RateType_DQLQuery.String())
+	getRateMetric(internalpb.RateType_DQLGet.String())
+	getSubLabelRateMetric(internalpb.RateType_DQLGet.String())
+	getSubLabelRateMetric(metricsinfo.DQLSlowQuery)
 	if err != nil {
 		return nil, err
 	}
-	return &metricsinfo.ProxyQuotaMetrics{
+	quotaMetrics := &metricsinfo.ProxyQuotaMetrics{
 		Hms:          metricsinfo.HardwareMetrics{},
 		Rms:          rms,
 		QueueMetrics: node.sched.getMetrics(),
-	}, nil
+	}
+	if node.simpleLimiter != nil {
+		quotaMetrics.Limiter = &metricsinfo.ProxyLimiterSnapshot{Digest: node.simpleLimiter.GetLimiterDigest()}
+	}
+	return quotaMetrics, nil
 }
 
 // getProxyMetrics get metrics of Proxy, not including the topological metrics of Query cluster and Data cluster.
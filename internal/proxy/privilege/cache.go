@@ -62,6 +62,8 @@ type privilegeCache struct {
 
 	credMut sync.RWMutex
 	credMap map[string]*internalpb.CredentialInfo
+
+	credentialScopes map[string][]*internalpb.CredentialScope // user to credential scopes cache, guarded by mu
 }
 
 func InitPrivilegeCache(ctx context.Context, mixCoord types.MixCoordClient) error {
@@ -73,6 +75,7 @@ func InitPrivilegeCache(ctx context.Context, mixCoord types.MixCoordClient) erro
 		mlog.Error(ctx, "fail to init meta cache", mlog.Err(err))
 		return err
 	}
+	privilegeCache.InitCredentialScopes(resp.GetCredentialScopes())
 	privilegeCache.InitPolicyInfo(resp.PolicyInfos, resp.UserRoles)
 	mlog.Info(ctx, "success to init privilege cache", mlog.Strings("policy_infos", resp.PolicyInfos))
 	return nil
@@ -85,6 +88,8 @@ func NewPrivilegeCache(mixCoord types.MixCoordClient) *privilegeCache {
 		userToRoles:    make(map[string]map[string]struct{}),
 
 		credMap: make(map[string]*internalpb.CredentialInfo),
+
+		credentialScopes: make(map[string][]*internalpb.CredentialScope),
 	}
 }
 
@@ -166,6 +171,30 @@ func (m *privilegeCache) unsafeInitPolicyInfo(info []string, userRoles []string)
 	}
 }
 
+// InitCredentialScopes replaces the cached credential scopes of all the restricted users.
+func (m *privilegeCache) InitCredentialScopes(scopes []*internalpb.UserCredentialScopes) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.unsafeInitCredentialScopes(scopes)
+}
+
+func (m *privilegeCache) unsafeInitCredentialScopes(scopes []*internalpb.UserCredentialScopes) {
+	m.credentialScopes = make(map[string][]*internalpb.CredentialScope, len(scopes))
+	for _, userScopes := range scopes {
+		m.credentialScopes[userScopes.GetUsername()] = userScopes.GetScopes()
+	}
+}
+
+// GetCredentialScopes returns the credential scopes of the user, nil if the user is not restricted.
+func (m *privilegeCache) GetCredentialScopes(username string) []*internalpb.CredentialScope {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.credentialScopes[username]
+}
+
 func (m *privilegeCache) GetPrivilegeInfo(ctx context.Context) []string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -228,6 +257,7 @@ func (m *privilegeCache) RefreshPolicyInfo(op typeutil.CacheOp) (err error) {
 		}
 	case typeutil.CacheDeleteUser:
 		delete(m.userToRoles, op.OpKey)
+		delete(m.credentialScopes, op.OpKey)
 	case typeutil.CacheDropRole:
 		for user := range m.userToRoles {
 			delete(m.userToRoles[user], op.OpKey)
@@ -257,6 +287,7 @@ func (m *privilegeCache) RefreshPolicyInfo(op typeutil.CacheOp) (err error) {
 		m.userToRoles = make(map[string]map[string]struct{})
 		m.privilegeInfos = make(map[string]struct{})
 		m.unsafeInitPolicyInfo(resp.PolicyInfos, resp.UserRoles)
+		m.unsafeInitCredentialScopes(resp.GetCredentialScopes())
 	default:
 		return merr.WrapErrParameterInvalidMsg("invalid opType, op_type: %d, op_key: %s", int(op.OpType), op.OpKey)
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
 type PrivilegeCacheTestSuite struct {
@@ -124,6 +125,30 @@ func (s *PrivilegeCacheTestSuite) TestGetCredentialInfo() {
 	})
 }
 
+func (s *PrivilegeCacheTestSuite) TestCredentialScopes() {
+	s.cache.InitCredentialScopes([]*internalpb.UserCredentialScopes{
+		{Username: "user1", Scopes: []*internalpb.CredentialScope{{DbName: "db1", CollectionName: "coll1", OperationClasses: []string{"dql"}}}},
+	})
+	s.Len(s.cache.GetCredentialScopes("user1"), 1)
+	s.Nil(s.cache.GetCredentialScopes("user2"))
+
+	s.mockMixCoord.EXPECT().ListPolicy(mock.Anything, mock.Anything).Return(&internalpb.ListPolicyResponse{
+		Status: merr.Success(),
+		CredentialScopes: []*internalpb.UserCredentialScopes{
+			{Username: "user2", Scopes: []*internalpb.CredentialScope{{DbName: "*", CollectionName: "*", OperationClasses: []string{"dql"}}}},
+		},
+	}, nil).Once()
+	s.NoError(s.cache.RefreshPolicyInfo(typeutil.CacheOp{OpType: typeutil.CacheRefresh}))
+	s.Nil(s.cache.GetCredentialScopes("user1"))
+	s.Len(s.cache.GetCredentialScopes("user2"), 1)
+
+	s.NoError(s.cache.RefreshPolicyInfo(typeutil.CacheOp{OpType: typeutil.CacheDeleteUser, OpKey: "user2"}))
+	s.Nil(s.cache.GetCredentialScopes("user2"))
+
+	var nilCache *privilegeCache
+	s.Nil(nilCache.GetCredentialScopes("user1"))
+}
+
 func TestPrivilegeCache(t *testing.T) {
 	suite.Run(t, new(PrivilegeCacheTestSuite))
 }
@@ -145,6 +145,15 @@ func PrivilegeInterceptor(ctx context.Context, req interface{}) (context.Context
 		objectNames = resolvedNames
 	}
 
+	scopedObjectNames := objectNames
+	if objectNameIndex != 0 {
+		scopedObjectNames = []string{objectName}
+	}
+	if err := checkCredentialScopes(username, dbName, objectType, objectPrivilege, scopedObjectNames); err != nil {
+		mlog.Info(ctx, "permission deny by credential scopes", mlog.String("username", username), mlog.Err(err))
+		return ctx, err
+	}
+
 	log := mlog.With(mlog.String("username", username), mlog.Strings("role_names", roleNames),
 		mlog.String("object_type", objectType), mlog.String("object_privilege", objectPrivilege),
 		mlog.FieldDbName(dbName),
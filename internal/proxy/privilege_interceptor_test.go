@@ -228,6 +228,57 @@ func TestPrivilegeInterceptorRequestDBName(t *testing.T) {
 	})
 }
 
+func TestPrivilegeInterceptorCredentialScopes(t *testing.T) {
+	paramtable.Init()
+	Params.Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer Params.Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	client := &MockMixCoordClientInterface{}
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status: merr.Success(),
+			PolicyInfos: []string{
+				funcutil.PolicyForPrivilege("role_all", commonpb.ObjectType_Global.String(), "*", commonpb.ObjectPrivilege_PrivilegeAll.String(), "default"),
+			},
+			UserRoles: []string{
+				funcutil.EncodeUserRoleCache("alice", "role_all"),
+				funcutil.EncodeUserRoleCache("bob", "role_all"),
+			},
+			// alice is restricted to query col1 and write col2, bob is not restricted.
+			CredentialScopes: []*internalpb.UserCredentialScopes{
+				{
+					Username: "alice",
+					Scopes: []*internalpb.CredentialScope{
+						{DbName: "default", CollectionName: "col1", OperationClasses: []string{util.OperationClassDQL}},
+						{DbName: "default", CollectionName: "col2", OperationClasses: []string{util.OperationClassDML}},
+					},
+				},
+			},
+		}, nil
+	}
+	err := InitMetaCache(context.Background(), client)
+	assert.NoError(t, err)
+
+	aliceCtx := GetContext(context.Background(), "alice:pwd")
+	_, err = PrivilegeInterceptor(aliceCtx, &milvuspb.QueryRequest{DbName: "default", CollectionName: "col1"})
+	assert.NoError(t, err)
+	_, err = PrivilegeInterceptor(aliceCtx, &milvuspb.InsertRequest{DbName: "default", CollectionName: "col2"})
+	assert.NoError(t, err)
+
+	// out of the operation classes, the collections or the database of the scopes.
+	_, err = PrivilegeInterceptor(aliceCtx, &milvuspb.InsertRequest{DbName: "default", CollectionName: "col1"})
+	assert.Error(t, err)
+	_, err = PrivilegeInterceptor(aliceCtx, &milvuspb.DropCollectionRequest{DbName: "default", CollectionName: "col1"})
+	assert.Error(t, err)
+	_, err = PrivilegeInterceptor(aliceCtx, &milvuspb.QueryRequest{DbName: "default", CollectionName: "col3"})
+	assert.Error(t, err)
+	_, err = PrivilegeInterceptor(aliceCtx, &milvuspb.QueryRequest{DbName: "db1", CollectionName: "col1"})
+	assert.Error(t, err)
+
+	_, err = PrivilegeInterceptor(GetContext(context.Background(), "bob:pwd"), &milvuspb.DropCollectionRequest{DbName: "default", CollectionName: "col1"})
+	assert.NoError(t, err)
+}
+
 // TestPrivilegeInterceptorClusterLevel guards the cluster-level half of the
 // #50678 fix: cluster-level privileges (e.g. CreateDatabase/DropDatabase) are
 // not scoped to a specific database, so they are authorized globally (AnyWord),
@@ -19,16 +19,24 @@ package proxy
 import (
 	"context"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/samber/lo"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proxy/connection"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
 	"github.com/milvus-io/milvus/pkg/v3/util"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
@@ -54,7 +62,17 @@ func RateLimitInterceptor(limiter types.Limiter) grpc.UnaryServerInterceptor {
 				}
 			}
 		}
-		err = limiter.Check(dbID, collectionIDToPartIDs, rt, n)
+		if checker, ok := limiter.(quotaStateChecker); ok && isQuotaExempted(ctx, collectionIDToPartIDs, rt) {
+			err = checker.CheckQuotaStates(dbID, collectionIDToPartIDs, rt)
+		} else {
+			err = limiter.Check(dbID, collectionIDToPartIDs, rt, n)
+			if checker, ok := limiter.(aliasLimitChecker); ok && err == nil {
+				err = checkAliasRateLimit(checker, request, collectionIDToPartIDs, rt, n)
+			}
+			if checker, ok := limiter.(appTagLimitChecker); ok && err == nil {
+				err = checkAppTagRateLimit(ctx, checker, collectionIDToPartIDs, rt, n)
+			}
+		}
 		nodeID := strconv.FormatInt(paramtable.GetNodeID(), 10)
 		metrics.ProxyRateLimitReqCount.WithLabelValues(nodeID, rt.String(), metrics.TotalLabel).Inc()
 		if err != nil {
@@ -71,6 +89,83 @@ func RateLimitInterceptor(limiter types.Limiter) grpc.UnaryServerInterceptor {
 	}
 }
 
+// quotaStateChecker checks the quota states only, implemented by SimpleLimiter.
+type quotaStateChecker interface {
+	CheckQuotaStates(dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType) error
+}
+
+// aliasLimitChecker checks the alias rate limits, implemented by SimpleLimiter.
+type aliasLimitChecker interface {
+	CheckAlias(collectionID int64, alias string, rt internalpb.RateType, n int) error
+}
+
+// checkAliasRateLimit checks the alias rate limits if the request accesses the collection through an alias.
+// The alias limiters are keyed by the alias names of the collection, so the request name is checked directly
+// without resolving it, a collection name never matches since the aliases and collections share the namespace.
+func checkAliasRateLimit(checker aliasLimitChecker, request any, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error {
+	if len(collectionIDToPartIDs) != 1 {
+		return nil
+	}
+	r, ok := request.(requestutil.CollectionNameGetter)
+	if !ok || r.GetCollectionName() == "" {
+		return nil
+	}
+	for collectionID := range collectionIDToPartIDs {
+		return checker.CheckAlias(collectionID, r.GetCollectionName(), rt, n)
+	}
+	return nil
+}
+
+// appTagLimitChecker checks the app tag rate limits, implemented by SimpleLimiter.
+type appTagLimitChecker interface {
+	CheckAppTag(collectionID int64, appTag string, rt internalpb.RateType, n int) error
+}
+
+// checkAppTagRateLimit checks the app tag rate limits on each collection of the request,
+// if the connection of the request is tagged with an app in its client info.
+func checkAppTagRateLimit(ctx context.Context, checker appTagLimitChecker, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error {
+	appTag := connection.GetAppTag(ctx)
+	if appTag == "" {
+		return nil
+	}
+	for collectionID := range collectionIDToPartIDs {
+		if collectionID == 0 {
+			continue
+		}
+		if err := checker.CheckAppTag(collectionID, appTag, rt, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isQuotaExempted returns whether the request carries a valid exemption token covering it,
+// the exempted requests bypass the rate limits but are still denied by the quota states.
+func isQuotaExempted(ctx context.Context, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(rlinternal.ExemptionTokenHeader)
+	if len(values) == 0 || values[0] == "" {
+		return false
+	}
+	token, err := rlinternal.ParseExemptionToken(values[0], Params.QuotaConfig.ExemptionSecret.GetValue(), time.Now())
+	if err != nil {
+		mlog.RatedWarn(ctx, 1, "ignore the invalid quota exemption token", mlog.Err(err))
+		return false
+	}
+	if !token.Covers(lo.Keys(collectionIDToPartIDs), rt) {
+		return false
+	}
+	mlog.RatedInfo(ctx, 1, "request is exempted from rate limits",
+		mlog.String("tokenID", token.ID),
+		mlog.String("issuer", token.Issuer),
+		mlog.Int64("collectionID", token.CollectionID),
+		mlog.String("rateType", rt.String()))
+	return true
+}
+
 type reqPartName interface {
 	requestutil.DBNameGetter
 	requestutil.CollectionNameGetter
@@ -175,18 +270,47 @@ func getCollectionAndPartitionIDs(ctx context.Context, r reqPartNames) (int64, m
 		}
 	}
 
-	parts := make([]int64, len(partitionNames))
-	for i, s := range partitionNames {
+	// a partition listed more than once is only charged once by the partition level limiters.
+	parts := make([]int64, 0, len(partitionNames))
+	for _, s := range lo.Uniq(partitionNames) {
 		part, err := globalMetaCache.GetPartitionInfo(ctx, r.GetDbName(), r.GetCollectionName(), s)
 		if err != nil {
 			return 0, nil, err
 		}
-		parts[i] = part.partitionID
+		parts = append(parts, part.partitionID)
 	}
 
 	return db.dbID, map[int64][]int64{collectionID: parts}, nil
 }
 
+// getQueryRateType returns DQLGet for the query requests looking up the primary keys only, e.g. "pk in [1, 2]",
+// and DQLQuery for the other ones. The requests failed to parse are limited as queries, the query task reports the error.
+func getQueryRateType(ctx context.Context, r *milvuspb.QueryRequest) internalpb.RateType {
+	if strings.TrimSpace(r.GetExpr()) == "" {
+		return internalpb.RateType_DQLQuery
+	}
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, r.GetDbName(), r.GetCollectionName())
+	if err != nil {
+		return internalpb.RateType_DQLQuery
+	}
+	expr, err := planparserv2.ParseExpr(schema.schemaHelper, r.GetExpr(), r.GetExprTemplateValues())
+	if err != nil || !isPrimaryKeyLookupExpr(expr) {
+		return internalpb.RateType_DQLQuery
+	}
+	return internalpb.RateType_DQLGet
+}
+
+func isPrimaryKeyLookupExpr(expr *planpb.Expr) bool {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_TermExpr:
+		return e.TermExpr.GetColumnInfo().GetIsPrimaryKey()
+	case *planpb.Expr_UnaryRangeExpr:
+		return e.UnaryRangeExpr.GetOp() == planpb.OpType_Equal && e.UnaryRangeExpr.GetColumnInfo().GetIsPrimaryKey()
+	default:
+		return false
+	}
+}
+
 func getCollectionID(r reqCollName) (int64, map[int64][]int64) {
 	db, _ := globalMetaCache.GetDatabaseInfo(context.TODO(), r.GetDbName())
 	if db == nil {
@@ -19,20 +19,24 @@ package proxy
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
 	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v3/util"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
 type limiterMock struct {
@@ -162,6 +166,15 @@ func TestRateLimitInterceptor(t *testing.T) {
 		assert.Equal(t, 1, len(col2part))
 		assert.Equal(t, 1, len(col2part[1]))
 
+		// duplicated partition names are only charged once.
+		_, col2part, _, _, err = GetRequestInfo(context.Background(), &milvuspb.QueryRequest{
+			CollectionName: "foo",
+			PartitionNames: []string{"p1", "p1"},
+			DbName:         "db1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{10}, col2part[1])
+
 		database, col2part, rt, size, err = GetRequestInfo(context.Background(), &milvuspb.CreateCollectionRequest{})
 		assert.NoError(t, err)
 		assert.Equal(t, 1, size)
@@ -290,6 +303,61 @@ func TestRateLimitInterceptor(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("ddl tokens weighted by payload size", func(t *testing.T) {
+		mockCache := NewMockCache(t)
+		mockCache.EXPECT().GetDatabaseInfo(mock.Anything, mock.Anything).Return(&databaseInfo{
+			dbID:             100,
+			createdTimestamp: 1,
+		}, nil).Maybe()
+		globalMetaCache = mockCache
+
+		req := &milvuspb.CreateCollectionRequest{
+			CollectionName: "foo",
+			Schema:         make([]byte, 1000),
+		}
+		paramtable.Get().Save(Params.QuotaConfig.DDLPayloadUnit.Key, "100")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DDLPayloadUnit.Key)
+		_, _, rt, tokens, err := GetRequestInfo(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, internalpb.RateType_DDLCollection, rt)
+		assert.Equal(t, (proto.Size(req)+99)/100, tokens)
+		assert.Greater(t, tokens, 10)
+
+		// the light ddl request consumes at least one token
+		_, _, rt, tokens, err = GetRequestInfo(context.Background(), &milvuspb.DropCollectionRequest{CollectionName: "foo"})
+		assert.NoError(t, err)
+		assert.Equal(t, internalpb.RateType_DDLCollection, rt)
+		assert.Equal(t, 1, tokens)
+
+		paramtable.Get().Save(Params.QuotaConfig.DDLPayloadUnit.Key, "0")
+		_, _, _, tokens, err = GetRequestInfo(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, tokens)
+	})
+
+	t.Run("query rate type", func(t *testing.T) {
+		mockCache := NewMockCache(t)
+		mockCache.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything, mock.Anything).Return(mustNewSchemaInfo(&schemapb.CollectionSchema{
+			Name: "foo",
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+				{FieldID: 101, Name: "age", DataType: schemapb.DataType_Int64},
+			},
+		}), nil).Maybe()
+		globalMetaCache = mockCache
+
+		getRateType := func(expr string) internalpb.RateType {
+			return getQueryRateType(context.Background(), &milvuspb.QueryRequest{CollectionName: "foo", Expr: expr})
+		}
+		assert.Equal(t, internalpb.RateType_DQLGet, getRateType("pk in [1, 2, 3]"))
+		assert.Equal(t, internalpb.RateType_DQLGet, getRateType("pk == 1"))
+		assert.Equal(t, internalpb.RateType_DQLQuery, getRateType(""))
+		assert.Equal(t, internalpb.RateType_DQLQuery, getRateType("pk > 1"))
+		assert.Equal(t, internalpb.RateType_DQLQuery, getRateType("age in [1, 2]"))
+		assert.Equal(t, internalpb.RateType_DQLQuery, getRateType("pk in [1] and age > 1"))
+		assert.Equal(t, internalpb.RateType_DQLQuery, getRateType("invalid expr"))
+	})
+
 	t.Run("namespace partition mode request info", func(t *testing.T) {
 		namespace := "tenant_partition"
 		schema := &schemapb.CollectionSchema{
@@ -698,3 +766,58 @@ func TestGetInfo(t *testing.T) {
 		assert.Zero(t, cost)
 	})
 }
+
+func TestIsQuotaExempted(t *testing.T) {
+	paramtable.Init()
+	rt := internalpb.RateType_DMLDelete
+	collections := map[int64][]int64{100: {}}
+	assert.False(t, isQuotaExempted(context.Background(), collections, rt))
+
+	now := time.Now()
+	raw, err := rlinternal.SignExemptionToken(&rlinternal.ExemptionToken{
+		ID:           "1",
+		CollectionID: 100,
+		RateTypes:    []internalpb.RateType{rt},
+		ExpireAt:     now.Add(time.Minute).Unix(),
+	}, "secret")
+	assert.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(rlinternal.ExemptionTokenHeader, raw))
+
+	// the exemption is disabled without secret.
+	assert.False(t, isQuotaExempted(ctx, collections, rt))
+
+	paramtable.Get().Save(Params.QuotaConfig.ExemptionSecret.Key, "secret")
+	defer paramtable.Get().Reset(Params.QuotaConfig.ExemptionSecret.Key)
+	assert.True(t, isQuotaExempted(ctx, collections, rt))
+	assert.False(t, isQuotaExempted(ctx, collections, internalpb.RateType_DMLInsert))
+	assert.False(t, isQuotaExempted(ctx, map[int64][]int64{101: {}}, rt))
+}
+
+type mockAliasLimitChecker struct {
+	checked map[int64]string
+}
+
+func (m *mockAliasLimitChecker) CheckAlias(collectionID int64, alias string, rt internalpb.RateType, n int) error {
+	m.checked[collectionID] = alias
+	if alias == "limited" {
+		return merr.ErrServiceRateLimit
+	}
+	return nil
+}
+
+func TestCheckAliasRateLimit(t *testing.T) {
+	checker := &mockAliasLimitChecker{checked: make(map[int64]string)}
+	rt := internalpb.RateType_DQLSearch
+
+	err := checkAliasRateLimit(checker, &milvuspb.SearchRequest{CollectionName: "limited"}, map[int64][]int64{100: {}}, rt, 1)
+	assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+	assert.Equal(t, "limited", checker.checked[100])
+
+	assert.NoError(t, checkAliasRateLimit(checker, &milvuspb.SearchRequest{CollectionName: "a1"}, map[int64][]int64{101: {}}, rt, 1))
+	assert.Equal(t, "a1", checker.checked[101])
+
+	// skipped for the requests of multiple collections or without collection name
+	assert.NoError(t, checkAliasRateLimit(checker, &milvuspb.SearchRequest{CollectionName: "limited"}, map[int64][]int64{102: {}, 103: {}}, rt, 1))
+	assert.NoError(t, checkAliasRateLimit(checker, &milvuspb.FlushRequest{}, map[int64][]int64{104: {}}, rt, 1))
+	assert.Len(t, checker.checked, 2)
+}
@@ -1372,6 +1372,10 @@ func (coord *MixCoordMock) ManualCompaction(ctx context.Context, req *milvuspb.M
 	return &milvuspb.ManualCompactionResponse{}, nil
 }
 
+func (coord *MixCoordMock) ReindexSegments(ctx context.Context, req *datapb.ReindexSegmentsRequest, opts ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error) {
+	return &milvuspb.ManualCompactionResponse{}, nil
+}
+
 func (coord *MixCoordMock) GetCompactionState(ctx context.Context, req *milvuspb.GetCompactionStateRequest, opts ...grpc.CallOption) (*milvuspb.GetCompactionStateResponse, error) {
 	return &milvuspb.GetCompactionStateResponse{}, nil
 }
@@ -1692,6 +1696,18 @@ func (coord *MixCoordMock) CheckQueryNodeDistribution(ctx context.Context, in *q
 	return merr.Success(), nil
 }
 
+func (coord *MixCoordMock) DecommissionNode(ctx context.Context, in *querypb.DecommissionNodeRequest, opts ...grpc.CallOption) (*querypb.DecommissionNodeResponse, error) {
+	return &querypb.DecommissionNodeResponse{Status: merr.Success()}, nil
+}
+
+func (coord *MixCoordMock) ListReplicaSuggestions(ctx context.Context, in *querypb.ListReplicaSuggestionsRequest, opts ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error) {
+	return &querypb.ListReplicaSuggestionsResponse{Status: merr.Success()}, nil
+}
+
+func (coord *MixCoordMock) ApproveReplicaSuggestions(ctx context.Context, in *querypb.ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return merr.Success(), nil
+}
+
 func (coord *MixCoordMock) UpdateLoadConfig(ctx context.Context, in *querypb.UpdateLoadConfigRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	return merr.Success(), nil
 }
@@ -1752,6 +1768,12 @@ func (coord *MixCoordMock) ListRestoreSnapshotJobs(ctx context.Context, req *dat
 	}, nil
 }
 
+func (coord *MixCoordMock) CloneCollection(ctx context.Context, req *datapb.CloneCollectionRequest, opts ...grpc.CallOption) (*datapb.CloneCollectionResponse, error) {
+	return &datapb.CloneCollectionResponse{
+		Status: merr.Success(),
+	}, nil
+}
+
 func (coord *MixCoordMock) PinSnapshotData(ctx context.Context, req *datapb.PinSnapshotDataRequest, opts ...grpc.CallOption) (*datapb.PinSnapshotDataResponse, error) {
 	return &datapb.PinSnapshotDataResponse{
 		Status: merr.Success(),
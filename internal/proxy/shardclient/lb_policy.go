@@ -19,6 +19,7 @@ package shardclient
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -223,13 +224,20 @@ func (lb *LBPolicyImpl) selectNode(ctx context.Context, balancer LBBalancer, wor
 
 		balancer.RegisterNodeInfo(lo.Values(candidateNodes))
 
-		// prefer serviceable nodes
-		var targetNodeID int64
+		// prefer serviceable nodes, then the healthy ones
+		targetNodes := candidateNodes
 		if len(serviceableNodes) > 0 {
-			targetNodeID, err = balancer.SelectNode(ctx, lo.Keys(serviceableNodes), workload.Nq)
-		} else {
-			targetNodeID, err = balancer.SelectNode(ctx, lo.Keys(candidateNodes), workload.Nq)
+			targetNodes = serviceableNodes
+		}
+		targetNodes = preferHealthyNodes(targetNodes)
+
+		// dispatch by the replica weights if they are customized
+		if node, ok := selectNodeByWeight(lo.Values(targetNodes)); ok {
+			return node, false, nil
 		}
+
+		var targetNodeID int64
+		targetNodeID, err = balancer.SelectNode(ctx, lo.Keys(targetNodes), workload.Nq)
 		if err != nil {
 			return NodeInfo{}, false, err
 		}
@@ -257,6 +265,52 @@ func (lb *LBPolicyImpl) selectNode(ctx context.Context, balancer LBBalancer, wor
 	return targetNode, selectedByBalancer, nil
 }
 
+// preferHealthyNodes filters out the nodes whose health scores are lower than the threshold,
+// all the nodes are returned if none of them is healthy, or the health scores are unknown.
+func preferHealthyNodes(nodes map[int64]NodeInfo) map[int64]NodeInfo {
+	threshold := paramtable.Get().ProxyCfg.ShardLeaderHealthThreshold.GetAsFloat()
+	if threshold <= 0 {
+		return nodes
+	}
+	healthyNodes := lo.PickBy(nodes, func(_ int64, node NodeInfo) bool {
+		return node.HealthScore >= threshold
+	})
+	if len(healthyNodes) == 0 || len(healthyNodes) == len(nodes) {
+		return nodes
+	}
+	return healthyNodes
+}
+
+// selectNodeByWeight picks a node randomly in proportion to the replica weights,
+// returns false if the weights are unknown or all the same, then the balancer is used.
+func selectNodeByWeight(nodes []NodeInfo) (NodeInfo, bool) {
+	if len(nodes) < 2 {
+		return NodeInfo{}, false
+	}
+	uniform := true
+	total := int64(0)
+	for _, node := range nodes {
+		if node.Weight <= 0 {
+			return NodeInfo{}, false
+		}
+		if node.Weight != nodes[0].Weight {
+			uniform = false
+		}
+		total += int64(node.Weight)
+	}
+	if uniform {
+		return NodeInfo{}, false
+	}
+	r := rand.Int63n(total)
+	for _, node := range nodes {
+		r -= int64(node.Weight)
+		if r < 0 {
+			return node, true
+		}
+	}
+	return nodes[len(nodes)-1], true
+}
+
 // ExecuteWithRetry will choose a qn to execute the workload, and retry if failed, until reach the max retryTimes.
 func (lb *LBPolicyImpl) ExecuteWithRetry(ctx context.Context, workload ChannelWorkload) error {
 	log := mlog.With(
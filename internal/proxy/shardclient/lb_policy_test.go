@@ -184,6 +184,88 @@ func (s *LBPolicySuite) TestSelectNode() {
 	s.ErrorIs(err, merr.ErrCollectionNotLoaded)
 }
 
+func (s *LBPolicySuite) TestSelectNodeByWeight() {
+	nodes := []NodeInfo{
+		{NodeID: 1, Serviceable: true, Weight: 100},
+		{NodeID: 2, Serviceable: true, Weight: 100},
+	}
+	_, ok := selectNodeByWeight(nodes)
+	s.False(ok)
+
+	nodes[1].Weight = 0
+	_, ok = selectNodeByWeight(nodes)
+	s.False(ok)
+
+	nodes[1].Weight = 1
+	hits := make(map[int64]int)
+	for i := 0; i < 1000; i++ {
+		node, ok := selectNodeByWeight(nodes)
+		s.True(ok)
+		hits[node.NodeID]++
+	}
+	s.Greater(hits[1], hits[2])
+
+	// the balancer is skipped when the replica weights are customized
+	s.mgr.EXPECT().GetShard(mock.Anything, true, s.dbName, s.collectionName, s.collectionID, s.channels[0]).
+		Return([]NodeInfo{
+			{NodeID: 1, Serviceable: true, Weight: 10},
+			{NodeID: 2, Serviceable: true, Weight: 100},
+			{NodeID: 3, Serviceable: false, Weight: 100},
+		}, nil)
+	s.lbBalancer.EXPECT().RegisterNodeInfo(mock.Anything)
+	excludeNodes := typeutil.NewUniqueSet()
+	targetNode, selectedByBalancer, err := s.lbPolicy.selectNode(context.Background(), s.lbBalancer, ChannelWorkload{
+		Db:             s.dbName,
+		CollectionName: s.collectionName,
+		CollectionID:   s.collectionID,
+		Channel:        s.channels[0],
+		Nq:             1,
+	}, &excludeNodes)
+	s.NoError(err)
+	s.False(selectedByBalancer)
+	s.Contains([]int64{1, 2}, targetNode.NodeID)
+}
+
+func (s *LBPolicySuite) TestPreferHealthyNodes() {
+	nodes := map[int64]NodeInfo{
+		1: {NodeID: 1, Serviceable: true, HealthScore: 1},
+		2: {NodeID: 2, Serviceable: true, HealthScore: 0.2},
+	}
+	s.Equal([]int64{1}, lo.Keys(preferHealthyNodes(nodes)))
+
+	// all the nodes are returned if none of them is healthy
+	nodes[1] = NodeInfo{NodeID: 1, Serviceable: true, HealthScore: 0.3}
+	s.Len(preferHealthyNodes(nodes), 2)
+
+	// the health scores are unknown
+	s.Len(preferHealthyNodes(map[int64]NodeInfo{1: {NodeID: 1}, 2: {NodeID: 2}}), 2)
+
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.ShardLeaderHealthThreshold.Key, "0")
+	nodes[1] = NodeInfo{NodeID: 1, Serviceable: true, HealthScore: 1}
+	s.Len(preferHealthyNodes(nodes), 2)
+	paramtable.Get().Reset(paramtable.Get().ProxyCfg.ShardLeaderHealthThreshold.Key)
+
+	// the degraded leader is skipped by the balancer
+	s.mgr.EXPECT().GetShard(mock.Anything, true, s.dbName, s.collectionName, s.collectionID, s.channels[0]).
+		Return([]NodeInfo{
+			{NodeID: 1, Serviceable: true, HealthScore: 1},
+			{NodeID: 2, Serviceable: true, HealthScore: 0.1},
+		}, nil)
+	s.lbBalancer.EXPECT().RegisterNodeInfo(mock.Anything)
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, []int64{1}, mock.Anything).Return(int64(1), nil)
+	excludeNodes := typeutil.NewUniqueSet()
+	targetNode, selectedByBalancer, err := s.lbPolicy.selectNode(context.Background(), s.lbBalancer, ChannelWorkload{
+		Db:             s.dbName,
+		CollectionName: s.collectionName,
+		CollectionID:   s.collectionID,
+		Channel:        s.channels[0],
+		Nq:             1,
+	}, &excludeNodes)
+	s.NoError(err)
+	s.True(selectedByBalancer)
+	s.Equal(int64(1), targetNode.NodeID)
+}
+
 func (s *LBPolicySuite) TestPreferredNodeHint() {
 	ctx := context.Background()
 
@@ -218,7 +218,17 @@ func parseShardLeaderList2QueryNode(shardsLeaders []*querypb.ShardLeadersList) m
 		qns := make([]NodeInfo, len(leaders.GetNodeIds()))
 
 		for j := range qns {
-			qns[j] = NodeInfo{leaders.GetNodeIds()[j], leaders.GetNodeAddrs()[j], leaders.GetServiceable()[j]}
+			qns[j] = NodeInfo{
+				NodeID:      leaders.GetNodeIds()[j],
+				Address:     leaders.GetNodeAddrs()[j],
+				Serviceable: leaders.GetServiceable()[j],
+			}
+			if j < len(leaders.GetWeights()) {
+				qns[j].Weight = leaders.GetWeights()[j]
+			}
+			if j < len(leaders.GetHealthScores()) {
+				qns[j].HealthScore = leaders.GetHealthScores()[j]
+			}
 		}
 
 		shard2QueryNodes[leaders.GetChannelName()] = qns
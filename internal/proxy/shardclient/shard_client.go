@@ -40,6 +40,10 @@ type NodeInfo struct {
 	NodeID      UniqueID
 	Address     string
 	Serviceable bool
+	// Weight is the routing weight of the replica the node serves, 0 if unknown.
+	Weight int32
+	// HealthScore is the health score of the node in [0, 1] reported by query coord, 0 if unknown.
+	HealthScore float64
 }
 
 func (n NodeInfo) String() string {
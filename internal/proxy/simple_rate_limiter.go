@@ -43,16 +43,35 @@ import (
 type SimpleLimiter struct {
 	quotaStatesMu sync.RWMutex
 	rateLimiter   *rlinternal.RateLimiterTree
+	// collection id -> alias name -> alias limiter, guarded by quotaStatesMu.
+	aliasLimiters map[int64]map[string]*rlinternal.RateLimiterNode
+	// digest of the limiter tree last set by the quota center, guarded by quotaStatesMu.
+	limiterDigest string
+	// app tag -> rates of the app tag on each collection, guarded by quotaStatesMu.
+	appTagRates map[string]*proxypb.Limiter
+	// the app tag limiters of the collections, created on demand by the tagged requests.
+	appTagLimiters *typeutil.ConcurrentMap[appTagLimiterKey, *rlinternal.RateLimiterNode]
 
 	// for alloc
 	allocWaitInterval time.Duration
 	allocRetryTimes   uint
 }
 
+type appTagLimiterKey struct {
+	collectionID int64
+	appTag       string
+}
+
 // NewSimpleLimiter returns a new SimpleLimiter.
 func NewSimpleLimiter(allocWaitInterval time.Duration, allocRetryTimes uint) *SimpleLimiter {
 	rootRateLimiter := newClusterLimiter()
-	m := &SimpleLimiter{rateLimiter: rlinternal.NewRateLimiterTree(rootRateLimiter), allocWaitInterval: allocWaitInterval, allocRetryTimes: allocRetryTimes}
+	m := &SimpleLimiter{
+		rateLimiter:       rlinternal.NewRateLimiterTree(rootRateLimiter),
+		aliasLimiters:     make(map[int64]map[string]*rlinternal.RateLimiterNode),
+		appTagLimiters:    typeutil.NewConcurrentMap[appTagLimiterKey, *rlinternal.RateLimiterNode](),
+		allocWaitInterval: allocWaitInterval,
+		allocRetryTimes:   allocRetryTimes,
+	}
 	return m
 }
 
@@ -144,13 +163,103 @@ func (m *SimpleLimiter) Check(dbID int64, collectionIDToPartIDs map[int64][]int6
 	return ret
 }
 
+// CheckAlias checks if the request through the alias of the collection would be limited by the alias rate limits,
+// it's applied on top of Check, so the more restrictive one of the alias and the collection limits wins.
+func (m *SimpleLimiter) CheckAlias(collectionID int64, alias string, rt internalpb.RateType, n int) error {
+	if !Params.QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() {
+		return nil
+	}
+	if n <= 0 {
+		return nil
+	}
+
+	m.quotaStatesMu.RLock()
+	defer m.quotaStatesMu.RUnlock()
+
+	aliasRateLimiters, ok := m.aliasLimiters[collectionID][alias]
+	if !ok {
+		return nil
+	}
+	return aliasRateLimiters.Check(rt, n)
+}
+
+// CheckAppTag checks if the request tagged with the app would be limited by the app tag rate limits on the collection,
+// it's applied on top of Check like the alias limits.
+func (m *SimpleLimiter) CheckAppTag(collectionID int64, appTag string, rt internalpb.RateType, n int) error {
+	if !Params.QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() {
+		return nil
+	}
+	if n <= 0 || appTag == "" {
+		return nil
+	}
+
+	m.quotaStatesMu.RLock()
+	defer m.quotaStatesMu.RUnlock()
+
+	rates, ok := m.appTagRates[appTag]
+	if !ok {
+		return nil
+	}
+	key := appTagLimiterKey{collectionID: collectionID, appTag: appTag}
+	node, ok := m.appTagLimiters.Get(key)
+	if !ok {
+		node = rlinternal.NewRateLimiterNode(internalpb.RateScope_Collection)
+		resetLimiterRates(node, rates)
+		node, _ = m.appTagLimiters.GetOrInsert(key, node)
+	}
+	return node.Check(rt, n)
+}
+
+// CheckQuotaStates checks if request would be denied by the quota states only, the rate limits are skipped.
+// It's used for the requests exempted from the rate limits.
+func (m *SimpleLimiter) CheckQuotaStates(dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType) error {
+	if !Params.QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() {
+		return nil
+	}
+
+	m.quotaStatesMu.RLock()
+	defer m.quotaStatesMu.RUnlock()
+
+	if err := m.rateLimiter.GetRootLimiters().CheckQuotaState(rt); err != nil {
+		return err
+	}
+	if dbID == util.InvalidDBID {
+		return nil
+	}
+	if err := m.rateLimiter.GetOrCreateDatabaseLimiters(dbID, newDatabaseLimiter).CheckQuotaState(rt); err != nil {
+		return err
+	}
+	for collectionID, partitionIDs := range collectionIDToPartIDs {
+		if collectionID == 0 {
+			continue
+		}
+		if !isNotCollectionLevelLimitRequest(rt) {
+			collectionRateLimiters := m.rateLimiter.GetOrCreateCollectionLimiters(dbID, collectionID,
+				newDatabaseLimiter, newCollectionLimiters)
+			if err := collectionRateLimiters.CheckQuotaState(rt); err != nil {
+				return err
+			}
+		}
+		for _, partID := range partitionIDs {
+			if partID == 0 {
+				continue
+			}
+			partitionRateLimiters := m.rateLimiter.GetOrCreatePartitionLimiters(dbID, collectionID, partID,
+				newDatabaseLimiter, newCollectionLimiters, newPartitionLimiters)
+			if err := partitionRateLimiters.CheckQuotaState(rt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func isNotCollectionLevelLimitRequest(rt internalpb.RateType) bool {
-	// Most ddl is global level, only DDLFlush will be applied at collection
+	// Most ddl is global level, only DDLFlush and DDLCompaction will be applied at collection
 	switch rt {
 	case internalpb.RateType_DDLCollection,
 		internalpb.RateType_DDLPartition,
-		internalpb.RateType_DDLIndex,
-		internalpb.RateType_DDLCompaction:
+		internalpb.RateType_DDLIndex:
 		return true
 	default:
 		return false
@@ -218,10 +327,19 @@ func (m *SimpleLimiter) SetRates(rootLimiter *proxypb.LimiterNode) error {
 		return err
 	}
 
+	m.updateAppTagLimiters(rootLimiter)
 	m.rateLimiter.ClearInvalidLimiterNode(rootLimiter)
+	m.limiterDigest = rlinternal.LimiterDigest(rootLimiter)
 	return nil
 }
 
+// GetLimiterDigest returns the digest of the limiter tree last set by the quota center.
+func (m *SimpleLimiter) GetLimiterDigest() string {
+	m.quotaStatesMu.RLock()
+	defer m.quotaStatesMu.RUnlock()
+	return m.limiterDigest
+}
+
 func initLimiter(source string, rln *rlinternal.RateLimiterNode, rateLimiterConfigs map[internalpb.RateType]*paramtable.ParamItem) {
 	for rt, p := range rateLimiterConfigs {
 		newLimit := ratelimitutil.Limit(p.GetAsFloat())
@@ -325,6 +443,7 @@ func (m *SimpleLimiter) updateRateLimiter(reqRootLimiterNode *proxypb.LimiterNod
 		return fmt.Sprintf("partition.%d", partitionID)
 	}
 
+	aliasLimiters := make(map[int64]map[string]*rlinternal.RateLimiterNode)
 	for dbID, reqDBRateLimiters := range reqRootLimiterNode.GetChildren() {
 		// update database rate limiters
 		dbRateLimiters := m.rateLimiter.GetOrCreateDatabaseLimiters(dbID, newDatabaseLimiter)
@@ -357,12 +476,78 @@ func (m *SimpleLimiter) updateRateLimiter(reqRootLimiterNode *proxypb.LimiterNod
 					return err
 				}
 			}
+
+			if len(reqCollectionRateLimiter.GetAliasLimiters()) > 0 {
+				aliasLimiters[collectionID] = m.updateAliasLimiters(collectionID, reqCollectionRateLimiter.GetAliasLimiters())
+			}
 		}
 	}
 
+	m.aliasLimiters = aliasLimiters
 	return nil
 }
 
+// updateAliasLimiters returns the alias limiters of the collection, the existing limiters are reused
+// to keep the consumed tokens, and only the rate types in the request are limited.
+func (m *SimpleLimiter) updateAliasLimiters(collectionID int64, reqAliasLimiters map[string]*proxypb.Limiter) map[string]*rlinternal.RateLimiterNode {
+	aliasLimiters := make(map[string]*rlinternal.RateLimiterNode, len(reqAliasLimiters))
+	for alias, reqAliasLimiter := range reqAliasLimiters {
+		node, ok := m.aliasLimiters[collectionID][alias]
+		if !ok {
+			node = rlinternal.NewRateLimiterNode(internalpb.RateScope_Collection)
+		}
+		resetLimiterRates(node, reqAliasLimiter)
+		for _, rate := range reqAliasLimiter.GetRates() {
+			setRateGaugeByRateType(rate.GetRt(), paramtable.GetNodeID(), fmt.Sprintf("collection.%d.alias.%s", collectionID, alias), rate.GetR())
+		}
+		aliasLimiters[alias] = node
+	}
+	return aliasLimiters
+}
+
+// updateAppTagLimiters updates the rates of the app tag limiters, the limiters of the removed app tags
+// and the collections not in the limiter tree any more are removed.
+func (m *SimpleLimiter) updateAppTagLimiters(reqRootLimiterNode *proxypb.LimiterNode) {
+	m.appTagRates = reqRootLimiterNode.GetAppTagLimiters()
+	collectionIDs := typeutil.NewUniqueSet()
+	for _, reqDBRateLimiters := range reqRootLimiterNode.GetChildren() {
+		for collectionID := range reqDBRateLimiters.GetChildren() {
+			collectionIDs.Insert(collectionID)
+		}
+	}
+	m.appTagLimiters.Range(func(key appTagLimiterKey, node *rlinternal.RateLimiterNode) bool {
+		rates, ok := m.appTagRates[key.appTag]
+		if !ok || !collectionIDs.Contain(key.collectionID) {
+			m.appTagLimiters.Remove(key)
+			return true
+		}
+		resetLimiterRates(node, rates)
+		return true
+	})
+	for appTag, reqAppTagLimiter := range m.appTagRates {
+		for _, rate := range reqAppTagLimiter.GetRates() {
+			setRateGaugeByRateType(rate.GetRt(), paramtable.GetNodeID(), fmt.Sprintf("app.%s", appTag), rate.GetR())
+		}
+	}
+}
+
+// resetLimiterRates limits the node with the rates of the request only, the existing limiters are reused
+// to keep the consumed tokens.
+func resetLimiterRates(node *rlinternal.RateLimiterNode, reqLimiter *proxypb.Limiter) {
+	limiters := typeutil.NewConcurrentMap[internalpb.RateType, *ratelimitutil.Limiter]()
+	for _, rate := range reqLimiter.GetRates() {
+		limit := ratelimitutil.Limit(rate.GetR())
+		limiter, ok := node.GetLimiters().Get(rate.GetRt())
+		if ok {
+			limiter.SetLimit(limit)
+		} else {
+			limiter = ratelimitutil.NewLimiter(limit, rate.GetR())
+		}
+		limiters.Insert(rate.GetRt(), limiter)
+	}
+	node.SetLimiters(limiters)
+}
+
 // setRateGaugeByRateType sets ProxyLimiterRate metrics.
 func setRateGaugeByRateType(rateType internalpb.RateType, nodeID int64, sourceID string, rate float64) {
 	if ratelimitutil.Limit(rate) == ratelimitutil.Inf {
@@ -188,8 +188,9 @@ func TestSimpleRateLimiter(t *testing.T) {
 	t.Run("test set rates", func(t *testing.T) {
 		simpleLimiter := NewSimpleLimiter(0, 0)
 		zeroRates := getZeroCollectionRates()
+		assert.Empty(t, simpleLimiter.GetLimiterDigest())
 
-		err := simpleLimiter.SetRates(newCollectionLimiterNode(map[int64]*proxypb.LimiterNode{
+		rootLimiter := newCollectionLimiterNode(map[int64]*proxypb.LimiterNode{
 			1: {
 				Limiter: &proxypb.Limiter{
 					Rates: zeroRates,
@@ -202,9 +203,11 @@ func TestSimpleRateLimiter(t *testing.T) {
 				},
 				Children: make(map[int64]*proxypb.LimiterNode),
 			},
-		}))
+		})
+		err := simpleLimiter.SetRates(rootLimiter)
 
 		assert.NoError(t, err)
+		assert.Equal(t, rlinternal.LimiterDigest(rootLimiter), simpleLimiter.GetLimiterDigest())
 	})
 
 	t.Run("test quota states", func(t *testing.T) {
@@ -229,6 +232,85 @@ func TestSimpleRateLimiter(t *testing.T) {
 		assert.Contains(t, codes, ratelimitutil.GetQuotaErrorString(commonpb.ErrorCode_DiskQuotaExhausted))
 		assert.Contains(t, codes, ratelimitutil.GetQuotaErrorString(commonpb.ErrorCode_ForceDeny))
 	})
+
+	t.Run("test alias limiters", func(t *testing.T) {
+		paramtable.Get().Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.QuotaConfig.QuotaAndLimitsEnabled.Key)
+
+		simpleLimiter := NewSimpleLimiter(0, 0)
+		setRates := func(aliasRate float64) {
+			err := simpleLimiter.SetRates(newCollectionLimiterNode(map[int64]*proxypb.LimiterNode{
+				collectionID: {
+					Limiter:  &proxypb.Limiter{},
+					Children: make(map[int64]*proxypb.LimiterNode),
+					AliasLimiters: map[string]*proxypb.Limiter{
+						"a1": {Rates: []*internalpb.Rate{{Rt: internalpb.RateType_DQLSearch, R: aliasRate}}},
+					},
+				},
+			}))
+			assert.NoError(t, err)
+		}
+		setRates(0)
+		assert.Error(t, simpleLimiter.CheckAlias(collectionID, "a1", internalpb.RateType_DQLSearch, 1))
+		// the rate types not set on the alias are not limited
+		assert.NoError(t, simpleLimiter.CheckAlias(collectionID, "a1", internalpb.RateType_DQLQuery, 1))
+		// not an alias with rate limits
+		assert.NoError(t, simpleLimiter.CheckAlias(collectionID, "a2", internalpb.RateType_DQLSearch, 1))
+
+		setRates(1000)
+		aliasLimiter := simpleLimiter.aliasLimiters[collectionID]["a1"]
+		assert.NoError(t, simpleLimiter.CheckAlias(collectionID, "a1", internalpb.RateType_DQLSearch, 1))
+		// the limiter of the alias is reused
+		setRates(1000)
+		assert.Same(t, aliasLimiter, simpleLimiter.aliasLimiters[collectionID]["a1"])
+
+		// the alias limiter is removed once it's not in the request
+		err := simpleLimiter.SetRates(newCollectionLimiterNode(map[int64]*proxypb.LimiterNode{
+			collectionID: {
+				Limiter:  &proxypb.Limiter{},
+				Children: make(map[int64]*proxypb.LimiterNode),
+			},
+		}))
+		assert.NoError(t, err)
+		assert.Empty(t, simpleLimiter.aliasLimiters)
+	})
+
+	t.Run("test app tag limiters", func(t *testing.T) {
+		paramtable.Get().Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.QuotaConfig.QuotaAndLimitsEnabled.Key)
+
+		simpleLimiter := NewSimpleLimiter(0, 0)
+		setRates := func(appTagRate float64) {
+			root := newCollectionLimiterNode(map[int64]*proxypb.LimiterNode{
+				collectionID: {
+					Limiter:  &proxypb.Limiter{},
+					Children: make(map[int64]*proxypb.LimiterNode),
+				},
+			})
+			root.AppTagLimiters = map[string]*proxypb.Limiter{
+				"etl": {Rates: []*internalpb.Rate{{Rt: internalpb.RateType_DQLSearch, R: appTagRate}}},
+			}
+			assert.NoError(t, simpleLimiter.SetRates(root))
+		}
+		setRates(0)
+		assert.Error(t, simpleLimiter.CheckAppTag(collectionID, "etl", internalpb.RateType_DQLSearch, 1))
+		// the rate types not set on the app tag are not limited
+		assert.NoError(t, simpleLimiter.CheckAppTag(collectionID, "etl", internalpb.RateType_DQLQuery, 1))
+		// not an app tag with rate limits
+		assert.NoError(t, simpleLimiter.CheckAppTag(collectionID, "dashboard", internalpb.RateType_DQLSearch, 1))
+		assert.NoError(t, simpleLimiter.CheckAppTag(collectionID, "", internalpb.RateType_DQLSearch, 1))
+
+		// the limiter of the app tag is updated in place
+		setRates(1000)
+		assert.Equal(t, 1, simpleLimiter.appTagLimiters.Len())
+		assert.NoError(t, simpleLimiter.CheckAppTag(collectionID, "etl", internalpb.RateType_DQLSearch, 1))
+
+		// the app tag limiter is removed once the collection is not in the request
+		err := simpleLimiter.SetRates(newCollectionLimiterNode(map[int64]*proxypb.LimiterNode{}))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, simpleLimiter.appTagLimiters.Len())
+		assert.NoError(t, simpleLimiter.CheckAppTag(collectionID, "etl", internalpb.RateType_DQLSearch, 1))
+	})
 }
 
 func getZeroRates() []*internalpb.Rate {
@@ -447,6 +447,58 @@ func validateCollectionTTL(props []*commonpb.KeyValuePair) (bool, error) {
 	return false, nil
 }
 
+// validatePartitionTTL checks the partition ttl properties, a non-positive ttl means the partition never expires.
+func validatePartitionTTL(props []*commonpb.KeyValuePair) error {
+	for _, pair := range props {
+		if _, ok := common.ParsePartitionTTLKey(pair.GetKey()); !ok {
+			continue
+		}
+		val, err := strconv.ParseInt(pair.GetValue(), 10, 64)
+		if err != nil {
+			return merr.WrapErrParameterInvalidMsg("%s is not a valid integer, got %s", pair.GetKey(), pair.GetValue())
+		}
+		if val < -1 || val > common.MaxTTLSeconds {
+			return merr.WrapErrParameterInvalidMsg("%s is out of range, expect [-1, %d], got %d", pair.GetKey(), common.MaxTTLSeconds, val)
+		}
+	}
+	return nil
+}
+
+func validateIdleRelease(props []*commonpb.KeyValuePair) error {
+	if _, exist := funcutil.TryGetAttrByKeyFromRepeatedKV(common.CollectionIdleReleasedLoadConfigKey, props); exist {
+		return merr.WrapErrParameterInvalidMsg("%s is reserved for the collections released for idle", common.CollectionIdleReleasedLoadConfigKey)
+	}
+	value, exist := funcutil.TryGetAttrByKeyFromRepeatedKV(common.CollectionIdleReleaseSecondsKey, props)
+	if !exist {
+		return nil
+	}
+	val, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || val < 0 {
+		return merr.WrapErrParameterInvalidMsg("%s must be a non-negative integer, got %s", common.CollectionIdleReleaseSecondsKey, value)
+	}
+	return nil
+}
+
+func validateCompactionSortedOutput(props []*commonpb.KeyValuePair) error {
+	value, exist := funcutil.TryGetAttrByKeyFromRepeatedKV(common.CollectionCompactionSortedOutputKey, props)
+	if !exist {
+		return nil
+	}
+	if _, err := strconv.ParseBool(value); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s must be a boolean, got %s", common.CollectionCompactionSortedOutputKey, value)
+	}
+	return nil
+}
+
+func validateCompactionWindow(props []*commonpb.KeyValuePair) error {
+	value, exist := funcutil.TryGetAttrByKeyFromRepeatedKV(common.CollectionCompactionWindowKey, props)
+	if !exist {
+		return nil
+	}
+	_, err := common.ParseCompactionWindows(value)
+	return err
+}
+
 func validateTTLField(props []*commonpb.KeyValuePair, fields []*schemapb.FieldSchema) (bool, error) {
 	for _, pair := range props {
 		if pair.Key == common.CollectionTTLFieldKey {
@@ -677,6 +729,22 @@ func (t *createCollectionTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if err := validateCompactionWindow(t.GetProperties()); err != nil {
+		return err
+	}
+
+	if err := validateIdleRelease(t.GetProperties()); err != nil {
+		return err
+	}
+
+	if err := validateCompactionSortedOutput(t.GetProperties()); err != nil {
+		return err
+	}
+
+	if err := validatePartitionTTL(t.GetProperties()); err != nil {
+		return err
+	}
+
 	t.Schema, err = proto.Marshal(t.schema)
 	if err != nil {
 		return err
@@ -1494,6 +1562,7 @@ func (t *dropCollectionTask) OnEnqueue() error {
 }
 
 func (t *dropCollectionTask) PreExecute(ctx context.Context) error {
+	setDeletionProtectionOverride(ctx, t.Base)
 	// No need to check collection name
 	// Validation shall be preformed in `CreateCollection`
 	// also permit drop collection one with bad collection name
@@ -2264,6 +2333,18 @@ func (t *alterCollectionTask) PreExecute(ctx context.Context) error {
 		if hasTTLField && hasTTLProp(collSchema.GetProperties()...) {
 			return merr.WrapErrParameterInvalidMsg("collection TTL is already set, cannot be set ttl field")
 		}
+		if err := validateCompactionWindow(t.GetProperties()); err != nil {
+			return err
+		}
+		if err := validateIdleRelease(t.GetProperties()); err != nil {
+			return err
+		}
+		if err := validateCompactionSortedOutput(t.GetProperties()); err != nil {
+			return err
+		}
+		if err := validatePartitionTTL(t.GetProperties()); err != nil {
+			return err
+		}
 
 		// Validate warmup policy for all warmup keys
 		if hasWarmupProp(t.Properties...) {
@@ -140,6 +140,7 @@ func (ddt *dropDatabaseTask) OnEnqueue() error {
 }
 
 func (ddt *dropDatabaseTask) PreExecute(ctx context.Context) error {
+	setDeletionProtectionOverride(ctx, ddt.Base)
 	return ValidateDatabaseName(ddt.GetDbName())
 }
 
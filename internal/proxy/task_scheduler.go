@@ -216,7 +216,7 @@ func (queue *baseTaskQueue) Enqueue(t task) error {
 			return err
 		}
 	} else {
-		ts, err = queue.tsoAllocatorIns.AllocOne(t.TraceCtx())
+		ts, err = queue.tsoAllocatorIns.AllocOne(withTimestampDBName(t.TraceCtx(), GetCurDBNameFromRequestOrContext(t.TraceCtx(), t)))
 		if err != nil {
 			return err
 		}
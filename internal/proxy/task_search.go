@@ -330,13 +330,13 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		t.Username = username
 	}
 
-	if collectionInfo.collectionTTL != 0 {
+	if ttl := getPartitionsTTL(collectionInfo.schema.GetProperties(), t.GetPartitionIDs()); ttl != 0 {
 		physicalTime := tsoutil.PhysicalTime(t.GetBase().GetTimestamp())
-		expireTime := physicalTime.Add(-time.Duration(collectionInfo.collectionTTL))
+		expireTime := physicalTime.Add(-time.Duration(ttl))
 		t.CollectionTtlTimestamps = tsoutil.ComposeTSByTime(expireTime)
 		// preventing overflow, abort
 		if t.CollectionTtlTimestamps > t.GetBase().GetTimestamp() {
-			return merr.WrapErrServiceInternalMsg("ttl timestamp overflow, base timestamp: %d, ttl duration %v", t.GetBase().GetTimestamp(), collectionInfo.collectionTTL)
+			return merr.WrapErrServiceInternalMsg("ttl timestamp overflow, base timestamp: %d, ttl duration %v", t.GetBase().GetTimestamp(), time.Duration(ttl))
 		}
 	}
 
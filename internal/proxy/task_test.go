@@ -7399,6 +7399,57 @@ func TestValidateCollectionTTL(t *testing.T) {
 	}
 }
 
+func TestValidateCompactionWindow(t *testing.T) {
+	assert.NoError(t, validateCompactionWindow(nil))
+	assert.NoError(t, validateCompactionWindow([]*commonpb.KeyValuePair{{Key: common.CollectionCompactionWindowKey, Value: "22-6,13-15"}}))
+	err := validateCompactionWindow([]*commonpb.KeyValuePair{{Key: common.CollectionCompactionWindowKey, Value: "22"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestValidateIdleRelease(t *testing.T) {
+	assert.NoError(t, validateIdleRelease(nil))
+	assert.NoError(t, validateIdleRelease([]*commonpb.KeyValuePair{{Key: common.CollectionIdleReleaseSecondsKey, Value: "3600"}}))
+	err := validateIdleRelease([]*commonpb.KeyValuePair{{Key: common.CollectionIdleReleaseSecondsKey, Value: "-1"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	err = validateIdleRelease([]*commonpb.KeyValuePair{{Key: common.CollectionIdleReleaseSecondsKey, Value: "1h"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	err = validateIdleRelease([]*commonpb.KeyValuePair{{Key: common.CollectionIdleReleasedLoadConfigKey, Value: `{"replica_number":1}`}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestValidateCompactionSortedOutput(t *testing.T) {
+	assert.NoError(t, validateCompactionSortedOutput(nil))
+	assert.NoError(t, validateCompactionSortedOutput([]*commonpb.KeyValuePair{{Key: common.CollectionCompactionSortedOutputKey, Value: "true"}}))
+	err := validateCompactionSortedOutput([]*commonpb.KeyValuePair{{Key: common.CollectionCompactionSortedOutputKey, Value: "yes"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestValidatePartitionTTL(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{"never_expire", "0", false},
+		{"disabled", "-1", false},
+		{"valid_value", "3600", false},
+		{"out_of_range", "-2", true},
+		{"invalid_format", "abc", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validatePartitionTTL([]*commonpb.KeyValuePair{{Key: common.PartitionTTLKey(100), Value: c.value}})
+			if c.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+	assert.NoError(t, validatePartitionTTL([]*commonpb.KeyValuePair{{Key: "partition.p1.ttl.seconds", Value: "abc"}}))
+}
+
 func TestHasWarmupProp(t *testing.T) {
 	t.Run("has generic warmup key", func(t *testing.T) {
 		props := []*commonpb.KeyValuePair{
@@ -30,6 +30,20 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/util/timerecord"
 )
 
+// timestampDBNameKey is the context key of the database which the timestamps are allocated for.
+type timestampDBNameKey struct{}
+
+// withTimestampDBName attaches the database to ctx,
+// rootcoord budgets the timestamp allocations of each database by it.
+func withTimestampDBName(ctx context.Context, dbName string) context.Context {
+	return context.WithValue(ctx, timestampDBNameKey{}, dbName)
+}
+
+func getTimestampDBName(ctx context.Context) string {
+	dbName, _ := ctx.Value(timestampDBNameKey{}).(string)
+	return dbName
+}
+
 // timestampAllocator implements tsoAllocator.
 type timestampAllocator struct {
 	tso    timestampAllocatorInterface
@@ -54,7 +68,8 @@ func (ta *timestampAllocator) alloc(ctx context.Context, count uint32) ([]Timest
 			commonpbutil.WithMsgType(commonpb.MsgType_RequestTSO),
 			commonpbutil.WithSourceID(ta.peerID),
 		),
-		Count: count,
+		Count:  count,
+		DbName: getTimestampDBName(ctx),
 	}
 
 	resp, err := ta.tso.AllocTimestamp(ctx, req)
@@ -1440,6 +1440,26 @@ func GetCurDBNameFromContextOrDefault(ctx context.Context) string {
 	return dbNameData[0]
 }
 
+// setDeletionProtectionOverride passes the deletion protection override in the request header to the base properties,
+// so the database or the collection is dropped regardless of the deletion protection of the database.
+func setDeletionProtectionOverride(ctx context.Context, base *commonpb.MsgBase) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return
+	}
+	values := md.Get(common.DeletionProtectionOverrideKey)
+	if len(values) < 1 {
+		return
+	}
+	if override, err := strconv.ParseBool(values[0]); err != nil || !override {
+		return
+	}
+	if base.Properties == nil {
+		base.Properties = make(map[string]string)
+	}
+	base.Properties[common.DeletionProtectionOverrideKey] = "true"
+}
+
 // GetCurDBNameFromRequestOrContext returns the database a request actually
 // operates on. It prefers the DbName carried in the request body (which is
 // what downstream handlers execute against, after DatabaseInterceptor has
@@ -3063,45 +3083,45 @@ func GetRequestInfo(ctx context.Context, req proto.Message) (int64, map[int64][]
 		return dbID, collToPartIDs, internalpb.RateType_DQLSearch, nq, err
 	case *milvuspb.QueryRequest:
 		dbID, collToPartIDs, err := getCollectionAndPartitionIDs(ctx, req.(reqPartNames))
-		return dbID, collToPartIDs, internalpb.RateType_DQLQuery, 1, err // think of the query request's nq as 1
+		return dbID, collToPartIDs, getQueryRateType(ctx, r), 1, err // think of the query request's nq as 1
 	case *milvuspb.CreateCollectionRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, getDDLTokens(r), nil
 	case *milvuspb.RefreshExternalCollectionRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, getDDLTokens(r), nil
 	case *milvuspb.RestoreExternalSnapshotRequest:
-		return getDatabaseID(r.GetDbName()), map[int64][]int64{}, internalpb.RateType_DDLCollection, 1, nil
+		return getDatabaseID(r.GetDbName()), map[int64][]int64{}, internalpb.RateType_DDLCollection, getDDLTokens(r), nil
 	case *milvuspb.ExportSnapshotRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, getDDLTokens(r), nil
 	case *milvuspb.DropCollectionRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, getDDLTokens(r), nil
 	case *milvuspb.LoadCollectionRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, getDDLTokens(r), nil
 	case *milvuspb.ReleaseCollectionRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLCollection, getDDLTokens(r), nil
 	case *milvuspb.CreatePartitionRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLPartition, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLPartition, getDDLTokens(r), nil
 	case *milvuspb.DropPartitionRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLPartition, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLPartition, getDDLTokens(r), nil
 	case *milvuspb.LoadPartitionsRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLPartition, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLPartition, getDDLTokens(r), nil
 	case *milvuspb.ReleasePartitionsRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLPartition, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLPartition, getDDLTokens(r), nil
 	case *milvuspb.CreateIndexRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLIndex, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLIndex, getDDLTokens(r), nil
 	case *milvuspb.DropIndexRequest:
 		dbID, collToPartIDs := getCollectionID(req.(reqCollName))
-		return dbID, collToPartIDs, internalpb.RateType_DDLIndex, 1, nil
+		return dbID, collToPartIDs, internalpb.RateType_DDLIndex, getDDLTokens(r), nil
 	case *milvuspb.FlushRequest:
 		db, err := globalMetaCache.GetDatabaseInfo(ctx, r.GetDbName())
 		if err != nil {
@@ -3116,7 +3136,7 @@ func GetRequestInfo(ctx context.Context, req proto.Message) (int64, map[int64][]
 			}
 			collToPartIDs[collectionID] = []int64{}
 		}
-		return db.dbID, collToPartIDs, internalpb.RateType_DDLFlush, 1, nil
+		return db.dbID, collToPartIDs, internalpb.RateType_DDLFlush, getDDLTokens(r), nil
 	case *milvuspb.ManualCompactionRequest:
 		// Use the db the request actually targets (normalized by
 		// DatabaseInterceptor), consistent with the sibling cases, so quota is
@@ -3127,7 +3147,7 @@ func GetRequestInfo(ctx context.Context, req proto.Message) (int64, map[int64][]
 		}
 		return dbInfo.dbID, map[int64][]int64{
 			r.GetCollectionID(): {},
-		}, internalpb.RateType_DDLCompaction, 1, nil
+		}, internalpb.RateType_DDLCompaction, getDDLTokens(r), nil
 	case *milvuspb.ListFileResourcesRequest:
 		// ListFileResources is a cluster-scoped read-only request. Like the
 		// other cluster list APIs, it does not consume a limiter token.
@@ -3136,15 +3156,15 @@ func GetRequestInfo(ctx context.Context, req proto.Message) (int64, map[int64][]
 		// File resources are cluster-scoped metadata used by collection
 		// analyzers. Charge the cluster collection-DDL limiter without
 		// attributing the request to an arbitrary database.
-		return util.InvalidDBID, map[int64][]int64{}, internalpb.RateType_DDLCollection, 1, nil
+		return util.InvalidDBID, map[int64][]int64{}, internalpb.RateType_DDLCollection, getDDLTokens(r), nil
 	case *milvuspb.CreateDatabaseRequest:
 		mlog.Info(context.TODO(), "rate limiter CreateDatabaseRequest")
-		return util.InvalidDBID, map[int64][]int64{}, internalpb.RateType_DDLDB, 1, nil
+		return util.InvalidDBID, map[int64][]int64{}, internalpb.RateType_DDLDB, getDDLTokens(r), nil
 	case *milvuspb.DropDatabaseRequest:
 		mlog.Info(context.TODO(), "rate limiter DropDatabaseRequest")
-		return util.InvalidDBID, map[int64][]int64{}, internalpb.RateType_DDLDB, 1, nil
+		return util.InvalidDBID, map[int64][]int64{}, internalpb.RateType_DDLDB, getDDLTokens(r), nil
 	case *milvuspb.AlterDatabaseRequest:
-		return util.InvalidDBID, map[int64][]int64{}, internalpb.RateType_DDLDB, 1, nil
+		return util.InvalidDBID, map[int64][]int64{}, internalpb.RateType_DDLDB, getDDLTokens(r), nil
 	default: // TODO: support more request
 		if req == nil {
 			return util.InvalidDBID, map[int64][]int64{}, 0, 0, merr.WrapErrParameterInvalidMsg("null request")
@@ -3154,6 +3174,16 @@ func GetRequestInfo(ctx context.Context, req proto.Message) (int64, map[int64][]
 	}
 }
 
+// getDDLTokens returns the tokens consumed by the DDL request, weighted by the payload size,
+// so that a DDL request with a heavy payload, e.g. a schema of thousands of fields, consumes more tokens.
+func getDDLTokens(req proto.Message) int {
+	unit := Params.QuotaConfig.DDLPayloadUnit.GetAsInt()
+	if unit <= 0 {
+		return 1
+	}
+	return max(1, (proto.Size(req)+unit-1)/unit)
+}
+
 // GetFailedResponse returns failed response.
 func GetFailedResponse(req any, err error) any {
 	switch req.(type) {
@@ -3228,13 +3258,13 @@ func GetMinHashFunctionOutputFields(collSchema *schemapb.CollectionSchema) []str
 	return fields
 }
 
-// getCollectionTTL returns ttl if collection's ttl is specified
-// or return global ttl if collection's ttl is not specified
-// this is a helper util wrapping common.GetCollectionTTL without returning error
-func getCollectionTTL(pairs []*commonpb.KeyValuePair) uint64 {
-	ttl, err := common.GetCollectionTTL(pairs)
+// getPartitionsTTL returns the ttl of the data in the given partitions, the partition ttl overrides the collection ttl,
+// all the partitions are considered if no partition is given, 0 is returned if the data never expires.
+// this is a helper util wrapping common.GetPartitionsTTLFromMap without returning error
+func getPartitionsTTL(pairs []*commonpb.KeyValuePair, partitionIDs []int64) uint64 {
+	ttl, err := common.GetPartitionsTTLFromMap(funcutil.KeyValuePair2Map(pairs), partitionIDs...)
 	if err != nil {
-		mlog.Error(context.TODO(), "failed to get collection ttl, use default ttl", mlog.Err(err))
+		mlog.Error(context.TODO(), "failed to get partition ttl, use default ttl", mlog.Err(err))
 	}
 	if ttl < 0 {
 		return 0
@@ -6889,3 +6889,55 @@ func TestFailMetricLabel(t *testing.T) {
 	assertLabels(metrics.CauseCancel, context.Canceled)
 	assertLabels(metrics.CauseCancel, errors.Wrap(context.Canceled, "rpc aborted"))
 }
+
+func TestSetDeletionProtectionOverride(t *testing.T) {
+	base := &commonpb.MsgBase{}
+	setDeletionProtectionOverride(context.Background(), base)
+	assert.Empty(t, base.GetProperties())
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.DeletionProtectionOverrideKey, "false"))
+	setDeletionProtectionOverride(ctx, base)
+	assert.Empty(t, base.GetProperties())
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.DeletionProtectionOverrideKey, "true"))
+	setDeletionProtectionOverride(ctx, base)
+	assert.Equal(t, "true", base.GetProperties()[common.DeletionProtectionOverrideKey])
+}
+
+func TestCheckCollectionBlockedForWrite(t *testing.T) {
+	oldCache := globalMetaCache
+	defer func() { globalMetaCache = oldCache }()
+	ctx := context.Background()
+	cache := NewMockCache(t)
+	globalMetaCache = cache
+
+	cache.EXPECT().GetCollectionSchema(mock.Anything, "db", "coll").Return(&schemaInfo{
+		CollectionSchema: &schemapb.CollectionSchema{Name: "coll"},
+	}, nil).Once()
+	assert.NoError(t, checkCollectionBlockedForWrite(ctx, "db", "coll", "insert"))
+
+	cache.EXPECT().GetCollectionSchema(mock.Anything, "db", "coll").Return(&schemaInfo{
+		CollectionSchema: &schemapb.CollectionSchema{
+			Name:       "coll",
+			Properties: []*commonpb.KeyValuePair{{Key: common.CollectionRecycledKey, Value: "true"}},
+		},
+	}, nil).Once()
+	assert.ErrorIs(t, checkCollectionBlockedForWrite(ctx, "db", "coll", "insert"), merr.ErrCollectionNotFound)
+}
+
+func TestGetPartitionsTTL(t *testing.T) {
+	pairs := []*commonpb.KeyValuePair{
+		{Key: common.CollectionTTLConfigKey, Value: "10"},
+		{Key: common.PartitionTTLKey(1), Value: "20"},
+	}
+	assert.Equal(t, uint64(10*time.Second), getPartitionsTTL(pairs, []int64{2}))
+	assert.Equal(t, uint64(20*time.Second), getPartitionsTTL(pairs, []int64{1, 2}))
+	assert.Equal(t, uint64(20*time.Second), getPartitionsTTL(pairs, nil))
+
+	// the data in a partition without ttl never expires
+	pairs = append(pairs, &commonpb.KeyValuePair{Key: common.PartitionTTLKey(3), Value: "0"})
+	assert.Equal(t, uint64(20*time.Second), getPartitionsTTL(pairs, []int64{1}))
+	assert.Equal(t, uint64(0), getPartitionsTTL(pairs, []int64{1, 3}))
+	assert.Equal(t, uint64(0), getPartitionsTTL(pairs, nil))
+	assert.Equal(t, uint64(0), getPartitionsTTL(nil, nil))
+}
@@ -73,7 +73,7 @@ type benefitEvaluator interface {
 // ============================================================================
 
 // commonSegmentNodeFilter is a reusable node filter for segment assignment
-// It filters out nodes that are not in normal state
+// It filters out nodes that are not in normal state, and the nodes whose loading queue is full
 type commonSegmentNodeFilter struct {
 	nodeManager *session.NodeManager
 }
@@ -83,14 +83,15 @@ func newCommonSegmentNodeFilter(nodeManager *session.NodeManager) nodeFilter {
 	return &commonSegmentNodeFilter{nodeManager: nodeManager}
 }
 
-// FilterNodes filters the input nodes and returns nodes in normal state
+// FilterNodes filters the input nodes and returns nodes in normal state with room in the loading queue
 func (f *commonSegmentNodeFilter) FilterNodes(ctx context.Context, nodes []int64, forceAssign bool) []int64 {
 	if forceAssign {
 		return nodes
 	}
 	return lo.Filter(nodes, func(node int64, _ int) bool {
 		info := f.nodeManager.Get(node)
-		return info != nil && info.GetState() == session.NodeStateNormal && !f.nodeManager.IsResourceExhausted(node)
+		return info != nil && info.GetState() == session.NodeStateNormal && !f.nodeManager.IsResourceExhausted(node) &&
+			!f.nodeManager.IsLoadingQueueFull(node)
 	})
 }
 
@@ -126,6 +126,48 @@ func TestRowCountBasedAssignPolicy_AssignSegment_MultipleSegments(t *testing.T)
 	assert.Equal(t, 2, nodeCount[2], "Node 2 should get 2 segments")
 }
 
+// TestRowCountBasedAssignPolicy_AssignSegment_SkipFullLoadingQueue tests segments skip the node whose loading queue is full
+func TestRowCountBasedAssignPolicy_AssignSegment_SkipFullLoadingQueue(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.LoadingQueueDepthLimit.Key, "8")
+	defer paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.LoadingQueueDepthLimit.Key)
+
+	nodeManager := session.NewNodeManager()
+	mockScheduler := task.NewMockScheduler(t)
+	mockScheduler.EXPECT().GetSegmentTaskDeltaSnapshot(mock.Anything, mock.Anything).Return(task.NewSegmentTaskDeltaSnapshot(nil, nil)).Maybe()
+	dist := meta.NewDistributionManager(nodeManager)
+
+	for i := int64(1); i <= 2; i++ {
+		nodeManager.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   i,
+			Version:  common.Version,
+			Address:  "localhost",
+			Hostname: "node",
+		}))
+		nodeManager.Get(i).SetState(session.NodeStateNormal)
+	}
+	// node 1 has more rows, node 2 has no rows but a full loading queue
+	dist.SegmentDistManager.Update(1, &meta.Segment{SegmentInfo: &datapb.SegmentInfo{ID: 100, NumOfRows: 10000}})
+	nodeManager.Get(2).UpdateStats(session.WithLoadingSegmentNum(8))
+
+	policy := newRowCountBasedAssignPolicy(nodeManager, mockScheduler, dist)
+	segments := []*meta.Segment{
+		{SegmentInfo: &datapb.SegmentInfo{ID: 1, NumOfRows: 1000}},
+	}
+	plans := policy.AssignSegment(context.Background(), 100, segments, []int64{1, 2}, false)
+	assert.Equal(t, 1, len(plans))
+	assert.Equal(t, int64(1), plans[0].To)
+
+	// all the loading queues are full
+	nodeManager.Get(1).UpdateStats(session.WithLoadingSegmentNum(10))
+	plans = policy.AssignSegment(context.Background(), 100, segments, []int64{1, 2}, false)
+	assert.Empty(t, plans)
+
+	// the force assignment ignores the loading queues
+	plans = policy.AssignSegment(context.Background(), 100, segments, []int64{1, 2}, true)
+	assert.Equal(t, 1, len(plans))
+}
+
 func TestRowCountBasedAssignPolicy_AssignSegment_BatchLimitOnlyForBalance(t *testing.T) {
 	paramtable.Init()
 	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.BalanceSegmentBatchSize.Key, "2")
@@ -288,7 +288,33 @@ func (c *ChannelChecker) createChannelLoadTask(ctx context.Context, channels []*
 		plans[i].Replica = replica
 	}
 
-	return balance.CreateChannelTasksFromPlans(ctx, c.ID(), Params.QueryCoordCfg.ChannelTaskTimeout.GetAsDuration(time.Millisecond), plans)
+	tasks := balance.CreateChannelTasksFromPlans(ctx, c.ID(), Params.QueryCoordCfg.ChannelTaskTimeout.GetAsDuration(time.Millisecond), plans)
+	critical := common.IsCollectionCritical(c.meta.GetCollectionSchema(ctx, replica.GetCollectionID()).GetProperties()...)
+	for _, t := range tasks {
+		t.SetImportance(c.getChannelImportance(t.Shard(), critical))
+	}
+	return tasks
+}
+
+// criticalChannelImportance is added to the importance of the channels of critical collections,
+// it's large enough to order them before any channel of the other collections.
+const criticalChannelImportance int64 = 1 << 48
+
+// getChannelImportance returns the importance of recovering the channel.
+// The number of growing rows reported by the delegators of other replicas is used as an
+// estimation of the recent write rate of the channel, channels of critical collections always come first.
+func (c *ChannelChecker) getChannelImportance(channel string, critical bool) int64 {
+	var importance int64
+	for _, ch := range c.dist.ChannelDistManager.GetByFilter(meta.WithChannelName2Channel(channel)) {
+		if ch.View != nil && ch.View.NumOfGrowingRows > importance {
+			importance = ch.View.NumOfGrowingRows
+		}
+	}
+	importance = min(importance, criticalChannelImportance-1)
+	if critical {
+		importance += criticalChannelImportance
+	}
+	return importance
 }
 
 func (c *ChannelChecker) createChannelReduceTasks(ctx context.Context, channels []*meta.DmChannel, replica *meta.Replica) []task.Task {
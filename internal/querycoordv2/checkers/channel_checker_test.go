@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/internal/coordinator/snmanager"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/metastore/kv/querycoord"
@@ -33,6 +35,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/task"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/kv"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
@@ -145,6 +148,57 @@ func (suite *ChannelCheckerTestSuite) TestLoadChannel() {
 	suite.EqualValues("test-insert-channel", action.ChannelName())
 }
 
+func (suite *ChannelCheckerTestSuite) TestLoadChannelImportance() {
+	ctx := context.Background()
+	checker := suite.checker
+	collection := utils.CreateTestCollection(1, 2)
+	collection.Schema = &schemapb.CollectionSchema{
+		Properties: []*commonpb.KeyValuePair{{Key: common.CollectionCriticalKey, Value: "true"}},
+	}
+	checker.meta.PutCollection(ctx, collection)
+	suite.meta.PutPartition(ctx, utils.CreateTestPartition(1, 1))
+	checker.meta.Put(ctx, utils.CreateTestReplica(1, 1, []int64{1}))
+	checker.meta.Put(ctx, utils.CreateTestReplica(2, 1, []int64{2}))
+	suite.setNodeAvailable(1, 2)
+	checker.meta.HandleNodeUp(ctx, 1)
+	checker.meta.HandleNodeUp(ctx, 2)
+
+	channels := []*datapb.VchannelInfo{
+		{
+			CollectionID: 1,
+			ChannelName:  "test-insert-channel",
+		},
+	}
+	suite.broker.EXPECT().GetRecoveryInfoV2(mock.Anything, int64(1)).Return(
+		channels, nil, nil)
+	checker.targetMgr.UpdateCollectionNextTarget(ctx, int64(1))
+
+	// the channel is still served by replica 2.
+	checker.dist.ChannelDistManager.Update(2, &meta.DmChannel{
+		VchannelInfo: &datapb.VchannelInfo{
+			CollectionID: 1,
+			ChannelName:  "test-insert-channel",
+		},
+		Node:    2,
+		Version: 1,
+		View: &meta.LeaderView{
+			ID:               2,
+			Channel:          "test-insert-channel",
+			NumOfGrowingRows: 100,
+			Status:           &querypb.LeaderViewStatus{Serviceable: true},
+		},
+	})
+
+	tasks := checker.Check(context.TODO())
+	suite.Len(tasks, 1)
+	suite.EqualValues(1, tasks[0].ReplicaID())
+	suite.Equal(criticalChannelImportance+100, tasks[0].Importance())
+
+	suite.Equal(int64(100), checker.getChannelImportance("test-insert-channel", false))
+	suite.Equal(int64(0), checker.getChannelImportance("unknown-channel", false))
+	suite.Equal(criticalChannelImportance, checker.getChannelImportance("unknown-channel", true))
+}
+
 func (suite *ChannelCheckerTestSuite) TestReduceChannel() {
 	ctx := context.Background()
 	checker := suite.checker
@@ -128,6 +128,7 @@ func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collec
 	idSegmentsStats := make(map[int64]*meta.Segment)
 	targetsStats := make(map[int64][]int64) // segmentID => FieldID
 	segmentsToUpdate := make(map[int64]*meta.Segment)
+	fieldsToUpdate := make(map[int64][]int64) // segmentID => FieldID
 	for _, segment := range segments {
 		// skip update index in read only node
 		if roNodeSet.Contain(segment.Node) {
@@ -163,13 +164,14 @@ func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collec
 					fieldIndexInfo.GetEnableIndex() &&
 					len(fieldIndexInfo.GetIndexFilePaths()) > 0 {
 					segmentsToUpdate[segmentID] = idSegments[segmentID]
+					fieldsToUpdate[segmentID] = append(fieldsToUpdate[segmentID], fieldIndexInfo.GetFieldID())
 				}
 			}
 		}
 	}
 
 	tasks = lo.FilterMap(lo.Values(segmentsToUpdate), func(segment *meta.Segment, _ int) (task.Task, bool) {
-		return c.createSegmentUpdateTask(ctx, segment, replica)
+		return c.createSegmentUpdateTask(ctx, segment, replica, fieldsToUpdate[segment.GetID()])
 	})
 
 	segmentsStatsToUpdate := typeutil.NewSet[int64]()
@@ -232,8 +234,11 @@ func (c *IndexChecker) checkRedundantIndices(segment *meta.Segment, indexInfos [
 	return redundant
 }
 
-func (c *IndexChecker) createSegmentUpdateTask(ctx context.Context, segment *meta.Segment, replica *meta.Replica) (task.Task, bool) {
+// createSegmentUpdateTask creates a reopen task for the segment, fieldIDs are the fields with new index to load,
+// which are used to attribute the failure of the task.
+func (c *IndexChecker) createSegmentUpdateTask(ctx context.Context, segment *meta.Segment, replica *meta.Replica, fieldIDs []int64) (task.Task, bool) {
 	action := task.NewSegmentActionWithScope(segment.Node, task.ActionTypeReopen, segment.GetInsertChannel(), segment.GetID(), querypb.DataScope_Historical, int(segment.GetNumOfRows()))
+	action.FieldIDs = fieldIDs
 	t, err := task.NewSegmentTask(
 		ctx,
 		params.Params.QueryCoordCfg.SegmentTaskTimeout.GetAsDuration(time.Millisecond),
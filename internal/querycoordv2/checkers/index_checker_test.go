@@ -155,6 +155,7 @@ func (suite *IndexCheckerSuite) TestLoadIndex() {
 	suite.EqualValues(200, t.ReplicaID())
 	suite.Equal(task.ActionTypeReopen, action.Type())
 	suite.EqualValues(2, action.GetSegmentID())
+	suite.Equal([]int64{101}, action.GetFieldIDs())
 
 	// test skip load index for read only node
 	suite.nodeMgr.Stopping(1)
@@ -220,6 +220,7 @@ func (c *SegmentChecker) checkReplica(ctx context.Context, replica *meta.Replica
 	// compare with targets to find the lack and redundancy of segments
 	lacks, loadPriorities, redundancies, toUpdate := c.getSealedSegmentDiff(ctx, replica.GetCollectionID(), replica, replicaSegmentDist)
 	tasks := c.createSegmentLoadTasks(c.getTraceCtx(ctx, replica.GetCollectionID()), lacks, loadPriorities, replica)
+	tasks = c.throttleWarmupTasks(ctx, replica, tasks)
 	task.SetReason("lacks of segment", tasks...)
 	task.SetPriority(task.TaskPriorityNormal, tasks...)
 	ret = append(ret, tasks...)
@@ -530,6 +531,49 @@ func (c *SegmentChecker) createSegmentLoadTasks(ctx context.Context, segments []
 	return balance.CreateSegmentTasksFromPlans(ctx, c.ID(), Params.QueryCoordCfg.SegmentTaskTimeout.GetAsDuration(time.Millisecond), plans)
 }
 
+// throttleWarmupTasks limits the segment load tasks of a warming up replica by the concurrent loads of the replica
+// and of each of its nodes, so the warm-up doesn't saturate the object storage and degrade the serving replicas.
+// The throttled segments are left to the next check.
+func (c *SegmentChecker) throttleWarmupTasks(ctx context.Context, replica *meta.Replica, tasks []task.Task) []task.Task {
+	maxPerReplica := Params.QueryCoordCfg.ReplicaWarmupMaxSegmentsPerReplica.GetAsInt()
+	maxPerNode := Params.QueryCoordCfg.ReplicaWarmupMaxSegmentsPerNode.GetAsInt()
+	if len(tasks) == 0 || (maxPerReplica <= 0 && maxPerNode <= 0) ||
+		!utils.IsReplicaWarmingUp(ctx, c.meta, c.targetMgr, c.dist, replica) {
+		return tasks
+	}
+
+	replicaFilter := task.WithReplicaID2TaskFilter(replica.GetID())
+	growFilter := task.WithTaskTypeFilter(task.TaskTypeGrow)
+	replicaLoading := c.scheduler.GetSegmentTaskNum(replicaFilter, growFilter)
+	nodeLoading := make(map[int64]int)
+	ret := make([]task.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if maxPerReplica > 0 && replicaLoading >= maxPerReplica {
+			break
+		}
+		if maxPerNode > 0 {
+			node := t.Actions()[0].Node()
+			if _, ok := nodeLoading[node]; !ok {
+				nodeLoading[node] = c.scheduler.GetSegmentTaskNum(replicaFilter, growFilter, task.WithNodeID2TaskFilter(node))
+			}
+			if nodeLoading[node] >= maxPerNode {
+				continue
+			}
+			nodeLoading[node]++
+		}
+		replicaLoading++
+		ret = append(ret, t)
+	}
+	if throttled := len(tasks) - len(ret); throttled > 0 {
+		mlog.RatedInfo(ctx, rate.Limit(10), "segment loads of warming up replica are throttled",
+			mlog.FieldCollectionID(replica.GetCollectionID()),
+			mlog.Int64("replicaID", replica.GetID()),
+			mlog.Int("throttled", throttled),
+			mlog.Int("loading", replicaLoading))
+	}
+	return ret
+}
+
 func (c *SegmentChecker) createSegmentReopenTasks(ctx context.Context, segments []*meta.Segment, replica *meta.Replica) []task.Task {
 	ret := make([]task.Task, 0, len(segments))
 	for _, s := range segments {
@@ -1344,6 +1344,80 @@ func (suite *SegmentCheckerTestSuite) TestReopenOnStaleDataVersion() {
 	suite.Len(addedTasks, 0)
 }
 
+func (suite *SegmentCheckerTestSuite) TestThrottleWarmupTasks() {
+	ctx := context.Background()
+	checker := suite.checker
+	paramtable.Get().Save(Params.QueryCoordCfg.ReplicaWarmupMaxSegmentsPerReplica.Key, "2")
+	defer paramtable.Get().Reset(Params.QueryCoordCfg.ReplicaWarmupMaxSegmentsPerReplica.Key)
+
+	checker.meta.PutCollection(ctx, utils.CreateTestCollection(1, 2))
+	checker.meta.PutPartition(ctx, utils.CreateTestPartition(1, 1))
+	serving := utils.CreateTestReplica(1, 1, []int64{1})
+	warming := utils.CreateTestReplica(2, 1, []int64{2, 3})
+	checker.meta.Put(ctx, serving, warming)
+	for _, nodeID := range []int64{1, 2, 3} {
+		suite.nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   nodeID,
+			Address:  "localhost",
+			Hostname: "localhost",
+		}))
+		checker.meta.HandleNodeUp(ctx, nodeID)
+	}
+
+	segments := []*datapb.SegmentInfo{
+		{ID: 1, PartitionID: 1, InsertChannel: "test-insert-channel"},
+		{ID: 2, PartitionID: 1, InsertChannel: "test-insert-channel"},
+		{ID: 3, PartitionID: 1, InsertChannel: "test-insert-channel"},
+		{ID: 4, PartitionID: 1, InsertChannel: "test-insert-channel"},
+	}
+	channels := []*datapb.VchannelInfo{{CollectionID: 1, ChannelName: "test-insert-channel"}}
+	suite.broker.EXPECT().GetRecoveryInfoV2(mock.Anything, int64(1)).Return(channels, segments, nil)
+	checker.targetMgr.UpdateCollectionNextTarget(ctx, int64(1))
+	checker.targetMgr.UpdateCollectionCurrentTarget(ctx, int64(1))
+
+	for _, nodeID := range []int64{1, 2} {
+		checker.dist.ChannelDistManager.Update(nodeID, &meta.DmChannel{
+			VchannelInfo: &datapb.VchannelInfo{CollectionID: 1, ChannelName: "test-insert-channel"},
+			Node:         nodeID,
+			Version:      1,
+			View: &meta.LeaderView{
+				ID: nodeID, CollectionID: 1, Channel: "test-insert-channel", Version: 1,
+				Status: &querypb.LeaderViewStatus{Serviceable: nodeID == 1},
+			},
+		})
+	}
+	checker.dist.SegmentDistManager.Update(1,
+		utils.CreateTestSegment(1, 1, 1, 1, 1, "test-insert-channel"),
+		utils.CreateTestSegment(1, 1, 2, 1, 1, "test-insert-channel"),
+		utils.CreateTestSegment(1, 1, 3, 1, 1, "test-insert-channel"),
+		utils.CreateTestSegment(1, 1, 4, 1, 1, "test-insert-channel"),
+	)
+	suite.True(utils.IsReplicaServiceable(ctx, checker.targetMgr, checker.dist, serving))
+	suite.False(utils.IsReplicaWarmingUp(ctx, checker.meta, checker.targetMgr, checker.dist, serving))
+	suite.True(utils.IsReplicaWarmingUp(ctx, checker.meta, checker.targetMgr, checker.dist, warming))
+
+	// one load of the warming up replica is in flight
+	suite.scheduler.EXPECT().GetSegmentTaskNum(mock.Anything, mock.Anything).Return(1).Once()
+	tasks := checker.checkReplica(ctx, warming)
+	suite.Len(tasks, 1)
+
+	paramtable.Get().Save(Params.QueryCoordCfg.ReplicaWarmupMaxSegmentsPerReplica.Key, "0")
+	paramtable.Get().Save(Params.QueryCoordCfg.ReplicaWarmupMaxSegmentsPerNode.Key, "1")
+	defer paramtable.Get().Reset(Params.QueryCoordCfg.ReplicaWarmupMaxSegmentsPerNode.Key)
+	suite.scheduler.EXPECT().GetSegmentTaskNum(mock.Anything, mock.Anything).Return(0).Once()
+	suite.scheduler.EXPECT().GetSegmentTaskNum(mock.Anything, mock.Anything, mock.Anything).Return(0).Twice()
+	tasks = checker.checkReplica(ctx, warming)
+	suite.Len(tasks, 2)
+	suite.ElementsMatch([]int64{2, 3}, lo.Map(tasks, func(t task.Task, _ int) int64 {
+		return t.Actions()[0].Node()
+	}))
+
+	// the serving replica is not throttled
+	checker.dist.SegmentDistManager.Update(1)
+	tasks = checker.checkReplica(ctx, serving)
+	suite.Len(tasks, 4)
+}
+
 func TestSegmentCheckerSuite(t *testing.T) {
 	suite.Run(t, new(SegmentCheckerTestSuite))
 }
@@ -0,0 +1,183 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// segmentCopy is a loaded copy of a sealed segment in a replica, with the info probed from its node.
+type segmentCopy struct {
+	replica *meta.Replica
+	segment *meta.Segment
+	info    *querypb.SegmentInfo
+}
+
+// consistencyProbeLoop compares the loaded segments across the replicas periodically,
+// see probeCollectionConsistency for details.
+func (s *Server) consistencyProbeLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(Params.QueryCoordCfg.ConsistencyProbeInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			mlog.Info(s.ctx, "consistency probe loop exit")
+			return
+		case <-ticker.C:
+		}
+		if !Params.QueryCoordCfg.ConsistencyProbeEnabled.GetAsBool() {
+			continue
+		}
+		for _, collectionID := range s.meta.GetAll(s.ctx) {
+			diverged := s.probeCollectionConsistency(s.ctx, collectionID)
+			if len(diverged) > 0 && Params.QueryCoordCfg.ConsistencyProbeAutoReload.GetAsBool() {
+				s.reloadDivergedSegments(collectionID, diverged)
+			}
+		}
+	}
+}
+
+// probeCollectionConsistency fetches the valid row count of the loaded sealed segments from each replica of the collection,
+// and compares the copies of each segment to find the diverged ones, which are likely caused by stale loads or missed deletes.
+// The diverged copies are returned grouped by replica ID.
+func (s *Server) probeCollectionConsistency(ctx context.Context, collectionID int64) map[int64][]*meta.Segment {
+	replicas := s.meta.GetByCollection(ctx, collectionID)
+	if len(replicas) < 2 {
+		return nil
+	}
+
+	copies := make(map[int64][]segmentCopy)
+	for _, replica := range replicas {
+		nodeSegments := lo.GroupBy(
+			s.dist.SegmentDistManager.GetByFilter(meta.WithCollectionID(collectionID), meta.WithReplica(replica)),
+			func(segment *meta.Segment) int64 { return segment.Node },
+		)
+		for nodeID, segments := range nodeSegments {
+			resp, err := s.cluster.GetSegmentInfo(ctx, nodeID, &querypb.GetSegmentInfoRequest{
+				CollectionID:  collectionID,
+				SegmentIDs:    lo.Map(segments, func(segment *meta.Segment, _ int) int64 { return segment.GetID() }),
+				WithValidRows: true,
+			})
+			if err = merr.CheckRPCCall(resp, err); err != nil {
+				mlog.Warn(ctx, "failed to probe segments of node",
+					mlog.Int64("collectionID", collectionID),
+					mlog.Int64("replicaID", replica.GetID()),
+					mlog.Int64("node", nodeID),
+					mlog.Err(err))
+				continue
+			}
+			infos := lo.KeyBy(resp.GetInfos(), func(info *querypb.SegmentInfo) int64 { return info.GetSegmentID() })
+			for _, segment := range segments {
+				if info, ok := infos[segment.GetID()]; ok {
+					copies[segment.GetID()] = append(copies[segment.GetID()], segmentCopy{replica: replica, segment: segment, info: info})
+				}
+			}
+		}
+	}
+
+	diverged := make(map[int64][]*meta.Segment)
+	total := 0
+	for segmentID, segmentCopies := range copies {
+		expected, divergedCopies := findDivergedCopies(segmentCopies)
+		for _, c := range divergedCopies {
+			mlog.Warn(ctx, "segment copy diverged from the other replicas",
+				mlog.Int64("collectionID", collectionID),
+				mlog.Int64("segmentID", segmentID),
+				mlog.Int64("replicaID", c.replica.GetID()),
+				mlog.Int64("node", c.segment.Node),
+				mlog.Int64("validRows", c.info.GetValidRows()),
+				mlog.Int64("expectedRows", expected))
+			diverged[c.replica.GetID()] = append(diverged[c.replica.GetID()], c.segment)
+			total++
+		}
+	}
+	metrics.QueryCoordDivergentSegmentNum.WithLabelValues(fmt.Sprint(collectionID)).Set(float64(total))
+	return diverged
+}
+
+// findDivergedCopies returns the expected valid row count of the segment and the copies not matching it.
+// The copies are compared only if they have applied the deletes up to the same timestamp, otherwise the
+// difference may be caused by the deletes still being consumed. The expected count is the one shared by
+// most copies, the smaller one wins the tie as a missed delete leaves more rows.
+// The copies whose data is not loaded report an unknown (negative) count and are ignored.
+func findDivergedCopies(copies []segmentCopy) (int64, []segmentCopy) {
+	copies = lo.Filter(copies, func(c segmentCopy, _ int) bool {
+		return c.info.GetValidRows() >= 0
+	})
+	if len(copies) < 2 {
+		return 0, nil
+	}
+	deltaTs := copies[0].info.GetLastDeltaTimestamp()
+	votes := make(map[int64]int)
+	for _, c := range copies {
+		if c.info.GetLastDeltaTimestamp() != deltaTs {
+			return 0, nil
+		}
+		votes[c.info.GetValidRows()]++
+	}
+	if len(votes) == 1 {
+		return copies[0].info.GetValidRows(), nil
+	}
+
+	var expected int64
+	maxVotes := 0
+	for rows, n := range votes {
+		if n > maxVotes || (n == maxVotes && rows < expected) {
+			expected, maxVotes = rows, n
+		}
+	}
+	return expected, lo.Filter(copies, func(c segmentCopy, _ int) bool {
+		return c.info.GetValidRows() != expected
+	})
+}
+
+// reloadDivergedSegments reopens the diverged segment copies on their nodes in background,
+// it's skipped if the segments of the collection are being reloaded.
+func (s *Server) reloadDivergedSegments(collectionID int64, diverged map[int64][]*meta.Segment) {
+	if !s.reloadingCollections.Insert(collectionID) {
+		mlog.Info(s.ctx, "skip reloading diverged segments as the collection is being reloaded", mlog.Int64("collectionID", collectionID))
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.reloadingCollections.Remove(collectionID)
+		for replicaID, segments := range diverged {
+			replica := s.meta.Get(s.ctx, replicaID)
+			if replica == nil || s.ctx.Err() != nil {
+				continue
+			}
+			mlog.Info(s.ctx, "reload diverged segments",
+				mlog.Int64("collectionID", collectionID),
+				mlog.Int64("replicaID", replicaID),
+				mlog.Int64s("segmentIDs", lo.Map(segments, func(segment *meta.Segment, _ int) int64 { return segment.GetID() })))
+			s.reloadReplicaSegments(replica, segments)
+		}
+	}()
+}
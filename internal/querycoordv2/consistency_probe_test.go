@@ -0,0 +1,127 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func (suite *ServiceSuite) TestProbeCollectionConsistency() {
+	suite.loadAll()
+	ctx := context.Background()
+	server := suite.server
+	collection := suite.collections[1]
+	replicas := suite.meta.GetByCollection(ctx, collection)
+	suite.Require().Greater(len(replicas), 1)
+	divergedNode := replicas[0].GetNodes()[0]
+	for _, replica := range replicas {
+		suite.updateSegmentDist(collection, replica.GetNodes()[0])
+	}
+	segments := suite.getAllSegments(collection)
+
+	suite.cluster.EXPECT().GetSegmentInfo(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, nodeID int64, req *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error) {
+			suite.True(req.GetWithValidRows())
+			suite.ElementsMatch(segments, req.GetSegmentIDs())
+			rows := int64(100)
+			if nodeID == divergedNode {
+				rows = 110
+			}
+			return &querypb.GetSegmentInfoResponse{
+				Status: merr.Success(),
+				Infos: lo.Map(req.GetSegmentIDs(), func(segmentID int64, _ int) *querypb.SegmentInfo {
+					return &querypb.SegmentInfo{SegmentID: segmentID, ValidRows: rows, LastDeltaTimestamp: 1000}
+				}),
+			}, nil
+		})
+
+	diverged := server.probeCollectionConsistency(ctx, collection)
+	suite.Len(diverged, 1)
+	suite.Len(diverged[replicas[0].GetID()], len(segments))
+
+	added := atomic.NewInt32(0)
+	suite.taskScheduler.EXPECT().Add(mock.Anything).Run(func(t task.Task) {
+		suite.Equal(utils.SegmentReload, t.Source())
+		suite.Equal(task.ActionTypeReopen, t.Actions()[0].Type())
+		suite.Equal(divergedNode, t.Actions()[0].Node())
+		added.Inc()
+		t.Cancel(nil)
+	}).Return(nil).Times(len(segments))
+	server.reloadDivergedSegments(collection, diverged)
+	server.wg.Wait()
+	suite.EqualValues(len(segments), added.Load())
+	suite.False(server.reloadingCollections.Contain(collection))
+}
+
+func TestFindDivergedCopies(t *testing.T) {
+	newCopies := func(rows []int64, deltaTs []uint64) []segmentCopy {
+		return lo.Map(rows, func(row int64, i int) segmentCopy {
+			return segmentCopy{info: &querypb.SegmentInfo{ValidRows: row, LastDeltaTimestamp: deltaTs[i]}}
+		})
+	}
+
+	t.Run("consistent", func(t *testing.T) {
+		expected, diverged := findDivergedCopies(newCopies([]int64{10, 10}, []uint64{1, 1}))
+		assert.EqualValues(t, 10, expected)
+		assert.Empty(t, diverged)
+	})
+
+	t.Run("majority", func(t *testing.T) {
+		expected, diverged := findDivergedCopies(newCopies([]int64{10, 12, 10}, []uint64{1, 1, 1}))
+		assert.EqualValues(t, 10, expected)
+		assert.Len(t, diverged, 1)
+		assert.EqualValues(t, 12, diverged[0].info.GetValidRows())
+	})
+
+	t.Run("tie", func(t *testing.T) {
+		expected, diverged := findDivergedCopies(newCopies([]int64{12, 10}, []uint64{1, 1}))
+		assert.EqualValues(t, 10, expected)
+		assert.Len(t, diverged, 1)
+		assert.EqualValues(t, 12, diverged[0].info.GetValidRows())
+	})
+
+	t.Run("deletes_in_flight", func(t *testing.T) {
+		_, diverged := findDivergedCopies(newCopies([]int64{10, 12}, []uint64{2, 1}))
+		assert.Empty(t, diverged)
+	})
+
+	t.Run("not_loaded", func(t *testing.T) {
+		_, diverged := findDivergedCopies(newCopies([]int64{10, -1}, []uint64{1, 1}))
+		assert.Empty(t, diverged)
+
+		expected, diverged := findDivergedCopies(newCopies([]int64{10, -1, 12}, []uint64{1, 0, 1}))
+		assert.EqualValues(t, 10, expected)
+		assert.Len(t, diverged, 1)
+		assert.EqualValues(t, 12, diverged[0].info.GetValidRows())
+	})
+
+	t.Run("single_copy", func(t *testing.T) {
+		_, diverged := findDivergedCopies(newCopies([]int64{10}, []uint64{1}))
+		assert.Empty(t, diverged)
+	})
+}
@@ -0,0 +1,72 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// NodeLoadedRows is the number of the rows of the sealed segments of a collection loaded on a query node.
+type NodeLoadedRows struct {
+	ReplicaID   int64 `json:"replica_id"`
+	NodeID      int64 `json:"node_id"`
+	NumRows     int64 `json:"num_rows"`
+	NumSegments int   `json:"num_segments"`
+}
+
+// GetLoadedRowsByNode returns the loaded rows of the collection on each read-write node of its replicas,
+// the nodes loading nothing are reported with zero rows.
+func (s *Server) GetLoadedRowsByNode(ctx context.Context, collectionID int64) ([]*NodeLoadedRows, error) {
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		return nil, err
+	}
+	if s.meta.GetCollection(ctx, collectionID) == nil {
+		return nil, merr.WrapErrCollectionNotLoaded(collectionID)
+	}
+
+	result := make([]*NodeLoadedRows, 0)
+	for _, replica := range s.meta.GetByCollection(ctx, collectionID) {
+		nodes := make(map[int64]*NodeLoadedRows)
+		for _, nodeID := range replica.GetRWNodes() {
+			nodes[nodeID] = &NodeLoadedRows{ReplicaID: replica.GetID(), NodeID: nodeID}
+		}
+		segments := s.dist.SegmentDistManager.GetByFilter(meta.WithCollectionID(collectionID), meta.WithReplica(replica))
+		for _, segment := range segments {
+			rows, ok := nodes[segment.Node]
+			if !ok {
+				rows = &NodeLoadedRows{ReplicaID: replica.GetID(), NodeID: segment.Node}
+				nodes[segment.Node] = rows
+			}
+			rows.NumRows += segment.GetNumOfRows()
+			rows.NumSegments++
+		}
+		for _, rows := range nodes {
+			result = append(result, rows)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ReplicaID != result[j].ReplicaID {
+			return result[i].ReplicaID < result[j].ReplicaID
+		}
+		return result[i].NodeID < result[j].NodeID
+	})
+	return result, nil
+}
@@ -0,0 +1,116 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/job"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// AlterReplicaNumber changes the replica number of a loaded collection online, without release and reload.
+// The new replicas are spawned and the redundant ones are removed by the load collection job of the broadcasted
+// load config, the segments are then balanced onto the new replicas by the checkers.
+// If resourceGroups is empty, the resource group currently used by the collection is kept.
+func (s *Server) AlterReplicaNumber(ctx context.Context, collectionID int64, replicaNumber int32, resourceGroups []string) error {
+	logger := mlog.With(
+		mlog.Int64("collectionID", collectionID),
+		mlog.Int32("replicaNumber", replicaNumber),
+		mlog.Strings("resourceGroups", resourceGroups),
+	)
+	logger.Info(ctx, "alter replica number request received")
+
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		logger.Warn(ctx, "failed to alter replica number", mlog.Err(err))
+		return err
+	}
+	if err := s.broadcastAlterLoadConfigCollectionV2ForAlterReplicaNumber(ctx, collectionID, replicaNumber, resourceGroups); err != nil {
+		logger.Warn(ctx, "failed to alter replica number", mlog.Err(err))
+		return err
+	}
+	logger.Info(ctx, "alter replica number done")
+	return nil
+}
+
+// broadcastAlterLoadConfigCollectionV2ForAlterReplicaNumber broadcasts the alter load config message with the new replica number,
+// the partitions, load fields and indexes of the collection are kept as is.
+func (s *Server) broadcastAlterLoadConfigCollectionV2ForAlterReplicaNumber(ctx context.Context, collectionID int64, replicaNumber int32, resourceGroups []string) error {
+	if replicaNumber <= 0 {
+		return merr.WrapErrParameterInvalid("replica number > 0", fmt.Sprintf("invalid replica number %d", replicaNumber))
+	}
+
+	broadcaster, err := s.startBroadcastWithCollectionIDLock(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	defer broadcaster.Close()
+
+	coll, err := s.broker.DescribeCollection(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+
+	currentLoadConfig := s.getCurrentLoadConfig(ctx, collectionID)
+	if currentLoadConfig.Collection == nil {
+		return merr.WrapErrCollectionNotLoaded(coll.GetCollectionName())
+	}
+	if len(resourceGroups) == 0 {
+		currentRGs := lo.Keys(currentLoadConfig.GetReplicaNumber())
+		if len(currentRGs) != 1 {
+			return merr.WrapErrParameterInvalidMsg("collection %s has replicas in %d resource groups, resource groups must be specified to alter the replica number",
+				coll.GetCollectionName(), len(currentRGs))
+		}
+		resourceGroups = currentRGs
+	}
+
+	// only check node number when the replica number increases.
+	expectedReplicasNumber, err := utils.AssignReplica(ctx, s.meta, resourceGroups, replicaNumber, int(replicaNumber) > len(currentLoadConfig.Replicas))
+	if err != nil {
+		return err
+	}
+	alterLoadConfigReq := &job.AlterLoadConfigRequest{
+		Meta:           s.meta,
+		CollectionInfo: coll,
+		Current:        currentLoadConfig,
+		Expected: job.ExpectedLoadConfig{
+			ExpectedPartitionIDs:  currentLoadConfig.GetPartitionIDs(),
+			ExpectedReplicaNumber: expectedReplicasNumber,
+			ExpectedFieldIndexID:  currentLoadConfig.GetFieldIndexID(),
+			ExpectedLoadFields:    currentLoadConfig.GetLoadFields(),
+			ExpectedPriority:      currentLoadConfig.GetLoadPriority(),
+			// the replica number is set explicitly, so the cluster level load config won't override it.
+			ExpectedUserSpecifiedReplicaMode: true,
+		},
+	}
+	msg, err := job.GenerateAlterLoadConfigMessage(ctx, alterLoadConfigReq)
+	if err != nil {
+		return err
+	}
+	if msg == nil {
+		mlog.Info(ctx, "alter replica number ignored, load config is unchanged",
+			mlog.Int64("collectionID", collectionID))
+		return nil
+	}
+	_, err = broadcaster.Broadcast(ctx, msg)
+	return err
+}
@@ -85,6 +85,10 @@ func (s *Server) broadcastAlterLoadConfigCollectionV2ForLoadCollection(ctx conte
 			mlog.Int64("collectionID", req.GetCollectionID()))
 		return nil
 	}
+	// reject the load before any replica or partition meta is created if the resource groups can't hold it.
+	if err := s.checkLoadCapacity(ctx, coll.GetSchema(), req.GetCollectionID(), partitionIDs, req.GetLoadFields(), expectedReplicasNumber); err != nil {
+		return err
+	}
 	_, err = broadcaster.Broadcast(ctx, msg)
 	return err
 }
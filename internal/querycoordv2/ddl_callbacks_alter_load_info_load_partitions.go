@@ -87,6 +87,10 @@ func (s *Server) broadcastAlterLoadConfigCollectionV2ForLoadPartitions(ctx conte
 			mlog.Int64s("partitionIDs", req.GetPartitionIDs()))
 		return nil
 	}
+	// reject the load before any replica or partition meta is created if the resource groups can't hold it.
+	if err := s.checkLoadCapacity(ctx, coll.GetSchema(), req.GetCollectionID(), req.GetPartitionIDs(), req.GetLoadFields(), expectedReplicasNumber); err != nil {
+		return err
+	}
 	_, err = broadcaster.Broadcast(ctx, msg)
 	return err
 }
@@ -0,0 +1,69 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/job"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+)
+
+// broadcastAlterLoadConfigCollectionV2ForRepairPartitions is called by the partition observer
+// to load the partitions in partitionIDs only, other fields of the load config are kept as is.
+func (s *Server) broadcastAlterLoadConfigCollectionV2ForRepairPartitions(ctx context.Context, collectionID int64, partitionIDs []int64) error {
+	broadcaster, err := s.startBroadcastWithCollectionIDLock(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	defer broadcaster.Close()
+
+	coll, err := s.broker.DescribeCollection(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+
+	currentLoadConfig := s.getCurrentLoadConfig(ctx, collectionID)
+	if currentLoadConfig.Collection == nil {
+		// the collection is released concurrently, nothing to repair.
+		return nil
+	}
+	alterLoadConfigReq := &job.AlterLoadConfigRequest{
+		Meta:           s.meta,
+		CollectionInfo: coll,
+		Current:        currentLoadConfig,
+		Expected: job.ExpectedLoadConfig{
+			ExpectedPartitionIDs:             partitionIDs,
+			ExpectedReplicaNumber:            currentLoadConfig.GetReplicaNumber(),
+			ExpectedFieldIndexID:             currentLoadConfig.GetFieldIndexID(),
+			ExpectedLoadFields:               currentLoadConfig.GetLoadFields(),
+			ExpectedPriority:                 currentLoadConfig.GetLoadPriority(),
+			ExpectedUserSpecifiedReplicaMode: currentLoadConfig.GetUserSpecifiedReplicaMode(),
+		},
+	}
+	msg, err := job.GenerateAlterLoadConfigMessage(ctx, alterLoadConfigReq)
+	if err != nil {
+		return err
+	}
+	if msg == nil {
+		mlog.Info(ctx, "repair partitions ignored, load config is unchanged",
+			mlog.Int64("collectionID", collectionID))
+		return nil
+	}
+	_, err = broadcaster.Broadcast(ctx, msg)
+	return err
+}
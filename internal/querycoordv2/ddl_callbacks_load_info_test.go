@@ -869,6 +869,48 @@ func (suite *ServiceSuite) TestLoadPartitionUpdateUserSpecifiedReplicaMode() {
 	suite.True(updatedCollection.GetUserSpecifiedReplicaMode())
 }
 
+func (suite *ServiceSuite) TestDDLCallbacksAlterReplicaNumber() {
+	ctx := context.Background()
+	suite.expectGetRecoverInfoForAllCollections()
+
+	for _, collection := range suite.collections {
+		if suite.loadTypes[collection] != querypb.LoadType_LoadCollection {
+			continue
+		}
+		// the collection is not loaded yet.
+		err := suite.server.AlterReplicaNumber(ctx, collection, 2, nil)
+		suite.ErrorIs(err, merr.ErrCollectionNotLoaded)
+
+		resp, err := suite.server.LoadCollection(ctx, &querypb.LoadCollectionRequest{
+			CollectionID:  collection,
+			ReplicaNumber: 1,
+		})
+		suite.Require().NoError(merr.CheckRPCCall(resp, err))
+		suite.targetMgr.UpdateCollectionCurrentTarget(ctx, collection)
+		partitions := suite.meta.GetPartitionsByCollection(ctx, collection)
+
+		// scale up without release.
+		suite.NoError(suite.server.AlterReplicaNumber(ctx, collection, 2, nil))
+		suite.EqualValues(2, suite.meta.GetReplicaNumber(ctx, collection))
+		suite.Len(suite.meta.GetByCollection(ctx, collection), 2)
+		suite.Len(suite.meta.GetPartitionsByCollection(ctx, collection), len(partitions))
+		suite.True(suite.meta.GetCollection(ctx, collection).GetUserSpecifiedReplicaMode())
+
+		// scale down.
+		suite.NoError(suite.server.AlterReplicaNumber(ctx, collection, 1, nil))
+		suite.EqualValues(1, suite.meta.GetReplicaNumber(ctx, collection))
+		suite.Len(suite.meta.GetByCollection(ctx, collection), 1)
+
+		// unchanged.
+		suite.NoError(suite.server.AlterReplicaNumber(ctx, collection, 1, nil))
+
+		err = suite.server.AlterReplicaNumber(ctx, collection, 0, nil)
+		suite.ErrorIs(err, merr.ErrParameterInvalid)
+		err = suite.server.AlterReplicaNumber(ctx, collection, int32(len(suite.nodes)+1), nil)
+		suite.ErrorIs(err, merr.ErrResourceGroupNodeNotEnough)
+	}
+}
+
 func (suite *ServiceSuite) TestSyncNewCreatedPartition() {
 	newPartition := int64(999)
 	ctx := context.Background()
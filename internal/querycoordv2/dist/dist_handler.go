@@ -152,6 +152,9 @@ func (dh *distHandler) pullDist(ctx context.Context, failures *int) {
 		mlog.RatedWarn(ctx, rate.Limit(30.0), "failed to get data distribution", fields...)
 	} else {
 		*failures = 0
+		if node := dh.nodeManager.Get(dh.nodeID); node != nil {
+			node.UpdateStats(session.WithHeartbeatLatency(d1))
+		}
 		dh.handleDistResp(ctx, resp)
 	}
 	mlog.RatedInfo(ctx, rate.Limit(120.0), "pull and handle distribution done",
@@ -171,6 +174,8 @@ func (dh *distHandler) handleDistResp(ctx context.Context, resp *querypb.GetData
 	now := time.Now()
 	node.SetLastHeartbeat(now)
 	metrics.QueryCoordLastHeartbeatTimeStamp.WithLabelValues(fmt.Sprint(resp.GetNodeID())).Set(float64(now.UnixNano()))
+	dh.dist.SegmentAccessStats.Add(resp.GetSegmentAccessCounts())
+	node.UpdateStats(session.WithLoadingSegmentNum(resp.GetLoadingSegmentNum()))
 
 	// skip  update dist if no distribution change happens in query node
 	if resp.GetLastModifyTs() != 0 && resp.GetLastModifyTs() <= dh.lastUpdateTs {
@@ -232,9 +237,24 @@ func (dh *distHandler) updateSegmentsDistribution(ctx context.Context, resp *que
 
 	if resp.GetIsDelta() {
 		dh.dist.SegmentDistManager.Patch(resp.GetNodeID(), updates, resp.GetRemovedSegmentIds())
+		dh.pruneSegmentAccessStats(resp.GetRemovedSegmentIds())
 		return
 	}
+	previous := dh.dist.SegmentDistManager.GetByFilter(meta.WithNodeID(resp.GetNodeID()))
 	dh.dist.SegmentDistManager.Update(resp.GetNodeID(), updates...)
+	current := typeutil.NewUniqueSet(lo.Map(updates, func(segment *meta.Segment, _ int) int64 { return segment.GetID() })...)
+	dh.pruneSegmentAccessStats(lo.FilterMap(previous, func(segment *meta.Segment, _ int) (int64, bool) {
+		return segment.GetID(), !current.Contain(segment.GetID())
+	}))
+}
+
+// pruneSegmentAccessStats removes the read counts of the segments released from the node,
+// unless the segments are still loaded on the other nodes.
+func (dh *distHandler) pruneSegmentAccessStats(segmentIDs []int64) {
+	released := lo.Filter(segmentIDs, func(segmentID int64, _ int) bool {
+		return len(dh.dist.SegmentDistManager.GetByFilter(meta.WithSegmentID(segmentID))) == 0
+	})
+	dh.dist.SegmentAccessStats.Remove(released...)
 }
 
 func (dh *distHandler) updateChannelsDistribution(ctx context.Context, resp *querypb.GetDataDistributionResponse) {
@@ -395,8 +415,10 @@ func (dh *distHandler) stop() {
 		dh.wg.Wait()
 
 		// clear dist
+		previous := dh.dist.SegmentDistManager.GetByFilter(meta.WithNodeID(dh.nodeID))
 		dh.dist.ChannelDistManager.Update(dh.nodeID)
 		dh.dist.SegmentDistManager.Update(dh.nodeID)
+		dh.pruneSegmentAccessStats(lo.Map(previous, func(segment *meta.Segment, _ int) int64 { return segment.GetID() }))
 	})
 }
 
@@ -114,13 +114,15 @@ func (suite *DistHandlerSuite) TestBasic() {
 				TargetVersion: 1011,
 			},
 		},
-		LastModifyTs: 1,
+		LastModifyTs:      1,
+		LoadingSegmentNum: 3,
 	}, nil)
 
 	suite.handler = newDistHandler(suite.ctx, suite.nodeID, suite.client, suite.nodeManager, suite.scheduler, suite.dist, suite.target, func(collectionID ...int64) {})
 	defer suite.handler.stop()
 
 	time.Sleep(3 * time.Second)
+	suite.EqualValues(3, suite.nodeManager.Get(1).LoadingSegmentNum())
 }
 
 func (suite *DistHandlerSuite) TestGetDistributionFailed() {
@@ -387,6 +389,7 @@ func TestDeltaDistributionPatch(t *testing.T) {
 		Segments: []*querypb.SegmentVersionInfo{
 			{ID: 1, Collection: 10, Partition: 100, Channel: channel, Version: 1},
 		},
+		SegmentAccessCounts: map[int64]int64{1: 5},
 		Channels: []*querypb.ChannelVersionInfo{
 			{Channel: channel, Collection: 10, Version: 1},
 			{Channel: releasedChannel, Collection: 11, Version: 1},
@@ -430,6 +433,7 @@ func TestDeltaDistributionPatch(t *testing.T) {
 		Segments: []*querypb.SegmentVersionInfo{
 			{ID: 2, Collection: 10, Partition: 100, Channel: channel, Version: 2},
 		},
+		SegmentAccessCounts: map[int64]int64{2: 3},
 		Channels: []*querypb.ChannelVersionInfo{
 			{Channel: channel, Collection: 10, Version: 1},
 		},
@@ -508,6 +512,8 @@ func TestDeltaDistributionPatch(t *testing.T) {
 	segments = dist.SegmentDistManager.GetByFilter(meta.WithNodeID(nodeID))
 	assert.Len(t, segments, 1)
 	assert.Equal(t, int64(2), segments[0].GetID())
+	// the read counts of the released segment are pruned.
+	assert.Equal(t, map[int64]int64{1: 0, 2: 3}, dist.SegmentAccessStats.Get(1, 2))
 	patchedChannel = findChannel(channel)
 	assert.Len(t, patchedChannel.View.Segments, 1)
 	assert.NotContains(t, patchedChannel.View.Segments, int64(1))
@@ -526,6 +532,14 @@ func TestDeltaDistributionPatch(t *testing.T) {
 	channels = dist.ChannelDistManager.GetByFilter(meta.WithNodeID2Channel(nodeID))
 	assert.Len(t, channels, 1)
 	assert.Equal(t, channel, channels[0].GetChannelName())
+
+	handler.handleDistResp(ctx, &querypb.GetDataDistributionResponse{
+		Status:       merr.Success(),
+		NodeID:       nodeID,
+		LastModifyTs: 6,
+	})
+	assert.Empty(t, dist.SegmentDistManager.GetByFilter(meta.WithNodeID(nodeID)))
+	assert.Equal(t, map[int64]int64{2: 0}, dist.SegmentAccessStats.Get(2))
 }
 
 func TestDeltaDistributionPatchNotifiesNewServiceableChannel(t *testing.T) {
@@ -19,6 +19,7 @@ package job
 import (
 	"context"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -26,6 +27,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/checkers"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/observers"
@@ -41,6 +43,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/retry"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
@@ -58,6 +61,20 @@ type LoadCollectionJob struct {
 	checkerController  *checkers.CheckerController
 	nodeMgr            *session.NodeManager
 	proxyManager       proxyutil.ProxyClientManagerInterface
+
+	// the states passed between the steps
+	collInfo            *milvuspb.DescribeCollectionResponse
+	replicas            []*messagespb.LoadReplicaConfig
+	existedReplicas     typeutil.UniqueSet
+	incomingPartitions  typeutil.Set[int64]
+	toReleasePartitions []int64
+	loadCtx             context.Context
+	loadSpan            trace.Span
+
+	// releaseOnly is true if the request only releases some loaded partitions, and shrinkReady is closed once
+	// the target shrunk by the released partitions becomes current.
+	releaseOnly bool
+	shrinkReady chan struct{}
 }
 
 func NewLoadCollectionJob(
@@ -73,10 +90,18 @@ func NewLoadCollectionJob(
 	nodeMgr *session.NodeManager,
 	proxyManager proxyutil.ProxyClientManagerInterface,
 ) *LoadCollectionJob {
+	// use the broadcast id as the msg id, so the rollback report of the job can be retrieved by it.
+	msgID := int64(0)
+	if broadcastHeader := result.Message.BroadcastHeader(); broadcastHeader != nil {
+		msgID = int64(broadcastHeader.BroadcastID)
+	}
+	undo := NewUndoList(ctx, meta, targetMgr, targetObserver)
+	undo.MsgID = msgID
+	undo.CollectionID = result.Message.Header().GetCollectionId()
 	return &LoadCollectionJob{
-		BaseJob:            NewBaseJob(ctx, 0, result.Message.Header().GetCollectionId()),
+		BaseJob:            NewBaseJob(ctx, msgID, result.Message.Header().GetCollectionId()),
 		result:             result,
-		undo:               NewUndoList(ctx, meta, targetMgr, targetObserver),
+		undo:               undo,
 		dist:               dist,
 		meta:               meta,
 		broker:             broker,
@@ -89,48 +114,90 @@ func NewLoadCollectionJob(
 	}
 }
 
+// Execute runs the load steps, see RunSteps for the retry of the steps.
 func (job *LoadCollectionJob) Execute() error {
+	return job.RunSteps(
+		Step{Name: "DescribeCollection", Run: job.describeCollection},
+		Step{Name: "ResolveReplicaConfig", Run: job.resolveReplicaConfig},
+		Step{Name: "SpawnReplicas", Run: job.spawnReplicas},
+		Step{Name: "PutCollection", Run: job.putCollection},
+		Step{Name: "UpdateNextTarget", Run: job.updateNextTarget},
+		Step{Name: "WaitPartitionsReleased", Run: job.waitPartitionsReleased},
+	)
+}
+
+func (job *LoadCollectionJob) describeCollection(ctx context.Context) error {
 	req := job.result.Message.Header()
 	meta.GlobalFailedLoadCache.Remove(req.GetCollectionId())
 
-	collInfo, err := job.broker.DescribeCollection(job.ctx, req.GetCollectionId())
+	collInfo, err := job.broker.DescribeCollection(ctx, req.GetCollectionId())
 	if errors.Is(err, merr.ErrCollectionNotFound) {
-		return nil
+		return errSkipRemainingSteps
 	}
 	if err != nil {
 		return err
 	}
+	job.collInfo = collInfo
+	return nil
+}
 
-	// 1. resolve replica config: use local cluster-level config if this is a replicated message
-	replicas := req.GetReplicas()
+// resolveReplicaConfig uses local cluster-level config if this is a replicated message.
+func (job *LoadCollectionJob) resolveReplicaConfig(ctx context.Context) error {
+	req := job.result.Message.Header()
+	job.replicas = req.GetReplicas()
 	if req.GetUseLocalReplicaConfig() {
-		localReplicas, err := getLocalReplicaConfig(job.ctx, job.meta, req.GetCollectionId())
+		localReplicas, err := getLocalReplicaConfig(ctx, job.meta, req.GetCollectionId())
 		if err != nil {
 			return err
 		}
-		replicas = localReplicas
+		job.replicas = localReplicas
 		mlog.Info(context.TODO(), "using local cluster-level replica config for replicated load",
 			mlog.Int("localReplicaCount", len(localReplicas)))
 	}
+	return nil
+}
 
-	// 2. create replica if not exist (may also remove redundant replicas)
-	if _, err := utils.SpawnReplicasWithReplicaConfig(job.ctx, job.meta, meta.SpawnWithReplicaConfigParams{
+// spawnReplicas creates replica if not exist (may also remove redundant replicas).
+func (job *LoadCollectionJob) spawnReplicas(ctx context.Context) error {
+	req := job.result.Message.Header()
+	// record the existed replicas at the first attempt, so the replicas created by a failed attempt are still rolled back
+	if job.existedReplicas == nil {
+		job.undo.IsNewCollection = !job.meta.CollectionManager.Exist(ctx, req.GetCollectionId())
+		job.existedReplicas = typeutil.NewUniqueSet()
+		for _, replica := range job.meta.GetByCollection(ctx, req.GetCollectionId()) {
+			job.existedReplicas.Insert(replica.GetID())
+		}
+	}
+	spawnedReplicas, err := utils.SpawnReplicasWithReplicaConfig(ctx, job.meta, meta.SpawnWithReplicaConfigParams{
 		CollectionID: req.GetCollectionId(),
-		Channels:     collInfo.GetVirtualChannelNames(),
-		Configs:      replicas,
-	}); err != nil {
+		Channels:     job.collInfo.GetVirtualChannelNames(),
+		Configs:      job.replicas,
+	})
+	if err != nil {
 		return err
 	}
+	job.undo.CreatedReplicas = job.undo.CreatedReplicas[:0]
+	for _, replica := range spawnedReplicas {
+		if !job.existedReplicas.Contain(replica.GetID()) {
+			job.undo.CreatedReplicas = append(job.undo.CreatedReplicas, replica.GetID())
+		}
+	}
+	job.undo.IsReplicaCreated = len(job.undo.CreatedReplicas) > 0
 
-	// 2.1 invalidate shard leader cache after replica changes, so proxies stop
+	// invalidate shard leader cache after replica changes, so proxies stop
 	// routing to released replicas' shard leaders before async cleanup happens.
 	if job.proxyManager != nil {
-		job.proxyManager.InvalidateShardLeaderCache(job.ctx, &proxypb.InvalidateShardLeaderCacheRequest{
+		job.proxyManager.InvalidateShardLeaderCache(ctx, &proxypb.InvalidateShardLeaderCacheRequest{
 			CollectionIDs: []int64{req.GetCollectionId()},
 		})
 	}
+	return nil
+}
 
-	// 3. put load info meta
+// putCollection puts load info meta, and releases the partitions not in the request.
+// The created replicas are rolled back if failed to store the meta, so the failure is not retried.
+func (job *LoadCollectionJob) putCollection(ctx context.Context) error {
+	req := job.result.Message.Header()
 	fieldIndexIDs := make(map[int64]int64, len(req.GetLoadFields()))
 	fieldIDs := make([]int64, 0, len(req.GetLoadFields()))
 	for _, loadField := range req.GetLoadFields() {
@@ -139,7 +206,7 @@ func (job *LoadCollectionJob) Execute() error {
 		}
 		fieldIDs = append(fieldIDs, loadField.GetFieldId())
 	}
-	replicaNumber := int32(len(replicas))
+	replicaNumber := int32(len(job.replicas))
 	partitions := lo.Map(req.GetPartitionIds(), func(partID int64, _ int) *meta.Partition {
 		return &meta.Partition{
 			PartitionLoadInfo: &querypb.PartitionLoadInfo{
@@ -153,7 +220,32 @@ func (job *LoadCollectionJob) Execute() error {
 		}
 	})
 
-	ctx, sp := otel.Tracer(typeutil.QueryCoordRole).Start(job.ctx, "LoadCollection", trace.WithNewRoot())
+	job.incomingPartitions = typeutil.NewSet(req.GetPartitionIds()...)
+	currentPartitions := job.meta.GetPartitionsByCollection(ctx, req.GetCollectionId())
+	toReleasePartitions := make([]int64, 0)
+	for _, partition := range currentPartitions {
+		if !job.incomingPartitions.Contain(partition.GetPartitionID()) {
+			toReleasePartitions = append(toReleasePartitions, partition.GetPartitionID())
+		}
+	}
+	if len(toReleasePartitions) > 0 && job.isReleaseOnly(ctx, replicaNumber, fieldIndexIDs, fieldIDs, currentPartitions) {
+		// the loaded partitions keep serving with the shrunk target, instead of being reloaded with a rebuilt target
+		job.shrinkReady = job.targetObserver.ShrinkPartition(req.GetCollectionId(), toReleasePartitions...)
+		if err := job.meta.RemovePartition(ctx, req.GetCollectionId(), toReleasePartitions...); err != nil {
+			return merr.Wrap(err, "failed to remove partitions")
+		}
+		job.toReleasePartitions = append(job.toReleasePartitions, toReleasePartitions...)
+		job.releaseOnly = true
+		mlog.Info(context.TODO(), "release partitions with shrunk target done",
+			mlog.Int64("collectionID", req.GetCollectionId()),
+			mlog.Int64s("toReleasePartitions", job.toReleasePartitions),
+		)
+		return nil
+	}
+
+	if job.loadCtx == nil {
+		job.loadCtx, job.loadSpan = otel.Tracer(typeutil.QueryCoordRole).Start(job.ctx, "LoadCollection", trace.WithNewRoot())
+	}
 	collection := &meta.Collection{
 		CollectionLoadInfo: &querypb.CollectionLoadInfo{
 			CollectionID:             req.GetCollectionId(),
@@ -166,28 +258,27 @@ func (job *LoadCollectionJob) Execute() error {
 			UserSpecifiedReplicaMode: req.GetUserSpecifiedReplicaMode(),
 		},
 		CreatedAt: time.Now(),
-		LoadSpan:  sp,
-		Schema:    collInfo.GetSchema(),
-	}
-	incomingPartitions := typeutil.NewSet(req.GetPartitionIds()...)
-	currentPartitions := job.meta.GetPartitionsByCollection(job.ctx, req.GetCollectionId())
-	toReleasePartitions := make([]int64, 0)
-	for _, partition := range currentPartitions {
-		if !incomingPartitions.Contain(partition.GetPartitionID()) {
-			toReleasePartitions = append(toReleasePartitions, partition.GetPartitionID())
-		}
+		LoadSpan:  job.loadSpan,
+		Schema:    job.collInfo.GetSchema(),
 	}
 	if len(toReleasePartitions) > 0 {
 		job.targetObserver.ReleasePartition(req.GetCollectionId(), toReleasePartitions...)
-		if err := job.meta.RemovePartition(job.ctx, req.GetCollectionId(), toReleasePartitions...); err != nil {
+		if err := job.meta.RemovePartition(ctx, req.GetCollectionId(), toReleasePartitions...); err != nil {
 			return merr.Wrap(err, "failed to remove partitions")
 		}
+		job.toReleasePartitions = append(job.toReleasePartitions, toReleasePartitions...)
 	}
 
-	if err = job.meta.PutCollection(job.ctx, collection, partitions...); err != nil {
+	if err := job.meta.PutCollection(ctx, collection, partitions...); err != nil {
 		msg := "failed to store collection and partitions"
-		mlog.Warn(job.ctx, msg, mlog.Err(err))
-		return merr.Wrapf(err, "%s", msg)
+		mlog.Warn(ctx, msg, mlog.Err(err))
+		job.undo.LackPartitions = lo.Filter(req.GetPartitionIds(), func(partID int64, _ int) bool {
+			return !lo.ContainsBy(currentPartitions, func(partition *meta.Partition) bool {
+				return partition.GetPartitionID() == partID
+			})
+		})
+		job.undo.RollBack()
+		return retry.Unrecoverable(merr.Wrapf(err, "%s", msg))
 	}
 	eventlog.Record(eventlog.NewRawEvt(eventlog.Level_Info, fmt.Sprintf("Start load collection %d", collection.CollectionID)))
 	metrics.QueryCoordNumPartitions.WithLabelValues().Add(float64(len(partitions)))
@@ -195,31 +286,70 @@ func (job *LoadCollectionJob) Execute() error {
 	mlog.Info(context.TODO(), "put collection and partitions done",
 		mlog.Int64("collectionID", req.GetCollectionId()),
 		mlog.Int64s("partitions", req.GetPartitionIds()),
-		mlog.Int64s("toReleasePartitions", toReleasePartitions),
+		mlog.Int64s("toReleasePartitions", job.toReleasePartitions),
 	)
+	return nil
+}
 
-	// 5. update next target, no need to rollback if pull target failed, target observer will pull target in periodically
-	if _, err = job.targetObserver.UpdateNextTarget(req.GetCollectionId()); err != nil {
-		return err
+// isReleaseOnly returns whether the request only releases some partitions of the loaded collection,
+// the other partitions are loaded and the load config of the collection is not changed.
+func (job *LoadCollectionJob) isReleaseOnly(ctx context.Context, replicaNumber int32, fieldIndexIDs map[int64]int64, fieldIDs []int64, currentPartitions []*meta.Partition) bool {
+	collection := job.meta.GetCollection(ctx, job.result.Message.Header().GetCollectionId())
+	if collection == nil || collection.GetStatus() != querypb.LoadStatus_Loaded || job.undo.IsReplicaCreated {
+		return false
+	}
+	if collection.GetReplicaNumber() != replicaNumber ||
+		!maps.Equal(collection.GetFieldIndexID(), fieldIndexIDs) ||
+		!lo.ElementsMatch(collection.GetLoadFields(), fieldIDs) {
+		return false
+	}
+	loadedPartitions := typeutil.NewSet[int64]()
+	for _, partition := range currentPartitions {
+		if partition.GetStatus() == querypb.LoadStatus_Loaded {
+			loadedPartitions.Insert(partition.GetPartitionID())
+		}
 	}
+	return job.incomingPartitions.Len() > 0 && loadedPartitions.Contain(job.incomingPartitions.Collect()...)
+}
 
-	// 6. register load task into collection observer
-	job.collectionObserver.LoadPartitions(ctx, req.GetCollectionId(), incomingPartitions.Collect())
+// updateNextTarget updates next target and registers load task into collection observer,
+// no need to rollback if pull target failed, target observer will pull target in periodically.
+func (job *LoadCollectionJob) updateNextTarget(ctx context.Context) error {
+	if job.releaseOnly {
+		// the shrunk target is promoted by the target observer, no need to pull a new one
+		return nil
+	}
+	collectionID := job.result.Message.Header().GetCollectionId()
+	if _, err := job.targetObserver.UpdateNextTarget(collectionID); err != nil {
+		return err
+	}
+	job.collectionObserver.LoadPartitions(job.loadCtx, collectionID, job.incomingPartitions.Collect())
+	return nil
+}
 
-	// 7. wait for partition released if any partition is released
-	if len(toReleasePartitions) > 0 {
-		if err = WaitCurrentTargetUpdated(ctx, job.targetObserver, req.GetCollectionId()); err != nil {
-			mlog.Warn(context.TODO(), "failed to wait current target updated", mlog.Err(err))
-			// return nil to avoid infinite retry on DDL callback
-			return nil
-		}
-		if err = WaitCollectionReleased(ctx, job.dist, job.checkerController, req.GetCollectionId(), toReleasePartitions...); err != nil {
-			mlog.Warn(context.TODO(), "failed to wait partition released", mlog.Err(err))
-			// return nil to avoid infinite retry on DDL callback
+// waitPartitionsReleased waits for partition released if any partition is released,
+// the failure is not returned to avoid infinite retry on DDL callback.
+func (job *LoadCollectionJob) waitPartitionsReleased(ctx context.Context) error {
+	if len(job.toReleasePartitions) == 0 {
+		return nil
+	}
+	collectionID := job.result.Message.Header().GetCollectionId()
+	waitCtx := job.loadCtx
+	if job.releaseOnly {
+		waitCtx = job.ctx
+		if err := WaitShrunkTargetUpdated(waitCtx, job.targetObserver, collectionID, job.shrinkReady); err != nil {
+			mlog.Warn(context.TODO(), "failed to wait shrunk target updated", mlog.Err(err))
 			return nil
 		}
-		mlog.Info(context.TODO(), "wait for partition released done", mlog.Int64s("toReleasePartitions", toReleasePartitions))
+	} else if err := WaitCurrentTargetUpdated(waitCtx, job.targetObserver, collectionID); err != nil {
+		mlog.Warn(context.TODO(), "failed to wait current target updated", mlog.Err(err))
+		return nil
+	}
+	if err := WaitCollectionReleased(waitCtx, job.dist, job.checkerController, collectionID, job.toReleasePartitions...); err != nil {
+		mlog.Warn(context.TODO(), "failed to wait partition released", mlog.Err(err))
+		return nil
 	}
+	mlog.Info(context.TODO(), "wait for partition released done", mlog.Int64s("toReleasePartitions", job.toReleasePartitions))
 	return nil
 }
 
@@ -27,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/metastore/mocks"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/pkg/v3/proto/messagespb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
@@ -92,10 +93,12 @@ func (suite *LoadCollectionJobSuite) TestDescribeCollectionOtherError() {
 	ctx := context.Background()
 	collectionID := int64(1001)
 
+	saveJobStepRetryParams(suite.T(), 2)
+
 	expectedErr := errors.New("broker unavailable")
 	broker := meta.NewMockBroker(suite.T())
 	broker.EXPECT().DescribeCollection(mock.Anything, collectionID).
-		Return(nil, expectedErr)
+		Return(nil, expectedErr).Times(2)
 
 	result := suite.buildBroadcastResult(collectionID, []int64{200, 201})
 	job := NewLoadCollectionJob(ctx, result, nil, nil, broker, nil, nil, nil, nil, nil, nil)
@@ -103,6 +106,33 @@ func (suite *LoadCollectionJobSuite) TestDescribeCollectionOtherError() {
 	err := job.Execute()
 	suite.Error(err)
 	suite.True(errors.Is(err, expectedErr))
+	suite.Nil(job.collInfo)
+}
+
+// TestDescribeCollectionTransientError tests that a transient DescribeCollection failure is retried
+// instead of aborting the load, and the job moves on to the next steps.
+func (suite *LoadCollectionJobSuite) TestDescribeCollectionTransientError() {
+	ctx := context.Background()
+	collectionID := int64(1003)
+	saveJobStepRetryParams(suite.T(), 3)
+
+	broker := meta.NewMockBroker(suite.T())
+	broker.EXPECT().DescribeCollection(mock.Anything, collectionID).
+		Return(nil, errors.New("broker unavailable")).Once()
+	broker.EXPECT().DescribeCollection(mock.Anything, collectionID).
+		Return(&milvuspb.DescribeCollectionResponse{
+			CollectionID:        collectionID,
+			VirtualChannelNames: []string{"ch1"},
+		}, nil).Once()
+
+	result := suite.buildBroadcastResult(collectionID, []int64{400})
+	job := NewLoadCollectionJob(ctx, result, nil, nil, broker, nil, nil, nil, nil, nil, nil)
+
+	// SpawnReplicasWithReplicaConfig panics on nil meta, after the DescribeCollection step succeeded
+	suite.Panics(func() {
+		job.Execute()
+	})
+	suite.Equal([]string{"ch1"}, job.collInfo.GetVirtualChannelNames())
 }
 
 // TestDescribeCollectionSuccess tests that Execute proceeds with VirtualChannelNames from DescribeCollection.
@@ -418,6 +448,47 @@ func (suite *LoadCollectionJobSuite) TestGetLocalReplicaConfig_AllocIDError() {
 	suite.Nil(replicas)
 }
 
+// TestUndoListRollBack tests that the rollback report is recorded and retrievable by msg id.
+func (suite *LoadCollectionJobSuite) TestUndoListRollBack() {
+	ctx := context.Background()
+	collectionID := int64(4000)
+
+	catalog := mocks.NewQueryCoordCatalog(suite.T())
+	catalog.EXPECT().ReleaseCollection(mock.Anything, collectionID).Return(nil)
+	catalog.EXPECT().ReleasePartition(mock.Anything, collectionID, int64(10)).Return(errors.New("mock error"))
+	m := meta.NewMeta(func() (int64, error) { return 0, nil }, catalog, session.NewNodeManager())
+	suite.NoError(m.PutCollectionWithoutSave(ctx, &meta.Collection{
+		CollectionLoadInfo: &querypb.CollectionLoadInfo{CollectionID: collectionID},
+	}))
+
+	// failed to drop the lack partitions
+	undo := NewUndoList(ctx, m, nil, nil)
+	undo.MsgID = 1
+	undo.CollectionID = collectionID
+	undo.LackPartitions = []int64{10}
+	report := undo.RollBack()
+	suite.Empty(report.DroppedPartitions)
+	suite.False(report.CollectionRemoved)
+	suite.Len(report.Errors, 1)
+
+	// remove the new collection
+	undo = NewUndoList(ctx, m, nil, nil)
+	undo.MsgID = 2
+	undo.CollectionID = collectionID
+	undo.IsNewCollection = true
+	undo.RollBack()
+	report, ok := GetRollbackReport(2)
+	suite.True(ok)
+	suite.Equal(collectionID, report.CollectionID)
+	suite.True(report.CollectionRemoved)
+	suite.False(report.TargetReverted)
+	suite.Empty(report.Errors)
+	suite.False(m.CollectionManager.Exist(ctx, collectionID))
+
+	_, ok = GetRollbackReport(3)
+	suite.False(ok)
+}
+
 func TestLoadCollectionJob(t *testing.T) {
 	suite.Run(t, new(LoadCollectionJobSuite))
 }
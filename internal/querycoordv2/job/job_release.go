@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/retry"
 )
 
 type ReleaseCollectionJob struct {
@@ -67,20 +68,29 @@ func NewReleaseCollectionJob(ctx context.Context,
 	}
 }
 
+// Execute runs the release steps, see RunSteps for the retry of the steps.
 func (job *ReleaseCollectionJob) Execute() error {
+	return job.RunSteps(
+		Step{Name: "RemoveCollection", Run: job.removeCollection},
+		Step{Name: "WaitCollectionReleased", Run: job.waitCollectionReleased},
+		Step{Name: "RemoveReplicas", Run: job.removeReplicas},
+	)
+}
+
+func (job *ReleaseCollectionJob) removeCollection(ctx context.Context) error {
 	collectionID := job.result.Message.Header().GetCollectionId()
-	replicas := job.meta.GetByCollection(job.ctx, collectionID)
+	replicas := job.meta.GetByCollection(ctx, collectionID)
 
-	if !job.meta.Exist(job.ctx, collectionID) && len(replicas) == 0 {
+	if !job.meta.Exist(ctx, collectionID) && len(replicas) == 0 {
 		mlog.Info(context.TODO(), "release collection end, the collection has not been loaded into QueryNode")
-		return nil
+		return errSkipRemainingSteps
 	}
 
-	if job.meta.Exist(job.ctx, collectionID) {
-		err := job.meta.CollectionManager.RemoveCollection(job.ctx, collectionID)
+	if job.meta.Exist(ctx, collectionID) {
+		err := job.meta.CollectionManager.RemoveCollection(ctx, collectionID)
 		if err != nil {
 			msg := "failed to remove collection"
-			mlog.Warn(job.ctx, msg, mlog.Err(err))
+			mlog.Warn(ctx, msg, mlog.Err(err))
 			return merr.Wrapf(err, "%s", msg)
 		}
 
@@ -88,7 +98,7 @@ func (job *ReleaseCollectionJob) Execute() error {
 
 		// try best discard cache
 		// shall not affect releasing if failed
-		if err := job.proxyManager.InvalidateCollectionMetaCache(job.ctx,
+		if err := job.proxyManager.InvalidateCollectionMetaCache(ctx,
 			&proxypb.InvalidateCollMetaCacheRequest{
 				CollectionID: collectionID,
 			},
@@ -97,21 +107,31 @@ func (job *ReleaseCollectionJob) Execute() error {
 		}
 
 		// try best clean shard leader cache
-		if err := job.proxyManager.InvalidateShardLeaderCache(job.ctx, &proxypb.InvalidateShardLeaderCacheRequest{
+		if err := job.proxyManager.InvalidateShardLeaderCache(ctx, &proxypb.InvalidateShardLeaderCacheRequest{
 			CollectionIDs: []int64{collectionID},
 		}); err != nil {
 			mlog.Warn(context.TODO(), "failed to invalidate shard leader cache", mlog.Err(err))
 		}
 	}
+	return nil
+}
 
-	if err := WaitCollectionReleased(job.ctx, job.dist, job.checkerController, collectionID); err != nil {
+// waitCollectionReleased waits until the collection is released from the nodes,
+// the wait has its own timeout so the failure is not retried.
+func (job *ReleaseCollectionJob) waitCollectionReleased(ctx context.Context) error {
+	collectionID := job.result.Message.Header().GetCollectionId()
+	if err := WaitCollectionReleased(ctx, job.dist, job.checkerController, collectionID); err != nil {
 		mlog.Warn(context.TODO(), "failed to wait collection released", mlog.Err(err))
-		return merr.Wrap(err, "failed to wait collection released")
+		return retry.Unrecoverable(merr.Wrap(err, "failed to wait collection released"))
 	}
+	return nil
+}
 
-	if err := job.meta.ReplicaManager.RemoveCollection(job.ctx, collectionID); err != nil {
+func (job *ReleaseCollectionJob) removeReplicas(ctx context.Context) error {
+	collectionID := job.result.Message.Header().GetCollectionId()
+	if err := job.meta.ReplicaManager.RemoveCollection(ctx, collectionID); err != nil {
 		msg := "failed to remove replicas"
-		mlog.Warn(job.ctx, msg, mlog.Err(err))
+		mlog.Warn(ctx, msg, mlog.Err(err))
 		return merr.Wrapf(err, "%s", msg)
 	}
 	mlog.Info(context.TODO(), "release collection job done", mlog.Int64("collectionID", collectionID))
@@ -18,6 +18,7 @@ package job
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -39,6 +40,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
 type releaseJobCatalog struct {
@@ -273,6 +275,7 @@ func TestReleaseCollectionJobIgnoresMissingCollectionAndReplica(t *testing.T) {
 }
 
 func TestReleaseCollectionJobReturnsCollectionRemovalError(t *testing.T) {
+	saveJobStepRetryParams(t, 2)
 	ctx := context.Background()
 	collectionID := int64(1004)
 	replicaID := int64(14)
@@ -297,7 +300,7 @@ func TestReleaseCollectionJobReturnsCollectionRemovalError(t *testing.T) {
 	require.ErrorContains(t, err, "failed to remove collection")
 	require.NotNil(t, m.GetCollection(ctx, collectionID))
 	require.Len(t, m.GetByCollection(ctx, collectionID), 1)
-	require.Equal(t, 1, catalog.releaseCollectionCalls)
+	require.Equal(t, 2, catalog.releaseCollectionCalls)
 	require.Equal(t, 0, catalog.releaseReplicasCalls)
 }
 
@@ -347,6 +350,7 @@ func TestReleaseCollectionJobContinuesWhenCacheInvalidationFails(t *testing.T) {
 }
 
 func TestReleaseCollectionJobReturnsReplicaRemovalError(t *testing.T) {
+	saveJobStepRetryParams(t, 2)
 	ctx := context.Background()
 	collectionID := int64(1006)
 	replicaID := int64(16)
@@ -372,5 +376,15 @@ func TestReleaseCollectionJobReturnsReplicaRemovalError(t *testing.T) {
 	require.Nil(t, m.GetCollection(ctx, collectionID))
 	require.Len(t, m.GetByCollection(ctx, collectionID), 1)
 	require.Equal(t, 1, catalog.releaseCollectionCalls)
-	require.Equal(t, 1, catalog.releaseReplicasCalls)
+	require.Equal(t, 2, catalog.releaseReplicasCalls)
+}
+
+func saveJobStepRetryParams(t *testing.T, maxAttempts int) {
+	params := paramtable.Get()
+	params.Save(params.QueryCoordCfg.JobStepMaxAttempts.Key, strconv.Itoa(maxAttempts))
+	params.Save(params.QueryCoordCfg.JobStepRetryInterval.Key, "1")
+	t.Cleanup(func() {
+		params.Reset(params.QueryCoordCfg.JobStepMaxAttempts.Key)
+		params.Reset(params.QueryCoordCfg.JobStepRetryInterval.Key)
+	})
 }
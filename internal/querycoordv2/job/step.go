@@ -0,0 +1,84 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/retry"
+)
+
+// errSkipRemainingSteps is returned by a step to finish the job successfully without running the remaining steps,
+// e.g. the collection to load has been dropped.
+var errSkipRemainingSteps = errors.New("skip remaining steps")
+
+// Step is a step of a job. The step must be idempotent, as it's retried on transient errors.
+// Return retry.Unrecoverable(err) to fail the job without retrying.
+type Step struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// RunSteps runs the steps in order, each step is retried with backoff up to queryCoord.jobStep.maxAttempts times.
+// The job fails with the error of the first failed step, the remaining steps are not run.
+func (job *BaseJob) RunSteps(steps ...Step) error {
+	maxAttempts := paramtable.Get().QueryCoordCfg.JobStepMaxAttempts.GetAsInt()
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	interval := paramtable.Get().QueryCoordCfg.JobStepRetryInterval.GetAsDuration(time.Millisecond)
+
+	for _, step := range steps {
+		attempts := 0
+		skipped := false
+		err := retry.Do(job.ctx, func() error {
+			attempts++
+			err := step.Run(job.ctx)
+			if errors.Is(err, errSkipRemainingSteps) {
+				skipped = true
+				return nil
+			}
+			return err
+		}, retry.Attempts(uint(maxAttempts)), retry.Sleep(interval))
+		if skipped {
+			mlog.Info(job.ctx, "skip remaining steps of job",
+				mlog.Int64("collectionID", job.collectionID),
+				mlog.String("step", step.Name))
+			return nil
+		}
+		if err != nil {
+			mlog.Warn(job.ctx, "job step failed",
+				mlog.Int64("collectionID", job.collectionID),
+				mlog.String("step", step.Name),
+				mlog.Int("attempts", attempts),
+				mlog.Err(err))
+			return err
+		}
+		if attempts > 1 {
+			mlog.Info(job.ctx, "job step succeeded after retries",
+				mlog.Int64("collectionID", job.collectionID),
+				mlog.String("step", step.Name),
+				mlog.Int("attempts", attempts))
+		}
+	}
+	return nil
+}
@@ -18,19 +18,51 @@ package job
 
 import (
 	"context"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
 
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/observers"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 )
 
+const (
+	rollbackReportCapacity   = 256
+	rollbackReportExpireTime = 24 * time.Hour
+)
+
+// rollbackReports keeps the recent rollback reports by the msg id of the job.
+var rollbackReports = expirable.NewLRU[int64, *RollbackReport](rollbackReportCapacity, nil, rollbackReportExpireTime)
+
+// RollbackReport records the actions taken by UndoList.RollBack when a loading request fails.
+type RollbackReport struct {
+	MsgID        int64
+	CollectionID int64
+
+	RemovedReplicas   []int64 // the replicas created during loading and removed
+	CollectionRemoved bool    // the collection load info is removed from meta
+	DroppedPartitions []int64 // the partitions dropped from meta
+	TargetReverted    bool    // the target of the collection or the partitions is released
+
+	Errors     []string // the errors met during rolling back, the related actions may be partially applied
+	RollbackAt time.Time
+}
+
+// GetRollbackReport returns the rollback report of the loading request by msg id.
+func GetRollbackReport(msgID int64) (*RollbackReport, bool) {
+	return rollbackReports.Get(msgID)
+}
+
 type UndoList struct {
 	IsTargetUpdated  bool // indicates if target updated during loading
 	IsReplicaCreated bool // indicates if created new replicas during loading
 	IsNewCollection  bool // indicates if created new collection during loading
 
-	CollectionID   int64
-	LackPartitions []int64
+	MsgID           int64
+	CollectionID    int64
+	LackPartitions  []int64
+	CreatedReplicas []int64
 
 	ctx            context.Context
 	meta           *meta.Meta
@@ -49,8 +81,11 @@ func NewUndoList(ctx context.Context, meta *meta.Meta,
 	}
 }
 
-func (u *UndoList) RollBack() {
+// RollBack undoes the actions of the failed loading request, and records them into a rollback report
+// retrievable by the msg id of the request, see GetRollbackReport.
+func (u *UndoList) RollBack() *RollbackReport {
 	log := mlog.With(
+		mlog.Int64("msgID", u.MsgID),
 		mlog.FieldCollectionID(u.CollectionID),
 		mlog.Int64s("partitionIDs", u.LackPartitions),
 	)
@@ -61,14 +96,34 @@ func (u *UndoList) RollBack() {
 		mlog.Bool("isTargetUpdated", u.IsTargetUpdated),
 	)
 
+	report := &RollbackReport{
+		MsgID:        u.MsgID,
+		CollectionID: u.CollectionID,
+		RollbackAt:   time.Now(),
+	}
+
+	if u.IsReplicaCreated && len(u.CreatedReplicas) > 0 {
+		if err := u.meta.ReplicaManager.RemoveReplicas(u.ctx, u.CollectionID, u.CreatedReplicas...); err != nil {
+			log.Warn(u.ctx, "failed to rollback replicas from meta", mlog.Err(err))
+			report.Errors = append(report.Errors, err.Error())
+		} else {
+			report.RemovedReplicas = u.CreatedReplicas
+		}
+	}
+
 	var err error
 	if u.IsNewCollection || u.IsReplicaCreated {
 		err = u.meta.CollectionManager.RemoveCollection(u.ctx, u.CollectionID)
+		report.CollectionRemoved = err == nil
 	} else {
 		err = u.meta.RemovePartition(u.ctx, u.CollectionID, u.LackPartitions...)
+		if err == nil {
+			report.DroppedPartitions = u.LackPartitions
+		}
 	}
 	if err != nil {
 		log.Warn(u.ctx, "failed to rollback collection from meta", mlog.Err(err))
+		report.Errors = append(report.Errors, err.Error())
 	}
 
 	if u.IsTargetUpdated {
@@ -77,5 +132,16 @@ func (u *UndoList) RollBack() {
 		} else {
 			u.targetObserver.ReleasePartition(u.CollectionID, u.LackPartitions...)
 		}
+		report.TargetReverted = true
 	}
+
+	rollbackReports.Add(u.MsgID, report)
+	log.Warn(u.ctx, "rollback failed loading request done",
+		mlog.Int64s("removedReplicas", report.RemovedReplicas),
+		mlog.Bool("collectionRemoved", report.CollectionRemoved),
+		mlog.Int64s("droppedPartitions", report.DroppedPartitions),
+		mlog.Bool("targetReverted", report.TargetReverted),
+		mlog.Strings("errors", report.Errors),
+	)
+	return report
 }
@@ -115,6 +115,21 @@ func WaitCurrentTargetUpdated(ctx context.Context, targetObserver *observers.Tar
 	}
 }
 
+// WaitShrunkTargetUpdated waits until the target shrunk by the released partitions becomes the current target.
+func WaitShrunkTargetUpdated(ctx context.Context, targetObserver *observers.TargetObserver, collection int64, ready chan struct{}) error {
+	// accelerate check
+	targetObserver.TriggerUpdateCurrentTarget(collection)
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		return merr.Wrapf(ctx.Err(), "context error while waiting for shrunk target updated, collection=%d", collection)
+	case <-time.After(waitCollectionReleasedTimeout):
+		return merr.WrapErrServiceUnavailableMsg("wait shrunk target updated timeout, collection=%d", collection)
+	}
+}
+
 func WaitUpdatePartition(ctx context.Context, targetObserver *observers.TargetObserver, collection int64, partition int64) error {
 	// manual trigger update next target
 	ready, err := targetObserver.UpdatePartition(collection, partition)
@@ -0,0 +1,158 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/kv"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/contextutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// loadAuditPrefix is the meta prefix of the load and release audit records.
+const loadAuditPrefix = "querycoord-load-audit"
+
+// LoadAuditOperation is the audited load or release request.
+type LoadAuditOperation string
+
+const (
+	LoadAuditLoadCollection    LoadAuditOperation = "load_collection"
+	LoadAuditReleaseCollection LoadAuditOperation = "release_collection"
+	LoadAuditLoadPartitions    LoadAuditOperation = "load_partitions"
+	LoadAuditReleasePartitions LoadAuditOperation = "release_partitions"
+)
+
+// LoadAuditRecord is the audit record of a load or release request of a collection.
+type LoadAuditRecord struct {
+	CollectionID int64              `json:"collection_id"`
+	PartitionIDs []int64            `json:"partition_ids,omitempty"`
+	Operation    LoadAuditOperation `json:"operation"`
+	// Requester is the user of the request, it's empty if the authorization is disabled.
+	Requester string `json:"requester"`
+	// ProxyID and MsgID are the source node and the message id of the request.
+	ProxyID    int64     `json:"proxy_id"`
+	MsgID      int64     `json:"msg_id"`
+	StartTime  time.Time `json:"start_time"`
+	FinishTime time.Time `json:"finish_time"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// loadAuditManager keeps the latest load and release audit records of the collections in the metastore.
+type loadAuditManager struct {
+	kv kv.TxnKV
+}
+
+func newLoadAuditManager(kv kv.TxnKV) *loadAuditManager {
+	return &loadAuditManager{kv: kv}
+}
+
+func loadAuditCollectionPrefix(collectionID int64) string {
+	return path.Join(loadAuditPrefix, strconv.FormatInt(collectionID, 10)) + "/"
+}
+
+func loadAuditKey(record *LoadAuditRecord) string {
+	// the zero padded nanoseconds keep the keys of a collection in request order.
+	return loadAuditCollectionPrefix(record.CollectionID) + fmt.Sprintf("%020d", record.StartTime.UnixNano())
+}
+
+// Append appends the record of the collection, and removes the oldest records exceeding maxRecords.
+func (m *loadAuditManager) Append(ctx context.Context, record *LoadAuditRecord, maxRecords int) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := m.kv.Save(ctx, loadAuditKey(record), string(value)); err != nil {
+		return err
+	}
+	keys, _, err := m.kv.LoadWithPrefix(ctx, loadAuditCollectionPrefix(record.CollectionID))
+	if err != nil {
+		return err
+	}
+	if len(keys) <= maxRecords {
+		return nil
+	}
+	sort.Strings(keys)
+	return m.kv.MultiRemove(ctx, keys[:len(keys)-maxRecords])
+}
+
+// List returns the records of the collection in request order.
+func (m *loadAuditManager) List(ctx context.Context, collectionID int64) ([]*LoadAuditRecord, error) {
+	_, values, err := m.kv.LoadWithPrefix(ctx, loadAuditCollectionPrefix(collectionID))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*LoadAuditRecord, 0, len(values))
+	for _, value := range values {
+		record := &LoadAuditRecord{}
+		if err := json.Unmarshal([]byte(value), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].StartTime.Before(records[j].StartTime) })
+	return records, nil
+}
+
+// recordLoadAudit records who requested the load or release and its outcome,
+// failing to record doesn't fail the request since it has been done.
+func (s *Server) recordLoadAudit(ctx context.Context, operation LoadAuditOperation, base *commonpb.MsgBase,
+	collectionID int64, partitionIDs []int64, start time.Time, err error,
+) {
+	maxRecords := paramtable.Get().QueryCoordCfg.LoadAuditMaxRecords.GetAsInt()
+	if s.loadAudit == nil || maxRecords <= 0 {
+		return
+	}
+	requester, _ := contextutil.GetCurUserFromContext(ctx)
+	record := &LoadAuditRecord{
+		CollectionID: collectionID,
+		PartitionIDs: partitionIDs,
+		Operation:    operation,
+		Requester:    requester,
+		ProxyID:      base.GetSourceID(),
+		MsgID:        base.GetMsgID(),
+		StartTime:    start,
+		FinishTime:   time.Now(),
+		Success:      err == nil,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if err := s.loadAudit.Append(ctx, record, maxRecords); err != nil {
+		mlog.Warn(ctx, "failed to record the load audit of collection",
+			mlog.FieldCollectionID(collectionID),
+			mlog.String("operation", string(operation)),
+			mlog.Err(err))
+	}
+}
+
+// ListLoadAuditRecords returns the load and release audit records of the collection in request order.
+func (s *Server) ListLoadAuditRecords(ctx context.Context, collectionID int64) ([]*LoadAuditRecord, error) {
+	if s.loadAudit == nil {
+		return []*LoadAuditRecord{}, nil
+	}
+	return s.loadAudit.List(ctx, collectionID)
+}
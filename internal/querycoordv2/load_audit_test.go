@@ -0,0 +1,100 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/crypto"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestLoadAuditManager(t *testing.T) {
+	ctx := context.Background()
+	m := newLoadAuditManager(memkv.NewMemoryKV())
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		err := m.Append(ctx, &LoadAuditRecord{
+			CollectionID: 1,
+			Operation:    LoadAuditLoadCollection,
+			MsgID:        int64(i),
+			StartTime:    start.Add(time.Duration(i) * time.Second),
+		}, 3)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, m.Append(ctx, &LoadAuditRecord{CollectionID: 2, StartTime: start}, 3))
+
+	// the oldest records exceeding the limit are removed.
+	records, err := m.List(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	for i, record := range records {
+		assert.Equal(t, int64(i+2), record.MsgID)
+	}
+	records, err = m.List(ctx, 2)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	records, err = m.List(ctx, 3)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestRecordLoadAudit(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	s := &Server{loadAudit: newLoadAuditManager(memkv.NewMemoryKV())}
+	userCtx := metadata.NewIncomingContext(ctx, metadata.New(map[string]string{
+		strings.ToLower(util.HeaderAuthorize): crypto.Base64Encode("alice" + util.CredentialSeparator + "pwd"),
+	}))
+
+	start := time.Now()
+	s.recordLoadAudit(userCtx, LoadAuditReleaseCollection, &commonpb.MsgBase{SourceID: 10, MsgID: 100}, 1, nil, start, nil)
+	s.recordLoadAudit(ctx, LoadAuditLoadPartitions, &commonpb.MsgBase{SourceID: 11, MsgID: 101}, 1, []int64{2}, start.Add(time.Second), errors.New("mocked"))
+
+	records, err := s.ListLoadAuditRecords(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, LoadAuditReleaseCollection, records[0].Operation)
+	assert.Equal(t, "alice", records[0].Requester)
+	assert.Equal(t, int64(10), records[0].ProxyID)
+	assert.Equal(t, int64(100), records[0].MsgID)
+	assert.True(t, records[0].Success)
+	assert.Empty(t, records[0].Error)
+	assert.Equal(t, LoadAuditLoadPartitions, records[1].Operation)
+	assert.Equal(t, "", records[1].Requester)
+	assert.Equal(t, []int64{2}, records[1].PartitionIDs)
+	assert.False(t, records[1].Success)
+	assert.Equal(t, "mocked", records[1].Error)
+
+	// the requests are not audited if disabled.
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.LoadAuditMaxRecords.Key, "0")
+	defer paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.LoadAuditMaxRecords.Key)
+	s.recordLoadAudit(ctx, LoadAuditLoadCollection, nil, 1, nil, start.Add(2*time.Second), nil)
+	records, err = s.ListLoadAuditRecords(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+}
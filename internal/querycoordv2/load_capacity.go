@@ -0,0 +1,274 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/logutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// diskIndexTypes are the index types loaded onto the local disk of query node instead of memory.
+var diskIndexTypes = typeutil.NewSet("DISKANN", "AISAQ")
+
+// loadResourceEstimate is the estimated resource needed to load one replica.
+type loadResourceEstimate struct {
+	SegmentNum int
+	RowNum     int64
+	MemorySize int64
+	DiskSize   int64
+}
+
+// resourceGroupCapacity is the memory capacity of a resource group against a load request.
+type resourceGroupCapacity struct {
+	ResourceGroup   string
+	ReplicaNumber   int
+	NodeNum         int
+	RequiredMemory  int64
+	TotalMemory     int64
+	UsedMemory      int64
+	AvailableMemory int64
+	// Unknown is true if any node of the resource group hasn't reported its memory capacity yet.
+	Unknown bool
+}
+
+func (c *resourceGroupCapacity) insufficient() bool {
+	return !c.Unknown && c.RequiredMemory > c.AvailableMemory
+}
+
+// loadCapacityReport is the result of the load capacity pre-flight check.
+type loadCapacityReport struct {
+	CollectionID   int64
+	Estimate       loadResourceEstimate
+	ResourceGroups []*resourceGroupCapacity
+}
+
+// insufficientResourceGroups returns the resource groups which don't have enough memory for the load.
+func (r *loadCapacityReport) insufficientResourceGroups() []*resourceGroupCapacity {
+	return lo.Filter(r.ResourceGroups, func(c *resourceGroupCapacity, _ int) bool {
+		return c.insufficient()
+	})
+}
+
+func (r *loadCapacityReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "collection %d needs %.2fMB memory and %.2fMB disk per replica for %d segments with %d rows",
+		r.CollectionID, logutil.ToMB(float64(r.Estimate.MemorySize)), logutil.ToMB(float64(r.Estimate.DiskSize)),
+		r.Estimate.SegmentNum, r.Estimate.RowNum)
+	for _, c := range r.ResourceGroups {
+		if c.Unknown {
+			fmt.Fprintf(&sb, "; resource group %s: %d replicas need %.2fMB memory, capacity unknown",
+				c.ResourceGroup, c.ReplicaNumber, logutil.ToMB(float64(c.RequiredMemory)))
+			continue
+		}
+		fmt.Fprintf(&sb, "; resource group %s: %d replicas need %.2fMB memory, %.2fMB available (total %.2fMB, used %.2fMB) on %d nodes",
+			c.ResourceGroup, c.ReplicaNumber, logutil.ToMB(float64(c.RequiredMemory)), logutil.ToMB(float64(c.AvailableMemory)),
+			logutil.ToMB(float64(c.TotalMemory)), logutil.ToMB(float64(c.UsedMemory)), c.NodeNum)
+	}
+	return sb.String()
+}
+
+// fieldRowSizes returns the estimated size of a row of each field.
+func fieldRowSizes(schema *schemapb.CollectionSchema) map[int64]int64 {
+	sizes := make(map[int64]int64)
+	for _, field := range typeutil.GetAllFieldSchemas(schema) {
+		size, err := typeutil.EstimateAvgSizePerRecord(&schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{field}})
+		if err != nil {
+			continue
+		}
+		sizes[field.GetFieldID()] = int64(size)
+	}
+	return sizes
+}
+
+// estimateSegmentResource estimates the resource to load a segment,
+// the raw data of a vector field is assumed to be replaced by its index.
+func estimateSegmentResource(schema *schemapb.CollectionSchema, rowSizes map[int64]int64, loadFields typeutil.Set[int64],
+	numRows int64, indexes []*querypb.FieldIndexInfo,
+) (memory int64, disk int64) {
+	indexedFields := typeutil.NewSet[int64]()
+	for _, index := range indexes {
+		if loadFields.Len() > 0 && !loadFields.Contain(index.GetFieldID()) {
+			continue
+		}
+		indexedFields.Insert(index.GetFieldID())
+		indexType, _ := lo.Find(index.GetIndexParams(), func(kv *commonpb.KeyValuePair) bool {
+			return kv.GetKey() == common.IndexTypeKey
+		})
+		if diskIndexTypes.Contain(indexType.GetValue()) {
+			disk += index.GetIndexSize()
+		} else {
+			memory += index.GetIndexSize()
+		}
+	}
+	for _, field := range typeutil.GetAllFieldSchemas(schema) {
+		if loadFields.Len() > 0 && !loadFields.Contain(field.GetFieldID()) && field.GetFieldID() >= common.StartOfUserFieldID {
+			continue
+		}
+		if indexedFields.Contain(field.GetFieldID()) && typeutil.IsVectorType(field.GetDataType()) {
+			continue
+		}
+		memory += rowSizes[field.GetFieldID()] * numRows
+	}
+	return memory, disk
+}
+
+// estimateLoadResource estimates the resource needed to load one replica of the given partitions.
+func (s *Server) estimateLoadResource(ctx context.Context, schema *schemapb.CollectionSchema, collectionID int64,
+	partitionIDs []int64, loadFields []int64,
+) (loadResourceEstimate, error) {
+	estimate := loadResourceEstimate{}
+	if len(partitionIDs) == 0 {
+		return estimate, nil
+	}
+	_, segments, err := s.broker.GetRecoveryInfoV2(ctx, collectionID, partitionIDs...)
+	if err != nil {
+		return estimate, err
+	}
+	segments = lo.Filter(segments, func(segment *datapb.SegmentInfo, _ int) bool {
+		return segment.GetNumOfRows() > 0
+	})
+	if len(segments) == 0 {
+		return estimate, nil
+	}
+	indexes, err := s.broker.GetIndexInfo(ctx, collectionID, lo.Map(segments, func(segment *datapb.SegmentInfo, _ int) int64 {
+		return segment.GetID()
+	})...)
+	if err != nil {
+		// the collection may have no index, estimate by the raw data only.
+		mlog.Info(ctx, "failed to get index info for load capacity estimation, ignore index size",
+			mlog.Int64("collectionID", collectionID), mlog.Err(err))
+		indexes = nil
+	}
+
+	rowSizes := fieldRowSizes(schema)
+	fields := typeutil.NewSet(loadFields...)
+	for _, segment := range segments {
+		memory, disk := estimateSegmentResource(schema, rowSizes, fields, segment.GetNumOfRows(), indexes[segment.GetID()])
+		estimate.SegmentNum++
+		estimate.RowNum += segment.GetNumOfRows()
+		estimate.MemorySize += memory
+		estimate.DiskSize += disk
+	}
+	return estimate, nil
+}
+
+// getResourceGroupCapacity calculates the memory capacity of the resource group,
+// the used memory is estimated from the segments distributed on its nodes.
+func (s *Server) getResourceGroupCapacity(ctx context.Context, rg string, rowSizes map[int64]map[int64]int64) (*resourceGroupCapacity, error) {
+	nodes, err := s.meta.ResourceManager.GetNodes(ctx, rg)
+	if err != nil {
+		return nil, err
+	}
+	ratio := Params.QueryCoordCfg.LoadCapacityMemoryUsageRatio.GetAsFloat()
+	capacity := &resourceGroupCapacity{
+		ResourceGroup: rg,
+		NodeNum:       len(nodes),
+	}
+	for _, node := range nodes {
+		nodeInfo := s.nodeMgr.Get(node)
+		if nodeInfo == nil || nodeInfo.MemCapacity() <= 0 {
+			capacity.Unknown = true
+			return capacity, nil
+		}
+		capacity.TotalMemory += int64(nodeInfo.MemCapacity() * 1024 * 1024)
+
+		for _, segment := range s.dist.SegmentDistManager.GetByFilter(meta.WithNodeID(node)) {
+			collection := s.meta.CollectionManager.GetCollection(ctx, segment.GetCollectionID())
+			if collection == nil {
+				continue
+			}
+			sizes, ok := rowSizes[segment.GetCollectionID()]
+			if !ok {
+				sizes = fieldRowSizes(collection.Schema)
+				rowSizes[segment.GetCollectionID()] = sizes
+			}
+			memory, _ := estimateSegmentResource(collection.Schema, sizes, typeutil.NewSet(collection.GetLoadFields()...),
+				segment.GetNumOfRows(), lo.Values(segment.IndexInfo))
+			capacity.UsedMemory += memory
+		}
+	}
+	capacity.AvailableMemory = int64(float64(capacity.TotalMemory)*ratio) - capacity.UsedMemory
+	return capacity, nil
+}
+
+// checkLoadCapacity estimates the memory needed to load the partitions with the expected replicas,
+// and rejects the load with a capacity report if any resource group doesn't have enough memory,
+// so the load fails fast instead of leaving the segments stalled in loading.
+// Only the partitions not loaded yet are counted.
+func (s *Server) checkLoadCapacity(ctx context.Context, schema *schemapb.CollectionSchema, collectionID int64,
+	partitionIDs []int64, loadFields []int64, expectedReplicaNumber map[string]int,
+) error {
+	if !Params.QueryCoordCfg.LoadCapacityCheckEnabled.GetAsBool() {
+		return nil
+	}
+	loaded := typeutil.NewSet(lo.Map(s.meta.GetPartitionsByCollection(ctx, collectionID), func(p *meta.Partition, _ int) int64 {
+		return p.GetPartitionID()
+	})...)
+	toLoad := lo.Filter(partitionIDs, func(partitionID int64, _ int) bool {
+		return !loaded.Contain(partitionID)
+	})
+
+	estimate, err := s.estimateLoadResource(ctx, schema, collectionID, toLoad, loadFields)
+	if err != nil {
+		return err
+	}
+	report := &loadCapacityReport{
+		CollectionID: collectionID,
+		Estimate:     estimate,
+	}
+	if estimate.MemorySize == 0 {
+		return nil
+	}
+
+	rgs := lo.Keys(expectedReplicaNumber)
+	sort.Strings(rgs)
+	rowSizes := make(map[int64]map[int64]int64)
+	for _, rg := range rgs {
+		capacity, err := s.getResourceGroupCapacity(ctx, rg, rowSizes)
+		if err != nil {
+			return err
+		}
+		capacity.ReplicaNumber = expectedReplicaNumber[rg]
+		capacity.RequiredMemory = estimate.MemorySize * int64(capacity.ReplicaNumber)
+		report.ResourceGroups = append(report.ResourceGroups, capacity)
+	}
+
+	insufficient := report.insufficientResourceGroups()
+	if len(insufficient) == 0 {
+		mlog.Info(ctx, "load capacity check passed", mlog.String("report", report.String()))
+		return nil
+	}
+	required := lo.SumBy(insufficient, func(c *resourceGroupCapacity) int64 { return c.RequiredMemory })
+	available := lo.SumBy(insufficient, func(c *resourceGroupCapacity) int64 { return c.AvailableMemory })
+	mlog.Warn(ctx, "load capacity check failed", mlog.String("report", report.String()))
+	return merr.WrapErrServiceMemoryLimitExceeded(float32(required), float32(available), report.String())
+}
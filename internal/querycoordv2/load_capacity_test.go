@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestEstimateSegmentResource(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{
+				{Key: common.DimKey, Value: "4"},
+			}},
+			{FieldID: 102, Name: "age", DataType: schemapb.DataType_Int32},
+		},
+	}
+	rowSizes := fieldRowSizes(schema)
+	assert.Equal(t, int64(8), rowSizes[100])
+	assert.Equal(t, int64(16), rowSizes[101])
+	assert.Equal(t, int64(4), rowSizes[102])
+
+	// no index, all the raw data is loaded.
+	memory, disk := estimateSegmentResource(schema, rowSizes, typeutil.NewSet[int64](), 10, nil)
+	assert.Equal(t, int64(280), memory)
+	assert.Zero(t, disk)
+
+	// the vector raw data is replaced by the index.
+	memIndex := &querypb.FieldIndexInfo{FieldID: 101, IndexSize: 100, IndexParams: []*commonpb.KeyValuePair{
+		{Key: common.IndexTypeKey, Value: "HNSW"},
+	}}
+	memory, disk = estimateSegmentResource(schema, rowSizes, typeutil.NewSet[int64](), 10, []*querypb.FieldIndexInfo{memIndex})
+	assert.Equal(t, int64(220), memory)
+	assert.Zero(t, disk)
+
+	diskIndex := &querypb.FieldIndexInfo{FieldID: 101, IndexSize: 100, IndexParams: []*commonpb.KeyValuePair{
+		{Key: common.IndexTypeKey, Value: "DISKANN"},
+	}}
+	memory, disk = estimateSegmentResource(schema, rowSizes, typeutil.NewSet[int64](), 10, []*querypb.FieldIndexInfo{diskIndex})
+	assert.Equal(t, int64(120), memory)
+	assert.Equal(t, int64(100), disk)
+
+	// the fields not loaded are skipped.
+	memory, _ = estimateSegmentResource(schema, rowSizes, typeutil.NewSet[int64](100, 101), 10, []*querypb.FieldIndexInfo{memIndex})
+	assert.Equal(t, int64(180), memory)
+}
+
+func TestLoadCapacityReport(t *testing.T) {
+	report := &loadCapacityReport{
+		CollectionID: 1,
+		Estimate:     loadResourceEstimate{SegmentNum: 2, RowNum: 100, MemorySize: 1024 * 1024},
+		ResourceGroups: []*resourceGroupCapacity{
+			{ResourceGroup: "rg1", ReplicaNumber: 1, NodeNum: 1, RequiredMemory: 1024 * 1024, TotalMemory: 4 * 1024 * 1024, AvailableMemory: 2 * 1024 * 1024},
+			{ResourceGroup: "rg2", ReplicaNumber: 2, NodeNum: 1, RequiredMemory: 2 * 1024 * 1024, TotalMemory: 2 * 1024 * 1024, AvailableMemory: 1024 * 1024},
+			{ResourceGroup: "rg3", ReplicaNumber: 1, RequiredMemory: 1024 * 1024, Unknown: true},
+		},
+	}
+	insufficient := report.insufficientResourceGroups()
+	assert.Len(t, insufficient, 1)
+	assert.Equal(t, "rg2", insufficient[0].ResourceGroup)
+
+	msg := report.String()
+	assert.Contains(t, msg, "collection 1 needs 1.00MB memory")
+	assert.Contains(t, msg, "resource group rg2: 2 replicas need 2.00MB memory, 1.00MB available")
+	assert.Contains(t, msg, "resource group rg3: 1 replicas need 1.00MB memory, capacity unknown")
+}
@@ -23,3 +23,8 @@ const (
 	MultiTargetBalancerName       = "MultipleTargetBalancer"
 	ChannelLevelScoreBalancerName = "ChannelLevelScoreBalancer"
 )
+
+const (
+	RGAssignPolicyLimits       = "Limits"
+	RGAssignPolicyProportional = "Proportional"
+)
@@ -28,12 +28,14 @@ import (
 type DistributionManager struct {
 	SegmentDistManager SegmentDistManagerInterface
 	ChannelDistManager ChannelDistManagerInterface
+	SegmentAccessStats *SegmentAccessStats
 }
 
 func NewDistributionManager(nodeManager *session.NodeManager) *DistributionManager {
 	return &DistributionManager{
 		SegmentDistManager: NewSegmentDistManager(),
 		ChannelDistManager: NewChannelDistManager(nodeManager),
+		SegmentAccessStats: NewSegmentAccessStats(),
 	}
 }
 
@@ -18,11 +18,17 @@ package meta
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
+
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
 const expireTime = 24 * time.Hour
@@ -35,15 +41,25 @@ type failInfo struct {
 	lastTime time.Time
 }
 
+// fieldFailInfo records the segments failed to load the field.
+type fieldFailInfo struct {
+	segmentIDs typeutil.UniqueSet
+	err        error
+	lastTime   time.Time
+}
+
 type FailedLoadCache struct {
 	mu sync.RWMutex
 	// CollectionID, ErrorCode -> error
 	records map[int64]map[int32]*failInfo
+	// CollectionID, FieldID -> failed segments
+	fieldRecords map[int64]map[int64]*fieldFailInfo
 }
 
 func NewFailedLoadCache() *FailedLoadCache {
 	return &FailedLoadCache{
-		records: make(map[int64]map[int32]*failInfo),
+		records:      make(map[int64]map[int32]*failInfo),
+		fieldRecords: make(map[int64]map[int64]*fieldFailInfo),
 	}
 }
 
@@ -51,6 +67,14 @@ func (l *FailedLoadCache) Get(collectionID int64) error {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 
+	if err := l.getFieldFailure(collectionID); err != nil {
+		mlog.Warn(context.TODO(), "FailedLoadCache hits failed field record",
+			mlog.FieldCollectionID(collectionID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
 	if _, ok := l.records[collectionID]; !ok {
 		return nil
 	}
@@ -99,10 +123,107 @@ func (l *FailedLoadCache) Put(collectionID int64, err error) {
 	)
 }
 
+// getFieldFailure returns the error identifying the failed fields and their segments,
+// the error of the field failed on most segments is kept as the cause.
+func (l *FailedLoadCache) getFieldFailure(collectionID int64) error {
+	infos := l.fieldRecords[collectionID]
+	if len(infos) == 0 {
+		return nil
+	}
+
+	fieldIDs := make([]int64, 0, len(infos))
+	for fieldID := range infos {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	sort.Slice(fieldIDs, func(i, j int) bool { return fieldIDs[i] < fieldIDs[j] })
+
+	var (
+		cause       error
+		maxSegments = 0
+		details     = make([]string, 0, len(fieldIDs))
+	)
+	for _, fieldID := range fieldIDs {
+		info := infos[fieldID]
+		segmentIDs := info.segmentIDs.Collect()
+		sort.Slice(segmentIDs, func(i, j int) bool { return segmentIDs[i] < segmentIDs[j] })
+		details = append(details, fmt.Sprintf("field %d of segments %v", fieldID, segmentIDs))
+		if info.segmentIDs.Len() > maxSegments {
+			maxSegments = info.segmentIDs.Len()
+			cause = info.err
+		}
+	}
+	return errors.Wrapf(cause, "failed to load %s", strings.Join(details, ", "))
+}
+
+// GetFailedFields returns the failed segments of each field failed to load.
+func (l *FailedLoadCache) GetFailedFields(collectionID int64) map[int64][]int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	result := make(map[int64][]int64, len(l.fieldRecords[collectionID]))
+	for fieldID, info := range l.fieldRecords[collectionID] {
+		result[fieldID] = info.segmentIDs.Collect()
+	}
+	return result
+}
+
+// PutFieldFailure records the fields of the segment failed to load.
+func (l *FailedLoadCache) PutFieldFailure(collectionID int64, segmentID int64, fieldIDs []int64, err error) {
+	if err == nil || len(fieldIDs) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.fieldRecords[collectionID]; !ok {
+		l.fieldRecords[collectionID] = make(map[int64]*fieldFailInfo)
+	}
+	for _, fieldID := range fieldIDs {
+		info, ok := l.fieldRecords[collectionID][fieldID]
+		if !ok {
+			info = &fieldFailInfo{segmentIDs: typeutil.NewUniqueSet()}
+			l.fieldRecords[collectionID][fieldID] = info
+		}
+		info.segmentIDs.Insert(segmentID)
+		info.err = err
+		info.lastTime = time.Now()
+	}
+	mlog.Warn(context.TODO(), "FailedLoadCache put failed field record",
+		mlog.FieldCollectionID(collectionID),
+		mlog.FieldSegmentID(segmentID),
+		mlog.Int64s("fieldIDs", fieldIDs),
+		mlog.Err(err),
+	)
+}
+
+// RemoveFieldFailure removes the failed records of the fields once the segment loads them.
+func (l *FailedLoadCache) RemoveFieldFailure(collectionID int64, segmentID int64, fieldIDs []int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	infos, ok := l.fieldRecords[collectionID]
+	if !ok {
+		return
+	}
+	for _, fieldID := range fieldIDs {
+		info, ok := infos[fieldID]
+		if !ok {
+			continue
+		}
+		info.segmentIDs.Remove(segmentID)
+		if info.segmentIDs.Len() == 0 {
+			delete(infos, fieldID)
+		}
+	}
+	if len(infos) == 0 {
+		delete(l.fieldRecords, collectionID)
+	}
+}
+
 func (l *FailedLoadCache) Remove(collectionID int64) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	delete(l.records, collectionID)
+	delete(l.fieldRecords, collectionID)
 	mlog.Info(context.TODO(), "FailedLoadCache removes cache", mlog.FieldCollectionID(collectionID))
 }
 
@@ -120,4 +241,14 @@ func (l *FailedLoadCache) TryExpire() {
 			mlog.Info(context.TODO(), "FailedLoadCache expires cache", mlog.FieldCollectionID(col))
 		}
 	}
+	for col, infos := range l.fieldRecords {
+		for fieldID, info := range infos {
+			if time.Since(info.lastTime) > expireTime {
+				delete(l.fieldRecords[col], fieldID)
+			}
+		}
+		if len(l.fieldRecords[col]) == 0 {
+			delete(l.fieldRecords, col)
+		}
+	}
 }
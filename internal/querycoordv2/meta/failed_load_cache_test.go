@@ -52,3 +52,44 @@ func TestFailedLoadCache(t *testing.T) {
 	err = GlobalFailedLoadCache.Get(colID)
 	assert.Equal(t, commonpb.ErrorCode_Success, merr.Status(err).ErrorCode)
 }
+
+func TestFailedLoadCacheFieldFailure(t *testing.T) {
+	cache := NewFailedLoadCache()
+
+	colID := int64(1)
+	mockErr := merr.WrapErrIndexNotFound("idx")
+
+	cache.PutFieldFailure(colID, 10, nil, mockErr)
+	cache.PutFieldFailure(colID, 10, []int64{101}, nil)
+	assert.NoError(t, cache.Get(colID))
+
+	cache.Put(colID, merr.WrapErrServiceMemoryLimitExceeded(0, 0))
+	cache.PutFieldFailure(colID, 10, []int64{101}, mockErr)
+	cache.PutFieldFailure(colID, 11, []int64{101, 102}, mockErr)
+	err := cache.Get(colID)
+	assert.ErrorIs(t, err, merr.ErrIndexNotFound)
+	assert.Contains(t, err.Error(), "field 101 of segments [10 11]")
+	assert.Contains(t, err.Error(), "field 102 of segments [11]")
+
+	failed := cache.GetFailedFields(colID)
+	assert.ElementsMatch(t, []int64{10, 11}, failed[101])
+	assert.ElementsMatch(t, []int64{11}, failed[102])
+
+	cache.RemoveFieldFailure(colID, 11, []int64{101, 102})
+	failed = cache.GetFailedFields(colID)
+	assert.ElementsMatch(t, []int64{10}, failed[101])
+	assert.NotContains(t, failed, int64(102))
+
+	cache.mu.Lock()
+	cache.fieldRecords[colID][101].lastTime = time.Now().Add(-expireTime * 2)
+	cache.mu.Unlock()
+	cache.TryExpire()
+	assert.Empty(t, cache.GetFailedFields(colID))
+	// fall back to the error not attributed to fields
+	assert.ErrorIs(t, cache.Get(colID), merr.ErrServiceMemoryLimitExceeded)
+
+	cache.PutFieldFailure(colID, 10, []int64{101}, mockErr)
+	cache.Remove(colID)
+	assert.NoError(t, cache.Get(colID))
+	assert.Empty(t, cache.GetFailedFields(colID))
+}
@@ -50,6 +50,13 @@ type ReplicaInterface interface {
 	ContainRWSQNode(node int64) bool
 }
 
+const (
+	// DefaultReplicaWeight is the routing weight of the replica if it's not set.
+	DefaultReplicaWeight int32 = 100
+	// MaxReplicaWeight is the max routing weight of the replica.
+	MaxReplicaWeight int32 = 100
+)
+
 // NilReplica is used to represent a nil replica.
 var NilReplica = newReplica(&querypb.Replica{
 	ID: -1,
@@ -162,6 +169,14 @@ func (replica *Replica) GetResourceGroup() string {
 	return replica.replicaPB.GetResourceGroup()
 }
 
+// GetWeight returns the routing weight of the replica for the search requests.
+func (replica *Replica) GetWeight() int32 {
+	if replica.replicaPB.GetWeight() <= 0 {
+		return DefaultReplicaWeight
+	}
+	return replica.replicaPB.GetWeight()
+}
+
 // GetNodes returns the rw nodes of the replica.
 // readonly, don't modify the returned slice.
 func (replica *Replica) GetNodes() []int64 {
@@ -329,6 +344,11 @@ func (replica *mutableReplica) SetResourceGroup(resourceGroup string) {
 	replica.replicaPB.ResourceGroup = resourceGroup
 }
 
+// SetWeight sets the routing weight of the replica.
+func (replica *mutableReplica) SetWeight(weight int32) {
+	replica.replicaPB.Weight = weight
+}
+
 // SetWaitRGReadyAt sets the timestamp from which this replica should wait for
 // its resource group to be fully ready before the first node assignment.
 // Pass zero time to clear the wait.
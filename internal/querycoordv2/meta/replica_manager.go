@@ -814,6 +814,25 @@ func (m *ReplicaManager) RemoveNode(ctx context.Context, collectionID typeutil.U
 	return m.put(ctx, collectionID, mutableReplica.IntoReplica())
 }
 
+// SetReplicaWeight sets the routing weight of the given replica, which is used by proxies to distribute the search requests.
+func (m *ReplicaManager) SetReplicaWeight(ctx context.Context, collectionID typeutil.UniqueID, replicaID typeutil.UniqueID, weight int32) error {
+	if weight <= 0 || weight > MaxReplicaWeight {
+		return merr.WrapErrParameterInvalidRange(1, MaxReplicaWeight, weight, "invalid replica weight")
+	}
+
+	m.collLock.Lock(collectionID)
+	defer m.collLock.Unlock(collectionID)
+
+	replica, ok := m.flatReplicas.Get(replicaID)
+	if !ok || replica.GetCollectionID() != collectionID {
+		return merr.WrapErrReplicaNotFound(replicaID)
+	}
+
+	mutableReplica := replica.CopyForWrite()
+	mutableReplica.SetWeight(weight)
+	return m.put(ctx, collectionID, mutableReplica.IntoReplica())
+}
+
 // RemoveSQNode removes the sq node from the given replica.
 func (m *ReplicaManager) RemoveSQNode(ctx context.Context, collectionID typeutil.UniqueID, replicaID typeutil.UniqueID, nodes ...typeutil.UniqueID) error {
 	m.collLock.Lock(collectionID)
@@ -861,6 +880,7 @@ func (m *ReplicaManager) GetReplicasJSON(ctx context.Context, meta *Meta) string
 				ResourceGroup:    r.GetResourceGroup(),
 				RONodes:          r.GetRONodes(),
 				ChannelToRWNodes: channelTowRWNodes,
+				Weight:           r.GetWeight(),
 			})
 		}
 		return true
@@ -889,7 +909,24 @@ func (m *ReplicaManager) RecoverSQNodesInCollection(ctx context.Context, collect
 	if !ok {
 		return merr.WrapErrCollectionNotLoaded(collectionID)
 	}
+	return m.put(ctx, collectionID, m.recoverSQNodes(ctx, collectionID, replicas, sqnNodesByRG, false)...)
+}
 
+// NeedRecoverSQNodesInCollection returns whether RecoverSQNodesInCollection would modify any replica of the collection,
+// without applying the modification.
+func (m *ReplicaManager) NeedRecoverSQNodesInCollection(ctx context.Context, collectionID int64, sqnNodesByRG map[string]typeutil.UniqueSet) bool {
+	m.collLock.Lock(collectionID)
+	defer m.collLock.Unlock(collectionID)
+
+	replicas, ok := m.coll2Replicas.Get(collectionID)
+	if !ok {
+		return false
+	}
+	return len(m.recoverSQNodes(ctx, collectionID, replicas, sqnNodesByRG, true)) > 0
+}
+
+// recoverSQNodes returns the replicas modified by the streaming query node recovery.
+func (m *ReplicaManager) recoverSQNodes(ctx context.Context, collectionID int64, replicas []*Replica, sqnNodesByRG map[string]typeutil.UniqueSet, dryRun bool) []*Replica {
 	// Build helpers based on whether we can use resource group isolation.
 	helpers := m.buildSQNodeAssignmentHelpers(replicas, sqnNodesByRG)
 
@@ -906,20 +943,22 @@ func (m *ReplicaManager) RecoverSQNodesInCollection(ctx context.Context, collect
 			mutableReplica.AddROSQNode(roNodes...)
 			mutableReplica.AddRWSQNode(recoverableNodes...)
 			mutableReplica.AddRWSQNode(incomingNode...)
-			mlog.Info(ctx, "new replica recovery streaming query node found",
-				mlog.FieldCollectionID(collectionID),
-				mlog.Int64("replicaID", assignment.GetReplicaID()),
-				mlog.String("resourceGroup", rgName),
-				mlog.Int64s("newRONodes", roNodes),
-				mlog.Int64s("roToRWNodes", recoverableNodes),
-				mlog.Int64s("newIncomingNodes", incomingNode),
-				mlog.Int64s("rwSQNodes", mutableReplica.GetRWSQNodes()),
-				mlog.Int64s("roSQNodes", mutableReplica.GetROSQNodes()),
-			)
+			if !dryRun {
+				mlog.Info(ctx, "new replica recovery streaming query node found",
+					mlog.FieldCollectionID(collectionID),
+					mlog.Int64("replicaID", assignment.GetReplicaID()),
+					mlog.String("resourceGroup", rgName),
+					mlog.Int64s("newRONodes", roNodes),
+					mlog.Int64s("roToRWNodes", recoverableNodes),
+					mlog.Int64s("newIncomingNodes", incomingNode),
+					mlog.Int64s("rwSQNodes", mutableReplica.GetRWSQNodes()),
+					mlog.Int64s("roSQNodes", mutableReplica.GetROSQNodes()),
+				)
+			}
 			modifiedReplicas = append(modifiedReplicas, mutableReplica.IntoReplica())
 		})
 	}
-	return m.put(ctx, collectionID, modifiedReplicas...)
+	return modifiedReplicas
 }
 
 // buildSQNodeAssignmentHelpers builds assignment helpers for streaming query node recovery.
@@ -39,6 +39,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/messagespb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v3/util/etcd"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
@@ -1062,6 +1063,32 @@ func TestReplicaManagerPutCrossCollectionPersistErrorIsAtomic(t *testing.T) {
 	assert.Empty(t, mgr.GetByCollection(ctx, replica2.GetCollectionID()))
 }
 
+func TestReplicaManagerSetReplicaWeight(t *testing.T) {
+	catalog := mocks.NewQueryCoordCatalog(t)
+	catalog.EXPECT().SaveReplica(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mgr := NewReplicaManager(RandomIncrementIDAllocator(), catalog)
+	ctx := context.Background()
+
+	replica := newReplica(&querypb.Replica{
+		ID:            1,
+		CollectionID:  10,
+		ResourceGroup: "rg1",
+	})
+	assert.NoError(t, mgr.Put(ctx, replica))
+	assert.Equal(t, DefaultReplicaWeight, mgr.Get(ctx, 1).GetWeight())
+
+	assert.ErrorIs(t, mgr.SetReplicaWeight(ctx, 10, 1, 0), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, mgr.SetReplicaWeight(ctx, 10, 1, MaxReplicaWeight+1), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, mgr.SetReplicaWeight(ctx, 20, 1, 10), merr.ErrReplicaNotFound)
+
+	assert.NoError(t, mgr.SetReplicaWeight(ctx, 10, 1, 10))
+	assert.EqualValues(t, 10, mgr.Get(ctx, 1).GetWeight())
+
+	// the weight is kept when the replica is updated
+	assert.NoError(t, mgr.RemoveSQNode(ctx, 10, 1, 100))
+	assert.EqualValues(t, 10, mgr.Get(ctx, 1).GetWeight())
+}
+
 func TestReplicaManagerRemoveCollectionReleasesKVWhenNotLoaded(t *testing.T) {
 	collectionID := int64(10)
 	ctx := context.Background()
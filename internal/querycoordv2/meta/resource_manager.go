@@ -658,6 +658,10 @@ func (rm *ResourceManager) handleNodeDown(ctx context.Context, node int64) {
 
 	// trigger node changes, expected to remove ro node from replica immediately
 	rm.nodeChangedNotifier.NotifyAll()
+	// trigger resource group changes, expected to recover the resource group missing node immediately
+	if rgName != "" {
+		rm.rgChangedNotifier.NotifyAll()
+	}
 	mlog.Info(context.TODO(), "HandleNodeDown: remove node from resource group",
 		mlog.String("rgName", rgName),
 		mlog.Int64("node", node),
@@ -981,7 +985,11 @@ func (rm *ResourceManager) mustSelectAssignIncomingNodeTargetRG(nodeInfo *sessio
 	}
 
 	// Second, assign it to rg do not reach limit.
-	if rg := rm.findMaxRGWithGivenFilter(
+	if paramtable.Get().QueryCoordCfg.RGIncomingNodeAssignPolicy.GetValue() == RGAssignPolicyProportional {
+		if rg := rm.findMinRequestsRatioRG(nodeID); rg != nil {
+			return rg
+		}
+	} else if rg := rm.findMaxRGWithGivenFilter(
 		func(rg *ResourceGroup) bool {
 			return rg.ReachLimitNumOfNodes() > 0 && rg.AcceptNode(nodeID)
 		},
@@ -1010,6 +1018,23 @@ func (rm *ResourceManager) findMaxRGWithGivenFilter(filter func(rg *ResourceGrou
 	return maxRG
 }
 
+// findMinRequestsRatioRG find the resource group with the lowest ratio of node number to requests,
+// resource groups without requests or reaching limits are ignored.
+func (rm *ResourceManager) findMinRequestsRatioRG(nodeID int64) *ResourceGroup {
+	var minRG *ResourceGroup
+	for _, rg := range rm.groups {
+		requests := int(rg.GetConfig().GetRequests().GetNodeNum())
+		if requests <= 0 || rg.ReachLimitNumOfNodes() <= 0 || !rg.AcceptNode(nodeID) {
+			continue
+		}
+		// compare nodeNum/requests without float division.
+		if minRG == nil || rg.NodeNum()*int(minRG.GetConfig().GetRequests().GetNodeNum()) < minRG.NodeNum()*requests {
+			minRG = rg
+		}
+	}
+	return minRG
+}
+
 // transferNode transfer given node to given resource group.
 // if given node is assigned in given resource group, do nothing.
 // if given node is assigned to other resource group, it will be unassigned first.
@@ -634,6 +634,33 @@ func (suite *ResourceManagerSuite) TestIncomingNode() {
 	suite.Len(nodes, 1)
 }
 
+func (suite *ResourceManagerSuite) TestIncomingNodeProportionalPolicy() {
+	ctx := suite.ctx
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.RGIncomingNodeAssignPolicy.Key, RGAssignPolicyProportional)
+	defer paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.RGIncomingNodeAssignPolicy.Key)
+
+	suite.manager.AddResourceGroup(ctx, "rg1", newResourceGroupConfig(10, 20))
+	suite.manager.AddResourceGroup(ctx, "rg2", newResourceGroupConfig(20, 40))
+	for i := 1; i <= 61; i++ {
+		suite.manager.nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   int64(i),
+			Address:  "localhost",
+			Hostname: "localhost",
+		}))
+		suite.manager.HandleNodeUp(ctx, int64(i))
+		// the redundant nodes are assigned by the ratio of node number to requests.
+		if i == 45 {
+			suite.Equal(15, suite.manager.GetResourceGroup(ctx, "rg1").NodeNum())
+			suite.Equal(30, suite.manager.GetResourceGroup(ctx, "rg2").NodeNum())
+			suite.Zero(suite.manager.GetResourceGroup(ctx, DefaultResourceGroupName).NodeNum())
+		}
+	}
+	// fall back to default rg after all rgs reach limits.
+	suite.Equal(20, suite.manager.GetResourceGroup(ctx, "rg1").NodeNum())
+	suite.Equal(40, suite.manager.GetResourceGroup(ctx, "rg2").NodeNum())
+	suite.Equal(1, suite.manager.GetResourceGroup(ctx, DefaultResourceGroupName).NodeNum())
+}
+
 func (suite *ResourceManagerSuite) TestUnassignFail() {
 	ctx := suite.ctx
 	// suite.man
@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"sync"
+)
+
+// SegmentAccessStats accumulates the read counts of the sealed segments reported by the query nodes
+// along with the data distribution. The counts are cumulative over all the replicas, the consumers
+// compute the read frequency by the difference between two samples. All the methods are no-op on nil.
+type SegmentAccessStats struct {
+	mu     sync.RWMutex
+	counts map[int64]int64
+}
+
+func NewSegmentAccessStats() *SegmentAccessStats {
+	return &SegmentAccessStats{
+		counts: make(map[int64]int64),
+	}
+}
+
+// Add adds the read counts reported by a query node.
+func (s *SegmentAccessStats) Add(counts map[int64]int64) {
+	if s == nil || len(counts) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for segmentID, count := range counts {
+		s.counts[segmentID] += count
+	}
+}
+
+// Get returns the accumulated read counts of the given segments, the segments never read are returned with zero.
+func (s *SegmentAccessStats) Get(segmentIDs ...int64) map[int64]int64 {
+	counts := make(map[int64]int64, len(segmentIDs))
+	if s == nil {
+		return counts
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, segmentID := range segmentIDs {
+		counts[segmentID] = s.counts[segmentID]
+	}
+	return counts
+}
+
+// Remove removes the counts of the given segments, e.g. the released or compacted ones.
+func (s *SegmentAccessStats) Remove(segmentIDs ...int64) {
+	if s == nil || len(segmentIDs) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, segmentID := range segmentIDs {
+		delete(s.counts, segmentID)
+	}
+}
@@ -0,0 +1,38 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegmentAccessStats(t *testing.T) {
+	stats := NewSegmentAccessStats()
+	stats.Add(map[int64]int64{1: 10, 2: 5})
+	stats.Add(map[int64]int64{1: 3, 3: 1})
+	assert.Equal(t, map[int64]int64{1: 13, 2: 5, 4: 0}, stats.Get(1, 2, 4))
+
+	stats.Remove(2, 4)
+	assert.Equal(t, map[int64]int64{1: 13, 2: 0, 3: 1}, stats.Get(1, 2, 3))
+
+	var nilStats *SegmentAccessStats
+	nilStats.Add(map[int64]int64{1: 1})
+	nilStats.Remove(1)
+	assert.Equal(t, map[int64]int64{1: 0}, nilStats.Get(1))
+}
@@ -287,6 +287,8 @@ func (mgr *TargetManager) RemovePartition(ctx context.Context, collectionID int6
 // remove partition from next target
 // NOTE: don't edit current target directly, it will be updated by target observer, which push the new next target as current target
 // need the full progress to update next target to current target, so the query view on delegator could be updated when current target is updated
+// If the next target doesn't exist, it's shrunk from the current target, so the other partitions keep serving
+// with the same segments and channels instead of waiting for a rebuilt target.
 func (mgr *TargetManager) RemovePartitionFromNextTarget(ctx context.Context, collectionID int64, partitionIDs ...int64) {
 	log := mlog.With(mlog.FieldCollectionID(collectionID),
 		mlog.Int64s("PartitionIDs", partitionIDs))
@@ -295,6 +297,9 @@ func (mgr *TargetManager) RemovePartitionFromNextTarget(ctx context.Context, col
 
 	log.Info(ctx, "remove partition from next target")
 	oleNextTarget := mgr.next.getCollectionTarget(collectionID)
+	if oleNextTarget == nil {
+		oleNextTarget = mgr.current.getCollectionTarget(collectionID)
+	}
 	if oleNextTarget != nil {
 		newTarget := mgr.removePartitionFromCollectionTarget(oleNextTarget, partitionSet)
 		if newTarget != nil {
@@ -283,6 +283,14 @@ func (suite *TargetManagerSuite) TestRemovePartitionFromNextTarget() {
 	suite.assertChannels(suite.channels[collectionID], suite.mgr.GetDmChannelsByCollection(ctx, collectionID, NextTarget))
 	suite.assertSegments(suite.getAllSegment(collectionID, suite.partitions[collectionID]), suite.mgr.GetSealedSegmentsByCollection(ctx, collectionID, CurrentTarget))
 	suite.assertChannels(suite.channels[collectionID], suite.mgr.GetDmChannelsByCollection(ctx, collectionID, CurrentTarget))
+
+	// the next target is shrunk from the current target if not exist
+	suite.True(suite.mgr.UpdateCollectionCurrentTarget(ctx, collectionID))
+	suite.False(suite.mgr.IsNextTargetExist(ctx, collectionID))
+	suite.mgr.RemovePartitionFromNextTarget(ctx, collectionID, 101)
+	suite.assertSegments([]int64{}, suite.mgr.GetSealedSegmentsByCollection(ctx, collectionID, NextTarget))
+	suite.assertChannels(suite.channels[collectionID], suite.mgr.GetDmChannelsByCollection(ctx, collectionID, NextTarget))
+	suite.assertSegments([]int64{3, 4}, suite.mgr.GetSealedSegmentsByCollection(ctx, collectionID, CurrentTarget))
 }
 
 func (suite *TargetManagerSuite) TestRemoveCollection() {
@@ -0,0 +1,156 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// PartitionRepairFunc applies the expected loaded partitions to the collection.
+type PartitionRepairFunc func(ctx context.Context, collectionID int64, partitionIDs []int64) error
+
+// PartitionObserver cross-checks the loaded partitions with the partitions of the collection in rootcoord.
+// The loaded partitions dropped upstream are released, and the partitions created upstream are loaded
+// if the whole collection is loaded.
+type PartitionObserver struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	meta   *meta.Meta
+	broker meta.Broker
+	repair PartitionRepairFunc
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+func NewPartitionObserver(meta *meta.Meta, broker meta.Broker, repair PartitionRepairFunc) *PartitionObserver {
+	return &PartitionObserver{
+		meta:   meta,
+		broker: broker,
+		repair: repair,
+	}
+}
+
+func (ob *PartitionObserver) Start() {
+	ob.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background()) //nolint:gosec // cancel is stored and called in Stop()
+		ob.cancel = cancel
+
+		ob.wg.Add(1)
+		go ob.schedule(ctx)
+	})
+}
+
+func (ob *PartitionObserver) Stop() {
+	ob.stopOnce.Do(func() {
+		if ob.cancel != nil {
+			ob.cancel()
+		}
+		ob.wg.Wait()
+	})
+}
+
+func (ob *PartitionObserver) schedule(ctx context.Context) {
+	defer ob.wg.Done()
+	mlog.Info(ctx, "Start check partition loop")
+
+	ticker := time.NewTicker(params.Params.QueryCoordCfg.CheckPartitionInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			mlog.Info(ctx, "Close partition observer")
+			return
+		case <-ticker.C:
+			if params.Params.QueryCoordCfg.EnablePartitionAutoRepair.GetAsBool() {
+				ob.checkAndRepairPartitions(ctx)
+			}
+		}
+	}
+}
+
+func (ob *PartitionObserver) checkAndRepairPartitions(ctx context.Context) {
+	collections := ob.meta.GetAllCollections(ctx)
+	for _, collection := range collections {
+		// the loading collections are left to the load job.
+		if collection.GetStatus() != querypb.LoadStatus_Loaded {
+			continue
+		}
+		ob.checkCollection(ctx, collection)
+	}
+}
+
+func (ob *PartitionObserver) checkCollection(ctx context.Context, collection *meta.Collection) {
+	collectionID := collection.GetCollectionID()
+	logger := mlog.With(mlog.FieldCollectionID(collectionID))
+	partitionIDs, err := ob.broker.GetPartitions(ctx, collectionID)
+	if err != nil {
+		// the dropped collection is released by the drop collection callback.
+		if !errors.Is(err, merr.ErrCollectionNotFound) {
+			logger.Warn(ctx, "failed to get partitions of collection", mlog.Err(err))
+		}
+		return
+	}
+	upstream := typeutil.NewUniqueSet(partitionIDs...)
+	loaded := typeutil.NewUniqueSet(ob.meta.GetPartitionIDsByCollection(ctx, collectionID)...)
+
+	expected := typeutil.NewUniqueSet()
+	dropped := make([]int64, 0)
+	for partitionID := range loaded {
+		if upstream.Contain(partitionID) {
+			expected.Insert(partitionID)
+		} else {
+			dropped = append(dropped, partitionID)
+		}
+	}
+	// the collection loaded by partitions doesn't load the new partitions.
+	added := make([]int64, 0)
+	if collection.GetLoadType() == querypb.LoadType_LoadCollection {
+		for partitionID := range upstream {
+			if !loaded.Contain(partitionID) {
+				added = append(added, partitionID)
+				expected.Insert(partitionID)
+			}
+		}
+	}
+	if len(dropped) == 0 && len(added) == 0 {
+		return
+	}
+
+	logger = logger.With(mlog.Int64s("droppedPartitions", dropped), mlog.Int64s("addedPartitions", added))
+	if expected.Len() == 0 {
+		logger.Warn(ctx, "all loaded partitions are dropped upstream, skip repairing partitions")
+		return
+	}
+	logger.Info(ctx, "found loaded partitions inconsistent with upstream, try to repair")
+	if err := ob.repair(ctx, collectionID, expected.Collect()); err != nil {
+		logger.Warn(ctx, "failed to repair partitions", mlog.Err(err))
+		return
+	}
+	logger.Info(ctx, "repair partitions done")
+}
@@ -0,0 +1,112 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestPartitionObserver(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	store := mocks.NewQueryCoordCatalog(t)
+	store.EXPECT().SaveCollection(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m := meta.NewMeta(params.RandomIncrementIDAllocator(), store, session.NewNodeManager())
+	newPartition := func(collectionID, partitionID int64) *meta.Partition {
+		return &meta.Partition{PartitionLoadInfo: &querypb.PartitionLoadInfo{
+			CollectionID: collectionID,
+			PartitionID:  partitionID,
+			Status:       querypb.LoadStatus_Loaded,
+		}}
+	}
+	err := m.PutCollection(ctx, &meta.Collection{CollectionLoadInfo: &querypb.CollectionLoadInfo{
+		CollectionID: 1,
+		Status:       querypb.LoadStatus_Loaded,
+		LoadType:     querypb.LoadType_LoadCollection,
+	}}, newPartition(1, 10), newPartition(1, 11))
+	assert.NoError(t, err)
+
+	broker := meta.NewMockBroker(t)
+	repaired := make(map[int64][]int64)
+	var repairErr error
+	ob := NewPartitionObserver(m, broker, func(ctx context.Context, collectionID int64, partitionIDs []int64) error {
+		sort.Slice(partitionIDs, func(i, j int) bool { return partitionIDs[i] < partitionIDs[j] })
+		repaired[collectionID] = partitionIDs
+		return repairErr
+	})
+
+	// nothing to repair.
+	broker.EXPECT().GetPartitions(mock.Anything, int64(1)).Return([]int64{10, 11}, nil).Once()
+	ob.checkAndRepairPartitions(ctx)
+	assert.Empty(t, repaired)
+
+	// the new partition is loaded since the whole collection is loaded.
+	broker.EXPECT().GetPartitions(mock.Anything, int64(1)).Return([]int64{10, 11, 12}, nil).Once()
+	ob.checkAndRepairPartitions(ctx)
+	assert.Equal(t, []int64{10, 11, 12}, repaired[1])
+
+	// the partition dropped upstream is released.
+	delete(repaired, 1)
+	broker.EXPECT().GetPartitions(mock.Anything, int64(1)).Return([]int64{10}, nil).Once()
+	ob.checkAndRepairPartitions(ctx)
+	assert.Equal(t, []int64{10}, repaired[1])
+
+	// the repair failure is retried in the next round.
+	delete(repaired, 1)
+	repairErr = errors.New("mock")
+	broker.EXPECT().GetPartitions(mock.Anything, int64(1)).Return([]int64{10, 11, 12}, nil).Once()
+	ob.checkAndRepairPartitions(ctx)
+	assert.Equal(t, []int64{10, 11, 12}, repaired[1])
+
+	// the dropped collection is skipped.
+	delete(repaired, 1)
+	broker.EXPECT().GetPartitions(mock.Anything, int64(1)).Return(nil, merr.WrapErrCollectionNotFound(1)).Once()
+	ob.checkAndRepairPartitions(ctx)
+	assert.Empty(t, repaired)
+
+	// the collection loaded by partitions doesn't load the new partitions.
+	repairErr = nil
+	err = m.PutCollection(ctx, &meta.Collection{CollectionLoadInfo: &querypb.CollectionLoadInfo{
+		CollectionID: 2,
+		Status:       querypb.LoadStatus_Loaded,
+		LoadType:     querypb.LoadType_LoadPartition,
+	}}, newPartition(2, 20), newPartition(2, 21))
+	assert.NoError(t, err)
+	store.EXPECT().ReleaseCollection(mock.Anything, int64(1)).Return(nil)
+	assert.NoError(t, m.CollectionManager.RemoveCollection(ctx, 1))
+	broker.EXPECT().GetPartitions(mock.Anything, int64(2)).Return([]int64{20, 21, 22}, nil).Once()
+	ob.checkAndRepairPartitions(ctx)
+	assert.Empty(t, repaired)
+	broker.EXPECT().GetPartitions(mock.Anything, int64(2)).Return([]int64{20, 22}, nil).Once()
+	ob.checkAndRepairPartitions(ctx)
+	assert.Equal(t, []int64{20}, repaired[2])
+}
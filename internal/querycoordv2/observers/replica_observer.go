@@ -30,18 +30,21 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/internal/util/streamingutil"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/syncutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
-// check replica, find read only nodes and remove it from replica if all segment/channel has been moved
+// check replica, find read only nodes and remove it from replica if all segment/channel has been moved.
+// In advisory mode, the node-in-replica changes are held as suggestions until approved or pending longer than the apply delay.
 type ReplicaObserver struct {
-	cancel    context.CancelFunc
-	wg        sync.WaitGroup
-	meta      *meta.Meta
-	distMgr   *meta.DistributionManager
-	targetMgr meta.TargetManagerInterface
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	meta        *meta.Meta
+	distMgr     *meta.DistributionManager
+	targetMgr   meta.TargetManagerInterface
+	suggestions *replicaSuggestions
 
 	startOnce sync.Once
 	stopOnce  sync.Once
@@ -49,12 +52,34 @@ type ReplicaObserver struct {
 
 func NewReplicaObserver(meta *meta.Meta, distMgr *meta.DistributionManager, targetMgr meta.TargetManagerInterface) *ReplicaObserver {
 	return &ReplicaObserver{
-		meta:      meta,
-		distMgr:   distMgr,
-		targetMgr: targetMgr,
+		meta:        meta,
+		distMgr:     distMgr,
+		targetMgr:   targetMgr,
+		suggestions: newReplicaSuggestions(),
 	}
 }
 
+// ListSuggestions returns the pending node-in-replica changes of the collection, or of all collections if collectionID is 0.
+func (ob *ReplicaObserver) ListSuggestions(collectionID int64) []*querypb.ReplicaSuggestion {
+	return ob.suggestions.list(collectionID)
+}
+
+// ApproveSuggestions approves the pending changes matching the filter, they're applied by the next check.
+// It returns the number of the approved suggestions.
+func (ob *ReplicaObserver) ApproveSuggestions(collectionID int64, replicaID int64, nodeIDs []int64) int {
+	return ob.suggestions.approve(collectionID, replicaID, nodeIDs)
+}
+
+// allowChange returns whether the change could be applied now, it's always true if advisory mode is disabled.
+// Otherwise the change is recorded as a suggestion and allowed after approved or pending longer than the apply delay.
+func (ob *ReplicaObserver) allowChange(key replicaSuggestionKey, found typeutil.Set[replicaSuggestionKey]) bool {
+	if !params.Params.QueryCoordCfg.ReplicaAdvisoryModeEnabled.GetAsBool() {
+		return true
+	}
+	found.Insert(key)
+	return ob.suggestions.suggest(key)
+}
+
 func (ob *ReplicaObserver) Start() {
 	ob.startOnce.Do(func() {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -124,8 +149,28 @@ func (ob *ReplicaObserver) checkStreamingQueryNodesInReplica(sqNodeIDsByRG map[s
 	ctx := context.Background()
 
 	collections := ob.meta.GetAll(context.Background())
+	found := typeutil.NewSet[replicaSuggestionKey]()
+	defer func() {
+		ob.suggestions.retain(found, querypb.ReplicaSuggestionType_RecoverSQNodes, querypb.ReplicaSuggestionType_RemoveROSQNode)
+	}()
 
 	for _, collectionID := range collections {
+		if params.Params.QueryCoordCfg.ReplicaAdvisoryModeEnabled.GetAsBool() {
+			if !ob.meta.NeedRecoverSQNodesInCollection(ctx, collectionID, sqNodeIDsByRG) {
+				continue
+			}
+			key := replicaSuggestionKey{suggestionType: querypb.ReplicaSuggestionType_RecoverSQNodes, collectionID: collectionID}
+			if !ob.allowChange(key, found) {
+				mlog.RatedInfo(ctx, rate.Limit(10), "streaming query node recovery is pending for approval", mlog.FieldCollectionID(collectionID))
+				continue
+			}
+			if err := ob.meta.RecoverSQNodesInCollection(ctx, collectionID, sqNodeIDsByRG); err != nil {
+				mlog.Warn(ctx, "fail to recover streaming query node of collection", mlog.FieldCollectionID(collectionID), mlog.Err(err))
+				continue
+			}
+			ob.suggestions.remove(key)
+			continue
+		}
 		ob.meta.RecoverSQNodesInCollection(context.Background(), collectionID, sqNodeIDsByRG)
 	}
 
@@ -141,7 +186,12 @@ func (ob *ReplicaObserver) checkStreamingQueryNodesInReplica(sqNodeIDsByRG map[s
 			for _, node := range roSQNodes {
 				channels := ob.distMgr.ChannelDistManager.GetByFilter(meta.WithCollectionID2Channel(collectionID), meta.WithNodeID2Channel(node))
 				segments := ob.distMgr.SegmentDistManager.GetByFilter(meta.WithCollectionID(collectionID), meta.WithNodeID(node))
-				if len(channels) == 0 && len(segments) == 0 {
+				if len(channels) == 0 && len(segments) == 0 && ob.allowChange(replicaSuggestionKey{
+					suggestionType: querypb.ReplicaSuggestionType_RemoveROSQNode,
+					collectionID:   collectionID,
+					replicaID:      replica.GetID(),
+					nodeID:         node,
+				}, found) {
 					removeNodes = append(removeNodes, node)
 				}
 			}
@@ -159,6 +209,7 @@ func (ob *ReplicaObserver) checkStreamingQueryNodesInReplica(sqNodeIDsByRG map[s
 				logger.Warn(context.TODO(), "fail to remove streaming query node from replica", mlog.Err(err))
 				continue
 			}
+			ob.removeSuggestions(querypb.ReplicaSuggestionType_RemoveROSQNode, collectionID, replica.GetID(), removeNodes)
 			logger.Info(context.TODO(), "all segment/channel has been removed from ro streaming query node, remove it from replica")
 		}
 	}
@@ -175,6 +226,11 @@ func (ob *ReplicaObserver) checkNodesInReplica() {
 	balancePolicy := paramtable.Get().QueryCoordCfg.Balancer.GetValue()
 	enableChannelExclusiveMode := balancePolicy == meta.ChannelLevelScoreBalancerName
 
+	found := typeutil.NewSet[replicaSuggestionKey]()
+	defer func() {
+		ob.suggestions.retain(found, querypb.ReplicaSuggestionType_RemoveRONode)
+	}()
+
 	// check all ro nodes, remove it from replica if all segment/channel has been moved
 	for _, collectionID := range collections {
 		replicas := ob.meta.GetByCollection(ctx, collectionID)
@@ -206,7 +262,12 @@ func (ob *ReplicaObserver) checkNodesInReplica() {
 			for _, node := range roNodes {
 				channels := ob.distMgr.ChannelDistManager.GetByFilter(meta.WithCollectionID2Channel(collectionID), meta.WithNodeID2Channel(node))
 				segments := ob.distMgr.SegmentDistManager.GetByFilter(meta.WithCollectionID(collectionID), meta.WithNodeID(node))
-				if len(channels) == 0 && len(segments) == 0 {
+				if len(channels) == 0 && len(segments) == 0 && ob.allowChange(replicaSuggestionKey{
+					suggestionType: querypb.ReplicaSuggestionType_RemoveRONode,
+					collectionID:   collectionID,
+					replicaID:      replica.GetID(),
+					nodeID:         node,
+				}, found) {
 					removeNodes = append(removeNodes, node)
 				}
 			}
@@ -219,6 +280,7 @@ func (ob *ReplicaObserver) checkNodesInReplica() {
 					mlog.Err(err))
 				continue
 			}
+			ob.removeSuggestions(querypb.ReplicaSuggestionType_RemoveRONode, collectionID, replica.GetID(), removeNodes)
 			hasNodeRemoved = true
 			logger.Info(context.TODO(), "all segment/channel has been removed from ro node, remove it from replica",
 				mlog.Int64s("removedNodes", removeNodes),
@@ -229,3 +291,10 @@ func (ob *ReplicaObserver) checkNodesInReplica() {
 		}
 	}
 }
+
+// removeSuggestions removes the suggestions of the applied node removals.
+func (ob *ReplicaObserver) removeSuggestions(suggestionType querypb.ReplicaSuggestionType, collectionID int64, replicaID int64, nodes []int64) {
+	ob.suggestions.remove(lo.Map(nodes, func(node int64, _ int) replicaSuggestionKey {
+		return replicaSuggestionKey{suggestionType: suggestionType, collectionID: collectionID, replicaID: replicaID, nodeID: node}
+	})...)
+}
@@ -236,6 +236,62 @@ func (suite *ReplicaObserverSuite) TestCheckNodesInReplica() {
 	}, 30*time.Second, 2*time.Second)
 }
 
+func (suite *ReplicaObserverSuite) TestAdvisoryMode() {
+	suite.observer.Stop()
+	ctx := suite.ctx
+	paramtable.Get().Save(Params.QueryCoordCfg.ReplicaAdvisoryModeEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.QueryCoordCfg.ReplicaAdvisoryModeEnabled.Key)
+
+	suite.nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+		NodeID:   1,
+		Address:  "localhost:8080",
+		Hostname: "localhost",
+	}))
+	suite.meta.HandleNodeUp(ctx, 1)
+	err := suite.meta.PutCollection(ctx, utils.CreateTestCollection(suite.collectionID, 1))
+	suite.NoError(err)
+	err = suite.meta.Put(ctx, meta.NewReplica(&querypb.Replica{
+		ID:            1,
+		CollectionID:  suite.collectionID,
+		Nodes:         []int64{1},
+		RoNodes:       []int64{2},
+		ResourceGroup: meta.DefaultResourceGroupName,
+	}, typeutil.NewUniqueSet(1)))
+	suite.NoError(err)
+
+	// the drained ro node is held as suggestion
+	suite.observer.checkNodesInReplica()
+	suite.Equal([]int64{2}, suite.meta.Get(ctx, 1).GetRONodes())
+	suggestions := suite.observer.ListSuggestions(suite.collectionID)
+	suite.Len(suggestions, 1)
+	suite.Equal(querypb.ReplicaSuggestionType_RemoveRONode, suggestions[0].GetType())
+	suite.Equal(int64(1), suggestions[0].GetReplicaID())
+	suite.Equal(int64(2), suggestions[0].GetNodeID())
+	suite.False(suggestions[0].GetApproved())
+	suite.Empty(suite.observer.ListSuggestions(suite.collectionID + 1))
+
+	// the suggestion is applied after approved
+	suite.Zero(suite.observer.ApproveSuggestions(suite.collectionID, 1, []int64{3}))
+	suite.Equal(1, suite.observer.ApproveSuggestions(suite.collectionID, 1, []int64{2}))
+	suite.True(suite.observer.ListSuggestions(0)[0].GetApproved())
+	suite.observer.checkNodesInReplica()
+	suite.Empty(suite.meta.Get(ctx, 1).GetRONodes())
+	suite.Empty(suite.observer.ListSuggestions(0))
+
+	// the suggestion is applied after pending longer than the apply delay
+	replica := suite.meta.Get(ctx, 1).CopyForWrite()
+	replica.AddRONode(2)
+	suite.NoError(suite.meta.Put(ctx, replica.IntoReplica()))
+	suite.observer.checkNodesInReplica()
+	suite.Len(suite.observer.ListSuggestions(0), 1)
+	paramtable.Get().Save(Params.QueryCoordCfg.ReplicaSuggestionApplyDelay.Key, "1")
+	defer paramtable.Get().Reset(Params.QueryCoordCfg.ReplicaSuggestionApplyDelay.Key)
+	time.Sleep(time.Second)
+	suite.observer.checkNodesInReplica()
+	suite.Empty(suite.meta.Get(ctx, 1).GetRONodes())
+	suite.Empty(suite.observer.ListSuggestions(0))
+}
+
 func (suite *ReplicaObserverSuite) TestCheckSQnodesInReplica() {
 	suite.observer.Stop()
 	snmanager.ResetStreamingNodeManager()
@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+type replicaSuggestionKey struct {
+	suggestionType querypb.ReplicaSuggestionType
+	collectionID   int64
+	replicaID      int64
+	nodeID         int64
+}
+
+type replicaSuggestion struct {
+	createTime time.Time
+	approved   bool
+}
+
+// replicaSuggestions holds the node-in-replica changes found by the replica observer in advisory mode.
+// The suggestions are kept in memory only, they're found again by the observer after the coordinator restarts.
+type replicaSuggestions struct {
+	mu          sync.Mutex
+	suggestions map[replicaSuggestionKey]*replicaSuggestion
+}
+
+func newReplicaSuggestions() *replicaSuggestions {
+	return &replicaSuggestions{
+		suggestions: make(map[replicaSuggestionKey]*replicaSuggestion),
+	}
+}
+
+// suggest records the change if it's not recorded yet, and returns whether the change could be applied now,
+// i.e. it's approved or pending longer than the apply delay.
+func (s *replicaSuggestions) suggest(key replicaSuggestionKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	suggestion, ok := s.suggestions[key]
+	if !ok {
+		suggestion = &replicaSuggestion{createTime: time.Now()}
+		s.suggestions[key] = suggestion
+	}
+	if suggestion.approved {
+		return true
+	}
+	delay := paramtable.Get().QueryCoordCfg.ReplicaSuggestionApplyDelay.GetAsDuration(time.Second)
+	return delay > 0 && time.Since(suggestion.createTime) >= delay
+}
+
+// retain removes the suggestions of the given types not found by the latest check,
+// e.g. the read only node turns back to read write node before the suggestion is applied.
+func (s *replicaSuggestions) retain(found typeutil.Set[replicaSuggestionKey], types ...querypb.ReplicaSuggestionType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	retainTypes := typeutil.NewSet(types...)
+	for key := range s.suggestions {
+		if retainTypes.Contain(key.suggestionType) && !found.Contain(key) {
+			delete(s.suggestions, key)
+		}
+	}
+}
+
+// remove removes the applied suggestions.
+func (s *replicaSuggestions) remove(keys ...replicaSuggestionKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		delete(s.suggestions, key)
+	}
+}
+
+// list returns the suggestions of the collection, or all suggestions if collectionID is 0.
+func (s *replicaSuggestions) list(collectionID int64) []*querypb.ReplicaSuggestion {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*querypb.ReplicaSuggestion, 0, len(s.suggestions))
+	for key, suggestion := range s.suggestions {
+		if collectionID != 0 && key.collectionID != collectionID {
+			continue
+		}
+		result = append(result, &querypb.ReplicaSuggestion{
+			Type:         key.suggestionType,
+			CollectionID: key.collectionID,
+			ReplicaID:    key.replicaID,
+			NodeID:       key.nodeID,
+			CreateTime:   suggestion.createTime.UnixMilli(),
+			Approved:     suggestion.approved,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].GetCreateTime() != result[j].GetCreateTime() {
+			return result[i].GetCreateTime() < result[j].GetCreateTime()
+		}
+		if result[i].GetCollectionID() != result[j].GetCollectionID() {
+			return result[i].GetCollectionID() < result[j].GetCollectionID()
+		}
+		if result[i].GetReplicaID() != result[j].GetReplicaID() {
+			return result[i].GetReplicaID() < result[j].GetReplicaID()
+		}
+		return result[i].GetNodeID() < result[j].GetNodeID()
+	})
+	return result
+}
+
+// approve approves the suggestions matching the filter and returns the number of them,
+// the zero value of collectionID, replicaID or empty nodeIDs matches all.
+func (s *replicaSuggestions) approve(collectionID int64, replicaID int64, nodeIDs []int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodes := typeutil.NewSet(nodeIDs...)
+	approved := 0
+	for key, suggestion := range s.suggestions {
+		if (collectionID != 0 && key.collectionID != collectionID) ||
+			(replicaID != 0 && key.replicaID != replicaID) ||
+			(nodes.Len() > 0 && !nodes.Contain(key.nodeID)) {
+			continue
+		}
+		suggestion.approved = true
+		approved++
+	}
+	return approved
+}
@@ -29,12 +29,14 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v3/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/lock"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
@@ -242,6 +244,11 @@ func (ob *TargetObserver) schedule(ctx context.Context) {
 				ob.keylocks.Lock(req.CollectionID)
 				ob.targetMgr.RemovePartitionFromNextTarget(ctx, req.CollectionID, req.PartitionIDs...)
 				ob.keylocks.Unlock(req.CollectionID)
+				if req.ReadyNotifier != nil {
+					ob.mut.Lock()
+					ob.readyNotifiers[req.CollectionID] = append(ob.readyNotifiers[req.CollectionID], req.ReadyNotifier)
+					ob.mut.Unlock()
+				}
 				req.Notifier <- nil
 			case UpdatePartition:
 				// Fast path: check with read lock first
@@ -311,7 +318,7 @@ func (ob *TargetObserver) check(ctx context.Context, collectionID int64) {
 		ob.updateCurrentTarget(ctx, collectionID)
 	}
 
-	if ob.shouldUpdateNextTarget(ctx, collectionID) {
+	if ob.shouldUpdateNextTarget(ctx, collectionID) && !ob.isTargetFrozen(ctx, collectionID) {
 		// update next target in collection level
 		ob.updateNextTarget(ctx, collectionID)
 
@@ -394,6 +401,24 @@ func (ob *TargetObserver) ReleasePartition(collectionID int64, partitionID ...in
 	<-notifier
 }
 
+// ShrinkPartition removes the partitions from the next target, which is shrunk from the current target if not exist,
+// so the other partitions keep serving without a rebuilt target.
+// The returned channel is closed once the shrunk target becomes the current target.
+func (ob *TargetObserver) ShrinkPartition(collectionID int64, partitionID ...int64) chan struct{} {
+	notifier := make(chan error)
+	readyCh := make(chan struct{})
+	defer close(notifier)
+	ob.updateChan <- targetUpdateRequest{
+		CollectionID:  collectionID,
+		PartitionIDs:  partitionID,
+		opType:        ReleasePartition,
+		Notifier:      notifier,
+		ReadyNotifier: readyCh,
+	}
+	<-notifier
+	return readyCh
+}
+
 func (ob *TargetObserver) clean() {
 	collectionSet := typeutil.NewUniqueSet(ob.meta.GetAll(context.TODO())...)
 	// for collection which has been removed from target, try to clear nextTargetLastUpdate
@@ -428,6 +453,43 @@ func (ob *TargetObserver) isNextTargetExpired(collectionID int64) bool {
 	return time.Since(lastUpdated) > params.Params.QueryCoordCfg.NextTargetSurviveTime.GetAsDuration(time.Second)
 }
 
+// isTargetFrozen returns whether the collection is frozen and its latest target already covers the freeze timestamp.
+// The next target of the frozen collection is not updated any more, so the segments flushed after the freeze are not loaded,
+// the freeze state is checked again after the next target expires.
+func (ob *TargetObserver) isTargetFrozen(ctx context.Context, collectionID int64) bool {
+	scope := meta.NextTarget
+	if !ob.targetMgr.IsNextTargetExist(ctx, collectionID) {
+		scope = meta.CurrentTarget
+	}
+	channels := ob.targetMgr.GetDmChannelsByCollection(ctx, collectionID, scope)
+	if len(channels) == 0 {
+		return false
+	}
+
+	collection, err := ob.broker.DescribeCollection(ctx, collectionID)
+	if err != nil {
+		mlog.RatedWarn(ctx, rate.Limit(10), "failed to describe collection to check freeze state",
+			mlog.FieldCollectionID(collectionID),
+			mlog.Err(err))
+		return false
+	}
+	freezeTs := common.GetCollectionFreezeTimestamp(funcutil.KeyValuePair2Map(collection.GetProperties()))
+	if freezeTs == 0 {
+		return false
+	}
+	for _, channel := range channels {
+		if channel.GetSeekPosition().GetTimestamp() < freezeTs {
+			return false
+		}
+	}
+
+	mlog.RatedInfo(ctx, rate.Limit(10), "skip updating next target of frozen collection",
+		mlog.FieldCollectionID(collectionID),
+		mlog.Uint64("freezeTs", freezeTs))
+	ob.updateNextTargetTimestamp(collectionID)
+	return true
+}
+
 func (ob *TargetObserver) updateNextTarget(ctx context.Context, collectionID int64) error {
 	log := mlog.With(mlog.FieldCollectionID(collectionID))
 
@@ -18,6 +18,7 @@ package observers
 
 import (
 	"context"
+	"strconv"
 	"testing"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/msgpb"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/metastore/kv/querycoord"
@@ -377,6 +379,29 @@ func (suite *TargetObserverSuite) TestTriggerRelease() {
 	suite.observer.ReleaseCollection(suite.collectionID)
 }
 
+func (suite *TargetObserverSuite) TestShrinkPartition() {
+	ctx := suite.ctx
+	_, err := suite.observer.UpdateNextTarget(suite.collectionID)
+	suite.NoError(err)
+	suite.Len(suite.targetMgr.GetSealedSegmentsByCollection(ctx, suite.collectionID, meta.NextTarget), 2)
+
+	ready := suite.observer.ShrinkPartition(suite.collectionID, suite.partitionID)
+	suite.NotNil(ready)
+	suite.Len(suite.targetMgr.GetSealedSegmentsByCollection(ctx, suite.collectionID, meta.NextTarget), 0)
+	suite.Len(suite.targetMgr.GetDmChannelsByCollection(ctx, suite.collectionID, meta.NextTarget), 2)
+
+	// the ready notifier is closed if the collection is released before the shrunk target becomes current
+	suite.meta.CollectionManager.RemoveCollection(ctx, suite.collectionID)
+	suite.Eventually(func() bool {
+		select {
+		case <-ready:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 func (suite *TargetObserverSuite) TearDownTest() {
 	suite.kv.Close()
 	suite.observer.Stop()
@@ -1094,6 +1119,50 @@ func TestShouldUpdateCurrentTarget_NoReadyDelegators(t *testing.T) {
 	assert.False(t, result, "Expected false when NO ready delegators exist")
 }
 
+func TestIsTargetFrozen(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	collectionID := int64(1000)
+
+	nodeMgr := session.NewNodeManager()
+	targetMgr := meta.NewMockTargetManager(t)
+	broker := meta.NewMockBroker(t)
+	metaInstance := &meta.Meta{
+		CollectionManager: meta.NewCollectionManager(nil),
+	}
+	observer := NewTargetObserver(metaInstance, targetMgr, meta.NewDistributionManager(nodeMgr), broker, session.NewMockCluster(t), nodeMgr)
+
+	freezeTs := uint64(1000)
+	channelTs := uint64(900)
+	targetMgr.EXPECT().IsNextTargetExist(mock.Anything, collectionID).Return(false)
+	targetMgr.EXPECT().GetDmChannelsByCollection(mock.Anything, collectionID, meta.CurrentTarget).RunAndReturn(
+		func(ctx context.Context, collectionID int64, scope meta.TargetScope) map[string]*meta.DmChannel {
+			return map[string]*meta.DmChannel{
+				"ch1": {VchannelInfo: &datapb.VchannelInfo{ChannelName: "ch1", SeekPosition: &msgpb.MsgPosition{Timestamp: channelTs}}},
+				"ch2": {VchannelInfo: &datapb.VchannelInfo{ChannelName: "ch2", SeekPosition: &msgpb.MsgPosition{Timestamp: 2000}}},
+			}
+		})
+	properties := []*commonpb.KeyValuePair{}
+	broker.EXPECT().DescribeCollection(mock.Anything, collectionID).RunAndReturn(
+		func(ctx context.Context, collectionID int64) (*milvuspb.DescribeCollectionResponse, error) {
+			return &milvuspb.DescribeCollectionResponse{Properties: properties}, nil
+		})
+
+	// not frozen.
+	assert.False(t, observer.isTargetFrozen(ctx, collectionID))
+
+	// frozen, but the target doesn't cover the freeze timestamp yet.
+	properties = []*commonpb.KeyValuePair{{Key: common.CollectionFreezeTimestampKey, Value: strconv.FormatUint(freezeTs, 10)}}
+	assert.False(t, observer.isTargetFrozen(ctx, collectionID))
+	_, ok := observer.nextTargetLastUpdate.Get(collectionID)
+	assert.False(t, ok)
+
+	// the target covers the freeze timestamp.
+	channelTs = freezeTs
+	assert.True(t, observer.isTargetFrozen(ctx, collectionID))
+	assert.False(t, observer.isNextTargetExpired(collectionID))
+}
+
 // TestUpdateAllReplicasCheckpointMetric tests the all-replicas checkpoint metric behavior
 func TestUpdateAllReplicasCheckpointMetric(t *testing.T) {
 	paramtable.Init()
@@ -150,6 +150,7 @@ func (suite *OpsServiceSuite) SetupTest() {
 		distController:      suite.distController,
 		ctx:                 context.Background(),
 		checkerController:   suite.checkerController,
+		replicaObserver:     observers.NewReplicaObserver(suite.meta, suite.dist, suite.targetMgr),
 	}
 	suite.server.collectionObserver = observers.NewCollectionObserver(
 		suite.server.dist,
@@ -473,6 +474,89 @@ func (suite *OpsServiceSuite) TestSuspendAndResumeNode() {
 	suite.Contains(nodes, int64(1))
 }
 
+func (suite *OpsServiceSuite) TestDecommissionNode() {
+	ctx := context.Background()
+
+	// test server unhealthy
+	suite.server.UpdateStateCode(commonpb.StateCode_Abnormal)
+	resp, err := suite.server.DecommissionNode(ctx, &querypb.DecommissionNodeRequest{})
+	suite.NoError(err)
+	suite.False(merr.Ok(resp.GetStatus()))
+
+	// test node not found
+	suite.server.UpdateStateCode(commonpb.StateCode_Healthy)
+	resp, err = suite.server.DecommissionNode(ctx, &querypb.DecommissionNodeRequest{
+		NodeID: 1,
+	})
+	suite.NoError(err)
+	suite.False(merr.Ok(resp.GetStatus()))
+
+	suite.nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+		NodeID:   1,
+		Address:  "localhost",
+		Hostname: "localhost",
+	}))
+	suite.meta.HandleNodeUp(ctx, 1)
+	suite.meta.Put(ctx, utils.CreateTestReplica(1, 1, []int64{1}))
+	suite.dist.SegmentDistManager.Update(1, &meta.Segment{
+		SegmentInfo: &datapb.SegmentInfo{
+			ID:            1,
+			CollectionID:  1,
+			PartitionID:   1,
+			InsertChannel: "channel1",
+		},
+		Node: 1,
+	})
+
+	// test node is marked as stopping and still serving
+	resp, err = suite.server.DecommissionNode(ctx, &querypb.DecommissionNodeRequest{
+		NodeID: 1,
+	})
+	suite.NoError(err)
+	suite.True(merr.Ok(resp.GetStatus()))
+	suite.True(suite.nodeMgr.Get(1).IsStoppingState())
+	suite.False(resp.GetDrained())
+	suite.Equal(int64(1), resp.GetRemainingSegmentNum())
+	suite.Equal([]int64{1}, resp.GetReplicaIDs())
+
+	// test node drained
+	suite.dist.SegmentDistManager.Update(1)
+	replica := suite.meta.Get(ctx, 1).CopyForWrite()
+	replica.RemoveNode(1)
+	suite.meta.Put(ctx, replica.IntoReplica())
+	resp, err = suite.server.DecommissionNode(ctx, &querypb.DecommissionNodeRequest{
+		NodeID: 1,
+	})
+	suite.NoError(err)
+	suite.True(merr.Ok(resp.GetStatus()))
+	suite.True(resp.GetDrained())
+	suite.Zero(resp.GetRemainingSegmentNum())
+	suite.Zero(resp.GetRemainingChannelNum())
+}
+
+func (suite *OpsServiceSuite) TestReplicaSuggestions() {
+	ctx := context.Background()
+
+	// test server unhealthy
+	suite.server.UpdateStateCode(commonpb.StateCode_Abnormal)
+	resp, err := suite.server.ListReplicaSuggestions(ctx, &querypb.ListReplicaSuggestionsRequest{})
+	suite.NoError(err)
+	suite.False(merr.Ok(resp.GetStatus()))
+	status, err := suite.server.ApproveReplicaSuggestions(ctx, &querypb.ApproveReplicaSuggestionsRequest{})
+	suite.NoError(err)
+	suite.False(merr.Ok(status))
+
+	// test no suggestion
+	suite.server.UpdateStateCode(commonpb.StateCode_Healthy)
+	resp, err = suite.server.ListReplicaSuggestions(ctx, &querypb.ListReplicaSuggestionsRequest{})
+	suite.NoError(err)
+	suite.True(merr.Ok(resp.GetStatus()))
+	suite.Empty(resp.GetSuggestions())
+	status, err = suite.server.ApproveReplicaSuggestions(ctx, &querypb.ApproveReplicaSuggestionsRequest{CollectionID: 1})
+	suite.NoError(err)
+	suite.False(merr.Ok(status))
+}
+
 func (suite *OpsServiceSuite) TestTransferSegment() {
 	ctx := context.Background()
 
@@ -657,3 +657,95 @@ func (s *Server) CheckQueryNodeDistribution(ctx context.Context, req *querypb.Ch
 	}
 	return merr.Success(), nil
 }
+
+// DecommissionNode marks the query node as stopping, so the replica observer turns it into RO node of all replicas
+// and the stopping balance moves its segments and channels out at the rate of the balance batch size.
+// It's idempotent, the caller could poll it until the response reports the node drained and then stop the node.
+func (s *Server) DecommissionNode(ctx context.Context, req *querypb.DecommissionNodeRequest) (*querypb.DecommissionNodeResponse, error) {
+	log := mlog.With(mlog.Int64("nodeID", req.GetNodeID()))
+	log.Info(ctx, "DecommissionNode request received")
+
+	errMsg := "failed to decommission query node"
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		log.Warn(ctx, errMsg, mlog.Err(err))
+		return &querypb.DecommissionNodeResponse{Status: merr.Status(err)}, nil
+	}
+
+	info := s.nodeMgr.Get(req.GetNodeID())
+	if info == nil {
+		err := merr.WrapErrNodeNotFound(req.GetNodeID(), errMsg)
+		log.Warn(ctx, errMsg, mlog.Err(err))
+		return &querypb.DecommissionNodeResponse{Status: merr.Status(err)}, nil
+	}
+
+	if info.IsEmbeddedQueryNodeInStreamingNode() {
+		return &querypb.DecommissionNodeResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("embedded query node in streaming node can't be decommissioned")),
+		}, nil
+	}
+
+	if !info.IsStoppingState() {
+		log.Info(ctx, "mark query node as stopping to drain it")
+		s.handleNodeStopping(req.GetNodeID())
+	}
+
+	segments := s.dist.SegmentDistManager.GetByFilter(meta.WithNodeID(req.GetNodeID()))
+	channels := s.dist.ChannelDistManager.GetByFilter(meta.WithNodeID2Channel(req.GetNodeID()))
+	replicaIDs := lo.Map(s.meta.GetByNode(ctx, req.GetNodeID()), func(replica *meta.Replica, _ int) int64 {
+		return replica.GetID()
+	})
+	drained := len(segments) == 0 && len(channels) == 0 && len(replicaIDs) == 0
+	log.Info(ctx, "query node drain progress",
+		mlog.Bool("drained", drained),
+		mlog.Int("remainingSegmentNum", len(segments)),
+		mlog.Int("remainingChannelNum", len(channels)),
+		mlog.Int64s("replicaIDs", replicaIDs))
+
+	return &querypb.DecommissionNodeResponse{
+		Status:              merr.Success(),
+		NodeID:              req.GetNodeID(),
+		Drained:             drained,
+		RemainingSegmentNum: int64(len(segments)),
+		RemainingChannelNum: int64(len(channels)),
+		ReplicaIDs:          replicaIDs,
+	}, nil
+}
+
+// ListReplicaSuggestions lists the node-in-replica changes held by the replica observer in advisory mode.
+func (s *Server) ListReplicaSuggestions(ctx context.Context, req *querypb.ListReplicaSuggestionsRequest) (*querypb.ListReplicaSuggestionsResponse, error) {
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		mlog.Warn(ctx, "failed to list replica suggestions", mlog.Err(err))
+		return &querypb.ListReplicaSuggestionsResponse{Status: merr.Status(err)}, nil
+	}
+
+	return &querypb.ListReplicaSuggestionsResponse{
+		Status:      merr.Success(),
+		Suggestions: s.replicaObserver.ListSuggestions(req.GetCollectionID()),
+	}, nil
+}
+
+// ApproveReplicaSuggestions approves the pending node-in-replica changes matching the request,
+// they're applied by the next check of the replica observer.
+func (s *Server) ApproveReplicaSuggestions(ctx context.Context, req *querypb.ApproveReplicaSuggestionsRequest) (*commonpb.Status, error) {
+	log := mlog.With(
+		mlog.FieldCollectionID(req.GetCollectionID()),
+		mlog.Int64("replicaID", req.GetReplicaID()),
+		mlog.Int64s("nodeIDs", req.GetNodeIDs()),
+	)
+	log.Info(ctx, "ApproveReplicaSuggestions request received")
+
+	errMsg := "failed to approve replica suggestions"
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		log.Warn(ctx, errMsg, mlog.Err(err))
+		return merr.Status(err), nil
+	}
+
+	approved := s.replicaObserver.ApproveSuggestions(req.GetCollectionID(), req.GetReplicaID(), req.GetNodeIDs())
+	if approved == 0 {
+		err := merr.WrapErrParameterInvalidMsg("no pending replica suggestion matched")
+		log.Warn(ctx, errMsg, mlog.Err(err))
+		return merr.Status(err), nil
+	}
+	log.Info(ctx, "replica suggestions approved", mlog.Int("approvedNum", approved))
+	return merr.Success(), nil
+}
@@ -0,0 +1,82 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// ReplicaLoadProgress is the loading progress of the sealed segments of the current target in a replica.
+type ReplicaLoadProgress struct {
+	ReplicaID      int64 `json:"replica_id"`
+	TargetSegments int   `json:"target_segments"`
+	LoadedSegments int   `json:"loaded_segments"`
+	// LoadingSegments is the number of the segment load tasks of the replica in flight.
+	LoadingSegments int   `json:"loading_segments"`
+	Percentage      int32 `json:"percentage"`
+	Serviceable     bool  `json:"serviceable"`
+	// WarmingUp is true if the replica is not serviceable while another replica of the collection is serving,
+	// then its segment loads are throttled by queryCoord.replicaWarmup.
+	WarmingUp bool `json:"warming_up"`
+}
+
+// GetReplicaLoadProgress returns the loading progress of each replica of the collection, the replicas spawned
+// for a loaded collection are reported with their warm-up progress, while the collection load percentage stays 100.
+func (s *Server) GetReplicaLoadProgress(ctx context.Context, collectionID int64) ([]*ReplicaLoadProgress, error) {
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		return nil, err
+	}
+	if s.meta.GetCollection(ctx, collectionID) == nil {
+		return nil, merr.WrapErrCollectionNotLoaded(collectionID)
+	}
+
+	targets := s.targetMgr.GetSealedSegmentsByCollection(ctx, collectionID, meta.CurrentTarget)
+	replicas := s.meta.GetByCollection(ctx, collectionID)
+	result := make([]*ReplicaLoadProgress, 0, len(replicas))
+	for _, replica := range replicas {
+		progress := &ReplicaLoadProgress{
+			ReplicaID:      replica.GetID(),
+			TargetSegments: len(targets),
+			LoadingSegments: s.taskScheduler.GetSegmentTaskNum(
+				task.WithReplicaID2TaskFilter(replica.GetID()),
+				task.WithTaskTypeFilter(task.TaskTypeGrow),
+			),
+			Serviceable: utils.IsReplicaServiceable(ctx, s.targetMgr, s.dist, replica),
+			WarmingUp:   utils.IsReplicaWarmingUp(ctx, s.meta, s.targetMgr, s.dist, replica),
+		}
+		loaded := make(map[int64]struct{})
+		for _, segment := range s.dist.SegmentDistManager.GetByFilter(meta.WithCollectionID(collectionID), meta.WithReplica(replica)) {
+			if _, ok := targets[segment.GetID()]; ok {
+				loaded[segment.GetID()] = struct{}{}
+			}
+		}
+		progress.LoadedSegments = len(loaded)
+		progress.Percentage = 100
+		if len(targets) > 0 {
+			progress.Percentage = int32(progress.LoadedSegments * 100 / len(targets))
+		}
+		result = append(result, progress)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ReplicaID < result[j].ReplicaID })
+	return result, nil
+}
@@ -0,0 +1,61 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func (suite *ServiceSuite) TestGetReplicaLoadProgress() {
+	suite.loadAll()
+	ctx := context.Background()
+	server := suite.server
+	collection := suite.collections[0]
+	replicas := suite.meta.GetByCollection(ctx, collection)
+	node := replicas[0].GetRWNodes()[0]
+	suite.updateSegmentDist(collection, node)
+	segments := suite.getAllSegments(collection)
+
+	suite.taskScheduler.EXPECT().GetSegmentTaskNum(mock.Anything, mock.Anything).Return(0).Times(len(replicas))
+	progress, err := server.GetReplicaLoadProgress(ctx, collection)
+	suite.NoError(err)
+	suite.Len(progress, len(replicas))
+	for _, p := range progress {
+		suite.Equal(len(segments), p.TargetSegments)
+		if p.ReplicaID == replicas[0].GetID() {
+			suite.Equal(len(segments), p.LoadedSegments)
+			suite.EqualValues(100, p.Percentage)
+		} else {
+			suite.Zero(p.LoadedSegments)
+		}
+		// no replica is serving without the channel dist
+		suite.False(p.Serviceable)
+		suite.False(p.WarmingUp)
+	}
+
+	_, err = server.GetReplicaLoadProgress(ctx, 999999)
+	suite.ErrorIs(err, merr.ErrCollectionNotLoaded)
+
+	server.UpdateStateCode(commonpb.StateCode_Initializing)
+	_, err = server.GetReplicaLoadProgress(ctx, collection)
+	suite.ErrorIs(err, merr.ErrServiceNotReady)
+}
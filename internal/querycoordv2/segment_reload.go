@@ -0,0 +1,150 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// ReloadSegments reopens the given loaded segments of the collection on their current nodes,
+// so that the indexes rebuilt after the segments were loaded take effect, all the loaded segments
+// of the collection are reloaded if segmentIDs is empty.
+// The replicas are reloaded one after another to keep the others serving, and at most
+// queryCoord.segmentReloadConcurrency segments of a replica are reopened at the same time.
+// The reload runs in background, it returns the number of the segment copies to reload.
+func (s *Server) ReloadSegments(ctx context.Context, collectionID int64, segmentIDs []int64) (int, error) {
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		return 0, err
+	}
+	if s.meta.GetCollection(ctx, collectionID) == nil {
+		return 0, merr.WrapErrCollectionNotLoaded(collectionID)
+	}
+
+	targets := typeutil.NewUniqueSet(segmentIDs...)
+	found := typeutil.NewUniqueSet()
+	replicas := s.meta.GetByCollection(ctx, collectionID)
+	plans := make(map[int64][]*meta.Segment, len(replicas))
+	total := 0
+	for _, replica := range replicas {
+		segments := s.dist.SegmentDistManager.GetByFilter(meta.WithCollectionID(collectionID), meta.WithReplica(replica))
+		for _, segment := range segments {
+			if targets.Len() > 0 && !targets.Contain(segment.GetID()) {
+				continue
+			}
+			found.Insert(segment.GetID())
+			plans[replica.GetID()] = append(plans[replica.GetID()], segment)
+			total++
+		}
+	}
+	for _, segmentID := range segmentIDs {
+		if !found.Contain(segmentID) {
+			return 0, merr.WrapErrSegmentNotLoaded(segmentID)
+		}
+	}
+	if total == 0 {
+		return 0, nil
+	}
+
+	if !s.reloadingCollections.Insert(collectionID) {
+		return 0, merr.WrapErrParameterInvalidMsg("segments of collection %d are being reloaded", collectionID)
+	}
+	mlog.Info(ctx, "start to reload segments",
+		mlog.Int64("collectionID", collectionID),
+		mlog.Int64s("segmentIDs", segmentIDs),
+		mlog.Int("total", total))
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.reloadingCollections.Remove(collectionID)
+		for _, replica := range replicas {
+			if s.ctx.Err() != nil {
+				return
+			}
+			s.reloadReplicaSegments(replica, plans[replica.GetID()])
+		}
+		mlog.Info(s.ctx, "segments reloaded", mlog.Int64("collectionID", collectionID), mlog.Int("total", total))
+	}()
+	return total, nil
+}
+
+// reloadReplicaSegments reopens the segments of the replica batch by batch,
+// the next batch starts after all the tasks of the current batch finished.
+func (s *Server) reloadReplicaSegments(replica *meta.Replica, segments []*meta.Segment) {
+	concurrency := max(Params.QueryCoordCfg.SegmentReloadConcurrency.GetAsInt(), 1)
+	for len(segments) > 0 && s.ctx.Err() == nil {
+		batch := segments[:min(concurrency, len(segments))]
+		segments = segments[len(batch):]
+
+		tasks := make([]task.Task, 0, len(batch))
+		for _, segment := range batch {
+			action := task.NewSegmentActionWithScope(segment.Node, task.ActionTypeReopen, segment.GetInsertChannel(), segment.GetID(), querypb.DataScope_Historical, int(segment.GetNumOfRows()))
+			t, err := task.NewSegmentTask(s.ctx,
+				Params.QueryCoordCfg.SegmentTaskTimeout.GetAsDuration(time.Millisecond),
+				utils.SegmentReload,
+				segment.GetCollectionID(),
+				replica,
+				commonpb.LoadPriority_LOW,
+				action,
+			)
+			if err != nil {
+				mlog.Warn(s.ctx, "failed to create segment reload task",
+					mlog.Int64("replicaID", replica.GetID()),
+					mlog.Int64("segmentID", segment.GetID()),
+					mlog.Int64("node", segment.Node),
+					mlog.Err(err))
+				continue
+			}
+			t.SetPriority(task.TaskPriorityNormal)
+			t.SetReason("segment reload")
+			if err := s.taskScheduler.Add(t); err != nil {
+				t.Cancel(err)
+				mlog.Warn(s.ctx, "failed to add segment reload task",
+					mlog.Int64("replicaID", replica.GetID()),
+					mlog.Int64("segmentID", segment.GetID()),
+					mlog.Int64("node", segment.Node),
+					mlog.Err(err))
+				continue
+			}
+			tasks = append(tasks, t)
+		}
+
+		for _, t := range tasks {
+			if err := t.Wait(); err != nil {
+				mlog.Warn(s.ctx, "segment reload task failed",
+					mlog.Int64("replicaID", replica.GetID()),
+					mlog.Int64("taskID", t.ID()),
+					mlog.Err(err))
+			}
+		}
+		mlog.Info(s.ctx, "segment reload batch finished",
+			mlog.Int64("collectionID", replica.GetCollectionID()),
+			mlog.Int64("replicaID", replica.GetID()),
+			mlog.Int("finished", len(tasks)),
+			mlog.Int("remaining", len(segments)))
+	}
+}
@@ -0,0 +1,127 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func (suite *ServiceSuite) TestReloadSegments() {
+	suite.loadAll()
+	ctx := context.Background()
+	server := suite.server
+	collection := suite.collections[0]
+	replicas := suite.meta.GetByCollection(ctx, collection)
+	node := replicas[0].GetNodes()[0]
+	suite.updateSegmentDist(collection, node)
+	segments := suite.getAllSegments(collection)
+
+	suite.Run("reload all segments", func() {
+		added := atomic.NewInt32(0)
+		suite.taskScheduler.EXPECT().Add(mock.Anything).Run(func(t task.Task) {
+			suite.Equal(utils.SegmentReload, t.Source())
+			suite.Len(t.Actions(), 1)
+			suite.Equal(task.ActionTypeReopen, t.Actions()[0].Type())
+			suite.Equal(node, t.Actions()[0].Node())
+			added.Inc()
+			t.Cancel(nil)
+		}).Return(nil).Times(len(segments))
+
+		total, err := server.ReloadSegments(ctx, collection, nil)
+		suite.NoError(err)
+		suite.Equal(len(segments), total)
+		server.wg.Wait()
+		suite.EqualValues(len(segments), added.Load())
+		suite.False(server.reloadingCollections.Contain(collection))
+	})
+
+	suite.Run("reload specified segment", func() {
+		suite.taskScheduler.EXPECT().Add(mock.Anything).Run(func(t task.Task) {
+			suite.Equal(segments[0], t.(*task.SegmentTask).SegmentID())
+			t.Cancel(nil)
+		}).Return(nil).Once()
+
+		total, err := server.ReloadSegments(ctx, collection, segments[:1])
+		suite.NoError(err)
+		suite.Equal(1, total)
+		server.wg.Wait()
+	})
+
+	suite.Run("segment not loaded", func() {
+		_, err := server.ReloadSegments(ctx, collection, []int64{999999})
+		suite.ErrorIs(err, merr.ErrSegmentNotLoaded)
+	})
+
+	suite.Run("collection is being reloaded", func() {
+		server.reloadingCollections.Insert(collection)
+		defer server.reloadingCollections.Remove(collection)
+		_, err := server.ReloadSegments(ctx, collection, nil)
+		suite.ErrorIs(err, merr.ErrParameterInvalid)
+	})
+
+	suite.Run("collection not loaded", func() {
+		_, err := server.ReloadSegments(ctx, 999999, nil)
+		suite.ErrorIs(err, merr.ErrCollectionNotLoaded)
+	})
+
+	suite.Run("server not healthy", func() {
+		server.UpdateStateCode(commonpb.StateCode_Initializing)
+		_, err := server.ReloadSegments(ctx, collection, nil)
+		suite.ErrorIs(err, merr.ErrServiceNotReady)
+	})
+}
+
+func (suite *ServiceSuite) TestGetLoadedRowsByNode() {
+	suite.loadAll()
+	ctx := context.Background()
+	server := suite.server
+	collection := suite.collections[0]
+	replicas := suite.meta.GetByCollection(ctx, collection)
+	node := replicas[0].GetRWNodes()[0]
+	suite.updateSegmentDist(collection, node)
+	segments := suite.getAllSegments(collection)
+
+	rows, err := server.GetLoadedRowsByNode(ctx, collection)
+	suite.NoError(err)
+	numNodes := 0
+	for _, replica := range replicas {
+		numNodes += len(replica.GetRWNodes())
+	}
+	suite.Len(rows, numNodes)
+	for _, r := range rows {
+		if r.NodeID == node && r.ReplicaID == replicas[0].GetID() {
+			suite.Equal(len(segments), r.NumSegments)
+		} else {
+			suite.Zero(r.NumSegments)
+		}
+	}
+
+	_, err = server.GetLoadedRowsByNode(ctx, 999999)
+	suite.ErrorIs(err, merr.ErrCollectionNotLoaded)
+
+	server.UpdateStateCode(commonpb.StateCode_Initializing)
+	_, err = server.GetLoadedRowsByNode(ctx, collection)
+	suite.ErrorIs(err, merr.ErrServiceNotReady)
+}
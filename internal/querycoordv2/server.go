@@ -93,6 +93,7 @@ type Server struct {
 	dist      *meta.DistributionManager
 	targetMgr meta.TargetManagerInterface
 	broker    meta.Broker
+	loadAudit *loadAuditManager
 
 	// Session
 	cluster          session.Cluster
@@ -114,6 +115,7 @@ type Server struct {
 	targetObserver       *observers.TargetObserver
 	replicaObserver      *observers.ReplicaObserver
 	resourceObserver     *observers.ResourceObserver
+	partitionObserver    *observers.PartitionObserver
 	leaderCacheObserver  *observers.LeaderCacheObserver
 	fileResourceObserver FileResourceObserver
 
@@ -134,6 +136,9 @@ type Server struct {
 
 	// load config watcher
 	loadConfigWatcher *LoadConfigWatcher
+
+	// the collections whose segments are being reloaded
+	reloadingCollections typeutil.ConcurrentSet[int64]
 }
 
 type FileResourceObserver interface {
@@ -380,6 +385,7 @@ func (s *Server) initMeta() error {
 	mlog.Info(s.ctx, "init meta")
 	s.store = querycoord.NewCatalog(s.kv)
 	s.meta = meta.NewMeta(s.idAllocator, s.store, s.nodeMgr)
+	s.loadAudit = newLoadAuditManager(s.kv)
 
 	s.broker = meta.NewCoordinatorBroker(
 		s.mixCoord,
@@ -449,6 +455,12 @@ func (s *Server) initObserver() {
 
 	s.resourceObserver = observers.NewResourceObserver(s.meta)
 
+	s.partitionObserver = observers.NewPartitionObserver(
+		s.meta,
+		s.broker,
+		s.broadcastAlterLoadConfigCollectionV2ForRepairPartitions,
+	)
+
 	s.leaderCacheObserver = observers.NewLeaderCacheObserver(
 		s.proxyClientManager,
 	)
@@ -518,6 +530,7 @@ func (s *Server) startServerLoop() {
 	s.targetObserver.Start()
 	s.replicaObserver.Start()
 	s.resourceObserver.Start()
+	s.partitionObserver.Start()
 
 	mlog.Info(s.ctx, "start task scheduler...")
 	s.taskScheduler.Start()
@@ -527,6 +540,9 @@ func (s *Server) startServerLoop() {
 
 	mlog.Info(s.ctx, "start job scheduler...")
 	s.jobScheduler.Start()
+
+	s.wg.Add(1)
+	go s.consistencyProbeLoop()
 }
 
 func (s *Server) Stop() error {
@@ -574,6 +590,9 @@ func (s *Server) Stop() error {
 	if s.resourceObserver != nil {
 		s.resourceObserver.Stop()
 	}
+	if s.partitionObserver != nil {
+		s.partitionObserver.Stop()
+	}
 	if s.leaderCacheObserver != nil {
 		s.leaderCacheObserver.Stop()
 	}
@@ -882,6 +901,27 @@ func (s *Server) GetInternalReplicasByCollection(ctx context.Context, collection
 	return s.meta.GetByCollection(ctx, collectionID)
 }
 
+// SetReplicaWeight sets the routing weight of the replica, proxies dispatch the search requests
+// of the collection to its replicas in proportion to the weights.
+func (s *Server) SetReplicaWeight(ctx context.Context, collectionID int64, replicaID int64, weight int32) error {
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		return err
+	}
+	if err := s.meta.SetReplicaWeight(ctx, collectionID, replicaID, weight); err != nil {
+		mlog.Warn(ctx, "failed to set replica weight",
+			mlog.Int64("collectionID", collectionID),
+			mlog.Int64("replicaID", replicaID),
+			mlog.Int32("weight", weight),
+			mlog.Err(err))
+		return err
+	}
+	mlog.Info(ctx, "replica weight updated",
+		mlog.Int64("collectionID", collectionID),
+		mlog.Int64("replicaID", replicaID),
+		mlog.Int32("weight", weight))
+	return nil
+}
+
 // IsCollectionUserSpecifiedReplicaMode returns whether the collection load config
 // was created from a request with an explicit replica number.
 func (s *Server) IsCollectionUserSpecifiedReplicaMode(ctx context.Context, collectionID int64) bool {
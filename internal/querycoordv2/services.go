@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
@@ -220,7 +221,10 @@ func (s *Server) LoadCollection(ctx context.Context, req *querypb.LoadCollection
 		return merr.Success(), nil
 	}
 
-	if err := s.broadcastAlterLoadConfigCollectionV2ForLoadCollection(ctx, req); err != nil {
+	start := time.Now()
+	err := s.broadcastAlterLoadConfigCollectionV2ForLoadCollection(ctx, req)
+	s.recordLoadAudit(ctx, LoadAuditLoadCollection, req.GetBase(), req.GetCollectionID(), nil, start, err)
+	if err != nil {
 		logger.Warn(ctx, "failed to load collection", mlog.Err(err))
 		metrics.QueryCoordLoadCount.WithLabelValues(metrics.FailLabel).Inc()
 		return merr.Status(err), nil
@@ -244,7 +248,13 @@ func (s *Server) ReleaseCollection(ctx context.Context, req *querypb.ReleaseColl
 		return merr.Status(err), nil
 	}
 
-	if err := s.broadcastDropLoadConfigCollectionV2ForReleaseCollection(ctx, req); err != nil {
+	start := time.Now()
+	err := s.broadcastDropLoadConfigCollectionV2ForReleaseCollection(ctx, req)
+	// the release of a collection not loaded is a no-op, it's not audited.
+	if !errors.Is(err, errReleaseCollectionNotLoaded) {
+		s.recordLoadAudit(ctx, LoadAuditReleaseCollection, req.GetBase(), req.GetCollectionID(), nil, start, err)
+	}
+	if err != nil {
 		if errors.Is(err, errReleaseCollectionNotLoaded) {
 			logger.Info(ctx, "release collection ignored, collection is not loaded")
 			metrics.QueryCoordReleaseCount.WithLabelValues(metrics.SuccessLabel).Inc()
@@ -293,7 +303,10 @@ func (s *Server) LoadPartitions(ctx context.Context, req *querypb.LoadPartitions
 		return merr.Success(), nil
 	}
 
-	if err := s.broadcastAlterLoadConfigCollectionV2ForLoadPartitions(ctx, req); err != nil {
+	start := time.Now()
+	err := s.broadcastAlterLoadConfigCollectionV2ForLoadPartitions(ctx, req)
+	s.recordLoadAudit(ctx, LoadAuditLoadPartitions, req.GetBase(), req.GetCollectionID(), req.GetPartitionIDs(), start, err)
+	if err != nil {
 		logger.Warn(ctx, "failed to load partitions", mlog.Err(err))
 		metrics.QueryCoordLoadCount.WithLabelValues(metrics.FailLabel).Inc()
 		return merr.Status(err), nil
@@ -325,7 +338,9 @@ func (s *Server) ReleasePartitions(ctx context.Context, req *querypb.ReleasePart
 		return merr.Status(err), nil
 	}
 
+	start := time.Now()
 	collectionReleased, err := s.broadcastAlterLoadConfigCollectionV2ForReleasePartitions(ctx, req)
+	s.recordLoadAudit(ctx, LoadAuditReleasePartitions, req.GetBase(), req.GetCollectionID(), req.GetPartitionIDs(), start, err)
 	if err != nil {
 		logger.Warn(ctx, "failed to release partitions", mlog.Err(err))
 		metrics.QueryCoordReleaseCount.WithLabelValues(metrics.FailLabel).Inc()
@@ -1125,6 +1140,10 @@ func (s *Server) ListLoadedSegments(ctx context.Context, req *querypb.ListLoaded
 		Status:     merr.Success(),
 		SegmentIDs: segmentIDs.Collect(),
 	}
+	if req.GetWithAccessCounts() {
+		// only the sealed segments in distribution are read
+		resp.AccessCounts = s.dist.SegmentAccessStats.Get(lo.Map(segments, func(segment *meta.Segment, _ int) int64 { return segment.GetID() })...)
+	}
 	return resp, nil
 }
 
@@ -42,6 +42,7 @@ type Cluster interface {
 	LoadPartitions(ctx context.Context, nodeID int64, req *querypb.LoadPartitionsRequest) (*commonpb.Status, error)
 	ReleasePartitions(ctx context.Context, nodeID int64, req *querypb.ReleasePartitionsRequest) (*commonpb.Status, error)
 	GetDataDistribution(ctx context.Context, nodeID int64, req *querypb.GetDataDistributionRequest) (*querypb.GetDataDistributionResponse, error)
+	GetSegmentInfo(ctx context.Context, nodeID int64, req *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error)
 	GetMetrics(ctx context.Context, nodeID int64, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 	SyncDistribution(ctx context.Context, nodeID int64, req *querypb.SyncDistributionRequest) (*commonpb.Status, error)
 	GetComponentStates(ctx context.Context, nodeID int64) (*milvuspb.ComponentStates, error)
@@ -222,6 +223,22 @@ func (c *QueryCluster) GetDataDistribution(ctx context.Context, nodeID int64, re
 	return resp, err
 }
 
+func (c *QueryCluster) GetSegmentInfo(ctx context.Context, nodeID int64, req *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error) {
+	var resp *querypb.GetSegmentInfoResponse
+	var err error
+	err1 := c.send(ctx, nodeID, func(cli types.QueryNodeClient) {
+		req := proto.Clone(req).(*querypb.GetSegmentInfoRequest)
+		req.Base = &commonpb.MsgBase{
+			TargetID: nodeID,
+		}
+		resp, err = cli.GetSegmentInfo(ctx, req)
+	})
+	if err1 != nil {
+		return nil, err1
+	}
+	return resp, err
+}
+
 func (c *QueryCluster) GetMetrics(ctx context.Context, nodeID int64, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	var (
 		resp *milvuspb.GetMetricsResponse
@@ -144,6 +144,10 @@ func (suite *ClusterTestSuite) createDefaultMockServer() querypb.QueryNodeServer
 		mock.Anything,
 		mock.AnythingOfType("*querypb.GetDataDistributionRequest"),
 	).Maybe().Return(&querypb.GetDataDistributionResponse{Status: succStatus}, nil)
+	svr.EXPECT().GetSegmentInfo(
+		mock.Anything,
+		mock.AnythingOfType("*querypb.GetSegmentInfoRequest"),
+	).Maybe().Return(&querypb.GetSegmentInfoResponse{Status: succStatus}, nil)
 	svr.EXPECT().GetMetrics(
 		mock.Anything,
 		mock.AnythingOfType("*milvuspb.GetMetricsRequest"),
@@ -197,6 +201,10 @@ func (suite *ClusterTestSuite) createFailedMockServer() querypb.QueryNodeServer
 		mock.Anything,
 		mock.AnythingOfType("*querypb.GetDataDistributionRequest"),
 	).Maybe().Return(&querypb.GetDataDistributionResponse{Status: failStatus}, nil)
+	svr.EXPECT().GetSegmentInfo(
+		mock.Anything,
+		mock.AnythingOfType("*querypb.GetSegmentInfoRequest"),
+	).Maybe().Return(&querypb.GetSegmentInfoResponse{Status: failStatus}, nil)
 	svr.EXPECT().GetMetrics(
 		mock.Anything,
 		mock.AnythingOfType("*milvuspb.GetMetricsRequest"),
@@ -335,6 +343,23 @@ func (suite *ClusterTestSuite) TestGetDataDistribution() {
 	suite.Equal("unexpected error", resp.GetStatus().GetReason())
 }
 
+func (suite *ClusterTestSuite) TestGetSegmentInfo() {
+	ctx := context.TODO()
+	resp, err := suite.cluster.GetSegmentInfo(ctx, 0, &querypb.GetSegmentInfoRequest{
+		Base:          &commonpb.MsgBase{},
+		WithValidRows: true,
+	})
+	suite.NoError(err)
+	suite.Equal(merr.Success(), resp.GetStatus())
+
+	resp, err = suite.cluster.GetSegmentInfo(ctx, 1, &querypb.GetSegmentInfoRequest{
+		Base: &commonpb.MsgBase{},
+	})
+	suite.NoError(err)
+	suite.Equal(commonpb.ErrorCode_UnexpectedError, resp.GetStatus().GetErrorCode())
+	suite.Equal("unexpected error", resp.GetStatus().GetReason())
+}
+
 func (suite *ClusterTestSuite) TestGetMetrics() {
 	ctx := context.TODO()
 	resp, err := suite.cluster.GetMetrics(ctx, 0, &milvuspb.GetMetricsRequest{})
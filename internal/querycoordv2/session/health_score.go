@@ -0,0 +1,62 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	// the health score is halved if the node is stopping or resource exhausted.
+	stoppingHealthFactor          = 0.5
+	resourceExhaustedHealthFactor = 0.5
+)
+
+// HealthScore returns the health score of the node in [0, 1] from the heartbeat metrics, higher is healthier.
+// The score decays if the last heartbeat lags behind or the distribution pull is slower than the pull interval,
+// and is halved if the node is stopping or resource exhausted. The offline node scores 0.
+func (m *NodeManager) HealthScore(nodeID int64) float64 {
+	node := m.Get(nodeID)
+	if node == nil {
+		return 0
+	}
+
+	pullInterval := paramtable.Get().QueryCoordCfg.DistPullInterval.GetAsDuration(time.Millisecond)
+	availableInterval := paramtable.Get().QueryCoordCfg.HeartbeatAvailableInterval.GetAsDuration(time.Millisecond)
+
+	score := 1.0
+	// the heartbeat lagging behind more than two pull intervals decays to 0 at the available interval.
+	lag := time.Since(node.LastHeartbeat())
+	if tolerance := 2 * pullInterval; lag > tolerance {
+		if lag >= availableInterval || availableInterval <= tolerance {
+			return 0
+		}
+		score *= float64(availableInterval-lag) / float64(availableInterval-tolerance)
+	}
+	if latency := node.HeartbeatLatency(); latency > pullInterval && pullInterval > 0 {
+		score *= float64(pullInterval) / float64(latency)
+	}
+	if node.IsStoppingState() {
+		score *= stoppingHealthFactor
+	}
+	if m.IsResourceExhausted(nodeID) {
+		score *= resourceExhaustedHealthFactor
+	}
+	return score
+}
@@ -207,6 +207,19 @@ func (n *NodeInfo) CPUNum() int64 {
 	return n.getCPUNum()
 }
 
+func (n *NodeInfo) HeartbeatLatency() time.Duration {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.getHeartbeatLatency()
+}
+
+// LoadingSegmentNum returns the loading queue depth of the node reported by the last heartbeat.
+func (n *NodeInfo) LoadingSegmentNum() int64 {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.getLoadingSegmentNum()
+}
+
 func (n *NodeInfo) SetLastHeartbeat(time time.Time) {
 	n.lastHeartbeat.Store(time.UnixNano())
 }
@@ -279,6 +292,29 @@ func WithCPUNum(num int64) StatsOption {
 	}
 }
 
+func WithHeartbeatLatency(latency time.Duration) StatsOption {
+	return func(n *NodeInfo) {
+		n.setHeartbeatLatency(latency)
+	}
+}
+
+func WithLoadingSegmentNum(num int64) StatsOption {
+	return func(n *NodeInfo) {
+		n.setLoadingSegmentNum(num)
+	}
+}
+
+// IsLoadingQueueFull checks if the loading queue depth of the query node reaches queryCoord.loadingQueueDepthLimit,
+// such node won't receive new segment loading tasks until its queue drains.
+func (m *NodeManager) IsLoadingQueueFull(nodeID int64) bool {
+	limit := paramtable.Get().QueryCoordCfg.LoadingQueueDepthLimit.GetAsInt64()
+	if limit <= 0 {
+		return false
+	}
+	node := m.Get(nodeID)
+	return node != nil && node.LoadingSegmentNum() >= limit
+}
+
 // MarkResourceExhaustion marks a query node as resource exhausted for the specified duration.
 // During this period, the node won't receive new segment/channel loading tasks.
 // If duration is 0 or negative, the resource exhaustion mark is cleared immediately.
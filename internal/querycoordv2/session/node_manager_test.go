@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
 type NodeManagerSuite struct {
@@ -255,6 +256,54 @@ func (s *NodeManagerSuite) TestNodeInfoLabels() {
 	s.Equal("rg1", info2.ResourceGroupName())
 }
 
+func (s *NodeManagerSuite) TestHealthScore() {
+	paramtable.Init()
+	s.Equal(0.0, s.nodeManager.HealthScore(1))
+
+	node := NewNodeInfo(ImmutableNodeInfo{NodeID: 1})
+	s.nodeManager.Add(node)
+	// the node never sent heartbeat
+	s.Equal(0.0, s.nodeManager.HealthScore(1))
+
+	node.SetLastHeartbeat(time.Now())
+	s.Equal(1.0, s.nodeManager.HealthScore(1))
+
+	// the distribution pull is 4 times slower than the pull interval
+	pullInterval := paramtable.Get().QueryCoordCfg.DistPullInterval.GetAsDuration(time.Millisecond)
+	node.UpdateStats(WithHeartbeatLatency(4 * pullInterval))
+	s.InDelta(0.25, s.nodeManager.HealthScore(1), 0.01)
+	node.UpdateStats(WithHeartbeatLatency(0))
+
+	s.nodeManager.MarkResourceExhaustion(1, time.Minute)
+	s.InDelta(0.5, s.nodeManager.HealthScore(1), 0.01)
+	s.nodeManager.Stopping(1)
+	s.InDelta(0.25, s.nodeManager.HealthScore(1), 0.01)
+
+	// the heartbeat lags behind the available interval
+	availableInterval := paramtable.Get().QueryCoordCfg.HeartbeatAvailableInterval.GetAsDuration(time.Millisecond)
+	node.SetLastHeartbeat(time.Now().Add(-availableInterval))
+	s.Equal(0.0, s.nodeManager.HealthScore(1))
+}
+
+func (s *NodeManagerSuite) TestLoadingQueueFull() {
+	paramtable.Init()
+	node := NewNodeInfo(ImmutableNodeInfo{NodeID: 1})
+	s.nodeManager.Add(node)
+	node.UpdateStats(WithLoadingSegmentNum(10))
+	s.EqualValues(10, node.LoadingSegmentNum())
+
+	// no limit by default
+	s.False(s.nodeManager.IsLoadingQueueFull(1))
+
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.LoadingQueueDepthLimit.Key, "10")
+	defer paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.LoadingQueueDepthLimit.Key)
+	s.True(s.nodeManager.IsLoadingQueueFull(1))
+	s.False(s.nodeManager.IsLoadingQueueFull(2))
+
+	node.UpdateStats(WithLoadingSegmentNum(9))
+	s.False(s.nodeManager.IsLoadingQueueFull(1))
+}
+
 func TestNodeManagerSuite(t *testing.T) {
 	suite.Run(t, new(NodeManagerSuite))
 }
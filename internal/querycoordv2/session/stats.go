@@ -16,11 +16,17 @@
 
 package session
 
+import "time"
+
 type stats struct {
 	segmentCnt      int
 	channelCnt      int
 	memCapacityInMB float64
 	CPUNum          int64
+	// heartbeatLatency is the latency of the last distribution pull from the node.
+	heartbeatLatency time.Duration
+	// loadingSegmentNum is the number of segments waiting or being loaded on the node.
+	loadingSegmentNum int64
 }
 
 func (s *stats) setSegmentCnt(cnt int) {
@@ -55,6 +61,22 @@ func (s *stats) getCPUNum() int64 {
 	return s.CPUNum
 }
 
+func (s *stats) setHeartbeatLatency(latency time.Duration) {
+	s.heartbeatLatency = latency
+}
+
+func (s *stats) getHeartbeatLatency() time.Duration {
+	return s.heartbeatLatency
+}
+
+func (s *stats) setLoadingSegmentNum(num int64) {
+	s.loadingSegmentNum = num
+}
+
+func (s *stats) getLoadingSegmentNum() int64 {
+	return s.loadingSegmentNum
+}
+
 func newStats() stats {
 	return stats{}
 }
@@ -104,6 +104,8 @@ type SegmentAction struct {
 
 	SegmentID typeutil.UniqueID
 	Scope     querypb.DataScope
+	// FieldIDs are the fields to load by the action, empty means the whole segment.
+	FieldIDs []int64
 
 	rpcReturned atomic.Bool
 }
@@ -139,6 +141,10 @@ func (action *SegmentAction) GetScope() querypb.DataScope {
 	return action.Scope
 }
 
+func (action *SegmentAction) GetFieldIDs() []int64 {
+	return action.FieldIDs
+}
+
 func (action *SegmentAction) IsFinished(distMgr *meta.DistributionManager) bool {
 	if !action.rpcReturned.Load() {
 		return false
@@ -242,6 +242,9 @@ func (ex *Executor) loadSegment(task *SegmentTask, step int) error {
 	if err != nil {
 		return err
 	}
+	if len(action.GetFieldIDs()) > 0 {
+		ex.filterLoadFieldIndexes(action, loadInfo)
+	}
 	req := packLoadSegmentRequest(
 		task,
 		action,
@@ -288,6 +291,21 @@ func (ex *Executor) loadSegment(task *SegmentTask, step int) error {
 	return nil
 }
 
+// filterLoadFieldIndexes keeps only the indexes of the fields to load by the action and the loaded ones,
+// so that a retry of the failed fields doesn't reload the other fields.
+func (ex *Executor) filterLoadFieldIndexes(action *SegmentAction, loadInfo *querypb.SegmentLoadInfo) {
+	loaded := typeutil.NewSet[int64]()
+	for _, segment := range ex.dist.SegmentDistManager.GetByFilter(meta.WithNodeID(action.Node()), meta.WithSegmentID(action.GetSegmentID())) {
+		for indexID := range segment.IndexInfo {
+			loaded.Insert(indexID)
+		}
+	}
+	fieldIDs := typeutil.NewSet(action.GetFieldIDs()...)
+	loadInfo.IndexInfos = lo.Filter(loadInfo.GetIndexInfos(), func(info *querypb.FieldIndexInfo, _ int) bool {
+		return fieldIDs.Contain(info.GetFieldID()) || loaded.Contain(info.GetIndexID())
+	})
+}
+
 // If we enable following checking when loading segments,
 // 1. all segment should always be loaded by streamingnode but not 2.5 querynode, make some search and query failure when upgrading.
 // Otherwise, some search and query result will be wrong when upgrading.
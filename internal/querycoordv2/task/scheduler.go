@@ -19,6 +19,7 @@ package task
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -962,6 +963,24 @@ func WithTaskTypeFilter(taskType Type) TaskFilter {
 	}
 }
 
+func WithReplicaID2TaskFilter(replicaID int64) TaskFilter {
+	return func(task Task) bool {
+		return task.ReplicaID() == replicaID
+	}
+}
+
+// WithNodeID2TaskFilter filters the tasks with any action on the node.
+func WithNodeID2TaskFilter(nodeID int64) TaskFilter {
+	return func(task Task) bool {
+		for _, action := range task.Actions() {
+			if action.Node() == nodeID {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 func (scheduler *taskScheduler) GetChannelTaskNum(filters ...TaskFilter) int {
 	if len(filters) == 0 {
 		return scheduler.channelTasks.Len()
@@ -1060,11 +1079,25 @@ func (scheduler *taskScheduler) schedule(node int64) {
 	})
 	preprocessDur := tr.RecordSpan()
 
+	// The executor limits the number of executing channel tasks,
+	// commit the channel tasks one by one ordered by priority then importance,
+	// so the channels of the important collections are recovered first among the tasks of the same priority.
+	commmittedNum := atomic.NewInt32(0)
+	channelTasks, otherTasks := lo.FilterReject(toProcess, func(task Task, _ int) bool {
+		_, ok := task.(*ChannelTask)
+		return ok
+	})
+	sortByPriorityAndImportance(channelTasks)
+	for _, task := range channelTasks {
+		if scheduler.process(task) {
+			commmittedNum.Inc()
+		}
+	}
+
 	// The scheduler doesn't limit the number of tasks,
 	// to commit tasks to executors as soon as possible, to reach higher merge possibility
-	commmittedNum := atomic.NewInt32(0)
-	funcutil.ProcessFuncParallel(len(toProcess), hardware.GetCPUNum(), func(idx int) error {
-		if scheduler.process(toProcess[idx]) {
+	funcutil.ProcessFuncParallel(len(otherTasks), hardware.GetCPUNum(), func(idx int) error {
+		if scheduler.process(otherTasks[idx]) {
 			commmittedNum.Inc()
 		}
 		return nil
@@ -1243,6 +1276,10 @@ func (scheduler *taskScheduler) recordSegmentTaskError(task *SegmentTask) {
 		mlog.String("status", task.Status()),
 		mlog.Err(task.err),
 	)
+	if fieldIDs := task.loadFieldIDs(); len(fieldIDs) > 0 {
+		meta.GlobalFailedLoadCache.PutFieldFailure(task.collectionID, task.SegmentID(), fieldIDs, task.Err())
+		return
+	}
 	meta.GlobalFailedLoadCache.Put(task.collectionID, task.Err())
 }
 
@@ -1300,6 +1337,10 @@ func (scheduler *taskScheduler) remove(task Task) {
 			task.Err() != nil &&
 			!errors.IsAny(task.Err(), merr.ErrChannelNotFound, merr.ErrServiceTooManyRequests) {
 			scheduler.recordSegmentTaskError(task)
+		} else if task.Status() == TaskStatusSucceeded {
+			if fieldIDs := task.loadFieldIDs(); len(fieldIDs) > 0 {
+				meta.GlobalFailedLoadCache.RemoveFieldFailure(task.CollectionID(), task.SegmentID(), fieldIDs)
+			}
 		}
 
 	case *ChannelTask:
@@ -1439,3 +1480,13 @@ func (scheduler *taskScheduler) checkStale(task Task, checkDistExist bool) error
 
 	return nil
 }
+
+// sortByPriorityAndImportance orders the tasks by priority, and by importance among the tasks of the same priority.
+func sortByPriorityAndImportance(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Priority() != tasks[j].Priority() {
+			return tasks[i].Priority() > tasks[j].Priority()
+		}
+		return tasks[i].Importance() > tasks[j].Importance()
+	})
+}
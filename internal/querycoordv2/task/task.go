@@ -86,6 +86,9 @@ type Task interface {
 	Err() error
 	Priority() Priority
 	SetPriority(priority Priority)
+	// Importance orders the tasks with the same priority, the higher one is executed first.
+	Importance() int64
+	SetImportance(importance int64)
 	Index() string // dedup indexing string
 
 	// cancel the task as we don't need to continue it
@@ -122,13 +125,14 @@ type baseTask struct {
 	shard        string
 	loadType     querypb.LoadType
 
-	source   Source
-	status   *atomic.String
-	priority Priority
-	err      error
-	actions  []Action
-	step     int
-	reason   string
+	source     Source
+	status     *atomic.String
+	priority   Priority
+	importance int64
+	err        error
+	actions    []Action
+	step       int
+	reason     string
 
 	// span for tracing
 	span trace.Span
@@ -215,6 +219,14 @@ func (task *baseTask) SetPriority(priority Priority) {
 	task.priority = priority
 }
 
+func (task *baseTask) Importance() int64 {
+	return task.importance
+}
+
+func (task *baseTask) SetImportance(importance int64) {
+	task.importance = importance
+}
+
 func (task *baseTask) Index() string {
 	return fmt.Sprintf("[replica=%d]", task.ReplicaID())
 }
@@ -379,6 +391,17 @@ func (task *SegmentTask) SegmentID() typeutil.UniqueID {
 	return task.segmentID
 }
 
+// loadFieldIDs returns the fields to load by the task, empty means the whole segment.
+func (task *SegmentTask) loadFieldIDs() []int64 {
+	var fieldIDs []int64
+	for _, action := range task.Actions() {
+		if action, ok := action.(*SegmentAction); ok {
+			fieldIDs = append(fieldIDs, action.GetFieldIDs()...)
+		}
+	}
+	return fieldIDs
+}
+
 func (task *SegmentTask) Index() string {
 	return fmt.Sprintf("%s[segment=%d][growing=%t]", task.baseTask.Index(), task.segmentID, task.Actions()[0].(*SegmentAction).GetScope() == querypb.DataScope_Streaming)
 }
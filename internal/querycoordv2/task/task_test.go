@@ -3264,3 +3264,25 @@ func (suite *TaskSuite) TestNodeTaskQueueRangeByNodePriority() {
 	})
 	suite.Equal([]Priority{TaskPriorityHigh, TaskPriorityNormal, TaskPriorityLow}, visited)
 }
+
+func (suite *TaskSuite) TestSortByPriorityAndImportance() {
+	ctx := context.Background()
+	newTask := func(id int64, priority Priority, importance int64) Task {
+		task, err := NewChannelTask(ctx, time.Minute, WrapIDSource(0), suite.collection, suite.replica,
+			NewChannelAction(1, ActionTypeGrow, "ch-0"))
+		suite.NoError(err)
+		task.SetID(id)
+		task.SetPriority(priority)
+		task.SetImportance(importance)
+		return task
+	}
+	tasks := []Task{
+		newTask(1, TaskPriorityNormal, 10),
+		newTask(2, TaskPriorityHigh, 0),
+		newTask(3, TaskPriorityNormal, 20),
+		newTask(4, TaskPriorityHigh, 5),
+		newTask(5, TaskPriorityLow, 100),
+	}
+	sortByPriorityAndImportance(tasks)
+	suite.Equal([]int64{4, 2, 3, 1, 5}, lo.Map(tasks, func(task Task, _ int) int64 { return task.ID() }))
+}
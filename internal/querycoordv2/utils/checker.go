@@ -28,6 +28,7 @@ const (
 	IndexCheckerName   = "index_checker"
 	LeaderCheckerName  = "leader_checker"
 	ManualBalanceName  = "manual_balance"
+	SegmentReloadName  = "segment_reload"
 )
 
 type CheckerType int32
@@ -39,6 +40,7 @@ const (
 	IndexChecker
 	LeaderChecker
 	ManualBalance
+	SegmentReload
 )
 
 var checkerNames = map[CheckerType]string{
@@ -48,6 +50,7 @@ var checkerNames = map[CheckerType]string{
 	IndexChecker:   IndexCheckerName,
 	LeaderChecker:  LeaderCheckerName,
 	ManualBalance:  ManualBalanceName,
+	SegmentReload:  SegmentReloadName,
 }
 
 func (s CheckerType) String() string {
@@ -19,6 +19,7 @@ package utils
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -190,6 +191,8 @@ func GetShardLeadersWithChannelsAndReplicaFilter(
 		ids := make([]int64, 0, len(replicas))
 		addrs := make([]string, 0, len(replicas))
 		serviceable := make([]bool, 0, len(replicas))
+		weights := make([]int32, 0, len(replicas))
+		scores := make([]float64, 0, len(replicas))
 		for _, replica := range replicas {
 			if replicaFilter != nil && !replicaFilter(replica) {
 				continue
@@ -205,6 +208,8 @@ func GetShardLeadersWithChannelsAndReplicaFilter(
 				ids = append(ids, info.ID())
 				addrs = append(addrs, info.Addr())
 				serviceable = append(serviceable, leader.IsServiceable())
+				weights = append(weights, replica.GetWeight())
+				scores = append(scores, nodeMgr.HealthScore(info.ID()))
 			}
 		}
 
@@ -215,17 +220,49 @@ func GetShardLeadersWithChannelsAndReplicaFilter(
 			return nil, err
 		}
 
-		ret = append(ret, &querypb.ShardLeadersList{
-			ChannelName: channel.GetChannelName(),
-			NodeIds:     ids,
-			NodeAddrs:   addrs,
-			Serviceable: serviceable,
-		})
+		ret = append(ret, sortShardLeadersByHealth(&querypb.ShardLeadersList{
+			ChannelName:  channel.GetChannelName(),
+			NodeIds:      ids,
+			NodeAddrs:    addrs,
+			Serviceable:  serviceable,
+			Weights:      weights,
+			HealthScores: scores,
+		}))
 	}
 
 	return ret, nil
 }
 
+// sortShardLeadersByHealth orders the leaders of the shard by the health scores from the healthiest,
+// the leaders with the same score keep the replica order.
+func sortShardLeadersByHealth(leaders *querypb.ShardLeadersList) *querypb.ShardLeadersList {
+	order := make([]int, len(leaders.GetNodeIds()))
+	for i := range order {
+		order[i] = i
+	}
+	scores := leaders.GetHealthScores()
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	sorted := &querypb.ShardLeadersList{
+		ChannelName:  leaders.GetChannelName(),
+		NodeIds:      make([]int64, 0, len(order)),
+		NodeAddrs:    make([]string, 0, len(order)),
+		Serviceable:  make([]bool, 0, len(order)),
+		Weights:      make([]int32, 0, len(order)),
+		HealthScores: make([]float64, 0, len(order)),
+	}
+	for _, i := range order {
+		sorted.NodeIds = append(sorted.NodeIds, leaders.GetNodeIds()[i])
+		sorted.NodeAddrs = append(sorted.NodeAddrs, leaders.GetNodeAddrs()[i])
+		sorted.Serviceable = append(sorted.Serviceable, leaders.GetServiceable()[i])
+		sorted.Weights = append(sorted.Weights, leaders.GetWeights()[i])
+		sorted.HealthScores = append(sorted.HealthScores, scores[i])
+	}
+	return sorted
+}
+
 func GetShardLeaders(ctx context.Context,
 	m *meta.Meta,
 	targetMgr meta.TargetManagerInterface,
@@ -308,6 +345,35 @@ func checkCollectionQueryable(ctx context.Context, m *meta.Meta, targetMgr meta.
 	return nil
 }
 
+// IsReplicaServiceable returns whether the replica has a serviceable shard leader for every channel of the current target.
+func IsReplicaServiceable(ctx context.Context, targetMgr meta.TargetManagerInterface, dist *meta.DistributionManager, replica *meta.Replica) bool {
+	channels := targetMgr.GetDmChannelsByCollection(ctx, replica.GetCollectionID(), meta.CurrentTarget)
+	if len(channels) == 0 {
+		return false
+	}
+	for channelName := range channels {
+		leader := dist.ChannelDistManager.GetShardLeader(channelName, replica)
+		if leader == nil || !leader.IsServiceable() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsReplicaWarmingUp returns whether the replica is warming up, which is not serviceable while another replica
+// of the collection is serving, e.g. the replica spawned for a loaded collection.
+func IsReplicaWarmingUp(ctx context.Context, m *meta.Meta, targetMgr meta.TargetManagerInterface, dist *meta.DistributionManager, replica *meta.Replica) bool {
+	if IsReplicaServiceable(ctx, targetMgr, dist, replica) {
+		return false
+	}
+	for _, other := range m.GetByCollection(ctx, replica.GetCollectionID()) {
+		if other.GetID() != replica.GetID() && IsReplicaServiceable(ctx, targetMgr, dist, other) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetChannelRWAndRONodesFor260 gets the RW and RO nodes of the channel.
 func GetChannelRWAndRONodesFor260(replica *meta.Replica, nodeManager *session.NodeManager) ([]int64, []int64) {
 	rwNodes, roNodes := replica.GetRWSQNodes(), replica.GetROSQNodes()
@@ -208,6 +208,23 @@ func (suite *UtilTestSuite) TestCheckLeaderAvaliableFailed() {
 	})
 }
 
+func (suite *UtilTestSuite) TestSortShardLeadersByHealth() {
+	leaders := sortShardLeadersByHealth(&querypb.ShardLeadersList{
+		ChannelName:  "channel",
+		NodeIds:      []int64{1, 2, 3},
+		NodeAddrs:    []string{"addr1", "addr2", "addr3"},
+		Serviceable:  []bool{true, false, true},
+		Weights:      []int32{1, 2, 3},
+		HealthScores: []float64{0.5, 1, 1},
+	})
+	suite.Equal("channel", leaders.GetChannelName())
+	suite.Equal([]int64{2, 3, 1}, leaders.GetNodeIds())
+	suite.Equal([]string{"addr2", "addr3", "addr1"}, leaders.GetNodeAddrs())
+	suite.Equal([]bool{false, true, true}, leaders.GetServiceable())
+	suite.Equal([]int32{2, 3, 1}, leaders.GetWeights())
+	suite.Equal([]float64{1, 1, 0.5}, leaders.GetHealthScores())
+}
+
 func (suite *UtilTestSuite) TestGetChannelRWAndRONodesFor260() {
 	nodes := []int64{1, 2, 3, 4, 5}
 	nodeManager := session.NewNodeManager()
@@ -0,0 +1,62 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"sync"
+)
+
+// AccessStats counts the reads of the sealed segments, the counts are drained and reported
+// to the coordinator along with the data distribution, see GetDataDistribution.
+// All the methods are no-op on nil.
+type AccessStats struct {
+	mu     sync.Mutex
+	counts map[int64]int64
+}
+
+func NewAccessStats() *AccessStats {
+	return &AccessStats{
+		counts: make(map[int64]int64),
+	}
+}
+
+// Record counts a read of each of the segments.
+func (s *AccessStats) Record(segments []Segment) {
+	if s == nil || len(segments) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, segment := range segments {
+		s.counts[segment.ID()]++
+	}
+}
+
+// Drain returns the read counts since the last drain and resets them.
+func (s *AccessStats) Drain() map[int64]int64 {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.counts) == 0 {
+		return nil
+	}
+	counts := s.counts
+	s.counts = make(map[int64]int64)
+	return counts
+}
@@ -0,0 +1,43 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessStats(t *testing.T) {
+	newSegment := func(id int64) Segment {
+		segment := NewMockSegment(t)
+		segment.EXPECT().ID().Return(id)
+		return segment
+	}
+	segment1, segment2 := newSegment(1), newSegment(2)
+
+	stats := NewAccessStats()
+	assert.Nil(t, stats.Drain())
+	stats.Record([]Segment{segment1, segment2})
+	stats.Record([]Segment{segment1})
+	assert.Equal(t, map[int64]int64{1: 2, 2: 1}, stats.Drain())
+	assert.Nil(t, stats.Drain())
+
+	var nilStats *AccessStats
+	nilStats.Record([]Segment{segment1})
+	assert.Nil(t, nilStats.Drain())
+}
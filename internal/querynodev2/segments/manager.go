@@ -70,16 +70,21 @@ func IncreaseVersion(version int64) SegmentAction {
 }
 
 type Manager struct {
-	Collection CollectionManager
-	Segment    SegmentManager
-	Loader     Loader
+	Collection  CollectionManager
+	Segment     SegmentManager
+	Loader      Loader
+	AccessStats *AccessStats
+	// LoadingSegmentNum is the number of segments waiting or being loaded by the loader,
+	// reported to the coordinator along with the data distribution.
+	LoadingSegmentNum atomic.Int64
 }
 
 func NewManager() *Manager {
 	segMgr := NewSegmentManager()
 	manager := &Manager{
-		Collection: NewCollectionManager(),
-		Segment:    segMgr,
+		Collection:  NewCollectionManager(),
+		Segment:     segMgr,
+		AccessStats: NewAccessStats(),
 	}
 
 	return manager
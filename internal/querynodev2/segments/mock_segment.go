@@ -1238,6 +1238,61 @@ func (_c *MockSegment_LoadInfo_Call) RunAndReturn(run func() *querypb.SegmentLoa
 	return _c
 }
 
+// LoadedRowNum provides a mock function with no fields
+func (_m *MockSegment) LoadedRowNum() (int64, bool) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadedRowNum")
+	}
+
+	var r0 int64
+	var r1 bool
+	if rf, ok := ret.Get(0).(func() (int64, bool)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockSegment_LoadedRowNum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadedRowNum'
+type MockSegment_LoadedRowNum_Call struct {
+	*mock.Call
+}
+
+// LoadedRowNum is a helper method to define mock.On call
+func (_e *MockSegment_Expecter) LoadedRowNum() *MockSegment_LoadedRowNum_Call {
+	return &MockSegment_LoadedRowNum_Call{Call: _e.mock.On("LoadedRowNum")}
+}
+
+func (_c *MockSegment_LoadedRowNum_Call) Run(run func()) *MockSegment_LoadedRowNum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSegment_LoadedRowNum_Call) Return(_a0 int64, _a1 bool) *MockSegment_LoadedRowNum_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSegment_LoadedRowNum_Call) RunAndReturn(run func() (int64, bool)) *MockSegment_LoadedRowNum_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MayPkExist provides a mock function with given fields: lc
 func (_m *MockSegment) MayPkExist(lc *storage.LocationsCache) bool {
 	ret := _m.Called(lc)
@@ -181,6 +181,9 @@ func Retrieve(ctx context.Context, manager *Manager, plan *RetrievePlan, req *qu
 	if req.GetScope() == querypb.DataScope_Historical {
 		SegType = SegmentTypeSealed
 		retrieveSegments, err = validateOnHistorical(ctx, manager, collID, req.GetReq().GetPartitionIDs(), segIDs)
+		if err == nil {
+			manager.AccessStats.Record(retrieveSegments)
+		}
 	} else {
 		SegType = SegmentTypeGrowing
 		retrieveSegments, err = validateOnStream(ctx, manager, collID, req.GetReq().GetPartitionIDs(), segIDs)
@@ -207,6 +210,9 @@ func RetrieveStream(ctx context.Context, manager *Manager, plan *RetrievePlan, r
 	if req.GetScope() == querypb.DataScope_Historical {
 		SegType = SegmentTypeSealed
 		retrieveSegments, err = validateOnHistorical(ctx, manager, collID, req.GetReq().GetPartitionIDs(), segIDs)
+		if err == nil {
+			manager.AccessStats.Record(retrieveSegments)
+		}
 	} else {
 		SegType = SegmentTypeGrowing
 		retrieveSegments, err = validateOnStream(ctx, manager, collID, req.GetReq().GetPartitionIDs(), segIDs)
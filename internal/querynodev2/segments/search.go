@@ -159,6 +159,7 @@ func SearchHistorical(ctx context.Context, manager *Manager, searchReq *SearchRe
 	if err != nil {
 		return nil, nil, err
 	}
+	manager.AccessStats.Record(segments)
 	searchResults, err := searchSegments(ctx, manager, segments, SegmentTypeSealed, searchReq)
 	return searchResults, segments, err
 }
@@ -590,8 +590,13 @@ func (s *LocalSegment) InsertCount() int64 {
 
 func (s *LocalSegment) RowNum() int64 {
 	// if segment is not loaded, return 0 (maybe not loaded or release by lru)
+	rowNum, _ := s.LoadedRowNum()
+	return rowNum
+}
+
+func (s *LocalSegment) LoadedRowNum() (int64, bool) {
 	if !s.ptrLock.PinIf(state.IsDataLoaded) {
-		return 0
+		return 0, false
 	}
 	defer s.ptrLock.Unpin()
 
@@ -603,7 +608,7 @@ func (s *LocalSegment) RowNum() int64 {
 			return nil, nil
 		}).Await()
 	}
-	return rowNum
+	return rowNum, true
 }
 
 func (s *LocalSegment) MemSize() int64 {
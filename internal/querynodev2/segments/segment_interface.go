@@ -76,6 +76,8 @@ type Segment interface {
 	InsertCount() int64
 	// RowNum returns the number of rows, it's slow, so DO NOT call it in a loop
 	RowNum() int64
+	// LoadedRowNum returns the number of rows like RowNum, and false if the segment data is not loaded
+	LoadedRowNum() (int64, bool)
 	MemSize() int64
 	// ResourceUsageEstimate returns the estimated resource usage of the segment
 	ResourceUsageEstimate() ResourceUsage
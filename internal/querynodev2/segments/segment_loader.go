@@ -443,6 +443,7 @@ func (loader *segmentLoader) prepare(ctx context.Context, segmentType SegmentTyp
 		if !isLoaded && !isLoading {
 			infos = append(infos, segment)
 			loader.loadingSegments.Insert(segment.GetSegmentID(), newLoadResult())
+			loader.manager.LoadingSegmentNum.Inc()
 		} else {
 			mlog.Info(context.TODO(), "skip loaded/loading segment",
 				mlog.Int64("segmentID", segment.GetSegmentID()),
@@ -471,6 +472,7 @@ func (loader *segmentLoader) unregister(segments ...*querypb.SegmentLoadInfo) {
 		result, ok := loader.loadingSegments.GetAndRemove(segments[i].GetSegmentID())
 		if ok {
 			result.SetResult(failure)
+			loader.manager.LoadingSegmentNum.Dec()
 		}
 	}
 }
@@ -310,6 +310,8 @@ func (suite *SegmentLoaderSuite) TestLoad() {
 		InsertChannel: fmt.Sprintf("by-dev-rootcoord-dml_0_%dv0", suite.collectionID),
 	})
 	suite.NoError(err)
+	// the loaded segments leave the loading queue
+	suite.EqualValues(0, suite.manager.LoadingSegmentNum.Load())
 }
 
 func (suite *SegmentLoaderSuite) TestLoadFail() {
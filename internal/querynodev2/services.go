@@ -797,19 +797,28 @@ func (node *QueryNode) GetSegmentInfo(ctx context.Context, in *querypb.GetSegmen
 		}
 
 		info := &querypb.SegmentInfo{
-			SegmentID:      segment.ID(),
-			SegmentState:   segment.Type(),
-			DmChannel:      segment.Shard().VirtualName(),
-			PartitionID:    segment.Partition(),
-			CollectionID:   segment.Collection(),
-			NodeID:         node.GetNodeID(),
-			NodeIds:        []int64{node.GetNodeID()},
-			MemSize:        segment.MemSize(),
-			NumRows:        segment.InsertCount(),
-			IndexName:      indexName,
-			IndexID:        indexID,
-			IndexInfos:     indexInfos,
-			StorageVersion: segment.LoadInfo().GetStorageVersion(),
+			SegmentID:          segment.ID(),
+			SegmentState:       segment.Type(),
+			DmChannel:          segment.Shard().VirtualName(),
+			PartitionID:        segment.Partition(),
+			CollectionID:       segment.Collection(),
+			NodeID:             node.GetNodeID(),
+			NodeIds:            []int64{node.GetNodeID()},
+			MemSize:            segment.MemSize(),
+			NumRows:            segment.InsertCount(),
+			IndexName:          indexName,
+			IndexID:            indexID,
+			IndexInfos:         indexInfos,
+			StorageVersion:     segment.LoadInfo().GetStorageVersion(),
+			LastDeltaTimestamp: segment.LastDeltaTimestamp(),
+		}
+		if in.GetWithValidRows() {
+			// the row count is unknown if the segment data is not loaded, e.g. lazy loaded or evicted
+			validRows, loaded := segment.LoadedRowNum()
+			if !loaded {
+				validRows = -1
+			}
+			info.ValidRows = validRows
 		}
 		segmentInfos = append(segmentInfos, info)
 	}
@@ -1303,9 +1312,11 @@ func (node *QueryNode) GetDataDistribution(ctx context.Context, req *querypb.Get
 	if !hasDataDistributionChange(req, lastModifyTs) {
 		node.distDeltaTracker.mu.Unlock()
 		resp := &querypb.GetDataDistributionResponse{
-			Status:       merr.Success(),
-			NodeID:       node.GetNodeID(),
-			LastModifyTs: lastModifyTs,
+			Status:              merr.Success(),
+			NodeID:              node.GetNodeID(),
+			LastModifyTs:        lastModifyTs,
+			SegmentAccessCounts: node.manager.AccessStats.Drain(),
+			LoadingSegmentNum:   node.manager.LoadingSegmentNum.Load(),
 		}
 		return resp, nil
 	}
@@ -1452,6 +1463,8 @@ func (node *QueryNode) GetDataDistribution(ctx context.Context, req *querypb.Get
 		RemovedChannelNames: removedChannelNames,
 		TotalSegmentCount:   int64(totalSegmentCount),
 		TotalChannelCount:   int64(totalChannelCount),
+		SegmentAccessCounts: node.manager.AccessStats.Drain(),
+		LoadingSegmentNum:   node.manager.LoadingSegmentNum.Load(),
 	}
 	return resp, nil
 }
@@ -0,0 +1,149 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"strconv"
+
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// collectionRateLimitKeyPairs lists the (max, min) pairs of the collection level rate limit properties.
+var collectionRateLimitKeyPairs = [][2]string{
+	{common.CollectionInsertRateMaxKey, common.CollectionInsertRateMinKey},
+	{common.CollectionDeleteRateMaxKey, common.CollectionDeleteRateMinKey},
+	{common.CollectionBulkLoadRateMaxKey, common.CollectionBulkLoadRateMinKey},
+	{common.CollectionQueryRateMaxKey, common.CollectionQueryRateMinKey},
+	{common.CollectionSearchRateMaxKey, common.CollectionSearchRateMinKey},
+	{common.CollectionGetRateMaxKey, common.CollectionGetRateMinKey},
+}
+
+// collectionSingleRateLimitKeys lists the collection level rate limit properties without a min counterpart.
+var collectionSingleRateLimitKeys = []string{
+	common.CollectionDiskQuotaKey,
+	common.CollectionDiskQuotaMBKey,
+	common.PartitionQueryRateMaxKey,
+	common.PartitionSearchRateMaxKey,
+	common.PartitionGetRateMaxKey,
+	common.CollectionFlushRateMaxKey,
+	common.CollectionCompactionRateMaxKey,
+}
+
+// validateAlterCollectionProperties validates the property changes of an alter collection properties request
+// that have cross-component effects before the request is broadcasted. A group of properties is only validated
+// if at least one of its keys is changed by the request, so the invalid values set before are not rechecked.
+// The effects on the other components are applied by the ack callbacks of the broadcasted message,
+// so the request is either rejected here or applied entirely.
+func validateAlterCollectionProperties(oldProps, newProps map[string]string) error {
+	rateLimitKeys := make([]string, 0, 2*len(collectionRateLimitKeyPairs)+len(collectionSingleRateLimitKeys))
+	for _, pair := range collectionRateLimitKeyPairs {
+		rateLimitKeys = append(rateLimitKeys, pair[0], pair[1])
+	}
+	rateLimitKeys = append(rateLimitKeys, collectionSingleRateLimitKeys...)
+
+	if isAnyPropertyChanged(oldProps, newProps, common.CollectionTTLConfigKey) {
+		if err := validateCollectionTTLProperty(newProps); err != nil {
+			return err
+		}
+	}
+	if isAnyPropertyChanged(oldProps, newProps, rateLimitKeys...) {
+		if err := validateCollectionRateLimitProperties(newProps); err != nil {
+			return err
+		}
+	}
+	if isAnyPropertyChanged(oldProps, newProps, common.CollectionReplicaNumber, common.CollectionResourceGroups) {
+		if err := validateCollectionLoadConfigProperties(newProps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateCollectionTTLProperty(newProps map[string]string) error {
+	value, ok := newProps[common.CollectionTTLConfigKey]
+	if !ok {
+		return nil
+	}
+	ttl, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ttl < 0 || ttl > common.MaxTTLSeconds {
+		return merr.WrapErrParameterInvalidMsg("invalid collection property %s=%s, should be an integer in [0, %d]",
+			common.CollectionTTLConfigKey, value, common.MaxTTLSeconds)
+	}
+	return nil
+}
+
+func validateCollectionRateLimitProperties(newProps map[string]string) error {
+	parse := func(key string) (float64, bool, error) {
+		value, ok := newProps[key]
+		if !ok {
+			return 0, false, nil
+		}
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			return 0, true, merr.WrapErrParameterInvalidMsg("invalid collection property %s=%s, should be a non-negative number", key, value)
+		}
+		return rate, true, nil
+	}
+	for _, key := range collectionSingleRateLimitKeys {
+		if _, _, err := parse(key); err != nil {
+			return err
+		}
+	}
+	for _, pair := range collectionRateLimitKeyPairs {
+		maxRate, maxExist, err := parse(pair[0])
+		if err != nil {
+			return err
+		}
+		minRate, minExist, err := parse(pair[1])
+		if err != nil {
+			return err
+		}
+		if maxExist && minExist && minRate > maxRate {
+			return merr.WrapErrParameterInvalidMsg("invalid collection properties, %s(%v) should not be greater than %s(%v)",
+				pair[1], minRate, pair[0], maxRate)
+		}
+	}
+	return nil
+}
+
+func validateCollectionLoadConfigProperties(newProps map[string]string) error {
+	if value, ok := newProps[common.CollectionReplicaNumber]; ok {
+		replicaNum, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || replicaNum <= 0 {
+			return merr.WrapErrParameterInvalidMsg("invalid collection property %s=%s, should be a positive integer", common.CollectionReplicaNumber, value)
+		}
+	}
+	if value, ok := newProps[common.CollectionResourceGroups]; ok {
+		if _, err := common.CollectionLevelResourceGroups(common.NewKeyValuePairs(map[string]string{common.CollectionResourceGroups: value})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isAnyPropertyChanged checks if any of the keys is added, deleted or modified.
+func isAnyPropertyChanged(oldProps, newProps map[string]string, keys ...string) bool {
+	for _, key := range keys {
+		oldValue, oldOk := oldProps[key]
+		newValue, newOk := newProps[key]
+		if oldOk != newOk || oldValue != newValue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestValidateAlterCollectionProperties_Unchanged(t *testing.T) {
+	oldProps := map[string]string{common.CollectionTTLConfigKey: "-1", "foo": "bar"}
+
+	// the unchanged properties are not validated again.
+	assert.NoError(t, validateAlterCollectionProperties(oldProps, map[string]string{common.CollectionTTLConfigKey: "-1", "foo": "baz"}))
+	assert.Error(t, validateAlterCollectionProperties(oldProps, map[string]string{common.CollectionTTLConfigKey: "-2"}))
+
+	// deleting a key is also a change.
+	assert.NoError(t, validateAlterCollectionProperties(oldProps, map[string]string{"foo": "bar"}))
+}
+
+func TestValidateAlterCollectionProperties(t *testing.T) {
+	cases := []struct {
+		name  string
+		props map[string]string
+		valid bool
+	}{
+		{"valid ttl", map[string]string{common.CollectionTTLConfigKey: "0"}, true},
+		{"negative ttl", map[string]string{common.CollectionTTLConfigKey: "-1"}, false},
+		{"non-integer ttl", map[string]string{common.CollectionTTLConfigKey: "1.5"}, false},
+		{"too large ttl", map[string]string{common.CollectionTTLConfigKey: "3155760001"}, false},
+		{"valid rate", map[string]string{common.CollectionInsertRateMaxKey: "10", common.CollectionInsertRateMinKey: "1"}, true},
+		{"invalid rate", map[string]string{common.CollectionSearchRateMaxKey: "abc"}, false},
+		{"negative rate", map[string]string{common.CollectionQueryRateMinKey: "-1"}, false},
+		{"min greater than max", map[string]string{common.CollectionDeleteRateMaxKey: "1", common.CollectionDeleteRateMinKey: "2"}, false},
+		{"invalid disk quota", map[string]string{common.CollectionDiskQuotaKey: "x"}, false},
+		{"negative collection disk quota", map[string]string{common.CollectionDiskQuotaMBKey: "-1"}, false},
+		{"valid flush and compaction rate", map[string]string{common.CollectionFlushRateMaxKey: "6", common.CollectionCompactionRateMaxKey: "1"}, true},
+		{"invalid flush rate", map[string]string{common.CollectionFlushRateMaxKey: "x"}, false},
+		{"negative compaction rate", map[string]string{common.CollectionCompactionRateMaxKey: "-1"}, false},
+		{"valid load config", map[string]string{common.CollectionReplicaNumber: "2", common.CollectionResourceGroups: "rg1,rg2"}, true},
+		{"zero replica", map[string]string{common.CollectionReplicaNumber: "0"}, false},
+		{"empty resource groups", map[string]string{common.CollectionResourceGroups: ""}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateAlterCollectionProperties(map[string]string{}, c.props)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// CollectionFreezeState is the read-only freeze state of a collection.
+type CollectionFreezeState struct {
+	DbName         string `json:"db_name"`
+	CollectionName string `json:"collection_name"`
+	CollectionID   int64  `json:"collection_id"`
+	Frozen         bool   `json:"frozen"`
+	// FreezeTimestamp is the tso since which the collection is read-only, zero if it's not frozen.
+	FreezeTimestamp uint64 `json:"freeze_timestamp,omitempty"`
+	FreezeTime      string `json:"freeze_time,omitempty"`
+}
+
+func newCollectionFreezeState(dbName string, coll *model.Collection) *CollectionFreezeState {
+	state := &CollectionFreezeState{
+		DbName:         dbName,
+		CollectionName: coll.Name,
+		CollectionID:   coll.CollectionID,
+	}
+	ts := common.GetCollectionFreezeTimestamp(common.CloneKeyValuePairs(coll.Properties).ToMap())
+	if ts != 0 {
+		state.Frozen = true
+		state.FreezeTimestamp = ts
+		state.FreezeTime = tsoutil.PhysicalTime(ts).Format(time.RFC3339)
+	}
+	return state
+}
+
+// GetCollectionFreezeState returns the read-only freeze state of the collection.
+func (c *Core) GetCollectionFreezeState(ctx context.Context, dbName string, collectionName string) (*CollectionFreezeState, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return nil, err
+	}
+	coll, err := c.meta.GetCollectionByName(ctx, dbName, collectionName, typeutil.MaxTimestamp, false)
+	if err != nil {
+		return nil, err
+	}
+	return newCollectionFreezeState(dbName, coll), nil
+}
+
+// FreezeCollection marks the collection read-only since a newly allocated timestamp. The timestamp is kept
+// in the collection properties, so the frozen state is visible in DescribeCollection, the quota center denies
+// the writes of the collection, and querycoord stops updating its targets with the segments flushed after it.
+// Freezing a frozen collection keeps the original freeze timestamp.
+func (c *Core) FreezeCollection(ctx context.Context, dbName string, collectionName string) (*CollectionFreezeState, error) {
+	state, err := c.GetCollectionFreezeState(ctx, dbName, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if state.Frozen {
+		return state, nil
+	}
+
+	ts, err := c.tsoAllocator.GenerateTSO(1)
+	if err != nil {
+		return nil, err
+	}
+	status, err := c.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Properties: []*commonpb.KeyValuePair{
+			{Key: common.CollectionFreezeTimestampKey, Value: strconv.FormatUint(ts, 10)},
+		},
+	})
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		return nil, err
+	}
+	mlog.Info(ctx, "collection is frozen",
+		mlog.String("dbName", dbName),
+		mlog.String("collectionName", collectionName),
+		mlog.Uint64("freezeTs", ts))
+	return c.GetCollectionFreezeState(ctx, dbName, collectionName)
+}
+
+// UnfreezeCollection removes the freeze timestamp of the collection, the writes and the target updates are resumed.
+func (c *Core) UnfreezeCollection(ctx context.Context, dbName string, collectionName string) (*CollectionFreezeState, error) {
+	state, err := c.GetCollectionFreezeState(ctx, dbName, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if !state.Frozen {
+		return state, nil
+	}
+
+	status, err := c.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+		DeleteKeys:     []string{common.CollectionFreezeTimestampKey},
+	})
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		return nil, err
+	}
+	mlog.Info(ctx, "collection is unfrozen",
+		mlog.String("dbName", dbName),
+		mlog.String("collectionName", collectionName),
+		mlog.Uint64("freezeTs", state.FreezeTimestamp))
+	return c.GetCollectionFreezeState(ctx, dbName, collectionName)
+}
+
+// getFrozenCollections returns the collections frozen by FreezeCollection.
+func (q *QuotaCenter) getFrozenCollections() []int64 {
+	frozenCollections := make([]int64, 0)
+	q.collectionIDToDBID.Range(func(collectionID, _ int64) bool {
+		if common.GetCollectionFreezeTimestamp(q.getCollectionLimitProperties(collectionID)) != 0 {
+			frozenCollections = append(frozenCollections, collectionID)
+		}
+		return true
+	})
+	return frozenCollections
+}
@@ -117,8 +117,6 @@ func (t *createCollectionTask) validate(ctx context.Context) error {
 
 // checkMaxCollectionsPerDB DB properties take precedence over quota configurations for max collections.
 func (t *createCollectionTask) checkMaxCollectionsPerDB(ctx context.Context, db2CollIDs map[int64][]int64) error {
-	Params := paramtable.Get()
-
 	collIDs, ok := db2CollIDs[t.header.DbId]
 	if !ok {
 		mlog.Warn(ctx, "can not found DB ID", mlog.String("collection", t.Req.GetCollectionName()), mlog.String("dbName", t.Req.GetDbName()))
@@ -131,27 +129,17 @@ func (t *createCollectionTask) checkMaxCollectionsPerDB(ctx context.Context, db2
 		return merr.WrapErrDatabaseNotFound(t.Req.GetDbName(), "failed to create collection")
 	}
 
-	check := func(maxColNumPerDB int) error {
-		if len(collIDs) >= maxColNumPerDB {
-			mlog.Warn(ctx, "unable to create collection because the number of collection has reached the limit in DB", mlog.Int("maxCollectionNumPerDB", maxColNumPerDB))
-			return merr.WrapErrCollectionNumLimitExceeded(t.Req.GetDbName(), maxColNumPerDB)
-		}
-		return nil
+	maxColNumPerDB, err := getMaxCollectionNumPerDB(db)
+	if err != nil {
+		mlog.Warn(ctx, "parse value of property fail", mlog.String("key", common.DatabaseMaxCollectionsKey),
+			mlog.String("value", db.GetProperty(common.DatabaseMaxCollectionsKey)), mlog.Err(err))
+		return err
 	}
-
-	maxColNumPerDBStr := db.GetProperty(common.DatabaseMaxCollectionsKey)
-	if maxColNumPerDBStr != "" {
-		maxColNumPerDB, err := strconv.Atoi(maxColNumPerDBStr)
-		if err != nil {
-			mlog.Warn(ctx, "parse value of property fail", mlog.String("key", common.DatabaseMaxCollectionsKey),
-				mlog.String("value", maxColNumPerDBStr), mlog.Err(err))
-			return merr.WrapErrServiceInternalMsg("parse value of property fail, key:%s, value:%s", common.DatabaseMaxCollectionsKey, maxColNumPerDBStr)
-		}
-		return check(maxColNumPerDB)
+	if len(collIDs) >= maxColNumPerDB {
+		mlog.Warn(ctx, "unable to create collection because the number of collection has reached the limit in DB", mlog.Int("maxCollectionNumPerDB", maxColNumPerDB))
+		return merr.WrapErrCollectionNumLimitExceeded(t.Req.GetDbName(), maxColNumPerDB)
 	}
-
-	maxColNumPerDB := Params.QuotaConfig.MaxCollectionNumPerDB.GetAsInt()
-	return check(maxColNumPerDB)
+	return nil
 }
 
 func checkGeometryDefaultValue(value string) error {
@@ -777,6 +765,7 @@ func (t *createCollectionTask) assignChannels(ctx context.Context) error {
 	vchannels, err := snmanager.StaticStreamingNodeManager.AllocVirtualChannels(ctx, balancer.AllocVChannelParam{
 		CollectionID: t.header.GetCollectionId(),
 		Num:          int(t.Req.GetShardsNum()),
+		ChannelClass: common.GetCollectionChannelClass(t.Req.GetProperties()...),
 	})
 	if err != nil {
 		return merr.Wrapf(err, "failed to allocate vchannels for collection %d (shards=%d)",
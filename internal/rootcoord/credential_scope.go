@@ -0,0 +1,210 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+
+	kvmetastore "github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
+	"github.com/milvus-io/milvus/pkg/v3/kv"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// credentialScopePrefix is the meta prefix of the credential scopes, next to the credentials.
+const credentialScopePrefix = kvmetastore.ComponentPrefix + "/credential-scope"
+
+// credentialScopes keeps the scopes of the credentials in the metastore with an in-memory copy.
+// A user with scopes can only operate the collections in its scopes with the allowed operation classes,
+// on top of the privileges granted to its roles, the user without scopes is not restricted.
+type credentialScopes struct {
+	kv     kv.TxnKV
+	mu     sync.RWMutex
+	scopes map[string]*internalpb.UserCredentialScopes
+}
+
+func newCredentialScopes(kv kv.TxnKV) *credentialScopes {
+	return &credentialScopes{
+		kv:     kv,
+		scopes: make(map[string]*internalpb.UserCredentialScopes),
+	}
+}
+
+func credentialScopeKey(username string) string {
+	return path.Join(credentialScopePrefix, username)
+}
+
+// Load loads the credential scopes from the metastore.
+func (s *credentialScopes) Load(ctx context.Context) error {
+	_, values, err := s.kv.LoadWithPrefix(ctx, credentialScopePrefix+"/")
+	if err != nil {
+		return err
+	}
+	scopes := make(map[string]*internalpb.UserCredentialScopes, len(values))
+	for _, value := range values {
+		userScopes := &internalpb.UserCredentialScopes{}
+		if err := proto.Unmarshal([]byte(value), userScopes); err != nil {
+			return err
+		}
+		scopes[userScopes.GetUsername()] = userScopes
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scopes = scopes
+	return nil
+}
+
+// Save replaces the scopes of the user.
+func (s *credentialScopes) Save(ctx context.Context, userScopes *internalpb.UserCredentialScopes) error {
+	value, err := proto.Marshal(userScopes)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.kv.Save(ctx, credentialScopeKey(userScopes.GetUsername()), string(value)); err != nil {
+		return err
+	}
+	s.scopes[userScopes.GetUsername()] = userScopes
+	return nil
+}
+
+// Remove removes the scopes of the user, so the user is not restricted any more.
+func (s *credentialScopes) Remove(ctx context.Context, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.scopes[username]; !ok {
+		return nil
+	}
+	if err := s.kv.Remove(ctx, credentialScopeKey(username)); err != nil {
+		return err
+	}
+	delete(s.scopes, username)
+	return nil
+}
+
+// Get returns the scopes of the user, nil if the user is not restricted.
+func (s *credentialScopes) Get(username string) []*internalpb.CredentialScope {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scopes[username].GetScopes()
+}
+
+// List returns the scopes of all the restricted users ordered by the username.
+func (s *credentialScopes) List() []*internalpb.UserCredentialScopes {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ret := make([]*internalpb.UserCredentialScopes, 0, len(s.scopes))
+	for _, userScopes := range s.scopes {
+		ret = append(ret, userScopes)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].GetUsername() < ret[j].GetUsername() })
+	return ret
+}
+
+// validateCredentialScope checks the operation classes and the existence of the database and the collection of the scope.
+func (c *Core) validateCredentialScope(ctx context.Context, scope *internalpb.CredentialScope) error {
+	scope.DbName = strings.TrimSpace(scope.GetDbName())
+	scope.CollectionName = strings.TrimSpace(scope.GetCollectionName())
+	if scope.GetDbName() == "" || scope.GetCollectionName() == "" {
+		return merr.WrapErrParameterInvalidMsg("db name and collection name of the credential scope are required, use %s for all", util.AnyWord)
+	}
+	if len(scope.GetOperationClasses()) == 0 {
+		return merr.WrapErrParameterInvalidMsg("operation classes of the credential scope are required")
+	}
+	for _, class := range scope.GetOperationClasses() {
+		if !util.IsOperationClass(class) {
+			return merr.WrapErrParameterInvalid("ddl, dml or dql", class, "invalid operation class")
+		}
+	}
+	if scope.GetDbName() == util.AnyWord {
+		if scope.GetCollectionName() != util.AnyWord {
+			return merr.WrapErrParameterInvalidMsg("collection %s must be scoped in a database", scope.GetCollectionName())
+		}
+		return nil
+	}
+	if _, err := c.meta.GetDatabaseByName(ctx, scope.GetDbName(), typeutil.MaxTimestamp); err != nil {
+		return err
+	}
+	if scope.GetCollectionName() == util.AnyWord {
+		return nil
+	}
+	_, err := c.meta.GetCollectionByName(ctx, scope.GetDbName(), scope.GetCollectionName(), typeutil.MaxTimestamp, false)
+	return err
+}
+
+// AlterCredentialScopes sets the scopes of the user, the user is not restricted if no scope is given.
+// The proxies reload the scopes with the policies to enforce them.
+func (c *Core) AlterCredentialScopes(ctx context.Context, username string, scopes []*internalpb.CredentialScope) error {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return err
+	}
+	username = strings.TrimSpace(username)
+	if username == util.UserRoot {
+		return merr.WrapErrParameterInvalidMsg("the credential of %s can not be scoped", util.UserRoot)
+	}
+	if _, err := c.meta.GetCredential(ctx, username); err != nil {
+		if errors.Is(err, merr.ErrIoKeyNotFound) {
+			return merr.WrapErrParameterInvalidMsg("user %s not found", username)
+		}
+		return err
+	}
+	for _, scope := range scopes {
+		if err := c.validateCredentialScope(ctx, scope); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if len(scopes) == 0 {
+		err = c.credentialScopes.Remove(ctx, username)
+	} else {
+		err = c.credentialScopes.Save(ctx, &internalpb.UserCredentialScopes{Username: username, Scopes: scopes})
+	}
+	if err != nil {
+		return err
+	}
+	mlog.Info(ctx, "credential scopes altered", mlog.String("username", username), mlog.Int("scopes", len(scopes)))
+	return c.proxyClientManager.RefreshPolicyInfoCache(ctx, &proxypb.RefreshPolicyInfoCacheRequest{
+		OpType: int32(typeutil.CacheRefresh),
+	})
+}
+
+// GetCredentialScopes returns the scopes of the user, empty if the user is not restricted.
+func (c *Core) GetCredentialScopes(ctx context.Context, username string) ([]*internalpb.CredentialScope, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return nil, err
+	}
+	return c.credentialScopes.Get(strings.TrimSpace(username)), nil
+}
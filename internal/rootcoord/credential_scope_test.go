@@ -0,0 +1,126 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestCredentialScopes(t *testing.T) {
+	ctx := context.Background()
+	kv := memkv.NewMemoryKV()
+	scopes := newCredentialScopes(kv)
+
+	var nilScopes *credentialScopes
+	assert.Nil(t, nilScopes.Get("user1"))
+	assert.Nil(t, nilScopes.List())
+
+	assert.NoError(t, scopes.Save(ctx, &internalpb.UserCredentialScopes{
+		Username: "user2",
+		Scopes: []*internalpb.CredentialScope{
+			{DbName: util.AnyWord, CollectionName: util.AnyWord, OperationClasses: []string{util.OperationClassDQL}},
+		},
+	}))
+	assert.NoError(t, scopes.Save(ctx, &internalpb.UserCredentialScopes{
+		Username: "user1",
+		Scopes: []*internalpb.CredentialScope{
+			{DbName: "db1", CollectionName: "coll1", OperationClasses: []string{util.OperationClassDML}},
+			{DbName: "db1", CollectionName: "coll2", OperationClasses: []string{util.OperationClassDQL}},
+		},
+	}))
+	assert.Len(t, scopes.Get("user1"), 2)
+	assert.Nil(t, scopes.Get("user3"))
+	list := scopes.List()
+	assert.Len(t, list, 2)
+	assert.Equal(t, "user1", list[0].GetUsername())
+
+	// the scopes are recovered from the metastore.
+	recovered := newCredentialScopes(kv)
+	assert.NoError(t, recovered.Load(ctx))
+	assert.Len(t, recovered.List(), 2)
+	assert.Equal(t, "coll2", recovered.Get("user1")[1].GetCollectionName())
+
+	assert.NoError(t, scopes.Remove(ctx, "user1"))
+	assert.NoError(t, scopes.Remove(ctx, "user3"))
+	assert.Nil(t, scopes.Get("user1"))
+	assert.NoError(t, recovered.Load(ctx))
+	assert.Len(t, recovered.List(), 1)
+}
+
+func TestCore_AlterCredentialScopes(t *testing.T) {
+	ctx := context.Background()
+	meta := mockrootcoord.NewIMetaTable(t)
+	c := newTestCore(withHealthyCode(), withMeta(meta), withValidProxyManager())
+	c.credentialScopes = newCredentialScopes(memkv.NewMemoryKV())
+
+	meta.EXPECT().GetCredential(mock.Anything, "user1").Return(&internalpb.CredentialInfo{Username: "user1"}, nil)
+	meta.EXPECT().GetCredential(mock.Anything, "user2").Return(nil, merr.WrapErrIoKeyNotFound("user2"))
+	meta.EXPECT().GetDatabaseByName(mock.Anything, "db1", mock.Anything).Return(&model.Database{Name: "db1"}, nil)
+	meta.EXPECT().GetDatabaseByName(mock.Anything, "db2", mock.Anything).Return(nil, merr.WrapErrDatabaseNotFound("db2"))
+	meta.EXPECT().GetCollectionByName(mock.Anything, "db1", "coll1", mock.Anything, false).Return(&model.Collection{Name: "coll1"}, nil)
+	meta.EXPECT().GetCollectionByName(mock.Anything, "db1", "coll2", mock.Anything, false).Return(nil, merr.WrapErrCollectionNotFound("coll2"))
+
+	err := c.AlterCredentialScopes(ctx, util.UserRoot, nil)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	err = c.AlterCredentialScopes(ctx, "user2", nil)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	for _, scope := range []*internalpb.CredentialScope{
+		{DbName: "db1", OperationClasses: []string{util.OperationClassDQL}},
+		{DbName: "db1", CollectionName: "coll1"},
+		{DbName: "db1", CollectionName: "coll1", OperationClasses: []string{"admin"}},
+		{DbName: util.AnyWord, CollectionName: "coll1", OperationClasses: []string{util.OperationClassDQL}},
+	} {
+		err = c.AlterCredentialScopes(ctx, "user1", []*internalpb.CredentialScope{scope})
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	}
+	err = c.AlterCredentialScopes(ctx, "user1", []*internalpb.CredentialScope{
+		{DbName: "db2", CollectionName: util.AnyWord, OperationClasses: []string{util.OperationClassDQL}},
+	})
+	assert.ErrorIs(t, err, merr.ErrDatabaseNotFound)
+	err = c.AlterCredentialScopes(ctx, "user1", []*internalpb.CredentialScope{
+		{DbName: "db1", CollectionName: "coll2", OperationClasses: []string{util.OperationClassDQL}},
+	})
+	assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+
+	err = c.AlterCredentialScopes(ctx, "user1", []*internalpb.CredentialScope{
+		{DbName: " db1 ", CollectionName: "coll1", OperationClasses: []string{util.OperationClassDML, util.OperationClassDQL}},
+		{DbName: "db1", CollectionName: util.AnyWord, OperationClasses: []string{util.OperationClassDQL}},
+	})
+	assert.NoError(t, err)
+	scopes, err := c.GetCredentialScopes(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Len(t, scopes, 2)
+	assert.Equal(t, "db1", scopes[0].GetDbName())
+
+	// the user is not restricted any more without scopes.
+	assert.NoError(t, c.AlterCredentialScopes(ctx, "user1", nil))
+	scopes, err = c.GetCredentialScopes(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Empty(t, scopes)
+}
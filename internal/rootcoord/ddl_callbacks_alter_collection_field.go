@@ -172,5 +172,7 @@ func (c *Core) broadcastAlterCollectionV2ForAlterCollectionField(ctx context.Con
 		rollbackAlterCollectionAnalyzerFileResourceReservation(ctx, c.meta, coll.CollectionID, addedFileResourceIds, err)
 		return err
 	}
+	c.recordPropertyChanges(ctx, coll.CollectionID, req.GetFieldName(),
+		common.CloneKeyValuePairs(oldFieldProperties).ToMap(), oldFieldPropertiesMap)
 	return nil
 }
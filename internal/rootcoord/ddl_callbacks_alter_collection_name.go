@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/distributed/streaming"
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/broadcaster/broadcast"
@@ -16,6 +17,14 @@ import (
 )
 
 func (c *Core) broadcastAlterCollectionForRenameCollection(ctx context.Context, req *milvuspb.RenameCollectionRequest) error {
+	return c.broadcastRenameCollection(ctx, req, nil)
+}
+
+// broadcastRenameCollection renames the collection, the properties of the collection are replaced in the same message
+// by the result of updateProperties if it's not nil.
+func (c *Core) broadcastRenameCollection(ctx context.Context, req *milvuspb.RenameCollectionRequest,
+	updateProperties func(props []*commonpb.KeyValuePair) []*commonpb.KeyValuePair,
+) error {
 	if req.DbName == "" {
 		req.DbName = util.DefaultDBName
 	}
@@ -75,6 +84,10 @@ func (c *Core) broadcastAlterCollectionForRenameCollection(ctx context.Context,
 		updates.CollectionName = req.GetNewName()
 		updateMask.Paths = append(updateMask.Paths, message.FieldMaskCollectionName)
 	}
+	if updateProperties != nil {
+		updates.Properties = updateProperties(coll.Properties)
+		updateMask.Paths = append(updateMask.Paths, message.FieldMaskCollectionProperties)
+	}
 
 	channels := make([]string, 0, len(coll.VirtualChannelNames)+1)
 	channels = append(channels, streaming.WAL().ControlChannel())
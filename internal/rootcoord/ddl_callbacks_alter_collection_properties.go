@@ -70,6 +70,10 @@ func (c *Core) broadcastAlterCollectionForAlterCollection(ctx context.Context, r
 		return merr.WrapErrParameterInvalidMsg("unknown or invalid IANA Time Zone ID: %s", tz)
 	}
 
+	if err := c.checkQuotaPropertyDelegation(ctx, req.GetDbName(), req.GetProperties(), req.GetDeleteKeys()); err != nil {
+		return err
+	}
+
 	isEnableDynamicSchema, targetValue, err := common.IsEnableDynamicSchema(req.GetProperties())
 	if err != nil {
 		rawValue, _ := funcutil.TryGetAttrByKeyFromRepeatedKV(common.EnableDynamicSchemaKey, req.GetProperties())
@@ -197,6 +201,11 @@ func (c *Core) broadcastAlterCollectionForAlterCollection(ctx context.Context, r
 		return errIgnoredAlterCollection
 	}
 
+	// the properties with cross-component effects are validated as a whole before anything is broadcasted.
+	if err := validateAlterCollectionProperties(oldProperties, newProperties); err != nil {
+		return err
+	}
+
 	// fill the put load config if rg or replica number is changed.
 	udpates.AlterLoadConfig = c.getAlterLoadConfigOfAlterCollection(coll.Properties, udpates.Properties)
 
@@ -213,6 +222,7 @@ func (c *Core) broadcastAlterCollectionForAlterCollection(ctx context.Context, r
 	if _, err := broadcaster.Broadcast(ctx, msg); err != nil {
 		return err
 	}
+	c.recordPropertyChanges(ctx, coll.CollectionID, "", oldProperties, newProperties)
 	return nil
 }
 
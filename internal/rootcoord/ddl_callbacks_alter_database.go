@@ -61,6 +61,10 @@ func (c *Core) broadcastAlterDatabase(ctx context.Context, req *rootcoordpb.Alte
 		return merr.WrapErrParameterInvalidMsg("unknown or invalid IANA Time Zone ID: %s", tz)
 	}
 
+	if err := c.checkQuotaPropertyDelegation(ctx, req.GetDbName(), req.GetProperties(), req.GetDeleteKeys()); err != nil {
+		return err
+	}
+
 	broadcaster, err := startBroadcastWithDatabaseLock(ctx, req.GetDbName())
 	if err != nil {
 		return err
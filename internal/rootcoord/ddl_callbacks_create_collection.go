@@ -56,6 +56,10 @@ func (c *Core) broadcastCreateCollectionV1(ctx context.Context, req *milvuspb.Cr
 		req.NumPartitions = int64(1)
 	}
 
+	if err := c.checkQuotaPropertyDelegation(ctx, req.GetDbName(), req.GetProperties(), nil); err != nil {
+		return err
+	}
+
 	broadcaster, err := c.startBroadcastWithCollectionLock(ctx, req.GetDbName(), req.GetCollectionName())
 	if err != nil {
 		return err
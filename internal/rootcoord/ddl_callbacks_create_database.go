@@ -36,6 +36,10 @@ import (
 
 func (c *Core) broadcastCreateDatabase(ctx context.Context, req *milvuspb.CreateDatabaseRequest) error {
 	req.DbName = strings.TrimSpace(req.DbName)
+	if err := c.checkQuotaPropertyDelegation(ctx, req.GetDbName(), req.GetProperties(), nil); err != nil {
+		return err
+	}
+
 	broadcaster, err := startBroadcastWithDatabaseLock(ctx, req.DbName)
 	if err != nil {
 		return err
@@ -24,6 +24,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
@@ -73,8 +74,20 @@ func TestDDLCallbacksDatabaseDDL(t *testing.T) {
 	require.Equal(t, db.Name, "test")
 	require.Len(t, db.Properties, 1)
 
+	// Drop a database with deletion protection
+	status, err = core.AlterDatabase(context.Background(), &rootcoordpb.AlterDatabaseRequest{
+		DbName:     "test",
+		Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseDeletionProtectionKey, Value: "true"}},
+	})
+	require.NoError(t, merr.CheckRPCCall(status, err))
+	status, err = core.DropDatabase(context.Background(), &milvuspb.DropDatabaseRequest{
+		DbName: "test",
+	})
+	require.ErrorIs(t, merr.CheckRPCCall(status, err), merr.ErrParameterInvalid)
+
 	// Drop a database
 	status, err = core.DropDatabase(context.Background(), &milvuspb.DropDatabaseRequest{
+		Base:   &commonpb.MsgBase{Properties: map[string]string{common.DeletionProtectionOverrideKey: "true"}},
 		DbName: "test",
 	})
 	require.NoError(t, merr.CheckRPCCall(status, err))
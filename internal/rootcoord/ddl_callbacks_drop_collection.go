@@ -129,6 +129,12 @@ func (c *DDLCallback) dropCollectionV1AckCallback(ctx context.Context, result me
 			if err := c.meta.DropCollection(ctx, collectionID, result.TimeTick); err != nil {
 				return merr.Wrap(err, "failed to drop collection")
 			}
+			if c.propertyHistory != nil {
+				if err := c.propertyHistory.Remove(ctx, collectionID); err != nil {
+					mlog.Warn(ctx, "failed to remove the property history of dropped collection",
+						mlog.Int64("collectionID", collectionID), mlog.Err(err))
+				}
+			}
 			continue
 		}
 		// Drop virtual channel data when the vchannel is acknowledged.
@@ -46,6 +46,9 @@ func (c *Core) broadcastDropDatabase(ctx context.Context, req *milvuspb.DropData
 	if err != nil {
 		return merr.Wrap(err, "failed to get database name")
 	}
+	if err := checkDeletionProtection(db, req.GetBase()); err != nil {
+		return err
+	}
 
 	// Call back cipher plugin when dropping database succeeded
 	if err := hookutil.RemoveEZByDBProperties(db.Properties); err != nil {
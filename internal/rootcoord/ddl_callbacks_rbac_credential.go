@@ -128,6 +128,11 @@ func (c *DDLCallback) dropUserV2AckCallback(ctx context.Context, result message.
 	if err := c.ExpireCredCache(ctx, result.Message.Header().UserName); err != nil {
 		return merr.Wrap(err, "failed to expire cred cache")
 	}
+	if c.credentialScopes != nil {
+		if err := c.credentialScopes.Remove(ctx, result.Message.Header().UserName); err != nil {
+			return merr.Wrap(err, "failed to remove credential scopes")
+		}
+	}
 	if err := c.proxyClientManager.RefreshPolicyInfoCache(ctx, &proxypb.RefreshPolicyInfoCacheRequest{
 		OpType: int32(typeutil.CacheDeleteUser),
 		OpKey:  result.Message.Header().UserName,
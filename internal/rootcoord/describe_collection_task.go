@@ -18,9 +18,11 @@ package rootcoord
 
 import (
 	"context"
+	"slices"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 )
 
@@ -67,6 +69,17 @@ func (t *describeCollectionTask) Execute(ctx context.Context) (err error) {
 	}
 	t.Rsp = convertModelToDesc(coll, aliases, db.Name)
 	t.Rsp.RequestTime = t.ts
+	if t.Req.GetBase().GetProperties()[common.CollectionPropertyHistoryKey] == "true" {
+		history, err := t.core.getPropertyHistoryProperty(ctx, coll.CollectionID)
+		if err != nil {
+			return err
+		}
+		// clone the properties to avoid appending to the ones of the cached collection.
+		t.Rsp.Properties = append(slices.Clone(t.Rsp.Properties), &commonpb.KeyValuePair{
+			Key:   common.CollectionPropertyHistoryKey,
+			Value: history,
+		})
+	}
 	return nil
 }
 
@@ -65,6 +65,14 @@ func (t *dropCollectionTask) validate(ctx context.Context) error {
 		return err
 	}
 
+	db, err := t.meta.GetDatabaseByID(ctx, collMeta.DBID, typeutil.MaxTimestamp)
+	if err != nil {
+		return err
+	}
+	if err := checkDeletionProtection(db, t.Req.GetBase()); err != nil {
+		return err
+	}
+
 	// fill the message body and header
 	// TODO: cleanupMetricsStep
 	t.header = &message.DropCollectionMessageHeader{
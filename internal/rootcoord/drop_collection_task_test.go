@@ -27,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/metastore/model"
 	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	pb "github.com/milvus-io/milvus/pkg/v3/proto/etcdpb"
 	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
@@ -110,6 +111,7 @@ func Test_dropCollectionTask_Prepare(t *testing.T) {
 			VirtualChannelNames: []string{"vchannel1"},
 		}, nil)
 		meta.EXPECT().ListAliasesByID(mock.Anything, mock.Anything).Return([]string{})
+		meta.EXPECT().GetDatabaseByID(mock.Anything, int64(1), mock.Anything).Return(&model.Database{ID: 1, Name: "db1"}, nil)
 
 		broker := &mockBroker{}
 		core := newTestCore(withMeta(meta), withBroker(broker))
@@ -128,4 +130,47 @@ func Test_dropCollectionTask_Prepare(t *testing.T) {
 		assert.Equal(t, "db1", task.body.DbName)
 		assert.Equal(t, []string{"vchannel1"}, task.vchannels)
 	})
+
+	t.Run("deletion protected", func(t *testing.T) {
+		collectionName := funcutil.GenRandomStr()
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().IsAlias(mock.Anything, mock.Anything, mock.Anything).Return(false)
+		meta.EXPECT().GetCollectionByName(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.Collection{
+			CollectionID:        1,
+			DBName:              "db1",
+			DBID:                1,
+			State:               pb.CollectionState_CollectionCreated,
+			VirtualChannelNames: []string{"vchannel1"},
+		}, nil)
+		meta.EXPECT().ListAliasesByID(mock.Anything, mock.Anything).Return([]string{})
+		meta.EXPECT().GetDatabaseByID(mock.Anything, int64(1), mock.Anything).Return(&model.Database{
+			ID:         1,
+			Name:       "db1",
+			Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseDeletionProtectionKey, Value: "true"}},
+		}, nil)
+		core := newTestCore(withMeta(meta))
+
+		task := &dropCollectionTask{
+			Core: core,
+			Req: &milvuspb.DropCollectionRequest{
+				Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_DropCollection},
+				CollectionName: collectionName,
+			},
+		}
+		err := task.Prepare(context.Background())
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+		task = &dropCollectionTask{
+			Core: core,
+			Req: &milvuspb.DropCollectionRequest{
+				Base: &commonpb.MsgBase{
+					MsgType:    commonpb.MsgType_DropCollection,
+					Properties: map[string]string{common.DeletionProtectionOverrideKey: "true"},
+				},
+				CollectionName: collectionName,
+			},
+		}
+		err = task.Prepare(context.Background())
+		assert.NoError(t, err)
+	})
 }
@@ -418,7 +418,7 @@ func (mt *MetaTable) CheckIfDatabaseCreatable(ctx context.Context, req *milvuspb
 		return merr.WrapErrParameterInvalidMsg("database already exist: %s", dbName)
 	}
 
-	cfgMaxDatabaseNum := Params.RootCoordCfg.MaxDatabaseNum.GetAsInt()
+	cfgMaxDatabaseNum := Params.QuotaConfig.MaxDatabaseNum.GetAsInt()
 	if len(mt.dbName2Meta) > cfgMaxDatabaseNum { // not include default database so use > instead of >= here.
 		return merr.WrapErrDatabaseNumLimitExceeded(cfgMaxDatabaseNum)
 	}
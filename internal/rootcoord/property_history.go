@@ -0,0 +1,161 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/json"
+	kvmetastore "github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
+	"github.com/milvus-io/milvus/pkg/v3/kv"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/contextutil"
+)
+
+// propertyHistoryPrefix is the meta prefix of the collection property change history.
+const propertyHistoryPrefix = kvmetastore.ComponentPrefix + "/property-history"
+
+// PropertyChange is the change of a single property, the old or new value is empty if the property is added or deleted.
+type PropertyChange struct {
+	Key      string `json:"key"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// PropertyChangeRecord is the property changes of a collection committed by one alter request.
+type PropertyChangeRecord struct {
+	CollectionID int64 `json:"collection_id"`
+	// FieldName is the name of the altered field, it's empty if the collection properties are altered.
+	FieldName string           `json:"field_name,omitempty"`
+	Requester string           `json:"requester"`
+	Time      time.Time        `json:"time"`
+	Changes   []PropertyChange `json:"changes"`
+}
+
+// diffProperties returns the changes from the old properties to the new ones sorted by key.
+func diffProperties(oldProps, newProps map[string]string) []PropertyChange {
+	changes := make([]PropertyChange, 0)
+	for key, oldValue := range oldProps {
+		if newValue, ok := newProps[key]; !ok || newValue != oldValue {
+			changes = append(changes, PropertyChange{Key: key, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	for key, newValue := range newProps {
+		if _, ok := oldProps[key]; !ok {
+			changes = append(changes, PropertyChange{Key: key, NewValue: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// propertyHistoryManager keeps the append-only property change history of the collections in the metastore.
+type propertyHistoryManager struct {
+	kv kv.TxnKV
+}
+
+func newPropertyHistoryManager(kv kv.TxnKV) *propertyHistoryManager {
+	return &propertyHistoryManager{kv: kv}
+}
+
+func propertyHistoryCollectionPrefix(collectionID int64) string {
+	return path.Join(propertyHistoryPrefix, strconv.FormatInt(collectionID, 10)) + "/"
+}
+
+func propertyHistoryKey(record *PropertyChangeRecord) string {
+	// the zero padded nanoseconds keep the keys of a collection in commit order.
+	return propertyHistoryCollectionPrefix(record.CollectionID) + fmt.Sprintf("%020d", record.Time.UnixNano())
+}
+
+// Append appends the record to the history of the collection.
+func (m *propertyHistoryManager) Append(ctx context.Context, record *PropertyChangeRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return m.kv.Save(ctx, propertyHistoryKey(record), string(value))
+}
+
+// List returns the history of the collection in commit order.
+func (m *propertyHistoryManager) List(ctx context.Context, collectionID int64) ([]*PropertyChangeRecord, error) {
+	_, values, err := m.kv.LoadWithPrefix(ctx, propertyHistoryCollectionPrefix(collectionID))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*PropertyChangeRecord, 0, len(values))
+	for _, value := range values {
+		record := &PropertyChangeRecord{}
+		if err := json.Unmarshal([]byte(value), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	return records, nil
+}
+
+// Remove removes the history of the dropped collection.
+func (m *propertyHistoryManager) Remove(ctx context.Context, collectionID int64) error {
+	return m.kv.RemoveWithPrefix(ctx, propertyHistoryCollectionPrefix(collectionID))
+}
+
+// recordPropertyChanges records the property changes committed by an alter request,
+// failing to record doesn't fail the request since the alter has been broadcasted.
+func (c *Core) recordPropertyChanges(ctx context.Context, collectionID int64, fieldName string, oldProps, newProps map[string]string) {
+	if c.propertyHistory == nil {
+		return
+	}
+	changes := diffProperties(oldProps, newProps)
+	if len(changes) == 0 {
+		return
+	}
+	requester, _ := contextutil.GetCurUserFromContext(ctx)
+	record := &PropertyChangeRecord{
+		CollectionID: collectionID,
+		FieldName:    fieldName,
+		Requester:    requester,
+		Time:         time.Now(),
+		Changes:      changes,
+	}
+	if err := c.propertyHistory.Append(ctx, record); err != nil {
+		mlog.Warn(ctx, "failed to record the property changes of collection",
+			mlog.FieldCollectionID(collectionID),
+			mlog.String("fieldName", fieldName),
+			mlog.Err(err))
+	}
+}
+
+// getPropertyHistoryProperty returns the property change history of the collection as a json property.
+func (c *Core) getPropertyHistoryProperty(ctx context.Context, collectionID int64) (string, error) {
+	records := make([]*PropertyChangeRecord, 0)
+	if c.propertyHistory != nil {
+		var err error
+		if records, err = c.propertyHistory.List(ctx, collectionID); err != nil {
+			return "", err
+		}
+	}
+	value, err := json.Marshal(records)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
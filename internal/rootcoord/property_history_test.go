@@ -0,0 +1,124 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/json"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+)
+
+func TestDiffProperties(t *testing.T) {
+	changes := diffProperties(map[string]string{
+		common.CollectionTTLConfigKey:      "10",
+		common.CollectionAutoCompactionKey: "true",
+		common.CollectionInsertRateMaxKey:  "100",
+	}, map[string]string{
+		common.CollectionTTLConfigKey:      "5",
+		common.CollectionAutoCompactionKey: "true",
+		common.MmapEnabledKey:              "true",
+	})
+	assert.Equal(t, []PropertyChange{
+		{Key: common.CollectionInsertRateMaxKey, OldValue: "100"},
+		{Key: common.CollectionTTLConfigKey, OldValue: "10", NewValue: "5"},
+		{Key: common.MmapEnabledKey, NewValue: "true"},
+	}, changes)
+
+	assert.Empty(t, diffProperties(map[string]string{"a": "1"}, map[string]string{"a": "1"}))
+}
+
+func TestPropertyHistoryManager(t *testing.T) {
+	ctx := context.Background()
+	core := newTestCore()
+	core.propertyHistory = newPropertyHistoryManager(memkv.NewMemoryKV())
+
+	core.recordPropertyChanges(GetContext(ctx, "alice:pwd"), 1, "",
+		map[string]string{common.CollectionTTLConfigKey: "10"}, map[string]string{common.CollectionTTLConfigKey: "5"})
+	core.recordPropertyChanges(ctx, 1, "vec", map[string]string{}, map[string]string{common.MmapEnabledKey: "true"})
+	// no changes are not recorded.
+	core.recordPropertyChanges(ctx, 1, "", map[string]string{"a": "1"}, map[string]string{"a": "1"})
+	core.recordPropertyChanges(ctx, 2, "", map[string]string{}, map[string]string{"a": "1"})
+
+	records, err := core.propertyHistory.List(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "alice", records[0].Requester)
+	assert.Equal(t, "", records[0].FieldName)
+	assert.Equal(t, []PropertyChange{{Key: common.CollectionTTLConfigKey, OldValue: "10", NewValue: "5"}}, records[0].Changes)
+	assert.Equal(t, "", records[1].Requester)
+	assert.Equal(t, "vec", records[1].FieldName)
+
+	assert.NoError(t, core.propertyHistory.Remove(ctx, 1))
+	records, err = core.propertyHistory.List(ctx, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+	records, err = core.propertyHistory.List(ctx, 2)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestDescribeCollectionWithPropertyHistory(t *testing.T) {
+	ctx := context.Background()
+	meta := mockrootcoord.NewIMetaTable(t)
+	meta.EXPECT().GetCollectionByID(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.Collection{
+		CollectionID: 1,
+		Name:         "coll",
+		DBID:         1,
+		Properties:   []*commonpb.KeyValuePair{{Key: common.CollectionTTLConfigKey, Value: "5"}},
+	}, nil)
+	meta.EXPECT().ListAliasesByID(mock.Anything, mock.Anything).Return(nil)
+	meta.EXPECT().GetDatabaseByID(mock.Anything, mock.Anything, mock.Anything).Return(&model.Database{ID: 1, Name: "db"}, nil)
+
+	core := newTestCore(withMeta(meta))
+	core.propertyHistory = newPropertyHistoryManager(memkv.NewMemoryKV())
+	core.recordPropertyChanges(ctx, 1, "",
+		map[string]string{common.CollectionTTLConfigKey: "10"}, map[string]string{common.CollectionTTLConfigKey: "5"})
+
+	describe := func(base *commonpb.MsgBase) *milvuspb.DescribeCollectionResponse {
+		task := &describeCollectionTask{
+			baseTask: newBaseTask(ctx, core),
+			Req:      &milvuspb.DescribeCollectionRequest{Base: base, CollectionID: 1},
+			Rsp:      &milvuspb.DescribeCollectionResponse{},
+		}
+		assert.NoError(t, task.Execute(ctx))
+		return task.Rsp
+	}
+
+	rsp := describe(&commonpb.MsgBase{MsgType: commonpb.MsgType_DescribeCollection})
+	assert.Len(t, rsp.GetProperties(), 1)
+
+	rsp = describe(&commonpb.MsgBase{
+		MsgType:    commonpb.MsgType_DescribeCollection,
+		Properties: map[string]string{common.CollectionPropertyHistoryKey: "true"},
+	})
+	assert.Len(t, rsp.GetProperties(), 2)
+	assert.Equal(t, common.CollectionPropertyHistoryKey, rsp.GetProperties()[1].GetKey())
+	records := make([]*PropertyChangeRecord, 0)
+	assert.NoError(t, json.Unmarshal([]byte(rsp.GetProperties()[1].GetValue()), &records))
+	assert.Len(t, records, 1)
+	assert.Equal(t, []PropertyChange{{Key: common.CollectionTTLConfigKey, OldValue: "10", NewValue: "5"}}, records[0].Changes)
+}
@@ -0,0 +1,217 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/json"
+	kvmetastore "github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
+	"github.com/milvus-io/milvus/internal/util/hookutil"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/kv"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// propertyPresetPrefix is the meta prefix of the collection property presets.
+const propertyPresetPrefix = kvmetastore.ComponentPrefix + "/property-presets"
+
+// PropertyPreset is a named bundle of collection properties, e.g. TTL, rate limit and compaction properties,
+// which can be referenced by CreateCollection and AlterCollection with the `collection.property.preset` property.
+type PropertyPreset struct {
+	Name       string            `json:"name"`
+	Properties map[string]string `json:"properties"`
+}
+
+// propertyPresetManager manages the property presets persisted in the metastore.
+type propertyPresetManager struct {
+	kv kv.TxnKV
+}
+
+func newPropertyPresetManager(kv kv.TxnKV) *propertyPresetManager {
+	return &propertyPresetManager{kv: kv}
+}
+
+func propertyPresetKey(name string) string {
+	return path.Join(propertyPresetPrefix, name)
+}
+
+func validatePropertyPreset(preset *PropertyPreset) error {
+	if preset == nil || preset.Name == "" {
+		return merr.WrapErrParameterInvalidMsg("property preset name should not be empty")
+	}
+	if strings.Contains(preset.Name, "/") {
+		return merr.WrapErrParameterInvalidMsg("property preset name %s should not contain '/'", preset.Name)
+	}
+	if len(preset.Properties) == 0 {
+		return merr.WrapErrParameterInvalidMsg("property preset %s has no properties", preset.Name)
+	}
+	if _, ok := preset.Properties[common.CollectionPropertyPresetKey]; ok {
+		return merr.WrapErrParameterInvalidMsg("property preset %s can not reference another preset", preset.Name)
+	}
+	props := make([]*commonpb.KeyValuePair, 0, len(preset.Properties))
+	for key, value := range preset.Properties {
+		props = append(props, &commonpb.KeyValuePair{Key: key, Value: value})
+	}
+	if err := validateReservedCollectionProperties(props, nil); err != nil {
+		return err
+	}
+	if hookutil.ContainsCipherProperties(props, nil) {
+		return merr.WrapErrParameterInvalidMsg("property preset %s can not contain cipher related properties", preset.Name)
+	}
+	return nil
+}
+
+// Save creates or overwrites the preset.
+func (m *propertyPresetManager) Save(ctx context.Context, preset *PropertyPreset) error {
+	if err := validatePropertyPreset(preset); err != nil {
+		return err
+	}
+	value, err := json.Marshal(preset)
+	if err != nil {
+		return err
+	}
+	if err := m.kv.Save(ctx, propertyPresetKey(preset.Name), string(value)); err != nil {
+		return err
+	}
+	mlog.Info(ctx, "property preset saved", mlog.String("name", preset.Name), mlog.Any("properties", preset.Properties))
+	return nil
+}
+
+// Get returns the preset with the given name.
+func (m *propertyPresetManager) Get(ctx context.Context, name string) (*PropertyPreset, error) {
+	value, err := m.kv.Load(ctx, propertyPresetKey(name))
+	if err != nil {
+		if errors.Is(err, merr.ErrIoKeyNotFound) {
+			return nil, merr.WrapErrParameterInvalidMsg("property preset %s not found", name)
+		}
+		return nil, err
+	}
+	preset := &PropertyPreset{}
+	if err := json.Unmarshal([]byte(value), preset); err != nil {
+		return nil, err
+	}
+	return preset, nil
+}
+
+// List returns all the presets sorted by name.
+func (m *propertyPresetManager) List(ctx context.Context) ([]*PropertyPreset, error) {
+	_, values, err := m.kv.LoadWithPrefix(ctx, propertyPresetPrefix+"/")
+	if err != nil {
+		return nil, err
+	}
+	presets := make([]*PropertyPreset, 0, len(values))
+	for _, value := range values {
+		preset := &PropertyPreset{}
+		if err := json.Unmarshal([]byte(value), preset); err != nil {
+			return nil, err
+		}
+		presets = append(presets, preset)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets, nil
+}
+
+// Drop removes the preset, the collections created with it keep their expanded properties.
+func (m *propertyPresetManager) Drop(ctx context.Context, name string) error {
+	if err := m.kv.Remove(ctx, propertyPresetKey(name)); err != nil {
+		return err
+	}
+	mlog.Info(ctx, "property preset dropped", mlog.String("name", name))
+	return nil
+}
+
+// Expand replaces the preset reference in the properties with the properties of the preset.
+// The properties set explicitly in the request take precedence over the ones of the preset.
+func (m *propertyPresetManager) Expand(ctx context.Context, props []*commonpb.KeyValuePair) ([]*commonpb.KeyValuePair, error) {
+	name, ok := "", false
+	explicitProps := make([]*commonpb.KeyValuePair, 0, len(props))
+	for _, prop := range props {
+		if prop.GetKey() == common.CollectionPropertyPresetKey {
+			name, ok = prop.GetValue(), true
+			continue
+		}
+		explicitProps = append(explicitProps, prop)
+	}
+	if !ok {
+		return props, nil
+	}
+	if name == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("property preset name should not be empty")
+	}
+	preset, err := m.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	presetProps := make([]*commonpb.KeyValuePair, 0, len(preset.Properties))
+	for key, value := range preset.Properties {
+		presetProps = append(presetProps, &commonpb.KeyValuePair{Key: key, Value: value})
+	}
+	return MergeProperties(presetProps, explicitProps), nil
+}
+
+// expandPropertyPreset expands the property preset referenced by the properties of the request.
+func (c *Core) expandPropertyPreset(ctx context.Context, props []*commonpb.KeyValuePair) ([]*commonpb.KeyValuePair, error) {
+	if c.propertyPresets == nil {
+		if _, ok := funcutil.TryGetAttrByKeyFromRepeatedKV(common.CollectionPropertyPresetKey, props); ok {
+			return nil, merr.WrapErrServiceNotReady(paramtable.GetRole(), paramtable.GetNodeID(), "property preset manager is not initialized")
+		}
+		return props, nil
+	}
+	return c.propertyPresets.Expand(ctx, props)
+}
+
+// SavePropertyPreset creates or overwrites a collection property preset.
+func (c *Core) SavePropertyPreset(ctx context.Context, preset *PropertyPreset) error {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return err
+	}
+	return c.propertyPresets.Save(ctx, preset)
+}
+
+// GetPropertyPreset returns the collection property preset with the given name.
+func (c *Core) GetPropertyPreset(ctx context.Context, name string) (*PropertyPreset, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return nil, err
+	}
+	return c.propertyPresets.Get(ctx, name)
+}
+
+// ListPropertyPresets returns all the collection property presets.
+func (c *Core) ListPropertyPresets(ctx context.Context) ([]*PropertyPreset, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return nil, err
+	}
+	return c.propertyPresets.List(ctx)
+}
+
+// DropPropertyPreset removes the collection property preset with the given name.
+func (c *Core) DropPropertyPreset(ctx context.Context, name string) error {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return err
+	}
+	return c.propertyPresets.Drop(ctx, name)
+}
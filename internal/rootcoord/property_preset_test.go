@@ -0,0 +1,81 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestPropertyPresetManager(t *testing.T) {
+	ctx := context.Background()
+	m := newPropertyPresetManager(memkv.NewMemoryKV())
+
+	// invalid presets.
+	assert.ErrorIs(t, m.Save(ctx, &PropertyPreset{}), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, m.Save(ctx, &PropertyPreset{Name: "a/b", Properties: map[string]string{common.CollectionTTLConfigKey: "10"}}), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, m.Save(ctx, &PropertyPreset{Name: "empty"}), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, m.Save(ctx, &PropertyPreset{Name: "nested", Properties: map[string]string{common.CollectionPropertyPresetKey: "other"}}), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, m.Save(ctx, &PropertyPreset{Name: "reserved", Properties: map[string]string{common.MaxFieldIDKey: "100"}}), merr.ErrParameterInvalid)
+
+	assert.NoError(t, m.Save(ctx, &PropertyPreset{Name: "timeseries", Properties: map[string]string{
+		common.CollectionTTLConfigKey:      "86400",
+		common.CollectionAutoCompactionKey: "true",
+	}}))
+	assert.NoError(t, m.Save(ctx, &PropertyPreset{Name: "high-ingest", Properties: map[string]string{
+		common.CollectionInsertRateMaxKey: "100",
+	}}))
+
+	presets, err := m.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, presets, 2)
+	assert.Equal(t, "high-ingest", presets[0].Name)
+	assert.Equal(t, "timeseries", presets[1].Name)
+
+	// no preset referenced.
+	props := []*commonpb.KeyValuePair{{Key: common.CollectionTTLConfigKey, Value: "10"}}
+	expanded, err := m.Expand(ctx, props)
+	assert.NoError(t, err)
+	assert.Equal(t, props, expanded)
+
+	// the explicit properties override the preset.
+	expanded, err = m.Expand(ctx, []*commonpb.KeyValuePair{
+		{Key: common.CollectionPropertyPresetKey, Value: "timeseries"},
+		{Key: common.CollectionTTLConfigKey, Value: "10"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		common.CollectionTTLConfigKey:      "10",
+		common.CollectionAutoCompactionKey: "true",
+	}, common.CloneKeyValuePairs(expanded).ToMap())
+
+	_, err = m.Expand(ctx, []*commonpb.KeyValuePair{{Key: common.CollectionPropertyPresetKey, Value: ""}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	assert.NoError(t, m.Drop(ctx, "timeseries"))
+	_, err = m.Get(ctx, "timeseries")
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	_, err = m.Expand(ctx, []*commonpb.KeyValuePair{{Key: common.CollectionPropertyPresetKey, Value: "timeseries"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
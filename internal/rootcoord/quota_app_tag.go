@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// parseAppTagRateLimits parses the app tag rate limits in json format, which maps the app tag to
+// the collection rate limit properties, an empty value means no app tag is limited.
+func parseAppTagRateLimits(value string) (map[string]map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var appTagLimits map[string]map[string]string
+	if err := json.Unmarshal([]byte(value), &appTagLimits); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid app tag rate limits %s: %s", value, err.Error())
+	}
+	return appTagLimits, nil
+}
+
+// toAppTagRequestLimiters returns the limiters of the app tags, which are set on the root node and applied to each collection by the proxies.
+// The budget of an app tag on a collection is shared by the proxies evenly, like the collection limits.
+func (q *QuotaCenter) toAppTagRequestLimiters() map[string]*proxypb.Limiter {
+	appTagLimits, err := parseAppTagRateLimits(Params.QuotaConfig.AppTagRateLimits.GetValue())
+	if err != nil {
+		mlog.RatedWarn(q.ctx, rate.Limit(1.0/60), "ignore the invalid app tag rate limits", mlog.Err(err))
+		return nil
+	}
+	proxyNum := q.proxies.GetProxyCount()
+	if len(appTagLimits) == 0 || proxyNum == 0 {
+		return nil
+	}
+
+	appTagLimiters := make(map[string]*proxypb.Limiter, len(appTagLimits))
+	for appTag, properties := range appTagLimits {
+		if appTag == "" {
+			continue
+		}
+		rates := make([]*internalpb.Rate, 0, len(properties))
+		for rt, configKey := range aliasRateLimitConfigKeys {
+			limit := Limit(getRateLimitConfig(properties, configKey, float64(Inf)))
+			if limit == Inf {
+				continue
+			}
+			rates = append(rates, &internalpb.Rate{Rt: rt, R: float64(limit) / float64(proxyNum)})
+		}
+		if len(rates) > 0 {
+			appTagLimiters[appTag] = &proxypb.Limiter{Rates: rates}
+		}
+	}
+	return appTagLimiters
+}
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/samber/lo"
+	"go.uber.org/atomic"
 	"golang.org/x/exp/maps"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
@@ -108,6 +109,7 @@ var dmlRateTypes = typeutil.NewSet(
 var dqlRateTypes = typeutil.NewSet(
 	internalpb.RateType_DQLSearch,
 	internalpb.RateType_DQLQuery,
+	internalpb.RateType_DQLGet,
 )
 
 type LimiterRange struct {
@@ -151,8 +153,14 @@ type QuotaCenter struct {
 	dataNodeMetrics  map[UniqueID]*metricsinfo.DataNodeQuotaMetrics
 	proxyMetrics     map[UniqueID]*metricsinfo.ProxyQuotaMetrics
 	dataCoordMetrics *metricsinfo.DataCoordQuotaMetrics
-	diskMu           sync.Mutex // guards dataCoordMetrics and totalBinlogSize
+	diskMu           sync.Mutex // guards dataCoordMetrics, totalBinlogSize and diskReclaim
 	totalBinlogSize  int64
+	diskReclaim      *diskReclaimTracker
+	// reclaimCompactions bounds the compactions triggered to reclaim disk in flight.
+	reclaimCompactions chan struct{}
+	// streamingNodeMetrics is the metrics of the query nodes embedded in the streaming nodes,
+	// they serve the growing data with the streaming service enabled.
+	streamingNodeMetrics map[UniqueID]*metricsinfo.QueryNodeQuotaMetrics
 
 	readableCollections map[int64]map[int64][]int64            // db id -> collection id -> partition id
 	writableCollections map[int64]map[int64][]int64            // db id -> collection id -> partition id
@@ -164,9 +172,20 @@ type QuotaCenter struct {
 	collectionIDToDBID *typeutil.ConcurrentMap[int64, int64] // collection id ->  db id
 
 	collectionProps map[int64]map[string]string // collection id -> collection properties
+	dbQuotaWindows  map[int64]string            // db id -> scheduled quota windows property
+	dbMaxTtDelays   map[int64]string            // db id -> max time tick delay property
 
 	rateLimiter *rlinternal.RateLimiterTree
 
+	// simulatedLimits is the limits calculated but not applied in the simulate mode, protected by lock.
+	simulatedLimits *metricsinfo.SimulatedQuotaLimits
+	// configHealth is the result of the last validation of the quota configs, protected by lock.
+	configHealth *metricsinfo.QuotaConfigHealth
+	// writeFactors is the write rate factors calculated by each protection in the last round, protected by lock.
+	writeFactors *metricsinfo.QuotaWriteFactors
+	// writeFactorRecorder collects the write rate factors of the round in progress.
+	writeFactorRecorder *writeFactorRecorder
+
 	tsoAllocator tso.Allocator
 
 	rateAllocateStrategy RateAllocateStrategy
@@ -176,11 +195,48 @@ type QuotaCenter struct {
 	// Key format: "collectionID-rateType"
 	prevRates map[string]float64
 
+	// writeDenyHolds keeps the collections denied to write by the protections, so that the deny states
+	// are not released until the hold duration and the release factor are met.
+	writeDenyHolds map[int64]*writeDenyHold
+
+	// writeDenyCooldowns keeps the deny writing cycles of the collections, the ones denied to write repeatedly
+	// are placed in the cooldown with lower write rates.
+	writeDenyCooldowns map[int64]*writeDenyCooldown
+
+	// nodeQuarantines keeps the nodes reporting the extreme metrics, the quarantined ones are excluded from the factors.
+	nodeQuarantines map[int64]*nodeQuarantine
+
+	// slowQueryStates keeps the collections whose dql limits are reduced by the slow query protection.
+	slowQueryStates map[int64]*slowQueryState
+
+	// dbReadUsages keeps the recent dql rates of the databases, the usage based database dql limits are computed from them.
+	dbReadUsages map[int64]map[internalpb.RateType]float64
+
+	// lastAccessTimes keeps the last time the loaded collections with idle release were searched or queried.
+	lastAccessTimes map[int64]time.Time
+
 	keyManager *KeyManager
 
+	replicateCheckpoints *replicateCheckpointCollector
+	replicatedTimeTicks  map[string]uint64 // source pchannel -> time tick replicated to the target clusters
+
+	usageReporter *storageUsageReporter
+
+	metricsHistory *quotaMetricsHistory
+
 	stopOnce sync.Once
 	stopChan chan struct{}
 	wg       sync.WaitGroup
+
+	// sendCtx is canceled to abort the in-flight rates push if it is not drained in time when stopping.
+	sendCtx    context.Context
+	sendCancel context.CancelFunc
+	started    atomic.Bool
+
+	// lastPushedRates is the rates request last pushed to the proxies, and lastPushedDigest is the digest of its limiter tree,
+	// the proxies reporting a different digest are found missing the update and the request is pushed again.
+	lastPushedRates  *proxypb.SetRatesRequest
+	lastPushedDigest string
 }
 
 // NewQuotaCenter returns a new QuotaCenter.
@@ -202,8 +258,19 @@ func NewQuotaCenter(proxies proxyutil.ProxyClientManagerInterface, mixCoord type
 		rateLimiter:          rlinternal.NewRateLimiterTree(initInfLimiter(internalpb.RateScope_Cluster, allOps)),
 		rateAllocateStrategy: DefaultRateAllocateStrategy,
 		prevRates:            make(map[string]float64),
+		writeDenyHolds:       make(map[int64]*writeDenyHold),
+		writeDenyCooldowns:   make(map[int64]*writeDenyCooldown),
+		slowQueryStates:      make(map[int64]*slowQueryState),
+		dbReadUsages:         make(map[int64]map[internalpb.RateType]float64),
+		nodeQuarantines:      make(map[int64]*nodeQuarantine),
+		lastAccessTimes:      make(map[int64]time.Time),
+		diskReclaim:          newDiskReclaimTracker(),
+		reclaimCompactions:   make(chan struct{}, maxInFlightReclaimCompactions),
+		metricsHistory:       newQuotaMetricsHistory(),
 		stopChan:             make(chan struct{}),
 	}
+	q.sendCtx, q.sendCancel = context.WithCancel(context.Background())
+	q.usageReporter = newStorageUsageReporter(q)
 	q.clearMetrics()
 	return q
 }
@@ -298,17 +365,27 @@ func getRateTypes(scope internalpb.RateScope, opType opType) typeutil.Set[intern
 }
 
 func (q *QuotaCenter) Start() {
+	q.started.Store(true)
 	q.wg.Add(1)
 	go func() {
 		defer q.wg.Done()
 		q.run()
 	}()
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		q.usageReporter.run()
+	}()
 }
 
 func (q *QuotaCenter) SetKeyManager(km *KeyManager) {
 	q.keyManager = km
 }
 
+func (q *QuotaCenter) SetReplicateCheckpointCollector(c *replicateCheckpointCollector) {
+	q.replicateCheckpoints = c
+}
+
 func (q *QuotaCenter) watchQuotaAndLimit() {
 	pt := paramtable.Get()
 	metrics.QueryNodeMemoryHighWaterLevel.Set(pt.QuotaConfig.QueryNodeMemoryHighWaterLevel.GetAsFloat())
@@ -334,6 +411,7 @@ func (q *QuotaCenter) watchQuotaAndLimit() {
 	pt.Watch(pt.QuotaConfig.DiskQuotaPerPartition.Key, config.NewHandler(pt.QuotaConfig.DiskQuotaPerPartition.Key, func(event *config.Event) {
 		metrics.DiskQuota.WithLabelValues(paramtable.GetStringNodeID(), "partition").Set(pt.QuotaConfig.DiskQuotaPerPartition.GetAsFloat())
 	}))
+	q.watchQuotaConfigHealth()
 }
 
 // run starts the service of QuotaCenter.
@@ -354,16 +432,30 @@ func (q *QuotaCenter) run() {
 				mlog.Warn(q.ctx, "quotaCenter collect metrics failed", mlog.Err(err))
 				break
 			}
+			q.checkProxyLimiterConsistency()
+			q.releaseIdleCollections(time.Now())
 			err = q.calculateRates()
 			if err != nil {
 				mlog.Warn(q.ctx, "quotaCenter calculate rates failed", mlog.Err(err))
 				break
 			}
+			if Params.QuotaConfig.SimulateMode.GetAsBool() {
+				q.recordSimulatedLimits(time.Now())
+				// the calculated limits and deny decisions are not applied in the simulate mode,
+				// only the limits from the quota configs are sent, which also lifts the limits applied before.
+				if err = q.resetAllCurrentRates(); err != nil {
+					mlog.Warn(q.ctx, "quotaCenter reset rates in simulate mode failed", mlog.Err(err))
+					break
+				}
+			} else {
+				q.clearSimulatedLimits()
+			}
 			err = q.sendRatesToProxy()
 			if err != nil {
 				mlog.Warn(q.ctx, "quotaCenter send rates to proxy failed", mlog.Err(err))
 			}
 			q.recordMetrics()
+			q.sampleMetricsHistory(time.Now())
 		}
 	}
 }
@@ -375,19 +467,62 @@ func (q *QuotaCenter) stop() {
 		// cancel all blocking request to coord
 		q.cancel()
 		close(q.stopChan)
+		q.drain()
 	})
 	q.wg.Wait()
+	if q.replicateCheckpoints != nil {
+		q.replicateCheckpoints.close(context.Background())
+	}
+}
+
+// drain waits for the in-flight rates push with a bounded timeout and cancels it if not finished in time,
+// then pushes the limits from the quota configs as the final snapshot, so that a push interrupted
+// by stopping never leaves proxies with the inconsistent limits.
+func (q *QuotaCenter) drain() {
+	defer q.sendCancel()
+	timeout := Params.QuotaConfig.StopDrainTimeout.GetAsDuration(time.Second)
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		mlog.Warn(q.ctx, "in-flight rates push is not drained in time, cancel it", mlog.Duration("timeout", timeout))
+		q.sendCancel()
+		<-done
+	}
+
+	if !q.started.Load() {
+		return
+	}
+	if err := q.resetAllCurrentRates(); err != nil {
+		mlog.Warn(q.ctx, "failed to reset rates for the final snapshot", mlog.Err(err))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := q.proxies.SetRates(ctx, q.toRatesRequest()); err != nil {
+		mlog.Warn(q.ctx, "failed to push the final rates snapshot to proxies", mlog.Err(err))
+		return
+	}
+	mlog.Info(q.ctx, "quota center pushed the final rates snapshot to proxies")
 }
 
 // clearMetrics removes all metrics stored in QuotaCenter.
 func (q *QuotaCenter) clearMetrics() {
 	q.dataNodeMetrics = make(map[UniqueID]*metricsinfo.DataNodeQuotaMetrics, 0)
 	q.queryNodeMetrics = make(map[UniqueID]*metricsinfo.QueryNodeQuotaMetrics, 0)
+	q.streamingNodeMetrics = make(map[UniqueID]*metricsinfo.QueryNodeQuotaMetrics, 0)
 	q.proxyMetrics = make(map[UniqueID]*metricsinfo.ProxyQuotaMetrics, 0)
 	q.collectionIDToDBID = typeutil.NewConcurrentMap[int64, int64]()
 	q.collections = typeutil.NewConcurrentMap[string, int64]()
 	q.dbs = typeutil.NewConcurrentMap[string, int64]()
 	q.collectionProps = make(map[int64]map[string]string)
+	q.dbQuotaWindows = make(map[int64]string)
+	q.dbMaxTtDelays = make(map[int64]string)
+	q.replicatedTimeTicks = make(map[string]uint64)
 }
 
 func updateNumEntitiesLoaded(current map[int64]int64, qn *metricsinfo.QueryNodeCollectionMetrics) map[int64]int64 {
@@ -417,6 +552,7 @@ func (q *QuotaCenter) collectMetrics() error {
 
 	oldDataNodes := typeutil.NewSet(lo.Keys(q.dataNodeMetrics)...)
 	oldQueryNodes := typeutil.NewSet(lo.Keys(q.queryNodeMetrics)...)
+	oldStreamingNodes := typeutil.NewSet(lo.Keys(q.streamingNodeMetrics)...)
 	q.clearMetrics()
 
 	ctx, cancel := context.WithTimeout(q.ctx, GetMetricsTimeout)
@@ -435,8 +571,14 @@ func (q *QuotaCenter) collectMetrics() error {
 		numEntitiesLoaded := make(map[int64]int64)
 		for _, queryNodeMetric := range queryCoordTopology.Cluster.ConnectedNodes {
 			if queryNodeMetric.QuotaMetrics != nil {
-				oldQueryNodes.Remove(queryNodeMetric.ID)
-				q.queryNodeMetrics[queryNodeMetric.ID] = queryNodeMetric.QuotaMetrics
+				// the query node embedded in the streaming node is relabeled by querycoord.
+				if queryNodeMetric.Type == typeutil.StreamingNodeRole {
+					oldStreamingNodes.Remove(queryNodeMetric.ID)
+					q.streamingNodeMetrics[queryNodeMetric.ID] = queryNodeMetric.QuotaMetrics
+				} else {
+					oldQueryNodes.Remove(queryNodeMetric.ID)
+					q.queryNodeMetrics[queryNodeMetric.ID] = queryNodeMetric.QuotaMetrics
+				}
 				collections.Insert(queryNodeMetric.QuotaMetrics.Effect.CollectionIDs...)
 			}
 			if queryNodeMetric.CollectionMetrics != nil {
@@ -575,10 +717,29 @@ func (q *QuotaCenter) collectMetrics() error {
 		}
 		for _, db := range dbs {
 			q.dbs.Insert(db.Name, db.ID)
+			if v := db.GetProperty(common.DatabaseScheduledQuotaWindowsKey); v != "" {
+				q.dbQuotaWindows[db.ID] = v
+			}
+			if v := db.GetProperty(common.DatabaseMaxTimeTickDelayKey); v != "" {
+				q.dbMaxTtDelays[db.ID] = v
+			}
 		}
 		return nil
 	})
 
+	if q.replicateCheckpoints != nil && Params.QuotaConfig.ReplicationLagProtectionEnabled.GetAsBool() {
+		group.Go(func() error {
+			timeTicks, err := q.replicateCheckpoints.collect(ctx)
+			if err != nil {
+				// the replication lag protection is skipped if the target clusters are unreachable
+				mlog.RatedWarn(q.ctx, rate.Limit(10), "failed to collect replicate checkpoints", mlog.Err(err))
+				return nil
+			}
+			q.replicatedTimeTicks = timeTicks
+			return nil
+		})
+	}
+
 	err := group.Wait()
 	if err != nil {
 		return err
@@ -591,9 +752,22 @@ func (q *QuotaCenter) collectMetrics() error {
 		metrics.RootCoordTtDelay.DeleteLabelValues(typeutil.QueryNodeRole, strconv.FormatInt(oldQN, 10))
 		metrics.RootCoordTtDelay.DeleteLabelValues(typeutil.StreamingNodeRole, strconv.FormatInt(oldQN, 10))
 	}
+	for oldSN := range oldStreamingNodes {
+		metrics.RootCoordTtDelay.DeleteLabelValues(typeutil.StreamingNodeRole, strconv.FormatInt(oldSN, 10))
+	}
 	return nil
 }
 
+// rangeQueryNodeMetrics ranges the metrics of the query nodes and the ones embedded in the streaming nodes.
+func (q *QuotaCenter) rangeQueryNodeMetrics(f func(role string, nodeID int64, metric *metricsinfo.QueryNodeQuotaMetrics)) {
+	for nodeID, metric := range q.queryNodeMetrics {
+		f(typeutil.QueryNodeRole, nodeID, metric)
+	}
+	for nodeID, metric := range q.streamingNodeMetrics {
+		f(typeutil.StreamingNodeRole, nodeID, metric)
+	}
+}
+
 func getDbPropertyWithAction(db *model.Database, property string, actionFunc func(bool)) {
 	if db == nil || property == "" || actionFunc == nil {
 		return
@@ -645,6 +819,50 @@ func (q *QuotaCenter) calculateDBDDLRates() {
 	}
 }
 
+// calculateDDLLimitStates reflects the database and collection number limits, which are enforced at DDL time,
+// as the DenyToDDL quota states. The rates are kept as is, so the collections could still be dropped.
+func (q *QuotaCenter) calculateDDLLimitStates() {
+	dbs, err := q.meta.ListDatabases(q.ctx, typeutil.MaxTimestamp)
+	if err != nil {
+		mlog.Warn(q.ctx, "get databases failed", mlog.Err(err))
+		return
+	}
+	db2Collections := q.meta.ListAllAvailCollections(q.ctx)
+
+	insertDenyState := func(limiters *rlinternal.RateLimiterNode, reason string) {
+		limiters.GetQuotaStates().GetOrInsert(milvuspb.QuotaState_DenyToDDL, &rlinternal.QuotaStateInfo{
+			ErrorCode: commonpb.ErrorCode_ForceDeny,
+			Reason:    reason,
+		})
+	}
+
+	totalCollectionNum := 0
+	for _, db := range dbs {
+		collectionNum := len(db2Collections[db.ID])
+		totalCollectionNum += collectionNum
+		maxCollectionNum, err := getMaxCollectionNumPerDB(db)
+		if err != nil {
+			mlog.Warn(q.ctx, "invalid max collection number of database", mlog.String("dbName", db.Name), mlog.Err(err))
+			continue
+		}
+		if collectionNum >= maxCollectionNum {
+			dbLimiters := q.rateLimiter.GetOrCreateDatabaseLimiters(db.ID,
+				newParamLimiterFunc(internalpb.RateScope_Database, allOps))
+			insertDenyState(dbLimiters, fmt.Sprintf("collection number reached the limit %d of database", maxCollectionNum))
+		}
+	}
+
+	// the default database is not counted, see MetaTable.CheckIfDatabaseCreatable
+	maxDatabaseNum := Params.QuotaConfig.MaxDatabaseNum.GetAsInt()
+	if len(dbs) > maxDatabaseNum {
+		insertDenyState(q.rateLimiter.GetRootLimiters(), fmt.Sprintf("database number reached the limit %d", maxDatabaseNum))
+	}
+	maxCollectionNum := Params.QuotaConfig.MaxCollectionNum.GetAsInt()
+	if totalCollectionNum >= maxCollectionNum {
+		insertDenyState(q.rateLimiter.GetRootLimiters(), fmt.Sprintf("collection number reached the limit %d", maxCollectionNum))
+	}
+}
+
 // forceDenyWriting sets dml rates to 0 to reject all dml requests.
 func (q *QuotaCenter) forceDenyWriting(errorCode commonpb.ErrorCode, cluster bool, dbIDs, collectionIDs []int64, col2partitionIDs map[int64][]int64, denyReason string) error {
 	var excludeRange typeutil.Set[internalpb.RateType]
@@ -815,6 +1033,38 @@ func (q *QuotaCenter) guaranteeMinRate(minRate float64, rt internalpb.RateType,
 	}
 }
 
+// guaranteeDatabaseReadRates preserves the guaranteed dql rates of the databases after the dql limits are cooled off,
+// the guaranteed rate is shared evenly by the readable collections of the database.
+// It may exceed the configured max rate of the database, the databases denied to read by force are skipped.
+func (q *QuotaCenter) guaranteeDatabaseReadRates(deniedDatabaseIDs map[int64]struct{}) {
+	guaranteedRates := map[internalpb.RateType]float64{
+		internalpb.RateType_DQLSearch: Params.QuotaConfig.DQLGuaranteedSearchRatePerDB.GetAsFloat(),
+		internalpb.RateType_DQLQuery:  Params.QuotaConfig.DQLGuaranteedQueryRatePerDB.GetAsFloat(),
+		internalpb.RateType_DQLGet:    Params.QuotaConfig.DQLGuaranteedGetRatePerDB.GetAsFloat(),
+	}
+	for dbID, collections := range q.readableCollections {
+		if _, ok := deniedDatabaseIDs[dbID]; ok || len(collections) == 0 {
+			continue
+		}
+		dbLimiter := q.rateLimiter.GetDatabaseLimiters(dbID)
+		if dbLimiter == nil {
+			continue
+		}
+		for rt, guaranteedRate := range guaranteedRates {
+			if guaranteedRate <= 0 {
+				continue
+			}
+			q.guaranteeMinRate(guaranteedRate, rt, dbLimiter)
+			collectionRate := guaranteedRate / float64(len(collections))
+			for collectionID := range collections {
+				if collectionLimiter := q.rateLimiter.GetCollectionLimiters(dbID, collectionID); collectionLimiter != nil {
+					q.guaranteeMinRate(collectionRate, rt, collectionLimiter)
+				}
+			}
+		}
+	}
+}
+
 func (q *QuotaCenter) getDenyReadingDBs() map[int64]struct{} {
 	dbIDs := make(map[int64]struct{})
 	for _, dbID := range lo.Uniq(q.collectionIDToDBID.Values()) {
@@ -846,6 +1096,10 @@ func (q *QuotaCenter) calculateReadRates() error {
 	if len(deniedDatabaseIDs) != 0 {
 		q.forceDenyReading(commonpb.ErrorCode_ForceDeny, false, maps.Keys(deniedDatabaseIDs), "force deny reading in database properties")
 	}
+
+	q.calculateSlowQueryRates()
+	q.calculateDatabaseUsageReadRates(deniedDatabaseIDs)
+	q.guaranteeDatabaseReadRates(deniedDatabaseIDs)
 	return nil
 }
 
@@ -871,6 +1125,9 @@ func (q *QuotaCenter) getDenyWritingDBs() map[int64]struct{} {
 
 // calculateWriteRates calculates and sets dml rates.
 func (q *QuotaCenter) calculateWriteRates() error {
+	q.startRecordWriteFactors()
+	defer func() { q.finishRecordWriteFactors(time.Now()) }()
+
 	// check force deny writing of cluster level
 	if Params.QuotaConfig.ForceDenyWriting.GetAsBool() {
 		return q.forceDenyWriting(commonpb.ErrorCode_ForceDeny, true, nil, nil, nil, "config force deny writing")
@@ -903,18 +1160,46 @@ func (q *QuotaCenter) calculateWriteRates() error {
 		}
 	}
 
+	q.updateNodeQuarantines(ts)
 	ttFactors := q.getTimeTickDelayFactor(ts)
 	updateCollectionFactor(ttFactors)
+	q.writeFactorRecorder.recordCollections(writeFactorTimeTickDelay, ttFactors)
 	memFactors := q.getMemoryFactor()
 	updateCollectionFactor(memFactors)
+	q.writeFactorRecorder.recordCollections(writeFactorMemory, memFactors)
 	growingSegFactors := q.getGrowingSegmentsSizeFactor()
 	updateCollectionFactor(growingSegFactors)
+	q.writeFactorRecorder.recordCollections(writeFactorGrowingSegmentsSize, growingSegFactors)
 	l0Factors := q.getL0SegmentsSizeFactor()
 	updateCollectionFactor(l0Factors)
+	q.writeFactorRecorder.recordCollections(writeFactorL0SegmentsRowCount, l0Factors)
 	deleteBufferRowCountFactors := q.getDeleteBufferRowCountFactor()
 	updateCollectionFactor(deleteBufferRowCountFactors)
+	q.writeFactorRecorder.recordCollections(writeFactorDeleteBufferRowCount, deleteBufferRowCountFactors)
 	deleteBufferSizeFactors := q.getDeleteBufferSizeFactor()
 	updateCollectionFactor(deleteBufferSizeFactors)
+	q.writeFactorRecorder.recordCollections(writeFactorDeleteBufferSize, deleteBufferSizeFactors)
+	replicationLagFactors := q.getReplicationLagFactor(ts)
+	updateCollectionFactor(replicationLagFactors)
+	q.writeFactorRecorder.recordCollections(writeFactorReplicationLag, replicationLagFactors)
+
+	now := time.Now()
+	denyCodes := q.suppressWriteDenyFlapping(now, collectionFactors, ttFactors)
+	for collection, errorCode := range q.getQuarantinedCollections() {
+		q.writeFactorRecorder.recordCollection(collection, writeFactorNodeQuarantine, 0)
+		collectionFactors[collection] = 0
+		denyCodes[collection] = errorCode
+	}
+	q.applyWriteDenyCooldowns(now, collectionFactors, denyCodes)
+
+	// the factors only limiting the insert rates, which the upsert requests are limited by as well.
+	insertFactors := q.getSmallSegmentInsertFactor()
+	q.writeFactorRecorder.recordCollections(writeFactorSmallSegmentNum, insertFactors)
+	for collection := range insertFactors {
+		if _, ok := collectionFactors[collection]; !ok {
+			collectionFactors[collection] = 1
+		}
+	}
 
 	ttCollections := make([]int64, 0)
 	memoryCollections := make([]int64, 0)
@@ -924,9 +1209,8 @@ func (q *QuotaCenter) calculateWriteRates() error {
 
 	for collection, factor := range collectionFactors {
 		metrics.RootCoordRateLimitRatio.WithLabelValues(strconv.FormatInt(collection, 10)).Set(1 - factor)
-		if factor <= 0 {
-			if _, ok := ttFactors[collection]; ok && factor == ttFactors[collection] {
-				// factor comes from ttFactor
+		if errorCode, ok := denyCodes[collection]; ok {
+			if errorCode == commonpb.ErrorCode_TimeTickLongDelay {
 				ttCollections = append(ttCollections, collection)
 			} else {
 				memoryCollections = append(memoryCollections, collection)
@@ -956,7 +1240,11 @@ func (q *QuotaCenter) calculateWriteRates() error {
 			// Check if rate change is significant enough to trigger an update
 			// Calculate newRate = baseLimit * factor for each rate type and compare with previous rate
 			// If the rate change is less than FactorChangeThreshold, skip SetLimit to reduce proxy updates
-			newRate := float64(v.Limit() * Limit(factor))
+			rtFactor := factor
+			if insertFactor, ok := insertFactors[collection]; ok && rt == internalpb.RateType_DMLInsert && insertFactor < rtFactor {
+				rtFactor = insertFactor
+			}
+			newRate := float64(v.Limit() * Limit(rtFactor))
 			rateKey := strconv.FormatInt(collection, 10) + "-" + strconv.FormatInt(int64(rt), 10)
 			prevRate, ok := q.prevRates[rateKey]
 			if ok && prevRate > 0 {
@@ -993,6 +1281,12 @@ func (q *QuotaCenter) calculateWriteRates() error {
 			return err
 		}
 	}
+	if frozenCollections := q.getFrozenCollections(); len(frozenCollections) > 0 {
+		if err = q.forceDenyWriting(commonpb.ErrorCode_ForceDeny, false, nil, frozenCollections, nil, "force deny writing for frozen collection"); err != nil {
+			mlog.Warn(q.ctx, "fail to force deny writing for frozen collection", mlog.Err(err))
+			return err
+		}
+	}
 
 	return nil
 }
@@ -1002,10 +1296,12 @@ func (q *QuotaCenter) getTimeTickDelayFactor(ts Timestamp) map[int64]float64 {
 		return make(map[int64]float64)
 	}
 
-	maxDelay := Params.QuotaConfig.MaxTimeTickDelay.GetAsDuration(time.Second)
-	if maxDelay < 0 {
-		// < 0 means disable tt protection
-		return make(map[int64]float64)
+	clusterMaxDelay := Params.QuotaConfig.MaxTimeTickDelay.GetAsDuration(time.Second)
+	getMaxDelay := func(collectionID int64) time.Duration {
+		if dbID, ok := q.collectionIDToDBID.Get(collectionID); ok {
+			return q.getDatabaseMaxTimeTickDelay(dbID, clusterMaxDelay)
+		}
+		return clusterMaxDelay
 	}
 
 	collectionsMaxDelay := make(map[int64]time.Duration)
@@ -1019,43 +1315,89 @@ func (q *QuotaCenter) getTimeTickDelayFactor(ts Timestamp) map[int64]float64 {
 	}
 
 	t1, _ := tsoutil.ParseTS(ts)
+	// getWALDelay returns the max delay of the wals on the streaming node,
+	// the collections on the pchannels of the wals are updated by the delay of each wal.
+	getWALDelay := func(streamingQuota *metricsinfo.StreamingQuotaMetrics) time.Duration {
+		var maxDelay time.Duration
+		if streamingQuota == nil {
+			return maxDelay
+		}
+		for _, wal := range streamingQuota.WALs {
+			t2, _ := tsoutil.ParseTS(wal.RecoveryTimeTick)
+			delay := t1.Sub(t2)
+			if maxDelay < delay {
+				maxDelay = delay
+			}
+			// Update all collections work on this pchannel.
+			pchannelInfo := channel.StaticPChannelStatsManager.MustGet().GetPChannelStats(wal.Channel)
+			updateCollectionDelay(delay, pchannelInfo.CollectionIDs())
+		}
+		return maxDelay
+	}
+	excludedNodes := q.getFactorExcludedNodes()
 	for nodeID, metric := range q.queryNodeMetrics {
+		if excludedNodes.Contain(nodeID) {
+			continue
+		}
 		if metric.Fgm.NumFlowGraph > 0 && metric.Fgm.MinFlowGraphChannel != "" {
 			t2, _ := tsoutil.ParseTS(metric.Fgm.MinFlowGraphTt)
 			delay := t1.Sub(t2)
 			updateCollectionDelay(delay, metric.Effect.CollectionIDs)
 			metrics.RootCoordTtDelay.WithLabelValues(typeutil.QueryNodeRole, strconv.FormatInt(nodeID, 10)).Set(float64(delay.Milliseconds()))
 		}
-		if metric.StreamingQuota != nil {
-			// If the query node is embedded in streaming node,
-			// we also need to use the wal's metrics to calculate the delay.
-			var maxDelay time.Duration
-			for _, wal := range metric.StreamingQuota.WALs {
-				t2, _ := tsoutil.ParseTS(wal.RecoveryTimeTick)
-				delay := t1.Sub(t2)
-				if maxDelay < delay {
-					maxDelay = delay
-				}
-				// Update all collections work on this pchannel.
-				pchannelInfo := channel.StaticPChannelStatsManager.MustGet().GetPChannelStats(wal.Channel)
-				updateCollectionDelay(delay, pchannelInfo.CollectionIDs())
-			}
-			if maxDelay > 0 {
-				metrics.RootCoordTtDelay.WithLabelValues(typeutil.StreamingNodeRole, strconv.FormatInt(nodeID, 10)).Set(float64(maxDelay.Milliseconds()))
-			}
+		// If the query node is embedded in streaming node,
+		// we also need to use the wal's metrics to calculate the delay.
+		if maxDelay := getWALDelay(metric.StreamingQuota); maxDelay > 0 {
+			metrics.RootCoordTtDelay.WithLabelValues(typeutil.StreamingNodeRole, strconv.FormatInt(nodeID, 10)).Set(float64(maxDelay.Milliseconds()))
+		}
+	}
+	for nodeID, metric := range q.streamingNodeMetrics {
+		if excludedNodes.Contain(nodeID) {
+			continue
+		}
+		// The streaming node is delayed by both the consuming of the growing data and the recovery of the wals.
+		maxDelay := getWALDelay(metric.StreamingQuota)
+		if metric.Fgm.NumFlowGraph > 0 && metric.Fgm.MinFlowGraphChannel != "" {
+			t2, _ := tsoutil.ParseTS(metric.Fgm.MinFlowGraphTt)
+			delay := t1.Sub(t2)
+			updateCollectionDelay(delay, metric.Effect.CollectionIDs)
+			maxDelay = max(maxDelay, delay)
+		}
+		if maxDelay > 0 {
+			metrics.RootCoordTtDelay.WithLabelValues(typeutil.StreamingNodeRole, strconv.FormatInt(nodeID, 10)).Set(float64(maxDelay.Milliseconds()))
 		}
 	}
 	for nodeID, metric := range q.dataNodeMetrics {
+		if excludedNodes.Contain(nodeID) {
+			continue
+		}
 		if metric.Fgm.NumFlowGraph > 0 && metric.Fgm.MinFlowGraphChannel != "" {
 			t2, _ := tsoutil.ParseTS(metric.Fgm.MinFlowGraphTt)
 			delay := t1.Sub(t2)
 			updateCollectionDelay(delay, metric.Effect.CollectionIDs)
 			metrics.RootCoordTtDelay.WithLabelValues(typeutil.DataNodeRole, strconv.FormatInt(nodeID, 10)).Set(float64(delay.Milliseconds()))
 		}
+		if len(metric.WALBacklogs) > 0 {
+			// only the collections producing the backlog are limited,
+			// instead of all the collections on the node.
+			var maxDelay time.Duration
+			for collectionID, backlog := range metric.WALBacklogs {
+				t2, _ := tsoutil.ParseTS(backlog.ConsumedTt)
+				delay := t1.Sub(t2)
+				maxDelay = max(maxDelay, delay)
+				updateCollectionDelay(delay, []int64{collectionID})
+			}
+			metrics.RootCoordTtDelay.WithLabelValues(typeutil.DataNodeRole, strconv.FormatInt(nodeID, 10)).Set(float64(maxDelay.Milliseconds()))
+		}
 	}
 
 	collectionFactor := make(map[int64]float64)
 	for collectionID, curMaxDelay := range collectionsMaxDelay {
+		maxDelay := getMaxDelay(collectionID)
+		if maxDelay < 0 {
+			// < 0 means disable tt protection
+			continue
+		}
 		if curMaxDelay.Nanoseconds() >= maxDelay.Nanoseconds() {
 			mlog.RatedWarn(q.ctx, rate.Limit(10), "QuotaCenter force deny writing due to long timeTick delay",
 				mlog.FieldCollectionID(collectionID),
@@ -1064,6 +1406,7 @@ func (q *QuotaCenter) getTimeTickDelayFactor(ts Timestamp) map[int64]float64 {
 				mlog.Duration("MaxDelay", maxDelay))
 			mlog.RatedInfo(q.ctx, rate.Limit(10), "DataNode and QueryNode Metrics",
 				mlog.Any("QueryNodeMetrics", q.queryNodeMetrics),
+				mlog.Any("StreamingNodeMetrics", q.streamingNodeMetrics),
 				mlog.Any("DataNodeMetrics", q.dataNodeMetrics))
 			collectionFactor[collectionID] = 0
 			continue
@@ -1085,6 +1428,23 @@ func (q *QuotaCenter) getTimeTickDelayFactor(ts Timestamp) map[int64]float64 {
 	return collectionFactor
 }
 
+// getDatabaseMaxTimeTickDelay returns the max time tick delay of the database,
+// the database property overrides the cluster config.
+func (q *QuotaCenter) getDatabaseMaxTimeTickDelay(dbID int64, defaultDelay time.Duration) time.Duration {
+	v, ok := q.dbMaxTtDelays[dbID]
+	if !ok {
+		return defaultDelay
+	}
+	seconds, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		mlog.RatedWarn(q.ctx, rate.Limit(10), "invalid max time tick delay of database",
+			mlog.Int64("dbID", dbID),
+			mlog.String("value", v))
+		return defaultDelay
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
 // getMemoryFactor checks whether any node has memory resource issue,
 // and return the factor according to max memory water level.
 func (q *QuotaCenter) getMemoryFactor() map[int64]float64 {
@@ -1096,6 +1456,8 @@ func (q *QuotaCenter) getMemoryFactor() map[int64]float64 {
 	dataNodeMemoryHighWaterLevel := Params.QuotaConfig.DataNodeMemoryHighWaterLevel.GetAsFloat()
 	queryNodeMemoryLowWaterLevel := Params.QuotaConfig.QueryNodeMemoryLowWaterLevel.GetAsFloat()
 	queryNodeMemoryHighWaterLevel := Params.QuotaConfig.QueryNodeMemoryHighWaterLevel.GetAsFloat()
+	streamingNodeMemoryLowWaterLevel := Params.QuotaConfig.StreamingNodeMemoryLowWaterLevel.GetAsFloat()
+	streamingNodeMemoryHighWaterLevel := Params.QuotaConfig.StreamingNodeMemoryHighWaterLevel.GetAsFloat()
 
 	collectionFactor := make(map[int64]float64)
 	updateCollectionFactor := func(factor float64, collections []int64) {
@@ -1106,9 +1468,14 @@ func (q *QuotaCenter) getMemoryFactor() map[int64]float64 {
 			}
 		}
 	}
+	excludedNodes := q.getFactorExcludedNodes()
 	for nodeID, metric := range q.queryNodeMetrics {
+		if excludedNodes.Contain(nodeID) {
+			continue
+		}
 		memoryWaterLevel := float64(metric.Hms.MemoryUsage) / float64(metric.Hms.Memory)
 		if memoryWaterLevel <= queryNodeMemoryLowWaterLevel {
+			q.writeFactorRecorder.recordNode(typeutil.QueryNodeRole, nodeID, writeFactorMemory, 1)
 			continue
 		}
 		if memoryWaterLevel >= queryNodeMemoryHighWaterLevel {
@@ -1120,10 +1487,12 @@ func (q *QuotaCenter) getMemoryFactor() map[int64]float64 {
 				mlog.Float64("curWatermark", memoryWaterLevel),
 				mlog.Float64("lowWatermark", queryNodeMemoryLowWaterLevel),
 				mlog.Float64("highWatermark", queryNodeMemoryHighWaterLevel))
+			q.writeFactorRecorder.recordNode(typeutil.QueryNodeRole, nodeID, writeFactorMemory, 0)
 			updateCollectionFactor(0, metric.Effect.CollectionIDs)
 			continue
 		}
 		factor := (queryNodeMemoryHighWaterLevel - memoryWaterLevel) / (queryNodeMemoryHighWaterLevel - queryNodeMemoryLowWaterLevel)
+		q.writeFactorRecorder.recordNode(typeutil.QueryNodeRole, nodeID, writeFactorMemory, factor)
 		updateCollectionFactor(factor, metric.Effect.CollectionIDs)
 		mlog.RatedWarn(q.ctx, rate.Limit(10), "QuotaCenter: QueryNode memory to low water level, limit writing rate",
 			mlog.String("Node", fmt.Sprintf("%s-%d", typeutil.QueryNodeRole, nodeID)),
@@ -1134,9 +1503,47 @@ func (q *QuotaCenter) getMemoryFactor() map[int64]float64 {
 			mlog.Float64("lowWatermark", queryNodeMemoryLowWaterLevel),
 			mlog.Float64("highWatermark", queryNodeMemoryHighWaterLevel))
 	}
+	for nodeID, metric := range q.streamingNodeMetrics {
+		if excludedNodes.Contain(nodeID) {
+			continue
+		}
+		memoryWaterLevel := float64(metric.Hms.MemoryUsage) / float64(metric.Hms.Memory)
+		if memoryWaterLevel <= streamingNodeMemoryLowWaterLevel {
+			q.writeFactorRecorder.recordNode(typeutil.StreamingNodeRole, nodeID, writeFactorMemory, 1)
+			continue
+		}
+		if memoryWaterLevel >= streamingNodeMemoryHighWaterLevel {
+			mlog.RatedWarn(q.ctx, rate.Limit(10), "QuotaCenter: StreamingNode memory to high water level",
+				mlog.String("Node", fmt.Sprintf("%s-%d", typeutil.StreamingNodeRole, nodeID)),
+				mlog.Int64s("collections", metric.Effect.CollectionIDs),
+				mlog.Uint64("UsedMem", metric.Hms.MemoryUsage),
+				mlog.Uint64("TotalMem", metric.Hms.Memory),
+				mlog.Float64("curWatermark", memoryWaterLevel),
+				mlog.Float64("lowWatermark", streamingNodeMemoryLowWaterLevel),
+				mlog.Float64("highWatermark", streamingNodeMemoryHighWaterLevel))
+			q.writeFactorRecorder.recordNode(typeutil.StreamingNodeRole, nodeID, writeFactorMemory, 0)
+			updateCollectionFactor(0, metric.Effect.CollectionIDs)
+			continue
+		}
+		factor := (streamingNodeMemoryHighWaterLevel - memoryWaterLevel) / (streamingNodeMemoryHighWaterLevel - streamingNodeMemoryLowWaterLevel)
+		q.writeFactorRecorder.recordNode(typeutil.StreamingNodeRole, nodeID, writeFactorMemory, factor)
+		updateCollectionFactor(factor, metric.Effect.CollectionIDs)
+		mlog.RatedWarn(q.ctx, rate.Limit(10), "QuotaCenter: StreamingNode memory to low water level, limit writing rate",
+			mlog.String("Node", fmt.Sprintf("%s-%d", typeutil.StreamingNodeRole, nodeID)),
+			mlog.Int64s("collections", metric.Effect.CollectionIDs),
+			mlog.Uint64("UsedMem", metric.Hms.MemoryUsage),
+			mlog.Uint64("TotalMem", metric.Hms.Memory),
+			mlog.Float64("curWatermark", memoryWaterLevel),
+			mlog.Float64("lowWatermark", streamingNodeMemoryLowWaterLevel),
+			mlog.Float64("highWatermark", streamingNodeMemoryHighWaterLevel))
+	}
 	for nodeID, metric := range q.dataNodeMetrics {
+		if excludedNodes.Contain(nodeID) {
+			continue
+		}
 		memoryWaterLevel := float64(metric.Hms.MemoryUsage) / float64(metric.Hms.Memory)
 		if memoryWaterLevel <= dataNodeMemoryLowWaterLevel {
+			q.writeFactorRecorder.recordNode(typeutil.DataNodeRole, nodeID, writeFactorMemory, 1)
 			continue
 		}
 		if memoryWaterLevel >= dataNodeMemoryHighWaterLevel {
@@ -1148,10 +1555,12 @@ func (q *QuotaCenter) getMemoryFactor() map[int64]float64 {
 				mlog.Float64("curWatermark", memoryWaterLevel),
 				mlog.Float64("lowWatermark", dataNodeMemoryLowWaterLevel),
 				mlog.Float64("highWatermark", dataNodeMemoryHighWaterLevel))
+			q.writeFactorRecorder.recordNode(typeutil.DataNodeRole, nodeID, writeFactorMemory, 0)
 			updateCollectionFactor(0, metric.Effect.CollectionIDs)
 			continue
 		}
 		factor := (dataNodeMemoryHighWaterLevel - memoryWaterLevel) / (dataNodeMemoryHighWaterLevel - dataNodeMemoryLowWaterLevel)
+		q.writeFactorRecorder.recordNode(typeutil.DataNodeRole, nodeID, writeFactorMemory, factor)
 		mlog.RatedWarn(q.ctx, rate.Limit(10), "QuotaCenter: DataNode memory to low water level, limit writing rate",
 			mlog.String("Node", fmt.Sprintf("%s-%d", typeutil.DataNodeRole, nodeID)),
 			mlog.Int64s("collections", metric.Effect.CollectionIDs),
@@ -1182,25 +1591,27 @@ func (q *QuotaCenter) getGrowingSegmentsSizeFactor() map[int64]float64 {
 			}
 		}
 	}
-	for nodeID, metric := range q.queryNodeMetrics {
+	q.rangeQueryNodeMetrics(func(role string, nodeID int64, metric *metricsinfo.QueryNodeQuotaMetrics) {
 		cur := float64(metric.GrowingSegmentsSize) / float64(metric.Hms.Memory)
 		if cur <= low {
-			continue
+			q.writeFactorRecorder.recordNode(role, nodeID, writeFactorGrowingSegmentsSize, 1)
+			return
 		}
 		factor := (high - cur) / (high - low)
 		if factor < Params.QuotaConfig.GrowingSegmentsSizeMinRateRatio.GetAsFloat() {
 			factor = Params.QuotaConfig.GrowingSegmentsSizeMinRateRatio.GetAsFloat()
 		}
+		q.writeFactorRecorder.recordNode(role, nodeID, writeFactorGrowingSegmentsSize, factor)
 		updateCollectionFactor(factor, metric.Effect.CollectionIDs)
 		mlog.RatedWarn(q.ctx, rate.Limit(10), "QuotaCenter: QueryNode growing segments size exceeds watermark, limit writing rate",
-			mlog.String("Node", fmt.Sprintf("%s-%d", typeutil.QueryNodeRole, nodeID)),
+			mlog.String("Node", fmt.Sprintf("%s-%d", role, nodeID)),
 			mlog.Int64s("collections", metric.Effect.CollectionIDs),
 			mlog.Int64("segmentsSize", metric.GrowingSegmentsSize),
 			mlog.Uint64("TotalMem", metric.Hms.Memory),
 			mlog.Float64("highWatermark", high),
 			mlog.Float64("lowWatermark", low),
 			mlog.Float64("factor", factor))
-	}
+	})
 	return collectionFactor
 }
 
@@ -1230,6 +1641,27 @@ func (q *QuotaCenter) getL0SegmentsSizeFactor() map[int64]float64 {
 	return collectionFactor
 }
 
+// getSmallSegmentInsertFactor returns the insert rate factors of the collections with the channels exceeding
+// the small segment soft limit of datacoord, the factor is the reciprocal of the small segment pressure.
+func (q *QuotaCenter) getSmallSegmentInsertFactor() map[int64]float64 {
+	if q.dataCoordMetrics == nil {
+		return nil
+	}
+	collectionFactor := make(map[int64]float64)
+	for collectionID, pressure := range q.dataCoordMetrics.CollectionSmallSegmentPressure {
+		if pressure <= 1 {
+			continue
+		}
+		factor := 1 / pressure
+		collectionFactor[collectionID] = factor
+		mlog.RatedWarn(q.ctx, rate.Limit(10), "QuotaCenter: DataCoord small segments on the channel exceed the soft limit, limit inserting and upserting rate",
+			mlog.Int64("collection", collectionID),
+			mlog.Float64("pressure", pressure),
+			mlog.Float64("factor", factor))
+	}
+	return collectionFactor
+}
+
 func (q *QuotaCenter) getDeleteBufferRowCountFactor() map[int64]float64 {
 	if !Params.QuotaConfig.DeleteBufferRowCountProtectionEnabled.GetAsBool() {
 		return nil
@@ -1239,11 +1671,11 @@ func (q *QuotaCenter) getDeleteBufferRowCountFactor() map[int64]float64 {
 	deleteBufferRowCountHighWaterLevel := Params.QuotaConfig.DeleteBufferRowCountHighWaterLevel.GetAsInt64()
 
 	deleteBufferNum := make(map[int64]int64)
-	for _, queryNodeMetrics := range q.queryNodeMetrics {
+	q.rangeQueryNodeMetrics(func(_ string, _ int64, queryNodeMetrics *metricsinfo.QueryNodeQuotaMetrics) {
 		for collectionID, num := range queryNodeMetrics.DeleteBufferInfo.CollectionDeleteBufferNum {
 			deleteBufferNum[collectionID] += num
 		}
-	}
+	})
 
 	collectionFactor := make(map[int64]float64)
 	for collID, rowCount := range deleteBufferNum {
@@ -1271,11 +1703,11 @@ func (q *QuotaCenter) getDeleteBufferSizeFactor() map[int64]float64 {
 	deleteBufferSizeHighWaterLevel := Params.QuotaConfig.DeleteBufferSizeHighWaterLevel.GetAsInt64()
 
 	deleteBufferSize := make(map[int64]int64)
-	for _, queryNodeMetrics := range q.queryNodeMetrics {
+	q.rangeQueryNodeMetrics(func(_ string, _ int64, queryNodeMetrics *metricsinfo.QueryNodeQuotaMetrics) {
 		for collectionID, size := range queryNodeMetrics.DeleteBufferInfo.CollectionDeleteBufferSize {
 			deleteBufferSize[collectionID] += size
 		}
-	}
+	})
 
 	collectionFactor := make(map[int64]float64)
 	for collID, bufferSize := range deleteBufferSize {
@@ -1320,6 +1752,7 @@ func (q *QuotaCenter) calculateRates() error {
 		return err
 	}
 
+	q.calculateDDLLimitStates()
 	q.calculateDBDDLRates()
 
 	// log.Debug("QuotaCenter calculates rate done", mlog.Any("rates", q.currentRates))
@@ -1347,7 +1780,10 @@ func (q *QuotaCenter) calculateEzStates() error {
 }
 
 func (q *QuotaCenter) resetAllCurrentRates() error {
-	clusterLimiter := newParamLimiterFunc(internalpb.RateScope_Cluster, allOps)()
+	sq := q.newScheduledQuota(time.Now())
+	clusterLimiter := newParamLimiterFuncWithLimitFunc(internalpb.RateScope_Cluster, allOps, func(rt internalpb.RateType) Limit {
+		return scaleLimit(Limit(quota.GetQuotaValue(internalpb.RateScope_Cluster, rt, Params)), sq.clusterFactor(rt))
+	})()
 	q.rateLimiter = rlinternal.NewRateLimiterTree(clusterLimiter)
 
 	enablePartitionRateLimit := false
@@ -1373,8 +1809,15 @@ func (q *QuotaCenter) resetAllCurrentRates() error {
 	}
 
 	collectionRateTypes := getRateTypes(internalpb.RateScope_Collection, allOps)
+	partitionRateTypes := getRateTypes(internalpb.RateScope_Partition, allOps)
 	initLimiters := func(sourceCollections map[int64]map[int64][]int64) {
 		for dbID, collections := range sourceCollections {
+			// the limits of the database, collections and partitions are scaled by the scheduled quota window.
+			scaledParamLimiterFunc := func(rateScope internalpb.RateScope) func() *rlinternal.RateLimiterNode {
+				return newParamLimiterFuncWithLimitFunc(rateScope, allOps, func(rt internalpb.RateType) Limit {
+					return scaleLimit(Limit(quota.GetQuotaValue(rateScope, rt, Params)), sq.databaseFactor(dbID, rt))
+				})
+			}
 			for collectionID, partitionIDs := range collections {
 				collectionLimitVals := make(map[internalpb.RateType]Limit, collectionRateTypes.Len())
 				collectionRateTypes.Range(func(rt internalpb.RateType) bool {
@@ -1382,7 +1825,7 @@ func (q *QuotaCenter) resetAllCurrentRates() error {
 					if err != nil {
 						limitVal = Limit(quota.GetQuotaValue(internalpb.RateScope_Collection, rt, Params))
 					}
-					collectionLimitVals[rt] = limitVal
+					collectionLimitVals[rt] = scaleLimit(limitVal, sq.databaseFactor(dbID, rt))
 					return true
 				})
 
@@ -1391,23 +1834,37 @@ func (q *QuotaCenter) resetAllCurrentRates() error {
 				}
 
 				collectionLimiter := q.rateLimiter.GetOrCreateCollectionLimiters(dbID, collectionID,
-					newParamLimiterFunc(internalpb.RateScope_Database, allOps),
+					scaledParamLimiterFunc(internalpb.RateScope_Database),
 					newParamLimiterFuncWithLimitFunc(internalpb.RateScope_Collection, allOps, getCollectionLimitVal))
 				updateLimiterHasUpdated(collectionLimiter)
 
-				if !enablePartitionRateLimit {
+				// the dql limits of the partitions may be overridden by the collection properties.
+				enableCollPartitionRateLimit := enablePartitionRateLimit
+				partitionLimitVals := make(map[internalpb.RateType]Limit, partitionRateTypes.Len())
+				partitionRateTypes.Range(func(rt internalpb.RateType) bool {
+					limitVal := q.getPartitionMaxLimit(rt, collectionID)
+					if limitVal != Inf {
+						enableCollPartitionRateLimit = true
+					}
+					partitionLimitVals[rt] = scaleLimit(limitVal, sq.databaseFactor(dbID, rt))
+					return true
+				})
+				if !enableCollPartitionRateLimit {
 					continue
 				}
+				getPartitionLimitVal := func(rateType internalpb.RateType) Limit {
+					return partitionLimitVals[rateType]
+				}
 				for _, partitionID := range partitionIDs {
 					partitionLimiter := q.rateLimiter.GetOrCreatePartitionLimiters(dbID, collectionID, partitionID,
-						newParamLimiterFunc(internalpb.RateScope_Database, allOps),
+						scaledParamLimiterFunc(internalpb.RateScope_Database),
 						newParamLimiterFuncWithLimitFunc(internalpb.RateScope_Collection, allOps, getCollectionLimitVal),
-						newParamLimiterFunc(internalpb.RateScope_Partition, allOps))
+						newParamLimiterFuncWithLimitFunc(internalpb.RateScope_Partition, allOps, getPartitionLimitVal))
 					updateLimiterHasUpdated(partitionLimiter)
 				}
 			}
 			if len(collections) == 0 {
-				dbLimiter := q.rateLimiter.GetOrCreateDatabaseLimiters(dbID, newParamLimiterFunc(internalpb.RateScope_Database, allOps))
+				dbLimiter := q.rateLimiter.GetOrCreateDatabaseLimiters(dbID, scaledParamLimiterFunc(internalpb.RateScope_Database))
 				updateLimiterHasUpdated(dbLimiter)
 			}
 		}
@@ -1431,11 +1888,32 @@ func (q *QuotaCenter) getCollectionMaxLimit(rt internalpb.RateType, collectionID
 		return Limit(getCollectionRateLimitConfig(collectionProps, common.CollectionSearchRateMaxKey)), nil
 	case internalpb.RateType_DQLQuery:
 		return Limit(getCollectionRateLimitConfig(collectionProps, common.CollectionQueryRateMaxKey)), nil
+	case internalpb.RateType_DQLGet:
+		return Limit(getCollectionRateLimitConfig(collectionProps, common.CollectionGetRateMaxKey)), nil
+	case internalpb.RateType_DDLFlush:
+		return Limit(getCollectionRateLimitConfig(collectionProps, common.CollectionFlushRateMaxKey)), nil
+	case internalpb.RateType_DDLCompaction:
+		return Limit(getCollectionRateLimitConfig(collectionProps, common.CollectionCompactionRateMaxKey)), nil
 	default:
 		return 0, merr.WrapErrServiceInternalMsg("unsupportd rate type:%s", rt.String())
 	}
 }
 
+// getPartitionMaxLimit returns the limit of each partition of the collection,
+// the dql limits can be set by the collection properties, other limits come from the partition level configuration.
+func (q *QuotaCenter) getPartitionMaxLimit(rt internalpb.RateType, collectionID int64) ratelimitutil.Limit {
+	switch rt {
+	case internalpb.RateType_DQLSearch:
+		return Limit(getCollectionRateLimitConfig(q.getCollectionLimitProperties(collectionID), common.PartitionSearchRateMaxKey))
+	case internalpb.RateType_DQLQuery:
+		return Limit(getCollectionRateLimitConfig(q.getCollectionLimitProperties(collectionID), common.PartitionQueryRateMaxKey))
+	case internalpb.RateType_DQLGet:
+		return Limit(getCollectionRateLimitConfig(q.getCollectionLimitProperties(collectionID), common.PartitionGetRateMaxKey))
+	default:
+		return Limit(quota.GetQuotaValue(internalpb.RateScope_Partition, rt, Params))
+	}
+}
+
 func (q *QuotaCenter) getCollectionLimitProperties(collection int64) map[string]string {
 	if props, ok := q.collectionProps[collection]; ok {
 		return props
@@ -1461,25 +1939,40 @@ func (q *QuotaCenter) getCollectionLimitProperties(collection int64) map[string]
 
 // checkDiskQuota checks if disk quota exceeded.
 func (q *QuotaCenter) checkDiskQuota(denyWritingDBs map[int64]struct{}) error {
+	reclaimTargets, err := q.doCheckDiskQuota(denyWritingDBs)
+	// the compactions are triggered after diskMu is released, so a slow datacoord never stalls the quota check.
+	q.triggerDiskReclaimCompaction(reclaimTargets)
+	return err
+}
+
+func (q *QuotaCenter) doCheckDiskQuota(denyWritingDBs map[int64]struct{}) ([]diskReclaimTarget, error) {
 	q.diskMu.Lock()
 	defer q.diskMu.Unlock()
 	if !Params.QuotaConfig.DiskProtectionEnabled.GetAsBool() {
-		return nil
+		return nil, nil
 	}
 	if q.dataCoordMetrics == nil {
-		return nil
+		return nil, nil
+	}
+
+	// all the collections are denied to write if the disk quota of cluster level is exceeded.
+	denyAllCollections := func() {
+		for collection := range q.dataCoordMetrics.CollectionBinlogSize {
+			q.writeFactorRecorder.recordCollection(collection, writeFactorDisk, 0)
+		}
 	}
 
 	// check disk quota of cluster level
 	totalDiskQuota := Params.QuotaConfig.DiskQuota.GetAsFloat()
-	total := q.dataCoordMetrics.TotalBinlogSize
+	total := q.dataCoordMetrics.TotalBinlogSize + q.dataCoordMetrics.TotalImportReservedSize
 	if float64(total) >= totalDiskQuota {
+		denyAllCollections()
 		mlog.RatedWarn(q.ctx, rate.Limit(10), "cluster disk quota exceeded", mlog.Int64("disk usage", total), mlog.Float64("disk quota", totalDiskQuota))
 		err := q.forceDenyWriting(commonpb.ErrorCode_DiskQuotaExhausted, true, nil, nil, nil, "cluster disk quota exceeded")
 		if err != nil {
 			mlog.Warn(q.ctx, "fail to force deny writing", mlog.Err(err))
 		}
-		return err
+		return nil, err
 	}
 
 	// check disk quota of loaded collections
@@ -1490,26 +1983,36 @@ func (q *QuotaCenter) checkDiskQuota(denyWritingDBs map[int64]struct{}) error {
 		totalLoaded += float64(queryNodeMetrics.LoadedBinlogSize)
 	}
 	if totalLoaded >= totalLoadedDiskQuota {
+		denyAllCollections()
 		mlog.RatedWarn(q.ctx, rate.Limit(10), "cluster loaded disk quota exceeded", mlog.Float64("total loaded", totalLoaded), mlog.Float64("total loaded disk quota", totalLoadedDiskQuota))
 		err := q.forceDenyWriting(commonpb.ErrorCode_DiskQuotaExhausted, true, nil, nil, nil, "cluster loaded disk quota exceeded")
 		if err != nil {
 			mlog.Warn(q.ctx, "fail to force deny writing", mlog.Err(err))
 		}
-		return err
+		return nil, err
 	}
 
-	collectionDiskQuota := Params.QuotaConfig.DiskQuotaPerCollection.GetAsFloat()
+	reclaimEnabled := Params.QuotaConfig.DiskReclaimEnabled.GetAsBool()
+	reclaimLowWaterLevel := Params.QuotaConfig.DiskReclaimLowWaterLevel.GetAsFloat()
+	now := time.Now()
 	dbSizeInfo := make(map[int64]int64)
 	collections := make([]int64, 0)
-	for collection, binlogSize := range q.dataCoordMetrics.CollectionBinlogSize {
-		collectionProps := q.getCollectionLimitProperties(collection)
-		colDiskQuota := getRateLimitConfig(collectionProps, common.CollectionDiskQuotaKey, collectionDiskQuota)
-		if float64(binlogSize) >= colDiskQuota {
+	collectionDiskUsage := q.getCollectionDiskUsage()
+	for collection, binlogSize := range collectionDiskUsage {
+		colDiskQuota := q.getCollectionDiskQuota(collection)
+		exceeded := float64(binlogSize) >= colDiskQuota
+		if reclaimEnabled {
+			exceeded = q.diskReclaim.shouldDeny(q.ctx, collection, binlogSize, colDiskQuota, reclaimLowWaterLevel, now)
+		}
+		if exceeded {
 			mlog.RatedWarn(q.ctx, rate.Limit(10), "collection disk quota exceeded",
 				mlog.Int64("collection", collection),
 				mlog.Int64("coll disk usage", binlogSize),
 				mlog.Float64("coll disk quota", colDiskQuota))
 			collections = append(collections, collection)
+			q.writeFactorRecorder.recordCollection(collection, writeFactorDisk, 0)
+		} else {
+			q.writeFactorRecorder.recordCollection(collection, writeFactorDisk, 1)
 		}
 		dbID, ok := q.collectionIDToDBID.Get(collection)
 		if !ok {
@@ -1545,10 +2048,31 @@ func (q *QuotaCenter) checkDiskQuota(denyWritingDBs map[int64]struct{}) error {
 	err := q.forceDenyWriting(commonpb.ErrorCode_DiskQuotaExhausted, false, dbIDs, collections, col2partitions, "disk quota exceeded")
 	if err != nil {
 		mlog.Warn(q.ctx, "fail to force deny writing", mlog.Err(err))
-		return err
+		return nil, err
+	}
+	var reclaimTargets []diskReclaimTarget
+	if reclaimEnabled {
+		q.diskReclaim.prune(collectionDiskUsage)
+		reclaimTargets = q.diskReclaim.targetsToCompact(now, Params.QuotaConfig.DiskReclaimCompactionInterval.GetAsDuration(time.Second))
+	} else if len(q.diskReclaim.collections) > 0 {
+		// reclaim is disabled, fall back to the plain quota check.
+		q.diskReclaim = newDiskReclaimTracker()
 	}
 	q.totalBinlogSize = total
-	return nil
+	return reclaimTargets, nil
+}
+
+// getCollectionDiskUsage returns the disk usage of the collections, including the disk size reserved by
+// the import jobs in progress, so that the writes are denied before the imports overshoot the quota.
+func (q *QuotaCenter) getCollectionDiskUsage() map[int64]int64 {
+	usage := make(map[int64]int64, len(q.dataCoordMetrics.CollectionBinlogSize))
+	for collection, binlogSize := range q.dataCoordMetrics.CollectionBinlogSize {
+		usage[collection] = binlogSize
+	}
+	for collection, reservedSize := range q.dataCoordMetrics.CollectionImportReservedSize {
+		usage[collection] += reservedSize
+	}
+	return usage
 }
 
 func (q *QuotaCenter) checkDBDiskQuota(dbSizeInfo map[int64]int64) []int64 {
@@ -1565,25 +2089,52 @@ func (q *QuotaCenter) checkDBDiskQuota(dbSizeInfo map[int64]int64) []int64 {
 
 	//  DB properties take precedence over quota configuration for disk quota.
 	for dbID, binlogSize := range dbSizeInfo {
-		db, err := q.meta.GetDatabaseByID(q.ctx, dbID, typeutil.MaxTimestamp)
-		if err == nil {
-			if dbDiskQuotaStr := db.GetProperty(common.DatabaseDiskQuotaKey); dbDiskQuotaStr != "" {
-				if dbDiskQuotaBytes, err := strconv.ParseFloat(dbDiskQuotaStr, 64); err == nil {
-					dbDiskQuotaMB := dbDiskQuotaBytes * 1024 * 1024
-					appendIfExceeded(dbID, binlogSize, dbDiskQuotaMB)
-					continue
-				} else {
-					mlog.Warn(q.ctx, "invalid configuration for diskQuota.mb",
-						mlog.String("config item", common.DatabaseDiskQuotaKey),
-						mlog.String("config value", dbDiskQuotaStr))
-				}
-			}
+		if dbDiskQuota, ok := q.getDatabaseDiskQuotaProperty(dbID); ok {
+			appendIfExceeded(dbID, binlogSize, dbDiskQuota)
+			continue
 		}
 		appendIfExceeded(dbID, binlogSize, Params.QuotaConfig.DiskQuotaPerDB.GetAsFloat())
 	}
 	return dbIDs
 }
 
+// getDatabaseDiskQuotaProperty returns the disk quota in bytes set by the database property.
+func (q *QuotaCenter) getDatabaseDiskQuotaProperty(dbID int64) (float64, bool) {
+	db, err := q.meta.GetDatabaseByID(q.ctx, dbID, typeutil.MaxTimestamp)
+	if err != nil {
+		return 0, false
+	}
+	dbDiskQuotaStr := db.GetProperty(common.DatabaseDiskQuotaKey)
+	if dbDiskQuotaStr == "" {
+		return 0, false
+	}
+	dbDiskQuotaMB, err := strconv.ParseFloat(dbDiskQuotaStr, 64)
+	if err != nil {
+		mlog.Warn(q.ctx, "invalid configuration for diskQuota.mb",
+			mlog.String("config item", common.DatabaseDiskQuotaKey),
+			mlog.String("config value", dbDiskQuotaStr))
+		return 0, false
+	}
+	return dbDiskQuotaMB * 1024 * 1024, true
+}
+
+// getCollectionDiskQuota returns the disk quota in bytes of the collection,
+// the collection properties take precedence over the database property and the cluster config.
+func (q *QuotaCenter) getCollectionDiskQuota(collection int64) float64 {
+	collectionProps := q.getCollectionLimitProperties(collection)
+	for _, key := range []string{common.CollectionDiskQuotaMBKey, common.CollectionDiskQuotaKey} {
+		if quota := getRateLimitConfig(collectionProps, key, -1); quota >= 0 {
+			return quota
+		}
+	}
+	if dbID, ok := q.collectionIDToDBID.Get(collection); ok {
+		if dbDiskQuota, ok := q.getDatabaseDiskQuotaProperty(dbID); ok {
+			return dbDiskQuota
+		}
+	}
+	return Params.QuotaConfig.DiskQuotaPerCollection.GetAsFloat()
+}
+
 func (q *QuotaCenter) toRequestLimiter(limiter *rlinternal.RateLimiterNode) *proxypb.Limiter {
 	var rates []*internalpb.Rate
 	switch q.rateAllocateStrategy {
@@ -1626,6 +2177,53 @@ func (q *QuotaCenter) toRequestLimiter(limiter *rlinternal.RateLimiterNode) *pro
 	}
 }
 
+// aliasRateLimitConfigKeys are the collection rate limit keys which can be set on the aliases.
+var aliasRateLimitConfigKeys = map[internalpb.RateType]string{
+	internalpb.RateType_DMLInsert:   common.CollectionInsertRateMaxKey,
+	internalpb.RateType_DMLDelete:   common.CollectionDeleteRateMaxKey,
+	internalpb.RateType_DMLBulkLoad: common.CollectionBulkLoadRateMaxKey,
+	internalpb.RateType_DQLSearch:   common.CollectionSearchRateMaxKey,
+	internalpb.RateType_DQLQuery:    common.CollectionQueryRateMaxKey,
+	internalpb.RateType_DQLGet:      common.CollectionGetRateMaxKey,
+}
+
+// toAliasRequestLimiters returns the limiters of the aliases of the collection, the alias rate limits are set
+// by the collection properties with the alias prefix, see common.AliasRateLimitKey.
+// The alias limit is capped by the current collection limit, so the more restrictive one wins.
+func (q *QuotaCenter) toAliasRequestLimiters(collectionID int64, collectionLimiter *rlinternal.RateLimiterNode) map[string]*proxypb.Limiter {
+	properties := q.getCollectionLimitProperties(collectionID)
+	if !common.HasAliasRateLimitProperties(properties) {
+		return nil
+	}
+	proxyNum := q.proxies.GetProxyCount()
+	if proxyNum == 0 {
+		return nil
+	}
+
+	aliasLimiters := make(map[string]*proxypb.Limiter)
+	for _, alias := range q.meta.ListAliasesByID(q.ctx, collectionID) {
+		aliasProperties := common.GetAliasRateLimitProperties(properties, alias)
+		if len(aliasProperties) == 0 {
+			continue
+		}
+		rates := make([]*internalpb.Rate, 0, len(aliasProperties))
+		for rt, configKey := range aliasRateLimitConfigKeys {
+			limit := Limit(getRateLimitConfig(aliasProperties, configKey, float64(Inf)))
+			if limit == Inf {
+				continue
+			}
+			if collectionLimit, ok := collectionLimiter.GetLimiters().Get(rt); ok && collectionLimit.Limit() < limit {
+				limit = collectionLimit.Limit()
+			}
+			rates = append(rates, &internalpb.Rate{Rt: rt, R: float64(limit) / float64(proxyNum)})
+		}
+		if len(rates) > 0 {
+			aliasLimiters[alias] = &proxypb.Limiter{Rates: rates}
+		}
+	}
+	return aliasLimiters
+}
+
 func (q *QuotaCenter) toRatesRequest() *proxypb.SetRatesRequest {
 	clusterRateLimiter := q.rateLimiter.GetRootLimiters()
 
@@ -1650,8 +2248,9 @@ func (q *QuotaCenter) toRatesRequest() *proxypb.SetRatesRequest {
 			})
 
 			collectionLimiters[collectionID] = &proxypb.LimiterNode{
-				Limiter:  collectionLimiter,
-				Children: partitionLimiters,
+				Limiter:       collectionLimiter,
+				Children:      partitionLimiters,
+				AliasLimiters: q.toAliasRequestLimiters(collectionID, collectionRateLimiters),
 			}
 			return true
 		})
@@ -1665,8 +2264,9 @@ func (q *QuotaCenter) toRatesRequest() *proxypb.SetRatesRequest {
 	})
 
 	clusterLimiter := &proxypb.LimiterNode{
-		Limiter:  q.toRequestLimiter(clusterRateLimiter),
-		Children: dbLimiters,
+		Limiter:        q.toRequestLimiter(clusterRateLimiter),
+		Children:       dbLimiters,
+		AppTagLimiters: q.toAppTagRequestLimiters(),
 	}
 
 	timestamp := tsoutil.ComposeTSByTime(time.Now())
@@ -1682,40 +2282,54 @@ func (q *QuotaCenter) toRatesRequest() *proxypb.SetRatesRequest {
 
 // sendRatesToProxy notifies Proxies to set rates for different rate types.
 func (q *QuotaCenter) sendRatesToProxy() error {
-	ctx, cancel := context.WithTimeout(context.Background(), SetRatesTimeout)
+	ctx, cancel := context.WithTimeout(q.sendCtx, SetRatesTimeout)
+	defer cancel()
+	req := q.toRatesRequest()
+	q.lastPushedRates = req
+	q.lastPushedDigest = rlinternal.LimiterDigest(req.GetRootLimiter())
+	return q.proxies.SetRates(ctx, req)
+}
+
+// checkProxyLimiterConsistency compares the limits enforced by the proxies with the last pushed ones,
+// and pushes the last rates again if any proxy diverges, e.g. the proxy restarted or dropped the update.
+func (q *QuotaCenter) checkProxyLimiterConsistency() {
+	if q.lastPushedRates == nil {
+		return
+	}
+	divergedProxies := make([]int64, 0)
+	for nodeID, proxyMetric := range q.proxyMetrics {
+		// skip the proxies not reporting the limiter summary.
+		if proxyMetric.Limiter == nil || proxyMetric.Limiter.Digest == q.lastPushedDigest {
+			continue
+		}
+		divergedProxies = append(divergedProxies, nodeID)
+		metrics.RootCoordProxyLimiterDivergenceCounter.WithLabelValues(strconv.FormatInt(nodeID, 10)).Inc()
+	}
+	if len(divergedProxies) == 0 {
+		return
+	}
+	mlog.Warn(q.ctx, "proxies enforce limits different from the last pushed ones, push the rates again",
+		mlog.Int64s("proxyIDs", divergedProxies),
+		mlog.String("lastPushedDigest", q.lastPushedDigest))
+	ctx, cancel := context.WithTimeout(q.sendCtx, SetRatesTimeout)
 	defer cancel()
-	return q.proxies.SetRates(ctx, q.toRatesRequest())
+	if err := q.proxies.SetRates(ctx, q.lastPushedRates); err != nil {
+		mlog.Warn(q.ctx, "quotaCenter push rates to diverged proxies failed", mlog.Err(err))
+	}
 }
 
 // recordMetrics records metrics of quota states.
 func (q *QuotaCenter) recordMetrics() {
 	metrics.RootCoordQuotaStates.Reset()
-	dbIDs := make(map[int64]string, q.dbs.Len())
-	collectionIDs := make(map[int64]string, q.collections.Len())
-	q.dbs.Range(func(name string, id int64) bool {
-		dbIDs[id] = name
-		return true
-	})
-	q.collections.Range(func(name string, id int64) bool {
-		_, collectionName := SplitCollectionKey(name)
-		collectionIDs[id] = collectionName
-		return true
-	})
+	nodeName := q.limiterNodeNamer()
 
 	rlinternal.TraverseRateLimiterTree(q.rateLimiter.GetRootLimiters(), nil,
 		func(node *rlinternal.RateLimiterNode, state milvuspb.QuotaState, errCode commonpb.ErrorCode, reason string) bool {
 			if errCode == commonpb.ErrorCode_MemoryQuotaExhausted ||
 				errCode == commonpb.ErrorCode_DiskQuotaExhausted ||
 				errCode == commonpb.ErrorCode_TimeTickLongDelay {
-				var name string
-				switch node.Level() {
-				case internalpb.RateScope_Cluster:
-					name = "cluster"
-				case internalpb.RateScope_Database:
-					name = "db_" + dbIDs[node.GetID()]
-				case internalpb.RateScope_Collection:
-					name = "collection_" + collectionIDs[node.GetID()]
-				default:
+				name, ok := nodeName(node)
+				if !ok || node.Level() == internalpb.RateScope_Partition {
 					return false
 				}
 				metrics.RootCoordQuotaStates.WithLabelValues(errCode.String(), name).Set(1.0)
@@ -1736,10 +2350,12 @@ func (q *QuotaCenter) diskAllowance(collection UniqueID) float64 {
 		return math.MaxInt64
 	}
 	totalDiskQuota := Params.QuotaConfig.DiskQuota.GetAsFloat()
-	colDiskQuota := Params.QuotaConfig.DiskQuotaPerCollection.GetAsFloat()
+	colDiskQuota := q.getCollectionDiskQuota(collection)
 	allowance := math.Min(totalDiskQuota, colDiskQuota)
-	if binlogSize, ok := q.dataCoordMetrics.CollectionBinlogSize[collection]; ok {
-		allowance = math.Min(allowance, colDiskQuota-float64(binlogSize))
+	binlogSize, ok := q.dataCoordMetrics.CollectionBinlogSize[collection]
+	reservedSize, reserved := q.dataCoordMetrics.CollectionImportReservedSize[collection]
+	if ok || reserved {
+		allowance = math.Min(allowance, colDiskQuota-float64(binlogSize+reservedSize))
 	}
 	allowance = math.Min(allowance, totalDiskQuota-float64(q.totalBinlogSize))
 	return allowance
@@ -1755,10 +2371,15 @@ func (q *QuotaCenter) getQuotaMetrics() *internalpb.GetQuotaMetricsResponse {
 	defer q.lock.RUnlock()
 
 	quotaCenterMetrics := &metricsinfo.QuotaCenterMetrics{
-		QueryNodeMetrics: q.queryNodeMetrics,
-		DataNodeMetrics:  q.dataNodeMetrics,
-		ProxyMetrics:     q.proxyMetrics,
-		DataCoordMetrics: q.dataCoordMetrics,
+		QueryNodeMetrics:     q.queryNodeMetrics,
+		StreamingNodeMetrics: q.streamingNodeMetrics,
+		DataNodeMetrics:      q.dataNodeMetrics,
+		ProxyMetrics:         q.proxyMetrics,
+		DataCoordMetrics:     q.dataCoordMetrics,
+		History:              q.metricsHistory.list(),
+		Simulated:            q.simulatedLimits,
+		ConfigHealth:         q.configHealth,
+		WriteFactors:         q.writeFactors,
 	}
 
 	responseString, err := metricsinfo.MarshalComponentInfos(quotaCenterMetrics)
@@ -40,6 +40,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
@@ -59,6 +60,7 @@ func TestQuotaCenter(t *testing.T) {
 
 	pcm := proxyutil.NewMockProxyClientManager(t)
 	pcm.EXPECT().GetProxyMetrics(mock.Anything).Return(nil, nil).Maybe()
+	pcm.EXPECT().SetRates(mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	dc := mocks.NewMixCoord(t)
 	dc.EXPECT().GetDataCoordTopology(mock.Anything, mock.Anything).Return(&metricsinfo.DataCoordTopology{}, nil).Maybe()
@@ -123,6 +125,45 @@ func TestQuotaCenter(t *testing.T) {
 		assert.True(t, time.Since(start).Seconds() <= 5)
 	})
 
+	t.Run("test QuotaCenter stop drains rates push", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().QuotaConfig.StopDrainTimeout.Key, "1")
+		defer paramtable.Get().Reset(paramtable.Get().QuotaConfig.StopDrainTimeout.Key)
+
+		pcm := proxyutil.NewMockProxyClientManager(t)
+		// the in-flight push is stuck until it is canceled
+		pcm.EXPECT().SetRates(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *proxypb.SetRatesRequest) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}).Once()
+		finalPushed := false
+		pcm.EXPECT().SetRates(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *proxypb.SetRatesRequest) error {
+			finalPushed = true
+			assert.NotNil(t, req.GetRootLimiter())
+			return nil
+		}).Once()
+
+		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, mockrootcoord.NewIMetaTable(t))
+		quotaCenter.started.Store(true)
+		var pushErr error
+		quotaCenter.wg.Add(1)
+		go func() {
+			defer quotaCenter.wg.Done()
+			pushErr = quotaCenter.sendRatesToProxy()
+		}()
+
+		start := time.Now()
+		quotaCenter.stop()
+		assert.Less(t, time.Since(start), SetRatesTimeout)
+		assert.ErrorIs(t, pushErr, context.Canceled)
+		assert.True(t, finalPushed)
+	})
+
+	t.Run("test QuotaCenter stop without start", func(t *testing.T) {
+		pcm := proxyutil.NewMockProxyClientManager(t)
+		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, mockrootcoord.NewIMetaTable(t))
+		quotaCenter.stop()
+	})
+
 	t.Run("test collectMetrics", func(t *testing.T) {
 		meta := mockrootcoord.NewIMetaTable(t)
 		meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, mock.Anything).Return(nil, merr.ErrCollectionNotFound).Maybe()
@@ -402,6 +443,7 @@ func TestQuotaCenter(t *testing.T) {
 		meta := mockrootcoord.NewIMetaTable(t)
 		meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, mock.Anything).Return(nil, merr.ErrCollectionNotFound).Maybe()
 		meta.EXPECT().ListDatabases(mock.Anything, mock.Anything).Return([]*model.Database{}, nil).Maybe()
+		meta.EXPECT().ListAllAvailCollections(mock.Anything).Return(map[int64][]int64{}).Maybe()
 		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, meta)
 		meta.EXPECT().ListAllAvailPartitions(mock.Anything).Return(quotaCenter.writableCollections).Maybe()
 		quotaCenter.clearMetrics()
@@ -464,6 +506,163 @@ func TestQuotaCenter(t *testing.T) {
 		Params.Save(Params.QuotaConfig.MaxTimeTickDelay.Key, backup)
 	})
 
+	t.Run("test getTimeTickDelayFactor database override", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, meta)
+		paramtable.Get().Save(Params.QuotaConfig.TtProtectionEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.QuotaConfig.TtProtectionEnabled.Key)
+		paramtable.Get().Save(Params.QuotaConfig.MaxTimeTickDelay.Key, "10")
+		defer paramtable.Get().Reset(Params.QuotaConfig.MaxTimeTickDelay.Key)
+
+		quotaCenter.collectionIDToDBID.Insert(1, 0)
+		quotaCenter.collectionIDToDBID.Insert(2, 1)
+		quotaCenter.collectionIDToDBID.Insert(3, 2)
+		quotaCenter.collectionIDToDBID.Insert(4, 3)
+		quotaCenter.dbMaxTtDelays = map[int64]string{
+			1: "20",
+			2: "-1",
+			3: "invalid",
+		}
+
+		t0 := time.Now()
+		quotaCenter.queryNodeMetrics = map[UniqueID]*metricsinfo.QueryNodeQuotaMetrics{
+			1: {
+				Fgm: metricsinfo.FlowGraphMetric{
+					NumFlowGraph:        1,
+					MinFlowGraphTt:      tsoutil.ComposeTSByTime(t0),
+					MinFlowGraphChannel: "dml",
+				},
+				Effect: metricsinfo.NodeEffect{NodeID: 1, CollectionIDs: []int64{1, 2, 3, 4}},
+			},
+		}
+		factors := quotaCenter.getTimeTickDelayFactor(tsoutil.ComposeTSByTime(t0.Add(5 * time.Second)))
+		assert.InDelta(t, 0.5, factors[1], 0.01)
+		// the database tolerates larger delay
+		assert.InDelta(t, 0.75, factors[2], 0.01)
+		// tt protection is disabled for the database
+		_, ok := factors[3]
+		assert.False(t, ok)
+		// invalid property falls back to the cluster config
+		assert.InDelta(t, 0.5, factors[4], 0.01)
+	})
+
+	t.Run("test getTimeTickDelayFactor data node wal backlogs", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, meta)
+		paramtable.Get().Save(Params.QuotaConfig.TtProtectionEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.QuotaConfig.TtProtectionEnabled.Key)
+		paramtable.Get().Save(Params.QuotaConfig.MaxTimeTickDelay.Key, "10")
+		defer paramtable.Get().Reset(Params.QuotaConfig.MaxTimeTickDelay.Key)
+
+		t0 := time.Now()
+		quotaCenter.dataNodeMetrics = map[UniqueID]*metricsinfo.DataNodeQuotaMetrics{
+			1: {
+				Effect: metricsinfo.NodeEffect{NodeID: 1, CollectionIDs: []int64{1, 2}},
+				WALBacklogs: map[int64]metricsinfo.WALBacklog{
+					1: {Channel: "dml_0_1v0", ConsumedTt: tsoutil.ComposeTSByTime(t0)},
+					2: {Channel: "dml_1_2v0", ConsumedTt: tsoutil.ComposeTSByTime(t0.Add(5 * time.Second))},
+				},
+			},
+		}
+		factors := quotaCenter.getTimeTickDelayFactor(tsoutil.ComposeTSByTime(t0.Add(5 * time.Second)))
+		// only the collection lagging behind is limited
+		assert.InDelta(t, 0.5, factors[1], 0.01)
+		assert.InDelta(t, 1.0, factors[2], 0.01)
+	})
+
+	t.Run("test streaming node metrics", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, meta)
+		paramtable.Get().Save(Params.QuotaConfig.TtProtectionEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.QuotaConfig.TtProtectionEnabled.Key)
+		paramtable.Get().Save(Params.QuotaConfig.MaxTimeTickDelay.Key, "10")
+		defer paramtable.Get().Reset(Params.QuotaConfig.MaxTimeTickDelay.Key)
+		paramtable.Get().Save(Params.QuotaConfig.StreamingNodeMemoryLowWaterLevel.Key, "0.8")
+		defer paramtable.Get().Reset(Params.QuotaConfig.StreamingNodeMemoryLowWaterLevel.Key)
+		paramtable.Get().Save(Params.QuotaConfig.StreamingNodeMemoryHighWaterLevel.Key, "0.9")
+		defer paramtable.Get().Reset(Params.QuotaConfig.StreamingNodeMemoryHighWaterLevel.Key)
+
+		t0 := time.Now()
+		quotaCenter.streamingNodeMetrics = map[UniqueID]*metricsinfo.QueryNodeQuotaMetrics{
+			1: {
+				Hms: metricsinfo.HardwareMetrics{MemoryUsage: 85, Memory: 100},
+				Fgm: metricsinfo.FlowGraphMetric{
+					NumFlowGraph:        1,
+					MinFlowGraphTt:      tsoutil.ComposeTSByTime(t0),
+					MinFlowGraphChannel: "dml",
+				},
+				Effect: metricsinfo.NodeEffect{NodeID: 1, CollectionIDs: []int64{1}},
+			},
+			2: {
+				Hms:    metricsinfo.HardwareMetrics{MemoryUsage: 95, Memory: 100},
+				Effect: metricsinfo.NodeEffect{NodeID: 2, CollectionIDs: []int64{2}},
+			},
+		}
+		factors := quotaCenter.getTimeTickDelayFactor(tsoutil.ComposeTSByTime(t0.Add(5 * time.Second)))
+		assert.InDelta(t, 0.5, factors[1], 0.01)
+		_, ok := factors[2]
+		assert.False(t, ok)
+
+		factors = quotaCenter.getMemoryFactor()
+		assert.InDelta(t, 0.5, factors[1], 0.01)
+		// the streaming node at the high water level denies writing
+		assert.InDelta(t, 0, factors[2], 0.01)
+
+		// the streaming nodes are reported apart from the query nodes
+		resp := quotaCenter.getQuotaMetrics()
+		assert.NoError(t, merr.Error(resp.GetStatus()))
+		quotaMetrics := &metricsinfo.QuotaCenterMetrics{}
+		assert.NoError(t, metricsinfo.UnmarshalComponentInfos(resp.GetMetricsInfo(), quotaMetrics))
+		assert.Len(t, quotaMetrics.StreamingNodeMetrics, 2)
+		assert.Empty(t, quotaMetrics.QueryNodeMetrics)
+	})
+
+	t.Run("test write factors", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, meta)
+		paramtable.Get().Save(Params.QuotaConfig.MemProtectionEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.QuotaConfig.MemProtectionEnabled.Key)
+		paramtable.Get().Save(Params.QuotaConfig.QueryNodeMemoryLowWaterLevel.Key, "0.8")
+		defer paramtable.Get().Reset(Params.QuotaConfig.QueryNodeMemoryLowWaterLevel.Key)
+		paramtable.Get().Save(Params.QuotaConfig.QueryNodeMemoryHighWaterLevel.Key, "0.9")
+		defer paramtable.Get().Reset(Params.QuotaConfig.QueryNodeMemoryHighWaterLevel.Key)
+		paramtable.Get().Save(Params.QuotaConfig.GrowingSegmentsSizeProtectionEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.QuotaConfig.GrowingSegmentsSizeProtectionEnabled.Key)
+
+		quotaCenter.queryNodeMetrics = map[UniqueID]*metricsinfo.QueryNodeQuotaMetrics{
+			1: {
+				Hms:    metricsinfo.HardwareMetrics{MemoryUsage: 85, Memory: 100},
+				Effect: metricsinfo.NodeEffect{NodeID: 1, CollectionIDs: []int64{1}},
+			},
+			2: {
+				Hms:    metricsinfo.HardwareMetrics{MemoryUsage: 10, Memory: 100},
+				Effect: metricsinfo.NodeEffect{NodeID: 2, CollectionIDs: []int64{2}},
+			},
+		}
+
+		// the factors are not recorded out of a round of rate calculation
+		quotaCenter.getMemoryFactor()
+
+		quotaCenter.startRecordWriteFactors()
+		quotaCenter.writeFactorRecorder.recordCollections(writeFactorMemory, quotaCenter.getMemoryFactor())
+		quotaCenter.writeFactorRecorder.recordCollections(writeFactorGrowingSegmentsSize, quotaCenter.getGrowingSegmentsSizeFactor())
+		quotaCenter.finishRecordWriteFactors(time.Now())
+		assert.Nil(t, quotaCenter.writeFactorRecorder)
+
+		resp := quotaCenter.getQuotaMetrics()
+		assert.NoError(t, merr.Error(resp.GetStatus()))
+		quotaMetrics := &metricsinfo.QuotaCenterMetrics{}
+		assert.NoError(t, metricsinfo.UnmarshalComponentInfos(resp.GetMetricsInfo(), quotaMetrics))
+		factors := quotaMetrics.WriteFactors
+		assert.NotNil(t, factors)
+		assert.InDelta(t, 0.5, factors.Collections[1][writeFactorMemory], 0.01)
+		_, ok := factors.Collections[2]
+		assert.False(t, ok)
+		assert.InDelta(t, 0.5, factors.Nodes["querynode-1"][writeFactorMemory], 0.01)
+		assert.InDelta(t, 1, factors.Nodes["querynode-2"][writeFactorMemory], 0.01)
+		assert.Contains(t, factors.Nodes["querynode-1"], writeFactorGrowingSegmentsSize)
+	})
+
 	t.Run("test TimeTickDelayFactor factors", func(t *testing.T) {
 		meta := mockrootcoord.NewIMetaTable(t)
 		meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, mock.Anything).Return(nil, merr.ErrCollectionNotFound).Maybe()
@@ -889,6 +1088,18 @@ func TestQuotaCenter(t *testing.T) {
 		quotaCenter.resetAllCurrentRates()
 		quotaCenter.checkDiskQuota(nil)
 		checkCollectionLimiter(1)
+
+		// collection DiskQuota exceeded by the disk size reserved by the import jobs in progress
+		quotaCenter.dataCoordMetrics = &metricsinfo.DataCoordQuotaMetrics{
+			CollectionBinlogSize: map[int64]int64{
+				1: 20 * 1024 * 1024, 2: 20 * 1024 * 1024, 3: 20 * 1024 * 1024,
+			},
+			TotalImportReservedSize:      30 * 1024 * 1024,
+			CollectionImportReservedSize: map[int64]int64{2: 10 * 1024 * 1024, 3: 20 * 1024 * 1024},
+		}
+		quotaCenter.resetAllCurrentRates()
+		quotaCenter.checkDiskQuota(nil)
+		checkCollectionLimiter(1)
 		paramtable.Get().Save(Params.QuotaConfig.DiskQuotaPerCollection.Key, colQuotaBackup)
 
 		// loaded DiskQuota exceeded
@@ -931,6 +1142,58 @@ func TestQuotaCenter(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("test alias limiters", func(t *testing.T) {
+		pcm := proxyutil.NewMockProxyClientManager(t)
+		pcm.EXPECT().GetProxyCount().Return(2)
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListAliasesByID(mock.Anything, int64(1)).Return([]string{"a1", "a2"})
+		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, meta)
+		quotaCenter.collectionProps[1] = map[string]string{
+			common.AliasRateLimitKey("a1", common.CollectionSearchRateMaxKey): "10",
+			common.AliasRateLimitKey("a1", common.CollectionQueryRateMaxKey):  "100",
+			// the alias points to another collection, ignored
+			common.AliasRateLimitKey("a3", common.CollectionSearchRateMaxKey): "10",
+		}
+
+		collectionLimiter := rlinternal.NewRateLimiterNode(internalpb.RateScope_Collection)
+		collectionLimiter.GetLimiters().Insert(internalpb.RateType_DQLSearch, ratelimitutil.NewLimiter(100, 100))
+		collectionLimiter.GetLimiters().Insert(internalpb.RateType_DQLQuery, ratelimitutil.NewLimiter(50, 50))
+		aliasLimiters := quotaCenter.toAliasRequestLimiters(1, collectionLimiter)
+		assert.Len(t, aliasLimiters, 1)
+		assert.ElementsMatch(t, []*internalpb.Rate{
+			{Rt: internalpb.RateType_DQLSearch, R: 5},
+			// capped by the collection limit
+			{Rt: internalpb.RateType_DQLQuery, R: 25},
+		}, aliasLimiters["a1"].GetRates())
+
+		// no alias rate limit properties
+		quotaCenter.collectionProps[2] = map[string]string{common.CollectionSearchRateMaxKey: "10"}
+		assert.Nil(t, quotaCenter.toAliasRequestLimiters(2, collectionLimiter))
+	})
+
+	t.Run("test app tag limiters", func(t *testing.T) {
+		pcm := proxyutil.NewMockProxyClientManager(t)
+		pcm.EXPECT().GetProxyCount().Return(2)
+		meta := mockrootcoord.NewIMetaTable(t)
+		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, meta)
+
+		key := Params.QuotaConfig.AppTagRateLimits.Key
+		defer paramtable.Get().Reset(key)
+		paramtable.Get().Save(key, `{"etl": {"collection.searchRate.max.vps": "10", "collection.insertRate.max.mb": "2"}, "dashboard": {"unknown": "1"}}`)
+		appTagLimiters := quotaCenter.toAppTagRequestLimiters()
+		assert.Len(t, appTagLimiters, 1)
+		assert.ElementsMatch(t, []*internalpb.Rate{
+			{Rt: internalpb.RateType_DQLSearch, R: 5},
+			{Rt: internalpb.RateType_DMLInsert, R: 1024 * 1024},
+		}, appTagLimiters["etl"].GetRates())
+
+		// the invalid value is ignored
+		paramtable.Get().Save(key, `{"etl": 10}`)
+		assert.Nil(t, quotaCenter.toAppTagRequestLimiters())
+		paramtable.Get().Save(key, "")
+		assert.Nil(t, quotaCenter.toAppTagRequestLimiters())
+	})
+
 	t.Run("test recordMetrics", func(t *testing.T) {
 		meta := mockrootcoord.NewIMetaTable(t)
 		meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, mock.Anything).Return(nil, merr.ErrCollectionNotFound).Maybe()
@@ -1007,6 +1270,22 @@ func TestQuotaCenter(t *testing.T) {
 		}
 	})
 
+	t.Run("test diskAllowance with import reserved size", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, mock.Anything).Return(nil, merr.ErrCollectionNotFound).Maybe()
+		meta.EXPECT().ListAllAvailPartitions(mock.Anything).Return(nil).Maybe()
+		quotaCenter := NewQuotaCenter(pcm, dc, core.tsoAllocator, meta)
+		paramtable.Get().Save(Params.QuotaConfig.DiskQuotaPerCollection.Key, "20")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DiskQuotaPerCollection.Key)
+		quotaCenter.dataCoordMetrics = &metricsinfo.DataCoordQuotaMetrics{
+			CollectionBinlogSize:         map[int64]int64{1: 5 * 1024 * 1024},
+			CollectionImportReservedSize: map[int64]int64{1: 10 * 1024 * 1024, 2: 15 * 1024 * 1024},
+		}
+		assert.Equal(t, float64(5*1024*1024), quotaCenter.diskAllowance(1))
+		// the collection with the importing data only
+		assert.Equal(t, float64(5*1024*1024), quotaCenter.diskAllowance(2))
+	})
+
 	t.Run("test reset current rates", func(t *testing.T) {
 		meta := mockrootcoord.NewIMetaTable(t)
 		meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, mock.Anything).Return(nil, merr.ErrCollectionNotFound).Maybe()
@@ -1062,6 +1341,16 @@ func TestQuotaCenter(t *testing.T) {
 					Key:   common.CollectionSearchRateMaxKey,
 					Value: "5",
 				},
+
+				{
+					Key:   common.CollectionFlushRateMaxKey,
+					Value: "6",
+				},
+
+				{
+					Key:   common.CollectionCompactionRateMaxKey,
+					Value: "36",
+				},
 			},
 		}, nil)
 		quotaCenter.resetAllCurrentRates()
@@ -1071,6 +1360,8 @@ func TestQuotaCenter(t *testing.T) {
 		assert.Equal(t, getRate(limiters, internalpb.RateType_DMLBulkLoad), float64(3*1024*1024))
 		assert.Equal(t, getRate(limiters, internalpb.RateType_DQLQuery), float64(4))
 		assert.Equal(t, getRate(limiters, internalpb.RateType_DQLSearch), float64(5))
+		assert.InDelta(t, 0.1, getRate(limiters, internalpb.RateType_DDLFlush), 1e-9)
+		assert.InDelta(t, 0.01, getRate(limiters, internalpb.RateType_DDLCompaction), 1e-9)
 	})
 }
 
@@ -1454,6 +1745,49 @@ func TestResetAllCurrentRates(t *testing.T) {
 	assert.NotNil(t, collection)
 }
 
+func TestResetAllCurrentRatesWithPartitionDQLLimit(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	qc := mocks.NewMixCoord(t)
+	meta := mockrootcoord.NewIMetaTable(t)
+	pcm := proxyutil.NewMockProxyClientManager(t)
+	core, _ := NewCore(ctx, nil)
+	core.tsoAllocator = newMockTsoAllocator()
+
+	meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, int64(100)).Return(&model.Collection{
+		CollectionID: 100,
+		Properties: []*commonpb.KeyValuePair{
+			{Key: common.PartitionSearchRateMaxKey, Value: "10"},
+		},
+	}, nil)
+	meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, int64(200)).Return(&model.Collection{CollectionID: 200}, nil)
+	meta.EXPECT().ListAllAvailPartitions(mock.Anything).Return(map[int64]map[int64][]int64{
+		1: {
+			100: []int64{1000, 1001},
+			200: []int64{2000},
+		},
+	})
+
+	quotaCenter := NewQuotaCenter(pcm, qc, core.tsoAllocator, meta)
+	err := quotaCenter.resetAllCurrentRates()
+	assert.NoError(t, err)
+
+	for _, partitionID := range []int64{1000, 1001} {
+		partition := quotaCenter.rateLimiter.GetPartitionLimiters(1, 100, partitionID)
+		assert.NotNil(t, partition)
+		limiter, ok := partition.GetLimiters().Get(internalpb.RateType_DQLSearch)
+		assert.True(t, ok)
+		assert.Equal(t, Limit(10), limiter.Limit())
+		assert.True(t, limiter.HasUpdated())
+		limiter, ok = partition.GetLimiters().Get(internalpb.RateType_DQLQuery)
+		assert.True(t, ok)
+		assert.Equal(t, Inf, limiter.Limit())
+	}
+	// no partition limit for the collection without the properties.
+	assert.Nil(t, quotaCenter.rateLimiter.GetPartitionLimiters(1, 200, 2000))
+}
+
 func newQuotaCenterForTesting(t *testing.T, ctx context.Context, meta IMetaTable) *QuotaCenter {
 	qc := mocks.NewMixCoord(t)
 	pcm := proxyutil.NewMockProxyClientManager(t)
@@ -1519,6 +1853,71 @@ func newQuotaCenterForTesting(t *testing.T, ctx context.Context, meta IMetaTable
 	return quotaCenter
 }
 
+func TestQuotaCenterSmallSegmentInsertFactor(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	Params.Save(Params.QuotaConfig.DMLLimitEnabled.Key, "true")
+	defer Params.Reset(Params.QuotaConfig.DMLLimitEnabled.Key)
+	Params.Save(Params.QuotaConfig.DMLMaxInsertRatePerCollection.Key, "10")
+	defer Params.Reset(Params.QuotaConfig.DMLMaxInsertRatePerCollection.Key)
+	Params.Save(Params.QuotaConfig.DMLMaxDeleteRatePerCollection.Key, "10")
+	defer Params.Reset(Params.QuotaConfig.DMLMaxDeleteRatePerCollection.Key)
+
+	meta := mockrootcoord.NewIMetaTable(t)
+	meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, mock.Anything).Return(nil, merr.ErrCollectionNotFound).Maybe()
+	meta.EXPECT().GetDatabaseByID(mock.Anything, mock.Anything, mock.Anything).Return(nil, merr.ErrDatabaseNotFound).Maybe()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, meta)
+	assert.Empty(t, quotaCenter.getSmallSegmentInsertFactor())
+
+	quotaCenter.dataCoordMetrics.CollectionSmallSegmentPressure = map[int64]float64{10: 2, 20: 0.5}
+	assert.Equal(t, map[int64]float64{10: 0.5}, quotaCenter.getSmallSegmentInsertFactor())
+
+	getLimit := func(collectionID int64, rt internalpb.RateType) Limit {
+		dbID, _ := quotaCenter.collectionIDToDBID.Get(collectionID)
+		limiter, ok := quotaCenter.rateLimiter.GetCollectionLimiters(dbID, collectionID).GetLimiters().Get(rt)
+		assert.True(t, ok)
+		return limiter.Limit()
+	}
+	insertLimit := getLimit(10, internalpb.RateType_DMLInsert)
+	deleteLimit := getLimit(10, internalpb.RateType_DMLDelete)
+	assert.NotEqual(t, Inf, insertLimit)
+
+	// only the insert rate of the collection exceeding the soft limit is reduced, the proxy limits upserts by it too.
+	assert.NoError(t, quotaCenter.calculateWriteRates())
+	assert.Equal(t, insertLimit*0.5, getLimit(10, internalpb.RateType_DMLInsert))
+	assert.Equal(t, deleteLimit, getLimit(10, internalpb.RateType_DMLDelete))
+	assert.Equal(t, insertLimit, getLimit(20, internalpb.RateType_DMLInsert))
+}
+
+func TestQuotaCenterFrozenCollection(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	meta := mockrootcoord.NewIMetaTable(t)
+	meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, collectionID int64) (*model.Collection, error) {
+			if collectionID == 10 {
+				return &model.Collection{
+					CollectionID: 10,
+					Properties: []*commonpb.KeyValuePair{
+						{Key: common.CollectionFreezeTimestampKey, Value: "449563457651212289"},
+					},
+				}, nil
+			}
+			return nil, merr.ErrCollectionNotFound
+		}).Maybe()
+	meta.EXPECT().GetDatabaseByID(mock.Anything, mock.Anything, mock.Anything).Return(nil, merr.ErrDatabaseNotFound).Maybe()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, meta)
+	assert.Equal(t, []int64{10}, quotaCenter.getFrozenCollections())
+
+	assert.NoError(t, quotaCenter.calculateWriteRates())
+	states := quotaCenter.rateLimiter.GetCollectionLimiters(1, 10).GetQuotaStates()
+	stateInfo, ok := states.Get(milvuspb.QuotaState_DenyToWrite)
+	assert.True(t, ok)
+	assert.Equal(t, commonpb.ErrorCode_ForceDeny, stateInfo.ErrorCode)
+	_, ok = quotaCenter.rateLimiter.GetCollectionLimiters(2, 20).GetQuotaStates().Get(milvuspb.QuotaState_DenyToWrite)
+	assert.False(t, ok)
+}
+
 func TestCheckDiskQuota(t *testing.T) {
 	paramtable.Init()
 	ctx := context.Background()
@@ -1607,7 +2006,8 @@ func TestCheckDiskQuota(t *testing.T) {
 			checkRate(quotaCenter.rateLimiter.GetCollectionLimiters(1, 10), 0)
 			checkRate(quotaCenter.rateLimiter.GetCollectionLimiters(2, 20), configQuotaValue)
 			checkRate(quotaCenter.rateLimiter.GetCollectionLimiters(2, 30), configQuotaValue)
-			checkRate(quotaCenter.rateLimiter.GetCollectionLimiters(4, 40), configQuotaValue)
+			// the disk quota of db4 is applied to its collections
+			checkRate(quotaCenter.rateLimiter.GetCollectionLimiters(4, 40), 0)
 			checkRate(quotaCenter.rateLimiter.GetPartitionLimiters(1, 10, 100), 0)
 			checkRate(quotaCenter.rateLimiter.GetPartitionLimiters(1, 10, 101), configQuotaValue)
 			checkRate(quotaCenter.rateLimiter.GetPartitionLimiters(2, 20, 200), configQuotaValue)
@@ -1616,6 +2016,35 @@ func TestCheckDiskQuota(t *testing.T) {
 		}
 	})
 
+	t.Run("collection disk quota precedence", func(t *testing.T) {
+		Params.Save(Params.QuotaConfig.DiskQuotaPerCollection.Key, "10")
+		defer Params.Reset(Params.QuotaConfig.DiskQuotaPerCollection.Key)
+
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetDatabaseByID(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, i int64, u uint64) (*model.Database, error) {
+				if i == 2 {
+					return &model.Database{
+						ID:         2,
+						Name:       "db2",
+						Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseDiskQuotaKey, Value: "5"}},
+					}, nil
+				}
+				return nil, merr.WrapErrDatabaseNotFound(i)
+			}).Maybe()
+		quotaCenter := newQuotaCenterForTesting(t, ctx, meta)
+		quotaCenter.collectionProps[10] = map[string]string{common.CollectionDiskQuotaMBKey: "20", common.CollectionDiskQuotaKey: "1"}
+		quotaCenter.collectionProps[20] = map[string]string{}
+		quotaCenter.collectionProps[30] = map[string]string{common.CollectionDiskQuotaKey: "8"}
+		quotaCenter.collectionProps[40] = map[string]string{common.CollectionDiskQuotaMBKey: "invalid"}
+
+		// collection property > database property > cluster config
+		assert.EqualValues(t, 20*1024*1024, quotaCenter.getCollectionDiskQuota(10))
+		assert.EqualValues(t, 5*1024*1024, quotaCenter.getCollectionDiskQuota(20))
+		assert.EqualValues(t, 8*1024*1024, quotaCenter.getCollectionDiskQuota(30))
+		assert.EqualValues(t, 10*1024*1024, quotaCenter.getCollectionDiskQuota(40))
+	})
+
 	t.Run("get quota center metrics", func(t *testing.T) {
 		meta := mockrootcoord.NewIMetaTable(t)
 		quotaCenter := newQuotaCenterForTesting(t, ctx, meta)
@@ -1655,6 +2084,46 @@ func TestTORequestLimiter(t *testing.T) {
 	assert.Equal(t, commonpb.ErrorCode_ForceDeny, proxyLimit.Codes[0])
 }
 
+func TestDDLLimitStates(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	qc := mocks.NewMixCoord(t)
+	meta := mockrootcoord.NewIMetaTable(t)
+	pcm := proxyutil.NewMockProxyClientManager(t)
+	core, _ := NewCore(ctx, nil)
+	core.tsoAllocator = newMockTsoAllocator()
+	quotaCenter := NewQuotaCenter(pcm, qc, core.tsoAllocator, meta)
+
+	paramtable.Get().Save(Params.QuotaConfig.MaxDatabaseNum.Key, "2")
+	defer paramtable.Get().Reset(Params.QuotaConfig.MaxDatabaseNum.Key)
+	paramtable.Get().Save(Params.QuotaConfig.MaxCollectionNumPerDB.Key, "2")
+	defer paramtable.Get().Reset(Params.QuotaConfig.MaxCollectionNumPerDB.Key)
+
+	meta.EXPECT().ListDatabases(mock.Anything, mock.Anything).Return([]*model.Database{
+		{ID: 1, Name: "default"},
+		{ID: 2, Name: "db2"},
+		{ID: 3, Name: "db3", Properties: []*commonpb.KeyValuePair{
+			{Key: common.DatabaseMaxCollectionsKey, Value: "3"},
+		}},
+	}, nil).Once()
+	meta.EXPECT().ListAllAvailCollections(mock.Anything).Return(map[int64][]int64{
+		1: {100},
+		2: {200, 201},
+		3: {300, 301},
+	}).Once()
+	quotaCenter.calculateDDLLimitStates()
+
+	assert.Nil(t, quotaCenter.rateLimiter.GetDatabaseLimiters(1))
+	assert.Nil(t, quotaCenter.rateLimiter.GetDatabaseLimiters(3))
+	dbLimiters := quotaCenter.rateLimiter.GetDatabaseLimiters(2)
+	assert.True(t, dbLimiters.GetQuotaStates().Contain(milvuspb.QuotaState_DenyToDDL))
+	// the rates are not changed, so that the collections could still be dropped
+	limiter, ok := dbLimiters.GetLimiters().Get(internalpb.RateType_DDLCollection)
+	assert.True(t, ok)
+	assert.NotEqualValues(t, 0.0, limiter.Limit())
+	assert.True(t, quotaCenter.rateLimiter.GetRootLimiters().GetQuotaStates().Contain(milvuspb.QuotaState_DenyToDDL))
+}
+
 func TestDatabaseForceDenyDDL(t *testing.T) {
 	getQuotaCenter := func() (*QuotaCenter, *mockrootcoord.IMetaTable) {
 		ctx := context.Background()
@@ -1796,3 +2265,41 @@ func TestDatabaseForceDenyDDL(t *testing.T) {
 		}
 	})
 }
+
+func TestProxyLimiterConsistency(t *testing.T) {
+	paramtable.Init()
+	pcm := proxyutil.NewMockProxyClientManager(t)
+	meta := mockrootcoord.NewIMetaTable(t)
+	quotaCenter := NewQuotaCenter(pcm, mocks.NewMixCoord(t), newMockTsoAllocator(), meta)
+	quotaCenter.resetAllCurrentRates()
+
+	// nothing is pushed yet
+	quotaCenter.proxyMetrics = map[UniqueID]*metricsinfo.ProxyQuotaMetrics{
+		1: {Limiter: &metricsinfo.ProxyLimiterSnapshot{}},
+	}
+	quotaCenter.checkProxyLimiterConsistency()
+
+	var pushed []*proxypb.SetRatesRequest
+	pcm.EXPECT().SetRates(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *proxypb.SetRatesRequest) error {
+		pushed = append(pushed, req)
+		return nil
+	})
+	assert.NoError(t, quotaCenter.sendRatesToProxy())
+	assert.Len(t, pushed, 1)
+	digest := rlinternal.LimiterDigest(pushed[0].GetRootLimiter())
+	assert.Equal(t, digest, quotaCenter.lastPushedDigest)
+
+	// the proxies enforcing the last pushed limits or not reporting the summary are skipped
+	quotaCenter.proxyMetrics = map[UniqueID]*metricsinfo.ProxyQuotaMetrics{
+		1: {Limiter: &metricsinfo.ProxyLimiterSnapshot{Digest: digest}},
+		2: {},
+	}
+	quotaCenter.checkProxyLimiterConsistency()
+	assert.Len(t, pushed, 1)
+
+	// the restarted proxy missing the limits gets the last pushed rates again
+	quotaCenter.proxyMetrics[3] = &metricsinfo.ProxyQuotaMetrics{Limiter: &metricsinfo.ProxyLimiterSnapshot{}}
+	quotaCenter.checkProxyLimiterConsistency()
+	assert.Len(t, pushed, 2)
+	assert.Same(t, pushed[0], pushed[1])
+}
@@ -0,0 +1,74 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/config"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const quotaConfigPrefix = "quotaAndLimits."
+
+// watchQuotaConfigHealth validates the quota configs on start, and validates them again once any of them is updated.
+func (q *QuotaCenter) watchQuotaConfigHealth() {
+	q.validateQuotaConfigs(time.Now())
+	paramtable.Get().WatchKeyPrefix(quotaConfigPrefix, config.NewHandler("quotaCenter.configHealth", func(event *config.Event) {
+		q.validateQuotaConfigs(time.Now())
+	}))
+}
+
+// validateQuotaConfigs records the invalid combinations of the quota configs, which are normalized by the param table,
+// the newly found ones are warned so that they are noticed instead of being discovered as weird limits.
+func (q *QuotaCenter) validateQuotaConfigs(now time.Time) {
+	issues := Params.QuotaConfig.Validate()
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	reported := make(map[string]struct{})
+	if q.configHealth != nil {
+		for _, issue := range q.configHealth.Issues {
+			reported[quotaConfigIssueKey(issue)] = struct{}{}
+		}
+	}
+	for _, issue := range issues {
+		if _, ok := reported[quotaConfigIssueKey(issue)]; ok {
+			continue
+		}
+		mlog.Warn(q.ctx, "invalid quota config, the low value is greater than the high value, use the normalized values",
+			mlog.String("lowKey", issue.LowKey),
+			mlog.String("lowValue", issue.LowValue),
+			mlog.String("highKey", issue.HighKey),
+			mlog.String("highValue", issue.HighValue),
+			mlog.String("effectiveLow", issue.EffectiveLow),
+			mlog.String("effectiveHigh", issue.EffectiveHigh))
+	}
+	if len(issues) == 0 && len(reported) > 0 {
+		mlog.Info(q.ctx, "invalid quota configs are fixed")
+	}
+	q.configHealth = &metricsinfo.QuotaConfigHealth{
+		Timestamp: now.Unix(),
+		Issues:    issues,
+	}
+}
+
+func quotaConfigIssueKey(issue *metricsinfo.QuotaConfigIssue) string {
+	return issue.LowKey + "=" + issue.LowValue + "," + issue.HighKey + "=" + issue.HighValue
+}
@@ -0,0 +1,70 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestQuotaCenterConfigHealth(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, mockrootcoord.NewIMetaTable(t))
+
+	getConfigHealth := func() *metricsinfo.QuotaConfigHealth {
+		resp := quotaCenter.getQuotaMetrics()
+		metrics := &metricsinfo.QuotaCenterMetrics{}
+		assert.NoError(t, metricsinfo.UnmarshalComponentInfos(resp.GetMetricsInfo(), metrics))
+		return metrics.ConfigHealth
+	}
+
+	quotaCenter.validateQuotaConfigs(time.Unix(1000, 0))
+	health := getConfigHealth()
+	assert.NotNil(t, health)
+	assert.Equal(t, int64(1000), health.Timestamp)
+	assert.Empty(t, health.Issues)
+
+	pt := paramtable.Get()
+	pt.Save(pt.QuotaConfig.DeleteBufferRowCountProtectionEnabled.Key, "true")
+	pt.Save(pt.QuotaConfig.DeleteBufferRowCountLowWaterLevel.Key, "1000")
+	pt.Save(pt.QuotaConfig.DeleteBufferRowCountHighWaterLevel.Key, "100")
+	defer pt.Reset(pt.QuotaConfig.DeleteBufferRowCountProtectionEnabled.Key)
+	defer pt.Reset(pt.QuotaConfig.DeleteBufferRowCountLowWaterLevel.Key)
+	defer pt.Reset(pt.QuotaConfig.DeleteBufferRowCountHighWaterLevel.Key)
+
+	quotaCenter.validateQuotaConfigs(time.Unix(2000, 0))
+	health = getConfigHealth()
+	assert.Equal(t, int64(2000), health.Timestamp)
+	assert.Len(t, health.Issues, 1)
+	issue := health.Issues[0]
+	assert.Equal(t, pt.QuotaConfig.DeleteBufferRowCountLowWaterLevel.Key, issue.LowKey)
+	assert.Equal(t, "1000", issue.LowValue)
+	assert.Equal(t, "100", issue.HighValue)
+	assert.Equal(t, "65536", issue.EffectiveHigh)
+
+	pt.Save(pt.QuotaConfig.DeleteBufferRowCountHighWaterLevel.Key, "10000")
+	quotaCenter.validateQuotaConfigs(time.Unix(3000, 0))
+	assert.Empty(t, getConfigHealth().Issues)
+}
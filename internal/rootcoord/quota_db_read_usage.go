@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"math"
+
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+)
+
+// calculateDatabaseUsageReadRates limits the dql rates of the databases by their recent usage. The recent dql rates
+// of a database are the smoothed sum of the real dql rates of its readable collections, and the limits are the recent
+// rates multiplied by the multiplier, bounded by the min rate and the static database limits.
+// A database reading steadily near its limits raises them round by round, while a sudden burst is bounded.
+func (q *QuotaCenter) calculateDatabaseUsageReadRates(deniedDatabaseIDs map[int64]struct{}) {
+	if !Params.QuotaConfig.DQLUsageBasedDBLimitEnabled.GetAsBool() {
+		q.dbReadUsages = make(map[int64]map[internalpb.RateType]float64)
+		return
+	}
+	multiplier := Params.QuotaConfig.DQLUsageBasedDBLimitMultiplier.GetAsFloat()
+	smoothing := Params.QuotaConfig.DQLUsageBasedDBLimitSmoothing.GetAsFloat()
+	minRate := Params.QuotaConfig.DQLUsageBasedDBLimitMinRate.GetAsFloat()
+
+	collectionRates := make(map[internalpb.RateType]map[int64]float64, dqlRateTypes.Len())
+	for rt := range dqlRateTypes {
+		collectionRates[rt] = q.getProxyCollectionRates(rt.String())
+	}
+
+	for dbID := range q.dbReadUsages {
+		if _, ok := q.readableCollections[dbID]; !ok {
+			// the database is dropped or has no loaded collection.
+			delete(q.dbReadUsages, dbID)
+		}
+	}
+	for dbID, collections := range q.readableCollections {
+		if _, ok := deniedDatabaseIDs[dbID]; ok || len(collections) == 0 {
+			continue
+		}
+		dbLimiter := q.rateLimiter.GetDatabaseLimiters(dbID)
+		if dbLimiter == nil {
+			continue
+		}
+		usages, ok := q.dbReadUsages[dbID]
+		if !ok {
+			usages = make(map[internalpb.RateType]float64, dqlRateTypes.Len())
+			q.dbReadUsages[dbID] = usages
+		}
+		for rt := range dqlRateTypes {
+			var recentRate float64
+			for collectionID := range collections {
+				recentRate += collectionRates[rt][collectionID]
+			}
+			if usage, ok := usages[rt]; ok {
+				recentRate = smoothing*recentRate + (1-smoothing)*usage
+			}
+			usages[rt] = recentRate
+
+			v, ok := dbLimiter.GetLimiters().Get(rt)
+			if !ok {
+				continue
+			}
+			if newLimit := Limit(math.Max(recentRate*multiplier, minRate)); newLimit < v.Limit() {
+				v.SetLimit(newLimit)
+				mlog.RatedInfo(q.ctx, rate.Limit(10), "QuotaCenter limit the dql rates of the database by the recent usage",
+					mlog.Int64("dbID", dbID),
+					mlog.String("rateType", rt.String()),
+					mlog.Float64("recentRate", recentRate),
+					mlog.Float64("limit", float64(newLimit)))
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/ratelimitutil"
+)
+
+func TestQuotaCenterDatabaseUsageReadRates(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, mockrootcoord.NewIMetaTable(t))
+	quotaCenter.dbs.Insert("db1", 1)
+	quotaCenter.collections.Insert(FormatCollectionKey(1, "coll10"), 10)
+	quotaCenter.collections.Insert(FormatCollectionKey(1, "coll11"), 11)
+	quotaCenter.readableCollections = map[int64]map[int64][]int64{1: {10: {100}, 11: {110}}}
+
+	setProxyRates := func(rate10, rate11 float64) {
+		quotaCenter.proxyMetrics = map[UniqueID]*metricsinfo.ProxyQuotaMetrics{
+			1: {Rms: []metricsinfo.RateMetric{
+				{Label: ratelimitutil.FormatSubLabel(internalpb.RateType_DQLSearch.String(),
+					ratelimitutil.GetCollectionSubLabel("db1", "coll10")), Rate: rate10},
+				{Label: ratelimitutil.FormatSubLabel(internalpb.RateType_DQLSearch.String(),
+					ratelimitutil.GetCollectionSubLabel("db1", "coll11")), Rate: rate11},
+			}},
+		}
+	}
+	dbLimit := func(rt internalpb.RateType) float64 {
+		limiter, ok := quotaCenter.rateLimiter.GetDatabaseLimiters(1).GetLimiters().Get(rt)
+		assert.True(t, ok)
+		return float64(limiter.Limit())
+	}
+	resetLimits := func() {
+		for rt := range dqlRateTypes {
+			limiter, _ := quotaCenter.rateLimiter.GetDatabaseLimiters(1).GetLimiters().Get(rt)
+			limiter.SetLimit(Inf)
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		resetLimits()
+		setProxyRates(100, 50)
+		quotaCenter.calculateDatabaseUsageReadRates(nil)
+		assert.Empty(t, quotaCenter.dbReadUsages)
+		assert.Equal(t, float64(Inf), dbLimit(internalpb.RateType_DQLSearch))
+	})
+
+	paramtable.Get().Save(Params.QuotaConfig.DQLUsageBasedDBLimitEnabled.Key, "true")
+	paramtable.Get().Save(Params.QuotaConfig.DQLUsageBasedDBLimitMultiplier.Key, "2")
+	paramtable.Get().Save(Params.QuotaConfig.DQLUsageBasedDBLimitSmoothing.Key, "0.5")
+	paramtable.Get().Save(Params.QuotaConfig.DQLUsageBasedDBLimitMinRate.Key, "10")
+	defer paramtable.Get().Reset(Params.QuotaConfig.DQLUsageBasedDBLimitEnabled.Key)
+	defer paramtable.Get().Reset(Params.QuotaConfig.DQLUsageBasedDBLimitMultiplier.Key)
+	defer paramtable.Get().Reset(Params.QuotaConfig.DQLUsageBasedDBLimitSmoothing.Key)
+	defer paramtable.Get().Reset(Params.QuotaConfig.DQLUsageBasedDBLimitMinRate.Key)
+
+	t.Run("follow the usage", func(t *testing.T) {
+		resetLimits()
+		setProxyRates(100, 50)
+		quotaCenter.calculateDatabaseUsageReadRates(nil)
+		assert.Equal(t, 150.0, quotaCenter.dbReadUsages[1][internalpb.RateType_DQLSearch])
+		assert.Equal(t, 300.0, dbLimit(internalpb.RateType_DQLSearch))
+		// the idle rate types are limited to the min rate.
+		assert.Equal(t, 10.0, dbLimit(internalpb.RateType_DQLQuery))
+
+		// the burst is smoothed by the recent usage.
+		resetLimits()
+		setProxyRates(1000, 50)
+		quotaCenter.calculateDatabaseUsageReadRates(nil)
+		assert.Equal(t, 600.0, quotaCenter.dbReadUsages[1][internalpb.RateType_DQLSearch])
+		assert.Equal(t, 1200.0, dbLimit(internalpb.RateType_DQLSearch))
+
+		resetLimits()
+		setProxyRates(0, 0)
+		quotaCenter.calculateDatabaseUsageReadRates(nil)
+		assert.Equal(t, 300.0, quotaCenter.dbReadUsages[1][internalpb.RateType_DQLSearch])
+		assert.Equal(t, 600.0, dbLimit(internalpb.RateType_DQLSearch))
+	})
+
+	t.Run("static limit", func(t *testing.T) {
+		resetLimits()
+		limiter, _ := quotaCenter.rateLimiter.GetDatabaseLimiters(1).GetLimiters().Get(internalpb.RateType_DQLSearch)
+		limiter.SetLimit(100)
+		quotaCenter.calculateDatabaseUsageReadRates(nil)
+		assert.Equal(t, 100.0, dbLimit(internalpb.RateType_DQLSearch))
+	})
+
+	t.Run("denied database", func(t *testing.T) {
+		resetLimits()
+		quotaCenter.calculateDatabaseUsageReadRates(map[int64]struct{}{1: {}})
+		assert.Equal(t, float64(Inf), dbLimit(internalpb.RateType_DQLSearch))
+	})
+
+	t.Run("database not readable", func(t *testing.T) {
+		quotaCenter.readableCollections = map[int64]map[int64][]int64{}
+		quotaCenter.calculateDatabaseUsageReadRates(nil)
+		assert.Empty(t, quotaCenter.dbReadUsages)
+	})
+}
@@ -0,0 +1,217 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/contextutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// collectionQuotaPropertyKeys are the collection quota properties bounded by the collection level quota configuration.
+var collectionQuotaPropertyKeys = typeutil.NewSet(
+	common.CollectionInsertRateMaxKey,
+	common.CollectionInsertRateMinKey,
+	common.CollectionDeleteRateMaxKey,
+	common.CollectionDeleteRateMinKey,
+	common.CollectionBulkLoadRateMaxKey,
+	common.CollectionBulkLoadRateMinKey,
+	common.CollectionQueryRateMaxKey,
+	common.CollectionQueryRateMinKey,
+	common.CollectionSearchRateMaxKey,
+	common.CollectionSearchRateMinKey,
+	common.CollectionGetRateMaxKey,
+	common.CollectionGetRateMinKey,
+	common.CollectionDiskQuotaKey,
+	common.PartitionQueryRateMaxKey,
+	common.PartitionSearchRateMaxKey,
+	common.PartitionGetRateMaxKey,
+	common.CollectionFlushRateMaxKey,
+	common.CollectionCompactionRateMaxKey,
+)
+
+// databaseDenyPropertyKeys are the database properties denying the requests, the database owners
+// could deny the requests but could not lift the deny.
+var databaseDenyPropertyKeys = typeutil.NewSet(
+	common.DatabaseForceDenyWritingKey,
+	common.DatabaseForceDenyReadingKey,
+	common.DatabaseForceDenyDDLKey,
+	common.DatabaseForceDenyCollectionDDLKey,
+	common.DatabaseForceDenyPartitionDDLKey,
+	common.DatabaseForceDenyIndexDDLKey,
+	common.DatabaseForceDenyFlushDDLKey,
+	common.DatabaseForceDenyCompactionDDLKey,
+)
+
+// databaseOwnerPrivileges are the privileges granted on a database making the owner role the owner of the database.
+var databaseOwnerPrivileges = typeutil.NewSet(
+	util.MetaStore2API(commonpb.ObjectPrivilege_PrivilegeGroupDatabaseAdmin.String()),
+	util.MetaStore2API(commonpb.ObjectPrivilege_PrivilegeAll.String()),
+	util.AnyWord,
+)
+
+// getQuotaPropertyBound returns the bound of the quota property defined by the cluster admins in the quota configuration,
+// and the property value converted to the unit of the bound. ok is false if the key is not a bounded quota property.
+func getQuotaPropertyBound(key string, value float64) (converted float64, bound float64, ok bool) {
+	megaBytes2Bytes := func(v float64) float64 {
+		return v * 1024.0 * 1024.0
+	}
+	switch {
+	case key == common.DatabaseDiskQuotaKey:
+		return megaBytes2Bytes(value), Params.QuotaConfig.DiskQuotaPerDB.GetAsFloat(), true
+	case key == common.DatabaseMaxCollectionsKey:
+		return value, Params.QuotaConfig.MaxCollectionNumPerDB.GetAsFloat(), true
+	case key == common.CollectionDiskQuotaMBKey:
+		return megaBytes2Bytes(value), Params.QuotaConfig.DiskQuotaPerCollection.GetAsFloat(), true
+	case collectionQuotaPropertyKeys.Contain(key):
+		bound = getCollectionRateLimitConfigDefaultValue(key)
+		// the negative value falls back to the quota configuration, which is the bound itself.
+		converted = getRateLimitConfig(map[string]string{key: strconv.FormatFloat(value, 'f', -1, 64)}, key, bound)
+		return converted, bound, true
+	default:
+		return 0, 0, false
+	}
+}
+
+func isDelegatedQuotaProperty(key string) bool {
+	_, _, ok := getQuotaPropertyBound(key, 0)
+	return ok || databaseDenyPropertyKeys.Contain(key)
+}
+
+// checkDelegatedQuotaProperty checks the quota property set by a database owner is within the bound.
+func checkDelegatedQuotaProperty(key string, value string) error {
+	if databaseDenyPropertyKeys.Contain(key) {
+		deny, err := strconv.ParseBool(strings.ToLower(value))
+		if err != nil {
+			return merr.WrapErrParameterInvalidMsg("invalid value %s of property %s", value, key)
+		}
+		if !deny {
+			return merr.WrapErrPrivilegeNotPermitted("the database owner could not lift the deny of property %s", key)
+		}
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return merr.WrapErrParameterInvalidMsg("invalid value %s of property %s", value, key)
+	}
+	converted, bound, _ := getQuotaPropertyBound(key, v)
+	if converted > bound {
+		return merr.WrapErrPrivilegeNotPermitted("the database owner could not raise property %s to %s over the bound of the cluster quota configuration", key, value)
+	}
+	return nil
+}
+
+// getQuotaDelegationRole returns whether the user is a cluster admin or an owner of the database.
+// The user is an owner if one of its owner roles is granted an owner privilege on the database,
+// the other privileges granted on the database, e.g. reading a collection, don't make the ownership.
+func (c *Core) getQuotaDelegationRole(ctx context.Context, user string, dbName string) (isAdmin bool, isOwner bool, err error) {
+	userRoles, err := c.meta.SelectUser(ctx, "", &milvuspb.UserEntity{Name: user}, true)
+	if err != nil {
+		return false, false, err
+	}
+	if len(userRoles) == 0 {
+		return false, false, nil
+	}
+	ownerRoles := typeutil.NewSet(Params.QuotaConfig.DelegationOwnerRoles.GetAsStrings()...)
+	for _, role := range userRoles[0].GetRoles() {
+		if role.GetName() == util.RoleAdmin {
+			return true, false, nil
+		}
+		if !ownerRoles.Contain(role.GetName()) {
+			continue
+		}
+		grants, err := c.meta.SelectGrant(ctx, "", &milvuspb.GrantEntity{Role: role, DbName: dbName})
+		if err != nil {
+			return false, false, err
+		}
+		for _, grant := range grants {
+			if databaseOwnerPrivileges.Contain(grant.GetGrantor().GetPrivilege().GetName()) {
+				isOwner = true
+			}
+		}
+	}
+	return false, isOwner, nil
+}
+
+// checkQuotaPropertyDelegation enforces the delegated quota administration on the quota properties set by
+// CreateDatabase, CreateCollection, AlterDatabase and AlterCollection. The cluster admins set them freely, the database
+// owners could only lower the limits under the bounds of the quota configuration, and the other users could not set them.
+func (c *Core) checkQuotaPropertyDelegation(ctx context.Context, dbName string, properties []*commonpb.KeyValuePair, deleteKeys []string) error {
+	if !Params.QuotaConfig.DelegationEnabled.GetAsBool() || !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return nil
+	}
+	quotaProperties := make([]*commonpb.KeyValuePair, 0)
+	for _, property := range properties {
+		if isDelegatedQuotaProperty(property.GetKey()) {
+			quotaProperties = append(quotaProperties, property)
+		}
+	}
+	quotaDeleteKeys := make([]string, 0)
+	for _, key := range deleteKeys {
+		if isDelegatedQuotaProperty(key) {
+			quotaDeleteKeys = append(quotaDeleteKeys, key)
+		}
+	}
+	if len(quotaProperties) == 0 && len(quotaDeleteKeys) == 0 {
+		return nil
+	}
+
+	user, err := contextutil.GetCurUserFromContext(ctx)
+	if err != nil || (user == util.UserRoot && !Params.CommonCfg.RootShouldBindRole.GetAsBool()) {
+		// the requests inside the cluster and from the root user are not restricted.
+		return nil
+	}
+	isAdmin, isOwner, err := c.getQuotaDelegationRole(ctx, user, dbName)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		return nil
+	}
+	if !isOwner {
+		return merr.WrapErrPrivilegeNotPermitted("only the cluster admins and the database owners could set the quota properties of database %s", dbName)
+	}
+
+	for _, property := range quotaProperties {
+		if err := checkDelegatedQuotaProperty(property.GetKey(), property.GetValue()); err != nil {
+			mlog.Info(ctx, "reject the quota property altered by the database owner",
+				mlog.String("user", user),
+				mlog.String("dbName", dbName),
+				mlog.String("key", property.GetKey()),
+				mlog.String("value", property.GetValue()),
+				mlog.Err(err))
+			return err
+		}
+	}
+	for _, key := range quotaDeleteKeys {
+		// deleting a limit falls back to the quota configuration, but deleting a deny lifts it.
+		if databaseDenyPropertyKeys.Contain(key) {
+			return merr.WrapErrPrivilegeNotPermitted("the database owner could not lift the deny of property %s", key)
+		}
+	}
+	return nil
+}
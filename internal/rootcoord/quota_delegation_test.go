@@ -0,0 +1,151 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestCheckQuotaPropertyDelegation(t *testing.T) {
+	paramtable.Init()
+	meta := mockrootcoord.NewIMetaTable(t)
+	c := newTestCore(withMeta(meta))
+
+	meta.EXPECT().SelectUser(mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, tenant string, entity *milvuspb.UserEntity, includeRoleInfo bool) ([]*milvuspb.UserResult, error) {
+			roles := map[string]string{"admin_user": "admin", "owner": "db_owner", "reader": "db_reader"}
+			return []*milvuspb.UserResult{{
+				User:  entity,
+				Roles: []*milvuspb.RoleEntity{{Name: roles[entity.GetName()]}},
+			}}, nil
+		}).Maybe()
+	meta.EXPECT().SelectGrant(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, tenant string, entity *milvuspb.GrantEntity) ([]*milvuspb.GrantEntity, error) {
+			grant := func(privilege string) []*milvuspb.GrantEntity {
+				return []*milvuspb.GrantEntity{{
+					Role:       entity.GetRole(),
+					DbName:     entity.GetDbName(),
+					Object:     &milvuspb.ObjectEntity{Name: commonpb.ObjectType_Global.String()},
+					Grantor:    &milvuspb.GrantorEntity{Privilege: &milvuspb.PrivilegeEntity{Name: privilege}},
+					ObjectName: util.AnyWord,
+				}}
+			}
+			// the owner role is granted the database admin privilege on db1, the other grants are not the ownership.
+			if entity.GetDbName() == "db1" && entity.GetRole().GetName() == "db_owner" {
+				return grant(util.MetaStore2API(commonpb.ObjectPrivilege_PrivilegeGroupDatabaseAdmin.String())), nil
+			}
+			return grant(util.MetaStore2API(commonpb.ObjectPrivilege_PrivilegeQuery.String())), nil
+		}).Maybe()
+
+	kvs := func(key, value string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: key, Value: value}}
+	}
+	ownerCtx := GetContext(context.Background(), "owner:123456")
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, c.checkQuotaPropertyDelegation(ownerCtx, "db2", kvs(common.CollectionInsertRateMaxKey, "100"), nil))
+	})
+
+	paramtable.Get().Save(Params.QuotaConfig.DelegationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.QuotaConfig.DelegationEnabled.Key)
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+	paramtable.Get().Save(Params.QuotaConfig.DelegationOwnerRoles.Key, "db_owner,db_reader")
+	defer paramtable.Get().Reset(Params.QuotaConfig.DelegationOwnerRoles.Key)
+	paramtable.Get().Save(Params.QuotaConfig.DMLLimitEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.QuotaConfig.DMLLimitEnabled.Key)
+	paramtable.Get().Save(Params.QuotaConfig.DMLMaxInsertRatePerCollection.Key, "10")
+	defer paramtable.Get().Reset(Params.QuotaConfig.DMLMaxInsertRatePerCollection.Key)
+	paramtable.Get().Save(Params.QuotaConfig.MaxCollectionNumPerDB.Key, "100")
+	defer paramtable.Get().Reset(Params.QuotaConfig.MaxCollectionNumPerDB.Key)
+
+	t.Run("not restricted", func(t *testing.T) {
+		// the properties other than the quota ones.
+		assert.NoError(t, c.checkQuotaPropertyDelegation(GetContext(context.Background(), "reader:123456"), "db1",
+			kvs(common.CollectionTTLConfigKey, "100"), nil))
+		// the requests inside the cluster, from the root and the cluster admins.
+		assert.NoError(t, c.checkQuotaPropertyDelegation(context.Background(), "db1", kvs(common.CollectionInsertRateMaxKey, "100"), nil))
+		assert.NoError(t, c.checkQuotaPropertyDelegation(GetContext(context.Background(), "root:123456"), "db1",
+			kvs(common.CollectionInsertRateMaxKey, "100"), nil))
+		assert.NoError(t, c.checkQuotaPropertyDelegation(GetContext(context.Background(), "admin_user:123456"), "db1",
+			kvs(common.CollectionInsertRateMaxKey, "100"), nil))
+	})
+
+	t.Run("not owner", func(t *testing.T) {
+		// the role is granted on the database, but not the owner privilege.
+		err := c.checkQuotaPropertyDelegation(GetContext(context.Background(), "reader:123456"), "db1", kvs(common.CollectionInsertRateMaxKey, "1"), nil)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+		// the owner role is not granted the owner privilege on the database.
+		err = c.checkQuotaPropertyDelegation(ownerCtx, "db2", kvs(common.CollectionInsertRateMaxKey, "1"), nil)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+	})
+
+	t.Run("owner within bounds", func(t *testing.T) {
+		assert.NoError(t, c.checkQuotaPropertyDelegation(ownerCtx, "db1", kvs(common.CollectionInsertRateMaxKey, "5"), nil))
+		assert.NoError(t, c.checkQuotaPropertyDelegation(ownerCtx, "db1", kvs(common.CollectionInsertRateMaxKey, "10"), nil))
+		assert.NoError(t, c.checkQuotaPropertyDelegation(ownerCtx, "db1", kvs(common.CollectionInsertRateMaxKey, "-1"), nil))
+		assert.NoError(t, c.checkQuotaPropertyDelegation(ownerCtx, "db1", kvs(common.DatabaseMaxCollectionsKey, "50"), nil))
+		assert.NoError(t, c.checkQuotaPropertyDelegation(ownerCtx, "db1", kvs(common.DatabaseForceDenyWritingKey, "true"), nil))
+		assert.NoError(t, c.checkQuotaPropertyDelegation(ownerCtx, "db1", nil, []string{common.CollectionInsertRateMaxKey}))
+	})
+
+	t.Run("owner over bounds", func(t *testing.T) {
+		err := c.checkQuotaPropertyDelegation(ownerCtx, "db1", kvs(common.CollectionInsertRateMaxKey, "11"), nil)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+		err = c.checkQuotaPropertyDelegation(ownerCtx, "db1", kvs(common.DatabaseMaxCollectionsKey, "101"), nil)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+		err = c.checkQuotaPropertyDelegation(ownerCtx, "db1", kvs(common.DatabaseForceDenyWritingKey, "false"), nil)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+		err = c.checkQuotaPropertyDelegation(ownerCtx, "db1", nil, []string{common.DatabaseForceDenyWritingKey})
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+		err = c.checkQuotaPropertyDelegation(ownerCtx, "db1", kvs(common.CollectionInsertRateMaxKey, "abc"), nil)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("create paths", func(t *testing.T) {
+		readerCtx := GetContext(context.Background(), "reader:123456")
+		err := c.broadcastCreateDatabase(readerCtx, &milvuspb.CreateDatabaseRequest{
+			DbName:     "db3",
+			Properties: kvs(common.DatabaseMaxCollectionsKey, "1"),
+		})
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+
+		schema, err := proto.Marshal(&schemapb.CollectionSchema{Name: "coll"})
+		assert.NoError(t, err)
+		err = c.broadcastCreateCollectionV1(readerCtx, &milvuspb.CreateCollectionRequest{
+			DbName:         "db1",
+			CollectionName: "coll",
+			Schema:         schema,
+			Properties:     kvs(common.CollectionInsertRateMaxKey, "1"),
+		})
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+	})
+}
@@ -0,0 +1,116 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/eventlog"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+)
+
+// writeDenyCooldown is the deny cycles and the cooldown state of a collection.
+type writeDenyCooldown struct {
+	denied        bool        // whether the collection is denied to write in the last round
+	cycles        []time.Time // the times the collection enters the deny writing state within the window
+	cooldownUntil time.Time   // zero if the collection is not in the cooldown
+}
+
+// applyWriteDenyCooldowns counts the times each collection enters the deny writing state,
+// the collection entering it more than maxDenyCycles times within the window is placed in the cooldown,
+// and its write rate factor is capped by the cooldown factor until the cooldown expires.
+func (q *QuotaCenter) applyWriteDenyCooldowns(now time.Time, collectionFactors map[int64]float64, denyCodes map[int64]commonpb.ErrorCode) {
+	maxCycles := Params.QuotaConfig.DenyCooldownMaxCycles.GetAsInt()
+	if maxCycles <= 0 {
+		q.writeDenyCooldowns = make(map[int64]*writeDenyCooldown)
+		return
+	}
+	window := Params.QuotaConfig.DenyCooldownWindowSeconds.GetAsDuration(time.Second)
+	cooldown := Params.QuotaConfig.DenyCooldownSeconds.GetAsDuration(time.Second)
+	cooldownFactor := Params.QuotaConfig.DenyCooldownFactor.GetAsFloat()
+
+	for collection, errorCode := range denyCodes {
+		state, ok := q.writeDenyCooldowns[collection]
+		if !ok {
+			state = &writeDenyCooldown{}
+			q.writeDenyCooldowns[collection] = state
+		}
+		if !state.denied {
+			state.denied = true
+			state.cycles = append(state.cycles, now)
+			mlog.Info(q.ctx, "QuotaCenter count the deny writing cycle",
+				mlog.FieldCollectionID(collection),
+				mlog.String("errorCode", errorCode.String()),
+				mlog.Int("cycles", len(state.cycles)))
+		}
+	}
+
+	for collection, state := range q.writeDenyCooldowns {
+		if _, ok := q.collectionIDToDBID.Get(collection); !ok {
+			// the collection is dropped.
+			delete(q.writeDenyCooldowns, collection)
+			continue
+		}
+		if _, ok := denyCodes[collection]; !ok {
+			state.denied = false
+		}
+		for len(state.cycles) > 0 && now.Sub(state.cycles[0]) > window {
+			state.cycles = state.cycles[1:]
+		}
+
+		if !state.cooldownUntil.IsZero() && !now.Before(state.cooldownUntil) {
+			state.cooldownUntil = time.Time{}
+			mlog.Info(q.ctx, "QuotaCenter release the collection from the deny writing cooldown", mlog.FieldCollectionID(collection))
+			eventlog.Record(eventlog.NewRawEvt(eventlog.Level_Info,
+				fmt.Sprintf("collection %d is released from the deny writing cooldown", collection)))
+		}
+		if state.cooldownUntil.IsZero() && len(state.cycles) > maxCycles {
+			state.cooldownUntil = now.Add(cooldown)
+			state.cycles = nil
+			mlog.Warn(q.ctx, "QuotaCenter place the collection in the deny writing cooldown since it's denied to write repeatedly",
+				mlog.FieldCollectionID(collection),
+				mlog.Int("maxDenyCycles", maxCycles),
+				mlog.Duration("window", window),
+				mlog.Time("cooldownUntil", state.cooldownUntil),
+				mlog.Float64("factor", cooldownFactor))
+			eventlog.Record(eventlog.NewRawEvt(eventlog.Level_Warn,
+				fmt.Sprintf("collection %d is placed in the deny writing cooldown until %s, it's denied to write more than %d times within %s",
+					collection, state.cooldownUntil.Format(time.RFC3339), maxCycles, window)))
+		}
+
+		if state.cooldownUntil.IsZero() {
+			if !state.denied && len(state.cycles) == 0 {
+				delete(q.writeDenyCooldowns, collection)
+			}
+			continue
+		}
+		factor, ok := collectionFactors[collection]
+		if !ok || factor > cooldownFactor {
+			factor = cooldownFactor
+		}
+		mlog.RatedInfo(q.ctx, rate.Limit(10), "QuotaCenter limit the write rates of the collection in the cooldown",
+			mlog.FieldCollectionID(collection),
+			mlog.Float64("factor", factor),
+			mlog.Time("cooldownUntil", state.cooldownUntil))
+		q.writeFactorRecorder.recordCollection(collection, writeFactorDenyCooldown, cooldownFactor)
+		collectionFactors[collection] = factor
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestQuotaCenterApplyWriteDenyCooldowns(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, mockrootcoord.NewIMetaTable(t))
+	quotaCenter.collectionIDToDBID.Insert(1, 1)
+	quotaCenter.collectionIDToDBID.Insert(2, 1)
+	denied := map[int64]commonpb.ErrorCode{1: commonpb.ErrorCode_MemoryQuotaExhausted}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		now := time.Now()
+		for i := 0; i < 10; i++ {
+			quotaCenter.applyWriteDenyCooldowns(now, map[int64]float64{1: 0}, denied)
+			factors := map[int64]float64{1: 1}
+			quotaCenter.applyWriteDenyCooldowns(now, factors, nil)
+			assert.Equal(t, 1.0, factors[1])
+		}
+		assert.Empty(t, quotaCenter.writeDenyCooldowns)
+	})
+
+	t.Run("cooldown and release", func(t *testing.T) {
+		paramtable.Get().Save(Params.QuotaConfig.DenyCooldownMaxCycles.Key, "2")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DenyCooldownMaxCycles.Key)
+		paramtable.Get().Save(Params.QuotaConfig.DenyCooldownWindowSeconds.Key, "60")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DenyCooldownWindowSeconds.Key)
+		paramtable.Get().Save(Params.QuotaConfig.DenyCooldownSeconds.Key, "300")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DenyCooldownSeconds.Key)
+		paramtable.Get().Save(Params.QuotaConfig.DenyCooldownFactor.Key, "0.2")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DenyCooldownFactor.Key)
+
+		now := time.Now()
+		// staying in the deny state is counted once.
+		quotaCenter.applyWriteDenyCooldowns(now, map[int64]float64{1: 0}, denied)
+		quotaCenter.applyWriteDenyCooldowns(now.Add(time.Second), map[int64]float64{1: 0}, denied)
+		assert.Len(t, quotaCenter.writeDenyCooldowns[1].cycles, 1)
+
+		// the cycles out of the window are not counted.
+		quotaCenter.applyWriteDenyCooldowns(now.Add(2*time.Second), map[int64]float64{1: 1}, nil)
+		quotaCenter.applyWriteDenyCooldowns(now.Add(2*time.Minute), map[int64]float64{1: 0}, denied)
+		quotaCenter.applyWriteDenyCooldowns(now.Add(2*time.Minute+time.Second), map[int64]float64{1: 1}, nil)
+		assert.Len(t, quotaCenter.writeDenyCooldowns[1].cycles, 1)
+
+		// flip-flops into the deny state more than 2 times within the window.
+		now = now.Add(4 * time.Minute)
+		for i := 0; i < 2; i++ {
+			quotaCenter.applyWriteDenyCooldowns(now, map[int64]float64{1: 0}, denied)
+			quotaCenter.applyWriteDenyCooldowns(now, map[int64]float64{1: 1}, nil)
+			assert.True(t, quotaCenter.writeDenyCooldowns[1].cooldownUntil.IsZero())
+		}
+		quotaCenter.applyWriteDenyCooldowns(now, map[int64]float64{1: 0}, denied)
+		assert.Equal(t, now.Add(5*time.Minute), quotaCenter.writeDenyCooldowns[1].cooldownUntil)
+
+		// the write rates are limited in the cooldown.
+		factors := map[int64]float64{1: 1}
+		quotaCenter.applyWriteDenyCooldowns(now.Add(time.Minute), factors, nil)
+		assert.Equal(t, 0.2, factors[1])
+		factors = map[int64]float64{1: 0.1}
+		quotaCenter.applyWriteDenyCooldowns(now.Add(time.Minute), factors, nil)
+		assert.Equal(t, 0.1, factors[1])
+		factors = map[int64]float64{}
+		quotaCenter.applyWriteDenyCooldowns(now.Add(time.Minute), factors, nil)
+		assert.Equal(t, 0.2, factors[1])
+		_, ok := factors[2]
+		assert.False(t, ok)
+
+		// released after the cooldown.
+		factors = map[int64]float64{1: 1}
+		quotaCenter.applyWriteDenyCooldowns(now.Add(5*time.Minute), factors, nil)
+		assert.Equal(t, 1.0, factors[1])
+		assert.Empty(t, quotaCenter.writeDenyCooldowns)
+	})
+
+	t.Run("dropped collection", func(t *testing.T) {
+		paramtable.Get().Save(Params.QuotaConfig.DenyCooldownMaxCycles.Key, "2")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DenyCooldownMaxCycles.Key)
+
+		quotaCenter.applyWriteDenyCooldowns(time.Now(), map[int64]float64{3: 0},
+			map[int64]commonpb.ErrorCode{3: commonpb.ErrorCode_TimeTickLongDelay})
+		assert.Empty(t, quotaCenter.writeDenyCooldowns)
+	})
+}
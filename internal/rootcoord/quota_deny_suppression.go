@@ -0,0 +1,85 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+)
+
+// writeDenyHold is the deny writing state of a collection caused by the protections.
+type writeDenyHold struct {
+	errorCode commonpb.ErrorCode
+	deniedAt  time.Time // the last time the collection is denied by the protections
+}
+
+// suppressWriteDenyFlapping returns the error codes of the collections which should be denied to write.
+// The collections denied by the protections are kept denied until they are not denied for the minimum hold duration
+// and their factors recover above the release factor, the factors of the held collections are set to 0.
+func (q *QuotaCenter) suppressWriteDenyFlapping(now time.Time, collectionFactors, ttFactors map[int64]float64) map[int64]commonpb.ErrorCode {
+	minHold := Params.QuotaConfig.DenyMinHoldSeconds.GetAsDuration(time.Second)
+	releaseFactor := Params.QuotaConfig.DenyReleaseFactor.GetAsFloat()
+
+	denyCodes := make(map[int64]commonpb.ErrorCode)
+	for collection, factor := range collectionFactors {
+		if factor > 0 {
+			continue
+		}
+		errorCode := commonpb.ErrorCode_MemoryQuotaExhausted
+		if ttFactor, ok := ttFactors[collection]; ok && factor == ttFactor {
+			// factor comes from ttFactor
+			errorCode = commonpb.ErrorCode_TimeTickLongDelay
+		}
+		denyCodes[collection] = errorCode
+		q.writeDenyHolds[collection] = &writeDenyHold{errorCode: errorCode, deniedAt: now}
+	}
+
+	for collection, hold := range q.writeDenyHolds {
+		if _, ok := denyCodes[collection]; ok {
+			continue
+		}
+		if _, ok := q.collectionIDToDBID.Get(collection); !ok {
+			// the collection is dropped.
+			delete(q.writeDenyHolds, collection)
+			continue
+		}
+		factor, ok := collectionFactors[collection]
+		if !ok {
+			factor = 1
+		}
+		if now.Sub(hold.deniedAt) >= minHold && factor > releaseFactor {
+			delete(q.writeDenyHolds, collection)
+			mlog.Info(q.ctx, "QuotaCenter release the deny writing state",
+				mlog.FieldCollectionID(collection),
+				mlog.String("errorCode", hold.errorCode.String()),
+				mlog.Float64("factor", factor))
+			continue
+		}
+		mlog.RatedInfo(q.ctx, rate.Limit(10), "QuotaCenter hold the deny writing state",
+			mlog.FieldCollectionID(collection),
+			mlog.String("errorCode", hold.errorCode.String()),
+			mlog.Float64("factor", factor),
+			mlog.Time("deniedAt", hold.deniedAt))
+		collectionFactors[collection] = 0
+		denyCodes[collection] = hold.errorCode
+	}
+	return denyCodes
+}
@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestQuotaCenterSuppressWriteDenyFlapping(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, mockrootcoord.NewIMetaTable(t))
+	quotaCenter.collectionIDToDBID.Insert(1, 1)
+	quotaCenter.collectionIDToDBID.Insert(2, 1)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		now := time.Now()
+		factors := map[int64]float64{1: 0, 2: 0}
+		denyCodes := quotaCenter.suppressWriteDenyFlapping(now, factors, map[int64]float64{2: 0})
+		assert.Equal(t, map[int64]commonpb.ErrorCode{
+			1: commonpb.ErrorCode_MemoryQuotaExhausted,
+			2: commonpb.ErrorCode_TimeTickLongDelay,
+		}, denyCodes)
+
+		factors = map[int64]float64{1: 0.01}
+		denyCodes = quotaCenter.suppressWriteDenyFlapping(now, factors, nil)
+		assert.Empty(t, denyCodes)
+		assert.Equal(t, 0.01, factors[1])
+		assert.Empty(t, quotaCenter.writeDenyHolds)
+	})
+
+	t.Run("hold and release", func(t *testing.T) {
+		paramtable.Get().Save(Params.QuotaConfig.DenyMinHoldSeconds.Key, "60")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DenyMinHoldSeconds.Key)
+		paramtable.Get().Save(Params.QuotaConfig.DenyReleaseFactor.Key, "0.2")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DenyReleaseFactor.Key)
+
+		now := time.Now()
+		denyCodes := quotaCenter.suppressWriteDenyFlapping(now, map[int64]float64{1: 0}, nil)
+		assert.Equal(t, commonpb.ErrorCode_MemoryQuotaExhausted, denyCodes[1])
+
+		// held by the minimum hold duration
+		factors := map[int64]float64{1: 0.5}
+		denyCodes = quotaCenter.suppressWriteDenyFlapping(now.Add(time.Second), factors, nil)
+		assert.Equal(t, commonpb.ErrorCode_MemoryQuotaExhausted, denyCodes[1])
+		assert.Equal(t, 0.0, factors[1])
+
+		// held by the release factor
+		factors = map[int64]float64{1: 0.1}
+		denyCodes = quotaCenter.suppressWriteDenyFlapping(now.Add(time.Minute), factors, nil)
+		assert.Equal(t, commonpb.ErrorCode_MemoryQuotaExhausted, denyCodes[1])
+		assert.Equal(t, 0.0, factors[1])
+
+		// released
+		factors = map[int64]float64{}
+		denyCodes = quotaCenter.suppressWriteDenyFlapping(now.Add(time.Minute), factors, nil)
+		assert.Empty(t, denyCodes)
+		assert.Empty(t, factors)
+		assert.Empty(t, quotaCenter.writeDenyHolds)
+	})
+
+	t.Run("dropped collection", func(t *testing.T) {
+		paramtable.Get().Save(Params.QuotaConfig.DenyMinHoldSeconds.Key, "60")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DenyMinHoldSeconds.Key)
+
+		now := time.Now()
+		quotaCenter.suppressWriteDenyFlapping(now, map[int64]float64{3: 0}, nil)
+		assert.Len(t, quotaCenter.writeDenyHolds, 1)
+		denyCodes := quotaCenter.suppressWriteDenyFlapping(now, map[int64]float64{}, nil)
+		assert.Empty(t, denyCodes)
+		assert.Empty(t, quotaCenter.writeDenyHolds)
+	})
+}
@@ -0,0 +1,169 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const maxInFlightReclaimCompactions = 4
+
+// diskReclaimState is the reclaim progress of a collection denied writing for disk quota.
+type diskReclaimState struct {
+	quota         float64
+	startSize     int64
+	currentSize   int64
+	deniedAt      time.Time
+	lastCompactAt time.Time
+}
+
+// diskReclaimTarget is a collection to compact for disk reclaim.
+type diskReclaimTarget struct {
+	collectionID int64
+	diskUsage    int64
+	reclaimed    int64
+}
+
+// diskReclaimTracker tracks the collections in delete-only mode for disk quota.
+// A collection enters the mode when its disk usage reaches the quota,
+// and leaves it only after the usage falls below lowWaterLevel * quota,
+// so the writing is not flipped on and off around the quota.
+// It's guarded by the diskMu of QuotaCenter.
+type diskReclaimTracker struct {
+	collections map[int64]*diskReclaimState
+}
+
+func newDiskReclaimTracker() *diskReclaimTracker {
+	return &diskReclaimTracker{
+		collections: make(map[int64]*diskReclaimState),
+	}
+}
+
+// shouldDeny updates the reclaim state of the collection with its current disk usage,
+// and returns whether the writing of the collection should be denied.
+func (t *diskReclaimTracker) shouldDeny(ctx context.Context, collectionID int64, size int64, quota float64, lowWaterLevel float64, now time.Time) bool {
+	state, ok := t.collections[collectionID]
+	if float64(size) >= quota {
+		if !ok {
+			t.collections[collectionID] = &diskReclaimState{
+				quota:       quota,
+				startSize:   size,
+				currentSize: size,
+				deniedAt:    now,
+			}
+			mlog.Info(ctx, "collection enters delete-only mode for disk quota",
+				mlog.Int64("collectionID", collectionID),
+				mlog.Int64("diskUsage", size),
+				mlog.Float64("diskQuota", quota))
+			return true
+		}
+		state.quota, state.currentSize = quota, size
+		return true
+	}
+	if !ok {
+		return false
+	}
+	state.quota, state.currentSize = quota, size
+	if float64(size) >= quota*lowWaterLevel {
+		return true
+	}
+	delete(t.collections, collectionID)
+	mlog.Info(ctx, "collection leaves delete-only mode for disk quota",
+		mlog.Int64("collectionID", collectionID),
+		mlog.Int64("diskUsage", size),
+		mlog.Int64("reclaimed", state.startSize-size),
+		mlog.Duration("duration", now.Sub(state.deniedAt)))
+	return false
+}
+
+// collectionsToCompact returns the collections in reclaim whose compaction is not triggered within the interval,
+// and marks them as triggered.
+func (t *diskReclaimTracker) collectionsToCompact(now time.Time, interval time.Duration) []int64 {
+	collectionIDs := make([]int64, 0)
+	for collectionID, state := range t.collections {
+		if now.Sub(state.lastCompactAt) < interval {
+			continue
+		}
+		state.lastCompactAt = now
+		collectionIDs = append(collectionIDs, collectionID)
+	}
+	return collectionIDs
+}
+
+// prune removes the collections not reported by the metrics any more, e.g. the dropped ones.
+func (t *diskReclaimTracker) prune(existing map[int64]int64) {
+	for collectionID := range t.collections {
+		if _, ok := existing[collectionID]; !ok {
+			delete(t.collections, collectionID)
+		}
+	}
+}
+
+// targetsToCompact snapshots the collections to compact, the snapshot is used out of diskMu.
+func (t *diskReclaimTracker) targetsToCompact(now time.Time, interval time.Duration) []diskReclaimTarget {
+	collectionIDs := t.collectionsToCompact(now, interval)
+	targets := make([]diskReclaimTarget, 0, len(collectionIDs))
+	for _, collectionID := range collectionIDs {
+		state := t.collections[collectionID]
+		targets = append(targets, diskReclaimTarget{
+			collectionID: collectionID,
+			diskUsage:    state.currentSize,
+			reclaimed:    state.startSize - state.currentSize,
+		})
+	}
+	return targets
+}
+
+// triggerDiskReclaimCompaction triggers compaction on the collections in reclaim asynchronously to apply the deletes
+// and release the disk space, the progress is checked by the next disk quota check.
+// At most maxInFlightReclaimCompactions triggers are in flight, the skipped collections are retried after the interval.
+// It must be called without holding the locks of QuotaCenter.
+func (q *QuotaCenter) triggerDiskReclaimCompaction(targets []diskReclaimTarget) {
+	for _, target := range targets {
+		logger := mlog.With(
+			mlog.Int64("collectionID", target.collectionID),
+			mlog.Int64("diskUsage", target.diskUsage),
+			mlog.Int64("reclaimed", target.reclaimed),
+		)
+		select {
+		case q.reclaimCompactions <- struct{}{}:
+		default:
+			logger.Info(q.ctx, "too many compactions in flight to reclaim disk, skip")
+			continue
+		}
+		q.wg.Add(1)
+		go func() {
+			defer func() {
+				<-q.reclaimCompactions
+				q.wg.Done()
+			}()
+			resp, err := q.mixCoord.ManualCompaction(q.ctx, &milvuspb.ManualCompactionRequest{
+				CollectionID: target.collectionID,
+			})
+			if err := merr.CheckRPCCall(resp, err); err != nil {
+				logger.Warn(q.ctx, "failed to trigger compaction to reclaim disk", mlog.Err(err))
+				return
+			}
+			logger.Info(q.ctx, "compaction triggered to reclaim disk", mlog.Int64("compactionID", resp.GetCompactionID()))
+		}()
+	}
+}
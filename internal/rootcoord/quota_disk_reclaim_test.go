@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestDiskReclaimTracker(t *testing.T) {
+	ctx := context.Background()
+	tracker := newDiskReclaimTracker()
+	now := time.Now()
+
+	assert.False(t, tracker.shouldDeny(ctx, 1, 80, 100, 0.9, now))
+	assert.True(t, tracker.shouldDeny(ctx, 1, 100, 100, 0.9, now))
+	// still denied until the usage falls below the low water level.
+	assert.True(t, tracker.shouldDeny(ctx, 1, 95, 100, 0.9, now))
+	assert.Equal(t, int64(100), tracker.collections[1].startSize)
+	assert.Equal(t, int64(95), tracker.collections[1].currentSize)
+
+	assert.ElementsMatch(t, []int64{1}, tracker.collectionsToCompact(now, time.Minute))
+	assert.Empty(t, tracker.collectionsToCompact(now.Add(time.Second), time.Minute))
+	assert.ElementsMatch(t, []int64{1}, tracker.collectionsToCompact(now.Add(time.Minute), time.Minute))
+
+	assert.False(t, tracker.shouldDeny(ctx, 1, 85, 100, 0.9, now))
+	assert.Empty(t, tracker.collections)
+
+	assert.True(t, tracker.shouldDeny(ctx, 2, 200, 100, 0.9, now))
+	tracker.prune(map[int64]int64{1: 10})
+	assert.Empty(t, tracker.collections)
+}
+
+func TestTriggerDiskReclaimCompaction(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	mixCoord := mocks.NewMixCoord(t)
+	q := &QuotaCenter{
+		ctx:                ctx,
+		mixCoord:           mixCoord,
+		diskReclaim:        newDiskReclaimTracker(),
+		reclaimCompactions: make(chan struct{}, 1),
+	}
+	now := time.Now()
+	q.diskReclaim.shouldDeny(ctx, 1, 100, 100, 0.9, now)
+	q.diskReclaim.shouldDeny(ctx, 2, 100, 100, 0.9, now)
+
+	t.Run("bounded in flight", func(t *testing.T) {
+		release := make(chan struct{})
+		mixCoord.EXPECT().ManualCompaction(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *milvuspb.ManualCompactionRequest) (*milvuspb.ManualCompactionResponse, error) {
+				<-release
+				return &milvuspb.ManualCompactionResponse{Status: merr.Success(), CompactionID: 10}, nil
+			}).Once()
+		targets := q.diskReclaim.targetsToCompact(now, time.Minute)
+		assert.Len(t, targets, 2)
+		// the trigger never blocks on the rpc, the second one is skipped for the bound.
+		q.triggerDiskReclaimCompaction(targets)
+		close(release)
+		q.wg.Wait()
+		assert.Empty(t, q.reclaimCompactions)
+		// not triggered again within the interval.
+		assert.Empty(t, q.diskReclaim.targetsToCompact(now.Add(time.Second), time.Minute))
+	})
+
+	t.Run("trigger failed", func(t *testing.T) {
+		mixCoord.EXPECT().ManualCompaction(mock.Anything, mock.Anything).Return(nil, merr.ErrServiceNotReady).Once()
+		q.triggerDiskReclaimCompaction([]diskReclaimTarget{{collectionID: 2, diskUsage: 100}})
+		q.wg.Wait()
+		assert.Empty(t, q.reclaimCompactions)
+	})
+}
@@ -0,0 +1,171 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// QuotaExemptionRequest is the request to issue a quota exemption token.
+type QuotaExemptionRequest struct {
+	DbName         string `json:"db_name"`
+	CollectionName string `json:"collection_name"`
+	// RateTypes are the names of the exempted rate types, e.g. DMLDelete.
+	RateTypes  []string `json:"rate_types"`
+	TTLSeconds int64    `json:"ttl_seconds"`
+	Issuer     string   `json:"issuer"`
+	Reason     string   `json:"reason"`
+}
+
+// QuotaExemption is an issued quota exemption token and its detail.
+type QuotaExemption struct {
+	Token string `json:"token"`
+	*rlinternal.ExemptionToken
+}
+
+// quotaExemptionManager tracks the issued quota exemption tokens until they expire.
+// The tokens are verified by proxies with their signatures, so they can not be revoked
+// and are kept short-lived.
+type quotaExemptionManager struct {
+	mu     sync.Mutex
+	tokens map[string]*rlinternal.ExemptionToken
+}
+
+func newQuotaExemptionManager() *quotaExemptionManager {
+	return &quotaExemptionManager{
+		tokens: make(map[string]*rlinternal.ExemptionToken),
+	}
+}
+
+func parseExemptionRateTypes(names []string) ([]internalpb.RateType, error) {
+	if len(names) == 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("rate types of quota exemption should not be empty")
+	}
+	rateTypes := make([]internalpb.RateType, 0, len(names))
+	for _, name := range names {
+		rt, ok := internalpb.RateType_value[name]
+		if !ok {
+			return nil, merr.WrapErrParameterInvalidMsg("unknown rate type %s", name)
+		}
+		rateTypes = append(rateTypes, internalpb.RateType(rt))
+	}
+	return rateTypes, nil
+}
+
+func (m *quotaExemptionManager) add(token *rlinternal.ExemptionToken, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pruneLocked(now)
+	m.tokens[token.ID] = token
+}
+
+// list returns the unexpired tokens sorted by the issued time.
+func (m *quotaExemptionManager) list(now time.Time) []*rlinternal.ExemptionToken {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pruneLocked(now)
+	tokens := make([]*rlinternal.ExemptionToken, 0, len(m.tokens))
+	for _, token := range m.tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].IssuedAt < tokens[j].IssuedAt })
+	return tokens
+}
+
+func (m *quotaExemptionManager) pruneLocked(now time.Time) {
+	for id, token := range m.tokens {
+		if token.Expired(now) {
+			delete(m.tokens, id)
+		}
+	}
+}
+
+// IssueQuotaExemption issues a short-lived token to bypass the rate limits of the collection,
+// proxies verify the token carried by the requests in the grpc metadata.
+func (c *Core) IssueQuotaExemption(ctx context.Context, req *QuotaExemptionRequest) (*QuotaExemption, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return nil, err
+	}
+	if c.quotaExemptions == nil {
+		return nil, merr.WrapErrServiceNotReady(paramtable.GetRole(), paramtable.GetNodeID(), "quota exemption manager is not initialized")
+	}
+	if req.Issuer == "" || req.Reason == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("issuer and reason of quota exemption should not be empty")
+	}
+	maxTTL := Params.QuotaConfig.ExemptionMaxTTL.GetAsInt64()
+	if req.TTLSeconds <= 0 || req.TTLSeconds > maxTTL {
+		return nil, merr.WrapErrParameterInvalidMsg("ttl of quota exemption should be in (0, %d] seconds", maxTTL)
+	}
+	rateTypes, err := parseExemptionRateTypes(req.RateTypes)
+	if err != nil {
+		return nil, err
+	}
+	coll, err := c.meta.GetCollectionByName(ctx, req.DbName, req.CollectionName, typeutil.MaxTimestamp, false)
+	if err != nil {
+		return nil, err
+	}
+	id, err := c.idAllocator.AllocOne()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	token := &rlinternal.ExemptionToken{
+		ID:           strconv.FormatInt(id, 10),
+		CollectionID: coll.CollectionID,
+		RateTypes:    rateTypes,
+		Issuer:       req.Issuer,
+		Reason:       req.Reason,
+		IssuedAt:     now.Unix(),
+		ExpireAt:     now.Add(time.Duration(req.TTLSeconds) * time.Second).Unix(),
+	}
+	signed, err := rlinternal.SignExemptionToken(token, Params.QuotaConfig.ExemptionSecret.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	c.quotaExemptions.add(token, now)
+	mlog.Info(ctx, "quota exemption token issued",
+		mlog.String("tokenID", token.ID),
+		mlog.String("issuer", token.Issuer),
+		mlog.String("reason", token.Reason),
+		mlog.Int64("collectionID", token.CollectionID),
+		mlog.Strings("rateTypes", req.RateTypes),
+		mlog.Time("expireAt", time.Unix(token.ExpireAt, 0)))
+	return &QuotaExemption{Token: signed, ExemptionToken: token}, nil
+}
+
+// ListQuotaExemptions returns the issued quota exemption tokens not expired yet, the signed tokens are not returned.
+func (c *Core) ListQuotaExemptions(ctx context.Context) ([]*rlinternal.ExemptionToken, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return nil, err
+	}
+	if c.quotaExemptions == nil {
+		return nil, merr.WrapErrServiceNotReady(paramtable.GetRole(), paramtable.GetNodeID(), "quota exemption manager is not initialized")
+	}
+	return c.quotaExemptions.list(time.Now()), nil
+}
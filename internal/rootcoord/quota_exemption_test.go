@@ -0,0 +1,55 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+)
+
+func TestParseExemptionRateTypes(t *testing.T) {
+	rateTypes, err := parseExemptionRateTypes([]string{"DMLDelete", "DMLBulkLoad"})
+	assert.NoError(t, err)
+	assert.Equal(t, []internalpb.RateType{internalpb.RateType_DMLDelete, internalpb.RateType_DMLBulkLoad}, rateTypes)
+
+	_, err = parseExemptionRateTypes(nil)
+	assert.Error(t, err)
+	_, err = parseExemptionRateTypes([]string{"Unknown"})
+	assert.Error(t, err)
+}
+
+func TestQuotaExemptionManager(t *testing.T) {
+	now := time.Now()
+	m := newQuotaExemptionManager()
+	m.add(&rlinternal.ExemptionToken{ID: "2", IssuedAt: now.Unix(), ExpireAt: now.Add(time.Hour).Unix()}, now)
+	m.add(&rlinternal.ExemptionToken{ID: "1", IssuedAt: now.Unix() - 1, ExpireAt: now.Add(time.Minute).Unix()}, now)
+
+	tokens := m.list(now)
+	assert.Len(t, tokens, 2)
+	assert.Equal(t, "1", tokens[0].ID)
+	assert.Equal(t, "2", tokens[1].ID)
+
+	// the expired tokens are pruned.
+	tokens = m.list(now.Add(10 * time.Minute))
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, "2", tokens[0].ID)
+}
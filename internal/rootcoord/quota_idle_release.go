@@ -0,0 +1,216 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// releaseIdleCollections releases the loaded collections which receive no search or query for the idle duration
+// set by the collection property, the proxies load them again on the first search or query with the load config
+// saved before the release.
+func (q *QuotaCenter) releaseIdleCollections(now time.Time) {
+	searchRates := q.getProxyCollectionRates(internalpb.RateType_DQLSearch.String())
+	queryRates := q.getProxyCollectionRates(internalpb.RateType_DQLQuery.String())
+	getRates := q.getProxyCollectionRates(internalpb.RateType_DQLGet.String())
+
+	loaded := make(map[int64]struct{})
+	for _, collections := range q.readableCollections {
+		for collectionID := range collections {
+			loaded[collectionID] = struct{}{}
+			props := q.getCollectionLimitProperties(collectionID)
+			if _, ok := props[common.CollectionIdleReleasedLoadConfigKey]; ok {
+				// the collection released for idle is loaded again.
+				if err := q.alterIdleReleasedLoadConfig(collectionID, nil); err != nil {
+					mlog.Warn(q.ctx, "QuotaCenter: failed to remove the load config of the collection released for idle",
+						mlog.FieldCollectionID(collectionID),
+						mlog.Err(err))
+				}
+			}
+			idleDuration := common.GetCollectionIdleReleaseDuration(props)
+			if idleDuration <= 0 {
+				delete(q.lastAccessTimes, collectionID)
+				continue
+			}
+			lastAccess, ok := q.lastAccessTimes[collectionID]
+			if !ok || searchRates[collectionID] > 0 || queryRates[collectionID] > 0 || getRates[collectionID] > 0 {
+				q.lastAccessTimes[collectionID] = now
+				continue
+			}
+			if now.Sub(lastAccess) < idleDuration {
+				continue
+			}
+			// the release is not issued again until the collection stays idle for another idle duration.
+			q.lastAccessTimes[collectionID] = now
+			if err := q.releaseIdleCollection(collectionID); err != nil {
+				mlog.Warn(q.ctx, "QuotaCenter: failed to release idle collection",
+					mlog.FieldCollectionID(collectionID),
+					mlog.Duration("idleDuration", now.Sub(lastAccess)),
+					mlog.Err(err))
+				continue
+			}
+			mlog.Info(q.ctx, "QuotaCenter: idle collection released",
+				mlog.FieldCollectionID(collectionID),
+				mlog.Duration("idleDuration", now.Sub(lastAccess)))
+		}
+	}
+
+	for collectionID := range q.lastAccessTimes {
+		if _, ok := loaded[collectionID]; !ok {
+			delete(q.lastAccessTimes, collectionID)
+		}
+	}
+}
+
+// releaseIdleCollection saves the load config of the collection before releasing it,
+// the collection is not released if the load config is not saved.
+func (q *QuotaCenter) releaseIdleCollection(collectionID int64) error {
+	config, err := q.getIdleReleaseLoadConfig(collectionID)
+	if err != nil {
+		return err
+	}
+	if err := q.alterIdleReleasedLoadConfig(collectionID, config); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(q.ctx, GetMetricsTimeout)
+	defer cancel()
+	status, err := q.mixCoord.ReleaseCollection(ctx, &querypb.ReleaseCollectionRequest{
+		Base:         commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_ReleaseCollection)),
+		CollectionID: collectionID,
+	})
+	return merr.CheckRPCCall(status, err)
+}
+
+// getIdleReleaseLoadConfig returns the current load config of the loaded collection,
+// including the replicas, the resource groups, the loaded fields and the loaded partitions.
+func (q *QuotaCenter) getIdleReleaseLoadConfig(collectionID int64) (*common.IdleReleasedLoadConfig, error) {
+	ctx, cancel := context.WithTimeout(q.ctx, GetMetricsTimeout)
+	defer cancel()
+	coll, err := q.meta.GetCollectionByIDWithMaxTs(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas, err := q.mixCoord.GetReplicas(ctx, &milvuspb.GetReplicasRequest{
+		Base:         commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_GetReplicas)),
+		CollectionID: collectionID,
+	})
+	if err := merr.CheckRPCCall(replicas, err); err != nil {
+		return nil, err
+	}
+	if len(replicas.GetReplicas()) == 0 {
+		return nil, merr.WrapErrCollectionNotLoaded(collectionID)
+	}
+	// the replicas are spawned one by one in the resource groups if there are more than one resource group.
+	resourceGroups := lo.Map(replicas.GetReplicas(), func(replica *milvuspb.ReplicaInfo, _ int) string {
+		return replica.GetResourceGroupName()
+	})
+	if len(lo.Uniq(resourceGroups)) == 1 {
+		resourceGroups = resourceGroups[:1]
+	}
+	config := &common.IdleReleasedLoadConfig{
+		ReplicaNumber:  int32(len(replicas.GetReplicas())),
+		ResourceGroups: resourceGroups,
+	}
+
+	collections, err := q.mixCoord.ShowLoadCollections(ctx, &querypb.ShowCollectionsRequest{
+		Base:          commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_ShowCollections)),
+		CollectionIDs: []int64{collectionID},
+	})
+	if err := merr.CheckRPCCall(collections, err); err != nil {
+		return nil, err
+	}
+	if len(collections.GetLoadFields()) > 0 && len(collections.GetLoadFields()[0].GetData()) > 0 {
+		loadFields := typeutil.NewSet(collections.GetLoadFields()[0].GetData()...)
+		config.SkipLoadDynamicField = true
+		for _, field := range coll.Fields {
+			if !loadFields.Contain(field.FieldID) {
+				continue
+			}
+			if field.IsDynamic {
+				config.SkipLoadDynamicField = false
+				continue
+			}
+			if !common.IsSystemField(field.FieldID) {
+				config.LoadFields = append(config.LoadFields, field.Name)
+			}
+		}
+	}
+
+	partitions, err := q.mixCoord.ShowLoadPartitions(ctx, &querypb.ShowPartitionsRequest{
+		Base:         commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_ShowPartitions)),
+		CollectionID: collectionID,
+	})
+	if err := merr.CheckRPCCall(partitions, err); err != nil {
+		return nil, err
+	}
+	loadedPartitions := typeutil.NewSet(partitions.GetPartitionIDs()...)
+	if !lo.EveryBy(coll.Partitions, func(partition *model.Partition) bool { return loadedPartitions.Contain(partition.PartitionID) }) {
+		for _, partition := range coll.Partitions {
+			if loadedPartitions.Contain(partition.PartitionID) {
+				config.Partitions = append(config.Partitions, partition.PartitionName)
+			}
+		}
+	}
+	return config, nil
+}
+
+// alterIdleReleasedLoadConfig saves the load config of the collection released for idle in the collection properties,
+// so the proxies can load the collection again with it, the saved load config is removed if the config is nil.
+func (q *QuotaCenter) alterIdleReleasedLoadConfig(collectionID int64, config *common.IdleReleasedLoadConfig) error {
+	ctx, cancel := context.WithTimeout(q.ctx, GetMetricsTimeout)
+	defer cancel()
+	coll, err := q.meta.GetCollectionByIDWithMaxTs(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	req := &milvuspb.AlterCollectionRequest{
+		Base:           commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_AlterCollection)),
+		DbName:         coll.DBName,
+		CollectionName: coll.Name,
+	}
+	if config == nil {
+		req.DeleteKeys = []string{common.CollectionIdleReleasedLoadConfigKey}
+	} else {
+		value, err := common.MarshalIdleReleasedLoadConfig(config)
+		if err != nil {
+			return err
+		}
+		req.Properties = []*commonpb.KeyValuePair{{Key: common.CollectionIdleReleasedLoadConfigKey, Value: value}}
+	}
+	status, err := q.mixCoord.AlterCollection(ctx, req)
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		return err
+	}
+	delete(q.collectionProps, collectionID)
+	return nil
+}
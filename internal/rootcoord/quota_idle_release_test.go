@@ -0,0 +1,146 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/mocks"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/ratelimitutil"
+)
+
+func TestQuotaCenterReleaseIdleCollections(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	meta := mockrootcoord.NewIMetaTable(t)
+	quotaCenter := newQuotaCenterForTesting(t, ctx, meta)
+	mixCoord := quotaCenter.mixCoord.(*mocks.MixCoord)
+	quotaCenter.dbs.Insert("db1", 1)
+	quotaCenter.collections.Insert(FormatCollectionKey(1, "coll10"), 10)
+	quotaCenter.collections.Insert(FormatCollectionKey(1, "coll20"), 20)
+	quotaCenter.readableCollections = map[int64]map[int64][]int64{1: {10: {100}, 20: {200}}}
+	quotaCenter.collectionProps[10] = map[string]string{common.CollectionIdleReleaseSecondsKey: "60"}
+	quotaCenter.collectionProps[20] = map[string]string{}
+
+	setSearchRate := func(rate float64) {
+		quotaCenter.proxyMetrics = map[UniqueID]*metricsinfo.ProxyQuotaMetrics{
+			1: {Rms: []metricsinfo.RateMetric{{
+				Label: ratelimitutil.FormatSubLabel(internalpb.RateType_DQLSearch.String(), ratelimitutil.GetCollectionSubLabel("db1", "coll10")),
+				Rate:  rate,
+			}}},
+		}
+	}
+
+	now := time.Now()
+	setSearchRate(0)
+	quotaCenter.releaseIdleCollections(now)
+	assert.Equal(t, now, quotaCenter.lastAccessTimes[10])
+	assert.NotContains(t, quotaCenter.lastAccessTimes, int64(20))
+
+	// searched recently
+	setSearchRate(10)
+	quotaCenter.releaseIdleCollections(now.Add(30 * time.Second))
+	assert.Equal(t, now.Add(30*time.Second), quotaCenter.lastAccessTimes[10])
+
+	// idle but not long enough
+	setSearchRate(0)
+	quotaCenter.releaseIdleCollections(now.Add(80 * time.Second))
+	assert.Equal(t, now.Add(30*time.Second), quotaCenter.lastAccessTimes[10])
+
+	// the load config is saved before the release
+	meta.EXPECT().GetCollectionByIDWithMaxTs(mock.Anything, int64(10)).Return(&model.Collection{
+		CollectionID: 10,
+		DBName:       "db1",
+		Name:         "coll10",
+		Fields: []*model.Field{
+			{FieldID: common.RowIDField, Name: common.RowIDFieldName},
+			{FieldID: 100, Name: "pk"},
+			{FieldID: 101, Name: "vec"},
+			{FieldID: 102, Name: "text"},
+			{FieldID: 103, Name: common.MetaFieldName, IsDynamic: true},
+		},
+		Partitions: []*model.Partition{
+			{PartitionID: 100, PartitionName: "p1"},
+			{PartitionID: 101, PartitionName: "p2"},
+		},
+	}, nil)
+	mixCoord.EXPECT().GetReplicas(mock.Anything, mock.Anything).Return(&milvuspb.GetReplicasResponse{
+		Status: merr.Success(),
+		Replicas: []*milvuspb.ReplicaInfo{
+			{ReplicaID: 1, ResourceGroupName: "rg1"},
+			{ReplicaID: 2, ResourceGroupName: "rg2"},
+		},
+	}, nil).Once()
+	mixCoord.EXPECT().ShowLoadCollections(mock.Anything, mock.Anything).Return(&querypb.ShowCollectionsResponse{
+		Status:     merr.Success(),
+		LoadFields: []*schemapb.LongArray{{Data: []int64{common.RowIDField, 100, 101}}},
+	}, nil).Once()
+	mixCoord.EXPECT().ShowLoadPartitions(mock.Anything, mock.Anything).Return(&querypb.ShowPartitionsResponse{
+		Status:       merr.Success(),
+		PartitionIDs: []int64{100},
+	}, nil).Once()
+	mixCoord.EXPECT().AlterCollection(mock.Anything, mock.MatchedBy(func(req *milvuspb.AlterCollectionRequest) bool {
+		if req.GetDbName() != "db1" || req.GetCollectionName() != "coll10" || len(req.GetProperties()) != 1 {
+			return false
+		}
+		config, ok := common.GetIdleReleasedLoadConfig(map[string]string{req.GetProperties()[0].GetKey(): req.GetProperties()[0].GetValue()})
+		return ok && assert.Equal(t, &common.IdleReleasedLoadConfig{
+			ReplicaNumber:        2,
+			ResourceGroups:       []string{"rg1", "rg2"},
+			LoadFields:           []string{"pk", "vec"},
+			SkipLoadDynamicField: true,
+			Partitions:           []string{"p1"},
+		}, config)
+	})).Return(merr.Success(), nil).Once()
+	mixCoord.EXPECT().ReleaseCollection(mock.Anything, mock.MatchedBy(func(req *querypb.ReleaseCollectionRequest) bool {
+		return req.GetCollectionID() == 10
+	})).Return(merr.Success(), nil).Once()
+	quotaCenter.releaseIdleCollections(now.Add(90 * time.Second))
+	assert.Equal(t, now.Add(90*time.Second), quotaCenter.lastAccessTimes[10])
+
+	// the saved load config is removed once the collection is loaded again
+	quotaCenter.collectionProps[10] = map[string]string{
+		common.CollectionIdleReleaseSecondsKey:     "60",
+		common.CollectionIdleReleasedLoadConfigKey: `{"replica_number":2}`,
+	}
+	mixCoord.EXPECT().AlterCollection(mock.Anything, mock.MatchedBy(func(req *milvuspb.AlterCollectionRequest) bool {
+		return req.GetCollectionName() == "coll10" && len(req.GetDeleteKeys()) == 1 &&
+			req.GetDeleteKeys()[0] == common.CollectionIdleReleasedLoadConfigKey
+	})).Return(merr.Success(), nil).Once()
+	quotaCenter.releaseIdleCollections(now.Add(95 * time.Second))
+	assert.NotContains(t, quotaCenter.collectionProps, int64(10))
+
+	// released collections are not tracked anymore
+	quotaCenter.readableCollections = map[int64]map[int64][]int64{1: {20: {200}}}
+	quotaCenter.releaseIdleCollections(now.Add(100 * time.Second))
+	assert.Empty(t, quotaCenter.lastAccessTimes)
+}
@@ -0,0 +1,154 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/ratelimitutil"
+)
+
+// quotaMetricsHistory keeps the latest downsampled quota metrics snapshots in a ring buffer.
+type quotaMetricsHistory struct {
+	mu          sync.Mutex
+	snapshots   []*metricsinfo.QuotaMetricsSnapshot
+	next        int // the position to write the next snapshot
+	count       int
+	lastSampled time.Time
+}
+
+func newQuotaMetricsHistory() *quotaMetricsHistory {
+	return &quotaMetricsHistory{}
+}
+
+// shouldSample returns whether a new snapshot should be added at the time,
+// the history is dropped if it is disabled.
+func (h *quotaMetricsHistory) shouldSample(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if Params.QuotaConfig.MetricsHistorySize.GetAsInt() <= 0 {
+		h.snapshots, h.next, h.count = nil, 0, 0
+		return false
+	}
+	interval := Params.QuotaConfig.MetricsHistorySampleInterval.GetAsDuration(time.Second)
+	return h.lastSampled.IsZero() || now.Sub(h.lastSampled) >= interval
+}
+
+// add appends the snapshot, the oldest one is dropped if the history is full.
+// The buffer is resized if the configured size is changed, the latest snapshots are kept.
+func (h *quotaMetricsHistory) add(now time.Time, snapshot *metricsinfo.QuotaMetricsSnapshot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	size := Params.QuotaConfig.MetricsHistorySize.GetAsInt()
+	if size <= 0 {
+		h.snapshots, h.next, h.count = nil, 0, 0
+		return
+	}
+	if len(h.snapshots) != size {
+		latest := h.listLocked()
+		if len(latest) > size {
+			latest = latest[len(latest)-size:]
+		}
+		h.snapshots = make([]*metricsinfo.QuotaMetricsSnapshot, size)
+		h.count = copy(h.snapshots, latest)
+		h.next = h.count % size
+	}
+	h.snapshots[h.next] = snapshot
+	h.next = (h.next + 1) % size
+	h.count = min(h.count+1, size)
+	h.lastSampled = now
+}
+
+// list returns the snapshots ordered from the oldest.
+func (h *quotaMetricsHistory) list() []*metricsinfo.QuotaMetricsSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.listLocked()
+}
+
+func (h *quotaMetricsHistory) listLocked() []*metricsinfo.QuotaMetricsSnapshot {
+	if h.count == 0 {
+		return nil
+	}
+	result := make([]*metricsinfo.QuotaMetricsSnapshot, 0, h.count)
+	start := (h.next - h.count + len(h.snapshots)) % len(h.snapshots)
+	for i := 0; i < h.count; i++ {
+		result = append(result, h.snapshots[(start+i)%len(h.snapshots)])
+	}
+	return result
+}
+
+func sumRateMetrics(rates map[metricsinfo.RateMetricLabel]float64, rms []metricsinfo.RateMetric) {
+	for _, rm := range rms {
+		rates[rm.Label] += rm.Rate
+	}
+}
+
+func memoryUsageRatio(hms metricsinfo.HardwareMetrics) float64 {
+	if hms.Memory == 0 {
+		return 0
+	}
+	return float64(hms.MemoryUsage) / float64(hms.Memory)
+}
+
+// sampleMetricsHistory adds a snapshot of the current quota metrics into the history,
+// it is downsampled by the configured sample interval.
+func (q *QuotaCenter) sampleMetricsHistory(now time.Time) {
+	if !q.metricsHistory.shouldSample(now) {
+		return
+	}
+	q.metricsHistory.add(now, q.snapshotQuotaMetrics(now))
+}
+
+func (q *QuotaCenter) snapshotQuotaMetrics(now time.Time) *metricsinfo.QuotaMetricsSnapshot {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+
+	snapshot := &metricsinfo.QuotaMetricsSnapshot{
+		Timestamp:      now.Unix(),
+		ProxyRates:     make(map[metricsinfo.RateMetricLabel]float64),
+		QueryNodeRates: make(map[metricsinfo.RateMetricLabel]float64),
+		DataNodeRates:  make(map[metricsinfo.RateMetricLabel]float64),
+		ClusterLimits:  make(map[string]float64),
+	}
+	for _, m := range q.proxyMetrics {
+		sumRateMetrics(snapshot.ProxyRates, m.Rms)
+	}
+	q.rangeQueryNodeMetrics(func(_ string, _ int64, m *metricsinfo.QueryNodeQuotaMetrics) {
+		sumRateMetrics(snapshot.QueryNodeRates, m.Rms)
+		snapshot.MaxMemoryUsageRatio = math.Max(snapshot.MaxMemoryUsageRatio, memoryUsageRatio(m.Hms))
+	})
+	for _, m := range q.dataNodeMetrics {
+		sumRateMetrics(snapshot.DataNodeRates, m.Rms)
+		snapshot.MaxMemoryUsageRatio = math.Max(snapshot.MaxMemoryUsageRatio, memoryUsageRatio(m.Hms))
+	}
+	if q.dataCoordMetrics != nil {
+		snapshot.TotalBinlogSize = q.dataCoordMetrics.TotalBinlogSize
+	}
+	q.rateLimiter.GetRootLimiters().GetLimiters().Range(func(rt internalpb.RateType, limiter *ratelimitutil.Limiter) bool {
+		// the infinite limits can not be marshaled into json, and they are not limited at all.
+		if limit := float64(limiter.Limit()); !math.IsInf(limit, 0) {
+			snapshot.ClusterLimits[rt.String()] = limit
+		}
+		return true
+	})
+	return snapshot
+}
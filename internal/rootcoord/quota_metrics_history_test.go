@@ -0,0 +1,91 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestQuotaMetricsHistory(t *testing.T) {
+	paramtable.Init()
+	Params.Save(Params.QuotaConfig.MetricsHistorySize.Key, "3")
+	defer Params.Reset(Params.QuotaConfig.MetricsHistorySize.Key)
+	Params.Save(Params.QuotaConfig.MetricsHistorySampleInterval.Key, "10")
+	defer Params.Reset(Params.QuotaConfig.MetricsHistorySampleInterval.Key)
+
+	timestamps := func(snapshots []*metricsinfo.QuotaMetricsSnapshot) []int64 {
+		return lo.Map(snapshots, func(s *metricsinfo.QuotaMetricsSnapshot, _ int) int64 { return s.Timestamp })
+	}
+
+	h := newQuotaMetricsHistory()
+	now := time.Unix(1000, 0)
+	assert.Empty(t, h.list())
+	assert.True(t, h.shouldSample(now))
+	for i := 0; i < 5; i++ {
+		ts := now.Add(time.Duration(i*10) * time.Second)
+		h.add(ts, &metricsinfo.QuotaMetricsSnapshot{Timestamp: ts.Unix()})
+	}
+	assert.Equal(t, []int64{1020, 1030, 1040}, timestamps(h.list()))
+
+	// downsampled by the sample interval.
+	assert.False(t, h.shouldSample(now.Add(45*time.Second)))
+	assert.True(t, h.shouldSample(now.Add(50*time.Second)))
+
+	// the latest snapshots are kept after resizing.
+	Params.Save(Params.QuotaConfig.MetricsHistorySize.Key, "2")
+	h.add(now.Add(50*time.Second), &metricsinfo.QuotaMetricsSnapshot{Timestamp: 1050})
+	assert.Equal(t, []int64{1040, 1050}, timestamps(h.list()))
+
+	// the history is dropped once disabled.
+	Params.Save(Params.QuotaConfig.MetricsHistorySize.Key, "0")
+	assert.False(t, h.shouldSample(now.Add(time.Hour)))
+	assert.Empty(t, h.list())
+}
+
+func TestQuotaCenterMetricsHistory(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, mockrootcoord.NewIMetaTable(t))
+	quotaCenter.proxyMetrics = map[int64]*metricsinfo.ProxyQuotaMetrics{
+		1: {Rms: []metricsinfo.RateMetric{{Label: "DMLInsert", Rate: 100}}},
+		2: {Rms: []metricsinfo.RateMetric{{Label: "DMLInsert", Rate: 50}}},
+	}
+	quotaCenter.queryNodeMetrics = map[int64]*metricsinfo.QueryNodeQuotaMetrics{
+		1: {Hms: metricsinfo.HardwareMetrics{Memory: 100, MemoryUsage: 80}},
+	}
+	quotaCenter.sampleMetricsHistory(time.Now())
+	// not sampled again within the sample interval.
+	quotaCenter.sampleMetricsHistory(time.Now())
+
+	resp := quotaCenter.getQuotaMetrics()
+	metrics := &metricsinfo.QuotaCenterMetrics{}
+	assert.NoError(t, metricsinfo.UnmarshalComponentInfos(resp.GetMetricsInfo(), metrics))
+	assert.Len(t, metrics.History, 1)
+	snapshot := metrics.History[0]
+	assert.Equal(t, 150.0, snapshot.ProxyRates["DMLInsert"])
+	assert.Equal(t, 0.8, snapshot.MaxMemoryUsageRatio)
+	assert.Equal(t, 500.0, snapshot.ClusterLimits["DMLInsert"])
+}
@@ -0,0 +1,168 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// nodeQuarantine is the quarantine state of a node reporting the extreme metrics.
+type nodeQuarantine struct {
+	extremeRounds int // the consecutive rounds with the extreme metrics
+	normalRounds  int // the consecutive rounds with the normal metrics since quarantined
+	quarantined   bool
+	errorCode     commonpb.ErrorCode
+	collections   []int64 // the collections on the node in the last round
+}
+
+// getConfigExcludedNodes returns the nodes excluded by quotaAndLimits.limitWriting.nodeExclusion.excludedNodes.
+func (q *QuotaCenter) getConfigExcludedNodes() typeutil.UniqueSet {
+	excluded := typeutil.NewUniqueSet()
+	for _, v := range Params.QuotaConfig.FactorExcludedNodes.GetAsStrings() {
+		nodeID, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			mlog.RatedWarn(q.ctx, rate.Limit(10), "invalid node id in the excluded nodes of quota center", mlog.String("value", v))
+			continue
+		}
+		excluded.Insert(nodeID)
+	}
+	return excluded
+}
+
+// getFactorExcludedNodes returns the nodes whose metrics are excluded from the time tick delay and memory factors,
+// including the ones excluded by the config and the quarantined ones.
+func (q *QuotaCenter) getFactorExcludedNodes() typeutil.UniqueSet {
+	excluded := q.getConfigExcludedNodes()
+	for nodeID, state := range q.nodeQuarantines {
+		if state.quarantined {
+			excluded.Insert(nodeID)
+		}
+	}
+	return excluded
+}
+
+// updateNodeQuarantines counts the consecutive rounds of the extreme metrics of each node,
+// the node is quarantined after quotaAndLimits.limitWriting.nodeExclusion.quarantineRounds rounds,
+// and released after the same number of rounds with the normal metrics.
+func (q *QuotaCenter) updateNodeQuarantines(ts Timestamp) {
+	rounds := Params.QuotaConfig.NodeQuarantineRounds.GetAsInt()
+	if rounds <= 0 {
+		q.nodeQuarantines = make(map[int64]*nodeQuarantine)
+		return
+	}
+
+	t1, _ := tsoutil.ParseTS(ts)
+	maxDelay := Params.QuotaConfig.MaxTimeTickDelay.GetAsDuration(time.Second)
+	// isExtreme returns the error code to deny writing if the metrics of the node are extreme.
+	isExtreme := func(fgm metricsinfo.FlowGraphMetric, hms metricsinfo.HardwareMetrics, highWaterLevel float64) (commonpb.ErrorCode, bool) {
+		if Params.QuotaConfig.TtProtectionEnabled.GetAsBool() && maxDelay >= 0 && fgm.NumFlowGraph > 0 && fgm.MinFlowGraphChannel != "" {
+			t2, _ := tsoutil.ParseTS(fgm.MinFlowGraphTt)
+			if t1.Sub(t2) >= maxDelay {
+				return commonpb.ErrorCode_TimeTickLongDelay, true
+			}
+		}
+		if Params.QuotaConfig.MemProtectionEnabled.GetAsBool() && hms.Memory > 0 &&
+			float64(hms.MemoryUsage)/float64(hms.Memory) >= highWaterLevel {
+			return commonpb.ErrorCode_MemoryQuotaExhausted, true
+		}
+		return commonpb.ErrorCode_Success, false
+	}
+
+	reported := typeutil.NewUniqueSet()
+	update := func(role string, nodeID int64, collections []int64, errorCode commonpb.ErrorCode, extreme bool) {
+		reported.Insert(nodeID)
+		state, ok := q.nodeQuarantines[nodeID]
+		if !ok {
+			state = &nodeQuarantine{}
+			q.nodeQuarantines[nodeID] = state
+		}
+		state.collections = collections
+		if !extreme {
+			state.extremeRounds = 0
+			if !state.quarantined {
+				return
+			}
+			state.normalRounds++
+			if state.normalRounds >= rounds {
+				state.quarantined = false
+				state.normalRounds = 0
+				mlog.Info(q.ctx, "QuotaCenter release the quarantined node",
+					mlog.String("role", role), mlog.Int64("nodeID", nodeID))
+			}
+			return
+		}
+		state.normalRounds = 0
+		state.extremeRounds++
+		state.errorCode = errorCode
+		if !state.quarantined && state.extremeRounds >= rounds {
+			state.quarantined = true
+			mlog.Warn(q.ctx, "QuotaCenter quarantine the node reporting the extreme metrics",
+				mlog.String("role", role),
+				mlog.Int64("nodeID", nodeID),
+				mlog.String("errorCode", errorCode.String()),
+				mlog.Int64s("collections", collections))
+		}
+	}
+
+	for nodeID, metric := range q.queryNodeMetrics {
+		errorCode, extreme := isExtreme(metric.Fgm, metric.Hms, Params.QuotaConfig.QueryNodeMemoryHighWaterLevel.GetAsFloat())
+		update(typeutil.QueryNodeRole, nodeID, metric.Effect.CollectionIDs, errorCode, extreme)
+	}
+	for nodeID, metric := range q.streamingNodeMetrics {
+		errorCode, extreme := isExtreme(metric.Fgm, metric.Hms, Params.QuotaConfig.StreamingNodeMemoryHighWaterLevel.GetAsFloat())
+		update(typeutil.StreamingNodeRole, nodeID, metric.Effect.CollectionIDs, errorCode, extreme)
+	}
+	for nodeID, metric := range q.dataNodeMetrics {
+		errorCode, extreme := isExtreme(metric.Fgm, metric.Hms, Params.QuotaConfig.DataNodeMemoryHighWaterLevel.GetAsFloat())
+		update(typeutil.DataNodeRole, nodeID, metric.Effect.CollectionIDs, errorCode, extreme)
+	}
+
+	// the nodes gone are forgotten.
+	for nodeID := range q.nodeQuarantines {
+		if !reported.Contain(nodeID) {
+			delete(q.nodeQuarantines, nodeID)
+		}
+	}
+}
+
+// getQuarantinedCollections returns the error codes of the collections on the quarantined nodes,
+// they are denied to write instead of limiting the write rates by the metrics of the quarantined nodes.
+func (q *QuotaCenter) getQuarantinedCollections() map[int64]commonpb.ErrorCode {
+	excluded := q.getConfigExcludedNodes()
+	collections := make(map[int64]commonpb.ErrorCode)
+	for nodeID, state := range q.nodeQuarantines {
+		if !state.quarantined || excluded.Contain(nodeID) {
+			continue
+		}
+		for _, collection := range state.collections {
+			if _, ok := q.collectionIDToDBID.Get(collection); ok {
+				collections[collection] = state.errorCode
+			}
+		}
+	}
+	return collections
+}
@@ -0,0 +1,108 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
+)
+
+func TestQuotaCenterNodeExclusion(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, mockrootcoord.NewIMetaTable(t))
+	quotaCenter.collectionIDToDBID.Insert(1, 1)
+	quotaCenter.collectionIDToDBID.Insert(2, 1)
+
+	paramtable.Get().Save(Params.QuotaConfig.MemProtectionEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.QuotaConfig.MemProtectionEnabled.Key)
+
+	setMemory := func(nodeID int64, usage uint64, collections ...int64) {
+		quotaCenter.queryNodeMetrics[nodeID] = &metricsinfo.QueryNodeQuotaMetrics{
+			Hms:    metricsinfo.HardwareMetrics{MemoryUsage: usage, Memory: 100},
+			Effect: metricsinfo.NodeEffect{NodeID: nodeID, CollectionIDs: collections},
+		}
+	}
+	ts := tsoutil.ComposeTSByTime(time.Now())
+
+	t.Run("excluded by config", func(t *testing.T) {
+		quotaCenter.queryNodeMetrics = make(map[int64]*metricsinfo.QueryNodeQuotaMetrics)
+		setMemory(1, 99, 1)
+		setMemory(2, 10, 2)
+		assert.Equal(t, 0.0, quotaCenter.getMemoryFactor()[1])
+
+		paramtable.Get().Save(Params.QuotaConfig.FactorExcludedNodes.Key, "1,invalid")
+		defer paramtable.Get().Reset(Params.QuotaConfig.FactorExcludedNodes.Key)
+		factors := quotaCenter.getMemoryFactor()
+		_, ok := factors[1]
+		assert.False(t, ok)
+		assert.Equal(t, 1.0, factors[2])
+	})
+
+	t.Run("quarantine and release", func(t *testing.T) {
+		paramtable.Get().Save(Params.QuotaConfig.NodeQuarantineRounds.Key, "2")
+		defer paramtable.Get().Reset(Params.QuotaConfig.NodeQuarantineRounds.Key)
+
+		quotaCenter.queryNodeMetrics = make(map[int64]*metricsinfo.QueryNodeQuotaMetrics)
+		setMemory(1, 99, 1)
+		setMemory(2, 10, 2)
+
+		quotaCenter.updateNodeQuarantines(ts)
+		assert.Empty(t, quotaCenter.getQuarantinedCollections())
+		assert.Equal(t, 0.0, quotaCenter.getMemoryFactor()[1])
+
+		// quarantined after the consecutive rounds, only the collections on the node are denied
+		quotaCenter.updateNodeQuarantines(ts)
+		assert.Equal(t, map[int64]commonpb.ErrorCode{1: commonpb.ErrorCode_MemoryQuotaExhausted}, quotaCenter.getQuarantinedCollections())
+		assert.True(t, quotaCenter.getFactorExcludedNodes().Contain(1))
+		_, ok := quotaCenter.getMemoryFactor()[1]
+		assert.False(t, ok)
+
+		// the excluded node by config is not denied
+		paramtable.Get().Save(Params.QuotaConfig.FactorExcludedNodes.Key, "1")
+		assert.Empty(t, quotaCenter.getQuarantinedCollections())
+		paramtable.Get().Reset(Params.QuotaConfig.FactorExcludedNodes.Key)
+
+		// released after the consecutive rounds with the normal metrics
+		setMemory(1, 10, 1)
+		quotaCenter.updateNodeQuarantines(ts)
+		assert.Len(t, quotaCenter.getQuarantinedCollections(), 1)
+		quotaCenter.updateNodeQuarantines(ts)
+		assert.Empty(t, quotaCenter.getQuarantinedCollections())
+		assert.False(t, quotaCenter.getFactorExcludedNodes().Contain(1))
+
+		// the nodes gone are forgotten
+		delete(quotaCenter.queryNodeMetrics, 1)
+		quotaCenter.updateNodeQuarantines(ts)
+		_, ok = quotaCenter.nodeQuarantines[1]
+		assert.False(t, ok)
+
+		// disabled
+		paramtable.Get().Save(Params.QuotaConfig.NodeQuarantineRounds.Key, "0")
+		quotaCenter.updateNodeQuarantines(ts)
+		assert.Empty(t, quotaCenter.nodeQuarantines)
+	})
+}
@@ -0,0 +1,189 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/cdc/cluster"
+	cdcmeta "github.com/milvus-io/milvus/internal/cdc/meta"
+	"github.com/milvus-io/milvus/internal/metastore/kv/streamingcoord"
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
+)
+
+// replicateCheckpointCollector collects the replicate checkpoints of the pchannels of current cluster
+// from the target clusters of the cross-cluster replication.
+type replicateCheckpointCollector struct {
+	etcdCli      *clientv3.Client
+	prefix       string
+	createClient cluster.CreateMilvusClientFunc
+	clients      map[string]cluster.MilvusClient // target cluster id -> client
+}
+
+func newReplicateCheckpointCollector(etcdCli *clientv3.Client) *replicateCheckpointCollector {
+	return &replicateCheckpointCollector{
+		etcdCli:      etcdCli,
+		prefix:       path.Join(paramtable.Get().EtcdCfg.MetaRootPath.GetValue(), streamingcoord.ReplicatePChannelMetaPrefix),
+		createClient: cluster.NewMilvusClient,
+		clients:      make(map[string]cluster.MilvusClient),
+	}
+}
+
+// collect returns the time tick replicated to the target clusters of each source pchannel,
+// the minimal one is kept if the pchannel is replicated to multiple clusters.
+func (c *replicateCheckpointCollector) collect(ctx context.Context) (map[string]uint64, error) {
+	channels, err := cdcmeta.ListReplicatePChannels(ctx, c.etcdCli, c.prefix)
+	if err != nil {
+		return nil, err
+	}
+	currentClusterID := paramtable.Get().CommonCfg.ClusterPrefix.GetValue()
+	targetClusters := make(map[string]struct{})
+	timeTicks := make(map[string]uint64)
+	for _, ch := range channels.Channels {
+		info := ch.Value
+		if !strings.Contains(info.GetSourceChannelName(), currentClusterID) {
+			// current cluster is not the source cluster of the replication
+			continue
+		}
+		targetClusterID := info.GetTargetCluster().GetClusterId()
+		targetClusters[targetClusterID] = struct{}{}
+		client, err := c.getClient(ctx, info.GetTargetCluster())
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.GetReplicateInfo(ctx, &milvuspb.GetReplicateInfoRequest{
+			SourceClusterId: currentClusterID,
+			TargetPchannel:  info.GetTargetChannelName(),
+		})
+		if err != nil {
+			c.closeClient(ctx, targetClusterID)
+			return nil, merr.Wrapf(err, "failed to get replicate info of channel %s from cluster %s", info.GetTargetChannelName(), targetClusterID)
+		}
+		timeTick := resp.GetCheckpoint().GetTimeTick()
+		if timeTick == 0 {
+			timeTick = info.GetInitializedCheckpoint().GetTimeTick()
+		}
+		if current, ok := timeTicks[info.GetSourceChannelName()]; !ok || timeTick < current {
+			timeTicks[info.GetSourceChannelName()] = timeTick
+		}
+	}
+	for targetClusterID := range c.clients {
+		if _, ok := targetClusters[targetClusterID]; !ok {
+			c.closeClient(ctx, targetClusterID)
+		}
+	}
+	return timeTicks, nil
+}
+
+func (c *replicateCheckpointCollector) getClient(ctx context.Context, targetCluster *commonpb.MilvusCluster) (cluster.MilvusClient, error) {
+	if client, ok := c.clients[targetCluster.GetClusterId()]; ok {
+		return client, nil
+	}
+	client, err := c.createClient(ctx, targetCluster)
+	if err != nil {
+		return nil, err
+	}
+	c.clients[targetCluster.GetClusterId()] = client
+	return client, nil
+}
+
+func (c *replicateCheckpointCollector) closeClient(ctx context.Context, targetClusterID string) {
+	if client, ok := c.clients[targetClusterID]; ok {
+		if err := client.Close(ctx); err != nil {
+			mlog.Warn(ctx, "failed to close milvus client of target cluster", mlog.String("clusterID", targetClusterID), mlog.Err(err))
+		}
+		delete(c.clients, targetClusterID)
+	}
+}
+
+func (c *replicateCheckpointCollector) close(ctx context.Context) {
+	for targetClusterID := range c.clients {
+		c.closeClient(ctx, targetClusterID)
+	}
+}
+
+// getCollectionMaxReplicationLag returns the max replication lag of the collection,
+// the collection property overrides the cluster config.
+func (q *QuotaCenter) getCollectionMaxReplicationLag(collectionID int64) time.Duration {
+	props := q.getCollectionLimitProperties(collectionID)
+	if v, ok := props[common.CollectionReplicationMaxLagKey]; ok {
+		seconds, err := strconv.ParseFloat(v, 64)
+		if err == nil && seconds > 0 {
+			return time.Duration(seconds * float64(time.Second))
+		}
+		mlog.RatedWarn(q.ctx, rate.Limit(10), "invalid max replication lag of collection",
+			mlog.FieldCollectionID(collectionID),
+			mlog.String("value", v))
+	}
+	return Params.QuotaConfig.MaxReplicationLag.GetAsDuration(time.Second)
+}
+
+// getReplicationLagFactor returns the factors of the collections on the pchannels whose replication
+// to the target clusters lags behind, the writes are slowed down to let the target clusters catch up.
+func (q *QuotaCenter) getReplicationLagFactor(ts Timestamp) map[int64]float64 {
+	collectionFactor := make(map[int64]float64)
+	if !Params.QuotaConfig.ReplicationLagProtectionEnabled.GetAsBool() {
+		return collectionFactor
+	}
+
+	minRateRatio := Params.QuotaConfig.ReplicationLagMinRateRatio.GetAsFloat()
+	t1, _ := tsoutil.ParseTS(ts)
+	for pchannel, replicatedTimeTick := range q.replicatedTimeTicks {
+		if replicatedTimeTick == 0 {
+			continue
+		}
+		t2, _ := tsoutil.ParseTS(replicatedTimeTick)
+		lag := t1.Sub(t2)
+		if lag <= 0 {
+			continue
+		}
+		pchannelInfo := channel.StaticPChannelStatsManager.MustGet().GetPChannelStats(types.ChannelID{Name: pchannel})
+		for _, collectionID := range pchannelInfo.CollectionIDs() {
+			maxLag := q.getCollectionMaxReplicationLag(collectionID)
+			factor := 1 - float64(lag)/float64(maxLag)
+			if factor < minRateRatio {
+				factor = minRateRatio
+			}
+			if current, ok := collectionFactor[collectionID]; ok && current <= factor {
+				continue
+			}
+			collectionFactor[collectionID] = factor
+			mlog.RatedWarn(q.ctx, rate.Limit(10), "QuotaCenter: limit writing due to replication lag",
+				mlog.FieldCollectionID(collectionID),
+				mlog.String("pchannel", pchannel),
+				mlog.Duration("lag", lag),
+				mlog.Duration("maxLag", maxLag),
+				mlog.Float64("factor", factor))
+		}
+	}
+	return collectionFactor
+}
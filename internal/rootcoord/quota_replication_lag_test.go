@@ -0,0 +1,63 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
+)
+
+func TestReplicationLagFactor(t *testing.T) {
+	paramtable.Init()
+	channel.ResetStaticPChannelStatsManager()
+	channel.RecoverPChannelStatsManager([]string{"by-dev-dml_0_100v0", "by-dev-dml_0_200v1", "by-dev-dml_1_300v0"})
+	t.Cleanup(channel.ResetStaticPChannelStatsManager)
+
+	pt := paramtable.Get()
+	pt.Save(pt.QuotaConfig.ReplicationLagProtectionEnabled.Key, "true")
+	defer pt.Reset(pt.QuotaConfig.ReplicationLagProtectionEnabled.Key)
+	pt.Save(pt.QuotaConfig.MaxReplicationLag.Key, "100")
+	defer pt.Reset(pt.QuotaConfig.MaxReplicationLag.Key)
+	pt.Save(pt.QuotaConfig.ReplicationLagMinRateRatio.Key, "0.1")
+	defer pt.Reset(pt.QuotaConfig.ReplicationLagMinRateRatio.Key)
+
+	now := time.Now()
+	quotaCenter := NewQuotaCenter(nil, nil, nil, nil)
+	quotaCenter.collectionProps[100] = map[string]string{}
+	quotaCenter.collectionProps[200] = map[string]string{common.CollectionReplicationMaxLagKey: "10"}
+	quotaCenter.collectionProps[300] = map[string]string{}
+	quotaCenter.replicatedTimeTicks = map[string]uint64{
+		"by-dev-dml_0": tsoutil.ComposeTSByTime(now.Add(-50 * time.Second)),
+		"by-dev-dml_1": 0,
+	}
+
+	factors := quotaCenter.getReplicationLagFactor(tsoutil.ComposeTSByTime(now))
+	assert.Len(t, factors, 2)
+	assert.InDelta(t, 0.5, factors[100], 0.01)
+	// the lag exceeds the max lag of the collection property
+	assert.InDelta(t, 0.1, factors[200], 0.01)
+
+	pt.Save(pt.QuotaConfig.ReplicationLagProtectionEnabled.Key, "false")
+	assert.Empty(t, quotaCenter.getReplicationLagFactor(tsoutil.ComposeTSByTime(now)))
+}
@@ -0,0 +1,157 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// quotaWindow is a time-of-day window in which the dml and dql rate limits are scaled.
+type quotaWindow struct {
+	Start     string   `json:"start"`
+	End       string   `json:"end"`
+	DMLFactor *float64 `json:"dmlFactor,omitempty"`
+	DQLFactor *float64 `json:"dqlFactor,omitempty"`
+
+	startMinute int
+	endMinute   int
+}
+
+// parseQuotaWindows parses the scheduled quota windows in json format, an empty value means no window.
+func parseQuotaWindows(value string) ([]*quotaWindow, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var windows []*quotaWindow
+	if err := json.Unmarshal([]byte(value), &windows); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid scheduled quota windows %s: %s", value, err.Error())
+	}
+	for _, w := range windows {
+		var err error
+		if w.startMinute, err = parseMinuteOfDay(w.Start); err != nil {
+			return nil, err
+		}
+		if w.endMinute, err = parseMinuteOfDay(w.End); err != nil {
+			return nil, err
+		}
+		for _, factor := range []*float64{w.DMLFactor, w.DQLFactor} {
+			if factor != nil && *factor < 0 {
+				return nil, merr.WrapErrParameterInvalidMsg("invalid scheduled quota window %s-%s, factor should not be negative", w.Start, w.End)
+			}
+		}
+	}
+	return windows, nil
+}
+
+func parseMinuteOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, merr.WrapErrParameterInvalidMsg("invalid time of day %s, should be in HH:MM format", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// contains checks if the time of day of t is in [start, end),
+// the window crosses midnight if end is earlier than start, and covers the whole day if they are equal.
+func (w *quotaWindow) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if w.startMinute == w.endMinute {
+		return true
+	}
+	if w.startMinute < w.endMinute {
+		return minute >= w.startMinute && minute < w.endMinute
+	}
+	return minute >= w.startMinute || minute < w.endMinute
+}
+
+// getScheduledQuotaFactor returns the factor of the rate type from the first window containing now, 1 if none.
+func getScheduledQuotaFactor(windows []*quotaWindow, rt internalpb.RateType, now time.Time) float64 {
+	for _, w := range windows {
+		if !w.contains(now) {
+			continue
+		}
+		switch {
+		case dmlRateTypes.Contain(rt) && w.DMLFactor != nil:
+			return *w.DMLFactor
+		case dqlRateTypes.Contain(rt) && w.DQLFactor != nil:
+			return *w.DQLFactor
+		}
+		return 1
+	}
+	return 1
+}
+
+// scaleLimit scales the limit by the factor, an infinite limit stays infinite.
+func scaleLimit(limit Limit, factor float64) Limit {
+	if limit == Inf || factor == 1 {
+		return limit
+	}
+	scaled := float64(limit) * factor
+	if scaled >= float64(Inf) {
+		return Inf
+	}
+	return Limit(scaled)
+}
+
+// scheduledQuota holds the quota windows effective at the time the rates are reset.
+type scheduledQuota struct {
+	now       time.Time
+	cluster   []*quotaWindow
+	databases map[int64][]*quotaWindow // db id -> windows from the db property
+}
+
+// newScheduledQuota loads the quota windows from the config and the collected database properties.
+// Invalid windows are ignored with a warning, so a misconfiguration never blocks the rate calculation.
+func (q *QuotaCenter) newScheduledQuota(now time.Time) *scheduledQuota {
+	sq := &scheduledQuota{
+		now:       now,
+		databases: make(map[int64][]*quotaWindow),
+	}
+	var err error
+	if sq.cluster, err = parseQuotaWindows(Params.QuotaConfig.ScheduledWindows.GetValue()); err != nil {
+		mlog.Warn(q.ctx, "invalid scheduled quota windows in config, ignore it", mlog.Err(err))
+	}
+
+	for dbID, value := range q.dbQuotaWindows {
+		windows, err := parseQuotaWindows(value)
+		if err != nil {
+			mlog.Warn(q.ctx, "invalid scheduled quota windows of database, ignore it",
+				mlog.Int64("dbID", dbID), mlog.Err(err))
+			continue
+		}
+		sq.databases[dbID] = windows
+	}
+	return sq
+}
+
+// clusterFactor returns the factor applied to the cluster level limit.
+func (sq *scheduledQuota) clusterFactor(rt internalpb.RateType) float64 {
+	return getScheduledQuotaFactor(sq.cluster, rt, sq.now)
+}
+
+// databaseFactor returns the factor applied to the limits of the database and its collections and partitions.
+func (sq *scheduledQuota) databaseFactor(dbID int64, rt internalpb.RateType) float64 {
+	if windows, ok := sq.databases[dbID]; ok {
+		return getScheduledQuotaFactor(windows, rt, sq.now)
+	}
+	return sq.clusterFactor(rt)
+}
@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestParseQuotaWindows(t *testing.T) {
+	windows, err := parseQuotaWindows("")
+	assert.NoError(t, err)
+	assert.Empty(t, windows)
+
+	windows, err = parseQuotaWindows(`[{"start": "09:00", "end": "18:00", "dmlFactor": 0.5}, {"start": "22:30", "end": "06:00", "dqlFactor": 2}]`)
+	assert.NoError(t, err)
+	assert.Len(t, windows, 2)
+	assert.Equal(t, 9*60, windows[0].startMinute)
+	assert.Equal(t, 18*60, windows[0].endMinute)
+	assert.Nil(t, windows[0].DQLFactor)
+	assert.Equal(t, 22*60+30, windows[1].startMinute)
+
+	for _, value := range []string{
+		`{"start": "09:00"}`,
+		`[{"start": "9am", "end": "18:00"}]`,
+		`[{"start": "09:00", "end": "24:00"}]`,
+		`[{"start": "09:00", "end": "18:00", "dmlFactor": -1}]`,
+	} {
+		_, err = parseQuotaWindows(value)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid, value)
+	}
+}
+
+func TestScheduledQuotaFactor(t *testing.T) {
+	windows, err := parseQuotaWindows(`[{"start": "09:00", "end": "18:00", "dmlFactor": 0.5}, {"start": "22:00", "end": "06:00", "dmlFactor": 2, "dqlFactor": 0.1}]`)
+	assert.NoError(t, err)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 1, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	assert.Equal(t, 0.5, getScheduledQuotaFactor(windows, internalpb.RateType_DMLInsert, at(9, 0)))
+	assert.Equal(t, 0.5, getScheduledQuotaFactor(windows, internalpb.RateType_DMLDelete, at(17, 59)))
+	assert.Equal(t, 1.0, getScheduledQuotaFactor(windows, internalpb.RateType_DQLSearch, at(12, 0)))
+	assert.Equal(t, 1.0, getScheduledQuotaFactor(windows, internalpb.RateType_DMLInsert, at(18, 0)))
+	// the night window crosses midnight.
+	assert.Equal(t, 2.0, getScheduledQuotaFactor(windows, internalpb.RateType_DMLInsert, at(23, 0)))
+	assert.Equal(t, 0.1, getScheduledQuotaFactor(windows, internalpb.RateType_DQLQuery, at(5, 59)))
+	assert.Equal(t, 1.0, getScheduledQuotaFactor(windows, internalpb.RateType_DMLInsert, at(6, 0)))
+	// ddl is never scaled.
+	assert.Equal(t, 1.0, getScheduledQuotaFactor(windows, internalpb.RateType_DDLCollection, at(23, 0)))
+
+	wholeDay, err := parseQuotaWindows(`[{"start": "00:00", "end": "00:00", "dmlFactor": 3}]`)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, getScheduledQuotaFactor(wholeDay, internalpb.RateType_DMLInsert, at(15, 0)))
+
+	assert.Equal(t, Inf, scaleLimit(Inf, 0.5))
+	assert.Equal(t, Limit(50), scaleLimit(Limit(100), 0.5))
+	assert.Equal(t, Limit(0), scaleLimit(Limit(100), 0))
+	assert.Equal(t, Inf, scaleLimit(Limit(float64(Inf)/2), 3))
+}
+
+func TestResetAllCurrentRatesWithScheduledQuota(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QuotaConfig.DMLLimitEnabled.Key, "true")
+	defer params.Reset(params.QuotaConfig.DMLLimitEnabled.Key)
+	params.Save(params.QuotaConfig.DMLMaxInsertRate.Key, "10")
+	defer params.Reset(params.QuotaConfig.DMLMaxInsertRate.Key)
+	params.Save(params.QuotaConfig.DMLMaxInsertRatePerDB.Key, "10")
+	defer params.Reset(params.QuotaConfig.DMLMaxInsertRatePerDB.Key)
+	params.Save(params.QuotaConfig.ScheduledWindows.Key, `[{"start": "00:00", "end": "00:00", "dmlFactor": 0.5}]`)
+	defer params.Reset(params.QuotaConfig.ScheduledWindows.Key)
+
+	meta := mockrootcoord.NewIMetaTable(t)
+	meta.EXPECT().ListAllAvailPartitions(mock.Anything).Return(map[int64]map[int64][]int64{
+		1: {},
+		2: {},
+	})
+	quotaCenter := newQuotaCenterForTesting(t, context.Background(), meta)
+	// db 2 overrides the windows of the config.
+	quotaCenter.dbQuotaWindows = map[int64]string{
+		2: `[{"start": "00:00", "end": "00:00", "dmlFactor": 2}]`,
+	}
+	assert.NoError(t, quotaCenter.resetAllCurrentRates())
+
+	tenMB := Limit(10 * 1024 * 1024)
+	getInsertLimit := func(node *rlinternal.RateLimiterNode) Limit {
+		limiter, ok := node.GetLimiters().Get(internalpb.RateType_DMLInsert)
+		assert.True(t, ok)
+		return limiter.Limit()
+	}
+	assert.Equal(t, tenMB/2, getInsertLimit(quotaCenter.rateLimiter.GetRootLimiters()))
+	assert.Equal(t, tenMB/2, getInsertLimit(quotaCenter.rateLimiter.GetDatabaseLimiters(1)))
+	assert.Equal(t, tenMB*2, getInsertLimit(quotaCenter.rateLimiter.GetDatabaseLimiters(2)))
+}
@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/ratelimitutil"
+)
+
+// limiterNodeNamer returns the function to name the limiter nodes by the names of the databases and collections.
+func (q *QuotaCenter) limiterNodeNamer() func(node *rlinternal.RateLimiterNode) (string, bool) {
+	dbIDs := make(map[int64]string, q.dbs.Len())
+	collectionIDs := make(map[int64]string, q.collections.Len())
+	q.dbs.Range(func(name string, id int64) bool {
+		dbIDs[id] = name
+		return true
+	})
+	q.collections.Range(func(name string, id int64) bool {
+		_, collectionName := SplitCollectionKey(name)
+		collectionIDs[id] = collectionName
+		return true
+	})
+
+	return func(node *rlinternal.RateLimiterNode) (string, bool) {
+		switch node.Level() {
+		case internalpb.RateScope_Cluster:
+			return "cluster", true
+		case internalpb.RateScope_Database:
+			return "db_" + dbIDs[node.GetID()], true
+		case internalpb.RateScope_Collection:
+			return "collection_" + collectionIDs[node.GetID()], true
+		case internalpb.RateScope_Partition:
+			return "partition_" + strconv.FormatInt(node.GetID(), 10), true
+		default:
+			return "", false
+		}
+	}
+}
+
+// recordSimulatedLimits records the rate limits and the quota states calculated in the simulate mode,
+// they are exported by the metrics and the quota metrics instead of being applied to proxies.
+func (q *QuotaCenter) recordSimulatedLimits(now time.Time) {
+	metrics.RootCoordSimulatedQuotaStates.Reset()
+	metrics.RootCoordSimulatedRateLimit.Reset()
+
+	nodeName := q.limiterNodeNamer()
+	simulated := &metricsinfo.SimulatedQuotaLimits{Timestamp: now.Unix()}
+	var traverse func(node *rlinternal.RateLimiterNode)
+	traverse = func(node *rlinternal.RateLimiterNode) {
+		name, ok := nodeName(node)
+		if !ok {
+			return
+		}
+		simulatedNode := &metricsinfo.SimulatedLimiterNode{
+			Name:   name,
+			Rates:  make(map[string]float64),
+			States: make(map[string]string),
+		}
+		node.GetLimiters().Range(func(rt internalpb.RateType, limiter *ratelimitutil.Limiter) bool {
+			// the infinite limits can not be marshaled into json, and they are not limited at all.
+			if limit := float64(limiter.Limit()); limiter.HasUpdated() && !math.IsInf(limit, 0) {
+				simulatedNode.Rates[rt.String()] = limit
+				metrics.RootCoordSimulatedRateLimit.WithLabelValues(name, rt.String()).Set(limit)
+			}
+			return true
+		})
+		node.GetQuotaStates().Range(func(state milvuspb.QuotaState, stateInfo *rlinternal.QuotaStateInfo) bool {
+			simulatedNode.States[state.String()] = stateInfo.ErrorCode.String()
+			metrics.RootCoordSimulatedQuotaStates.WithLabelValues(stateInfo.ErrorCode.String(), name).Set(1.0)
+			return true
+		})
+		if len(simulatedNode.Rates) > 0 || len(simulatedNode.States) > 0 {
+			simulated.Nodes = append(simulated.Nodes, simulatedNode)
+		}
+		node.GetChildren().Range(func(_ int64, child *rlinternal.RateLimiterNode) bool {
+			traverse(child)
+			return true
+		})
+	}
+	traverse(q.rateLimiter.GetRootLimiters())
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.simulatedLimits = simulated
+}
+
+// clearSimulatedLimits clears the simulated limits after the simulate mode is turned off.
+func (q *QuotaCenter) clearSimulatedLimits() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.simulatedLimits == nil {
+		return
+	}
+	q.simulatedLimits = nil
+	metrics.RootCoordSimulatedQuotaStates.Reset()
+	metrics.RootCoordSimulatedRateLimit.Reset()
+}
@@ -0,0 +1,68 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestQuotaCenterSimulatedLimits(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, mockrootcoord.NewIMetaTable(t))
+	quotaCenter.dbs.Insert("db1", 1)
+	quotaCenter.collections.Insert(FormatCollectionKey(1, "coll10"), 10)
+
+	collLimiter := quotaCenter.rateLimiter.GetCollectionLimiters(1, 10)
+	insertLimiter, ok := collLimiter.GetLimiters().Get(internalpb.RateType_DMLInsert)
+	assert.True(t, ok)
+	insertLimiter.SetLimit(100)
+	collLimiter.GetQuotaStates().Insert(milvuspb.QuotaState_DenyToWrite, &rlinternal.QuotaStateInfo{
+		ErrorCode: commonpb.ErrorCode_DiskQuotaExhausted,
+		Reason:    "disk quota exhausted",
+	})
+
+	quotaCenter.recordSimulatedLimits(time.Unix(1000, 0))
+	resp := quotaCenter.getQuotaMetrics()
+	metrics := &metricsinfo.QuotaCenterMetrics{}
+	assert.NoError(t, metricsinfo.UnmarshalComponentInfos(resp.GetMetricsInfo(), metrics))
+	assert.NotNil(t, metrics.Simulated)
+	assert.Equal(t, int64(1000), metrics.Simulated.Timestamp)
+	assert.Len(t, metrics.Simulated.Nodes, 1)
+	node := metrics.Simulated.Nodes[0]
+	assert.Equal(t, "collection_coll10", node.Name)
+	assert.Equal(t, 100.0, node.Rates[internalpb.RateType_DMLInsert.String()])
+	assert.Equal(t, commonpb.ErrorCode_DiskQuotaExhausted.String(), node.States[milvuspb.QuotaState_DenyToWrite.String()])
+
+	quotaCenter.clearSimulatedLimits()
+	resp = quotaCenter.getQuotaMetrics()
+	metrics = &metricsinfo.QuotaCenterMetrics{}
+	assert.NoError(t, metricsinfo.UnmarshalComponentInfos(resp.GetMetricsInfo(), metrics))
+	assert.Nil(t, metrics.Simulated)
+}
@@ -0,0 +1,139 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"math"
+
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/ratelimitutil"
+)
+
+// slowQueryState is the dql cooling state of a collection caused by the slow query protection.
+type slowQueryState struct {
+	factor float64
+	// baseRates are the real dql rates of the collection when the protection is triggered,
+	// the dql limits are reduced progressively from them.
+	baseRates map[internalpb.RateType]float64
+}
+
+// getProxyCollectionRates returns the real time rates of the collections reported by all proxies for the label.
+func (q *QuotaCenter) getProxyCollectionRates(label string) map[int64]float64 {
+	rates := make(map[int64]float64)
+	for _, metric := range q.proxyMetrics {
+		for _, r := range metric.Rms {
+			mainLabel, dbName, collectionName, ok := ratelimitutil.SplitCollectionSubLabel(r.Label)
+			if !ok || mainLabel != label {
+				continue
+			}
+			dbID, ok := q.dbs.Get(dbName)
+			if !ok {
+				continue
+			}
+			collectionID, ok := q.collections.Get(FormatCollectionKey(dbID, collectionName))
+			if !ok {
+				continue
+			}
+			rates[collectionID] += r.Rate
+		}
+	}
+	return rates
+}
+
+// calculateSlowQueryRates reduces the dql limits of the collections whose slow query rate exceeds the threshold,
+// the limits are cooled off every round while the collection stays slow and recover once it's not.
+func (q *QuotaCenter) calculateSlowQueryRates() {
+	if !Params.QuotaConfig.SlowQueryProtectionEnabled.GetAsBool() {
+		q.slowQueryStates = make(map[int64]*slowQueryState)
+		return
+	}
+	maxSlowQueryRate := Params.QuotaConfig.MaxSlowQueryRate.GetAsFloat()
+	coolOffSpeed := Params.QuotaConfig.SlowQueryCoolOffSpeed.GetAsFloat()
+	minRateRatio := Params.QuotaConfig.SlowQueryMinRateRatio.GetAsFloat()
+
+	slowRates := q.getProxyCollectionRates(metricsinfo.DQLSlowQuery)
+	var searchRates, queryRates, getRates map[int64]float64
+	for collectionID, slowRate := range slowRates {
+		if slowRate <= maxSlowQueryRate {
+			continue
+		}
+		state, ok := q.slowQueryStates[collectionID]
+		if !ok {
+			if searchRates == nil {
+				searchRates = q.getProxyCollectionRates(internalpb.RateType_DQLSearch.String())
+				queryRates = q.getProxyCollectionRates(internalpb.RateType_DQLQuery.String())
+				getRates = q.getProxyCollectionRates(internalpb.RateType_DQLGet.String())
+			}
+			state = &slowQueryState{
+				factor: 1,
+				baseRates: map[internalpb.RateType]float64{
+					internalpb.RateType_DQLSearch: searchRates[collectionID],
+					internalpb.RateType_DQLQuery:  queryRates[collectionID],
+					internalpb.RateType_DQLGet:    getRates[collectionID],
+				},
+			}
+			q.slowQueryStates[collectionID] = state
+		}
+		state.factor = math.Max(state.factor*coolOffSpeed, minRateRatio)
+	}
+
+	for collectionID, state := range q.slowQueryStates {
+		dbID, ok := q.collectionIDToDBID.Get(collectionID)
+		if !ok {
+			// the collection is dropped or released.
+			delete(q.slowQueryStates, collectionID)
+			continue
+		}
+		if slowRates[collectionID] <= maxSlowQueryRate {
+			state.factor /= coolOffSpeed
+			if state.factor >= 1 {
+				delete(q.slowQueryStates, collectionID)
+				continue
+			}
+		}
+		collectionLimiter := q.rateLimiter.GetCollectionLimiters(dbID, collectionID)
+		if collectionLimiter == nil {
+			continue
+		}
+		for rt, baseRate := range state.baseRates {
+			v, ok := collectionLimiter.GetLimiters().Get(rt)
+			if !ok || baseRate <= 0 {
+				continue
+			}
+			if newLimit := Limit(baseRate * state.factor); newLimit < v.Limit() {
+				v.SetLimit(newLimit)
+			}
+		}
+		collectionProps := q.getCollectionLimitProperties(collectionID)
+		q.guaranteeMinRate(getCollectionRateLimitConfig(collectionProps, common.CollectionSearchRateMinKey),
+			internalpb.RateType_DQLSearch, collectionLimiter)
+		q.guaranteeMinRate(getCollectionRateLimitConfig(collectionProps, common.CollectionQueryRateMinKey),
+			internalpb.RateType_DQLQuery, collectionLimiter)
+		q.guaranteeMinRate(getCollectionRateLimitConfig(collectionProps, common.CollectionGetRateMinKey),
+			internalpb.RateType_DQLGet, collectionLimiter)
+		mlog.RatedWarn(q.ctx, rate.Limit(10), "QuotaCenter: limit reading due to high slow query rate",
+			mlog.FieldCollectionID(collectionID),
+			mlog.Float64("slowQueryRate", slowRates[collectionID]),
+			mlog.Float64("maxSlowQueryRate", maxSlowQueryRate),
+			mlog.Float64("factor", state.factor))
+	}
+}
@@ -0,0 +1,166 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/ratelimitutil"
+)
+
+func TestQuotaCenterSlowQueryRates(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	quotaCenter := newQuotaCenterForTesting(t, ctx, mockrootcoord.NewIMetaTable(t))
+	quotaCenter.dbs.Insert("db1", 1)
+	quotaCenter.collections.Insert(FormatCollectionKey(1, "coll10"), 10)
+	quotaCenter.collectionIDToDBID.Insert(10, 1)
+	quotaCenter.collectionProps[10] = map[string]string{}
+
+	subLabel := ratelimitutil.GetCollectionSubLabel("db1", "coll10")
+	setProxyRates := func(slowRate float64) {
+		quotaCenter.proxyMetrics = map[UniqueID]*metricsinfo.ProxyQuotaMetrics{
+			1: {Rms: []metricsinfo.RateMetric{
+				{Label: ratelimitutil.FormatSubLabel(metricsinfo.DQLSlowQuery, subLabel), Rate: slowRate},
+				{Label: ratelimitutil.FormatSubLabel(internalpb.RateType_DQLSearch.String(), subLabel), Rate: 100},
+				{Label: ratelimitutil.FormatSubLabel(internalpb.RateType_DQLQuery.String(), subLabel), Rate: 50},
+			}},
+		}
+	}
+	searchLimit := func() float64 {
+		limiter, ok := quotaCenter.rateLimiter.GetCollectionLimiters(1, 10).GetLimiters().Get(internalpb.RateType_DQLSearch)
+		assert.True(t, ok)
+		return float64(limiter.Limit())
+	}
+	resetLimits := func() {
+		for _, rt := range []internalpb.RateType{internalpb.RateType_DQLSearch, internalpb.RateType_DQLQuery} {
+			limiter, _ := quotaCenter.rateLimiter.GetCollectionLimiters(1, 10).GetLimiters().Get(rt)
+			limiter.SetLimit(Inf)
+		}
+	}
+
+	paramtable.Get().Save(Params.QuotaConfig.SlowQueryProtectionEnabled.Key, "true")
+	paramtable.Get().Save(Params.QuotaConfig.MaxSlowQueryRate.Key, "5")
+	paramtable.Get().Save(Params.QuotaConfig.SlowQueryCoolOffSpeed.Key, "0.5")
+	paramtable.Get().Save(Params.QuotaConfig.SlowQueryMinRateRatio.Key, "0.2")
+	defer paramtable.Get().Reset(Params.QuotaConfig.SlowQueryProtectionEnabled.Key)
+	defer paramtable.Get().Reset(Params.QuotaConfig.MaxSlowQueryRate.Key)
+	defer paramtable.Get().Reset(Params.QuotaConfig.SlowQueryCoolOffSpeed.Key)
+	defer paramtable.Get().Reset(Params.QuotaConfig.SlowQueryMinRateRatio.Key)
+
+	t.Run("not slow", func(t *testing.T) {
+		resetLimits()
+		setProxyRates(5)
+		quotaCenter.calculateSlowQueryRates()
+		assert.Empty(t, quotaCenter.slowQueryStates)
+		assert.Equal(t, float64(Inf), searchLimit())
+	})
+
+	t.Run("cool off and recover", func(t *testing.T) {
+		resetLimits()
+		setProxyRates(10)
+		quotaCenter.calculateSlowQueryRates()
+		assert.Equal(t, 0.5, quotaCenter.slowQueryStates[10].factor)
+		assert.Equal(t, 50.0, searchLimit())
+		queryLimiter, _ := quotaCenter.rateLimiter.GetCollectionLimiters(1, 10).GetLimiters().Get(internalpb.RateType_DQLQuery)
+		assert.Equal(t, Limit(25), queryLimiter.Limit())
+
+		// the base rates are kept while the collection stays slow.
+		resetLimits()
+		quotaCenter.proxyMetrics[1].Rms[1].Rate = 10
+		quotaCenter.calculateSlowQueryRates()
+		assert.Equal(t, 0.25, quotaCenter.slowQueryStates[10].factor)
+		assert.Equal(t, 25.0, searchLimit())
+
+		resetLimits()
+		quotaCenter.calculateSlowQueryRates()
+		assert.Equal(t, 0.2, quotaCenter.slowQueryStates[10].factor)
+		assert.Equal(t, 20.0, searchLimit())
+
+		resetLimits()
+		setProxyRates(0)
+		quotaCenter.calculateSlowQueryRates()
+		assert.Equal(t, 0.4, quotaCenter.slowQueryStates[10].factor)
+		assert.Equal(t, 40.0, searchLimit())
+
+		resetLimits()
+		quotaCenter.calculateSlowQueryRates()
+		quotaCenter.calculateSlowQueryRates()
+		assert.Empty(t, quotaCenter.slowQueryStates)
+		assert.Equal(t, float64(Inf), searchLimit())
+	})
+
+	t.Run("min rate", func(t *testing.T) {
+		resetLimits()
+		quotaCenter.collectionProps[10] = map[string]string{common.CollectionSearchRateMinKey: "80"}
+		defer func() { quotaCenter.collectionProps[10] = map[string]string{} }()
+		setProxyRates(10)
+		quotaCenter.calculateSlowQueryRates()
+		assert.Equal(t, 80.0, searchLimit())
+		quotaCenter.slowQueryStates = make(map[int64]*slowQueryState)
+	})
+
+	t.Run("db guaranteed rate", func(t *testing.T) {
+		paramtable.Get().Save(Params.QuotaConfig.DQLGuaranteedSearchRatePerDB.Key, "160")
+		defer paramtable.Get().Reset(Params.QuotaConfig.DQLGuaranteedSearchRatePerDB.Key)
+		quotaCenter.readableCollections = map[int64]map[int64][]int64{1: {10: {100}, 11: {110}}}
+		defer func() { quotaCenter.readableCollections = nil }()
+
+		resetLimits()
+		setProxyRates(10)
+		quotaCenter.calculateSlowQueryRates()
+		assert.Equal(t, 50.0, searchLimit())
+		// the databases denied to read are not guaranteed
+		quotaCenter.guaranteeDatabaseReadRates(map[int64]struct{}{1: {}})
+		assert.Equal(t, 50.0, searchLimit())
+		// the guaranteed rate is shared by the collections of the database
+		quotaCenter.guaranteeDatabaseReadRates(nil)
+		assert.Equal(t, 80.0, searchLimit())
+		queryLimiter, _ := quotaCenter.rateLimiter.GetCollectionLimiters(1, 10).GetLimiters().Get(internalpb.RateType_DQLQuery)
+		assert.Equal(t, Limit(25), queryLimiter.Limit())
+		quotaCenter.slowQueryStates = make(map[int64]*slowQueryState)
+	})
+
+	t.Run("dropped collection", func(t *testing.T) {
+		resetLimits()
+		setProxyRates(10)
+		quotaCenter.calculateSlowQueryRates()
+		assert.Len(t, quotaCenter.slowQueryStates, 1)
+		quotaCenter.collectionIDToDBID.Remove(10)
+		defer quotaCenter.collectionIDToDBID.Insert(10, 1)
+		quotaCenter.calculateSlowQueryRates()
+		assert.Empty(t, quotaCenter.slowQueryStates)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		resetLimits()
+		setProxyRates(10)
+		quotaCenter.calculateSlowQueryRates()
+		assert.Len(t, quotaCenter.slowQueryStates, 1)
+		paramtable.Get().Save(Params.QuotaConfig.SlowQueryProtectionEnabled.Key, "false")
+		quotaCenter.calculateSlowQueryRates()
+		assert.Empty(t, quotaCenter.slowQueryStates)
+	})
+}
@@ -0,0 +1,115 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+)
+
+// the names of the write rate factors calculated by the protections.
+const (
+	writeFactorTimeTickDelay        = "time_tick_delay"
+	writeFactorMemory               = "memory"
+	writeFactorGrowingSegmentsSize  = "growing_segments_size"
+	writeFactorL0SegmentsRowCount   = "l0_segments_row_count"
+	writeFactorDeleteBufferRowCount = "delete_buffer_row_count"
+	writeFactorDeleteBufferSize     = "delete_buffer_size"
+	writeFactorReplicationLag       = "replication_lag"
+	writeFactorDisk                 = "disk"
+	writeFactorNodeQuarantine       = "node_quarantine"
+	writeFactorDenyCooldown         = "deny_cooldown"
+	writeFactorSmallSegmentNum      = "small_segment_num"
+)
+
+// writeFactorRecorder collects the write rate factors of a round of rate calculation by each protection,
+// so that it's able to tell which protection limits the write rates. All the methods are no-op on nil.
+type writeFactorRecorder struct {
+	collections map[int64]map[string]float64
+	nodes       map[string]map[string]float64
+}
+
+func newWriteFactorRecorder() *writeFactorRecorder {
+	return &writeFactorRecorder{
+		collections: make(map[int64]map[string]float64),
+		nodes:       make(map[string]map[string]float64),
+	}
+}
+
+// recordCollections records the factors of the collections calculated by the protection.
+func (r *writeFactorRecorder) recordCollections(name string, factors map[int64]float64) {
+	for collection, factor := range factors {
+		r.recordCollection(collection, name, factor)
+	}
+}
+
+func (r *writeFactorRecorder) recordCollection(collection int64, name string, factor float64) {
+	if r == nil {
+		return
+	}
+	if _, ok := r.collections[collection]; !ok {
+		r.collections[collection] = make(map[string]float64)
+	}
+	r.collections[collection][name] = factor
+}
+
+// recordNode records the factor calculated by the metrics of the node.
+func (r *writeFactorRecorder) recordNode(role string, nodeID int64, name string, factor float64) {
+	if r == nil {
+		return
+	}
+	node := fmt.Sprintf("%s-%d", role, nodeID)
+	if _, ok := r.nodes[node]; !ok {
+		r.nodes[node] = make(map[string]float64)
+	}
+	r.nodes[node][name] = factor
+	metrics.RootCoordNodeWriteFactor.WithLabelValues(role, strconv.FormatInt(nodeID, 10), name).Set(factor)
+}
+
+// startRecordWriteFactors starts to record the write factors of a new round of rate calculation.
+func (q *QuotaCenter) startRecordWriteFactors() {
+	metrics.RootCoordNodeWriteFactor.Reset()
+	q.writeFactorRecorder = newWriteFactorRecorder()
+}
+
+// finishRecordWriteFactors exports the recorded write factors by the metrics and the quota metrics.
+func (q *QuotaCenter) finishRecordWriteFactors(now time.Time) {
+	r := q.writeFactorRecorder
+	if r == nil {
+		return
+	}
+	q.writeFactorRecorder = nil
+
+	metrics.RootCoordCollectionWriteFactor.Reset()
+	for collection, factors := range r.collections {
+		for name, factor := range factors {
+			metrics.RootCoordCollectionWriteFactor.WithLabelValues(strconv.FormatInt(collection, 10), name).Set(factor)
+		}
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.writeFactors = &metricsinfo.QuotaWriteFactors{
+		Timestamp:   now.Unix(),
+		Collections: r.collections,
+		Nodes:       r.nodes,
+	}
+}
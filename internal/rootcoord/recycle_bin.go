@@ -0,0 +1,382 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/json"
+	kvmetastore "github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/kv"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// recycleBinPrefix is the meta prefix of the soft-deleted collections.
+const recycleBinPrefix = kvmetastore.ComponentPrefix + "/recycle-bin"
+
+// RecycledCollection is a collection dropped in a database with soft delete enabled,
+// it's renamed to a hidden name and can be undropped until it expires.
+type RecycledCollection struct {
+	CollectionID   int64     `json:"collection_id"`
+	DbName         string    `json:"db_name"`
+	CollectionName string    `json:"collection_name"`
+	RecycledName   string    `json:"recycled_name"`
+	DroppedAt      time.Time `json:"dropped_at"`
+	ExpireAt       time.Time `json:"expire_at"`
+}
+
+// recycledCollectionName returns the hidden name of the soft-deleted collection.
+func recycledCollectionName(collectionID int64) string {
+	return fmt.Sprintf("__recycled_%d", collectionID)
+}
+
+// recycleBin keeps the soft-deleted collections in the metastore with an in-memory copy.
+type recycleBin struct {
+	kv          kv.TxnKV
+	mu          sync.RWMutex
+	collections map[int64]*RecycledCollection
+}
+
+func newRecycleBin(kv kv.TxnKV) *recycleBin {
+	return &recycleBin{
+		kv:          kv,
+		collections: make(map[int64]*RecycledCollection),
+	}
+}
+
+func recycleBinKey(collectionID int64) string {
+	return path.Join(recycleBinPrefix, strconv.FormatInt(collectionID, 10))
+}
+
+// Load loads the soft-deleted collections from the metastore.
+func (b *recycleBin) Load(ctx context.Context) error {
+	_, values, err := b.kv.LoadWithPrefix(ctx, recycleBinPrefix+"/")
+	if err != nil {
+		return err
+	}
+	collections := make(map[int64]*RecycledCollection, len(values))
+	for _, value := range values {
+		recycled := &RecycledCollection{}
+		if err := json.Unmarshal([]byte(value), recycled); err != nil {
+			return err
+		}
+		collections[recycled.CollectionID] = recycled
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.collections = collections
+	return nil
+}
+
+// Add puts the soft-deleted collection into the recycle bin.
+func (b *recycleBin) Add(ctx context.Context, recycled *RecycledCollection) error {
+	value, err := json.Marshal(recycled)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.kv.Save(ctx, recycleBinKey(recycled.CollectionID), string(value)); err != nil {
+		return err
+	}
+	b.collections[recycled.CollectionID] = recycled
+	return nil
+}
+
+// Remove removes the collection from the recycle bin once it's undropped or dropped permanently.
+func (b *recycleBin) Remove(ctx context.Context, collectionID int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.kv.Remove(ctx, recycleBinKey(collectionID)); err != nil {
+		return err
+	}
+	delete(b.collections, collectionID)
+	return nil
+}
+
+// Contains returns whether the collection is in the recycle bin.
+func (b *recycleBin) Contains(collectionID int64) bool {
+	if b == nil {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.collections[collectionID]
+	return ok
+}
+
+// Find returns the latest dropped collection with the original name in the database.
+func (b *recycleBin) Find(dbName string, collectionName string) (*RecycledCollection, bool) {
+	var found *RecycledCollection
+	for _, recycled := range b.List(dbName) {
+		if recycled.CollectionName == collectionName {
+			found = recycled
+		}
+	}
+	return found, found != nil
+}
+
+// List returns the soft-deleted collections of the database in drop order, or of all databases if dbName is empty.
+func (b *recycleBin) List(dbName string) []*RecycledCollection {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	collections := make([]*RecycledCollection, 0, len(b.collections))
+	for _, recycled := range b.collections {
+		if dbName == "" || recycled.DbName == dbName {
+			collections = append(collections, recycled)
+		}
+	}
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].DroppedAt.Before(collections[j].DroppedAt)
+	})
+	return collections
+}
+
+// Expired returns the soft-deleted collections whose undrop window has expired.
+func (b *recycleBin) Expired(now time.Time) []*RecycledCollection {
+	collections := b.List("")
+	expired := make([]*RecycledCollection, 0)
+	for _, recycled := range collections {
+		if !now.Before(recycled.ExpireAt) {
+			expired = append(expired, recycled)
+		}
+	}
+	return expired
+}
+
+// checkDeletionProtection rejects the drop if the database has deletion protection enabled,
+// unless the request overrides it in the base properties.
+func checkDeletionProtection(db *model.Database, base *commonpb.MsgBase) error {
+	if !common.IsDatabaseDeletionProtected(db.Properties...) {
+		return nil
+	}
+	if base.GetProperties()[common.DeletionProtectionOverrideKey] == "true" {
+		return nil
+	}
+	return merr.WrapErrParameterInvalidMsg("database %s has deletion protection enabled, set %s to drop it anyway",
+		db.Name, common.DeletionProtectionOverrideKey)
+}
+
+// softDropCollection moves the collection into the recycle bin if its database has soft delete enabled,
+// it returns false if the collection should be dropped permanently.
+func (c *Core) softDropCollection(ctx context.Context, req *milvuspb.DropCollectionRequest) (bool, error) {
+	if c.recycleBin == nil {
+		return false, nil
+	}
+	coll, err := c.meta.GetCollectionByName(ctx, req.GetDbName(), req.GetCollectionName(), typeutil.MaxTimestamp, false)
+	if err != nil {
+		// let the permanent drop handle the missing collection.
+		return false, nil
+	}
+	if c.recycleBin.Contains(coll.CollectionID) {
+		// dropping a soft-deleted collection drops it permanently.
+		return false, nil
+	}
+	db, err := c.meta.GetDatabaseByID(ctx, coll.DBID, typeutil.MaxTimestamp)
+	if err != nil {
+		return false, err
+	}
+	window, err := common.DatabaseSoftDeleteWindow(db.Properties...)
+	if err != nil || window == 0 {
+		return false, err
+	}
+	if err := checkDeletionProtection(db, req.GetBase()); err != nil {
+		return false, err
+	}
+	if aliases := c.meta.ListAliasesByID(ctx, coll.CollectionID); len(aliases) > 0 {
+		return false, merr.WrapErrParameterInvalidMsg("unable to drop the collection [%s] because it has associated aliases %v, please remove all aliases before dropping the collection", req.GetCollectionName(), aliases)
+	}
+
+	// the soft-deleted collection is not served anymore, it's not loaded again after the undrop.
+	if err := c.broker.ReleaseCollection(ctx, coll.CollectionID); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	recycled := &RecycledCollection{
+		CollectionID:   coll.CollectionID,
+		DbName:         db.Name,
+		CollectionName: coll.Name,
+		RecycledName:   recycledCollectionName(coll.CollectionID),
+		DroppedAt:      now,
+		ExpireAt:       now.Add(window),
+	}
+	// record the collection before renaming it, so a failed rename leaves a record that purges nothing.
+	if err := c.recycleBin.Add(ctx, recycled); err != nil {
+		return false, err
+	}
+	// the collection is marked recycled with the rename, so the writes to it are rejected by the proxies.
+	if err := c.broadcastRenameCollection(ctx, &milvuspb.RenameCollectionRequest{
+		DbName:  db.Name,
+		OldName: coll.Name,
+		NewName: recycled.RecycledName,
+	}, func(props []*commonpb.KeyValuePair) []*commonpb.KeyValuePair {
+		return append(removeRecycledMark(props), &commonpb.KeyValuePair{Key: common.CollectionRecycledKey, Value: "true"})
+	}); err != nil {
+		if removeErr := c.recycleBin.Remove(ctx, coll.CollectionID); removeErr != nil {
+			mlog.Warn(ctx, "failed to remove the collection from recycle bin", mlog.FieldCollectionID(coll.CollectionID), mlog.Err(removeErr))
+		}
+		return false, err
+	}
+	mlog.Info(ctx, "collection is moved into recycle bin",
+		mlog.String("dbName", db.Name),
+		mlog.String("collectionName", coll.Name),
+		mlog.FieldCollectionID(coll.CollectionID),
+		mlog.Time("expireAt", recycled.ExpireAt))
+	return true, nil
+}
+
+// UndropCollection restores the latest soft-deleted collection with the name in the database.
+func (c *Core) UndropCollection(ctx context.Context, dbName string, collectionName string) (*RecycledCollection, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return nil, err
+	}
+	if dbName == "" {
+		dbName = util.DefaultDBName
+	}
+	recycled, ok := c.recycleBin.Find(dbName, collectionName)
+	if !ok {
+		return nil, merr.WrapErrCollectionNotFoundWithDB(dbName, collectionName)
+	}
+	if err := c.broadcastRenameCollection(ctx, &milvuspb.RenameCollectionRequest{
+		DbName:  recycled.DbName,
+		OldName: recycled.RecycledName,
+		NewName: recycled.CollectionName,
+	}, removeRecycledMark); err != nil {
+		return nil, err
+	}
+	if err := c.recycleBin.Remove(ctx, recycled.CollectionID); err != nil {
+		return nil, err
+	}
+	mlog.Info(ctx, "collection is undropped from recycle bin",
+		mlog.String("dbName", recycled.DbName),
+		mlog.String("collectionName", recycled.CollectionName),
+		mlog.FieldCollectionID(recycled.CollectionID))
+	return recycled, nil
+}
+
+// ListRecycledCollections returns the soft-deleted collections of the database, or of all databases if dbName is empty.
+func (c *Core) ListRecycledCollections(ctx context.Context, dbName string) ([]*RecycledCollection, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return nil, err
+	}
+	return c.recycleBin.List(dbName), nil
+}
+
+// removeRecycledMark returns the collection properties without the recycled mark.
+func removeRecycledMark(props []*commonpb.KeyValuePair) []*commonpb.KeyValuePair {
+	return lo.Filter(props, func(kv *commonpb.KeyValuePair, _ int) bool {
+		return kv.GetKey() != common.CollectionRecycledKey
+	})
+}
+
+// dropRecycledCollection drops the soft-deleted collection permanently and removes it from the recycle bin.
+func (c *Core) dropRecycledCollection(ctx context.Context, recycled *RecycledCollection) error {
+	err := c.broadcastDropCollectionV1(ctx, &milvuspb.DropCollectionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:    commonpb.MsgType_DropCollection,
+			Properties: map[string]string{common.DeletionProtectionOverrideKey: "true"},
+		},
+		DbName:         recycled.DbName,
+		CollectionName: recycled.RecycledName,
+	})
+	if err != nil && !errors.Is(err, errIgnoredDropCollection) {
+		return err
+	}
+	if err := c.recycleBin.Remove(ctx, recycled.CollectionID); err != nil {
+		return err
+	}
+	mlog.Info(ctx, "collection in recycle bin is dropped permanently",
+		mlog.String("dbName", recycled.DbName),
+		mlog.String("collectionName", recycled.CollectionName),
+		mlog.FieldCollectionID(recycled.CollectionID))
+	return nil
+}
+
+// dropRecycledCollectionsOfDatabase drops the soft-deleted collections of the database permanently before the database
+// is dropped, since a database with collections can't be dropped. They're kept if the database has other collections
+// or is protected from deletion, and the drop of the database fails later.
+func (c *Core) dropRecycledCollectionsOfDatabase(ctx context.Context, req *milvuspb.DropDatabaseRequest) error {
+	if c.recycleBin == nil {
+		return nil
+	}
+	recycledCollections := c.recycleBin.List(req.GetDbName())
+	if len(recycledCollections) == 0 {
+		return nil
+	}
+	db, err := c.meta.GetDatabaseByName(ctx, req.GetDbName(), typeutil.MaxTimestamp)
+	if err != nil {
+		// let the drop of the database handle the missing database.
+		return nil
+	}
+	if err := checkDeletionProtection(db, req.GetBase()); err != nil {
+		return err
+	}
+	colls, err := c.meta.ListCollections(ctx, db.Name, typeutil.MaxTimestamp, false)
+	if err != nil {
+		return err
+	}
+	if lo.ContainsBy(colls, func(coll *model.Collection) bool { return !c.recycleBin.Contains(coll.CollectionID) }) {
+		return nil
+	}
+	for _, recycled := range recycledCollections {
+		if err := c.dropRecycledCollection(ctx, recycled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeRecycleBin drops the expired soft-deleted collections permanently.
+func (c *Core) purgeRecycleBin(ctx context.Context) {
+	for _, recycled := range c.recycleBin.Expired(time.Now()) {
+		if err := c.dropRecycledCollection(ctx, recycled); err != nil {
+			mlog.Warn(ctx, "failed to purge the collection in recycle bin", mlog.FieldCollectionID(recycled.CollectionID), mlog.Err(err))
+		}
+	}
+}
+
+func (c *Core) startRecycleBinPurgeLoop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(Params.RootCoordCfg.RecycleBinPurgeInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			mlog.Info(c.ctx, "rootcoord's recycle bin purge loop quit!")
+			return
+		case <-ticker.C:
+			c.purgeRecycleBin(c.ctx)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestRecycleBin(t *testing.T) {
+	ctx := context.Background()
+	kv := memkv.NewMemoryKV()
+	bin := newRecycleBin(kv)
+
+	var nilBin *recycleBin
+	assert.False(t, nilBin.Contains(1))
+
+	now := time.Now()
+	assert.NoError(t, bin.Add(ctx, &RecycledCollection{
+		CollectionID:   1,
+		DbName:         "db1",
+		CollectionName: "coll",
+		RecycledName:   recycledCollectionName(1),
+		DroppedAt:      now.Add(-2 * time.Hour),
+		ExpireAt:       now.Add(-time.Hour),
+	}))
+	assert.NoError(t, bin.Add(ctx, &RecycledCollection{
+		CollectionID:   2,
+		DbName:         "db1",
+		CollectionName: "coll",
+		RecycledName:   recycledCollectionName(2),
+		DroppedAt:      now,
+		ExpireAt:       now.Add(time.Hour),
+	}))
+	assert.NoError(t, bin.Add(ctx, &RecycledCollection{
+		CollectionID:   3,
+		DbName:         "db2",
+		CollectionName: "other",
+		RecycledName:   recycledCollectionName(3),
+		DroppedAt:      now,
+		ExpireAt:       now.Add(time.Hour),
+	}))
+
+	assert.True(t, bin.Contains(1))
+	assert.False(t, bin.Contains(4))
+	assert.Len(t, bin.List(""), 3)
+	assert.Len(t, bin.List("db1"), 2)
+
+	// the latest dropped collection with the name is found.
+	recycled, ok := bin.Find("db1", "coll")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), recycled.CollectionID)
+	_, ok = bin.Find("db2", "coll")
+	assert.False(t, ok)
+
+	expired := bin.Expired(now)
+	assert.Len(t, expired, 1)
+	assert.Equal(t, int64(1), expired[0].CollectionID)
+
+	// the recycle bin is recovered from the metastore.
+	recovered := newRecycleBin(kv)
+	assert.NoError(t, recovered.Load(ctx))
+	assert.Len(t, recovered.List(""), 3)
+	assert.Equal(t, "__recycled_3", recovered.List("db2")[0].RecycledName)
+
+	assert.NoError(t, bin.Remove(ctx, 1))
+	assert.False(t, bin.Contains(1))
+	assert.NoError(t, recovered.Load(ctx))
+	assert.Len(t, recovered.List(""), 2)
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	db := &model.Database{Name: "db1"}
+	assert.NoError(t, checkDeletionProtection(db, nil))
+
+	db.Properties = []*commonpb.KeyValuePair{{Key: common.DatabaseDeletionProtectionKey, Value: "true"}}
+	assert.ErrorIs(t, checkDeletionProtection(db, nil), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, checkDeletionProtection(db, &commonpb.MsgBase{
+		Properties: map[string]string{common.DeletionProtectionOverrideKey: "false"},
+	}), merr.ErrParameterInvalid)
+	assert.NoError(t, checkDeletionProtection(db, &commonpb.MsgBase{
+		Properties: map[string]string{common.DeletionProtectionOverrideKey: "true"},
+	}))
+}
+
+func TestRemoveRecycledMark(t *testing.T) {
+	props := []*commonpb.KeyValuePair{
+		{Key: common.CollectionTTLConfigKey, Value: "3600"},
+		{Key: common.CollectionRecycledKey, Value: "true"},
+	}
+	assert.Equal(t, []*commonpb.KeyValuePair{{Key: common.CollectionTTLConfigKey, Value: "3600"}}, removeRecycledMark(props))
+	assert.Empty(t, removeRecycledMark(nil))
+}
+
+func TestDropRecycledCollectionsOfDatabase(t *testing.T) {
+	ctx := context.Background()
+	req := &milvuspb.DropDatabaseRequest{DbName: "db1"}
+
+	// no recycle bin
+	core := newTestCore()
+	assert.NoError(t, core.dropRecycledCollectionsOfDatabase(ctx, req))
+
+	meta := mockrootcoord.NewIMetaTable(t)
+	core = newTestCore(withMeta(meta))
+	core.recycleBin = newRecycleBin(memkv.NewMemoryKV())
+	// no recycled collection in the database
+	assert.NoError(t, core.dropRecycledCollectionsOfDatabase(ctx, req))
+
+	assert.NoError(t, core.recycleBin.Add(ctx, &RecycledCollection{
+		CollectionID:   1,
+		DbName:         "db1",
+		CollectionName: "coll",
+		RecycledName:   recycledCollectionName(1),
+	}))
+
+	// the database is protected from deletion
+	meta.EXPECT().GetDatabaseByName(mock.Anything, "db1", mock.Anything).Return(&model.Database{
+		Name:       "db1",
+		Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseDeletionProtectionKey, Value: "true"}},
+	}, nil).Once()
+	assert.ErrorIs(t, core.dropRecycledCollectionsOfDatabase(ctx, req), merr.ErrParameterInvalid)
+	assert.True(t, core.recycleBin.Contains(1))
+
+	// the database has other collections
+	meta.EXPECT().GetDatabaseByName(mock.Anything, "db1", mock.Anything).Return(&model.Database{Name: "db1"}, nil).Once()
+	meta.EXPECT().ListCollections(mock.Anything, "db1", mock.Anything, false).Return([]*model.Collection{
+		{CollectionID: 1},
+		{CollectionID: 2},
+	}, nil).Once()
+	assert.NoError(t, core.dropRecycledCollectionsOfDatabase(ctx, req))
+	assert.True(t, core.recycleBin.Contains(1))
+}
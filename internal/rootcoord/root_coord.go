@@ -140,6 +140,8 @@ type Core struct {
 	scheduler        IScheduler
 	broker           Broker
 	ddlTsLockManager DdlTsLockManager
+	// budgets the timestamp allocations of each database
+	tsoDBLimiter *tsoDBLimiter
 
 	metaKVCreator metaKVCreator
 
@@ -159,6 +161,13 @@ type Core struct {
 	quotaCenter    *QuotaCenter
 	keyManager     *KeyManager
 
+	propertyPresets *propertyPresetManager
+	propertyHistory *propertyHistoryManager
+	recycleBin      *recycleBin
+	quotaExemptions *quotaExemptionManager
+	// credentialScopes restricts the users to the collections and operation classes in their scopes.
+	credentialScopes *credentialScopes
+
 	stateCode atomic.Int32
 	initOnce  sync.Once
 	startOnce sync.Once
@@ -482,7 +491,20 @@ func (c *Core) initInternal() error {
 		return err
 	}
 
+	c.propertyPresets = newPropertyPresetManager(c.metaKVCreator())
+	c.propertyHistory = newPropertyHistoryManager(c.metaKVCreator())
+	c.recycleBin = newRecycleBin(c.metaKVCreator())
+	if err := c.recycleBin.Load(initCtx); err != nil {
+		return err
+	}
+	c.credentialScopes = newCredentialScopes(c.metaKVCreator())
+	if err := c.credentialScopes.Load(initCtx); err != nil {
+		return err
+	}
+	c.quotaExemptions = newQuotaExemptionManager()
+
 	c.scheduler = newScheduler(c.ctx, c.idAllocator, c.tsoAllocator)
+	c.tsoDBLimiter = newTSODBLimiter()
 
 	c.factory.Init(Params)
 	chanMap := c.meta.ListCollectionPhysicalChannels(c.ctx)
@@ -510,6 +532,7 @@ func (c *Core) initInternal() error {
 	mlog.Debug(context.TODO(), "init telemetry manager done")
 
 	c.quotaCenter = NewQuotaCenter(c.proxyClientManager, c.mixCoord, c.tsoAllocator, c.meta)
+	c.quotaCenter.SetReplicateCheckpointCollector(newReplicateCheckpointCollector(c.etcdCli))
 	mlog.Debug(context.TODO(), "RootCoord init QuotaCenter done")
 
 	// Initialize KeyManager for KMS key state management
@@ -750,10 +773,11 @@ func (c *Core) startInternal() error {
 }
 
 func (c *Core) startServerLoop() {
-	c.wg.Add(3)
+	c.wg.Add(4)
 	go c.tsLoop()
 	go c.startTimeTickLoop()
 	go c.chanTimeTick.startWatch(&c.wg)
+	go c.startRecycleBinPurgeLoop()
 }
 
 // Start starts RootCoord.
@@ -903,7 +927,11 @@ func (c *Core) DropDatabase(ctx context.Context, in *milvuspb.DropDatabaseReques
 	mlog.Info(ctx, "received request to drop database", mlog.String("role", typeutil.RootCoordRole),
 		mlog.String("dbName", in.GetDbName()), mlog.Int64("msgID", in.GetBase().GetMsgID()))
 
-	if err := c.broadcastDropDatabase(ctx, in); err != nil {
+	err := c.dropRecycledCollectionsOfDatabase(ctx, in)
+	if err == nil {
+		err = c.broadcastDropDatabase(ctx, in)
+	}
+	if err != nil {
 		if errors.Is(err, merr.ErrDatabaseNotFound) {
 			mlog.Info(ctx, "drop a database that not found, ignore it", mlog.String("dbName", in.GetDbName()))
 			return merr.Success(), nil
@@ -919,6 +947,7 @@ func (c *Core) DropDatabase(ctx context.Context, in *milvuspb.DropDatabaseReques
 	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.SuccessLabel).Inc()
 	metrics.RootCoordDDLReqLatency.WithLabelValues(method).Observe(float64(tr.ElapseSpan().Milliseconds()))
 	metrics.CleanupRootCoordDBMetrics(in.GetDbName())
+	c.tsoDBLimiter.Remove(in.GetDbName())
 	mlog.Info(ctx, "done to drop database", mlog.String("role", typeutil.RootCoordRole),
 		mlog.String("dbName", in.GetDbName()), mlog.Int64("msgID", in.GetBase().GetMsgID()))
 	return merr.Success(), nil
@@ -976,6 +1005,14 @@ func (c *Core) CreateCollection(ctx context.Context, in *milvuspb.CreateCollecti
 		mlog.String("collectionName", in.GetCollectionName()))
 	logger.Info(ctx, "received request to create collection")
 
+	properties, err := c.expandPropertyPreset(ctx, in.GetProperties())
+	if err != nil {
+		logger.Info(ctx, "failed to expand property preset", mlog.Err(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues("CreateCollection", metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+	in.Properties = properties
+
 	if err := c.broadcastCreateCollectionV1(ctx, in); err != nil {
 		if errors.Is(err, errIgnoredCreateCollection) {
 			logger.Info(ctx, "create existed collection with same schema, ignore it")
@@ -1080,6 +1117,17 @@ func (c *Core) DropCollection(ctx context.Context, in *milvuspb.DropCollectionRe
 		mlog.String("name", in.GetCollectionName()))
 	logger.Info(ctx, "received request to drop collection")
 
+	if recycled, err := c.softDropCollection(ctx, in); err != nil || recycled {
+		if err != nil {
+			logger.Info(ctx, "failed to move collection into recycle bin", mlog.Err(err))
+			metrics.RootCoordDDLReqCounter.WithLabelValues("DropCollection", metrics.FailLabel).Inc()
+			return merr.Status(err), nil
+		}
+		metrics.RootCoordDDLReqCounter.WithLabelValues("DropCollection", metrics.SuccessLabel).Inc()
+		logger.Info(ctx, "done to move collection into recycle bin")
+		return merr.Success(), nil
+	}
+
 	if err := c.broadcastDropCollectionV1(ctx, in); err != nil {
 		if errors.Is(err, errIgnoredDropCollection) {
 			logger.Info(ctx, "drop collection that not found, ignore it")
@@ -1400,6 +1448,14 @@ func (c *Core) AlterCollection(ctx context.Context, in *milvuspb.AlterCollection
 
 	mlog.Info(context.TODO(), "received request to alter collection")
 
+	properties, err := c.expandPropertyPreset(ctx, in.GetProperties())
+	if err != nil {
+		mlog.Warn(context.TODO(), "failed to expand property preset", mlog.Err(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues("AlterCollection", metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+	in.Properties = properties
+
 	if err := c.validateResourceGroups(ctx, in.GetProperties(), "collection"); err != nil {
 		mlog.Warn(context.TODO(), "failed to validate resource groups", mlog.Err(err))
 		metrics.RootCoordDDLReqCounter.WithLabelValues("AlterCollection", metrics.FailLabel).Inc()
@@ -1759,6 +1815,14 @@ func (c *Core) AllocTimestamp(ctx context.Context, in *rootcoordpb.AllocTimestam
 		}
 	}
 
+	if err := c.tsoDBLimiter.Check(in.GetDbName(), in.GetCount()); err != nil {
+		mlog.RatedWarn(ctx, 10, "timestamp allocation of database rejected", mlog.String("dbName", in.GetDbName()),
+			mlog.Uint32("count", in.GetCount()), mlog.Err(err))
+		return &rootcoordpb.AllocTimestampResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
 	ts, err := c.tsoAllocator.GenerateTSO(in.GetCount())
 	physicalTs, _ := tsoutil.ParseTS(ts)
 	if err != nil {
@@ -2745,10 +2809,11 @@ func (c *Core) ListPolicy(ctx context.Context, in *internalpb.ListPolicyRequest)
 	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.SuccessLabel).Inc()
 	metrics.RootCoordDDLReqLatency.WithLabelValues(method).Observe(float64(tr.ElapseSpan().Milliseconds()))
 	return &internalpb.ListPolicyResponse{
-		Status:          merr.Success(),
-		PolicyInfos:     expandPolicies,
-		UserRoles:       userRoles,
-		PrivilegeGroups: allGroups,
+		Status:           merr.Success(),
+		PolicyInfos:      expandPolicies,
+		UserRoles:        userRoles,
+		PrivilegeGroups:  allGroups,
+		CredentialScopes: c.credentialScopes.List(),
 	}, nil
 }
 
@@ -73,6 +73,10 @@ func (t *showCollectionTask) Execute(ctx context.Context) error {
 		if !isVisibleCollectionForCurUser(coll.Name, visibleCollections) {
 			continue
 		}
+		// the soft-deleted collections are hidden until they're undropped.
+		if t.core.recycleBin.Contains(coll.CollectionID) {
+			continue
+		}
 
 		t.Rsp.CollectionNames = append(t.Rsp.CollectionNames, coll.Name)
 		t.Rsp.CollectionIds = append(t.Rsp.CollectionIds, coll.CollectionID)
@@ -0,0 +1,196 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// DatabaseStorageUsage is the storage usage of one database.
+type DatabaseStorageUsage struct {
+	DBID          int64  `json:"db_id"`
+	DBName        string `json:"db_name"`
+	CollectionNum int    `json:"collection_num"`
+	BinlogSize    int64  `json:"binlog_size"`
+	DeltaLogSize  int64  `json:"delta_log_size"`
+	IndexSize     int64  `json:"index_size"`
+	TotalSize     int64  `json:"total_size"`
+}
+
+// StorageUsageReport is the storage usage of all databases at the report time.
+type StorageUsageReport struct {
+	ReportTime int64                   `json:"report_time"` // unix milliseconds
+	Databases  []*DatabaseStorageUsage `json:"databases"`
+}
+
+// getStorageUsageReport aggregates the per-collection sizes reported by datacoord into per-database usage.
+func (q *QuotaCenter) getStorageUsageReport() *StorageUsageReport {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	q.diskMu.Lock()
+	defer q.diskMu.Unlock()
+
+	dbNames := make(map[int64]string, q.dbs.Len())
+	q.dbs.Range(func(name string, id int64) bool {
+		dbNames[id] = name
+		return true
+	})
+
+	usages := make(map[int64]*DatabaseStorageUsage)
+	getUsage := func(collectionID int64) *DatabaseStorageUsage {
+		dbID, ok := q.collectionIDToDBID.Get(collectionID)
+		if !ok {
+			return nil
+		}
+		usage, ok := usages[dbID]
+		if !ok {
+			usage = &DatabaseStorageUsage{DBID: dbID, DBName: dbNames[dbID]}
+			usages[dbID] = usage
+		}
+		return usage
+	}
+
+	if q.dataCoordMetrics != nil {
+		for collectionID, size := range q.dataCoordMetrics.CollectionBinlogSize {
+			usage := getUsage(collectionID)
+			if usage == nil {
+				continue
+			}
+			deltaSize := q.dataCoordMetrics.CollectionDeltaLogSize[collectionID]
+			usage.CollectionNum++
+			usage.BinlogSize += size - deltaSize
+			usage.DeltaLogSize += deltaSize
+		}
+		for collectionID, size := range q.dataCoordMetrics.CollectionIndexSize {
+			if usage := getUsage(collectionID); usage != nil {
+				usage.IndexSize += size
+			}
+		}
+	}
+
+	report := &StorageUsageReport{
+		ReportTime: time.Now().UnixMilli(),
+		Databases:  make([]*DatabaseStorageUsage, 0, len(usages)),
+	}
+	for _, usage := range usages {
+		usage.TotalSize = usage.BinlogSize + usage.DeltaLogSize + usage.IndexSize
+		report.Databases = append(report.Databases, usage)
+	}
+	sort.Slice(report.Databases, func(i, j int) bool {
+		return report.Databases[i].DBID < report.Databases[j].DBID
+	})
+	return report
+}
+
+// storageUsageReporter periodically pushes the per-database storage usage
+// to the configured webhook for chargeback.
+type storageUsageReporter struct {
+	quotaCenter *QuotaCenter
+	client      *http.Client
+}
+
+func newStorageUsageReporter(q *QuotaCenter) *storageUsageReporter {
+	return &storageUsageReporter{
+		quotaCenter: q,
+		client:      &http.Client{},
+	}
+}
+
+// run reports the storage usage until the quota center is stopped.
+func (r *storageUsageReporter) run() {
+	interval := Params.QuotaConfig.StorageUsageReportInterval.GetAsDuration(time.Second)
+	mlog.Info(r.quotaCenter.ctx, "Start storage usage reporter", mlog.Duration("interval", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.quotaCenter.stopChan:
+			mlog.Info(r.quotaCenter.ctx, "storage usage reporter exit")
+			return
+		case <-ticker.C:
+			if !Params.QuotaConfig.StorageUsageReportEnabled.GetAsBool() {
+				continue
+			}
+			if err := r.push(r.quotaCenter.ctx, r.quotaCenter.getStorageUsageReport()); err != nil {
+				mlog.Warn(r.quotaCenter.ctx, "failed to push storage usage report", mlog.Err(err))
+			}
+		}
+	}
+}
+
+// push posts the report to the webhook as json, it's a no-op if no webhook is configured.
+func (r *storageUsageReporter) push(ctx context.Context, report *StorageUsageReport) error {
+	url := Params.QuotaConfig.StorageUsageReportWebhookURL.GetValue()
+	if url == "" {
+		return nil
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, Params.QuotaConfig.StorageUsageReportWebhookTimeout.GetAsDuration(time.Second))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return merr.WrapErrServiceInternalMsg("unexpected status code %d from storage usage webhook", resp.StatusCode)
+	}
+	return nil
+}
+
+// GetStorageUsageReport returns the storage usage of the databases.
+// The usage of all databases is returned if dbName is empty.
+func (c *Core) GetStorageUsageReport(ctx context.Context, dbName string) (*StorageUsageReport, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return nil, err
+	}
+	if c.quotaCenter == nil || !Params.QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() {
+		return nil, merr.WrapErrServiceUnavailable("quota and limits is disabled, storage usage is not collected")
+	}
+	report := c.quotaCenter.getStorageUsageReport()
+	if dbName == "" {
+		return report, nil
+	}
+	for _, usage := range report.Databases {
+		if usage.DBName == dbName {
+			report.Databases = []*DatabaseStorageUsage{usage}
+			return report, nil
+		}
+	}
+	if _, err := c.meta.GetDatabaseByName(ctx, dbName, typeutil.MaxTimestamp); err != nil {
+		return nil, err
+	}
+	report.Databases = []*DatabaseStorageUsage{}
+	return report, nil
+}
@@ -0,0 +1,106 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/json"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func newStorageUsageQuotaCenterForTesting(t *testing.T) *QuotaCenter {
+	quotaCenter := newQuotaCenterForTesting(t, context.Background(), mockrootcoord.NewIMetaTable(t))
+	quotaCenter.dbs.Insert("default", 1)
+	quotaCenter.dbs.Insert("db2", 2)
+	quotaCenter.collectionIDToDBID.Insert(10, 1)
+	quotaCenter.collectionIDToDBID.Insert(11, 1)
+	quotaCenter.collectionIDToDBID.Insert(20, 2)
+	quotaCenter.dataCoordMetrics = &metricsinfo.DataCoordQuotaMetrics{
+		CollectionBinlogSize:   map[int64]int64{10: 100, 11: 50, 20: 300, 30: 1000},
+		CollectionDeltaLogSize: map[int64]int64{10: 10, 20: 30},
+		CollectionIndexSize:    map[int64]int64{10: 20, 20: 40},
+	}
+	return quotaCenter
+}
+
+func TestQuotaCenter_GetStorageUsageReport(t *testing.T) {
+	paramtable.Init()
+	quotaCenter := newStorageUsageQuotaCenterForTesting(t)
+
+	report := quotaCenter.getStorageUsageReport()
+	assert.NotZero(t, report.ReportTime)
+	// collection 30 has no known database and is skipped.
+	assert.Len(t, report.Databases, 2)
+	assert.Equal(t, &DatabaseStorageUsage{
+		DBID:          1,
+		DBName:        "default",
+		CollectionNum: 2,
+		BinlogSize:    140,
+		DeltaLogSize:  10,
+		IndexSize:     20,
+		TotalSize:     170,
+	}, report.Databases[0])
+	assert.Equal(t, &DatabaseStorageUsage{
+		DBID:          2,
+		DBName:        "db2",
+		CollectionNum: 1,
+		BinlogSize:    270,
+		DeltaLogSize:  30,
+		IndexSize:     40,
+		TotalSize:     340,
+	}, report.Databases[1])
+
+	quotaCenter.dataCoordMetrics = nil
+	assert.Empty(t, quotaCenter.getStorageUsageReport().Databases)
+}
+
+func TestStorageUsageReporter_Push(t *testing.T) {
+	paramtable.Init()
+	quotaCenter := newStorageUsageQuotaCenterForTesting(t)
+	reporter := newStorageUsageReporter(quotaCenter)
+	ctx := context.Background()
+
+	// no webhook configured.
+	assert.NoError(t, reporter.push(ctx, quotaCenter.getStorageUsageReport()))
+
+	var received StorageUsageReport
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	paramtable.Get().Save(Params.QuotaConfig.StorageUsageReportWebhookURL.Key, server.URL)
+	defer paramtable.Get().Reset(Params.QuotaConfig.StorageUsageReportWebhookURL.Key)
+
+	assert.NoError(t, reporter.push(ctx, quotaCenter.getStorageUsageReport()))
+	assert.Len(t, received.Databases, 2)
+	assert.Equal(t, int64(170), received.Databases[0].TotalSize)
+
+	statusCode = http.StatusInternalServerError
+	assert.Error(t, reporter.push(ctx, quotaCenter.getStorageUsageReport()))
+}
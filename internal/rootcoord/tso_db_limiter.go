@@ -0,0 +1,83 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/ratelimitutil"
+)
+
+// tsoDBLimiter budgets the timestamp allocations of each database,
+// so a database flooding the requests can't starve the TSO allocator of the others.
+// The budget is rootCoord.dbTimestampAllocRate per second, the allocations beyond it are rejected before allocating.
+type tsoDBLimiter struct {
+	mu       sync.Mutex
+	limiters map[string]*ratelimitutil.Limiter
+}
+
+func newTSODBLimiter() *tsoDBLimiter {
+	return &tsoDBLimiter{
+		limiters: make(map[string]*ratelimitutil.Limiter),
+	}
+}
+
+// Check consumes count timestamps from the budget of the database,
+// the requests without a database are not limited.
+func (l *tsoDBLimiter) Check(dbName string, count uint32) error {
+	if l == nil || dbName == "" {
+		return nil
+	}
+
+	rate := Params.RootCoordCfg.DBTimestampAllocRate.GetAsFloat()
+	if rate > 0 {
+		limiter := l.getLimiter(dbName, rate)
+		if !limiter.AllowN(time.Now(), int(count)) {
+			metrics.RootCoordDBTimestampAllocCounter.WithLabelValues(dbName, metrics.RejectedLabel).Add(float64(count))
+			return merr.WrapErrServiceRateLimit(rate, fmt.Sprintf("too many timestamp allocations of database %s", dbName))
+		}
+	}
+	metrics.RootCoordDBTimestampAllocCounter.WithLabelValues(dbName, metrics.SuccessLabel).Add(float64(count))
+	return nil
+}
+
+func (l *tsoDBLimiter) getLimiter(dbName string, rate float64) *ratelimitutil.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limiter, ok := l.limiters[dbName]
+	if !ok {
+		limiter = ratelimitutil.NewLimiter(ratelimitutil.Limit(rate), rate)
+		l.limiters[dbName] = limiter
+	} else if limiter.Limit() != ratelimitutil.Limit(rate) {
+		limiter.SetLimit(ratelimitutil.Limit(rate))
+	}
+	return limiter
+}
+
+// Remove drops the budget of the dropped database.
+func (l *tsoDBLimiter) Remove(dbName string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.limiters, dbName)
+}
@@ -0,0 +1,62 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestTSODBLimiter(t *testing.T) {
+	t.Run("unlimited", func(t *testing.T) {
+		l := newTSODBLimiter()
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, l.Check("db1", 1000))
+		}
+		assert.Empty(t, l.limiters)
+
+		var nilLimiter *tsoDBLimiter
+		assert.NoError(t, nilLimiter.Check("db1", 1))
+		nilLimiter.Remove("db1")
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		Params.Save(Params.RootCoordCfg.DBTimestampAllocRate.Key, "10")
+		defer Params.Reset(Params.RootCoordCfg.DBTimestampAllocRate.Key)
+
+		l := newTSODBLimiter()
+		// the overdraft is paid back by the following allocations
+		assert.NoError(t, l.Check("db1", 30))
+		err := l.Check("db1", 1)
+		assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+
+		// the budgets of the other databases are not consumed
+		assert.NoError(t, l.Check("db2", 10))
+		// the requests without a database are not limited
+		assert.NoError(t, l.Check("", 100))
+
+		l.Remove("db1")
+		assert.NoError(t, l.Check("db1", 10))
+
+		// the rate is updated on the fly
+		Params.Save(Params.RootCoordCfg.DBTimestampAllocRate.Key, "0")
+		assert.NoError(t, l.Check("db1", 100))
+	})
+}
@@ -141,8 +141,22 @@ func getCollectionRateLimitConfigDefaultValue(configKey string) float64 {
 		return Params.QuotaConfig.DQLMaxSearchRatePerCollection.GetAsFloat()
 	case common.CollectionSearchRateMinKey:
 		return Params.QuotaConfig.DQLMinSearchRatePerCollection.GetAsFloat()
+	case common.CollectionGetRateMaxKey:
+		return Params.QuotaConfig.DQLMaxGetRatePerCollection.GetAsFloat()
+	case common.CollectionGetRateMinKey:
+		return Params.QuotaConfig.DQLMinGetRatePerCollection.GetAsFloat()
 	case common.CollectionDiskQuotaKey:
 		return Params.QuotaConfig.DiskQuotaPerCollection.GetAsFloat()
+	case common.PartitionQueryRateMaxKey:
+		return Params.QuotaConfig.DQLMaxQueryRatePerPartition.GetAsFloat()
+	case common.PartitionSearchRateMaxKey:
+		return Params.QuotaConfig.DQLMaxSearchRatePerPartition.GetAsFloat()
+	case common.PartitionGetRateMaxKey:
+		return Params.QuotaConfig.DQLMaxGetRatePerPartition.GetAsFloat()
+	case common.CollectionFlushRateMaxKey:
+		return Params.QuotaConfig.MaxFlushRatePerCollection.GetAsFloat()
+	case common.CollectionCompactionRateMaxKey:
+		return Params.QuotaConfig.MaxCompactionRatePerCollection.GetAsFloat()
 	default:
 		return float64(0)
 	}
@@ -178,8 +192,22 @@ func getRateLimitConfig(properties map[string]string, configKey string, configVa
 			return rate
 		case common.CollectionSearchRateMinKey:
 			return rate
-		case common.CollectionDiskQuotaKey:
+		case common.CollectionGetRateMaxKey:
+			return rate
+		case common.CollectionGetRateMinKey:
+			return rate
+		case common.CollectionDiskQuotaKey, common.CollectionDiskQuotaMBKey:
 			return megaBytes2Bytes(rate)
+		case common.PartitionQueryRateMaxKey:
+			return rate
+		case common.PartitionSearchRateMaxKey:
+			return rate
+		case common.PartitionGetRateMaxKey:
+			return rate
+		case common.CollectionFlushRateMaxKey:
+			return rate / 60
+		case common.CollectionCompactionRateMaxKey:
+			return rate / 3600
 
 		default:
 			return float64(0)
@@ -206,6 +234,19 @@ func getRateLimitConfig(properties map[string]string, configKey string, configVa
 	return configValue
 }
 
+// getMaxCollectionNumPerDB returns the maximum number of collections in the database,
+// the database property takes precedence over the quota configuration.
+func getMaxCollectionNumPerDB(db *model.Database) (int, error) {
+	if v := db.GetProperty(common.DatabaseMaxCollectionsKey); v != "" {
+		maxColNumPerDB, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, merr.WrapErrServiceInternalMsg("parse value of property fail, key:%s, value:%s", common.DatabaseMaxCollectionsKey, v)
+		}
+		return maxColNumPerDB, nil
+	}
+	return Params.QuotaConfig.MaxCollectionNumPerDB.GetAsInt(), nil
+}
+
 func getQueryCoordMetrics(ctx context.Context, mixCoord types.MixCoord) (*metricsinfo.QueryCoordTopology, error) {
 	req, err := metricsinfo.ConstructRequestByMetricType(metricsinfo.SystemInfoMetrics)
 	if err != nil {
@@ -111,17 +111,19 @@ func Test_getCollectionRateLimitConfig(t *testing.T) {
 	}
 
 	configMap := map[string]string{
-		common.CollectionInsertRateMaxKey:   "5",
-		common.CollectionInsertRateMinKey:   "5",
-		common.CollectionDeleteRateMaxKey:   "5",
-		common.CollectionDeleteRateMinKey:   "5",
-		common.CollectionBulkLoadRateMaxKey: "5",
-		common.CollectionBulkLoadRateMinKey: "5",
-		common.CollectionQueryRateMaxKey:    "5",
-		common.CollectionQueryRateMinKey:    "5",
-		common.CollectionSearchRateMaxKey:   "5",
-		common.CollectionSearchRateMinKey:   "5",
-		common.CollectionDiskQuotaKey:       "5",
+		common.CollectionInsertRateMaxKey:     "5",
+		common.CollectionInsertRateMinKey:     "5",
+		common.CollectionDeleteRateMaxKey:     "5",
+		common.CollectionDeleteRateMinKey:     "5",
+		common.CollectionBulkLoadRateMaxKey:   "5",
+		common.CollectionBulkLoadRateMinKey:   "5",
+		common.CollectionQueryRateMaxKey:      "5",
+		common.CollectionQueryRateMinKey:      "5",
+		common.CollectionSearchRateMaxKey:     "5",
+		common.CollectionSearchRateMinKey:     "5",
+		common.CollectionDiskQuotaKey:         "5",
+		common.CollectionFlushRateMaxKey:      "6",
+		common.CollectionCompactionRateMaxKey: "36",
 	}
 
 	tests := []struct {
@@ -225,6 +227,24 @@ func Test_getCollectionRateLimitConfig(t *testing.T) {
 			want: float64(5 * 1024 * 1024),
 		},
 
+		{
+			name: "test CollectionFlushRateMaxKey",
+			args: args{
+				properties: configMap,
+				configKey:  common.CollectionFlushRateMaxKey,
+			},
+			want: float64(0.1),
+		},
+
+		{
+			name: "test CollectionCompactionRateMaxKey",
+			args: args{
+				properties: configMap,
+				configKey:  common.CollectionCompactionRateMaxKey,
+			},
+			want: float64(0.01),
+		},
+
 		{
 			name: "test invalid config value",
 			args: args{
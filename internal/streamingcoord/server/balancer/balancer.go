@@ -32,6 +32,9 @@ type Balancer interface {
 	// GetLatestChannelAssignment returns the latest channel assignment.
 	GetLatestChannelAssignment() (*WatchChannelAssignmentsCallbackParam, error)
 
+	// GetChannelRecoveryReport returns the reconciliation report of the pchannels when the balancer is recovered.
+	GetChannelRecoveryReport() *channel.RecoveryReport
+
 	// GetAllStreamingNodes fetches all streaming node info with resource group (including frozen nodes).
 	GetAllStreamingNodes(ctx context.Context) (map[int64]*types.StreamingNodeInfoWithResourceGroup, error)
 
@@ -79,6 +82,10 @@ type Balancer interface {
 	// MarkAsAvailable marks the pchannels as available, and trigger a rebalance.
 	MarkAsUnavailable(ctx context.Context, pChannels []types.PChannelInfo) error
 
+	// MoveChannel pins the pchannel to the target streaming node, and trigger a rebalance to move it.
+	// The pin is removed if the target node id is not positive, then the pchannel is balanced by the policy again.
+	MoveChannel(ctx context.Context, pchannel string, targetNodeID int64) error
+
 	// UpdateReplicateConfiguration updates the replicate configuration.
 	UpdateReplicateConfiguration(ctx context.Context, result message.BroadcastResultAlterReplicateConfigMessageV2) error
 
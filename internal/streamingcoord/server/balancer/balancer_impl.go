@@ -64,6 +64,7 @@ func RecoverBalancer(
 		reqCh:                  make(chan *request, 5),
 		backgroundTaskNotifier: syncutil.NewAsyncTaskNotifier[struct{}](),
 		freezeNodes:            typeutil.NewConcurrentSet[int64](),
+		pinnedChannels:         make(map[types.ChannelID]int64),
 		primaryRGChangedCh:     make(chan struct{}, 1),
 	}
 	b.SetLogger(logger)
@@ -89,6 +90,7 @@ type balancerImpl struct {
 	reqCh                  chan *request                         // reqCh is the request channel, send the operation to background task.
 	backgroundTaskNotifier *syncutil.AsyncTaskNotifier[struct{}] // backgroundTaskNotifier is used to conmunicate with the background task.
 	freezeNodes            *typeutil.ConcurrentSet[int64]        // freezeNodes is the nodes that will be frozen, no more wal will be assigned to these nodes and wal will be removed from these nodes.
+	pinnedChannels         map[types.ChannelID]int64             // pinnedChannels is the pchannels moved to the nodes manually, only accessed by the background task.
 	primaryRGChangedCh     chan struct{}                         // primaryRGChangedCh wakes the balance loop when streaming.primaryResourceGroup changes.
 	primaryRGChangeHandler config.EventHandler
 
@@ -134,6 +136,11 @@ func (b *balancerImpl) GetLatestChannelAssignment() (*WatchChannelAssignmentsCal
 	return b.channelMetaManager.GetLatestChannelAssignment()
 }
 
+// GetChannelRecoveryReport returns the reconciliation report of the pchannels when the balancer is recovered.
+func (b *balancerImpl) GetChannelRecoveryReport() *channel.RecoveryReport {
+	return b.channelMetaManager.GetRecoveryReport()
+}
+
 // ReplicateRole returns the replicate role of the balancer.
 func (b *balancerImpl) ReplicateRole() replicateutil.Role {
 	return b.channelMetaManager.ReplicateRole()
@@ -296,6 +303,19 @@ func (b *balancerImpl) MarkAsUnavailable(ctx context.Context, pChannels []types.
 	return err
 }
 
+// MoveChannel pins the pchannel to the target streaming node.
+func (b *balancerImpl) MoveChannel(ctx context.Context, pchannel string, targetNodeID int64) error {
+	if !b.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return status.NewOnShutdownError("balancer is closing")
+	}
+	defer b.lifetime.Done()
+
+	ctx, cancel := contextutil.MergeContext(ctx, b.ctx)
+	defer cancel()
+	_, err := b.sendRequestAndWaitFinish(ctx, newOpMoveChannel(ctx, pchannel, targetNodeID))
+	return err
+}
+
 // Trigger trigger a re-balance.
 func (b *balancerImpl) Trigger(ctx context.Context) error {
 	if !b.lifetime.Add(typeutil.LifetimeStateWorking) {
@@ -590,6 +610,7 @@ func (b *balancerImpl) balance(ctx context.Context) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	b.releaseDuplicateChannelWatches(ctx, pchannelView, nodeStatus)
 
 	// call the balance strategy to generate the expected layout.
 	accessMode := types.AccessModeRO
@@ -597,10 +618,11 @@ func (b *balancerImpl) balance(ctx context.Context) (bool, error) {
 		accessMode = types.AccessModeRW
 	}
 	currentLayout := generateCurrentLayout(pchannelView, nodeStatus, accessMode)
-	expectedLayout, err := b.policy.Balance(currentLayout)
+	expectedLayout, err := balanceByChannelClass(b.policy, currentLayout, channel.GetPChannelClasses())
 	if err != nil {
 		return false, merr.Wrap(err, "fail to balance")
 	}
+	b.applyPinnedChannels(ctx, currentLayout, expectedLayout)
 
 	b.Logger().Info(ctx, "balance policy generate result success, try to assign...", mlog.Stringer("expectedLayout", expectedLayout))
 	// bookkeeping the meta assignment started.
@@ -667,6 +689,7 @@ func (b *balancerImpl) applyBalanceResultToStreamingNode(ctx context.Context, mo
 				defer cancel()
 				if err := resource.Resource().StreamingNodeManagerClient().Remove(opCtx, assignment); err != nil {
 					b.Logger().Warn(ctx, "fail to remove channel", mlog.String("assignment", assignment.String()), mlog.Err(err))
+					b.channelMetaManager.NotifyAssignmentFailure(assignment, err)
 					return err
 				}
 				b.Logger().Info(ctx, "remove channel success", mlog.String("assignment", assignment.String()))
@@ -677,6 +700,7 @@ func (b *balancerImpl) applyBalanceResultToStreamingNode(ctx context.Context, mo
 			defer cancel()
 			if err := resource.Resource().StreamingNodeManagerClient().Assign(opCtx, channel.CurrentAssignment()); err != nil {
 				b.Logger().Warn(ctx, "fail to assign channel", mlog.String("assignment", channel.CurrentAssignment().String()), mlog.Err(err))
+				b.channelMetaManager.NotifyAssignmentFailure(channel.CurrentAssignment(), err)
 				return err
 			}
 			b.Logger().Info(ctx, "assign channel success", mlog.String("assignment", channel.CurrentAssignment().String()))
@@ -0,0 +1,33 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// GetPChannelClasses returns the class of the dedicated pchannels, keyed by the pchannel name.
+// The pchannels not in the map are the general pchannels.
+func GetPChannelClasses() map[string]string {
+	value := paramtable.Get().StreamingCfg.WALBalancerChannelClasses.GetValue()
+	if value == "" {
+		return map[string]string{}
+	}
+	classToPChannels := make(map[string][]string)
+	if err := json.Unmarshal([]byte(value), &classToPChannels); err != nil {
+		mlog.RatedWarn(context.TODO(), 1, "invalid channel classes config, ignored", mlog.String("value", value), mlog.Err(err))
+		return map[string]string{}
+	}
+	classes := make(map[string]string)
+	for class, pchannels := range classToPChannels {
+		if class == "" {
+			continue
+		}
+		for _, pchannel := range pchannels {
+			classes[pchannel] = class
+		}
+	}
+	return classes
+}
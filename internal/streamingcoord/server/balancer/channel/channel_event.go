@@ -0,0 +1,225 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/util/hookutil"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// channelEventSinkSymbol is the symbol looked up from the channel event sink plugin.
+const channelEventSinkSymbol = "ChannelEventSink"
+
+// channelEventBufferSize is the max number of the channel events waiting for the sinks,
+// the exceeded ones are dropped.
+const channelEventBufferSize = 1024
+
+// ChannelEventType is the type of the pchannel topology change.
+type ChannelEventType string
+
+const (
+	ChannelEventReassign     ChannelEventType = "reassign"      // the pchannel is assigned to a new server or term, it's not watched yet.
+	ChannelEventWatchSuccess ChannelEventType = "watch_success" // the pchannel is watched by the assigned server, the history assignments are released.
+	ChannelEventRelease      ChannelEventType = "release"       // the pchannel is released by its server and marked as unavailable.
+	ChannelEventFailure      ChannelEventType = "failure"       // the pchannel fails to be watched or released by the server.
+)
+
+// ChannelEvent is the pchannel topology change passed to the channel event sinks.
+type ChannelEvent struct {
+	Type             ChannelEventType `json:"type"`
+	Channel          string           `json:"channel"`
+	Term             int64            `json:"term"`
+	ServerID         int64            `json:"server_id"`
+	PreviousServerID int64            `json:"previous_server_id,omitempty"` // only set by the reassign event.
+	Error            string           `json:"error,omitempty"`              // only set by the failure event.
+	Timestamp        int64            `json:"timestamp"`                    // unix milliseconds when the event happens.
+}
+
+// ChannelEventSink receives the channel events,
+// so the external systems can react to the pchannel topology changes without polling.
+type ChannelEventSink interface {
+	// Name returns the name of the sink for logging.
+	Name() string
+
+	// Emit passes the event to the sink, it should return before ctx is done.
+	Emit(ctx context.Context, event ChannelEvent) error
+}
+
+var channelEvents = newChannelEventNotifier()
+
+// RegisterChannelEventSink registers a sink of the channel events besides the configured ones.
+func RegisterChannelEventSink(sink ChannelEventSink) {
+	channelEvents.register(sink)
+}
+
+// channelEventNotifier passes the channel events to the sinks asynchronously,
+// so the channel assignment is never blocked by a slow sink.
+type channelEventNotifier struct {
+	startOnce  sync.Once
+	pluginOnce sync.Once
+	events     chan ChannelEvent
+
+	mu         sync.RWMutex
+	registered []ChannelEventSink
+	plugin     ChannelEventSink
+	client     *http.Client
+}
+
+func newChannelEventNotifier() *channelEventNotifier {
+	return &channelEventNotifier{
+		events: make(chan ChannelEvent, channelEventBufferSize),
+		client: &http.Client{},
+	}
+}
+
+func (n *channelEventNotifier) register(sink ChannelEventSink) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.registered = append(n.registered, sink)
+}
+
+// notify queues the events for the sinks, the events are dropped if no sink is configured or the queue is full.
+func (n *channelEventNotifier) notify(events ...ChannelEvent) {
+	if len(events) == 0 || len(n.sinks()) == 0 {
+		return
+	}
+	n.startOnce.Do(func() {
+		go n.loop()
+	})
+	for _, event := range events {
+		select {
+		case n.events <- event:
+		default:
+			mlog.RatedWarn(context.TODO(), 1, "channel event is dropped because the sinks can't keep up",
+				mlog.String("type", string(event.Type)), mlog.String("channel", event.Channel))
+		}
+	}
+}
+
+func (n *channelEventNotifier) loop() {
+	for event := range n.events {
+		for _, sink := range n.sinks() {
+			ctx, cancel := context.WithTimeout(context.Background(), paramtable.Get().StreamingCfg.WALBalancerChannelEventSinkTimeout.GetAsDurationByParse())
+			if err := sink.Emit(ctx, event); err != nil {
+				mlog.RatedWarn(ctx, 1, "failed to emit channel event", mlog.String("sink", sink.Name()),
+					mlog.String("type", string(event.Type)), mlog.String("channel", event.Channel), mlog.Err(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// sinks returns the configured and registered sinks.
+func (n *channelEventNotifier) sinks() []ChannelEventSink {
+	n.pluginOnce.Do(n.loadPlugin)
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	sinks := make([]ChannelEventSink, 0, len(n.registered)+3)
+	if paramtable.Get().StreamingCfg.WALBalancerChannelEventLogEnabled.GetAsBool() {
+		sinks = append(sinks, logChannelEventSink{})
+	}
+	if url := paramtable.Get().StreamingCfg.WALBalancerChannelEventWebhookURL.GetValue(); url != "" {
+		sinks = append(sinks, &webhookChannelEventSink{client: n.client, url: url})
+	}
+	if n.plugin != nil {
+		sinks = append(sinks, n.plugin)
+	}
+	return append(sinks, n.registered...)
+}
+
+func (n *channelEventNotifier) loadPlugin() {
+	path := paramtable.Get().StreamingCfg.WALBalancerChannelEventPluginPath.GetValue()
+	if path == "" {
+		return
+	}
+	plugin, err := hookutil.LoadPlugin[func(context.Context, []byte) error](path, channelEventSinkSymbol)
+	if err != nil {
+		mlog.Warn(context.TODO(), "failed to load channel event sink plugin", mlog.String("path", path), mlog.Err(err))
+		return
+	}
+	n.mu.Lock()
+	n.plugin = pluginChannelEventSink(plugin)
+	n.mu.Unlock()
+}
+
+// logChannelEventSink logs the channel events.
+type logChannelEventSink struct{}
+
+func (logChannelEventSink) Name() string {
+	return "log"
+}
+
+func (logChannelEventSink) Emit(ctx context.Context, event ChannelEvent) error {
+	body, err := json.Marshal(&event)
+	if err != nil {
+		return err
+	}
+	mlog.Info(ctx, "channel event", mlog.String("event", string(body)))
+	return nil
+}
+
+// webhookChannelEventSink posts the channel events to an http endpoint in json.
+type webhookChannelEventSink struct {
+	client *http.Client
+	url    string
+}
+
+func (s *webhookChannelEventSink) Name() string {
+	return "webhook"
+}
+
+func (s *webhookChannelEventSink) Emit(ctx context.Context, event ChannelEvent) error {
+	body, err := json.Marshal(&event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return merr.WrapErrServiceInternalMsg("unexpected status code %d from channel event webhook", resp.StatusCode)
+	}
+	return nil
+}
+
+// pluginChannelEventSink passes the channel events to a go plugin in json.
+type pluginChannelEventSink func(ctx context.Context, event []byte) error
+
+func (pluginChannelEventSink) Name() string {
+	return "plugin"
+}
+
+func (s pluginChannelEventSink) Emit(ctx context.Context, event ChannelEvent) error {
+	body, err := json.Marshal(&event)
+	if err != nil {
+		return err
+	}
+	return s(ctx, body)
+}
+
+// newChannelEvent creates a channel event of the assignment.
+func newChannelEvent(eventType ChannelEventType, assignment types.PChannelInfoAssigned) ChannelEvent {
+	return ChannelEvent{
+		Type:      eventType,
+		Channel:   assignment.Channel.Name,
+		Term:      assignment.Channel.Term,
+		ServerID:  assignment.Node.ServerID,
+		Timestamp: time.Now().UnixMilli(),
+	}
+}
@@ -0,0 +1,98 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+type collectChannelEventSink struct {
+	mu     sync.Mutex
+	events []ChannelEvent
+}
+
+func (s *collectChannelEventSink) Name() string {
+	return "collect"
+}
+
+func (s *collectChannelEventSink) Emit(ctx context.Context, event ChannelEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *collectChannelEventSink) Events() []ChannelEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ChannelEvent{}, s.events...)
+}
+
+func TestChannelEventNotifier(t *testing.T) {
+	paramtable.Init()
+	assignment := types.PChannelInfoAssigned{
+		Channel: types.PChannelInfo{Name: "test-channel", Term: 2},
+		Node:    types.StreamingNodeInfo{ServerID: 3},
+	}
+
+	t.Run("no_sink", func(t *testing.T) {
+		n := newChannelEventNotifier()
+		n.notify(newChannelEvent(ChannelEventWatchSuccess, assignment))
+		assert.Empty(t, n.events)
+	})
+
+	t.Run("registered_sink", func(t *testing.T) {
+		n := newChannelEventNotifier()
+		sink := &collectChannelEventSink{}
+		n.register(sink)
+
+		reassign := newChannelEvent(ChannelEventReassign, assignment)
+		reassign.PreviousServerID = 1
+		n.notify(reassign, newChannelEvent(ChannelEventWatchSuccess, assignment))
+		assert.Eventually(t, func() bool {
+			return len(sink.Events()) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		events := sink.Events()
+		assert.Equal(t, ChannelEventReassign, events[0].Type)
+		assert.Equal(t, int64(1), events[0].PreviousServerID)
+		assert.Equal(t, ChannelEventWatchSuccess, events[1].Type)
+		assert.Equal(t, "test-channel", events[1].Channel)
+		assert.Equal(t, int64(2), events[1].Term)
+		assert.Equal(t, int64(3), events[1].ServerID)
+	})
+
+	t.Run("webhook", func(t *testing.T) {
+		received := make(chan ChannelEvent, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var event ChannelEvent
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+			received <- event
+		}))
+		defer server.Close()
+		paramtable.Get().Save(paramtable.Get().StreamingCfg.WALBalancerChannelEventWebhookURL.Key, server.URL)
+		defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALBalancerChannelEventWebhookURL.Key)
+		paramtable.Get().Save(paramtable.Get().StreamingCfg.WALBalancerChannelEventLogEnabled.Key, "true")
+		defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALBalancerChannelEventLogEnabled.Key)
+
+		n := newChannelEventNotifier()
+		failure := newChannelEvent(ChannelEventFailure, assignment)
+		failure.Error = errors.New("node unavailable").Error()
+		n.notify(failure)
+		select {
+		case event := <-received:
+			assert.Equal(t, failure, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the event should be posted to the webhook")
+		}
+	})
+}
@@ -35,6 +35,7 @@ type (
 	AllocVChannelParam struct {
 		CollectionID int64
 		Num          int
+		ChannelClass string // the vchannels are only allocated on the pchannels of the class, empty means the general pchannels.
 	}
 
 	WatchChannelAssignmentsCallbackParam struct {
@@ -64,10 +65,11 @@ func RecoverChannelManager(ctx context.Context, incomingChannel ...string) (*Cha
 	if err != nil {
 		return nil, err
 	}
-	channels, metrics, err := recoverFromConfigurationAndMeta(ctx, streamingVersion, replicateConfig, incomingChannel...)
+	channels, metrics, reportBuilder, err := recoverFromConfigurationAndMeta(ctx, streamingVersion, replicateConfig, incomingChannel...)
 	if err != nil {
 		return nil, err
 	}
+	recoveryReport := reportBuilder.build(ctx, streamingVersion.GetVersion(), funcutil.GetControlChannel(cchannelMeta.Pchannel))
 
 	globalVersion := resource.Resource().Session().GetRegisteredRevision()
 	cm := &ChannelManager{
@@ -81,6 +83,7 @@ func RecoverChannelManager(ctx context.Context, incomingChannel ...string) (*Cha
 		cchannelMeta:     cchannelMeta,
 		streamingVersion: streamingVersion,
 		replicateConfig:  replicateConfig,
+		recoveryReport:   recoveryReport,
 	}
 
 	// Register the channel manager singleton after recovery.
@@ -136,14 +139,16 @@ func recoverCChannelMeta(ctx context.Context, incomingChannel ...string) (*strea
 }
 
 // recoverFromConfigurationAndMeta recovers the channel manager from configuration and meta.
-func recoverFromConfigurationAndMeta(ctx context.Context, streamingVersion *streamingpb.StreamingVersion, replicateConfig *replicateutil.ConfigHelper, incomingChannel ...string) (map[ChannelID]*PChannelMeta, *channelMetrics, error) {
+// The reconciliation result of each pchannel is recorded into the returned report builder.
+func recoverFromConfigurationAndMeta(ctx context.Context, streamingVersion *streamingpb.StreamingVersion, replicateConfig *replicateutil.ConfigHelper, incomingChannel ...string) (map[ChannelID]*PChannelMeta, *channelMetrics, *recoveryReportBuilder, error) {
 	// Recover metrics.
 	metrics := newPChannelMetrics()
+	reportBuilder := newRecoveryReportBuilder(incomingChannel)
 
 	// Get all channels from meta.
 	channelMetas, err := resource.Resource().StreamingCatalog().ListPChannel(ctx)
 	if err != nil {
-		return nil, metrics, err
+		return nil, metrics, nil, err
 	}
 
 	// TODO: only support rw channel here now, add ro channel in future.
@@ -152,6 +157,7 @@ func recoverFromConfigurationAndMeta(ctx context.Context, streamingVersion *stre
 		c := newPChannelMetaFromProto(channel, replicateConfig)
 		metrics.AssignPChannelStatus(c)
 		channels[c.ChannelID()] = c
+		reportBuilder.addFromMeta(c)
 	}
 
 	// Get new incoming meta from configuration.
@@ -167,9 +173,10 @@ func recoverFromConfigurationAndMeta(ctx context.Context, streamingVersion *stre
 		c.availableInReplication = isChannelAvailableInReplication(c.Name(), replicateConfig)
 		if _, ok := channels[c.ChannelID()]; !ok {
 			channels[c.ChannelID()] = c
+			reportBuilder.addFromConfiguration(c)
 		}
 	}
-	return channels, metrics, nil
+	return channels, metrics, reportBuilder, nil
 }
 
 func recoverReplicateConfiguration(ctx context.Context) (*replicateutil.ConfigHelper, error) {
@@ -217,6 +224,12 @@ type ChannelManager struct {
 	// 1 if streaming service has been run once.
 	streamingEnableNotifiers []*syncutil.AsyncTaskNotifier[struct{}]
 	replicateConfig          *replicateutil.ConfigHelper
+	recoveryReport           *RecoveryReport // the reconciliation report of the pchannels at recovery, immutable.
+}
+
+// GetRecoveryReport returns the reconciliation report of the pchannels when the channel manager is recovered.
+func (cm *ChannelManager) GetRecoveryReport() *RecoveryReport {
+	return cm.recoveryReport
 }
 
 // RegisterStreamingEnabledNotifier registers a notifier into the balancer.
@@ -394,9 +407,12 @@ func (cm *ChannelManager) AllocVirtualChannels(ctx context.Context, param AllocV
 	cm.cond.L.Lock()
 	defer cm.cond.L.Unlock()
 
-	availableChannels := cm.sortAvailableChannelsByVChannelCount()
+	channelClasses := GetPChannelClasses()
+	availableChannels := lo.Filter(cm.sortAvailableChannelsByVChannelCount(), func(ch withVChannelCount, _ int) bool {
+		return channelClasses[ch.id.Name] == param.ChannelClass
+	})
 	if len(availableChannels) < param.Num {
-		return nil, status.NewInner("not enough pchannels to allocate, expected: %d, got: %d", param.Num, len(availableChannels))
+		return nil, status.NewInner("not enough pchannels of class %q to allocate, expected: %d, got: %d", param.ChannelClass, param.Num, len(availableChannels))
 	}
 
 	vchannels := make([]string, 0, param.Num)
@@ -448,6 +464,7 @@ func (cm *ChannelManager) AssignPChannels(ctx context.Context, pChannelToStreami
 
 	// modified channels.
 	pChannelMetas := make([]*streamingpb.PChannelMeta, 0, len(pChannelToStreamingNode))
+	previousServerIDs := make(map[ChannelID]int64, len(pChannelToStreamingNode))
 	for id, assign := range pChannelToStreamingNode {
 		pchannel, ok := cm.channels[id]
 		if !ok {
@@ -456,6 +473,7 @@ func (cm *ChannelManager) AssignPChannels(ctx context.Context, pChannelToStreami
 		mutablePchannel := pchannel.CopyForWrite()
 		if mutablePchannel.TryAssignToServerID(assign.Channel.AccessMode, assign.Node) {
 			pChannelMetas = append(pChannelMetas, mutablePchannel.IntoRawMeta())
+			previousServerIDs[id] = pchannel.CurrentServerID()
 		}
 	}
 
@@ -464,11 +482,16 @@ func (cm *ChannelManager) AssignPChannels(ctx context.Context, pChannelToStreami
 		return nil, err
 	}
 	updates := make(map[ChannelID]*PChannelMeta, len(pChannelMetas))
+	events := make([]ChannelEvent, 0, len(pChannelMetas))
 	for _, pchannel := range pChannelMetas {
 		meta := newPChannelMetaFromProto(pchannel, cm.replicateConfig)
 		updates[meta.ChannelID()] = meta
 		cm.metrics.AssignPChannelStatus(meta)
+		event := newChannelEvent(ChannelEventReassign, meta.CurrentAssignment())
+		event.PreviousServerID = previousServerIDs[meta.ChannelID()]
+		events = append(events, event)
 	}
+	channelEvents.notify(events...)
 	return updates, nil
 }
 
@@ -497,9 +520,13 @@ func (cm *ChannelManager) AssignPChannelsDone(ctx context.Context, pChannels []C
 	}
 
 	// Update metrics.
+	events := make([]ChannelEvent, 0, len(pChannelMetas))
 	for _, pchannel := range pChannelMetas {
-		cm.metrics.AssignPChannelStatus(newPChannelMetaFromProto(pchannel, cm.replicateConfig))
+		meta := newPChannelMetaFromProto(pchannel, cm.replicateConfig)
+		cm.metrics.AssignPChannelStatus(meta)
+		events = append(events, newChannelEvent(ChannelEventWatchSuccess, meta.CurrentAssignment()))
 	}
+	channelEvents.notify(events...)
 	return nil
 }
 
@@ -523,12 +550,23 @@ func (cm *ChannelManager) MarkAsUnavailable(ctx context.Context, pChannels []typ
 	if err := cm.updatePChannelMeta(ctx, pChannelMetas); err != nil {
 		return err
 	}
+	events := make([]ChannelEvent, 0, len(pChannelMetas))
 	for _, pchannel := range pChannelMetas {
-		cm.metrics.AssignPChannelStatus(newPChannelMetaFromProto(pchannel, cm.replicateConfig))
+		meta := newPChannelMetaFromProto(pchannel, cm.replicateConfig)
+		cm.metrics.AssignPChannelStatus(meta)
+		events = append(events, newChannelEvent(ChannelEventRelease, meta.CurrentAssignment()))
 	}
+	channelEvents.notify(events...)
 	return nil
 }
 
+// NotifyAssignmentFailure emits the failure event of the assignment which fails to be applied to the streaming node.
+func (cm *ChannelManager) NotifyAssignmentFailure(assignment types.PChannelInfoAssigned, err error) {
+	event := newChannelEvent(ChannelEventFailure, assignment)
+	event.Error = err.Error()
+	channelEvents.notify(event)
+}
+
 // updatePChannelMeta updates the pchannel metas.
 func (cm *ChannelManager) updatePChannelMeta(ctx context.Context, pChannelMetas []*streamingpb.PChannelMeta) error {
 	if len(pChannelMetas) == 0 {
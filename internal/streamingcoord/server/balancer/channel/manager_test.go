@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/walimplstest"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/replicateutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/syncutil"
 )
@@ -92,6 +94,13 @@ func TestChannelManager(t *testing.T) {
 	assert.ErrorIs(t, err, ErrChannelNotExist)
 
 	// Test success.
+	sink := &collectChannelEventSink{}
+	RegisterChannelEventSink(sink)
+	defer func() {
+		channelEvents.mu.Lock()
+		channelEvents.registered = nil
+		channelEvents.mu.Unlock()
+	}()
 	catalog.EXPECT().SavePChannels(mock.Anything, mock.Anything).Unset()
 	catalog.EXPECT().SavePChannels(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, pm []*streamingpb.PChannelMeta) error {
 		return nil
@@ -131,6 +140,17 @@ func TestChannelManager(t *testing.T) {
 	assert.False(t, ok)
 	assert.Zero(t, nodeID)
 
+	assert.Eventually(t, func() bool {
+		return len(sink.Events()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	events := sink.Events()
+	assert.Equal(t, ChannelEventReassign, events[0].Type)
+	assert.Equal(t, int64(1), events[0].PreviousServerID)
+	assert.Equal(t, int64(2), events[0].ServerID)
+	assert.Equal(t, ChannelEventWatchSuccess, events[1].Type)
+	assert.Equal(t, ChannelEventRelease, events[2].Type)
+	assert.Equal(t, "test-channel", events[2].Channel)
+
 	t.Run("UpdateReplicateConfiguration", func(t *testing.T) {
 		param, err := m.GetLatestChannelAssignment()
 		oldLocalVersion := param.Version.Local
@@ -462,6 +482,30 @@ func TestAllocVirtualChannels(t *testing.T) {
 	assert.Equal(t, allocVChannels[1], "by-dev-rootcoord-dml_11_1v1")
 	assert.Equal(t, allocVChannels[2], "by-dev-rootcoord-dml_12_1v2")
 	assert.Equal(t, allocVChannels[3], "by-dev-rootcoord-dml_13_1v3")
+
+	// the dedicated pchannels are only allocated to the collections of the class.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALBalancerChannelClasses.Key,
+		`{"high-throughput": ["by-dev-rootcoord-dml_10", "by-dev-rootcoord-dml_11"]}`)
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALBalancerChannelClasses.Key)
+	allocVChannels, err = m.AllocVirtualChannels(ctx, AllocVChannelParam{
+		CollectionID: 2,
+		Num:          2,
+		ChannelClass: "high-throughput",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"by-dev-rootcoord-dml_10_2v0", "by-dev-rootcoord-dml_11_2v1"}, allocVChannels)
+	_, err = m.AllocVirtualChannels(ctx, AllocVChannelParam{
+		CollectionID: 2,
+		Num:          3,
+		ChannelClass: "high-throughput",
+	})
+	assert.Error(t, err)
+	allocVChannels, err = m.AllocVirtualChannels(ctx, AllocVChannelParam{
+		CollectionID: 3,
+		Num:          1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "by-dev-rootcoord-dml_12_3v0", allocVChannels[0])
 }
 
 func TestStreamingEnableChecker(t *testing.T) {
@@ -966,3 +1010,61 @@ func newChannelID(name string) ChannelID {
 		Name: name,
 	}
 }
+
+func TestRecovery_Report(t *testing.T) {
+	ResetStaticPChannelStatsManager()
+	RecoverPChannelStatsManager([]string{})
+
+	catalog := mock_metastore.NewMockStreamingCoordCataLog(t)
+	s := sessionutil.NewMockSession(t)
+	s.EXPECT().GetRegisteredRevision().Return(int64(1))
+	resource.InitForTest(resource.OptStreamingCatalog(catalog), resource.OptSession(s))
+
+	ctx := context.Background()
+	catalog.EXPECT().GetCChannel(mock.Anything).Return(&streamingpb.CChannelMeta{Pchannel: "ch1"}, nil)
+	catalog.EXPECT().GetVersion(mock.Anything).Return(&streamingpb.StreamingVersion{Version: 1}, nil)
+	catalog.EXPECT().ListPChannel(mock.Anything).Return([]*streamingpb.PChannelMeta{
+		{
+			Channel: &streamingpb.PChannelInfo{Name: "ch1", Term: 2},
+			Node:    &streamingpb.StreamingNodeInfo{ServerId: 1},
+			State:   streamingpb.PChannelMetaState_PCHANNEL_META_STATE_ASSIGNED,
+		},
+		{
+			Channel: &streamingpb.PChannelInfo{Name: "ch2", Term: 1},
+			State:   streamingpb.PChannelMetaState_PCHANNEL_META_STATE_UNAVAILABLE,
+		},
+		{
+			Channel: &streamingpb.PChannelInfo{Name: "ch3", Term: 1},
+			Node:    &streamingpb.StreamingNodeInfo{ServerId: 2},
+			State:   streamingpb.PChannelMetaState_PCHANNEL_META_STATE_ASSIGNING,
+		},
+	}, nil)
+	catalog.EXPECT().GetReplicateConfiguration(mock.Anything).Return(nil, nil)
+
+	m, err := RecoverChannelManager(ctx, "ch1", "ch2", "ch4")
+	assert.NoError(t, err)
+
+	report := m.GetRecoveryReport()
+	assert.NotNil(t, report)
+	assert.Equal(t, int64(1), report.StreamingVersion)
+	assert.Len(t, report.Channels, 4)
+	results := make(map[string]string)
+	for _, ch := range report.Channels {
+		results[ch.Name] = ch.Result
+	}
+	assert.Equal(t, map[string]string{
+		"ch1": RecoveryResultRecovered,
+		"ch2": RecoveryResultReassigning,
+		"ch3": RecoveryResultRecovered,
+		"ch4": RecoveryResultAdded,
+	}, results)
+	assert.Equal(t, int64(1), report.Channels[0].ServerID)
+	assert.False(t, report.Channels[2].InConfiguration)
+	assert.Equal(t, map[string]int{
+		RecoveryResultRecovered:                2,
+		RecoveryResultReassigning:              1,
+		RecoveryResultAdded:                    1,
+		recoveryResultNotInConfiguration:       1,
+		recoveryResultUnavailableInReplication: 0,
+	}, report.Summary)
+}
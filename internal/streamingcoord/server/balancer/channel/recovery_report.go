@@ -0,0 +1,142 @@
+package channel
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	// RecoveryResultRecovered means the pchannel is recovered from meta and still assigned to a streaming node.
+	RecoveryResultRecovered = "recovered"
+	// RecoveryResultReassigning means the pchannel is recovered from meta but not assigned,
+	// it will be reassigned to a streaming node by the balancer.
+	RecoveryResultReassigning = "reassigning"
+	// RecoveryResultAdded means the pchannel is new incoming from the configuration.
+	RecoveryResultAdded = "added"
+
+	// recoveryResultNotInConfiguration and recoveryResultUnavailableInReplication are only reported in the summary,
+	// a pchannel may be counted in them and one of the results above at the same time.
+	recoveryResultNotInConfiguration       = "not_in_configuration"
+	recoveryResultUnavailableInReplication = "unavailable_in_replication"
+)
+
+// RecoveredPChannel is the reconciliation result of a pchannel when the channel manager is recovered.
+type RecoveredPChannel struct {
+	Name     string `json:"name"`
+	Result   string `json:"result"`
+	State    string `json:"state"`
+	Term     int64  `json:"term"`
+	ServerID int64  `json:"server_id"`
+	// InConfiguration is false if the pchannel is kept in meta but removed from the configuration.
+	InConfiguration        bool `json:"in_configuration"`
+	AvailableInReplication bool `json:"available_in_replication"`
+}
+
+// RecoveryReport is the reconciliation report of the pchannels when the channel manager is recovered,
+// it's kept until the next restart for the post-restart verification.
+type RecoveryReport struct {
+	RecoveredAt      time.Time            `json:"recovered_at"`
+	StreamingVersion int64                `json:"streaming_version"`
+	ControlChannel   string               `json:"control_channel"`
+	Summary          map[string]int       `json:"summary"`
+	Channels         []*RecoveredPChannel `json:"channels"`
+}
+
+// recoveryReportBuilder collects the reconciliation results of the pchannels during recovery.
+type recoveryReportBuilder struct {
+	incoming map[string]struct{}
+	channels []*RecoveredPChannel
+}
+
+func newRecoveryReportBuilder(incomingChannel []string) *recoveryReportBuilder {
+	incoming := make(map[string]struct{}, len(incomingChannel))
+	for _, ch := range incomingChannel {
+		incoming[ch] = struct{}{}
+	}
+	return &recoveryReportBuilder{incoming: incoming}
+}
+
+// addFromMeta records a pchannel recovered from meta.
+func (b *recoveryReportBuilder) addFromMeta(c *PChannelMeta) {
+	result := RecoveryResultReassigning
+	if c.IsAssignedOrAssigning() {
+		result = RecoveryResultRecovered
+	}
+	b.add(c, result)
+}
+
+// addFromConfiguration records a pchannel new incoming from the configuration.
+func (b *recoveryReportBuilder) addFromConfiguration(c *PChannelMeta) {
+	b.add(c, RecoveryResultAdded)
+}
+
+func (b *recoveryReportBuilder) add(c *PChannelMeta, result string) {
+	_, inConfiguration := b.incoming[c.Name()]
+	b.channels = append(b.channels, &RecoveredPChannel{
+		Name:                   c.Name(),
+		Result:                 result,
+		State:                  c.State().String(),
+		Term:                   c.CurrentTerm(),
+		ServerID:               c.CurrentServerID(),
+		InConfiguration:        inConfiguration || len(b.incoming) == 0,
+		AvailableInReplication: c.AvailableInReplication(),
+	})
+}
+
+// build builds the report, logs it and updates the metrics.
+func (b *recoveryReportBuilder) build(ctx context.Context, streamingVersion int64, controlChannel string) *RecoveryReport {
+	sort.Slice(b.channels, func(i, j int) bool { return b.channels[i].Name < b.channels[j].Name })
+	summary := map[string]int{
+		RecoveryResultRecovered:                0,
+		RecoveryResultReassigning:              0,
+		RecoveryResultAdded:                    0,
+		recoveryResultNotInConfiguration:       0,
+		recoveryResultUnavailableInReplication: 0,
+	}
+	for _, ch := range b.channels {
+		summary[ch.Result]++
+		if !ch.InConfiguration {
+			summary[recoveryResultNotInConfiguration]++
+		}
+		if !ch.AvailableInReplication {
+			summary[recoveryResultUnavailableInReplication]++
+		}
+	}
+	report := &RecoveryReport{
+		RecoveredAt:      time.Now(),
+		StreamingVersion: streamingVersion,
+		ControlChannel:   controlChannel,
+		Summary:          summary,
+		Channels:         b.channels,
+	}
+
+	constLabel := prometheus.Labels{metrics.NodeIDLabelName: paramtable.GetStringNodeID()}
+	for result, count := range summary {
+		metrics.StreamingCoordRecoveredPChannelTotal.MustCurryWith(constLabel).
+			WithLabelValues(result).Set(float64(count))
+	}
+
+	mlog.Info(ctx, "channel manager recovery report",
+		mlog.Int64("streamingVersion", streamingVersion),
+		mlog.String("controlChannel", controlChannel),
+		mlog.Any("summary", summary))
+	for _, ch := range b.channels {
+		if ch.Result != RecoveryResultRecovered || !ch.InConfiguration || !ch.AvailableInReplication {
+			mlog.Info(ctx, "pchannel reconciled at recovery",
+				mlog.String("channel", ch.Name),
+				mlog.String("result", ch.Result),
+				mlog.String("state", ch.State),
+				mlog.Int64("serverID", ch.ServerID),
+				mlog.Bool("inConfiguration", ch.InConfiguration),
+				mlog.Bool("availableInReplication", ch.AvailableInReplication))
+		}
+	}
+	return report
+}
@@ -0,0 +1,101 @@
+package balancer
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+)
+
+// splitLayoutByChannelClass splits the layout into one sub layout per channel class,
+// the pchannels of a class can only be assigned to the streaming nodes of the class,
+// and the nodes of a class are excluded from the general layout.
+// If no node of a class is available, the pchannels of the class fall back to the general layout.
+// If no general node is available, the general pchannels can be assigned to any node.
+func splitLayoutByChannelClass(layout CurrentLayout, channelClasses map[string]string) []CurrentLayout {
+	nodesOfClass := make(map[string][]int64)
+	for serverID, node := range layout.AllNodesInfo {
+		nodesOfClass[node.ChannelClass] = append(nodesOfClass[node.ChannelClass], serverID)
+	}
+	channelsOfClass := make(map[string][]channel.ChannelID)
+	for id := range layout.Channels {
+		class := channelClasses[id.Name]
+		if class != "" && len(nodesOfClass[class]) == 0 {
+			mlog.RatedWarn(context.TODO(), 1, "no streaming node of the channel class, fall back to the general nodes",
+				mlog.String("channelClass", class), mlog.Stringer("channel", id))
+			class = ""
+		}
+		channelsOfClass[class] = append(channelsOfClass[class], id)
+	}
+	if len(channelsOfClass[""]) > 0 && len(nodesOfClass[""]) == 0 {
+		for class, serverIDs := range nodesOfClass {
+			if len(channelsOfClass[class]) == 0 {
+				nodesOfClass[""] = append(nodesOfClass[""], serverIDs...)
+			}
+		}
+		if len(nodesOfClass[""]) == 0 {
+			// all the nodes are dedicated to classes, share them with the general pchannels.
+			for serverID := range layout.AllNodesInfo {
+				nodesOfClass[""] = append(nodesOfClass[""], serverID)
+			}
+		}
+	}
+
+	layouts := make([]CurrentLayout, 0, len(channelsOfClass))
+	for class, channelIDs := range channelsOfClass {
+		layouts = append(layouts, subLayout(layout, channelIDs, nodesOfClass[class]))
+	}
+	return layouts
+}
+
+// subLayout returns the layout with only the given channels and nodes.
+func subLayout(layout CurrentLayout, channelIDs []channel.ChannelID, serverIDs []int64) CurrentLayout {
+	sub := CurrentLayout{
+		Config:             layout.Config,
+		Channels:           make(map[channel.ChannelID]types.PChannelInfo, len(channelIDs)),
+		Stats:              make(map[channel.ChannelID]channel.PChannelStatsView, len(channelIDs)),
+		AllNodesInfo:       make(map[int64]types.StreamingNodeStatus, len(serverIDs)),
+		ChannelsToNodes:    make(map[types.ChannelID]int64, len(channelIDs)),
+		ExpectedAccessMode: make(map[channel.ChannelID]types.AccessMode, len(channelIDs)),
+	}
+	for _, serverID := range serverIDs {
+		sub.AllNodesInfo[serverID] = layout.AllNodesInfo[serverID]
+	}
+	for _, id := range channelIDs {
+		sub.Channels[id] = layout.Channels[id]
+		if stats, ok := layout.Stats[id]; ok {
+			sub.Stats[id] = stats
+		}
+		if mode, ok := layout.ExpectedAccessMode[id]; ok {
+			sub.ExpectedAccessMode[id] = mode
+		}
+		// the channel on a node out of the sub layout will be reassigned.
+		if serverID, ok := layout.ChannelsToNodes[id]; ok {
+			if _, ok := sub.AllNodesInfo[serverID]; ok {
+				sub.ChannelsToNodes[id] = serverID
+			}
+		}
+	}
+	return sub
+}
+
+// balanceByChannelClass balances the layout of each channel class independently and merges the results.
+func balanceByChannelClass(policy Policy, layout CurrentLayout, channelClasses map[string]string) (ExpectedLayout, error) {
+	if len(channelClasses) == 0 {
+		return policy.Balance(layout)
+	}
+	expected := ExpectedLayout{
+		ChannelAssignment: make(map[types.ChannelID]types.PChannelInfoAssigned, len(layout.Channels)),
+	}
+	for _, sub := range splitLayoutByChannelClass(layout, channelClasses) {
+		subExpected, err := policy.Balance(sub)
+		if err != nil {
+			return ExpectedLayout{}, err
+		}
+		for id, assignment := range subExpected.ChannelAssignment {
+			expected.ChannelAssignment[id] = assignment
+		}
+	}
+	return expected, nil
+}
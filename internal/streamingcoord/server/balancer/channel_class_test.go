@@ -0,0 +1,69 @@
+package balancer
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+)
+
+func TestSplitLayoutByChannelClass(t *testing.T) {
+	newNode := func(serverID int64, class string) types.StreamingNodeStatus {
+		return types.StreamingNodeStatus{
+			StreamingNodeInfo: types.StreamingNodeInfo{ServerID: serverID},
+			ChannelClass:      class,
+		}
+	}
+	ch := func(name string) channel.ChannelID {
+		return channel.ChannelID{Name: name}
+	}
+	layout := CurrentLayout{
+		Channels: map[channel.ChannelID]types.PChannelInfo{
+			ch("dml_0"): {Name: "dml_0"},
+			ch("dml_1"): {Name: "dml_1"},
+			ch("dml_2"): {Name: "dml_2"},
+			ch("dml_3"): {Name: "dml_3"},
+		},
+		AllNodesInfo: map[int64]types.StreamingNodeStatus{
+			1: newNode(1, ""),
+			2: newNode(2, "high-throughput"),
+			3: newNode(3, "idle"),
+		},
+		ChannelsToNodes: map[types.ChannelID]int64{
+			ch("dml_0"): 2,
+			ch("dml_1"): 2,
+		},
+	}
+	classes := map[string]string{
+		"dml_1": "high-throughput",
+		"dml_2": "no-node",
+	}
+
+	layouts := splitLayoutByChannelClass(layout, classes)
+	assert.Len(t, layouts, 2)
+	for _, sub := range layouts {
+		if _, ok := sub.Channels[ch("dml_1")]; ok {
+			assert.Len(t, sub.Channels, 1)
+			assert.Equal(t, []int64{2}, lo.Keys(sub.AllNodesInfo))
+			assert.Equal(t, int64(2), sub.ChannelsToNodes[ch("dml_1")])
+			continue
+		}
+		// the class without node falls back to the general nodes.
+		assert.Len(t, sub.Channels, 3)
+		assert.Equal(t, []int64{1}, lo.Keys(sub.AllNodesInfo))
+		// the general pchannel on the dedicated node is reassigned.
+		assert.NotContains(t, sub.ChannelsToNodes, ch("dml_0"))
+	}
+
+	// no general node, the nodes of the classes without pchannel are shared.
+	delete(layout.AllNodesInfo, 1)
+	layouts = splitLayoutByChannelClass(layout, classes)
+	for _, sub := range layouts {
+		if _, ok := sub.Channels[ch("dml_0")]; ok {
+			assert.Equal(t, []int64{3}, lo.Keys(sub.AllNodesInfo))
+		}
+	}
+}
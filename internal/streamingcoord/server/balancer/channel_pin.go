@@ -0,0 +1,83 @@
+package balancer
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/syncutil"
+)
+
+// newOpMoveChannel is a operation to pin the pchannel to the target streaming node.
+// The pchannel will be removed from the current node and assigned to the target node at next balance.
+func newOpMoveChannel(ctx context.Context, pchannel string, targetNodeID int64) *request {
+	future := syncutil.NewFuture[response]()
+	return &request{
+		ctx: ctx,
+		apply: func(impl *balancerImpl) {
+			future.Set(response{err: impl.pinChannel(ctx, pchannel, targetNodeID)})
+		},
+		future: future,
+	}
+}
+
+// pinChannel validates the target streaming node and pins the pchannel to it.
+func (b *balancerImpl) pinChannel(ctx context.Context, pchannel string, targetNodeID int64) error {
+	id := types.ChannelID{Name: pchannel}
+	if _, ok := b.channelMetaManager.CurrentPChannelsView().Channels[id]; !ok {
+		return merr.WrapErrParameterInvalidMsg("pchannel %s not found", pchannel)
+	}
+	if targetNodeID <= 0 {
+		delete(b.pinnedChannels, id)
+		b.Logger().Info(ctx, "unpin channel", mlog.String("channel", pchannel))
+		return nil
+	}
+
+	rgName := paramtable.Get().StreamingCfg.PrimaryResourceGroup.GetValue()
+	nodeStatus, err := b.fetchStreamingNodeStatus(ctx, rgName)
+	if err != nil {
+		return err
+	}
+	node, ok := nodeStatus[targetNodeID]
+	if !ok {
+		return merr.WrapErrParameterInvalidMsg("streaming node %d not found", targetNodeID)
+	}
+	if !node.IsHealthy() {
+		return merr.WrapErrParameterInvalidMsg("streaming node %d is not healthy: %s", targetNodeID, node.ErrorOfNode())
+	}
+	if class := channel.GetPChannelClasses()[pchannel]; node.ChannelClass != class {
+		return merr.WrapErrParameterInvalidMsg("channel class of streaming node %d is %q, but pchannel %s requires %q",
+			targetNodeID, node.ChannelClass, pchannel, class)
+	}
+	b.pinnedChannels[id] = targetNodeID
+	b.Logger().Info(ctx, "pin channel to streaming node", mlog.String("channel", pchannel), mlog.Int64("targetNodeID", targetNodeID))
+	return nil
+}
+
+// applyPinnedChannels overrides the assignment generated by the balance policy with the pinned channels.
+// The pin is dropped once the pinned node is not available any more, and the policy takes over the channel.
+func (b *balancerImpl) applyPinnedChannels(ctx context.Context, currentLayout CurrentLayout, expectedLayout ExpectedLayout) {
+	for id, serverID := range b.pinnedChannels {
+		node, ok := currentLayout.AllNodesInfo[serverID]
+		if !ok {
+			b.Logger().Warn(ctx, "pinned streaming node is not available, unpin channel",
+				mlog.Stringer("channel", id), mlog.Int64("serverID", serverID))
+			delete(b.pinnedChannels, id)
+			continue
+		}
+		info, ok := currentLayout.Channels[id]
+		if !ok {
+			delete(b.pinnedChannels, id)
+			continue
+		}
+		if assigned, ok := expectedLayout.ChannelAssignment[id]; ok && assigned.Node.ServerID == serverID {
+			continue
+		}
+		info.AccessMode = currentLayout.ExpectedAccessMode[id]
+		info.Term++
+		expectedLayout.ChannelAssignment[id] = types.PChannelInfoAssigned{Channel: info, Node: node.StreamingNodeInfo}
+	}
+}
@@ -0,0 +1,55 @@
+package balancer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+)
+
+func TestApplyPinnedChannels(t *testing.T) {
+	ch := func(name string) channel.ChannelID {
+		return channel.ChannelID{Name: name}
+	}
+	newNode := func(serverID int64) types.StreamingNodeStatus {
+		return types.StreamingNodeStatus{StreamingNodeInfo: types.StreamingNodeInfo{ServerID: serverID}}
+	}
+	currentLayout := CurrentLayout{
+		Channels: map[channel.ChannelID]types.PChannelInfo{
+			ch("dml_0"): {Name: "dml_0", Term: 1},
+			ch("dml_1"): {Name: "dml_1", Term: 1},
+		},
+		AllNodesInfo: map[int64]types.StreamingNodeStatus{
+			1: newNode(1),
+			2: newNode(2),
+		},
+		ExpectedAccessMode: map[channel.ChannelID]types.AccessMode{
+			ch("dml_0"): types.AccessModeRW,
+			ch("dml_1"): types.AccessModeRW,
+		},
+	}
+	expectedLayout := ExpectedLayout{
+		ChannelAssignment: map[channel.ChannelID]types.PChannelInfoAssigned{
+			ch("dml_0"): {Channel: types.PChannelInfo{Name: "dml_0", Term: 2}, Node: newNode(1).StreamingNodeInfo},
+			ch("dml_1"): {Channel: types.PChannelInfo{Name: "dml_1", Term: 2}, Node: newNode(1).StreamingNodeInfo},
+		},
+	}
+	b := &balancerImpl{
+		pinnedChannels: map[channel.ChannelID]int64{
+			ch("dml_0"): 2,
+			ch("dml_1"): 3,
+		},
+	}
+
+	b.applyPinnedChannels(context.Background(), currentLayout, expectedLayout)
+	assigned := expectedLayout.ChannelAssignment[ch("dml_0")]
+	assert.EqualValues(t, 2, assigned.Node.ServerID)
+	assert.Equal(t, types.AccessModeRW, assigned.Channel.AccessMode)
+	assert.EqualValues(t, 2, assigned.Channel.Term)
+	// the pinned node is not available, the assignment of policy is kept and the pin is dropped.
+	assert.EqualValues(t, 1, expectedLayout.ChannelAssignment[ch("dml_1")].Node.ServerID)
+	assert.Equal(t, map[channel.ChannelID]int64{ch("dml_0"): 2}, b.pinnedChannels)
+}
@@ -0,0 +1,85 @@
+package balancer
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/resource"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// findDuplicateChannelWatches finds the pchannels served by more than one streaming node,
+// and returns the watches should be released.
+// The channel in assigning state is skipped, the old node is still allowed to serve it until the assignment is done.
+// The current assigned node of meta survives if it serves the channel, otherwise the watch with the highest term survives.
+func findDuplicateChannelWatches(view *channel.PChannelView, nodeStatus map[int64]*types.StreamingNodeStatus) []types.PChannelInfoAssigned {
+	watches := make(map[types.ChannelID][]types.PChannelInfoAssigned)
+	for _, status := range nodeStatus {
+		for id, m := range status.Metrics.WALMetrics {
+			var info types.PChannelInfo
+			switch m := m.(type) {
+			case types.RWWALMetrics:
+				info = m.ChannelInfo
+			case types.ROWALMetrics:
+				info = m.ChannelInfo
+			default:
+				continue
+			}
+			watches[id] = append(watches[id], types.PChannelInfoAssigned{
+				Channel: info,
+				Node:    status.StreamingNodeInfo,
+			})
+		}
+	}
+
+	duplicates := make([]types.PChannelInfoAssigned, 0)
+	for id, assignments := range watches {
+		if len(assignments) <= 1 {
+			continue
+		}
+		meta, ok := view.Channels[id]
+		if ok && !meta.IsAssigned() {
+			continue
+		}
+		sort.Slice(assignments, func(i, j int) bool {
+			if ok && (assignments[i].Node.ServerID == meta.CurrentServerID()) != (assignments[j].Node.ServerID == meta.CurrentServerID()) {
+				return assignments[i].Node.ServerID == meta.CurrentServerID()
+			}
+			if assignments[i].Channel.Term != assignments[j].Channel.Term {
+				return assignments[i].Channel.Term > assignments[j].Channel.Term
+			}
+			return assignments[i].Node.ServerID < assignments[j].Node.ServerID
+		})
+		duplicates = append(duplicates, assignments[1:]...)
+	}
+	return duplicates
+}
+
+// releaseDuplicateChannelWatches releases the duplicate watches of pchannels on the streaming nodes.
+func (b *balancerImpl) releaseDuplicateChannelWatches(ctx context.Context, view *channel.PChannelView, nodeStatus map[int64]*types.StreamingNodeStatus) {
+	duplicates := findDuplicateChannelWatches(view, nodeStatus)
+	if len(duplicates) == 0 {
+		return
+	}
+	opTimeout := paramtable.Get().StreamingCfg.WALBalancerOperationTimeout.GetAsDurationByParse()
+	for _, assignment := range duplicates {
+		b.Logger().Warn(ctx, "pchannel is served by more than one streaming node, release the duplicate watch",
+			mlog.String("assignment", assignment.String()))
+		metrics.StreamingCoordDuplicateChannelWatchTotal.WithLabelValues(
+			paramtable.GetStringNodeID(), assignment.Channel.Name, strconv.FormatInt(assignment.Node.ServerID, 10)).Inc()
+
+		opCtx, cancel := context.WithTimeout(ctx, opTimeout)
+		err := resource.Resource().StreamingNodeManagerClient().Remove(opCtx, assignment)
+		cancel()
+		if err != nil {
+			b.Logger().Warn(ctx, "fail to release duplicate channel watch", mlog.String("assignment", assignment.String()), mlog.Err(err))
+			continue
+		}
+		b.Logger().Info(ctx, "release duplicate channel watch success", mlog.String("assignment", assignment.String()))
+	}
+}
@@ -0,0 +1,67 @@
+package balancer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer/channel"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+)
+
+func TestFindDuplicateChannelWatches(t *testing.T) {
+	node := func(serverID int64) types.StreamingNodeInfo {
+		return types.StreamingNodeInfo{ServerID: serverID}
+	}
+	assigned := func(name string, serverID int64) *channel.PChannelMeta {
+		m := channel.NewPChannelMeta(name, types.AccessModeRW).CopyForWrite()
+		m.TryAssignToServerID(types.AccessModeRW, node(serverID))
+		m.AssignToServerDone()
+		return m.PChannelMeta
+	}
+	status := func(serverID int64, channels ...types.PChannelInfo) *types.StreamingNodeStatus {
+		walMetrics := make(map[types.ChannelID]types.WALMetrics)
+		for _, info := range channels {
+			walMetrics[info.ChannelID()] = types.RWWALMetrics{ChannelInfo: info}
+		}
+		return &types.StreamingNodeStatus{
+			StreamingNodeInfo: node(serverID),
+			Metrics:           types.StreamingNodeMetrics{WALMetrics: walMetrics},
+		}
+	}
+
+	assigning := channel.NewPChannelMeta("dml_2", types.AccessModeRW).CopyForWrite()
+	assigning.TryAssignToServerID(types.AccessModeRW, node(3))
+	view := &channel.PChannelView{
+		Channels: map[channel.ChannelID]*channel.PChannelMeta{
+			{Name: "dml_0"}: assigned("dml_0", 1),
+			{Name: "dml_1"}: assigned("dml_1", 3),
+			{Name: "dml_2"}: assigning.PChannelMeta,
+		},
+	}
+	nodeStatus := map[int64]*types.StreamingNodeStatus{
+		1: status(1,
+			types.PChannelInfo{Name: "dml_0", Term: 2},
+			types.PChannelInfo{Name: "dml_1", Term: 1},
+			types.PChannelInfo{Name: "dml_2", Term: 1}),
+		2: status(2,
+			types.PChannelInfo{Name: "dml_0", Term: 1},
+			types.PChannelInfo{Name: "dml_1", Term: 2},
+			types.PChannelInfo{Name: "dml_3", Term: 1}),
+		3: status(3,
+			types.PChannelInfo{Name: "dml_2", Term: 2}),
+	}
+
+	duplicates := findDuplicateChannelWatches(view, nodeStatus)
+	assert.ElementsMatch(t, []types.PChannelInfoAssigned{
+		// the assigned node of meta survives.
+		{Channel: types.PChannelInfo{Name: "dml_0", Term: 1}, Node: node(2)},
+		// the assigned node of meta doesn't serve it, the highest term survives.
+		{Channel: types.PChannelInfo{Name: "dml_1", Term: 1}, Node: node(1)},
+	}, duplicates)
+
+	assert.Empty(t, findDuplicateChannelWatches(view, map[int64]*types.StreamingNodeStatus{
+		1: status(1, types.PChannelInfo{Name: "dml_0", Term: 2}),
+		2: status(2, types.PChannelInfo{Name: "dml_1", Term: 2}),
+	}))
+}
@@ -6,6 +6,7 @@ import (
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/service/balancer/picker"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/service/contextutil"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/service/discoverer"
@@ -208,6 +209,7 @@ func (c *managerClientImpl) getAllStreamingNodeStatus(ctx context.Context, state
 	for serverID, session := range state.Sessions() {
 		serverID := serverID
 		address := session.Address
+		channelClass := session.GetServerLabel()[sessionutil.LabelChannelClass]
 		rg := session.GetResourceGroupName()
 		if rg == "" {
 			rg = common.DefaultResourceGroupName
@@ -230,8 +232,9 @@ func (c *managerClientImpl) getAllStreamingNodeStatus(ctx context.Context, state
 					ServerID: serverID,
 					Address:  address,
 				},
-				Metrics: types.NewStreamingNodeBalanceAttrsFromProto(resp.Metrics),
-				Err:     err,
+				Metrics:      types.NewStreamingNodeBalanceAttrsFromProto(resp.Metrics),
+				ChannelClass: channelClass,
+				Err:          err,
 			}
 			mlog.Debug(ctx, "collect status success", mlog.Int64("serverID", serverID), mlog.Any("status", resp))
 			return nil
@@ -2,13 +2,19 @@ package flusherimpl
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/samber/lo"
 
 	"github.com/milvus-io/milvus/internal/flushcommon/pipeline"
+	"github.com/milvus-io/milvus/internal/flushcommon/util"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/pkg/v3/config"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message/adaptor"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
 // newDataSyncServiceWrapper creates a new data sync service wrapper.
@@ -44,6 +50,9 @@ func (ds *dataSyncServiceWrapper) Start() {
 
 // HandleMessage handles the incoming message.
 func (ds *dataSyncServiceWrapper) HandleMessage(ctx context.Context, msg message.ImmutableMessage) error {
+	if err := ds.waitUntilResumed(ctx); err != nil {
+		return err
+	}
 	ds.handler.GenerateMsgPack(msg)
 	for ds.handler.PendingMsgPack.Len() > 0 {
 		next := ds.handler.PendingMsgPack.Next()
@@ -69,6 +78,46 @@ func (ds *dataSyncServiceWrapper) HandleMessage(ctx context.Context, msg message
 	return nil
 }
 
+// waitUntilResumed blocks the messages of the vchannel until it's resumed, if it's paused by datacoord.
+// The flowgraph is reported as paused meanwhile, so the quota center doesn't throttle the writes by its time tick delay.
+func (ds *dataSyncServiceWrapper) waitUntilResumed(ctx context.Context) error {
+	if !isVChannelPaused(ds.channelName) {
+		return nil
+	}
+	resumeChan := make(chan struct{}, 1)
+	watchKey := paramtable.Get().DataNodeCfg.DataSyncPausedVChannels.Key
+	handler := config.NewHandler(fmt.Sprintf("%s-%s", watchKey, ds.channelName), func(event *config.Event) {
+		if !isVChannelPaused(ds.channelName) {
+			select {
+			case resumeChan <- struct{}{}:
+			default:
+			}
+		}
+	})
+	paramtable.Get().Watch(watchKey, handler)
+	defer paramtable.Get().Unwatch(watchKey, handler)
+	// the vchannel may be resumed before watching
+	if !isVChannelPaused(ds.channelName) {
+		return nil
+	}
+
+	util.GetRateCollector().PauseFlowGraphChannel(ds.channelName)
+	defer util.GetRateCollector().ResumeFlowGraphChannel(ds.channelName)
+	ds.handler.Logger.Info(ctx, "pause consumption of vchannel...", mlog.FieldVChannel(ds.channelName))
+	select {
+	case <-resumeChan:
+		ds.handler.Logger.Info(ctx, "continue to consume messages of vchannel", mlog.FieldVChannel(ds.channelName))
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isVChannelPaused returns whether the consumption of the vchannel is paused.
+func isVChannelPaused(vchannel string) bool {
+	return lo.Contains(paramtable.Get().DataNodeCfg.DataSyncPausedVChannels.GetAsStrings(), vchannel)
+}
+
 // Close close the input channel and gracefully close the data sync service.
 func (ds *dataSyncServiceWrapper) Close() {
 	// The input channel should be closed first, otherwise the flowgraph in datasync service will be blocked.
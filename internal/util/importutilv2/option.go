@@ -54,6 +54,10 @@ const (
 	// BackupFlag indicates whether the import is in backup-restore mode, default to false.
 	BackupFlag = "backup"
 
+	// SegmentPathsFlag indicates whether the paths of the backup files are the prefixes of the segments
+	// rather than the partitions, such paths are imported without listing, default to false.
+	SegmentPathsFlag = "segment_paths"
+
 	// L0Import indicates whether to import l0 segments only.
 	L0Import = "l0_import"
 
@@ -132,6 +136,14 @@ func IsBackup(options Options) bool {
 	return true
 }
 
+func IsSegmentPaths(options Options) bool {
+	isSegmentPaths, err := funcutil.GetAttrByKeyFromRepeatedKV(SegmentPathsFlag, options)
+	if err != nil || strings.ToLower(isSegmentPaths) != "true" {
+		return false
+	}
+	return true
+}
+
 func IsL0Import(options Options) bool {
 	isL0Import, err := funcutil.GetAttrByKeyFromRepeatedKV(L0Import, options)
 	if err != nil || strings.ToLower(isL0Import) != "true" {
@@ -227,3 +227,9 @@ func TestIsAutoCommit(t *testing.T) {
 	opts = []*commonpb.KeyValuePair{{Key: AutoCommitKey, Value: "false"}}
 	assert.False(t, IsAutoCommit(opts))
 }
+
+func TestIsSegmentPaths(t *testing.T) {
+	assert.False(t, IsSegmentPaths(nil))
+	assert.False(t, IsSegmentPaths([]*commonpb.KeyValuePair{{Key: SegmentPathsFlag, Value: "false"}}))
+	assert.True(t, IsSegmentPaths([]*commonpb.KeyValuePair{{Key: SegmentPathsFlag, Value: "True"}}))
+}
@@ -190,6 +190,18 @@ func (m *GrpcQueryCoordClient) CheckQueryNodeDistribution(ctx context.Context, r
 	return &commonpb.Status{}, m.Err
 }
 
+func (m *GrpcQueryCoordClient) DecommissionNode(ctx context.Context, req *querypb.DecommissionNodeRequest, opts ...grpc.CallOption) (*querypb.DecommissionNodeResponse, error) {
+	return &querypb.DecommissionNodeResponse{}, m.Err
+}
+
+func (m *GrpcQueryCoordClient) ListReplicaSuggestions(ctx context.Context, req *querypb.ListReplicaSuggestionsRequest, opts ...grpc.CallOption) (*querypb.ListReplicaSuggestionsResponse, error) {
+	return &querypb.ListReplicaSuggestionsResponse{}, m.Err
+}
+
+func (m *GrpcQueryCoordClient) ApproveReplicaSuggestions(ctx context.Context, req *querypb.ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
 func (m *GrpcQueryCoordClient) UpdateLoadConfig(ctx context.Context, req *querypb.UpdateLoadConfigRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	return &commonpb.Status{}, m.Err
 }
@@ -50,6 +50,7 @@ func initLimitConfigMaps() {
 				internalpb.RateType_DMLBulkLoad:   &quotaConfig.DMLMaxBulkLoadRate,
 				internalpb.RateType_DQLSearch:     &quotaConfig.DQLMaxSearchRate,
 				internalpb.RateType_DQLQuery:      &quotaConfig.DQLMaxQueryRate,
+				internalpb.RateType_DQLGet:        &quotaConfig.DQLMaxGetRate,
 				internalpb.RateType_DDLDB:         &quotaConfig.MaxDBRate,
 			},
 			internalpb.RateScope_Database: {
@@ -63,14 +64,17 @@ func initLimitConfigMaps() {
 				internalpb.RateType_DMLBulkLoad:   &quotaConfig.DMLMaxBulkLoadRatePerDB,
 				internalpb.RateType_DQLSearch:     &quotaConfig.DQLMaxSearchRatePerDB,
 				internalpb.RateType_DQLQuery:      &quotaConfig.DQLMaxQueryRatePerDB,
+				internalpb.RateType_DQLGet:        &quotaConfig.DQLMaxGetRatePerDB,
 			},
 			internalpb.RateScope_Collection: {
-				internalpb.RateType_DMLInsert:   &quotaConfig.DMLMaxInsertRatePerCollection,
-				internalpb.RateType_DMLDelete:   &quotaConfig.DMLMaxDeleteRatePerCollection,
-				internalpb.RateType_DMLBulkLoad: &quotaConfig.DMLMaxBulkLoadRatePerCollection,
-				internalpb.RateType_DQLSearch:   &quotaConfig.DQLMaxSearchRatePerCollection,
-				internalpb.RateType_DQLQuery:    &quotaConfig.DQLMaxQueryRatePerCollection,
-				internalpb.RateType_DDLFlush:    &quotaConfig.MaxFlushRatePerCollection,
+				internalpb.RateType_DMLInsert:     &quotaConfig.DMLMaxInsertRatePerCollection,
+				internalpb.RateType_DMLDelete:     &quotaConfig.DMLMaxDeleteRatePerCollection,
+				internalpb.RateType_DMLBulkLoad:   &quotaConfig.DMLMaxBulkLoadRatePerCollection,
+				internalpb.RateType_DQLSearch:     &quotaConfig.DQLMaxSearchRatePerCollection,
+				internalpb.RateType_DQLQuery:      &quotaConfig.DQLMaxQueryRatePerCollection,
+				internalpb.RateType_DQLGet:        &quotaConfig.DQLMaxGetRatePerCollection,
+				internalpb.RateType_DDLFlush:      &quotaConfig.MaxFlushRatePerCollection,
+				internalpb.RateType_DDLCompaction: &quotaConfig.MaxCompactionRatePerCollection,
 			},
 			internalpb.RateScope_Partition: {
 				internalpb.RateType_DMLInsert:   &quotaConfig.DMLMaxInsertRatePerPartition,
@@ -78,6 +82,7 @@ func initLimitConfigMaps() {
 				internalpb.RateType_DMLBulkLoad: &quotaConfig.DMLMaxBulkLoadRatePerPartition,
 				internalpb.RateType_DQLSearch:   &quotaConfig.DQLMaxSearchRatePerPartition,
 				internalpb.RateType_DQLQuery:    &quotaConfig.DQLMaxQueryRatePerPartition,
+				internalpb.RateType_DQLGet:      &quotaConfig.DQLMaxGetRatePerPartition,
 			},
 		}
 
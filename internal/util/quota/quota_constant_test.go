@@ -32,19 +32,23 @@ func TestGetQuotaConfigMap(t *testing.T) {
 	paramtable.Init()
 	{
 		m := GetQuotaConfigMap(internalpb.RateScope_Cluster)
-		assert.Equal(t, 11, len(m))
+		assert.Equal(t, 12, len(m))
+		assert.Contains(t, m, internalpb.RateType_DQLGet)
 	}
 	{
 		m := GetQuotaConfigMap(internalpb.RateScope_Database)
-		assert.Equal(t, 10, len(m))
+		assert.Equal(t, 11, len(m))
+		assert.Contains(t, m, internalpb.RateType_DQLGet)
 	}
 	{
 		m := GetQuotaConfigMap(internalpb.RateScope_Collection)
-		assert.Equal(t, 6, len(m))
+		assert.Equal(t, 8, len(m))
+		assert.Contains(t, m, internalpb.RateType_DQLGet)
 	}
 	{
 		m := GetQuotaConfigMap(internalpb.RateScope_Partition)
-		assert.Equal(t, 5, len(m))
+		assert.Equal(t, 6, len(m))
+		assert.Contains(t, m, internalpb.RateType_DQLGet)
 	}
 	{
 		m := GetQuotaConfigMap(internalpb.RateScope(1000))
@@ -0,0 +1,103 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitutil
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// ExemptionTokenHeader is the grpc metadata key carrying the quota exemption token of the request.
+const ExemptionTokenHeader = "quota-exemption-token"
+
+// ExemptionToken allows the requests of the given rate types on the collection to bypass the rate limits
+// until it expires, the quota states denying the requests are still enforced.
+type ExemptionToken struct {
+	ID           string                `json:"id"`
+	CollectionID int64                 `json:"collection_id"`
+	RateTypes    []internalpb.RateType `json:"rate_types"`
+	Issuer       string                `json:"issuer"`
+	Reason       string                `json:"reason"`
+	IssuedAt     int64                 `json:"issued_at"`
+	ExpireAt     int64                 `json:"expire_at"`
+}
+
+// Expired returns whether the token is expired at the time.
+func (t *ExemptionToken) Expired(now time.Time) bool {
+	return now.Unix() >= t.ExpireAt
+}
+
+// Covers returns whether the token exempts the requests of the rate type on the collections.
+func (t *ExemptionToken) Covers(collectionIDs []int64, rt internalpb.RateType) bool {
+	if len(collectionIDs) == 0 || !lo.Contains(t.RateTypes, rt) {
+		return false
+	}
+	return lo.EveryBy(collectionIDs, func(collectionID int64) bool {
+		return collectionID == t.CollectionID
+	})
+}
+
+func signExemptionPayload(payload string, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SignExemptionToken encodes the token as `payload.signature`, signed with the secret.
+func SignExemptionToken(token *ExemptionToken, secret string) (string, error) {
+	if secret == "" {
+		return "", merr.WrapErrServiceUnavailable("quota exemption secret is not configured")
+	}
+	bs, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(bs)
+	return payload + "." + signExemptionPayload(payload, secret), nil
+}
+
+// ParseExemptionToken verifies the signature and the expiration of the token.
+func ParseExemptionToken(raw string, secret string, now time.Time) (*ExemptionToken, error) {
+	if secret == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("quota exemption token is not enabled")
+	}
+	payload, signature, ok := strings.Cut(raw, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(signExemptionPayload(payload, secret))) {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid quota exemption token")
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid quota exemption token")
+	}
+	token := &ExemptionToken{}
+	if err := json.Unmarshal(bs, token); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid quota exemption token")
+	}
+	if token.Expired(now) {
+		return nil, merr.WrapErrParameterInvalidMsg("quota exemption token %s is expired", token.ID)
+	}
+	return token, nil
+}
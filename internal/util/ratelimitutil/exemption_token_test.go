@@ -0,0 +1,65 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestExemptionToken(t *testing.T) {
+	now := time.Now()
+	token := &ExemptionToken{
+		ID:           "1",
+		CollectionID: 100,
+		RateTypes:    []internalpb.RateType{internalpb.RateType_DMLDelete},
+		Issuer:       "admin",
+		Reason:       "incident",
+		IssuedAt:     now.Unix(),
+		ExpireAt:     now.Add(time.Minute).Unix(),
+	}
+
+	_, err := SignExemptionToken(token, "")
+	assert.Error(t, err)
+
+	raw, err := SignExemptionToken(token, "secret")
+	assert.NoError(t, err)
+
+	parsed, err := ParseExemptionToken(raw, "secret", now)
+	assert.NoError(t, err)
+	assert.Equal(t, token, parsed)
+	assert.True(t, parsed.Covers([]int64{100}, internalpb.RateType_DMLDelete))
+	assert.False(t, parsed.Covers([]int64{100}, internalpb.RateType_DMLInsert))
+	assert.False(t, parsed.Covers([]int64{100, 101}, internalpb.RateType_DMLDelete))
+	assert.False(t, parsed.Covers(nil, internalpb.RateType_DMLDelete))
+
+	_, err = ParseExemptionToken(raw, "another", now)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	_, err = ParseExemptionToken(raw, "", now)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	_, err = ParseExemptionToken(raw+"x", "secret", now)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	_, err = ParseExemptionToken("invalid", "secret", now)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	_, err = ParseExemptionToken(raw, "secret", now.Add(time.Minute))
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
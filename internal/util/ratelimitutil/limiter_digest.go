@@ -0,0 +1,41 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
+)
+
+// LimiterDigest returns the digest of the limiter tree pushed by the quota center, it's used to check
+// whether the proxies enforce the same limits as the last pushed ones. It returns empty string for nil tree.
+func LimiterDigest(rootLimiter *proxypb.LimiterNode) string {
+	if rootLimiter == nil {
+		return ""
+	}
+	// the map fields are marshaled in the order of the keys with the deterministic option.
+	bytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(rootLimiter)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:8])
+}
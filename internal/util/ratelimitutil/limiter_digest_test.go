@@ -0,0 +1,47 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
+)
+
+func TestLimiterDigest(t *testing.T) {
+	newTree := func(rate float64) *proxypb.LimiterNode {
+		return &proxypb.LimiterNode{
+			Limiter: &proxypb.Limiter{},
+			Children: map[int64]*proxypb.LimiterNode{
+				1: {Limiter: &proxypb.Limiter{Rates: []*internalpb.Rate{{Rt: internalpb.RateType_DMLInsert, R: rate}}}},
+				2: {Limiter: &proxypb.Limiter{}},
+				3: {Limiter: &proxypb.Limiter{}},
+			},
+		}
+	}
+
+	assert.Empty(t, LimiterDigest(nil))
+	digest := LimiterDigest(newTree(100))
+	assert.NotEmpty(t, digest)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, digest, LimiterDigest(newTree(100)))
+	}
+	assert.NotEqual(t, digest, LimiterDigest(newTree(200)))
+}
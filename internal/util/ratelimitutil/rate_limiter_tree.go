@@ -18,6 +18,8 @@ package ratelimitutil
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -87,28 +89,56 @@ func (rln *RateLimiterNode) Check(rt internalpb.RateType, n int) error {
 		return rln.GetQuotaExceededError(rt)
 	}
 	if limit {
-		return rln.GetRateLimitError(rate)
+		return merr.WithExtraInfo(rln.GetRateLimitError(rate), rln.quotaErrorExtraInfo(rt))
 	}
 	return nil
 }
 
+// CheckQuotaState returns the quota exceeded error if the requests of the rate type are denied,
+// the rate limit is not consumed.
+func (rln *RateLimiterNode) CheckQuotaState(rt internalpb.RateType) error {
+	limit, ok := rln.limiters.Get(rt)
+	if ok && limit.Limit() == 0 {
+		return rln.GetQuotaExceededError(rt)
+	}
+	return nil
+}
+
+// quotaErrorExtraInfo returns the machine-readable detail of the limiter rejecting the request.
+func (rln *RateLimiterNode) quotaErrorExtraInfo(rt internalpb.RateType) map[string]string {
+	return map[string]string{
+		ratelimitutil.QuotaScopeKey:      rln.level.String(),
+		ratelimitutil.QuotaResourceIDKey: strconv.FormatInt(rln.id, 10),
+		ratelimitutil.QuotaRateTypeKey:   rt.String(),
+	}
+}
+
+func (rln *RateLimiterNode) getQuotaStateError(rt internalpb.RateType, state milvuspb.QuotaState, stateInfo *QuotaStateInfo) error {
+	extraInfo := rln.quotaErrorExtraInfo(rt)
+	extraInfo[ratelimitutil.QuotaStateKey] = state.String()
+	extraInfo[ratelimitutil.QuotaMetricKey] = ratelimitutil.GetQuotaErrorMetric(stateInfo.ErrorCode)
+	err := merr.WrapErrServiceQuotaExceeded(ratelimitutil.GetQuotaErrorStringWithReason(stateInfo.ErrorCode, stateInfo.Reason),
+		fmt.Sprintf("denied at %s level, id: %d", strings.ToLower(rln.level.String()), rln.id))
+	return merr.WithExtraInfo(err, extraInfo)
+}
+
 func (rln *RateLimiterNode) GetQuotaExceededError(rt internalpb.RateType) error {
 	switch rt {
 	case internalpb.RateType_DMLInsert, internalpb.RateType_DMLDelete, internalpb.RateType_DMLBulkLoad:
 		if stateInfo, ok := rln.quotaStates.Get(milvuspb.QuotaState_DenyToWrite); ok {
-			return merr.WrapErrServiceQuotaExceeded(ratelimitutil.GetQuotaErrorStringWithReason(stateInfo.ErrorCode, stateInfo.Reason))
+			return rln.getQuotaStateError(rt, milvuspb.QuotaState_DenyToWrite, stateInfo)
 		}
-	case internalpb.RateType_DQLSearch, internalpb.RateType_DQLQuery:
+	case internalpb.RateType_DQLSearch, internalpb.RateType_DQLQuery, internalpb.RateType_DQLGet:
 		if stateInfo, ok := rln.quotaStates.Get(milvuspb.QuotaState_DenyToRead); ok {
-			return merr.WrapErrServiceQuotaExceeded(ratelimitutil.GetQuotaErrorStringWithReason(stateInfo.ErrorCode, stateInfo.Reason))
+			return rln.getQuotaStateError(rt, milvuspb.QuotaState_DenyToRead, stateInfo)
 		}
 	case internalpb.RateType_DDLCollection, internalpb.RateType_DDLPartition,
 		internalpb.RateType_DDLIndex, internalpb.RateType_DDLCompaction, internalpb.RateType_DDLFlush:
 		if stateInfo, ok := rln.quotaStates.Get(milvuspb.QuotaState_DenyToDDL); ok {
-			return merr.WrapErrServiceQuotaExceeded(ratelimitutil.GetQuotaErrorStringWithReason(stateInfo.ErrorCode, stateInfo.Reason))
+			return rln.getQuotaStateError(rt, milvuspb.QuotaState_DenyToDDL, stateInfo)
 		}
 	}
-	return merr.WrapErrServiceQuotaExceeded(fmt.Sprintf("rate type: %s", rt.String()))
+	return merr.WithExtraInfo(merr.WrapErrServiceQuotaExceeded(fmt.Sprintf("rate type: %s", rt.String())), rln.quotaErrorExtraInfo(rt))
 }
 
 func (rln *RateLimiterNode) GetRateLimitError(rate float64) error {
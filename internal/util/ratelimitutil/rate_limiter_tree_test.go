@@ -120,10 +120,27 @@ func TestRateLimiterNodeCheck(t *testing.T) {
 		{
 			err := limitNode.Check(internalpb.RateType_DMLInsert, 1)
 			assert.True(t, errors.Is(err, merr.ErrServiceRateLimit))
+			extraInfo := merr.GetExtraInfo(err)
+			assert.Equal(t, internalpb.RateScope_Cluster.String(), extraInfo[ratelimitutil.QuotaScopeKey])
+			assert.Equal(t, internalpb.RateType_DMLInsert.String(), extraInfo[ratelimitutil.QuotaRateTypeKey])
 		}
 	})
 }
 
+func TestRateLimiterNodeCheckQuotaState(t *testing.T) {
+	limitNode := NewRateLimiterNode(internalpb.RateScope_Collection)
+	limitNode.limiters.Insert(internalpb.RateType_DMLInsert, ratelimitutil.NewLimiter(0, 0))
+	limitNode.limiters.Insert(internalpb.RateType_DMLDelete, ratelimitutil.NewLimiter(0.01, 0.01))
+	limitNode.quotaStates.Insert(milvuspb.QuotaState_DenyToWrite, &QuotaStateInfo{ErrorCode: commonpb.ErrorCode_DiskQuotaExhausted})
+
+	assert.ErrorIs(t, limitNode.CheckQuotaState(internalpb.RateType_DMLInsert), merr.ErrServiceQuotaExceeded)
+	// the rate limit is not consumed.
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, limitNode.CheckQuotaState(internalpb.RateType_DMLDelete))
+	}
+	assert.NoError(t, limitNode.CheckQuotaState(internalpb.RateType_DQLSearch))
+}
+
 func TestRateLimiterNodeGetQuotaExceededError(t *testing.T) {
 	t.Run("write", func(t *testing.T) {
 		limitNode := NewRateLimiterNode(internalpb.RateScope_Cluster)
@@ -158,6 +175,23 @@ func TestRateLimiterNodeGetQuotaExceededError(t *testing.T) {
 		assert.True(t, errors.Is(err, merr.ErrServiceQuotaExceeded))
 		assert.True(t, strings.Contains(err.Error(), "rate type"))
 	})
+
+	t.Run("extra info", func(t *testing.T) {
+		limitNode := NewRateLimiterNode(internalpb.RateScope_Collection)
+		limitNode.id = 100
+		limitNode.quotaStates.Insert(milvuspb.QuotaState_DenyToWrite, &QuotaStateInfo{ErrorCode: commonpb.ErrorCode_DiskQuotaExhausted})
+		err := limitNode.GetQuotaExceededError(internalpb.RateType_DMLInsert)
+		assert.True(t, errors.Is(err, merr.ErrServiceQuotaExceeded))
+		assert.True(t, strings.Contains(err.Error(), "collection level, id: 100"))
+
+		status := merr.Status(err)
+		assert.Equal(t, merr.Code(merr.ErrServiceQuotaExceeded), status.GetCode())
+		assert.Equal(t, internalpb.RateScope_Collection.String(), status.GetExtraInfo()[ratelimitutil.QuotaScopeKey])
+		assert.Equal(t, "100", status.GetExtraInfo()[ratelimitutil.QuotaResourceIDKey])
+		assert.Equal(t, internalpb.RateType_DMLInsert.String(), status.GetExtraInfo()[ratelimitutil.QuotaRateTypeKey])
+		assert.Equal(t, milvuspb.QuotaState_DenyToWrite.String(), status.GetExtraInfo()[ratelimitutil.QuotaStateKey])
+		assert.Equal(t, "disk", status.GetExtraInfo()[ratelimitutil.QuotaMetricKey])
+	})
 }
 
 func TestRateLimiterTreeClearInvalidLimiterNode(t *testing.T) {
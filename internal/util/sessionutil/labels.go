@@ -53,6 +53,8 @@ const (
 	// All Roles
 	LabelStandalone    = "STANDALONE"
 	LabelResourceGroup = "RESOURCE_GROUP"
+	// LabelChannelClass marks the node as dedicated to the channels of the class.
+	LabelChannelClass = "CHANNEL_CLASS"
 )
 
 // NewServerLabel creates a new server label with the given role and label.
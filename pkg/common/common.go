@@ -18,6 +18,7 @@ package common
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"math/bits"
 	"strconv"
 	"strings"
@@ -259,7 +260,14 @@ const (
 	CollectionExternalSource    = "collection.external_source"
 	CollectionExternalSpec      = "collection.external_spec"
 	CollectionTTLFieldKey       = "ttl_field"
-	MaxTTLSeconds               = 3155760000 // 100 years
+	// CollectionPropertyPresetKey references a named property preset, which is expanded into the properties it bundles.
+	CollectionPropertyPresetKey = "collection.property.preset"
+	// CollectionPropertyHistoryKey is set to true in the base properties of DescribeCollection to return
+	// the property change history of the collection as a json property with the same key.
+	CollectionPropertyHistoryKey = "collection.property.history"
+	// CollectionChannelClassKey is the channel class whose dedicated pchannels host the vchannels of the collection.
+	CollectionChannelClassKey = "collection.channel.class"
+	MaxTTLSeconds             = 3155760000 // 100 years
 
 	// Deprecated: will be removed in the 3.0 after implementing ack sync up semantic.
 	CollectionOnTruncatingKey = "collection.on.truncating" // when collection is on truncating, forbid the compaction of current collection.
@@ -281,9 +289,56 @@ const (
 	CollectionQueryRateMinKey    = "collection.queryRate.min.qps"
 	CollectionSearchRateMaxKey   = "collection.searchRate.max.vps"
 	CollectionSearchRateMinKey   = "collection.searchRate.min.vps"
+	CollectionGetRateMaxKey      = "collection.getRate.max.qps"
+	CollectionGetRateMinKey      = "collection.getRate.min.qps"
 	CollectionDiskQuotaKey       = "collection.diskProtection.diskQuota.mb"
+	CollectionDiskQuotaMBKey     = "collection.diskQuota.mb" // takes precedence over CollectionDiskQuotaKey and the database disk quota
+	// CollectionFlushRateMaxKey and CollectionCompactionRateMaxKey limit the flush and manual compaction requests
+	// of the collection, they take precedence over the collection level flush and compaction rate configuration.
+	CollectionFlushRateMaxKey      = "collection.flushRate.max.perMinute"
+	CollectionCompactionRateMaxKey = "collection.compactionRate.max.perHour"
+
+	// PartitionTTLKeyPrefix and PartitionTTLKeySuffix wrap the partition id of the collection property overriding
+	// the collection ttl of the partition, e.g. partition.<partition id>.ttl.seconds, see PartitionTTLKey.
+	PartitionTTLKeyPrefix = "partition."
+	PartitionTTLKeySuffix = ".ttl.seconds"
+
+	// AliasRateLimitKeyPrefix prefixes the collection rate limit properties applied to the requests through an alias
+	// of the collection, e.g. alias.<alias name>.collection.searchRate.max.vps, see AliasRateLimitKey.
+	AliasRateLimitKeyPrefix = "alias."
+
+	// CollectionReplicationMaxLagKey overrides the max replication lag in seconds of the collection.
+	CollectionReplicationMaxLagKey = "collection.replication.maxLag.seconds"
+
+	// CollectionCompactionWindowKey restricts the auto compaction of the collection to the maintenance windows,
+	// comma separated hour ranges "start-end" in the timezone of the collection, e.g. "22-6" or "1-3,13-15".
+	// The end hour is exclusive and the range wraps around midnight if start > end.
+	CollectionCompactionWindowKey = "collection.compaction.window"
+
+	// CollectionDQLMaxLatencyKey is the max allowed latency in milliseconds of the dql requests of the collection,
+	// the slower requests are counted as slow queries for the slow query protection, it overrides proxy.slowQuerySpanInSeconds.
+	CollectionDQLMaxLatencyKey = "collection.dql.maxLatency.ms"
+
+	// CollectionIdleReleaseSecondsKey releases the loaded collection once it receives no search or query for the seconds,
+	// the released collection is loaded again with its original load config on the first search or query, which waits for the load.
+	CollectionIdleReleaseSecondsKey = "collection.idle.release.seconds"
+	// CollectionIdleReleasedLoadConfigKey keeps the load config of the collection released for idle, it's set by rootcoord
+	// before the release and removed once the collection is loaded again. It's reserved and can't be set by the users.
+	CollectionIdleReleasedLoadConfigKey = "collection.idle.release.loadConfig"
+
+	// CollectionCompactionSortedOutputKey makes the mix compaction of the collection produce segments sorted by
+	// the primary key, so the querynodes could apply the deletes and look up the primary keys by binary search.
+	CollectionCompactionSortedOutputKey = "collection.compaction.sortedOutput"
+
+	// CollectionFreezeTimestampKey is the freeze timestamp of the read-only collection set by FreezeCollection,
+	// the writes of the frozen collection are denied and querycoord stops updating its targets once they cover the timestamp.
+	CollectionFreezeTimestampKey = "collection.freeze.timestamp"
 
 	PartitionDiskQuotaKey = "partition.diskProtection.diskQuota.mb"
+	// the dql rate limits applied to each partition of the collection independently.
+	PartitionQueryRateMaxKey  = "partition.queryRate.max.qps"
+	PartitionSearchRateMaxKey = "partition.searchRate.max.vps"
+	PartitionGetRateMaxKey    = "partition.getRate.max.qps"
 
 	// database level properties
 	DatabaseReplicaNumber       = "database.replica.number"
@@ -292,6 +347,11 @@ const (
 	DatabaseMaxCollectionsKey   = "database.max.collections"
 	DatabaseForceDenyWritingKey = "database.force.deny.writing"
 	DatabaseForceDenyReadingKey = "database.force.deny.reading"
+	// DatabaseScheduledQuotaWindowsKey overrides quotaAndLimits.scheduledWindows for the database.
+	DatabaseScheduledQuotaWindowsKey = "database.quota.scheduledWindows"
+	// DatabaseMaxTimeTickDelayKey overrides quotaAndLimits.limitWriting.ttProtection.maxTimeTickDelay for the database,
+	// a negative value disables the tt protection of the database.
+	DatabaseMaxTimeTickDelayKey = "database.ttProtection.maxTimeTickDelay.seconds"
 
 	DatabaseForceDenyDDLKey           = "database.force.deny.ddl" // all ddl
 	DatabaseForceDenyCollectionDDLKey = "database.force.deny.collectionDDL"
@@ -300,9 +360,22 @@ const (
 	DatabaseForceDenyFlushDDLKey      = "database.force.deny.flush"
 	DatabaseForceDenyCompactionDDLKey = "database.force.deny.compaction"
 
+	// DatabaseDeletionProtectionKey rejects dropping the database or any collection in it when it's true.
+	DatabaseDeletionProtectionKey = "database.deletion.protection"
+	// DeletionProtectionOverrideKey is set to true in the request header or the base properties of DropDatabase
+	// or DropCollection to drop it regardless of the deletion protection of the database.
+	DeletionProtectionOverrideKey = "deletion.protection.override"
+	// CollectionRecycledKey marks the collection soft-deleted into the recycle bin, the writes to it are rejected.
+	CollectionRecycledKey = "collection.recycled"
+	// DatabaseSoftDeleteWindowKey is the time in seconds a dropped collection of the database is kept in the
+	// recycle bin and can be undropped before it's dropped permanently, zero or absent disables the soft delete.
+	DatabaseSoftDeleteWindowKey = "database.softDelete.window.seconds"
+
 	// collection level load properties
 	CollectionReplicaNumber  = "collection.replica.number"
 	CollectionResourceGroups = "collection.resource_groups"
+	// channels of critical collections are recovered first when many channels need to be reassigned.
+	CollectionCriticalKey = "collection.critical"
 
 	// CMEK related property keys, used in db and collection properties
 	EncryptionEnabledKey = "cipher.enabled"
@@ -663,6 +736,53 @@ func IsDisableFuncRuntimeCheck(kvs ...*commonpb.KeyValuePair) (bool, error) {
 	return false, nil
 }
 
+// IsCollectionCritical returns whether the collection is marked as critical by its properties.
+func IsCollectionCritical(kvs ...*commonpb.KeyValuePair) bool {
+	for _, kv := range kvs {
+		if kv.GetKey() == CollectionCriticalKey {
+			critical, err := strconv.ParseBool(strings.ToLower(kv.GetValue()))
+			return err == nil && critical
+		}
+	}
+	return false
+}
+
+// IsDatabaseDeletionProtected returns whether the deletion protection is enabled by the database properties.
+func IsDatabaseDeletionProtected(kvs ...*commonpb.KeyValuePair) bool {
+	for _, kv := range kvs {
+		if kv.GetKey() == DatabaseDeletionProtectionKey {
+			protected, err := strconv.ParseBool(strings.ToLower(kv.GetValue()))
+			return err == nil && protected
+		}
+	}
+	return false
+}
+
+// IsCollectionRecycled returns whether the collection is soft-deleted into the recycle bin by its properties.
+func IsCollectionRecycled(kvs ...*commonpb.KeyValuePair) bool {
+	for _, kv := range kvs {
+		if kv.GetKey() == CollectionRecycledKey {
+			recycled, err := strconv.ParseBool(kv.GetValue())
+			return err == nil && recycled
+		}
+	}
+	return false
+}
+
+// DatabaseSoftDeleteWindow returns the soft delete window of the database, zero if it's disabled.
+func DatabaseSoftDeleteWindow(kvs ...*commonpb.KeyValuePair) (time.Duration, error) {
+	for _, kv := range kvs {
+		if kv.GetKey() == DatabaseSoftDeleteWindowKey {
+			seconds, err := strconv.ParseInt(kv.GetValue(), 10, 64)
+			if err != nil || seconds < 0 {
+				return 0, merr.WrapErrParameterInvalidMsg("invalid database property: [key=%s] [value=%s]", kv.GetKey(), kv.GetValue())
+			}
+			return time.Duration(seconds) * time.Second, nil
+		}
+	}
+	return 0, nil
+}
+
 func IsPartitionKeyIsolationPropEnabled(props map[string]string) (bool, error) {
 	val, ok := props[PartitionKeyIsolationKey]
 	if !ok {
@@ -688,6 +808,16 @@ func GetNamespaceMode(kvs ...*commonpb.KeyValuePair) string {
 	return NamespaceModePartitionKey
 }
 
+// GetCollectionChannelClass returns the channel class of the collection, empty if the collection is not flagged.
+func GetCollectionChannelClass(kvs ...*commonpb.KeyValuePair) string {
+	for _, kv := range kvs {
+		if kv.GetKey() == CollectionChannelClassKey {
+			return kv.GetValue()
+		}
+	}
+	return ""
+}
+
 func IsNamespaceModePartitionKey(kvs ...*commonpb.KeyValuePair) bool {
 	return GetNamespaceMode(kvs...) == NamespaceModePartitionKey
 }
@@ -933,6 +1063,171 @@ func GetCollectionTTL(kvs []*commonpb.KeyValuePair) (time.Duration, error) {
 	return time.Duration(value) * time.Second, nil
 }
 
+// CompactionWindow is the hour range [Start, End) of a day, it wraps around midnight if Start > End.
+type CompactionWindow struct {
+	Start int
+	End   int
+}
+
+// Contains returns whether the hour of the day is inside the window.
+func (w CompactionWindow) Contains(hour int) bool {
+	if w.Start <= w.End {
+		return hour >= w.Start && hour < w.End
+	}
+	return hour >= w.Start || hour < w.End
+}
+
+// ParseCompactionWindows parses the value of CollectionCompactionWindowKey.
+func ParseCompactionWindows(value string) ([]CompactionWindow, error) {
+	parts := strings.Split(value, ",")
+	windows := make([]CompactionWindow, 0, len(parts))
+	for _, part := range parts {
+		hours := strings.Split(strings.TrimSpace(part), "-")
+		if len(hours) != 2 {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid compaction window %q, should be start-end hours", part)
+		}
+		start, err := strconv.Atoi(strings.TrimSpace(hours[0]))
+		if err != nil {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid compaction window %q, %v", part, err)
+		}
+		end, err := strconv.Atoi(strings.TrimSpace(hours[1]))
+		if err != nil {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid compaction window %q, %v", part, err)
+		}
+		if start < 0 || start > 23 || end < 0 || end > 24 || start == end {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid compaction window %q, start should be in [0, 23], end in [0, 24] and differ from start", part)
+		}
+		windows = append(windows, CompactionWindow{Start: start, End: end})
+	}
+	return windows, nil
+}
+
+// GetCollectionIdleReleaseDuration returns the idle duration after which the collection is released,
+// it's zero if the property is not set or invalid.
+func GetCollectionIdleReleaseDuration(kvs map[string]string) time.Duration {
+	value, ok := kvs[CollectionIdleReleaseSecondsKey]
+	if !ok {
+		return 0
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// IsCollectionCompactionSortedOutput returns whether the mix compaction of the collection must produce sorted segments.
+func IsCollectionCompactionSortedOutput(kvs map[string]string) bool {
+	value, ok := kvs[CollectionCompactionSortedOutputKey]
+	if !ok {
+		return false
+	}
+	enabled, err := strconv.ParseBool(strings.ToLower(value))
+	return err == nil && enabled
+}
+
+// GetCollectionFreezeTimestamp returns the freeze timestamp of the collection, it's zero if the collection is not frozen.
+func GetCollectionFreezeTimestamp(kvs map[string]string) uint64 {
+	value, ok := kvs[CollectionFreezeTimestampKey]
+	if !ok {
+		return 0
+	}
+	ts, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return ts
+}
+
+// IdleReleasedLoadConfig is the load config of the collection saved before it's released for idle.
+type IdleReleasedLoadConfig struct {
+	ReplicaNumber  int32    `json:"replica_number"`
+	ResourceGroups []string `json:"resource_groups,omitempty"`
+	// the loaded fields, empty means all the fields are loaded.
+	LoadFields           []string `json:"load_fields,omitempty"`
+	SkipLoadDynamicField bool     `json:"skip_load_dynamic_field,omitempty"`
+	// the loaded partitions, empty means the collection is loaded as a whole.
+	Partitions []string `json:"partitions,omitempty"`
+}
+
+// MarshalIdleReleasedLoadConfig returns the value of CollectionIdleReleasedLoadConfigKey for the load config.
+func MarshalIdleReleasedLoadConfig(config *IdleReleasedLoadConfig) (string, error) {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// GetIdleReleasedLoadConfig returns the load config saved before the collection is released for idle,
+// it returns false if the collection is not released for idle or the saved value is invalid.
+func GetIdleReleasedLoadConfig(kvs map[string]string) (*IdleReleasedLoadConfig, bool) {
+	value, ok := kvs[CollectionIdleReleasedLoadConfigKey]
+	if !ok {
+		return nil, false
+	}
+	config := &IdleReleasedLoadConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil || config.ReplicaNumber <= 0 {
+		return nil, false
+	}
+	return config, true
+}
+
+// IsInCompactionWindow returns whether the time is inside the compaction windows of the collection properties,
+// the hours are in the timezone of the collection, UTC if it's not set. It's always true without compaction window.
+func IsInCompactionWindow(kvs map[string]string, t time.Time) (bool, error) {
+	value, ok := kvs[CollectionCompactionWindowKey]
+	if !ok || value == "" {
+		return true, nil
+	}
+	windows, err := ParseCompactionWindows(value)
+	if err != nil {
+		return true, err
+	}
+	loc := time.UTC
+	if tz, ok := kvs[TimezoneKey]; ok {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+	hour := t.In(loc).Hour()
+	for _, window := range windows {
+		if window.Contains(hour) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AliasRateLimitKey returns the collection property key of the rate limit config applied to the alias,
+// the config key is one of the collection rate limit keys, such as CollectionSearchRateMaxKey.
+func AliasRateLimitKey(alias string, configKey string) string {
+	return AliasRateLimitKeyPrefix + alias + "." + configKey
+}
+
+// GetAliasRateLimitProperties returns the rate limit configs of the alias from the collection properties,
+// the returned map is keyed by the collection rate limit keys.
+func GetAliasRateLimitProperties(properties map[string]string, alias string) map[string]string {
+	prefix := AliasRateLimitKeyPrefix + alias + "."
+	result := make(map[string]string)
+	for key, value := range properties {
+		if configKey, ok := strings.CutPrefix(key, prefix); ok {
+			result[configKey] = value
+		}
+	}
+	return result
+}
+
+// HasAliasRateLimitProperties checks whether any alias rate limit config is set in the collection properties.
+func HasAliasRateLimitProperties(properties map[string]string) bool {
+	for key := range properties {
+		if strings.HasPrefix(key, AliasRateLimitKeyPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func GetCollectionTTLFromMap(kvs map[string]string) (time.Duration, error) {
 	value, exist := kvs[CollectionTTLConfigKey]
 	if !exist {
@@ -947,6 +1242,89 @@ func GetCollectionTTLFromMap(kvs map[string]string) (time.Duration, error) {
 	return time.Duration(ttlSeconds) * time.Second, nil
 }
 
+// PartitionTTLKey returns the collection property key of the partition ttl.
+func PartitionTTLKey(partitionID int64) string {
+	return PartitionTTLKeyPrefix + strconv.FormatInt(partitionID, 10) + PartitionTTLKeySuffix
+}
+
+// ParsePartitionTTLKey returns the partition id of the partition ttl key, false if the key is not a partition ttl key.
+func ParsePartitionTTLKey(key string) (int64, bool) {
+	id, ok := strings.CutPrefix(key, PartitionTTLKeyPrefix)
+	if !ok {
+		return 0, false
+	}
+	id, ok = strings.CutSuffix(id, PartitionTTLKeySuffix)
+	if !ok {
+		return 0, false
+	}
+	partitionID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return partitionID, true
+}
+
+// GetPartitionTTLFromMap returns the ttl of the partition overriding the collection ttl, false if it's not set.
+// A non-positive ttl means the data of the partition never expires.
+func GetPartitionTTLFromMap(kvs map[string]string, partitionID int64) (time.Duration, bool, error) {
+	value, exist := kvs[PartitionTTLKey(partitionID)]
+	if !exist {
+		return 0, false, nil
+	}
+
+	ttlSeconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	if ttlSeconds <= 0 {
+		return 0, true, nil
+	}
+	return time.Duration(ttlSeconds) * time.Second, true, nil
+}
+
+// GetPartitionsTTLFromMap returns the ttl of the data in the partitions, the partition ttl overrides the collection ttl.
+// The longest ttl is returned for multiple partitions, so no data is treated as expired before its own ttl,
+// and all the partitions of the collection are considered if no partition is given.
+// It returns the collection ttl as GetCollectionTTLFromMap does if no partition ttl applies,
+// a non-positive ttl means the data never expires.
+func GetPartitionsTTLFromMap(kvs map[string]string, partitionIDs ...int64) (time.Duration, error) {
+	collectionTTL, err := GetCollectionTTLFromMap(kvs)
+	if err != nil {
+		return 0, err
+	}
+	ttls := make([]time.Duration, 0, len(partitionIDs)+1)
+	if len(partitionIDs) == 0 {
+		// the partitions without the partition ttl take the collection ttl.
+		ttls = append(ttls, collectionTTL)
+		for key := range kvs {
+			if partitionID, ok := ParsePartitionTTLKey(key); ok {
+				partitionIDs = append(partitionIDs, partitionID)
+			}
+		}
+	}
+	for _, partitionID := range partitionIDs {
+		partitionTTL, ok, err := GetPartitionTTLFromMap(kvs, partitionID)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			partitionTTL = collectionTTL
+		}
+		ttls = append(ttls, partitionTTL)
+	}
+
+	ttl := ttls[0]
+	for _, partitionTTL := range ttls[1:] {
+		if ttl <= 0 {
+			break
+		}
+		if partitionTTL <= 0 || partitionTTL > ttl {
+			ttl = partitionTTL
+		}
+	}
+	return ttl, nil
+}
+
 func CheckNamespace(schema *schemapb.CollectionSchema, namespace *string) error {
 	enabled := schema.GetEnableNamespace()
 	namespaceIsSet := namespace != nil
@@ -315,6 +315,40 @@ func TestIsDisableFuncRuntimeCheck(t *testing.T) {
 	assert.False(t, disable)
 }
 
+func TestIsCollectionCritical(t *testing.T) {
+	assert.False(t, IsCollectionCritical())
+	assert.False(t, IsCollectionCritical(&commonpb.KeyValuePair{Key: CollectionCriticalKey, Value: "false"}))
+	assert.False(t, IsCollectionCritical(&commonpb.KeyValuePair{Key: CollectionCriticalKey, Value: "invalid"}))
+	assert.True(t, IsCollectionCritical(&commonpb.KeyValuePair{Key: CollectionCriticalKey, Value: "True"}))
+}
+
+func TestIsDatabaseDeletionProtected(t *testing.T) {
+	assert.False(t, IsDatabaseDeletionProtected())
+	assert.False(t, IsDatabaseDeletionProtected(&commonpb.KeyValuePair{Key: DatabaseDeletionProtectionKey, Value: "invalid"}))
+	assert.True(t, IsDatabaseDeletionProtected(&commonpb.KeyValuePair{Key: DatabaseDeletionProtectionKey, Value: "TRUE"}))
+}
+
+func TestIsCollectionRecycled(t *testing.T) {
+	assert.False(t, IsCollectionRecycled())
+	assert.False(t, IsCollectionRecycled(&commonpb.KeyValuePair{Key: CollectionRecycledKey, Value: "invalid"}))
+	assert.True(t, IsCollectionRecycled(&commonpb.KeyValuePair{Key: CollectionRecycledKey, Value: "true"}))
+}
+
+func TestDatabaseSoftDeleteWindow(t *testing.T) {
+	window, err := DatabaseSoftDeleteWindow()
+	assert.NoError(t, err)
+	assert.Zero(t, window)
+
+	window, err = DatabaseSoftDeleteWindow(&commonpb.KeyValuePair{Key: DatabaseSoftDeleteWindowKey, Value: "3600"})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, window)
+
+	_, err = DatabaseSoftDeleteWindow(&commonpb.KeyValuePair{Key: DatabaseSoftDeleteWindowKey, Value: "-1"})
+	assert.Error(t, err)
+	_, err = DatabaseSoftDeleteWindow(&commonpb.KeyValuePair{Key: DatabaseSoftDeleteWindowKey, Value: "1h"})
+	assert.Error(t, err)
+}
+
 func TestGetCollectionTTL(t *testing.T) {
 	type testCase struct {
 		tag       string
@@ -357,6 +391,109 @@ func TestGetCollectionTTL(t *testing.T) {
 	})
 }
 
+func TestAliasRateLimitProperties(t *testing.T) {
+	key := AliasRateLimitKey("a1", CollectionSearchRateMaxKey)
+	assert.Equal(t, "alias.a1.collection.searchRate.max.vps", key)
+
+	properties := map[string]string{
+		key: "10",
+		AliasRateLimitKey("a1", CollectionQueryRateMaxKey): "20",
+		AliasRateLimitKey("a2", CollectionQueryRateMaxKey): "30",
+		CollectionSearchRateMaxKey:                         "100",
+	}
+	assert.True(t, HasAliasRateLimitProperties(properties))
+	assert.Equal(t, map[string]string{
+		CollectionSearchRateMaxKey: "10",
+		CollectionQueryRateMaxKey:  "20",
+	}, GetAliasRateLimitProperties(properties, "a1"))
+	assert.Empty(t, GetAliasRateLimitProperties(properties, "a3"))
+	assert.False(t, HasAliasRateLimitProperties(map[string]string{CollectionSearchRateMaxKey: "100"}))
+}
+
+func TestPartitionTTL(t *testing.T) {
+	key := PartitionTTLKey(100)
+	assert.Equal(t, "partition.100.ttl.seconds", key)
+	partitionID, ok := ParsePartitionTTLKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), partitionID)
+	for _, key := range []string{CollectionTTLConfigKey, "partition.100", "partition.p1.ttl.seconds", "partition.100.ttl"} {
+		_, ok = ParsePartitionTTLKey(key)
+		assert.False(t, ok, key)
+	}
+
+	properties := map[string]string{
+		CollectionTTLConfigKey: "10",
+		PartitionTTLKey(100):   "20",
+		PartitionTTLKey(101):   "0",
+		PartitionTTLKey(102):   "invalid",
+	}
+	ttl, ok, err := GetPartitionTTLFromMap(properties, 100)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 20*time.Second, ttl)
+
+	// never expires
+	ttl, ok, err = GetPartitionTTLFromMap(properties, 101)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	_, _, err = GetPartitionTTLFromMap(properties, 102)
+	assert.Error(t, err)
+
+	_, ok, err = GetPartitionTTLFromMap(properties, 103)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestGetPartitionsTTLFromMap(t *testing.T) {
+	// no ttl
+	ttl, err := GetPartitionsTTLFromMap(map[string]string{}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(-1), ttl)
+
+	properties := map[string]string{
+		CollectionTTLConfigKey: "10",
+		PartitionTTLKey(100):   "20",
+		PartitionTTLKey(101):   "5",
+	}
+	ttl, err = GetPartitionsTTLFromMap(properties, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 20*time.Second, ttl)
+	ttl, err = GetPartitionsTTLFromMap(properties, 101)
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, ttl)
+	// the partition without partition ttl takes the collection ttl
+	ttl, err = GetPartitionsTTLFromMap(properties, 101, 102)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, ttl)
+	// the longest ttl of all the partitions
+	ttl, err = GetPartitionsTTLFromMap(properties)
+	assert.NoError(t, err)
+	assert.Equal(t, 20*time.Second, ttl)
+
+	// the partition never expires
+	properties[PartitionTTLKey(103)] = "0"
+	ttl, err = GetPartitionsTTLFromMap(properties, 100, 103)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), ttl)
+	ttl, err = GetPartitionsTTLFromMap(properties)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	// the collection ttl is not set
+	delete(properties, CollectionTTLConfigKey)
+	ttl, err = GetPartitionsTTLFromMap(properties, 100, 102)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(-1), ttl)
+
+	properties[PartitionTTLKey(104)] = "invalid"
+	_, err = GetPartitionsTTLFromMap(properties)
+	assert.Error(t, err)
+	_, err = GetPartitionsTTLFromMap(map[string]string{CollectionTTLConfigKey: "invalid"}, 100)
+	assert.Error(t, err)
+}
+
 func TestWarmupPolicy(t *testing.T) {
 	t.Run("GetWarmupPolicy", func(t *testing.T) {
 		// Test when warmup key exists
@@ -687,3 +824,78 @@ func TestWKTWKBConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestCompactionWindow(t *testing.T) {
+	windows, err := ParseCompactionWindows("22-6, 13-15")
+	assert.NoError(t, err)
+	assert.Equal(t, []CompactionWindow{{Start: 22, End: 6}, {Start: 13, End: 15}}, windows)
+	assert.True(t, windows[0].Contains(23))
+	assert.True(t, windows[0].Contains(5))
+	assert.False(t, windows[0].Contains(6))
+	assert.True(t, windows[1].Contains(13))
+	assert.False(t, windows[1].Contains(15))
+
+	for _, value := range []string{"", "1", "a-2", "1-b", "3-3", "24-1", "1-25", "1-2-3"} {
+		_, err := ParseCompactionWindows(value)
+		assert.Error(t, err, value)
+	}
+
+	at := time.Date(2024, 1, 1, 3, 30, 0, 0, time.UTC)
+	inWindow, err := IsInCompactionWindow(map[string]string{}, at)
+	assert.NoError(t, err)
+	assert.True(t, inWindow)
+
+	inWindow, err = IsInCompactionWindow(map[string]string{CollectionCompactionWindowKey: "1-4"}, at)
+	assert.NoError(t, err)
+	assert.True(t, inWindow)
+
+	// 03:30 UTC is 11:30 in Asia/Shanghai
+	inWindow, err = IsInCompactionWindow(map[string]string{CollectionCompactionWindowKey: "1-4", TimezoneKey: "Asia/Shanghai"}, at)
+	assert.NoError(t, err)
+	assert.False(t, inWindow)
+
+	_, err = IsInCompactionWindow(map[string]string{CollectionCompactionWindowKey: "invalid"}, at)
+	assert.Error(t, err)
+}
+
+func TestGetCollectionIdleReleaseDuration(t *testing.T) {
+	assert.Equal(t, time.Duration(0), GetCollectionIdleReleaseDuration(map[string]string{}))
+	assert.Equal(t, time.Hour, GetCollectionIdleReleaseDuration(map[string]string{CollectionIdleReleaseSecondsKey: "3600"}))
+	assert.Equal(t, time.Duration(0), GetCollectionIdleReleaseDuration(map[string]string{CollectionIdleReleaseSecondsKey: "0"}))
+	assert.Equal(t, time.Duration(0), GetCollectionIdleReleaseDuration(map[string]string{CollectionIdleReleaseSecondsKey: "abc"}))
+}
+
+func TestIsCollectionCompactionSortedOutput(t *testing.T) {
+	assert.False(t, IsCollectionCompactionSortedOutput(map[string]string{}))
+	assert.True(t, IsCollectionCompactionSortedOutput(map[string]string{CollectionCompactionSortedOutputKey: "True"}))
+	assert.False(t, IsCollectionCompactionSortedOutput(map[string]string{CollectionCompactionSortedOutputKey: "false"}))
+	assert.False(t, IsCollectionCompactionSortedOutput(map[string]string{CollectionCompactionSortedOutputKey: "abc"}))
+}
+
+func TestGetCollectionFreezeTimestamp(t *testing.T) {
+	assert.Equal(t, uint64(0), GetCollectionFreezeTimestamp(map[string]string{}))
+	assert.Equal(t, uint64(449563457651212289), GetCollectionFreezeTimestamp(map[string]string{CollectionFreezeTimestampKey: "449563457651212289"}))
+	assert.Equal(t, uint64(0), GetCollectionFreezeTimestamp(map[string]string{CollectionFreezeTimestampKey: "abc"}))
+}
+
+func TestIdleReleasedLoadConfig(t *testing.T) {
+	_, ok := GetIdleReleasedLoadConfig(map[string]string{})
+	assert.False(t, ok)
+	_, ok = GetIdleReleasedLoadConfig(map[string]string{CollectionIdleReleasedLoadConfigKey: "invalid"})
+	assert.False(t, ok)
+	_, ok = GetIdleReleasedLoadConfig(map[string]string{CollectionIdleReleasedLoadConfigKey: "{}"})
+	assert.False(t, ok)
+
+	config := &IdleReleasedLoadConfig{
+		ReplicaNumber:        2,
+		ResourceGroups:       []string{"rg1", "rg2"},
+		LoadFields:           []string{"pk", "vec"},
+		SkipLoadDynamicField: true,
+		Partitions:           []string{"p1"},
+	}
+	value, err := MarshalIdleReleasedLoadConfig(config)
+	assert.NoError(t, err)
+	got, ok := GetIdleReleasedLoadConfig(map[string]string{CollectionIdleReleasedLoadConfigKey: value})
+	assert.True(t, ok)
+	assert.Equal(t, config, got)
+}
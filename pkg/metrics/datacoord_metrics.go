@@ -199,6 +199,17 @@ var (
 			statusLabelName,
 		})
 
+	DataCoordCompactionCleaningTaskNum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "compaction_cleaning_task_num",
+			Help:      "Number of compaction tasks waiting to be cleaned, status is stuck if failing to be cleaned too long",
+		}, []string{
+			compactionTypeLabelName,
+			statusLabelName,
+		})
+
 	DataCoordCompactionLatency = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: milvusNamespace,
@@ -213,6 +224,27 @@ var (
 			stageLabelName,
 		})
 
+	DataCoordCompactionQueueLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "compaction_queue_latency",
+			Help:      "latency of compaction task waiting in the queue before it's scheduled",
+			Buckets:   longTaskBuckets,
+		}, []string{
+			compactionTypeLabelName,
+		})
+
+	DataCoordCompactionQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "compaction_queue_depth",
+			Help:      "number of the compaction tasks waiting in the queue of each database, only reported with the database task prioritizer",
+		}, []string{
+			databaseLabelName,
+		})
+
 	ImportJobLatency = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: milvusNamespace,
@@ -264,6 +296,15 @@ var (
 			Help:      "garbage collection running count",
 		}, []string{nodeIDLabelName})
 
+	// GarbageCollectorOrphanSegmentNum records the orphan segments found by the last orphan segment check.
+	GarbageCollectorOrphanSegmentNum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "gc_orphan_segment_num",
+			Help:      "number of orphan segments found by the last orphan segment check",
+		}, []string{nodeIDLabelName, orphanTypeLabelName})
+
 	/* hard to implement, commented now
 	DataCoordSegmentSizeRatio = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -451,7 +492,10 @@ func RegisterDataCoord(registry *prometheus.Registry) {
 	registry.MustRegister(DataCoordDmlChannelNum)
 	registry.MustRegister(DataCoordCompactedSegmentSize)
 	registry.MustRegister(DataCoordCompactionTaskNum)
+	registry.MustRegister(DataCoordCompactionCleaningTaskNum)
 	registry.MustRegister(DataCoordCompactionLatency)
+	registry.MustRegister(DataCoordCompactionQueueLatency)
+	registry.MustRegister(DataCoordCompactionQueueDepth)
 	registry.MustRegister(ImportJobLatency)
 	registry.MustRegister(ImportTaskLatency)
 	registry.MustRegister(DataCoordSizeStoredL0Segment)
@@ -468,6 +512,7 @@ func RegisterDataCoord(registry *prometheus.Registry) {
 	registry.MustRegister(CopySegmentJobLatency)
 	registry.MustRegister(GarbageCollectorFileScanDuration)
 	registry.MustRegister(GarbageCollectorRunCount)
+	registry.MustRegister(GarbageCollectorOrphanSegmentNum)
 	registry.MustRegister(DataCoordTaskExecuteLatency)
 	registry.MustRegister(IndexStatsTaskNum)
 	registry.MustRegister(TaskVersion)
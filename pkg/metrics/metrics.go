@@ -106,6 +106,8 @@ const (
 	Pending   = "pending"
 	Executing = "executing"
 	Done      = "done"
+	Cleaning  = "cleaning"
+	Stuck     = "stuck"
 
 	ImportStagePending      = "pending"
 	ImportStagePreImport    = "preimport"
@@ -177,6 +179,7 @@ const (
 
 	filesystemKeyLabelName = "fs"
 	reasonLabelName        = "reason"
+	orphanTypeLabelName    = "orphan_type"
 )
 
 var (
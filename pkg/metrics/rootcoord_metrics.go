@@ -74,6 +74,15 @@ var (
 			Help:      "lateste timestamp allocated in memory",
 		})
 
+	// RootCoordDBTimestampAllocCounter counts the timestamps allocated or rejected for each database.
+	RootCoordDBTimestampAllocCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.RootCoordRole,
+			Name:      "db_timestamp_alloc_count",
+			Help:      "count of timestamps allocated for each database",
+		}, []string{databaseLabelName, statusLabelName})
+
 	// RootCoordTimestampSaved records the number of timestamp allocations in ETCD.
 	RootCoordTimestampSaved = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -179,6 +188,30 @@ var (
 			"name",
 		})
 
+	// RootCoordSimulatedQuotaStates records the quota states calculated in the simulate mode.
+	RootCoordSimulatedQuotaStates = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.RootCoordRole,
+			Name:      "simulated_quota_states",
+			Help:      "The quota states calculated but not applied in the simulate mode",
+		}, []string{
+			"quota_states",
+			"name",
+		})
+
+	// RootCoordSimulatedRateLimit records the rate limits calculated in the simulate mode.
+	RootCoordSimulatedRateLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.RootCoordRole,
+			Name:      "simulated_rate_limit",
+			Help:      "The rate limits calculated but not applied in the simulate mode",
+		}, []string{
+			"name",
+			"rate_type",
+		})
+
 	// RootCoordForceDenyWritingCounter records the number of times that milvus turns into force-deny-writing states.
 	RootCoordForceDenyWritingCounter = prometheus.NewCounter(
 		prometheus.CounterOpts{
@@ -188,6 +221,40 @@ var (
 			Help:      "The number of times milvus turns into force-deny-writing states",
 		})
 
+	// RootCoordProxyLimiterDivergenceCounter records the number of times that the proxy enforces limits different from the last pushed ones.
+	RootCoordProxyLimiterDivergenceCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.RootCoordRole,
+			Name:      "proxy_limiter_divergence_counter",
+			Help:      "The number of times the proxy enforces limits different from the ones last pushed by the quota center",
+		}, []string{nodeIDLabelName})
+
+	// RootCoordCollectionWriteFactor records the write rate factors of the collections calculated by each protection.
+	RootCoordCollectionWriteFactor = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.RootCoordRole,
+			Name:      "collection_write_factor",
+			Help:      "The write rate factor of collection calculated by each protection, 1 means not limited and 0 means denied",
+		}, []string{
+			collectionIDLabelName,
+			"factor",
+		})
+
+	// RootCoordNodeWriteFactor records the write rate factors calculated by the metrics of each node.
+	RootCoordNodeWriteFactor = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.RootCoordRole,
+			Name:      "node_write_factor",
+			Help:      "The write rate factor calculated by the metrics of node, applied to the collections on the node",
+		}, []string{
+			roleNameLabelName,
+			nodeIDLabelName,
+			"factor",
+		})
+
 	// RootCoordRateLimitRatio reflects the ratio of rate limit.
 	RootCoordRateLimitRatio = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -264,6 +331,7 @@ func RegisterMixCoord(registry *prometheus.Registry) {
 	registry.MustRegister(RootCoordIDAllocCounter)
 	registry.MustRegister(RootCoordTimestamp)
 	registry.MustRegister(RootCoordTimestampSaved)
+	registry.MustRegister(RootCoordDBTimestampAllocCounter)
 
 	// for collection
 	registry.MustRegister(RootCoordNumOfCollections)
@@ -278,7 +346,12 @@ func RegisterMixCoord(registry *prometheus.Registry) {
 	registry.MustRegister(RootCoordNumOfRoles)
 	registry.MustRegister(RootCoordTtDelay)
 	registry.MustRegister(RootCoordQuotaStates)
+	registry.MustRegister(RootCoordSimulatedQuotaStates)
+	registry.MustRegister(RootCoordSimulatedRateLimit)
 	registry.MustRegister(RootCoordForceDenyWritingCounter)
+	registry.MustRegister(RootCoordProxyLimiterDivergenceCounter)
+	registry.MustRegister(RootCoordCollectionWriteFactor)
+	registry.MustRegister(RootCoordNodeWriteFactor)
 	registry.MustRegister(RootCoordRateLimitRatio)
 	registry.MustRegister(RootCoordDDLReqLatencyInQueue)
 
@@ -298,6 +371,9 @@ func CleanupRootCoordDBMetrics(dbName string) {
 	RootCoordNumOfCollections.Delete(prometheus.Labels{
 		databaseLabelName: dbName,
 	})
+	RootCoordDBTimestampAllocCounter.DeletePartialMatch(prometheus.Labels{
+		databaseLabelName: dbName,
+	})
 }
 
 func CleanupRootCoordCollectionMetrics(dbName string, collectionName string) {
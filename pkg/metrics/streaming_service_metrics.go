@@ -32,6 +32,7 @@ const (
 	WALFlusherStateLabelName              = "state"
 	WALRecoveryStorageStateLabelName      = "state"
 	WALStateLabelName                     = "state"
+	PChannelRecoveryResultLabelName       = "result"
 	WALRateLimitControllerSourceLabelName = "source"
 	WALRateLimitStateLabelName            = "state"
 	WALChannelLabelName                   = channelNameLabelName
@@ -131,6 +132,16 @@ var (
 		Help: "Info of assignment",
 	})
 
+	StreamingCoordRecoveredPChannelTotal = newStreamingCoordGaugeVec(prometheus.GaugeOpts{
+		Name: "recovered_pchannel_total",
+		Help: "Total of pchannels reconciled by result when the channel manager is recovered",
+	}, PChannelRecoveryResultLabelName)
+
+	StreamingCoordDuplicateChannelWatchTotal = newStreamingCoordCounterVec(prometheus.CounterOpts{
+		Name: "duplicate_channel_watch_total",
+		Help: "Total of pchannel watches released because the pchannel is served by more than one streaming node",
+	}, WALChannelLabelName, StreamingNodeLabelName)
+
 	StreamingCoordAssignmentListenerTotal = newStreamingCoordGaugeVec(prometheus.GaugeOpts{
 		Name: "assignment_listener_total",
 		Help: "Total of assignment listener",
@@ -606,6 +617,8 @@ func registerStreamingCoord(registry *prometheus.Registry) {
 	registry.MustRegister(StreamingCoordPChannelInfo)
 	registry.MustRegister(StreamingCoordVChannelTotal)
 	registry.MustRegister(StreamingCoordAssignmentVersion)
+	registry.MustRegister(StreamingCoordRecoveredPChannelTotal)
+	registry.MustRegister(StreamingCoordDuplicateChannelWatchTotal)
 	registry.MustRegister(StreamingCoordAssignmentListenerTotal)
 	registry.MustRegister(StreamingCoordBroadcasterTaskTotal)
 	registry.MustRegister(StreamingCoordBroadcasterTaskExecutionDurationSeconds)
@@ -715,6 +728,13 @@ func newStreamingCoordGaugeVec(opts prometheus.GaugeOpts, extra ...string) *prom
 	return prometheus.NewGaugeVec(opts, labels)
 }
 
+func newStreamingCoordCounterVec(opts prometheus.CounterOpts, extra ...string) *prometheus.CounterVec {
+	opts.Namespace = milvusNamespace
+	opts.Subsystem = typeutil.StreamingCoordRole
+	labels := mergeLabel(extra...)
+	return prometheus.NewCounterVec(opts, labels)
+}
+
 func newStreamingCoordHistogramVec(opts prometheus.HistogramOpts, extra ...string) *prometheus.HistogramVec {
 	opts.Namespace = milvusNamespace
 	opts.Subsystem = typeutil.StreamingCoordRole
@@ -78,6 +78,7 @@ const (
 	DataCoord_ExportSnapshot_FullMethodName                       = "/milvus.proto.data.DataCoord/ExportSnapshot"
 	DataCoord_GetRestoreSnapshotState_FullMethodName              = "/milvus.proto.data.DataCoord/GetRestoreSnapshotState"
 	DataCoord_ListRestoreSnapshotJobs_FullMethodName              = "/milvus.proto.data.DataCoord/ListRestoreSnapshotJobs"
+	DataCoord_CloneCollection_FullMethodName                      = "/milvus.proto.data.DataCoord/CloneCollection"
 	DataCoord_PinSnapshotData_FullMethodName                      = "/milvus.proto.data.DataCoord/PinSnapshotData"
 	DataCoord_UnpinSnapshotData_FullMethodName                    = "/milvus.proto.data.DataCoord/UnpinSnapshotData"
 	DataCoord_BatchUpdateManifest_FullMethodName                  = "/milvus.proto.data.DataCoord/BatchUpdateManifest"
@@ -88,6 +89,7 @@ const (
 	DataCoord_CommitImport_FullMethodName                         = "/milvus.proto.data.DataCoord/CommitImport"
 	DataCoord_AbortImport_FullMethodName                          = "/milvus.proto.data.DataCoord/AbortImport"
 	DataCoord_HandleCommitVchannel_FullMethodName                 = "/milvus.proto.data.DataCoord/HandleCommitVchannel"
+	DataCoord_ReindexSegments_FullMethodName                      = "/milvus.proto.data.DataCoord/ReindexSegments"
 )
 
 // DataCoordClient is the client API for DataCoord service.
@@ -159,6 +161,7 @@ type DataCoordClient interface {
 	ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (*ExportSnapshotResponse, error)
 	GetRestoreSnapshotState(ctx context.Context, in *GetRestoreSnapshotStateRequest, opts ...grpc.CallOption) (*GetRestoreSnapshotStateResponse, error)
 	ListRestoreSnapshotJobs(ctx context.Context, in *ListRestoreSnapshotJobsRequest, opts ...grpc.CallOption) (*ListRestoreSnapshotJobsResponse, error)
+	CloneCollection(ctx context.Context, in *CloneCollectionRequest, opts ...grpc.CallOption) (*CloneCollectionResponse, error)
 	PinSnapshotData(ctx context.Context, in *PinSnapshotDataRequest, opts ...grpc.CallOption) (*PinSnapshotDataResponse, error)
 	UnpinSnapshotData(ctx context.Context, in *UnpinSnapshotDataRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	// batch update manifest
@@ -174,6 +177,8 @@ type DataCoordClient interface {
 	CommitImport(ctx context.Context, in *CommitImportRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	AbortImport(ctx context.Context, in *AbortImportRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	HandleCommitVchannel(ctx context.Context, in *HandleCommitVchannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	// reindex the segments by the mix compaction of each segment, bypassing the size heuristics
+	ReindexSegments(ctx context.Context, in *ReindexSegmentsRequest, opts ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error)
 }
 
 type dataCoordClient struct {
@@ -681,6 +686,15 @@ func (c *dataCoordClient) ListRestoreSnapshotJobs(ctx context.Context, in *ListR
 	return out, nil
 }
 
+func (c *dataCoordClient) CloneCollection(ctx context.Context, in *CloneCollectionRequest, opts ...grpc.CallOption) (*CloneCollectionResponse, error) {
+	out := new(CloneCollectionResponse)
+	err := c.cc.Invoke(ctx, DataCoord_CloneCollection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataCoordClient) PinSnapshotData(ctx context.Context, in *PinSnapshotDataRequest, opts ...grpc.CallOption) (*PinSnapshotDataResponse, error) {
 	out := new(PinSnapshotDataResponse)
 	err := c.cc.Invoke(ctx, DataCoord_PinSnapshotData_FullMethodName, in, out, opts...)
@@ -771,6 +785,15 @@ func (c *dataCoordClient) HandleCommitVchannel(ctx context.Context, in *HandleCo
 	return out, nil
 }
 
+func (c *dataCoordClient) ReindexSegments(ctx context.Context, in *ReindexSegmentsRequest, opts ...grpc.CallOption) (*milvuspb.ManualCompactionResponse, error) {
+	out := new(milvuspb.ManualCompactionResponse)
+	err := c.cc.Invoke(ctx, DataCoord_ReindexSegments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataCoordServer is the server API for DataCoord service.
 // All implementations should embed UnimplementedDataCoordServer
 // for forward compatibility
@@ -840,6 +863,7 @@ type DataCoordServer interface {
 	ExportSnapshot(context.Context, *ExportSnapshotRequest) (*ExportSnapshotResponse, error)
 	GetRestoreSnapshotState(context.Context, *GetRestoreSnapshotStateRequest) (*GetRestoreSnapshotStateResponse, error)
 	ListRestoreSnapshotJobs(context.Context, *ListRestoreSnapshotJobsRequest) (*ListRestoreSnapshotJobsResponse, error)
+	CloneCollection(context.Context, *CloneCollectionRequest) (*CloneCollectionResponse, error)
 	PinSnapshotData(context.Context, *PinSnapshotDataRequest) (*PinSnapshotDataResponse, error)
 	UnpinSnapshotData(context.Context, *UnpinSnapshotDataRequest) (*commonpb.Status, error)
 	// batch update manifest
@@ -855,6 +879,8 @@ type DataCoordServer interface {
 	CommitImport(context.Context, *CommitImportRequest) (*commonpb.Status, error)
 	AbortImport(context.Context, *AbortImportRequest) (*commonpb.Status, error)
 	HandleCommitVchannel(context.Context, *HandleCommitVchannelRequest) (*commonpb.Status, error)
+	// reindex the segments by the mix compaction of each segment, bypassing the size heuristics
+	ReindexSegments(context.Context, *ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error)
 }
 
 // UnimplementedDataCoordServer should be embedded to have forward compatible implementations.
@@ -1026,6 +1052,10 @@ func (UnimplementedDataCoordServer) GetRestoreSnapshotState(context.Context, *Ge
 func (UnimplementedDataCoordServer) ListRestoreSnapshotJobs(context.Context, *ListRestoreSnapshotJobsRequest) (*ListRestoreSnapshotJobsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRestoreSnapshotJobs not implemented")
 }
+
+func (UnimplementedDataCoordServer) CloneCollection(context.Context, *CloneCollectionRequest) (*CloneCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneCollection not implemented")
+}
 func (UnimplementedDataCoordServer) PinSnapshotData(context.Context, *PinSnapshotDataRequest) (*PinSnapshotDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PinSnapshotData not implemented")
 }
@@ -1056,6 +1086,9 @@ func (UnimplementedDataCoordServer) AbortImport(context.Context, *AbortImportReq
 func (UnimplementedDataCoordServer) HandleCommitVchannel(context.Context, *HandleCommitVchannelRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleCommitVchannel not implemented")
 }
+func (UnimplementedDataCoordServer) ReindexSegments(context.Context, *ReindexSegmentsRequest) (*milvuspb.ManualCompactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReindexSegments not implemented")
+}
 
 // UnsafeDataCoordServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DataCoordServer will
@@ -2058,6 +2091,24 @@ func _DataCoord_ListRestoreSnapshotJobs_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataCoord_CloneCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCoordServer).CloneCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataCoord_CloneCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCoordServer).CloneCollection(ctx, req.(*CloneCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataCoord_PinSnapshotData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PinSnapshotDataRequest)
 	if err := dec(in); err != nil {
@@ -2238,6 +2289,24 @@ func _DataCoord_HandleCommitVchannel_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataCoord_ReindexSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexSegmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCoordServer).ReindexSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataCoord_ReindexSegments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCoordServer).ReindexSegments(ctx, req.(*ReindexSegmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DataCoord_ServiceDesc is the grpc.ServiceDesc for DataCoord service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2465,6 +2534,10 @@ var DataCoord_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListRestoreSnapshotJobs",
 			Handler:    _DataCoord_ListRestoreSnapshotJobs_Handler,
 		},
+		{
+			MethodName: "CloneCollection",
+			Handler:    _DataCoord_CloneCollection_Handler,
+		},
 		{
 			MethodName: "PinSnapshotData",
 			Handler:    _DataCoord_PinSnapshotData_Handler,
@@ -2505,6 +2578,10 @@ var DataCoord_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HandleCommitVchannel",
 			Handler:    _DataCoord_HandleCommitVchannel_Handler,
 		},
+		{
+			MethodName: "ReindexSegments",
+			Handler:    _DataCoord_ReindexSegments_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "data_coord.proto",
@@ -58,6 +58,9 @@ const (
 	QueryCoord_TransferSegment_FullMethodName            = "/milvus.proto.query.QueryCoord/TransferSegment"
 	QueryCoord_TransferChannel_FullMethodName            = "/milvus.proto.query.QueryCoord/TransferChannel"
 	QueryCoord_CheckQueryNodeDistribution_FullMethodName = "/milvus.proto.query.QueryCoord/CheckQueryNodeDistribution"
+	QueryCoord_DecommissionNode_FullMethodName           = "/milvus.proto.query.QueryCoord/DecommissionNode"
+	QueryCoord_ListReplicaSuggestions_FullMethodName     = "/milvus.proto.query.QueryCoord/ListReplicaSuggestions"
+	QueryCoord_ApproveReplicaSuggestions_FullMethodName  = "/milvus.proto.query.QueryCoord/ApproveReplicaSuggestions"
 	QueryCoord_ClearReadTaskQueue_FullMethodName         = "/milvus.proto.query.QueryCoord/ClearReadTaskQueue"
 	QueryCoord_UpdateLoadConfig_FullMethodName           = "/milvus.proto.query.QueryCoord/UpdateLoadConfig"
 	QueryCoord_RunAnalyzer_FullMethodName                = "/milvus.proto.query.QueryCoord/RunAnalyzer"
@@ -109,6 +112,9 @@ type QueryCoordClient interface {
 	TransferSegment(ctx context.Context, in *TransferSegmentRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	TransferChannel(ctx context.Context, in *TransferChannelRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	CheckQueryNodeDistribution(ctx context.Context, in *CheckQueryNodeDistributionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	DecommissionNode(ctx context.Context, in *DecommissionNodeRequest, opts ...grpc.CallOption) (*DecommissionNodeResponse, error)
+	ListReplicaSuggestions(ctx context.Context, in *ListReplicaSuggestionsRequest, opts ...grpc.CallOption) (*ListReplicaSuggestionsResponse, error)
+	ApproveReplicaSuggestions(ctx context.Context, in *ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	ClearReadTaskQueue(ctx context.Context, in *internalpb.ClearReadTaskQueueRequest, opts ...grpc.CallOption) (*internalpb.ClearReadTaskQueueResponse, error)
 	UpdateLoadConfig(ctx context.Context, in *UpdateLoadConfigRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	RunAnalyzer(ctx context.Context, in *RunAnalyzerRequest, opts ...grpc.CallOption) (*milvuspb.RunAnalyzerResponse, error)
@@ -449,6 +455,33 @@ func (c *queryCoordClient) CheckQueryNodeDistribution(ctx context.Context, in *C
 	return out, nil
 }
 
+func (c *queryCoordClient) DecommissionNode(ctx context.Context, in *DecommissionNodeRequest, opts ...grpc.CallOption) (*DecommissionNodeResponse, error) {
+	out := new(DecommissionNodeResponse)
+	err := c.cc.Invoke(ctx, QueryCoord_DecommissionNode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryCoordClient) ListReplicaSuggestions(ctx context.Context, in *ListReplicaSuggestionsRequest, opts ...grpc.CallOption) (*ListReplicaSuggestionsResponse, error) {
+	out := new(ListReplicaSuggestionsResponse)
+	err := c.cc.Invoke(ctx, QueryCoord_ListReplicaSuggestions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryCoordClient) ApproveReplicaSuggestions(ctx context.Context, in *ApproveReplicaSuggestionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, QueryCoord_ApproveReplicaSuggestions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queryCoordClient) ClearReadTaskQueue(ctx context.Context, in *internalpb.ClearReadTaskQueueRequest, opts ...grpc.CallOption) (*internalpb.ClearReadTaskQueueResponse, error) {
 	out := new(internalpb.ClearReadTaskQueueResponse)
 	err := c.cc.Invoke(ctx, QueryCoord_ClearReadTaskQueue_FullMethodName, in, out, opts...)
@@ -538,6 +571,9 @@ type QueryCoordServer interface {
 	TransferSegment(context.Context, *TransferSegmentRequest) (*commonpb.Status, error)
 	TransferChannel(context.Context, *TransferChannelRequest) (*commonpb.Status, error)
 	CheckQueryNodeDistribution(context.Context, *CheckQueryNodeDistributionRequest) (*commonpb.Status, error)
+	DecommissionNode(context.Context, *DecommissionNodeRequest) (*DecommissionNodeResponse, error)
+	ListReplicaSuggestions(context.Context, *ListReplicaSuggestionsRequest) (*ListReplicaSuggestionsResponse, error)
+	ApproveReplicaSuggestions(context.Context, *ApproveReplicaSuggestionsRequest) (*commonpb.Status, error)
 	ClearReadTaskQueue(context.Context, *internalpb.ClearReadTaskQueueRequest) (*internalpb.ClearReadTaskQueueResponse, error)
 	UpdateLoadConfig(context.Context, *UpdateLoadConfigRequest) (*commonpb.Status, error)
 	RunAnalyzer(context.Context, *RunAnalyzerRequest) (*milvuspb.RunAnalyzerResponse, error)
@@ -657,6 +693,15 @@ func (UnimplementedQueryCoordServer) TransferChannel(context.Context, *TransferC
 func (UnimplementedQueryCoordServer) CheckQueryNodeDistribution(context.Context, *CheckQueryNodeDistributionRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckQueryNodeDistribution not implemented")
 }
+func (UnimplementedQueryCoordServer) DecommissionNode(context.Context, *DecommissionNodeRequest) (*DecommissionNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecommissionNode not implemented")
+}
+func (UnimplementedQueryCoordServer) ListReplicaSuggestions(context.Context, *ListReplicaSuggestionsRequest) (*ListReplicaSuggestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReplicaSuggestions not implemented")
+}
+func (UnimplementedQueryCoordServer) ApproveReplicaSuggestions(context.Context, *ApproveReplicaSuggestionsRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveReplicaSuggestions not implemented")
+}
 func (UnimplementedQueryCoordServer) ClearReadTaskQueue(context.Context, *internalpb.ClearReadTaskQueueRequest) (*internalpb.ClearReadTaskQueueResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearReadTaskQueue not implemented")
 }
@@ -1332,6 +1377,60 @@ func _QueryCoord_CheckQueryNodeDistribution_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueryCoord_DecommissionNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecommissionNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryCoordServer).DecommissionNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryCoord_DecommissionNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryCoordServer).DecommissionNode(ctx, req.(*DecommissionNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryCoord_ListReplicaSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReplicaSuggestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryCoordServer).ListReplicaSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryCoord_ListReplicaSuggestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryCoordServer).ListReplicaSuggestions(ctx, req.(*ListReplicaSuggestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryCoord_ApproveReplicaSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveReplicaSuggestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryCoordServer).ApproveReplicaSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryCoord_ApproveReplicaSuggestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryCoordServer).ApproveReplicaSuggestions(ctx, req.(*ApproveReplicaSuggestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QueryCoord_ClearReadTaskQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(internalpb.ClearReadTaskQueueRequest)
 	if err := dec(in); err != nil {
@@ -1573,6 +1672,18 @@ var QueryCoord_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckQueryNodeDistribution",
 			Handler:    _QueryCoord_CheckQueryNodeDistribution_Handler,
 		},
+		{
+			MethodName: "DecommissionNode",
+			Handler:    _QueryCoord_DecommissionNode_Handler,
+		},
+		{
+			MethodName: "ListReplicaSuggestions",
+			Handler:    _QueryCoord_ListReplicaSuggestions_Handler,
+		},
+		{
+			MethodName: "ApproveReplicaSuggestions",
+			Handler:    _QueryCoord_ApproveReplicaSuggestions_Handler,
+		},
 		{
 			MethodName: "ClearReadTaskQueue",
 			Handler:    _QueryCoord_ClearReadTaskQueue_Handler,
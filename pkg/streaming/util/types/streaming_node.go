@@ -91,8 +91,9 @@ type StreamingNodeInfoWithResourceGroup struct {
 // StreamingNodeStatus is the information of a streaming node.
 type StreamingNodeStatus struct {
 	StreamingNodeInfo
-	Metrics StreamingNodeMetrics
-	Err     error
+	Metrics      StreamingNodeMetrics
+	ChannelClass string // Channel class label from session's ServerLabels, if empty, the streaming node is in general rotation.
+	Err          error
 }
 
 // IsHealthy returns whether the streaming node is healthy.
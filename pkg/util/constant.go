@@ -461,6 +461,41 @@ func GetPrivilegeLevel(privilege string) string {
 	return ""
 }
 
+// The operation classes of the privileges, a credential scope grants a user the operation classes on its collections.
+const (
+	OperationClassDDL = "ddl"
+	OperationClassDML = "dml"
+	OperationClassDQL = "dql"
+)
+
+var dmlPrivileges = ConvertPrivileges([]string{
+	commonpb.ObjectPrivilege_PrivilegeInsert.String(),
+	commonpb.ObjectPrivilege_PrivilegeDelete.String(),
+	commonpb.ObjectPrivilege_PrivilegeUpsert.String(),
+	commonpb.ObjectPrivilege_PrivilegeImport.String(),
+	commonpb.ObjectPrivilege_PrivilegeFlush.String(),
+})
+
+// IsOperationClass returns whether the name is a valid operation class.
+func IsOperationClass(name string) bool {
+	return name == OperationClassDDL || name == OperationClassDML || name == OperationClassDQL
+}
+
+// GetOperationClass returns the operation class of the privilege in api format,
+// the read only privileges are dql, the ones writing data are dml and the others are ddl.
+func GetOperationClass(privilege string) string {
+	switch {
+	case lo.Contains(CollectionReadOnlyPrivileges, privilege),
+		lo.Contains(DatabaseReadOnlyPrivileges, privilege),
+		lo.Contains(ClusterReadOnlyPrivileges, privilege):
+		return OperationClassDQL
+	case lo.Contains(dmlPrivileges, privilege):
+		return OperationClassDML
+	default:
+		return OperationClassDDL
+	}
+}
+
 // StringSet convert array to map for conveniently check if the array contains an element
 func StringSet(strings []string) map[string]struct{} {
 	stringsMap := make(map[string]struct{})
@@ -46,3 +46,15 @@ func TestExprPrivilegeDefinition(t *testing.T) {
 	assert.True(t, IsPrivilegeNameDefined("Expr"))
 	assert.Equal(t, milvuspb.PrivilegeLevel_Cluster.String(), GetPrivilegeLevel("Expr"))
 }
+
+func TestGetOperationClass(t *testing.T) {
+	assert.Equal(t, OperationClassDQL, GetOperationClass(MetaStore2API(commonpb.ObjectPrivilege_PrivilegeSearch.String())))
+	assert.Equal(t, OperationClassDQL, GetOperationClass(MetaStore2API(commonpb.ObjectPrivilege_PrivilegeShowCollections.String())))
+	assert.Equal(t, OperationClassDML, GetOperationClass(MetaStore2API(commonpb.ObjectPrivilege_PrivilegeInsert.String())))
+	assert.Equal(t, OperationClassDML, GetOperationClass(MetaStore2API(commonpb.ObjectPrivilege_PrivilegeUpsert.String())))
+	assert.Equal(t, OperationClassDDL, GetOperationClass(MetaStore2API(commonpb.ObjectPrivilege_PrivilegeCreateIndex.String())))
+	assert.Equal(t, OperationClassDDL, GetOperationClass(MetaStore2API(commonpb.ObjectPrivilege_PrivilegeDropCollection.String())))
+
+	assert.True(t, IsOperationClass(OperationClassDML))
+	assert.False(t, IsOperationClass("admin"))
+}
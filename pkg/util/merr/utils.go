@@ -134,9 +134,55 @@ func Status(err error) *commonpb.Status {
 		// but you may retry" signal.
 		status.Retriable = false
 	}
+	for key, value := range GetExtraInfo(err) {
+		if status.ExtraInfo == nil {
+			status.ExtraInfo = make(map[string]string)
+		}
+		status.ExtraInfo[key] = value
+	}
 	return status
 }
 
+// extraInfoError attaches the machine-readable key-values to the error,
+// which are returned to the client in the ExtraInfo of the status.
+type extraInfoError struct {
+	error
+	extraInfo map[string]string
+}
+
+func (e *extraInfoError) Unwrap() error {
+	return e.error
+}
+
+// WithExtraInfo attaches the extra info to the error, the code and type of the error are kept.
+func WithExtraInfo(err error, extraInfo map[string]string) error {
+	if err == nil || len(extraInfo) == 0 {
+		return err
+	}
+	return &extraInfoError{error: err, extraInfo: extraInfo}
+}
+
+// GetExtraInfo returns the extra info attached to the error chain,
+// the outer one takes precedence if a key is attached more than once.
+func GetExtraInfo(err error) map[string]string {
+	var extraInfo map[string]string
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		e, ok := cur.(*extraInfoError)
+		if !ok {
+			continue
+		}
+		if extraInfo == nil {
+			extraInfo = make(map[string]string, len(e.extraInfo))
+		}
+		for key, value := range e.extraInfo {
+			if _, ok := extraInfo[key]; !ok {
+				extraInfo[key] = value
+			}
+		}
+	}
+	return extraInfo
+}
+
 func CheckRPCCall(resp any, err error) error {
 	if err != nil {
 		return err
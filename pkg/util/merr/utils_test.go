@@ -119,6 +119,22 @@ func TestStatusInputErrorForcesNonRetriable(t *testing.T) {
 	assert.True(t, plain.GetRetriable())
 }
 
+func TestStatusWithExtraInfo(t *testing.T) {
+	assert.NoError(t, WithExtraInfo(nil, map[string]string{"key": "value"}))
+	assert.Nil(t, GetExtraInfo(ErrServiceQuotaExceeded))
+
+	err := WithExtraInfo(WrapErrServiceQuotaExceeded("disk quota exceeded"), map[string]string{"scope": "collection", "id": "1"})
+	err = WithExtraInfo(errors.Wrap(err, "check failed"), map[string]string{"id": "2"})
+	assert.ErrorIs(t, err, ErrServiceQuotaExceeded)
+	assert.Equal(t, map[string]string{"scope": "collection", "id": "2"}, GetExtraInfo(err))
+
+	status := Status(WrapErrAsInputError(err))
+	assert.Equal(t, Code(ErrServiceQuotaExceeded), status.GetCode())
+	assert.Equal(t, "collection", status.GetExtraInfo()["scope"])
+	assert.Equal(t, "2", status.GetExtraInfo()["id"])
+	assert.Equal(t, "true", status.GetExtraInfo()[InputErrorFlagKey])
+}
+
 func TestIsMilvusError_WrappedChain(t *testing.T) {
 	// Direct milvus error
 	assert.True(t, IsMilvusError(ErrCollectionNotFound))
@@ -72,6 +72,9 @@ const (
 	// CompactionTaskKey request for get compaction tasks from the datacoord
 	CompactionTaskKey = "compaction_tasks"
 
+	// CompactionCleaningTaskKey request for get the compaction tasks waiting to be cleaned from the datacoord
+	CompactionCleaningTaskKey = "compaction_cleaning_tasks"
+
 	// BuildIndexTaskKey request for get building index tasks from the datacoord
 	BuildIndexTaskKey = "build_index_tasks"
 
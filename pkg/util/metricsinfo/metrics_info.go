@@ -233,6 +233,7 @@ type Replica struct {
 	ResourceGroup    string             `json:"resource_group,omitempty"`
 	RONodes          []int64            `json:"ro_nodes,omitempty"`
 	ChannelToRWNodes map[string][]int64 `json:"channel_to_rw_nodes,omitempty"`
+	Weight           int32              `json:"weight,omitempty"`
 }
 
 // Channel is a subscribed channel of in querynode or datanode.
@@ -409,6 +410,18 @@ type CompactionTask struct {
 	NodeID         int64    `json:"node_id,omitempty,string"`
 }
 
+// CompactionCleaningTask is a finished compaction task waiting to be cleaned.
+type CompactionCleaningTask struct {
+	PlanID         int64  `json:"plan_id,omitempty,string"`
+	CollectionID   int64  `json:"collection_id,omitempty,string"`
+	Type           string `json:"type,omitempty"`
+	State          string `json:"state,omitempty"`
+	CleanStartTime string `json:"clean_start_time,omitempty"`
+	CleanFailures  int    `json:"clean_failures,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	Stuck          bool   `json:"stuck,omitempty"`
+}
+
 // RootCoordConfiguration records the configuration of RootCoord.
 type RootCoordConfiguration struct {
 	MinSegmentSizeToEnableIndex int64 `json:"min_segment_size_to_enable_index"`
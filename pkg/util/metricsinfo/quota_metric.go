@@ -28,6 +28,9 @@ const (
 	ReadResultThroughput    RateMetricLabel = "ReadResultThroughput"
 	InsertConsumeThroughput RateMetricLabel = "InsertConsumeThroughput"
 	DeleteConsumeThroughput RateMetricLabel = "DeleteConsumeThroughput"
+
+	// DQLSlowQuery is the rate of the slow dql requests reported by proxies.
+	DQLSlowQuery RateMetricLabel = "DQLSlowQuery"
 )
 
 const (
@@ -48,6 +51,8 @@ type FlowGraphMetric struct {
 	MinFlowGraphChannel string
 	MinFlowGraphTt      typeutil.Timestamp
 	NumFlowGraph        int
+	// PausedChannels are the vchannels whose flow graphs are paused, excluded from the time tick delay.
+	PausedChannels []string `json:",omitempty"`
 }
 
 // NodeEffect contains the a node and its effected collection info.
@@ -91,6 +96,15 @@ type DataCoordQuotaMetrics struct {
 	PartitionsBinlogSize map[int64]map[int64]int64
 	// l0 segments
 	CollectionL0RowCount map[int64]int64
+	// storage usage breakdown, CollectionBinlogSize includes the delta log size
+	CollectionDeltaLogSize map[int64]int64
+	CollectionIndexSize    map[int64]int64
+	// disk size reserved by the import jobs in progress, the importing segments are not counted in the binlog size
+	TotalImportReservedSize      int64           `json:",omitempty"`
+	CollectionImportReservedSize map[int64]int64 `json:",omitempty"`
+	// the ratio of the small segment number to the channel small segment soft limit of the most crowded channel,
+	// only the collections with the channels exceeding the soft limit are reported.
+	CollectionSmallSegmentPressure map[int64]float64 `json:",omitempty"`
 }
 
 // DataNodeQuotaMetrics are metrics of DataNode.
@@ -99,6 +113,15 @@ type DataNodeQuotaMetrics struct {
 	Rms    []RateMetric
 	Fgm    FlowGraphMetric
 	Effect NodeEffect
+	// WALBacklogs is the wal messages not consumed by the flow graphs, keyed by the collection id.
+	WALBacklogs map[int64]WALBacklog `json:",omitempty"`
+}
+
+// WALBacklog is the backlog of the wal messages of a collection, the messages after the consumed time tick
+// of its most lagging vchannel are not consumed yet.
+type WALBacklog struct {
+	Channel    string
+	ConsumedTt typeutil.Timestamp
 }
 
 // ProxyQuotaMetrics are metrics of Proxy.
@@ -106,6 +129,15 @@ type ProxyQuotaMetrics struct {
 	Hms          HardwareMetrics
 	Rms          []RateMetric
 	QueueMetrics []TaskQueueMetrics
+	// Limiter is the summary of the limits enforced by the proxy, nil for the proxies not reporting it.
+	Limiter *ProxyLimiterSnapshot `json:",omitempty"`
+}
+
+// ProxyLimiterSnapshot is the summary of the limits enforced by the proxy,
+// it's compared with the limits last pushed by the quota center to find the dropped updates.
+type ProxyLimiterSnapshot struct {
+	// Digest is the digest of the limiter tree last set by the quota center, empty if not set since the proxy started.
+	Digest string
 }
 
 type QuotaCenterMetrics struct {
@@ -113,4 +145,70 @@ type QuotaCenterMetrics struct {
 	DataNodeMetrics  map[int64]*DataNodeQuotaMetrics
 	ProxyMetrics     map[int64]*ProxyQuotaMetrics
 	DataCoordMetrics *DataCoordQuotaMetrics
+	// StreamingNodeMetrics is the metrics of the query nodes embedded in the streaming nodes.
+	StreamingNodeMetrics map[int64]*QueryNodeQuotaMetrics `json:",omitempty"`
+	// History is the downsampled snapshots of the quota metrics, ordered from the oldest.
+	History []*QuotaMetricsSnapshot `json:",omitempty"`
+	// Simulated is the limits calculated but not applied in the simulate mode.
+	Simulated *SimulatedQuotaLimits `json:",omitempty"`
+	// ConfigHealth is the result of the last validation of the quota configs.
+	ConfigHealth *QuotaConfigHealth
+	// WriteFactors is the write rate factors calculated by each protection in the last round.
+	WriteFactors *QuotaWriteFactors `json:",omitempty"`
+}
+
+// QuotaWriteFactors is the write rate factors calculated by each protection of the quota center,
+// 1 means not limited and 0 means denied, the write rates of a collection are limited by the minimum one.
+type QuotaWriteFactors struct {
+	Timestamp int64 // unix seconds
+	// Collections is the factors of the collections, keyed by the collection id and the factor name.
+	Collections map[int64]map[string]float64 `json:",omitempty"`
+	// Nodes is the factors calculated by the metrics of the nodes, keyed by {role}-{node id} and the factor name.
+	Nodes map[string]map[string]float64 `json:",omitempty"`
+}
+
+// QuotaConfigHealth is the invalid combinations found by the validation of the quota configs.
+type QuotaConfigHealth struct {
+	Timestamp int64 // unix seconds
+	Issues    []*QuotaConfigIssue
+}
+
+// QuotaConfigIssue is a pair of the quota configs whose low value is greater than the high value,
+// the effective values are the ones normalized and used by the quota center.
+type QuotaConfigIssue struct {
+	LowKey        string
+	LowValue      string
+	HighKey       string
+	HighValue     string
+	EffectiveLow  string
+	EffectiveHigh string
+}
+
+// SimulatedQuotaLimits is the rate limits and quota states calculated by the quota center in the simulate mode.
+type SimulatedQuotaLimits struct {
+	Timestamp int64 // unix seconds
+	Nodes     []*SimulatedLimiterNode
+}
+
+// SimulatedLimiterNode is the limited rates and the quota states of a limiter node,
+// named as cluster, db_{name}, collection_{name} or partition_{id}.
+type SimulatedLimiterNode struct {
+	Name string
+	// Rates is the limits of the rate types, keyed by the rate type name.
+	Rates map[string]float64 `json:",omitempty"`
+	// States is the error codes of the quota states, keyed by the quota state name.
+	States map[string]string `json:",omitempty"`
+}
+
+// QuotaMetricsSnapshot is a downsampled snapshot of the quota metrics,
+// the rates of the same label are summed up over the nodes.
+type QuotaMetricsSnapshot struct {
+	Timestamp           int64 // unix seconds
+	ProxyRates          map[RateMetricLabel]float64
+	QueryNodeRates      map[RateMetricLabel]float64
+	DataNodeRates       map[RateMetricLabel]float64
+	MaxMemoryUsageRatio float64
+	TotalBinlogSize     int64
+	// ClusterLimits is the rate limits of the cluster level, keyed by the rate type name.
+	ClusterLimits map[string]float64
 }
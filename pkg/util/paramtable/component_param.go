@@ -1949,11 +1949,12 @@ type rootCoordConfig struct {
 	MaxPartitionNum             ParamItem `refreshable:"true"`
 	MinSegmentSizeToEnableIndex ParamItem `refreshable:"true"`
 	EnableActiveStandby         ParamItem `refreshable:"false"`
-	MaxDatabaseNum              ParamItem `refreshable:"true"`
 	MaxGeneralCapacity          ParamItem `refreshable:"true"`
 	GracefulStopTimeout         ParamItem `refreshable:"true"`
 	UseLockScheduler            ParamItem `refreshable:"true"`
 	DefaultDBProperties         ParamItem `refreshable:"false"`
+	RecycleBinPurgeInterval     ParamItem `refreshable:"false"`
+	DBTimestampAllocRate        ParamItem `refreshable:"true"`
 }
 
 func (p *rootCoordConfig) init(base *BaseTable) {
@@ -1995,15 +1996,6 @@ Segments with smaller size than this parameter will not be indexed, and will be
 	}
 	p.EnableActiveStandby.Init(base.mgr)
 
-	p.MaxDatabaseNum = ParamItem{
-		Key:          "rootCoord.maxDatabaseNum",
-		Version:      "2.3.0",
-		DefaultValue: "64",
-		Doc:          "Maximum number of database",
-		Export:       true,
-	}
-	p.MaxDatabaseNum.Init(base.mgr)
-
 	p.MaxGeneralCapacity = ParamItem{
 		Key:          "rootCoord.maxGeneralCapacity",
 		Version:      "2.3.5",
@@ -2045,6 +2037,24 @@ Segments with smaller size than this parameter will not be indexed, and will be
 		Export:       false,
 	}
 	p.DefaultDBProperties.Init(base.mgr)
+
+	p.RecycleBinPurgeInterval = ParamItem{
+		Key:          "rootCoord.recycleBin.purgeInterval",
+		Version:      "3.0.0",
+		DefaultValue: "60",
+		Doc:          "the interval in seconds to permanently drop the soft-deleted collections whose undrop window has expired",
+		Export:       true,
+	}
+	p.RecycleBinPurgeInterval.Init(base.mgr)
+
+	p.DBTimestampAllocRate = ParamItem{
+		Key:          "rootCoord.dbTimestampAllocRate",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Doc:          "the max number of timestamps allocated per second for the requests of each database, the allocations beyond it are rejected with a rate limit error, 0 means unlimited",
+		Export:       true,
+	}
+	p.DBTimestampAllocRate.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -2098,6 +2108,7 @@ type proxyConfig struct {
 	CostMetricsExpireTime             ParamItem `refreshable:"false"`
 	CheckWorkloadRequestNum           ParamItem `refreshable:"false"`
 	WorkloadToleranceFactor           ParamItem `refreshable:"false"`
+	ShardLeaderHealthThreshold        ParamItem `refreshable:"true"`
 	RetryTimesOnReplica               ParamItem `refreshable:"true"`
 	RetryTimesOnHealthCheck           ParamItem `refreshable:"true"`
 	ReplicaBlacklistDuration          ParamItem `refreshable:"true"`
@@ -2128,6 +2139,9 @@ type proxyConfig struct {
 	QueryNodePoolingSize   ParamItem `refreshable:"false"`
 
 	HybridSearchRequeryPolicy ParamItem `refreshable:"true"`
+
+	// the collections released for idle are loaded again on the first search or query
+	IdleCollectionLoadTimeout ParamItem `refreshable:"true"`
 }
 
 func (p *proxyConfig) init(base *BaseTable) {
@@ -2515,6 +2529,15 @@ please adjust in embedded Milvus: false`,
 	}
 	p.WorkloadToleranceFactor.Init(base.mgr)
 
+	p.ShardLeaderHealthThreshold = ParamItem{
+		Key:          "proxy.shardLeaderHealthThreshold",
+		Version:      "3.0.0",
+		DefaultValue: "0.5",
+		Doc: `the shard leaders whose health scores reported by query coord are lower than this threshold are only selected
+		if no healthier leader is available, set to 0 to select the leaders regardless of their health`,
+	}
+	p.ShardLeaderHealthThreshold.Init(base.mgr)
+
 	p.RetryTimesOnReplica = ParamItem{
 		Key:          "proxy.retryTimesOnReplica",
 		Version:      "2.3.0",
@@ -2714,6 +2737,16 @@ Disabled if the value is less or equal to 0.`,
 		Export:       true,
 	}
 	p.QueryNodePoolingSize.Init(base.mgr)
+
+	p.IdleCollectionLoadTimeout = ParamItem{
+		Key:          "proxy.idleCollectionLoadTimeout",
+		Version:      "3.0.0",
+		DefaultValue: "300",
+		Doc: `timeout in seconds for the first search or query of a collection released for idle (collection.idle.release.seconds)
+to wait for the collection loaded again, the request fails with collection not loaded after the timeout`,
+		Export: true,
+	}
+	p.IdleCollectionLoadTimeout.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -2780,9 +2813,20 @@ type queryCoordConfig struct {
 	NextTargetSurviveTime          ParamItem `refreshable:"true"`
 	UpdateNextTargetInterval       ParamItem `refreshable:"false"`
 	CheckNodeInReplicaInterval     ParamItem `refreshable:"false"`
+	ReplicaAdvisoryModeEnabled     ParamItem `refreshable:"true"`
+	ReplicaSuggestionApplyDelay    ParamItem `refreshable:"true"`
 	CheckResourceGroupInterval     ParamItem `refreshable:"false"`
 	LeaderViewUpdateInterval       ParamItem `refreshable:"false"`
 	EnableRGAutoRecover            ParamItem `refreshable:"true"`
+	RGIncomingNodeAssignPolicy     ParamItem `refreshable:"true"`
+	EnablePartitionAutoRepair      ParamItem `refreshable:"true"`
+	CheckPartitionInterval         ParamItem `refreshable:"false"`
+	SegmentReloadConcurrency       ParamItem `refreshable:"true"`
+	ConsistencyProbeEnabled        ParamItem `refreshable:"true"`
+	ConsistencyProbeInterval       ParamItem `refreshable:"false"`
+	ConsistencyProbeAutoReload     ParamItem `refreshable:"true"`
+	JobStepMaxAttempts             ParamItem `refreshable:"true"`
+	JobStepRetryInterval           ParamItem `refreshable:"true"`
 	CheckHealthInterval            ParamItem `refreshable:"false"`
 	CheckHealthRPCTimeout          ParamItem `refreshable:"true"`
 	BrokerTimeout                  ParamItem `refreshable:"false"`
@@ -2795,6 +2839,9 @@ type queryCoordConfig struct {
 	StoppingBalanceAssignPolicy    ParamItem `refreshable:"true"`
 	ChannelExclusiveNodeFactor     ParamItem `refreshable:"true"`
 
+	ReplicaWarmupMaxSegmentsPerReplica ParamItem `refreshable:"true"`
+	ReplicaWarmupMaxSegmentsPerNode    ParamItem `refreshable:"true"`
+
 	CollectionObserverInterval                   ParamItem `refreshable:"false"`
 	CollectionBalanceSegmentBatchSize            ParamItem `refreshable:"true"`
 	CollectionBalanceChannelBatchSize            ParamItem `refreshable:"true"`
@@ -2822,6 +2869,14 @@ type queryCoordConfig struct {
 	UpdateTargetNeedSegmentDataReady ParamItem `refreshable:"true"`
 
 	AutoWarmupForNonPKIsolationCollection ParamItem `refreshable:"false"`
+
+	// load capacity pre-flight check
+	LoadCapacityCheckEnabled     ParamItem `refreshable:"true"`
+	LoadCapacityMemoryUsageRatio ParamItem `refreshable:"true"`
+
+	LoadingQueueDepthLimit ParamItem `refreshable:"true"`
+
+	LoadAuditMaxRecords ParamItem `refreshable:"true"`
 }
 
 func (p *queryCoordConfig) init(base *BaseTable) {
@@ -3211,6 +3266,27 @@ If this parameter is set false, Milvus simply searches the growing segments with
 	}
 	p.CheckNodeInReplicaInterval.Init(base.mgr)
 
+	p.ReplicaAdvisoryModeEnabled = ParamItem{
+		Key:          "queryCoord.replicaObserver.advisoryMode.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Whether to hold the node-in-replica changes of the replica observer as suggestions,
+e.g. removing the drained read only nodes and recovering the streaming query nodes.
+The suggestions are listed by ListReplicaSuggestions and applied after approved by ApproveReplicaSuggestions,
+or pending longer than queryCoord.replicaObserver.advisoryMode.applyDelay.`,
+		Export: true,
+	}
+	p.ReplicaAdvisoryModeEnabled.Init(base.mgr)
+
+	p.ReplicaSuggestionApplyDelay = ParamItem{
+		Key:          "queryCoord.replicaObserver.advisoryMode.applyDelay",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Doc:          "The seconds after which a pending replica suggestion is applied without approval, 0 means the suggestion is applied only after approved.",
+		Export:       true,
+	}
+	p.ReplicaSuggestionApplyDelay.Init(base.mgr)
+
 	p.CheckResourceGroupInterval = ParamItem{
 		Key:          "queryCoord.checkResourceGroupInterval",
 		Version:      "2.2.3",
@@ -3236,6 +3312,110 @@ If this parameter is set false, Milvus simply searches the growing segments with
 	}
 	p.EnableRGAutoRecover.Init(base.mgr)
 
+	p.RGIncomingNodeAssignPolicy = ParamItem{
+		Key:          "queryCoord.rgIncomingNodeAssignPolicy",
+		Version:      "3.0.0",
+		DefaultValue: "Limits",
+		Doc: `The policy to assign the incoming query nodes after the requests of all resource groups are met, options: Limits, Proportional.
+Limits assigns the node to the resource group with the most vacancy under its limits,
+Proportional assigns the node to the resource group with the lowest ratio of node number to requests under its limits.`,
+		Export: true,
+	}
+	p.RGIncomingNodeAssignPolicy.Init(base.mgr)
+
+	p.EnablePartitionAutoRepair = ParamItem{
+		Key:          "queryCoord.enablePartitionAutoRepair",
+		Version:      "3.0.0",
+		DefaultValue: "true",
+		Doc: `Whether to repair the loaded partitions by the partitions of the collection periodically,
+the partitions dropped are released and the partitions created are loaded if the whole collection is loaded.`,
+		Export: true,
+	}
+	p.EnablePartitionAutoRepair.Init(base.mgr)
+
+	p.CheckPartitionInterval = ParamItem{
+		Key:          "queryCoord.checkPartitionInterval",
+		Version:      "3.0.0",
+		DefaultValue: "60",
+		Doc:          "the interval in seconds to check the loaded partitions against the partitions of the collection",
+		Export:       true,
+	}
+	p.CheckPartitionInterval.Init(base.mgr)
+
+	p.SegmentReloadConcurrency = ParamItem{
+		Key:          "queryCoord.segmentReloadConcurrency",
+		Version:      "3.0.0",
+		DefaultValue: "4",
+		Doc:          "the max number of segments of a replica reopened at the same time by the segment reload api",
+		Export:       true,
+	}
+	p.SegmentReloadConcurrency.Init(base.mgr)
+
+	p.ConsistencyProbeEnabled = ParamItem{
+		Key:          "queryCoord.consistencyProbe.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc:          "whether to compare the valid row count of each segment across the replicas periodically to find the diverged ones",
+		Export:       true,
+	}
+	p.ConsistencyProbeEnabled.Init(base.mgr)
+
+	p.ConsistencyProbeInterval = ParamItem{
+		Key:          "queryCoord.consistencyProbe.interval",
+		Version:      "3.0.0",
+		DefaultValue: "600",
+		Doc:          "the interval in seconds of the replica consistency probe",
+		Export:       true,
+	}
+	p.ConsistencyProbeInterval.Init(base.mgr)
+
+	p.ConsistencyProbeAutoReload = ParamItem{
+		Key:          "queryCoord.consistencyProbe.autoReload",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc:          "whether to reload the diverged segment copies found by the replica consistency probe on their nodes",
+		Export:       true,
+	}
+	p.ConsistencyProbeAutoReload.Init(base.mgr)
+
+	p.JobStepMaxAttempts = ParamItem{
+		Key:          "queryCoord.jobStep.maxAttempts",
+		Version:      "3.0.0",
+		DefaultValue: "5",
+		Doc:          "the max attempts of a step of the load and release jobs, a step failed with transient errors is retried before failing the job",
+		Export:       true,
+	}
+	p.JobStepMaxAttempts.Init(base.mgr)
+
+	p.JobStepRetryInterval = ParamItem{
+		Key:          "queryCoord.jobStep.retryInterval",
+		Version:      "3.0.0",
+		DefaultValue: "200",
+		Doc:          "the initial interval in milliseconds between the attempts of a job step, it's doubled after each attempt",
+		Export:       true,
+	}
+	p.JobStepRetryInterval.Init(base.mgr)
+
+	p.ReplicaWarmupMaxSegmentsPerReplica = ParamItem{
+		Key:          "queryCoord.replicaWarmup.maxLoadingSegmentsPerReplica",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Doc: `the max number of the concurrent segment loads of a warming up replica, which is not serviceable yet
+while another replica of the collection is serving, e.g. the replica spawned for a loaded collection.
+It's distinct from the balance limits to keep the loading from saturating the object storage, 0 means unlimited.`,
+		Export: true,
+	}
+	p.ReplicaWarmupMaxSegmentsPerReplica.Init(base.mgr)
+
+	p.ReplicaWarmupMaxSegmentsPerNode = ParamItem{
+		Key:          "queryCoord.replicaWarmup.maxLoadingSegmentsPerNode",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Doc:          "the max number of the concurrent segment loads of a warming up replica on each of its nodes, 0 means unlimited",
+		Export:       true,
+	}
+	p.ReplicaWarmupMaxSegmentsPerNode.Init(base.mgr)
+
 	p.CheckHealthInterval = ParamItem{
 		Key:          "queryCoord.checkHealthInterval",
 		Version:      "2.2.7",
@@ -3546,6 +3726,50 @@ Set to 0 to disable the penalty period.`,
 		Export:       false,
 	}
 	p.AutoWarmupForNonPKIsolationCollection.Init(base.mgr)
+
+	p.LoadCapacityCheckEnabled = ParamItem{
+		Key:          "queryCoord.loadCapacityCheckEnabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc:          "whether to estimate the memory needed before loading a collection or partitions, and reject the load if the resource groups don't have enough memory",
+		Export:       true,
+	}
+	p.LoadCapacityCheckEnabled.Init(base.mgr)
+
+	p.LoadCapacityMemoryUsageRatio = ParamItem{
+		Key:          "queryCoord.loadCapacityMemoryUsageRatio",
+		Version:      "3.0.0",
+		DefaultValue: "0.9",
+		Doc:          "the max ratio of the memory capacity of the query nodes which could be used by the loaded data in the load capacity check",
+		Export:       true,
+	}
+	p.LoadCapacityMemoryUsageRatio.Init(base.mgr)
+
+	p.LoadingQueueDepthLimit = ParamItem{
+		Key:          "queryCoord.loadingQueueDepthLimit",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Doc: `The max number of segments waiting or being loaded on a query node, reported by the heartbeat.
+The nodes reaching the limit don't receive new segment loading tasks until their queues drain, 0 means no limit.`,
+		Export: true,
+	}
+	p.LoadingQueueDepthLimit.Init(base.mgr)
+
+	p.LoadAuditMaxRecords = ParamItem{
+		Key:          "queryCoord.loadAudit.maxRecords",
+		Version:      "3.0.0",
+		DefaultValue: "100",
+		Formatter: func(v string) string {
+			if getAsInt(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+		Doc: `The max number of the load and release audit records kept for each collection, the oldest ones are removed first.
+The records persist who requested each load or release and its outcome, 0 means the requests are not audited.`,
+		Export: true,
+	}
+	p.LoadAuditMaxRecords.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -5194,6 +5418,7 @@ type dataCoordConfig struct {
 	// --- SEGMENTS ---
 	SegmentMaxSize                 ParamItem `refreshable:"false"`
 	DiskSegmentMaxSize             ParamItem `refreshable:"true"`
+	SegmentIndexMemoryFactors      ParamItem `refreshable:"true"`
 	SegmentSealProportion          ParamItem `refreshable:"false"`
 	SegmentSealProportionJitter    ParamItem `refreshable:"true"`
 	SegAssignmentExpiration        ParamItem `refreshable:"false"`
@@ -5211,37 +5436,71 @@ type dataCoordConfig struct {
 	SegmentFlushInterval           ParamItem `refreshable:"true"`
 	BlockingL0EntryNum             ParamItem `refreshable:"true"`
 	BlockingL0SizeInMB             ParamItem `refreshable:"true"`
+	GrowingSegmentMaxAge           ParamItem `refreshable:"true"`
 	DVForceAllIndexReady           ParamItem `refreshable:"true"`
 
+	// the seal barrier of the tiny growing segments after a compaction of the same partition completes
+	SegmentSealCompactionBarrierWindow    ParamItem `refreshable:"true"`
+	SegmentSealCompactionBarrierSizeRatio ParamItem `refreshable:"true"`
+	SegmentSealCompactionBarrierMaxWait   ParamItem `refreshable:"true"`
+
 	// compaction
 	EnableCompaction                       ParamItem `refreshable:"false"`
 	EnableAutoCompaction                   ParamItem `refreshable:"true"`
 	IndexBasedCompaction                   ParamItem `refreshable:"true"`
 	CompactionTaskPrioritizer              ParamItem `refreshable:"true"`
+	CompactionDatabaseWeights              ParamItem `refreshable:"true"`
 	CompactionTaskQueueCapacity            ParamItem `refreshable:"false"`
+	CompactionPlanHookPluginPath           ParamItem `refreshable:"false"`
+	CompactionPlanHookURL                  ParamItem `refreshable:"true"`
+	CompactionPlanHookTimeout              ParamItem `refreshable:"true"`
+	CompactionPlanHookFailOpen             ParamItem `refreshable:"true"`
 	CompactionPreAllocateIDExpansionFactor ParamItem `refreshable:"false"`
+	CompactionSizeEstimationEnabled        ParamItem `refreshable:"true"`
+	CompactionPriorityBoostMaxDuration     ParamItem `refreshable:"true"`
+	SegmentAccessStatsEnabled              ParamItem `refreshable:"true"`
+	SegmentAccessStatsInterval             ParamItem `refreshable:"false"`
+	SegmentAccessColdReadRate              ParamItem `refreshable:"true"`
 
 	CompactionRPCTimeout                       ParamItem `refreshable:"true"`
 	CompactionMaxParallelTasks                 ParamItem `refreshable:"true"`
+	CompactionTaskIOBudget                     ParamItem `refreshable:"true"`
+	CompactionCollectionIOBudget               ParamItem `refreshable:"true"`
+	CompactionNodeIOBudget                     ParamItem `refreshable:"true"`
+	CompactionL0MaxParallelPerChannel          ParamItem `refreshable:"true"`
+	CompactionMixMaxParallelPerChannel         ParamItem `refreshable:"true"`
+	CompactionClusteringMaxParallelPerChannel  ParamItem `refreshable:"true"`
+	CompactionL0MaxParallelPerNode             ParamItem `refreshable:"true"`
+	CompactionMixMaxParallelPerNode            ParamItem `refreshable:"true"`
+	CompactionClusteringMaxParallelPerNode     ParamItem `refreshable:"true"`
 	CompactionWorkerParallelTasks              ParamItem `refreshable:"true"`
 	CompactionMaxFullSegmentThreshold          ParamItem `refreshable:"true"`
 	CompactionForceMergeDataNodeMemoryFactor   ParamItem `refreshable:"true"`
 	CompactionForceMergeQueryNodeMemoryFactor  ParamItem `refreshable:"true"`
 	MinSegmentToMerge                          ParamItem `refreshable:"true"`
 	SegmentSmallProportion                     ParamItem `refreshable:"true"`
+	ChannelSmallSegmentSoftLimit               ParamItem `refreshable:"true"`
 	SegmentCompactableProportion               ParamItem `refreshable:"true"`
 	SegmentExpansionRate                       ParamItem `refreshable:"true"`
 	CompactionTimeoutInSeconds                 ParamItem `refreshable:"true"` // deprecated
 	CompactionDropToleranceInSeconds           ParamItem `refreshable:"true"`
 	CompactionGCIntervalInSeconds              ParamItem `refreshable:"true"`
+	CompactionCleanStuckThreshold              ParamItem `refreshable:"true"`
 	CompactionCheckIntervalInSeconds           ParamItem `refreshable:"false"` // deprecated
 	CompactionScheduleInterval                 ParamItem `refreshable:"false"`
+	CompactionVerifyResult                     ParamItem `refreshable:"true"`
+	CompactionVerifyStatslogs                  ParamItem `refreshable:"true"`
+	CompactionWorkerRetryTimes                 ParamItem `refreshable:"true"`
 	MixCompactionTriggerInterval               ParamItem `refreshable:"false"`
+	MixCompactionFlushCooldown                 ParamItem `refreshable:"true"`
 	L0CompactionTriggerInterval                ParamItem `refreshable:"false"`
 	GlobalCompactionInterval                   ParamItem `refreshable:"false"`
 	CompactionExpiryTolerance                  ParamItem `refreshable:"true"`
 	BumpSchemaVersionCompactionEnabled         ParamItem `refreshable:"true"`
 	BumpSchemaVersionCompactionTriggerInterval ParamItem `refreshable:"true"`
+	LogMergeCompactionEnabled                  ParamItem `refreshable:"true"`
+	LogMergeCompactionTriggerInterval          ParamItem `refreshable:"false"`
+	LogMergeCompactionFileNumThreshold         ParamItem `refreshable:"true"`
 	L0ManifestUpdatePoolSize                   ParamItem `refreshable:"true"`
 
 	SingleCompactionRatioThreshold    ParamItem `refreshable:"true"`
@@ -5279,6 +5538,12 @@ type dataCoordConfig struct {
 	ClusteringCompactionMinClusterSizeRatio    ParamItem `refreshable:"true"`
 	ClusteringCompactionMaxClusterSizeRatio    ParamItem `refreshable:"true"`
 	ClusteringCompactionMaxClusterSize         ParamItem `refreshable:"true"`
+	ClusteringCompactionCommitBatchSize        ParamItem `refreshable:"true"`
+
+	// Clustering Compaction triggered by the clustering key skew
+	ClusteringCompactionSkewTriggerEnable      ParamItem `refreshable:"true"`
+	ClusteringCompactionSkewRatioThreshold     ParamItem `refreshable:"true"`
+	ClusteringCompactionSkewTriggerMinInterval ParamItem `refreshable:"true"`
 
 	// LevelZero Segment
 	LevelZeroCompactionTriggerMinSize        ParamItem `refreshable:"true"`
@@ -5307,6 +5572,14 @@ type dataCoordConfig struct {
 	GCLOBSafetyWindow  ParamItem `refreshable:"true"`
 	GCLOBCheckInterval ParamItem `refreshable:"true"`
 
+	// Orphan Segment Check
+	GCOrphanSegmentCheckEnabled  ParamItem `refreshable:"true"`
+	GCOrphanSegmentCheckInterval ParamItem `refreshable:"false"`
+	GCOrphanSegmentRepairEnabled ParamItem `refreshable:"true"`
+	// the consecutive checks finding a segment missing its files before it's dropped
+	GCOrphanSegmentRepairConfirmTimes ParamItem `refreshable:"true"`
+	GCOrphanSegmentMaxRepairPerCheck  ParamItem `refreshable:"true"`
+
 	BindIndexNodeMode           ParamItem `refreshable:"false"`
 	IndexNodeAddress            ParamItem `refreshable:"false"`
 	WithCredential              ParamItem `refreshable:"false"`
@@ -5356,6 +5629,7 @@ type dataCoordConfig struct {
 	MixCompactionSlotUsage               ParamItem `refreshable:"true"`
 	L0DeleteCompactionSlotUsage          ParamItem `refreshable:"true"`
 	BumpSchemaVersionCompactionSlotUsage ParamItem `refreshable:"true"`
+	LogMergeCompactionSlotUsage          ParamItem `refreshable:"true"`
 	IndexTaskSlotUsage                   ParamItem `refreshable:"true"`
 	ScalarIndexTaskSlotUsage             ParamItem `refreshable:"true"`
 	StatsTaskSlotUsage                   ParamItem `refreshable:"true"`
@@ -5446,6 +5720,17 @@ func (p *dataCoordConfig) init(base *BaseTable) {
 	}
 	p.DiskSegmentMaxSize.Init(base.mgr)
 
+	p.SegmentIndexMemoryFactors = ParamItem{
+		Key:          "dataCoord.segment.indexMemoryFactors",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `The memory factors of the vector index types relative to the raw data, in the format of "HNSW:1.5,IVF_FLAT:1".
+The expected size of the compacted segments is divided by the max factor of the indexes of the collection,
+so that the loaded segments fit the memory model of their index types. Index types not listed use factor 1.`,
+		Export: true,
+	}
+	p.SegmentIndexMemoryFactors.Init(base.mgr)
+
 	p.SegmentSealProportion = ParamItem{
 		Key:          "dataCoord.segment.sealProportion",
 		Version:      "2.0.0",
@@ -5551,6 +5836,16 @@ exceeds this threshold, the earliest growing segments will be sealed.`,
 	}
 	p.BlockingL0SizeInMB.Init(base.mgr)
 
+	p.GrowingSegmentMaxAge = ParamItem{
+		Key:          "dataCoord.sealPolicy.channel.growingSegmentMaxAge",
+		Version:      "3.0.0",
+		DefaultValue: "-1",
+		Doc: `The max age in seconds of growing segments, if the checkpoint of the shard lags behind more than it,
+the growing segments older than it will be sealed regardless of the size. A negative value disables it.`,
+		Export: true,
+	}
+	p.GrowingSegmentMaxAge.Init(base.mgr)
+
 	p.DVForceAllIndexReady = ParamItem{
 		Key:          "dataCoord.dataview.forceAllIndexReady",
 		Version:      "2.6.2",
@@ -5560,6 +5855,35 @@ exceeds this threshold, the earliest growing segments will be sealed.`,
 	}
 	p.DVForceAllIndexReady.Init(base.mgr)
 
+	p.SegmentSealCompactionBarrierWindow = ParamItem{
+		Key:          "dataCoord.segment.sealCompactionBarrier.window",
+		Version:      "3.0.0",
+		DefaultValue: "60",
+		Doc: `The window in seconds after a compaction of a partition completes, within which the seal of the tiny growing segments
+of the partition is deferred, so the trailing data is merged by the following allocations instead of becoming a small segment to compact.
+The segments sealed by capacity are never deferred, 0 disables the barrier.`,
+		Export: true,
+	}
+	p.SegmentSealCompactionBarrierWindow.Init(base.mgr)
+
+	p.SegmentSealCompactionBarrierSizeRatio = ParamItem{
+		Key:          "dataCoord.segment.sealCompactionBarrier.sizeRatio",
+		Version:      "3.0.0",
+		DefaultValue: "0.1",
+		Doc:          "The growing segments with rows less than the ratio of the max rows are tiny, their seal is deferred by the seal compaction barrier",
+		Export:       true,
+	}
+	p.SegmentSealCompactionBarrierSizeRatio.Init(base.mgr)
+
+	p.SegmentSealCompactionBarrierMaxWait = ParamItem{
+		Key:          "dataCoord.segment.sealCompactionBarrier.maxWait",
+		Version:      "3.0.0",
+		DefaultValue: "300",
+		Doc:          "The max duration in seconds the seal of a segment is deferred by the seal compaction barrier",
+		Export:       true,
+	}
+	p.SegmentSealCompactionBarrierMaxWait.Init(base.mgr)
+
 	p.EnableCompaction = ParamItem{
 		Key:          "dataCoord.enableCompaction",
 		Version:      "2.0.0",
@@ -5592,14 +5916,25 @@ This configuration takes effect only when dataCoord.enableCompaction is set as t
 		Key:          "dataCoord.compaction.taskPrioritizer",
 		Version:      "2.5.0",
 		DefaultValue: "level",
-		Doc: `compaction task prioritizer, options: [default, level, mix].
+		Doc: `compaction task prioritizer, options: [default, level, mix, database].
 default is FIFO.
 level is prioritized by level: L0 compactions first, then mix compactions, then clustering compactions.
-mix is prioritized by level: mix compactions first, then L0 compactions, then clustering compactions.`,
+mix is prioritized by level: mix compactions first, then L0 compactions, then clustering compactions.
+database is fair across the databases by weighted round robin, the tasks of each database are prioritized by level.`,
 		Export: true,
 	}
 	p.CompactionTaskPrioritizer.Init(base.mgr)
 
+	p.CompactionDatabaseWeights = ParamItem{
+		Key:          "dataCoord.compaction.databaseWeights",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `the weights of the databases in the weighted round robin of the database task prioritizer,
+in the format of db1:weight1,db2:weight2, the databases not listed are weighted 1`,
+		Export: true,
+	}
+	p.CompactionDatabaseWeights.Init(base.mgr)
+
 	p.CompactionTaskQueueCapacity = ParamItem{
 		Key:          "dataCoord.compaction.taskQueueCapacity",
 		Version:      "2.5.0",
@@ -5609,6 +5944,45 @@ mix is prioritized by level: mix compactions first, then L0 compactions, then cl
 	}
 	p.CompactionTaskQueueCapacity.Init(base.mgr)
 
+	p.CompactionPlanHookPluginPath = ParamItem{
+		Key:          "dataCoord.compaction.planHook.pluginPath",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `The path of the go plugin to audit or veto the compaction plans before they are enqueued,
+the plugin should export the symbol CompactionPlanHook with the type func(context.Context, []byte) error,
+the argument is the plan in json, and the plan is vetoed if an error is returned. It takes precedence over the url.`,
+		Export: true,
+	}
+	p.CompactionPlanHookPluginPath.Init(base.mgr)
+
+	p.CompactionPlanHookURL = ParamItem{
+		Key:          "dataCoord.compaction.planHook.url",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `The http endpoint to audit or veto the compaction plans before they are enqueued,
+the plan is posted in json, the plan is approved by 2xx responses and vetoed by 4xx responses.`,
+		Export: true,
+	}
+	p.CompactionPlanHookURL.Init(base.mgr)
+
+	p.CompactionPlanHookTimeout = ParamItem{
+		Key:          "dataCoord.compaction.planHook.timeout",
+		Version:      "3.0.0",
+		DefaultValue: "3000",
+		Doc:          "The timeout in milliseconds of the compaction plan hook",
+		Export:       true,
+	}
+	p.CompactionPlanHookTimeout.Init(base.mgr)
+
+	p.CompactionPlanHookFailOpen = ParamItem{
+		Key:          "dataCoord.compaction.planHook.failOpen",
+		Version:      "3.0.0",
+		DefaultValue: "true",
+		Doc:          "Whether to enqueue the compaction plan if the hook fails or times out, false means the plan is dropped",
+		Export:       true,
+	}
+	p.CompactionPlanHookFailOpen.Init(base.mgr)
+
 	p.CompactionPreAllocateIDExpansionFactor = ParamItem{
 		Key:          "dataCoord.compaction.preAllocateIDExpansionFactor",
 		Version:      "2.5.8",
@@ -5617,6 +5991,53 @@ mix is prioritized by level: mix compactions first, then L0 compactions, then cl
 	}
 	p.CompactionPreAllocateIDExpansionFactor.Init(base.mgr)
 
+	p.CompactionSizeEstimationEnabled = ParamItem{
+		Key:          "dataCoord.compaction.sizeEstimation.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "true",
+		Doc: `Estimate the mix compaction result size by the per field averages of the binlog memory sizes and the deleted rows,
+and spread it evenly over the result segments instead of filling them up to the max segment size one by one.`,
+		Export: true,
+	}
+	p.CompactionSizeEstimationEnabled.Init(base.mgr)
+
+	p.CompactionPriorityBoostMaxDuration = ParamItem{
+		Key:          "dataCoord.compaction.priorityBoost.maxDuration",
+		Version:      "3.0.0",
+		DefaultValue: "86400",
+		Doc:          "The max duration in seconds of a compaction priority boost of a collection, the compaction tasks of the boosted collection are scheduled before the others until the boost expires.",
+		Export:       true,
+	}
+	p.CompactionPriorityBoostMaxDuration.Init(base.mgr)
+
+	p.SegmentAccessStatsEnabled = ParamItem{
+		Key:          "dataCoord.segmentAccessStats.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Collect the read counts of the sealed segments reported by the query nodes, the read frequency is used to
+prefer compacting the cold segments and is exposed for the tiering decisions.`,
+		Export: true,
+	}
+	p.SegmentAccessStatsEnabled.Init(base.mgr)
+
+	p.SegmentAccessStatsInterval = ParamItem{
+		Key:          "dataCoord.segmentAccessStats.interval",
+		Version:      "3.0.0",
+		DefaultValue: "60",
+		Doc:          "The interval in seconds to collect the segment read counts from the query coord.",
+		Export:       true,
+	}
+	p.SegmentAccessStatsInterval.Init(base.mgr)
+
+	p.SegmentAccessColdReadRate = ParamItem{
+		Key:          "dataCoord.segmentAccessStats.coldReadRate",
+		Version:      "3.0.0",
+		DefaultValue: "1",
+		Doc:          "A segment is cold if its read rate in reads per minute is not greater than this value.",
+		Export:       true,
+	}
+	p.SegmentAccessColdReadRate.Init(base.mgr)
+
 	p.CompactionRPCTimeout = ParamItem{
 		Key:          "dataCoord.compaction.rpcTimeout",
 		Version:      "2.2.12",
@@ -5634,6 +6055,107 @@ mix is prioritized by level: mix compactions first, then L0 compactions, then cl
 	}
 	p.CompactionMaxParallelTasks.Init(base.mgr)
 
+	nonNegativeFormatter := func(v string) string {
+		if getAsFloat(v) < 0 {
+			return "0"
+		}
+		return v
+	}
+	p.CompactionTaskIOBudget = ParamItem{
+		Key:          "dataCoord.compaction.ioBudget.task",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    nonNegativeFormatter,
+		Doc: `The object storage bandwidth budget in MB/s of each compaction task, it's passed in the compaction plan
+and enforced by the worker, 0 means unlimited.`,
+		Export: true,
+	}
+	p.CompactionTaskIOBudget.Init(base.mgr)
+
+	p.CompactionCollectionIOBudget = ParamItem{
+		Key:          "dataCoord.compaction.ioBudget.collection",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    nonNegativeFormatter,
+		Doc: `The aggregate object storage bandwidth budget in MB/s of the running compaction tasks of each collection,
+the dispatch of the compaction task is deferred once the budget is exhausted, 0 means unlimited.
+It takes effect only if dataCoord.compaction.ioBudget.task is set.`,
+		Export: true,
+	}
+	p.CompactionCollectionIOBudget.Init(base.mgr)
+
+	p.CompactionNodeIOBudget = ParamItem{
+		Key:          "dataCoord.compaction.ioBudget.node",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    nonNegativeFormatter,
+		Doc: `The aggregate object storage bandwidth budget in MB/s of the running compaction tasks on each worker,
+the compaction task is not dispatched to the worker whose budget is exhausted, and the worker limits the bandwidth
+of all its compaction tasks by it, 0 means unlimited.`,
+		Export: true,
+	}
+	p.CompactionNodeIOBudget.Init(base.mgr)
+
+	p.CompactionL0MaxParallelPerChannel = ParamItem{
+		Key:          "dataCoord.compaction.concurrency.l0.maxPerChannel",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    nonNegativeFormatter,
+		Doc:          "The max number of the executing L0 compaction tasks of a channel, 0 means unlimited.",
+		Export:       true,
+	}
+	p.CompactionL0MaxParallelPerChannel.Init(base.mgr)
+
+	p.CompactionMixMaxParallelPerChannel = ParamItem{
+		Key:          "dataCoord.compaction.concurrency.mix.maxPerChannel",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    nonNegativeFormatter,
+		Doc:          "The max number of the executing mix compaction tasks of a channel, 0 means unlimited.",
+		Export:       true,
+	}
+	p.CompactionMixMaxParallelPerChannel.Init(base.mgr)
+
+	p.CompactionClusteringMaxParallelPerChannel = ParamItem{
+		Key:          "dataCoord.compaction.concurrency.clustering.maxPerChannel",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    nonNegativeFormatter,
+		Doc:          "The max number of the executing clustering compaction tasks of a channel, 0 means unlimited.",
+		Export:       true,
+	}
+	p.CompactionClusteringMaxParallelPerChannel.Init(base.mgr)
+
+	p.CompactionL0MaxParallelPerNode = ParamItem{
+		Key:          "dataCoord.compaction.concurrency.l0.maxPerNode",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    nonNegativeFormatter,
+		Doc:          "The max number of the running L0 compaction tasks on a worker, the task waits in the queue if all the workers reach the limit, 0 means unlimited.",
+		Export:       true,
+	}
+	p.CompactionL0MaxParallelPerNode.Init(base.mgr)
+
+	p.CompactionMixMaxParallelPerNode = ParamItem{
+		Key:          "dataCoord.compaction.concurrency.mix.maxPerNode",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    nonNegativeFormatter,
+		Doc:          "The max number of the running mix compaction tasks on a worker, the task waits in the queue if all the workers reach the limit, 0 means unlimited.",
+		Export:       true,
+	}
+	p.CompactionMixMaxParallelPerNode.Init(base.mgr)
+
+	p.CompactionClusteringMaxParallelPerNode = ParamItem{
+		Key:          "dataCoord.compaction.concurrency.clustering.maxPerNode",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    nonNegativeFormatter,
+		Doc:          "The max number of the running clustering compaction tasks on a worker, the task waits in the queue if all the workers reach the limit, 0 means unlimited.",
+		Export:       true,
+	}
+	p.CompactionClusteringMaxParallelPerNode.Init(base.mgr)
+
 	p.L0ManifestUpdatePoolSize = ParamItem{
 		Key:          "dataCoord.compaction.levelzero.manifestUpdatePoolSize",
 		Version:      "3.0.0",
@@ -5665,6 +6187,23 @@ mix is prioritized by level: mix compactions first, then L0 compactions, then cl
 	}
 	p.SegmentSmallProportion.Init(base.mgr)
 
+	p.ChannelSmallSegmentSoftLimit = ParamItem{
+		Key:          "dataCoord.segment.channelSmallSegmentSoftLimit",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter: func(v string) string {
+			if getAsInt(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+		Doc: `the soft limit of the number of the flushed small segments waiting for compaction on each channel, 0 means disabled.
+When a channel exceeds it, the compaction priority of its collection is boosted,
+and the insert and upsert rates of the collection are reduced by the quota center in proportion to the excess.`,
+		Export: true,
+	}
+	p.ChannelSmallSegmentSoftLimit.Init(base.mgr)
+
 	p.SegmentCompactableProportion = ParamItem{
 		Key:          "dataCoord.segment.compactableProportion",
 		Version:      "2.2.1",
@@ -5711,6 +6250,15 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.CompactionGCIntervalInSeconds.Init(base.mgr)
 
+	p.CompactionCleanStuckThreshold = ParamItem{
+		Key:          "dataCoord.compaction.cleanStuckThreshold",
+		Version:      "3.0.0",
+		Doc:          "The compaction task failing to be cleaned longer than this time(in seconds) is reported as stuck in cleaning",
+		DefaultValue: "3600",
+		Export:       true,
+	}
+	p.CompactionCleanStuckThreshold.Init(base.mgr)
+
 	p.CompactionCheckIntervalInSeconds = ParamItem{
 		Key:          "dataCoord.compaction.check.interval",
 		Version:      "2.0.0",
@@ -5734,6 +6282,33 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.CompactionScheduleInterval.Init(base.mgr)
 
+	p.CompactionVerifyResult = ParamItem{
+		Key:          "dataCoord.compaction.verifyResult",
+		Version:      "3.0.0",
+		DefaultValue: "true",
+		Doc:          "Verify the row counts of the compaction result before committing it to meta, the inconsistent results are rejected",
+		Export:       true,
+	}
+	p.CompactionVerifyResult.Init(base.mgr)
+
+	p.CompactionVerifyStatslogs = ParamItem{
+		Key:          "dataCoord.compaction.verifyStatslogs",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc:          "Verify the statslogs of the compaction result exist in object storage with the reported sizes, it takes effect only when verifyResult is enabled",
+		Export:       true,
+	}
+	p.CompactionVerifyStatslogs.Init(base.mgr)
+
+	p.CompactionWorkerRetryTimes = ParamItem{
+		Key:          "dataCoord.compaction.workerRetryTimes",
+		Version:      "3.0.0",
+		DefaultValue: "3",
+		Doc:          "Max times to re-schedule a compaction task onto another datanode when its datanode is lost, the task fails once exceeded. The transient failures are retried without counting",
+		Export:       true,
+	}
+	p.CompactionWorkerRetryTimes.Init(base.mgr)
+
 	p.CompactionMaxFullSegmentThreshold = ParamItem{
 		Key:          "dataCoord.compaction.maxFullSegmentThreshold",
 		Version:      "2.6.8",
@@ -5891,6 +6466,41 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.BumpSchemaVersionCompactionTriggerInterval.Init(base.mgr)
 
+	p.LogMergeCompactionEnabled = ParamItem{
+		Key:          "dataCoord.compaction.logMerge.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Enable log merge compaction, which merges the small deltalogs and statslogs of a flushed segment
+without rewriting the insert binlogs, it relieves the segments of the wide schema from the file number explosion`,
+		Export: true,
+	}
+	p.LogMergeCompactionEnabled.Init(base.mgr)
+
+	p.LogMergeCompactionTriggerInterval = ParamItem{
+		Key:          "dataCoord.compaction.logMerge.triggerInterval",
+		Version:      "3.0.0",
+		Doc:          "The time interval in seconds for trigger log merge compaction",
+		DefaultValue: "300",
+		Export:       true,
+	}
+	p.LogMergeCompactionTriggerInterval.Init(base.mgr)
+
+	p.LogMergeCompactionFileNumThreshold = ParamItem{
+		Key:          "dataCoord.compaction.logMerge.fileNumThreshold",
+		Version:      "3.0.0",
+		Doc:          "The log merge compaction is triggered when the number of the deltalog and statslog files of a segment exceeds the threshold",
+		DefaultValue: "200",
+		Export:       true,
+		Formatter: func(value string) string {
+			num := getAsInt(value)
+			if num < 2 {
+				return "2"
+			}
+			return strconv.Itoa(num)
+		},
+	}
+	p.LogMergeCompactionFileNumThreshold.Init(base.mgr)
+
 	p.MixCompactionTriggerInterval = ParamItem{
 		Key:          "dataCoord.compaction.mix.triggerInterval",
 		Version:      "2.4.15",
@@ -5900,6 +6510,15 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.MixCompactionTriggerInterval.Init(base.mgr)
 
+	p.MixCompactionFlushCooldown = ParamItem{
+		Key:          "dataCoord.compaction.mix.flushCooldown",
+		Version:      "3.0.0",
+		Doc:          "The time in seconds after flush before a segment is considered by mix compaction, force triggers ignore it. 0 means disabled",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.MixCompactionFlushCooldown.Init(base.mgr)
+
 	p.L0CompactionTriggerInterval = ParamItem{
 		Key:          "dataCoord.compaction.levelzero.triggerInterval",
 		Version:      "2.4.15",
@@ -6132,6 +6751,45 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.ClusteringCompactionMaxClusterSize.Init(base.mgr)
 
+	p.ClusteringCompactionCommitBatchSize = ParamItem{
+		Key:          "dataCoord.compaction.clustering.commitBatchSize",
+		Version:      "3.0.0",
+		DefaultValue: "64",
+		Doc: `The max number of the output segments committed to the meta in one batch when a clustering compaction completes,
+the output segments stay invisible until all of them are committed and indexed. 0 means committing all of them at once.`,
+		Export: true,
+	}
+	p.ClusteringCompactionCommitBatchSize.Init(base.mgr)
+
+	p.ClusteringCompactionSkewTriggerEnable = ParamItem{
+		Key:          "dataCoord.compaction.clustering.skewTrigger.enable",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Enable triggering clustering compaction automatically when the clustering key distribution drifts,
+the rows of the segments not clustered by the last clustering compaction are outside the cluster ranges of the partition stats.
+It works with autoEnable, and is still capped by minInterval.`,
+		Export: true,
+	}
+	p.ClusteringCompactionSkewTriggerEnable.Init(base.mgr)
+
+	p.ClusteringCompactionSkewRatioThreshold = ParamItem{
+		Key:          "dataCoord.compaction.clustering.skewTrigger.ratioThreshold",
+		Version:      "3.0.0",
+		DefaultValue: "0.2",
+		Doc:          "Trigger clustering compaction if the ratio of the rows outside the cluster ranges of a partition and channel reaches the threshold",
+		Export:       true,
+	}
+	p.ClusteringCompactionSkewRatioThreshold.Init(base.mgr)
+
+	p.ClusteringCompactionSkewTriggerMinInterval = ParamItem{
+		Key:          "dataCoord.compaction.clustering.skewTrigger.minInterval",
+		Version:      "3.0.0",
+		DefaultValue: "7200",
+		Doc:          "The minimum interval in seconds between the skew triggered clustering compactions of one partition and channel, to avoid retriggering the failed ones frequently",
+		Export:       true,
+	}
+	p.ClusteringCompactionSkewTriggerMinInterval.Init(base.mgr)
+
 	p.EnableGarbageCollection = ParamItem{
 		Key:          "dataCoord.enableGarbageCollection",
 		Version:      "2.0.0",
@@ -6298,6 +6956,54 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.GCLOBCheckInterval.Init(base.mgr)
 
+	p.GCOrphanSegmentCheckEnabled = ParamItem{
+		Key:          "dataCoord.gc.orphanSegment.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Enable the consistency check between the segments in meta and the segment files on object storage,
+which reports the segments whose files are on object storage but missing from meta, and the flushed segments whose files are missing from object storage.`,
+		Export: true,
+	}
+	p.GCOrphanSegmentCheckEnabled.Init(base.mgr)
+
+	p.GCOrphanSegmentCheckInterval = ParamItem{
+		Key:          "dataCoord.gc.orphanSegment.checkInterval",
+		Version:      "3.0.0",
+		DefaultValue: "86400",
+		Doc:          "The interval of the orphan segment check, unit: second.",
+		Export:       true,
+	}
+	p.GCOrphanSegmentCheckInterval.Init(base.mgr)
+
+	p.GCOrphanSegmentRepairEnabled = ParamItem{
+		Key:          "dataCoord.gc.orphanSegment.repairEnabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Repair the orphan segments found by the check, the flushed segments whose files are missing from object storage are marked as dropped
+once confirmed by dataCoord.gc.orphanSegment.repairConfirmTimes consecutive checks. Only report them if disabled.
+The files of the segments missing from meta are always left to the gc, which removes them after dataCoord.gc.missingTolerance.`,
+		Export: true,
+	}
+	p.GCOrphanSegmentRepairEnabled.Init(base.mgr)
+
+	p.GCOrphanSegmentRepairConfirmTimes = ParamItem{
+		Key:          "dataCoord.gc.orphanSegment.repairConfirmTimes",
+		Version:      "3.0.0",
+		DefaultValue: "3",
+		Doc:          "The number of the consecutive orphan segment checks finding a flushed segment missing its files before it's marked as dropped.",
+		Export:       true,
+	}
+	p.GCOrphanSegmentRepairConfirmTimes.Init(base.mgr)
+
+	p.GCOrphanSegmentMaxRepairPerCheck = ParamItem{
+		Key:          "dataCoord.gc.orphanSegment.maxRepairPerCheck",
+		Version:      "3.0.0",
+		DefaultValue: "10",
+		Doc:          "The max number of the segments missing their files marked as dropped by an orphan segment check, the rest are left to the next check.",
+		Export:       true,
+	}
+	p.GCOrphanSegmentMaxRepairPerCheck.Init(base.mgr)
+
 	p.MinSegmentNumRowsToEnableIndex = ParamItem{
 		Key:          "indexCoord.segment.minSegmentNumRowsToEnableIndex",
 		Version:      "2.0.0",
@@ -6774,6 +7480,23 @@ if param targetScalarIndexVersion is not set, the default value is -1, which mea
 	}
 	p.BumpSchemaVersionCompactionSlotUsage.Init(base.mgr)
 
+	p.LogMergeCompactionSlotUsage = ParamItem{
+		Key:          "dataCoord.slot.logMergeCompactionUsage",
+		Version:      "3.0.0",
+		Doc:          "slot usage of log merge compaction task.",
+		DefaultValue: "1",
+		PanicIfEmpty: false,
+		Export:       true,
+		Formatter: func(value string) string {
+			slot := getAsInt(value)
+			if slot < 1 {
+				return "1"
+			}
+			return strconv.Itoa(slot)
+		},
+	}
+	p.LogMergeCompactionSlotUsage.Init(base.mgr)
+
 	p.IndexTaskSlotUsage = ParamItem{
 		Key:          "dataCoord.slot.indexTaskSlotUsage",
 		Version:      "2.5.8",
@@ -6937,6 +7660,9 @@ type dataNodeConfig struct {
 	FlowGraphSkipModeSkipNum  ParamItem `refreshable:"true"`
 	FlowGraphSkipModeColdTime ParamItem `refreshable:"true"`
 
+	// paused vchannels
+	DataSyncPausedVChannels ParamItem `refreshable:"true"`
+
 	// segment
 	FlushInsertBufferSize  ParamItem `refreshable:"true"`
 	FlushDeleteBufferBytes ParamItem `refreshable:"true"`
@@ -7068,6 +7794,17 @@ func (p *dataNodeConfig) init(base *BaseTable) {
 	}
 	p.FlowGraphSkipModeColdTime.Init(base.mgr)
 
+	p.DataSyncPausedVChannels = ParamItem{
+		Key:          "dataNode.dataSync.pausedVChannels",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `The vchannels whose consumption is paused, separated by comma, managed by the channel pause api of datacoord.
+The flowgraph of a paused vchannel stops consuming messages until it's resumed, the other vchannels on the same
+pchannel are held back too as they share the consumption of the wal. It's used to debug the poison messages.`,
+		Export: false,
+	}
+	p.DataSyncPausedVChannels.Init(base.mgr)
+
 	p.MaxParallelSyncTaskNum = ParamItem{
 		Key:          "dataNode.dataSync.maxParallelSyncTaskNum",
 		Version:      "2.3.0",
@@ -7571,6 +8308,13 @@ type streamingConfig struct {
 	WALBalancerPolicyVChannelFairRebalanceTolerance     ParamItem `refreshable:"true"`
 	WALBalancerPolicyVChannelFairRebalanceMaxStep       ParamItem `refreshable:"true"`
 	WALBalancerExpectedInitialStreamingNodeNum          ParamItem `refreshable:"true"`
+	WALBalancerChannelClasses                           ParamItem `refreshable:"true"`
+
+	// channel events
+	WALBalancerChannelEventLogEnabled  ParamItem `refreshable:"true"`
+	WALBalancerChannelEventWebhookURL  ParamItem `refreshable:"true"`
+	WALBalancerChannelEventPluginPath  ParamItem `refreshable:"false"`
+	WALBalancerChannelEventSinkTimeout ParamItem `refreshable:"true"`
 
 	// broadcaster
 	WALBroadcasterConcurrencyRatio       ParamItem `refreshable:"false"`
@@ -7835,6 +8579,56 @@ then open the streaming service to continue the upgrade process.`,
 	}
 	p.WALBalancerExpectedInitialStreamingNodeNum.Init(base.mgr)
 
+	p.WALBalancerChannelClasses = ParamItem{
+		Key:     "streaming.walBalancer.channelClasses",
+		Version: "3.0.0",
+		Doc: `The pchannels dedicated to each channel class in json format, e.g. {"high-throughput": ["by-dev-rootcoord-dml_0"]}.
+The pchannels of a class are only assigned to the streaming nodes labeled with the class by environment variable MILVUS_SERVER_LABEL_CHANNEL_CLASS,
+and these nodes are excluded from the general rotation. If no node of the class is alive, the pchannels fall back to the general nodes.
+The vchannels of a collection with the property collection.channel.class are only allocated on the pchannels of the class.`,
+		DefaultValue: "",
+		Export:       false,
+	}
+	p.WALBalancerChannelClasses.Init(base.mgr)
+
+	p.WALBalancerChannelEventLogEnabled = ParamItem{
+		Key:          "streaming.walBalancer.channelEvent.logEnabled",
+		Version:      "3.0.0",
+		Doc:          "Whether to log the channel events (watch success, release, reassignment and failure of the pchannels) in json",
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALBalancerChannelEventLogEnabled.Init(base.mgr)
+
+	p.WALBalancerChannelEventWebhookURL = ParamItem{
+		Key:     "streaming.walBalancer.channelEvent.webhookURL",
+		Version: "3.0.0",
+		Doc: `The http endpoint which the channel events are posted to in json, empty means disabled.
+The events are posted asynchronously and dropped if the endpoint can't keep up, so the channel assignment is never blocked by it.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALBalancerChannelEventWebhookURL.Init(base.mgr)
+
+	p.WALBalancerChannelEventPluginPath = ParamItem{
+		Key:     "streaming.walBalancer.channelEvent.pluginPath",
+		Version: "3.0.0",
+		Doc: `The path of the go plugin which the channel events are passed to in json, empty means disabled.
+The plugin should export the symbol ChannelEventSink with the type func(context.Context, []byte) error, e.g. to produce the events into a message queue.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALBalancerChannelEventPluginPath.Init(base.mgr)
+
+	p.WALBalancerChannelEventSinkTimeout = ParamItem{
+		Key:          "streaming.walBalancer.channelEvent.sinkTimeout",
+		Version:      "3.0.0",
+		Doc:          "The timeout of passing a channel event to the webhook or the plugin, 3s by default",
+		DefaultValue: "3s",
+		Export:       true,
+	}
+	p.WALBalancerChannelEventSinkTimeout.Init(base.mgr)
+
 	p.WALBroadcasterConcurrencyRatio = ParamItem{
 		Key:          "streaming.walBroadcaster.concurrencyRatio",
 		Version:      "2.5.4",
@@ -225,6 +225,10 @@ func TestComponentParam(t *testing.T) {
 		params.Save("rootCoord.defaultDBProperties", "{\"key\":\"value\"}")
 		assert.Equal(t, "{\"key\":\"value\"}", Params.DefaultDBProperties.GetValue())
 
+		assert.Equal(t, 0.0, Params.DBTimestampAllocRate.GetAsFloat())
+		params.Save("rootCoord.dbTimestampAllocRate", "1000")
+		assert.Equal(t, 1000.0, Params.DBTimestampAllocRate.GetAsFloat())
+
 		SetCreateTime(time.Now())
 		SetUpdateTime(time.Now())
 	})
@@ -299,6 +303,7 @@ func TestComponentParam(t *testing.T) {
 
 		assert.Equal(t, int64(10), Params.CheckWorkloadRequestNum.GetAsInt64())
 		assert.Equal(t, float64(0.1), Params.WorkloadToleranceFactor.GetAsFloat())
+		assert.Equal(t, 0.5, Params.ShardLeaderHealthThreshold.GetAsFloat())
 		assert.Equal(t, int64(10000), Params.MaxSearchAggregationResultEntries.GetAsInt64())
 		params.Save(Params.MaxSearchAggregationResultEntries.Key, "1024")
 		assert.Equal(t, int64(1024), Params.MaxSearchAggregationResultEntries.GetAsInt64())
@@ -321,6 +326,8 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, int64(4096), Params.MaxArrayCapacity.GetAsInt64())
 		params.Save("proxy.maxArrayCapacity", "-1")
 		assert.Equal(t, int64(4096), Params.MaxArrayCapacity.GetAsInt64())
+
+		assert.Equal(t, 300*time.Second, Params.IdleCollectionLoadTimeout.GetAsDuration(time.Second))
 	})
 
 	// t.Run("test proxyConfig panic", func(t *testing.T) {
@@ -404,6 +411,9 @@ func TestComponentParam(t *testing.T) {
 		checkNodeInReplicaInterval := &Params.CheckNodeInReplicaInterval
 		assert.Equal(t, 100, checkNodeInReplicaInterval.GetAsInt())
 
+		assert.False(t, Params.ReplicaAdvisoryModeEnabled.GetAsBool())
+		assert.Equal(t, time.Duration(0), Params.ReplicaSuggestionApplyDelay.GetAsDuration(time.Second))
+
 		params.Save("queryCoord.checkResourceGroupInterval", "10")
 		checkResourceGroupInterval := &Params.CheckResourceGroupInterval
 		assert.Equal(t, 10, checkResourceGroupInterval.GetAsInt())
@@ -479,6 +489,14 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 100, Params.BalanceCheckCollectionMaxCount.GetAsInt())
 		assert.Equal(t, 30, Params.ResourceExhaustionPenaltyDuration.GetAsInt())
 		assert.Equal(t, 10, Params.ResourceExhaustionCleanupInterval.GetAsInt())
+
+		assert.Equal(t, 0, Params.LoadingQueueDepthLimit.GetAsInt())
+		params.Save("queryCoord.loadingQueueDepthLimit", "64")
+		assert.Equal(t, 64, Params.LoadingQueueDepthLimit.GetAsInt())
+
+		assert.Equal(t, 100, Params.LoadAuditMaxRecords.GetAsInt())
+		params.Save("queryCoord.loadAudit.maxRecords", "-1")
+		assert.Equal(t, 0, Params.LoadAuditMaxRecords.GetAsInt())
 	})
 
 	t.Run("test queryNodeConfig", func(t *testing.T) {
@@ -654,8 +672,25 @@ func TestComponentParam(t *testing.T) {
 		params.Save("dataCoord.compaction.dropTolerance", "100")
 		assert.Equal(t, float64(100), Params.CompactionDropToleranceInSeconds.GetAsDuration(time.Second).Seconds())
 		assert.Equal(t, int64(10000), Params.CompactionPreAllocateIDExpansionFactor.GetAsInt64())
+		assert.Equal(t, 0, Params.ChannelSmallSegmentSoftLimit.GetAsInt())
+		params.Save("dataCoord.segment.channelSmallSegmentSoftLimit", "-1")
+		assert.Equal(t, 0, Params.ChannelSmallSegmentSoftLimit.GetAsInt())
+		params.Save("dataCoord.segment.channelSmallSegmentSoftLimit", "1000")
+		assert.Equal(t, 1000, Params.ChannelSmallSegmentSoftLimit.GetAsInt())
+		assert.Equal(t, "level", Params.CompactionTaskPrioritizer.GetValue())
+		assert.Empty(t, Params.CompactionDatabaseWeights.GetAsStrings())
+		params.Save("dataCoord.compaction.databaseWeights", "db1:3,db2:1")
+		assert.Equal(t, []string{"db1:3", "db2:1"}, Params.CompactionDatabaseWeights.GetAsStrings())
 		assert.False(t, Params.StorageFormatCompactionEnabled.GetAsBool())
 
+		assert.Equal(t, float64(0), Params.CompactionTaskIOBudget.GetAsFloat())
+		params.Save("dataCoord.compaction.ioBudget.task", "20")
+		assert.Equal(t, float64(20), Params.CompactionTaskIOBudget.GetAsFloat())
+		params.Save("dataCoord.compaction.ioBudget.collection", "-1")
+		assert.Equal(t, float64(0), Params.CompactionCollectionIOBudget.GetAsFloat())
+		params.Save("dataCoord.compaction.ioBudget.node", "100")
+		assert.Equal(t, float64(100), Params.CompactionNodeIOBudget.GetAsFloat())
+
 		params.Save("dataCoord.compaction.clustering.enable", "true")
 		assert.Equal(t, true, Params.ClusteringCompactionEnable.GetAsBool())
 		params.Save("dataCoord.compaction.clustering.newDataSizeThreshold", "10")
@@ -670,6 +705,9 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 1.2, Params.ClusteringCompactionMaxSegmentSizeRatio.GetAsFloat())
 		params.Save("dataCoord.compaction.clustering.preferSegmentSizeRatio", "0.5")
 		assert.Equal(t, 0.5, Params.ClusteringCompactionPreferSegmentSizeRatio.GetAsFloat())
+		assert.Equal(t, 64, Params.ClusteringCompactionCommitBatchSize.GetAsInt())
+		params.Save("dataCoord.compaction.clustering.commitBatchSize", "16")
+		assert.Equal(t, 16, Params.ClusteringCompactionCommitBatchSize.GetAsInt())
 		params.Save("dataCoord.slot.clusteringCompactionUsage", "10")
 		assert.Equal(t, 10, Params.ClusteringCompactionSlotUsage.GetAsInt())
 		params.Save("dataCoord.slot.mixCompactionUsage", "5")
@@ -790,6 +828,10 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 0.01, params.StreamingCfg.WALBalancerPolicyVChannelFairRebalanceTolerance.GetAsFloat())
 		assert.Equal(t, 3, params.StreamingCfg.WALBalancerPolicyVChannelFairRebalanceMaxStep.GetAsInt())
 		assert.Equal(t, 30*time.Minute, params.StreamingCfg.WALBalancerOperationTimeout.GetAsDurationByParse())
+		assert.False(t, params.StreamingCfg.WALBalancerChannelEventLogEnabled.GetAsBool())
+		assert.Equal(t, "", params.StreamingCfg.WALBalancerChannelEventWebhookURL.GetValue())
+		assert.Equal(t, "", params.StreamingCfg.WALBalancerChannelEventPluginPath.GetValue())
+		assert.Equal(t, 3*time.Second, params.StreamingCfg.WALBalancerChannelEventSinkTimeout.GetAsDurationByParse())
 		assert.Equal(t, 4.0, params.StreamingCfg.WALBroadcasterConcurrencyRatio.GetAsFloat())
 		assert.Equal(t, 5*time.Minute, params.StreamingCfg.WALBroadcasterTombstoneCheckInternal.GetAsDurationByParse())
 		assert.Equal(t, 8192, params.StreamingCfg.WALBroadcasterTombstoneMaxCount.GetAsInt())
@@ -129,6 +129,22 @@ func (pi *ParamItem) getWithRaw() (result, raw string, err error) {
 		return *s, *s, nil
 	}
 
+	result, raw, err = pi.getUnformatted()
+	if pi.Formatter != nil {
+		result = pi.Formatter(result)
+	}
+	if result == "" && pi.PanicIfEmpty {
+		panic(fmt.Sprintf("%s is empty", pi.Key))
+	}
+	return result, raw, err
+}
+
+// getUnformatted returns the configured value before formatted, along with the value of the primary key.
+func (pi *ParamItem) getUnformatted() (value, raw string, err error) {
+	if s := pi.tempValue.Load(); s != nil {
+		return *s, *s, nil
+	}
+
 	if pi.manager == nil {
 		panic(fmt.Sprintf("manager is nil %s", pi.Key))
 	}
@@ -154,14 +170,7 @@ func (pi *ParamItem) getWithRaw() (result, raw string, err error) {
 		effectiveRaw = pi.DefaultValue
 		raw = pi.DefaultValue
 	}
-	result = effectiveRaw
-	if pi.Formatter != nil {
-		result = pi.Formatter(result)
-	}
-	if result == "" && pi.PanicIfEmpty {
-		panic(fmt.Sprintf("%s is empty", pi.Key))
-	}
-	return result, raw, err
+	return effectiveRaw, raw, err
 }
 
 // SetTempValue set the value for this ParamItem,
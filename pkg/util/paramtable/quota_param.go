@@ -43,8 +43,10 @@ const (
 type quotaConfig struct {
 	QuotaAndLimitsEnabled      ParamItem `refreshable:"false"`
 	QuotaCenterCollectInterval ParamItem `refreshable:"false"`
+	StopDrainTimeout           ParamItem `refreshable:"true"`
 	FactorChangeThreshold      ParamItem `refreshable:"true"`
 	ForceDenyAllDDL            ParamItem `refreshable:"true"`
+	SimulateMode               ParamItem `refreshable:"true"`
 	AllocRetryTimes            ParamItem `refreshable:"false"`
 	AllocWaitInterval          ParamItem `refreshable:"false"`
 	ComplexDeleteLimitEnable   ParamItem `refreshable:"false"`
@@ -53,6 +55,7 @@ type quotaConfig struct {
 	DDLLimitEnabled   ParamItem `refreshable:"true"`
 	DDLCollectionRate ParamItem `refreshable:"true"`
 	DDLPartitionRate  ParamItem `refreshable:"true"`
+	DDLPayloadUnit    ParamItem `refreshable:"true"`
 
 	IndexLimitEnabled ParamItem `refreshable:"true"`
 	MaxIndexRate      ParamItem `refreshable:"true"`
@@ -64,11 +67,12 @@ type quotaConfig struct {
 	CompactionLimitEnabled ParamItem `refreshable:"true"`
 	MaxCompactionRate      ParamItem `refreshable:"true"`
 
-	DDLCollectionRatePerDB ParamItem `refreshable:"true"`
-	DDLPartitionRatePerDB  ParamItem `refreshable:"true"`
-	MaxIndexRatePerDB      ParamItem `refreshable:"true"`
-	MaxFlushRatePerDB      ParamItem `refreshable:"true"`
-	MaxCompactionRatePerDB ParamItem `refreshable:"true"`
+	DDLCollectionRatePerDB         ParamItem `refreshable:"true"`
+	DDLPartitionRatePerDB          ParamItem `refreshable:"true"`
+	MaxIndexRatePerDB              ParamItem `refreshable:"true"`
+	MaxFlushRatePerDB              ParamItem `refreshable:"true"`
+	MaxCompactionRatePerDB         ParamItem `refreshable:"true"`
+	MaxCompactionRatePerCollection ParamItem `refreshable:"true"`
 
 	DBLimitEnabled ParamItem `refreshable:"true"`
 	MaxDBRate      ParamItem `refreshable:"true"`
@@ -118,8 +122,19 @@ type quotaConfig struct {
 	DQLMinSearchRatePerPartition  ParamItem `refreshable:"true"`
 	DQLMaxQueryRatePerPartition   ParamItem `refreshable:"true"`
 	DQLMinQueryRatePerPartition   ParamItem `refreshable:"true"`
+	DQLMaxGetRate                 ParamItem `refreshable:"true"`
+	DQLMinGetRate                 ParamItem `refreshable:"true"`
+	DQLMaxGetRatePerDB            ParamItem `refreshable:"true"`
+	DQLMinGetRatePerDB            ParamItem `refreshable:"true"`
+	DQLMaxGetRatePerCollection    ParamItem `refreshable:"true"`
+	DQLMinGetRatePerCollection    ParamItem `refreshable:"true"`
+	DQLMaxGetRatePerPartition     ParamItem `refreshable:"true"`
+	DQLGuaranteedSearchRatePerDB  ParamItem `refreshable:"true"`
+	DQLGuaranteedQueryRatePerDB   ParamItem `refreshable:"true"`
+	DQLGuaranteedGetRatePerDB     ParamItem `refreshable:"true"`
 
 	// limits
+	MaxDatabaseNum                 ParamItem `refreshable:"true"`
 	MaxCollectionNum               ParamItem `refreshable:"true"`
 	MaxCollectionNumPerDB          ParamItem `refreshable:"true"`
 	TopKLimit                      ParamItem `refreshable:"true"`
@@ -134,6 +149,14 @@ type quotaConfig struct {
 
 	// limit writing
 	ForceDenyWriting                      ParamItem `refreshable:"true"`
+	DenyMinHoldSeconds                    ParamItem `refreshable:"true"`
+	DenyReleaseFactor                     ParamItem `refreshable:"true"`
+	DenyCooldownMaxCycles                 ParamItem `refreshable:"true"`
+	DenyCooldownWindowSeconds             ParamItem `refreshable:"true"`
+	DenyCooldownSeconds                   ParamItem `refreshable:"true"`
+	DenyCooldownFactor                    ParamItem `refreshable:"true"`
+	FactorExcludedNodes                   ParamItem `refreshable:"true"`
+	NodeQuarantineRounds                  ParamItem `refreshable:"true"`
 	TtProtectionEnabled                   ParamItem `refreshable:"true"`
 	MaxTimeTickDelay                      ParamItem `refreshable:"true"`
 	MemProtectionEnabled                  ParamItem `refreshable:"true"`
@@ -141,6 +164,8 @@ type quotaConfig struct {
 	DataNodeMemoryHighWaterLevel          ParamItem `refreshable:"true"`
 	QueryNodeMemoryLowWaterLevel          ParamItem `refreshable:"true"`
 	QueryNodeMemoryHighWaterLevel         ParamItem `refreshable:"true"`
+	StreamingNodeMemoryLowWaterLevel      ParamItem `refreshable:"true"`
+	StreamingNodeMemoryHighWaterLevel     ParamItem `refreshable:"true"`
 	GrowingSegmentsSizeProtectionEnabled  ParamItem `refreshable:"true"`
 	GrowingSegmentsSizeMinRateRatio       ParamItem `refreshable:"true"`
 	GrowingSegmentsSizeLowWaterLevel      ParamItem `refreshable:"true"`
@@ -151,6 +176,9 @@ type quotaConfig struct {
 	DiskQuotaPerDB                        ParamItem `refreshable:"true"`
 	DiskQuotaPerCollection                ParamItem `refreshable:"true"`
 	DiskQuotaPerPartition                 ParamItem `refreshable:"true"`
+	DiskReclaimEnabled                    ParamItem `refreshable:"true"`
+	DiskReclaimLowWaterLevel              ParamItem `refreshable:"true"`
+	DiskReclaimCompactionInterval         ParamItem `refreshable:"true"`
 	L0SegmentRowCountProtectionEnabled    ParamItem `refreshable:"true"`
 	L0SegmentRowCountLowWaterLevel        ParamItem `refreshable:"true"`
 	L0SegmentRowCountHighWaterLevel       ParamItem `refreshable:"true"`
@@ -160,9 +188,48 @@ type quotaConfig struct {
 	DeleteBufferSizeProtectionEnabled     ParamItem `refreshable:"true"`
 	DeleteBufferSizeLowWaterLevel         ParamItem `refreshable:"true"`
 	DeleteBufferSizeHighWaterLevel        ParamItem `refreshable:"true"`
+	ReplicationLagProtectionEnabled       ParamItem `refreshable:"true"`
+	MaxReplicationLag                     ParamItem `refreshable:"true"`
+	ReplicationLagMinRateRatio            ParamItem `refreshable:"true"`
 
 	// limit reading
 	ForceDenyReading ParamItem `refreshable:"true"`
+
+	// slow query protection
+	SlowQueryProtectionEnabled ParamItem `refreshable:"true"`
+	MaxSlowQueryRate           ParamItem `refreshable:"true"`
+	SlowQueryCoolOffSpeed      ParamItem `refreshable:"true"`
+	SlowQueryMinRateRatio      ParamItem `refreshable:"true"`
+
+	// usage based database dql limits
+	DQLUsageBasedDBLimitEnabled    ParamItem `refreshable:"true"`
+	DQLUsageBasedDBLimitMultiplier ParamItem `refreshable:"true"`
+	DQLUsageBasedDBLimitSmoothing  ParamItem `refreshable:"true"`
+	DQLUsageBasedDBLimitMinRate    ParamItem `refreshable:"true"`
+
+	// storage usage report
+	StorageUsageReportEnabled        ParamItem `refreshable:"true"`
+	StorageUsageReportInterval       ParamItem `refreshable:"false"`
+	StorageUsageReportWebhookURL     ParamItem `refreshable:"true"`
+	StorageUsageReportWebhookTimeout ParamItem `refreshable:"true"`
+
+	// scheduled quota windows
+	ScheduledWindows ParamItem `refreshable:"true"`
+
+	// quota exemption tokens
+	ExemptionSecret ParamItem `refreshable:"true"`
+	ExemptionMaxTTL ParamItem `refreshable:"true"`
+
+	// quota metrics history
+	MetricsHistorySize           ParamItem `refreshable:"true"`
+	MetricsHistorySampleInterval ParamItem `refreshable:"true"`
+
+	// app tag rate limits
+	AppTagRateLimits ParamItem `refreshable:"true"`
+
+	// delegated quota administration
+	DelegationEnabled    ParamItem `refreshable:"true"`
+	DelegationOwnerRoles ParamItem `refreshable:"true"`
 }
 
 func (p *quotaConfig) init(base *BaseTable) {
@@ -194,6 +261,23 @@ seconds, (0 ~ 65536)`,
 	}
 	p.QuotaCenterCollectInterval.Init(base.mgr)
 
+	p.StopDrainTimeout = ParamItem{
+		Key:          "quotaAndLimits.stopDrainTimeout",
+		Version:      "3.0.0",
+		DefaultValue: "5",
+		Formatter: func(v string) string {
+			if getAsInt(v) <= 0 {
+				return "5"
+			}
+			return v
+		},
+		Doc: `stopDrainTimeout is the time that quotaCenter waits for the in-flight rates push to proxies when stopping,
+the push not finished in time is canceled, then a final snapshot of the limits from the quota configs is pushed to proxies
+within the same timeout, so that proxies are not left with the inconsistent limits. seconds, (0 ~ Inf)`,
+		Export: true,
+	}
+	p.StopDrainTimeout.Init(base.mgr)
+
 	const defaultFactorChangeThreshold = "0.05"
 	p.FactorChangeThreshold = ParamItem{
 		Key:          "quotaAndLimits.factorChangeThreshold",
@@ -223,6 +307,17 @@ to reduce unnecessary proxy updates. Range: (0, 1]`,
 	}
 	p.ForceDenyAllDDL.Init(base.mgr)
 
+	p.SimulateMode = ParamItem{
+		Key:          "quotaAndLimits.simulateMode",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `true to calculate the limits and deny decisions without applying them to proxies,
+the simulated limits are exported by metrics and the quota metrics of rootcoord,
+used to evaluate the quota configs before enforcement.`,
+		Export: true,
+	}
+	p.SimulateMode.Init(base.mgr)
+
 	// ddl
 	max := fmt.Sprintf("%f", defaultMax)
 	min := fmt.Sprintf("%f", defaultMin)
@@ -309,6 +404,25 @@ To use this setting, set quotaAndLimits.ddl.enabled to true at the same time.`,
 	}
 	p.DDLPartitionRate.Init(base.mgr)
 
+	p.DDLPayloadUnit = ParamItem{
+		Key:          "quotaAndLimits.ddl.payloadUnitSize",
+		Version:      "3.0.0",
+		DefaultValue: "65536",
+		Formatter: func(v string) string {
+			// [0 ~ Inf)
+			if getAsInt(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+		Doc: `Payload size in bytes of one DDL token.
+A DDL request consumes one token of the DDL rate limits per payloadUnitSize bytes of its payload, and at least one token,
+so that the DDL requests with large payloads, such as creating collections with thousands of fields, are throttled proportionally.
+Setting this item to 0 makes every DDL request consume one token regardless of its payload size.`,
+		Export: true,
+	}
+	p.DDLPayloadUnit.Init(base.mgr)
+
 	p.DDLPartitionRatePerDB = ParamItem{
 		Key:          "quotaAndLimits.ddl.db.partitionRate",
 		Version:      "2.4.1",
@@ -507,6 +621,28 @@ To use this setting, set quotaAndLimits.compaction.enabled to true at the same t
 	}
 	p.MaxCompactionRatePerDB.Init(base.mgr)
 
+	p.MaxCompactionRatePerCollection = ParamItem{
+		Key:          "quotaAndLimits.compactionRate.collection.max",
+		Version:      "3.0.0",
+		DefaultValue: max,
+		Formatter: func(v string) string {
+			if p.ForceDenyAllDDL.GetAsBool() {
+				return min
+			}
+			if !p.CompactionLimitEnabled.GetAsBool() {
+				return max
+			}
+			// [0 ~ Inf)
+			if getAsInt(v) < 0 {
+				return max
+			}
+			return v
+		},
+		Doc:    "qps of collection level, default no limit, rate for manualCompaction",
+		Export: true,
+	}
+	p.MaxCompactionRatePerCollection.Init(base.mgr)
+
 	p.DBLimitEnabled = ParamItem{
 		Key:          "quotaAndLimits.dbRate.enabled",
 		Version:      "2.5.8",
@@ -1424,11 +1560,205 @@ To use this setting, set quotaAndLimits.dql.enabled to true at the same time.`,
 	}
 	p.DQLMinQueryRatePerPartition.Init(base.mgr)
 
+	// the get rates follow the query rates unless they're set, since the gets were limited as queries before.
+	p.DQLMaxGetRate = ParamItem{
+		Key:          "quotaAndLimits.dql.getRate.max",
+		Version:      "3.0.0",
+		DefaultValue: "-1",
+		Formatter: func(v string) string {
+			if !p.DQLLimitEnabled.GetAsBool() {
+				return max
+			}
+			// [0, inf)
+			if getAsFloat(v) < 0 {
+				return p.DQLMaxQueryRate.GetValue()
+			}
+			return v
+		},
+		Doc: `Maximum number of gets per second, the gets are the queries looking up the primary keys only,
+such as "pk in [1, 2]" or "pk == 1", the other queries are limited by quotaAndLimits.dql.queryRate.
+A negative value means following quotaAndLimits.dql.queryRate.max.
+To use this setting, set quotaAndLimits.dql.enabled to true at the same time.`,
+		Export: true,
+	}
+	p.DQLMaxGetRate.Init(base.mgr)
+
+	p.DQLMinGetRate = ParamItem{
+		Key:          "quotaAndLimits.dql.getRate.min",
+		Version:      "3.0.0",
+		DefaultValue: min,
+		Formatter: func(v string) string {
+			if !p.DQLLimitEnabled.GetAsBool() {
+				return min
+			}
+			rate := getAsFloat(v)
+			// [0, inf)
+			if rate < 0 {
+				return min
+			}
+			if !p.checkMinMaxLegal(rate, p.DQLMaxGetRate.GetAsFloat()) {
+				return min
+			}
+			return v
+		},
+	}
+	p.DQLMinGetRate.Init(base.mgr)
+
+	p.DQLMaxGetRatePerDB = ParamItem{
+		Key:          "quotaAndLimits.dql.getRate.db.max",
+		Version:      "3.0.0",
+		DefaultValue: "-1",
+		Formatter: func(v string) string {
+			if !p.DQLLimitEnabled.GetAsBool() {
+				return max
+			}
+			// [0, inf)
+			if getAsFloat(v) < 0 {
+				return p.DQLMaxQueryRatePerDB.GetValue()
+			}
+			return v
+		},
+		Doc:    "qps, a negative value means following quotaAndLimits.dql.queryRate.db.max",
+		Export: true,
+	}
+	p.DQLMaxGetRatePerDB.Init(base.mgr)
+
+	p.DQLMinGetRatePerDB = ParamItem{
+		Key:          "quotaAndLimits.dql.getRate.db.min",
+		Version:      "3.0.0",
+		DefaultValue: min,
+		Formatter: func(v string) string {
+			if !p.DQLLimitEnabled.GetAsBool() {
+				return min
+			}
+			rate := getAsFloat(v)
+			// [0, inf)
+			if rate < 0 {
+				return min
+			}
+			if !p.checkMinMaxLegal(rate, p.DQLMaxGetRatePerDB.GetAsFloat()) {
+				return min
+			}
+			return v
+		},
+	}
+	p.DQLMinGetRatePerDB.Init(base.mgr)
+
+	p.DQLMaxGetRatePerCollection = ParamItem{
+		Key:          "quotaAndLimits.dql.getRate.collection.max",
+		Version:      "3.0.0",
+		DefaultValue: "-1",
+		Formatter: func(v string) string {
+			if !p.DQLLimitEnabled.GetAsBool() {
+				return max
+			}
+			// [0, inf)
+			if getAsFloat(v) < 0 {
+				return p.DQLMaxQueryRatePerCollection.GetValue()
+			}
+			return v
+		},
+		Doc:    "qps, a negative value means following quotaAndLimits.dql.queryRate.collection.max",
+		Export: true,
+	}
+	p.DQLMaxGetRatePerCollection.Init(base.mgr)
+
+	p.DQLMinGetRatePerCollection = ParamItem{
+		Key:          "quotaAndLimits.dql.getRate.collection.min",
+		Version:      "3.0.0",
+		DefaultValue: min,
+		Formatter: func(v string) string {
+			if !p.DQLLimitEnabled.GetAsBool() {
+				return min
+			}
+			rate := getAsFloat(v)
+			// [0, inf)
+			if rate < 0 {
+				return min
+			}
+			if !p.checkMinMaxLegal(rate, p.DQLMaxGetRatePerCollection.GetAsFloat()) {
+				return min
+			}
+			return v
+		},
+	}
+	p.DQLMinGetRatePerCollection.Init(base.mgr)
+
+	p.DQLMaxGetRatePerPartition = ParamItem{
+		Key:          "quotaAndLimits.dql.getRate.partition.max",
+		Version:      "3.0.0",
+		DefaultValue: "-1",
+		Formatter: func(v string) string {
+			if !p.DQLLimitEnabled.GetAsBool() {
+				return max
+			}
+			// [0, inf)
+			if getAsFloat(v) < 0 {
+				return p.DQLMaxQueryRatePerPartition.GetValue()
+			}
+			return v
+		},
+		Doc:    "qps, a negative value means following quotaAndLimits.dql.queryRate.partition.max",
+		Export: true,
+	}
+	p.DQLMaxGetRatePerPartition.Init(base.mgr)
+
+	guaranteedRateFormatter := func(v string) string {
+		rate := getAsFloat(v)
+		// [0, inf)
+		if rate < 0 {
+			return "0"
+		}
+		return v
+	}
+	p.DQLGuaranteedSearchRatePerDB = ParamItem{
+		Key:          "quotaAndLimits.dql.searchRate.db.guaranteed",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    guaranteedRateFormatter,
+		Doc: `vps (vectors per second), the search rate always preserved for each database even when the dql limits are
+cooled off by the protections such as slow query protection, it's shared by the loaded collections of the database.
+The databases denied to read by force are not guaranteed, 0 means no guarantee.`,
+		Export: true,
+	}
+	p.DQLGuaranteedSearchRatePerDB.Init(base.mgr)
+
+	p.DQLGuaranteedQueryRatePerDB = ParamItem{
+		Key:          "quotaAndLimits.dql.queryRate.db.guaranteed",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    guaranteedRateFormatter,
+		Doc:          "qps, the query rate always preserved for each database, see quotaAndLimits.dql.searchRate.db.guaranteed",
+		Export:       true,
+	}
+	p.DQLGuaranteedQueryRatePerDB.Init(base.mgr)
+
+	p.DQLGuaranteedGetRatePerDB = ParamItem{
+		Key:          "quotaAndLimits.dql.getRate.db.guaranteed",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter:    guaranteedRateFormatter,
+		Doc:          "qps, the get rate always preserved for each database, see quotaAndLimits.dql.searchRate.db.guaranteed",
+		Export:       true,
+	}
+	p.DQLGuaranteedGetRatePerDB.Init(base.mgr)
+
 	// limits
+	p.MaxDatabaseNum = ParamItem{
+		Key:          "quotaAndLimits.limits.maxDatabaseNum",
+		Version:      "2.3.0",
+		DefaultValue: "64",
+		FallbackKeys: []string{"rootCoord.maxDatabaseNum"},
+		Doc:          "Maximum number of databases, not including the default database.",
+		Export:       true,
+	}
+	p.MaxDatabaseNum.Init(base.mgr)
+
 	p.MaxCollectionNum = ParamItem{
 		Key:          "quotaAndLimits.limits.maxCollectionNum",
 		Version:      "2.2.0",
 		DefaultValue: "65536",
+		Doc:          "Maximum number of collections in the cluster.",
 		Export:       true,
 	}
 	p.MaxCollectionNum.Init(base.mgr)
@@ -1537,6 +1867,128 @@ specific conditions, such as memory of nodes to water marker), ` + "true" + ` me
 	}
 	p.ForceDenyWriting.Init(base.mgr)
 
+	p.DenyMinHoldSeconds = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.denySuppression.minHoldSeconds",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+		Doc: `the minimum duration in seconds to keep denying the dml requests of a collection once it's denied by
+the protections such as time tick delay and memory, 0 means the deny state is released as soon as the metrics recover.`,
+		Export: true,
+	}
+	p.DenyMinHoldSeconds.Init(base.mgr)
+
+	p.DenyReleaseFactor = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.denySuppression.releaseFactor",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 0 || getAsFloat(v) >= 1 {
+				return "0"
+			}
+			return v
+		},
+		Doc: `the hysteresis to release the deny state of a collection, range: [0, 1).
+The deny state is released only if the write rate factor recovers above it,
+for example, with the memory water levels 0.85 and 0.95, the releaseFactor 0.2 releases the deny state when memory is lower than 0.93.`,
+		Export: true,
+	}
+	p.DenyReleaseFactor.Init(base.mgr)
+
+	p.DenyCooldownMaxCycles = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.denyCooldown.maxDenyCycles",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter: func(v string) string {
+			if getAsInt(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+		Doc: `the max number of times a collection is allowed to enter the deny writing state within the window,
+the collection exceeding it is placed in the cooldown, 0 means disabled.`,
+		Export: true,
+	}
+	p.DenyCooldownMaxCycles.Init(base.mgr)
+
+	p.DenyCooldownWindowSeconds = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.denyCooldown.windowSeconds",
+		Version:      "3.0.0",
+		DefaultValue: "600",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+		Doc:    `the window in seconds to count the times a collection enters the deny writing state`,
+		Export: true,
+	}
+	p.DenyCooldownWindowSeconds.Init(base.mgr)
+
+	p.DenyCooldownSeconds = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.denyCooldown.cooldownSeconds",
+		Version:      "3.0.0",
+		DefaultValue: "1800",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+		Doc:    `the duration in seconds a collection stays in the cooldown`,
+		Export: true,
+	}
+	p.DenyCooldownSeconds.Init(base.mgr)
+
+	p.DenyCooldownFactor = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.denyCooldown.factor",
+		Version:      "3.0.0",
+		DefaultValue: "0.5",
+		Formatter: func(v string) string {
+			if getAsFloat(v) <= 0 || getAsFloat(v) > 1 {
+				return "0.5"
+			}
+			return v
+		},
+		Doc: `the max write rate factor of a collection in the cooldown, range: (0, 1].
+The dml rates of the collection are limited to at most factor times the max rates until the cooldown expires.`,
+		Export: true,
+	}
+	p.DenyCooldownFactor.Init(base.mgr)
+
+	p.FactorExcludedNodes = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.nodeExclusion.excludedNodes",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `the comma separated ids of the nodes whose metrics are excluded from the time tick delay and memory protections,
+so a pathological node doesn't limit the write rates of the collections on it.`,
+		Export: true,
+	}
+	p.FactorExcludedNodes.Init(base.mgr)
+
+	p.NodeQuarantineRounds = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.nodeExclusion.quarantineRounds",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Formatter: func(v string) string {
+			if getAsInt(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+		Doc: `the number of the consecutive rounds a node reports the time tick delay over maxTimeTickDelay or the memory over the high water level
+before it's quarantined, 0 means disabled. The metrics of a quarantined node are excluded from the time tick delay and memory protections,
+and only the collections on it are denied to write, it's released after the same number of the consecutive rounds with normal metrics.`,
+		Export: true,
+	}
+	p.NodeQuarantineRounds.Init(base.mgr)
+
 	p.TtProtectionEnabled = ParamItem{
 		Key:          "quotaAndLimits.limitWriting.ttProtection.enabled",
 		Version:      "2.2.0",
@@ -1559,6 +2011,7 @@ specific conditions, such as memory of nodes to water marker), ` + "true" + ` me
 		Doc: `maxTimeTickDelay indicates the backpressure for DML Operations.
 DML rates would be reduced according to the ratio of time tick delay to maxTimeTickDelay,
 if time tick delay is greater than maxTimeTickDelay, all DML requests would be rejected.
+It can be overridden by the database property database.ttProtection.maxTimeTickDelay.seconds.
 seconds`,
 		Export: true,
 	}
@@ -1667,6 +2120,49 @@ When memory usage < memoryLowWaterLevel, no action.`,
 	}
 	p.QueryNodeMemoryHighWaterLevel.Init(base.mgr)
 
+	p.StreamingNodeMemoryLowWaterLevel = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.memProtection.streamingNodeMemoryLowWaterLevel",
+		Version:      "3.0.0",
+		DefaultValue: lowWaterLevel,
+		Formatter: func(v string) string {
+			if !p.MemProtectionEnabled.GetAsBool() {
+				return lowWaterLevel
+			}
+			level := getAsFloat(v)
+			// (0, 1]
+			if level <= 0 || level > 1 {
+				return lowWaterLevel
+			}
+			return v
+		},
+		Doc:    "(0, 1], memoryLowWaterLevel in StreamingNodes serving the growing data",
+		Export: true,
+	}
+	p.StreamingNodeMemoryLowWaterLevel.Init(base.mgr)
+
+	p.StreamingNodeMemoryHighWaterLevel = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.memProtection.streamingNodeMemoryHighWaterLevel",
+		Version:      "3.0.0",
+		DefaultValue: highWaterLevel,
+		Formatter: func(v string) string {
+			if !p.MemProtectionEnabled.GetAsBool() {
+				return highWaterLevel
+			}
+			level := getAsFloat(v)
+			// (0, 1]
+			if level <= 0 || level > 1 {
+				return highWaterLevel
+			}
+			if !p.checkMinMaxLegal(p.StreamingNodeMemoryLowWaterLevel.GetAsFloat(), getAsFloat(v)) {
+				return highWaterLevel
+			}
+			return v
+		},
+		Doc:    "(0, 1], memoryHighWaterLevel in StreamingNodes serving the growing data",
+		Export: true,
+	}
+	p.StreamingNodeMemoryHighWaterLevel.Init(base.mgr)
+
 	p.GrowingSegmentsSizeProtectionEnabled = ParamItem{
 		Key:          "quotaAndLimits.limitWriting.growingSegmentsSizeProtection.enabled",
 		Version:      "2.2.9",
@@ -1844,6 +2340,42 @@ but the rate will not be lower than minRateRatio * dmlRate.`,
 	}
 	p.DiskQuotaPerPartition.Init(base.mgr)
 
+	p.DiskReclaimEnabled = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.diskProtection.reclaimEnabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `When a collection exceeds its disk quota, trigger compaction on it to reclaim the space of the deleted data,
+and keep it in delete-only mode until the disk usage falls below reclaimLowWaterLevel * quota.`,
+		Export: true,
+	}
+	p.DiskReclaimEnabled.Init(base.mgr)
+
+	p.DiskReclaimLowWaterLevel = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.diskProtection.reclaimLowWaterLevel",
+		Version:      "3.0.0",
+		DefaultValue: "0.9",
+		Formatter: func(v string) string {
+			level := getAsFloat(v)
+			// (0, 1]
+			if level <= 0 || level > 1 {
+				return "0.9"
+			}
+			return v
+		},
+		Doc:    "(0, 1], the ratio of the disk quota below which the writing of a collection in reclaim is allowed again",
+		Export: true,
+	}
+	p.DiskReclaimLowWaterLevel.Init(base.mgr)
+
+	p.DiskReclaimCompactionInterval = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.diskProtection.reclaimCompactionInterval",
+		Version:      "3.0.0",
+		DefaultValue: "600",
+		Doc:          "seconds, the interval to trigger compaction again on a collection still in reclaim",
+		Export:       true,
+	}
+	p.DiskReclaimCompactionInterval.Init(base.mgr)
+
 	p.L0SegmentRowCountProtectionEnabled = ParamItem{
 		Key:          "quotaAndLimits.limitWriting.l0SegmentsRowCountProtection.enabled",
 		Version:      "2.4.7",
@@ -1862,12 +2394,19 @@ but the rate will not be lower than minRateRatio * dmlRate.`,
 	}
 	p.L0SegmentRowCountLowWaterLevel.Init(base.mgr)
 
+	defaultL0SegmentRowCountHighWaterLevel := "50000000"
 	p.L0SegmentRowCountHighWaterLevel = ParamItem{
 		Key:          "quotaAndLimits.limitWriting.l0SegmentsRowCountProtection.highWaterLevel",
 		Version:      "2.4.7",
-		DefaultValue: "50000000",
-		Doc:          "l0 segment row count quota, high water level",
-		Export:       true,
+		DefaultValue: defaultL0SegmentRowCountHighWaterLevel,
+		Formatter: func(v string) string {
+			if !p.checkMinMaxLegal(p.L0SegmentRowCountLowWaterLevel.GetAsFloat(), getAsFloat(v)) {
+				return defaultL0SegmentRowCountHighWaterLevel
+			}
+			return v
+		},
+		Doc:    "l0 segment row count quota, high water level",
+		Export: true,
 	}
 	p.L0SegmentRowCountHighWaterLevel.Init(base.mgr)
 
@@ -1889,12 +2428,19 @@ but the rate will not be lower than minRateRatio * dmlRate.`,
 	}
 	p.DeleteBufferRowCountLowWaterLevel.Init(base.mgr)
 
+	defaultDeleteBufferRowCountHighWaterLevel := "65536"
 	p.DeleteBufferRowCountHighWaterLevel = ParamItem{
 		Key:          "quotaAndLimits.limitWriting.deleteBufferRowCountProtection.highWaterLevel",
 		Version:      "2.4.11",
-		DefaultValue: "65536",
-		Doc:          "delete buffer row count quota, high water level",
-		Export:       true,
+		DefaultValue: defaultDeleteBufferRowCountHighWaterLevel,
+		Formatter: func(v string) string {
+			if !p.checkMinMaxLegal(p.DeleteBufferRowCountLowWaterLevel.GetAsFloat(), getAsFloat(v)) {
+				return defaultDeleteBufferRowCountHighWaterLevel
+			}
+			return v
+		},
+		Doc:    "delete buffer row count quota, high water level",
+		Export: true,
 	}
 	p.DeleteBufferRowCountHighWaterLevel.Init(base.mgr)
 
@@ -1916,15 +2462,64 @@ but the rate will not be lower than minRateRatio * dmlRate.`,
 	}
 	p.DeleteBufferSizeLowWaterLevel.Init(base.mgr)
 
+	defaultDeleteBufferSizeHighWaterLevel := "268435456" // 256MB
 	p.DeleteBufferSizeHighWaterLevel = ParamItem{
 		Key:          "quotaAndLimits.limitWriting.deleteBufferSizeProtection.highWaterLevel",
 		Version:      "2.4.11",
-		DefaultValue: "268435456", // 256MB
-		Doc:          "delete buffer size quota, high water level",
-		Export:       true,
+		DefaultValue: defaultDeleteBufferSizeHighWaterLevel,
+		Formatter: func(v string) string {
+			if !p.checkMinMaxLegal(p.DeleteBufferSizeLowWaterLevel.GetAsFloat(), getAsFloat(v)) {
+				return defaultDeleteBufferSizeHighWaterLevel
+			}
+			return v
+		},
+		Doc:    "delete buffer size quota, high water level",
+		Export: true,
 	}
 	p.DeleteBufferSizeHighWaterLevel.Init(base.mgr)
 
+	p.ReplicationLagProtectionEnabled = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.replicationLagProtection.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc:          "switch to slow down the writes when the cross-cluster replication lags behind",
+		Export:       true,
+	}
+	p.ReplicationLagProtectionEnabled.Init(base.mgr)
+
+	p.MaxReplicationLag = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.replicationLagProtection.maxLag",
+		Version:      "3.0.0",
+		DefaultValue: "300",
+		Formatter: func(v string) string {
+			if getAsFloat(v) <= 0 {
+				return "300"
+			}
+			return v
+		},
+		Doc: `maxLag is the replication lag in seconds to reach the minimal write rate,
+the write rates are reduced according to the ratio of the replication lag to maxLag.
+It can be overridden by the collection property collection.replication.maxLag.seconds.`,
+		Export: true,
+	}
+	p.MaxReplicationLag.Init(base.mgr)
+
+	p.ReplicationLagMinRateRatio = ParamItem{
+		Key:          "quotaAndLimits.limitWriting.replicationLagProtection.minRateRatio",
+		Version:      "3.0.0",
+		DefaultValue: "0.1",
+		Formatter: func(v string) string {
+			f := getAsFloat(v)
+			if f < 0 || f > 1 {
+				return "0.1"
+			}
+			return v
+		},
+		Doc:    "the minimal ratio of the write rates kept when the replication lag exceeds maxLag",
+		Export: true,
+	}
+	p.ReplicationLagMinRateRatio.Init(base.mgr)
+
 	// limit reading
 	p.ForceDenyReading = ParamItem{
 		Key:          "quotaAndLimits.limitReading.forceDeny",
@@ -1936,6 +2531,247 @@ specific conditions, such as collection has been dropped), ` + "true" + ` means
 	}
 	p.ForceDenyReading.Init(base.mgr)
 
+	p.SlowQueryProtectionEnabled = ParamItem{
+		Key:          "quotaAndLimits.limitReading.slowQueryProtection.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `switch to reduce the dql limits of the collections whose slow query rate exceeds maxSlowQueryRate,
+a dql request is slow if its latency exceeds the collection property collection.dql.maxLatency.ms or proxy.slowQuerySpanInSeconds`,
+		Export: true,
+	}
+	p.SlowQueryProtectionEnabled.Init(base.mgr)
+
+	p.MaxSlowQueryRate = ParamItem{
+		Key:          "quotaAndLimits.limitReading.slowQueryProtection.maxSlowQueryRate",
+		Version:      "3.0.0",
+		DefaultValue: "10",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 0 {
+				return "10"
+			}
+			return v
+		},
+		Doc:    "the max slow dql requests per second of a collection before its dql limits are reduced",
+		Export: true,
+	}
+	p.MaxSlowQueryRate.Init(base.mgr)
+
+	p.SlowQueryCoolOffSpeed = ParamItem{
+		Key:          "quotaAndLimits.limitReading.slowQueryProtection.coolOffSpeed",
+		Version:      "3.0.0",
+		DefaultValue: "0.9",
+		Formatter: func(v string) string {
+			f := getAsFloat(v)
+			if f <= 0 || f >= 1 {
+				return "0.9"
+			}
+			return v
+		},
+		Doc:    "the factor multiplied to the dql limits every round while the slow query rate exceeds maxSlowQueryRate, range (0, 1)",
+		Export: true,
+	}
+	p.SlowQueryCoolOffSpeed.Init(base.mgr)
+
+	p.SlowQueryMinRateRatio = ParamItem{
+		Key:          "quotaAndLimits.limitReading.slowQueryProtection.minRateRatio",
+		Version:      "3.0.0",
+		DefaultValue: "0.1",
+		Formatter: func(v string) string {
+			f := getAsFloat(v)
+			if f < 0 || f > 1 {
+				return "0.1"
+			}
+			return v
+		},
+		Doc:    "the minimal ratio of the dql rates kept when the slow query rate exceeds maxSlowQueryRate",
+		Export: true,
+	}
+	p.SlowQueryMinRateRatio.Init(base.mgr)
+
+	p.DQLUsageBasedDBLimitEnabled = ParamItem{
+		Key:          "quotaAndLimits.limitReading.usageBasedDBLimit.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `switch to limit the dql rates of each database by its recent usage, the limit is the sum of the recent dql rates
+of the loaded collections in the database multiplied by multiplier, it never exceeds the static database limits.
+It bounds the sudden growth of a noisy database while the steady ones expand gradually round by round.`,
+		Export: true,
+	}
+	p.DQLUsageBasedDBLimitEnabled.Init(base.mgr)
+
+	p.DQLUsageBasedDBLimitMultiplier = ParamItem{
+		Key:          "quotaAndLimits.limitReading.usageBasedDBLimit.multiplier",
+		Version:      "3.0.0",
+		DefaultValue: "2",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 1 {
+				return "2"
+			}
+			return v
+		},
+		Doc:    "the multiplier of the recent dql rates of a database to compute its dql limits, at least 1",
+		Export: true,
+	}
+	p.DQLUsageBasedDBLimitMultiplier.Init(base.mgr)
+
+	p.DQLUsageBasedDBLimitSmoothing = ParamItem{
+		Key:          "quotaAndLimits.limitReading.usageBasedDBLimit.smoothing",
+		Version:      "3.0.0",
+		DefaultValue: "0.3",
+		Formatter: func(v string) string {
+			f := getAsFloat(v)
+			if f <= 0 || f > 1 {
+				return "0.3"
+			}
+			return v
+		},
+		Doc: `the weight of the latest dql rates in the recent dql rates of a database, range (0, 1],
+the smaller it is, the slower the limits follow the changes of the usage`,
+		Export: true,
+	}
+	p.DQLUsageBasedDBLimitSmoothing.Init(base.mgr)
+
+	p.DQLUsageBasedDBLimitMinRate = ParamItem{
+		Key:          "quotaAndLimits.limitReading.usageBasedDBLimit.minRate",
+		Version:      "3.0.0",
+		DefaultValue: "100",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 0 {
+				return "100"
+			}
+			return v
+		},
+		Doc: `the minimal usage based dql limits of each database, vps for search and qps for query and get,
+so that the idle databases could start to read`,
+		Export: true,
+	}
+	p.DQLUsageBasedDBLimitMinRate.Init(base.mgr)
+
+	p.StorageUsageReportEnabled = ParamItem{
+		Key:          "quotaAndLimits.storageUsageReport.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc:          "switch to enable the periodic per-database storage usage report, only works when quotaAndLimits is enabled",
+		Export:       true,
+	}
+	p.StorageUsageReportEnabled.Init(base.mgr)
+
+	const defaultStorageUsageReportInterval = "60"
+	p.StorageUsageReportInterval = ParamItem{
+		Key:          "quotaAndLimits.storageUsageReport.interval",
+		Version:      "3.0.0",
+		DefaultValue: defaultStorageUsageReportInterval,
+		Formatter: func(v string) string {
+			if getAsInt(v) <= 0 {
+				return defaultStorageUsageReportInterval
+			}
+			return v
+		},
+		Doc:    "interval of the storage usage report, in seconds",
+		Export: true,
+	}
+	p.StorageUsageReportInterval.Init(base.mgr)
+
+	p.StorageUsageReportWebhookURL = ParamItem{
+		Key:          "quotaAndLimits.storageUsageReport.webhook.url",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "the storage usage report will be posted to this endpoint as json if it is not empty",
+		Export:       true,
+	}
+	p.StorageUsageReportWebhookURL.Init(base.mgr)
+
+	p.StorageUsageReportWebhookTimeout = ParamItem{
+		Key:          "quotaAndLimits.storageUsageReport.webhook.timeout",
+		Version:      "3.0.0",
+		DefaultValue: "10",
+		Doc:          "timeout of posting the storage usage report to the webhook, in seconds",
+		Export:       true,
+	}
+	p.StorageUsageReportWebhookTimeout.Init(base.mgr)
+
+	p.ScheduledWindows = ParamItem{
+		Key:          "quotaAndLimits.scheduledWindows",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `time-of-day windows in which the dml and dql rate limits are scaled, in json format, e.g.
+[{"start": "09:00", "end": "18:00", "dmlFactor": 0.5}, {"start": "22:00", "end": "06:00", "dmlFactor": 2}]
+start is inclusive and end is exclusive in the local time of rootcoord, a window whose end is earlier than its start crosses midnight, a window whose end equals its start covers the whole day.
+dmlFactor and dqlFactor default to 1, the first matched window takes effect.
+It can be overridden by the database property database.quota.scheduledWindows.`,
+		Export: true,
+	}
+	p.ScheduledWindows.Init(base.mgr)
+
+	p.ExemptionSecret = ParamItem{
+		Key:          "quotaAndLimits.exemption.secret",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `the key to sign the quota exemption tokens issued by rootcoord, it must be the same on rootcoord and proxies.
+The exemption tokens are disabled if it is empty.`,
+		Export: true,
+	}
+	p.ExemptionSecret.Init(base.mgr)
+
+	p.ExemptionMaxTTL = ParamItem{
+		Key:          "quotaAndLimits.exemption.maxTTL",
+		Version:      "3.0.0",
+		DefaultValue: "3600",
+		Doc:          "seconds, the max lifetime of a quota exemption token",
+		Export:       true,
+	}
+	p.ExemptionMaxTTL.Init(base.mgr)
+
+	p.MetricsHistorySize = ParamItem{
+		Key:          "quotaAndLimits.metricsHistory.size",
+		Version:      "3.0.0",
+		DefaultValue: "60",
+		Doc:          "max number of the downsampled quota metrics snapshots kept by quotaCenter and returned by getQuotaMetrics, 0 to disable the history",
+		Export:       true,
+	}
+	p.MetricsHistorySize.Init(base.mgr)
+
+	p.MetricsHistorySampleInterval = ParamItem{
+		Key:          "quotaAndLimits.metricsHistory.sampleInterval",
+		Version:      "3.0.0",
+		DefaultValue: "60",
+		Doc:          "seconds, the minimal interval between two snapshots in the quota metrics history",
+		Export:       true,
+	}
+	p.MetricsHistorySampleInterval.Init(base.mgr)
+
+	p.AppTagRateLimits = ParamItem{
+		Key:          "quotaAndLimits.appTag.rateLimits",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `the rate limits of the client applications on each collection in json, keyed by the app tag, e.g.
+{"etl": {"collection.insertRate.max.mb": "10"}, "dashboard": {"collection.searchRate.max.vps": "100", "collection.queryRate.max.qps": "50"}}
+The app tag of a connection is the app_name reserved in its client info, the keys are the collection rate limit properties of insert, delete, bulkLoad, search, query and get.
+The app tag limits are applied on top of the collection limits, the requests without the app tag are not limited by them.`,
+		Export: true,
+	}
+	p.AppTagRateLimits.Init(base.mgr)
+
+	p.DelegationEnabled = ParamItem{
+		Key:          "quotaAndLimits.delegation.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `whether to restrict the quota related database and collection properties altered by the users other than the cluster admins.
+The database owners could only lower the limits under the bounds defined by the quota configuration of the cluster admins,
+the other users are not allowed to alter them. It takes effect only if the authorization is enabled.`,
+		Export: true,
+	}
+	p.DelegationEnabled.Init(base.mgr)
+
+	p.DelegationOwnerRoles = ParamItem{
+		Key:          "quotaAndLimits.delegation.ownerRoles",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "comma separated roles of the database owners, a user with the role granted the DatabaseAdmin or All privilege on a database is the owner of the database",
+		Export:       true,
+	}
+	p.DelegationOwnerRoles.Init(base.mgr)
+
 	p.AllocRetryTimes = ParamItem{
 		Key:          "quotaAndLimits.limits.allocRetryTimes",
 		Version:      "2.4.0",
@@ -35,6 +35,7 @@ func TestQuotaParam(t *testing.T) {
 		assert.Equal(t, false, qc.DDLLimitEnabled.GetAsBool())
 		assert.Equal(t, defaultMax, qc.DDLCollectionRate.GetAsFloat())
 		assert.Equal(t, defaultMax, qc.DDLPartitionRate.GetAsFloat())
+		assert.Equal(t, 65536, qc.DDLPayloadUnit.GetAsInt())
 	})
 
 	t.Run("test deny all ddl", func(t *testing.T) {
@@ -52,6 +53,7 @@ func TestQuotaParam(t *testing.T) {
 		assert.EqualValues(t, 0, qc.MaxIndexRatePerDB.GetAsFloat())
 		assert.EqualValues(t, 0, qc.MaxCompactionRate.GetAsFloat())
 		assert.EqualValues(t, 0, qc.MaxCompactionRatePerDB.GetAsFloat())
+		assert.EqualValues(t, 0, qc.MaxCompactionRatePerCollection.GetAsFloat())
 		assert.EqualValues(t, 0, qc.MaxDBRate.GetAsFloat())
 	})
 
@@ -63,6 +65,7 @@ func TestQuotaParam(t *testing.T) {
 		assert.Equal(t, defaultMax, qc.MaxFlushRate.GetAsFloat())
 		assert.Equal(t, false, qc.CompactionLimitEnabled.GetAsBool())
 		assert.Equal(t, defaultMax, qc.MaxCompactionRate.GetAsFloat())
+		assert.Equal(t, defaultMax, qc.MaxCompactionRatePerCollection.GetAsFloat())
 	})
 
 	t.Run("test dml", func(t *testing.T) {
@@ -175,8 +178,45 @@ func TestQuotaParam(t *testing.T) {
 		assert.Equal(t, float64(0), params.QuotaConfig.DQLMinQueryRatePerCollection.GetAsFloat())
 	})
 
+	t.Run("test get dql", func(t *testing.T) {
+		params.Init(NewBaseTable(SkipRemote(true)))
+		params.Save(params.QuotaConfig.DQLLimitEnabled.Key, "true")
+		params.Save(params.QuotaConfig.DQLMaxQueryRate.Key, "10")
+		params.Save(params.QuotaConfig.DQLMaxQueryRatePerCollection.Key, "5")
+		// the get rates follow the query rates by default
+		assert.Equal(t, float64(10), params.QuotaConfig.DQLMaxGetRate.GetAsFloat())
+		assert.Equal(t, float64(5), params.QuotaConfig.DQLMaxGetRatePerCollection.GetAsFloat())
+		assert.Equal(t, float64(0), params.QuotaConfig.DQLMinGetRate.GetAsFloat())
+
+		params.Save(params.QuotaConfig.DQLMaxGetRate.Key, "100")
+		params.Save(params.QuotaConfig.DQLMinGetRate.Key, "20")
+		params.Save(params.QuotaConfig.DQLMaxGetRatePerCollection.Key, "50")
+		params.Save(params.QuotaConfig.DQLMinGetRatePerCollection.Key, "60")
+		assert.Equal(t, float64(100), params.QuotaConfig.DQLMaxGetRate.GetAsFloat())
+		assert.Equal(t, float64(20), params.QuotaConfig.DQLMinGetRate.GetAsFloat())
+		assert.Equal(t, float64(50), params.QuotaConfig.DQLMaxGetRatePerCollection.GetAsFloat())
+		assert.Equal(t, float64(0), params.QuotaConfig.DQLMinGetRatePerCollection.GetAsFloat())
+
+		params.Save(params.QuotaConfig.DQLLimitEnabled.Key, "false")
+		assert.Equal(t, defaultMax, params.QuotaConfig.DQLMaxGetRate.GetAsFloat())
+	})
+
+	t.Run("test db guaranteed dql", func(t *testing.T) {
+		params.Init(NewBaseTable(SkipRemote(true)))
+		assert.Equal(t, float64(0), params.QuotaConfig.DQLGuaranteedSearchRatePerDB.GetAsFloat())
+		assert.Equal(t, float64(0), params.QuotaConfig.DQLGuaranteedQueryRatePerDB.GetAsFloat())
+
+		params.Save(params.QuotaConfig.DQLGuaranteedSearchRatePerDB.Key, "100")
+		params.Save(params.QuotaConfig.DQLGuaranteedQueryRatePerDB.Key, "-1")
+		defer params.Reset(params.QuotaConfig.DQLGuaranteedSearchRatePerDB.Key)
+		defer params.Reset(params.QuotaConfig.DQLGuaranteedQueryRatePerDB.Key)
+		assert.Equal(t, float64(100), params.QuotaConfig.DQLGuaranteedSearchRatePerDB.GetAsFloat())
+		assert.Equal(t, float64(0), params.QuotaConfig.DQLGuaranteedQueryRatePerDB.GetAsFloat())
+	})
+
 	t.Run("test limits", func(t *testing.T) {
 		params.Init(NewBaseTable(SkipRemote(true)))
+		assert.Equal(t, 64, qc.MaxDatabaseNum.GetAsInt())
 		assert.Equal(t, 65536, qc.MaxCollectionNum.GetAsInt())
 		assert.Equal(t, 65536, qc.MaxCollectionNumPerDB.GetAsInt())
 		assert.Equal(t, 1024, params.QuotaConfig.MaxResourceGroupNumOfQueryNode.GetAsInt())
@@ -190,12 +230,29 @@ func TestQuotaParam(t *testing.T) {
 
 	t.Run("test limit writing", func(t *testing.T) {
 		assert.False(t, qc.ForceDenyWriting.GetAsBool())
+		assert.Empty(t, qc.FactorExcludedNodes.GetAsStrings())
+		assert.Equal(t, 0, qc.NodeQuarantineRounds.GetAsInt())
+		params.Save(params.QuotaConfig.NodeQuarantineRounds.Key, "-1")
+		assert.Equal(t, 0, qc.NodeQuarantineRounds.GetAsInt())
+		params.Reset(params.QuotaConfig.NodeQuarantineRounds.Key)
+
+		assert.Equal(t, 0, qc.DenyCooldownMaxCycles.GetAsInt())
+		assert.Equal(t, 600.0, qc.DenyCooldownWindowSeconds.GetAsFloat())
+		assert.Equal(t, 1800.0, qc.DenyCooldownSeconds.GetAsFloat())
+		assert.Equal(t, 0.5, qc.DenyCooldownFactor.GetAsFloat())
+		baseParams.Save(qc.DenyCooldownFactor.Key, "0")
+		assert.Equal(t, 0.5, qc.DenyCooldownFactor.GetAsFloat())
+		baseParams.Save(qc.DenyCooldownFactor.Key, "0.2")
+		assert.Equal(t, 0.2, qc.DenyCooldownFactor.GetAsFloat())
+		baseParams.Reset(qc.DenyCooldownFactor.Key)
 		assert.Equal(t, false, qc.TtProtectionEnabled.GetAsBool())
 		assert.Equal(t, 1200, qc.MaxTimeTickDelay.GetAsInt())
 		assert.Equal(t, defaultLowWaterLevel, qc.DataNodeMemoryLowWaterLevel.GetAsFloat())
 		assert.Equal(t, defaultHighWaterLevel, qc.DataNodeMemoryHighWaterLevel.GetAsFloat())
 		assert.Equal(t, defaultLowWaterLevel, qc.QueryNodeMemoryLowWaterLevel.GetAsFloat())
 		assert.Equal(t, defaultHighWaterLevel, qc.QueryNodeMemoryHighWaterLevel.GetAsFloat())
+		assert.Equal(t, defaultLowWaterLevel, qc.StreamingNodeMemoryLowWaterLevel.GetAsFloat())
+		assert.Equal(t, defaultHighWaterLevel, qc.StreamingNodeMemoryHighWaterLevel.GetAsFloat())
 		assert.Equal(t, false, qc.GrowingSegmentsSizeProtectionEnabled.GetAsBool())
 		assert.Equal(t, 0.5, qc.GrowingSegmentsSizeMinRateRatio.GetAsFloat())
 		assert.Equal(t, 0.2, qc.GrowingSegmentsSizeLowWaterLevel.GetAsFloat())
@@ -205,10 +262,36 @@ func TestQuotaParam(t *testing.T) {
 		assert.Equal(t, defaultMax, qc.DiskQuotaPerCollection.GetAsFloat())
 	})
 
+	t.Run("test delegation", func(t *testing.T) {
+		assert.False(t, qc.DelegationEnabled.GetAsBool())
+		assert.Empty(t, qc.DelegationOwnerRoles.GetAsStrings())
+		baseParams.Save(qc.DelegationOwnerRoles.Key, "db_owner,tenant_owner")
+		assert.Equal(t, []string{"db_owner", "tenant_owner"}, qc.DelegationOwnerRoles.GetAsStrings())
+		baseParams.Reset(qc.DelegationOwnerRoles.Key)
+	})
+
 	t.Run("test limit reading", func(t *testing.T) {
 		assert.False(t, qc.ForceDenyReading.GetAsBool())
 	})
 
+	t.Run("test usage based db limit", func(t *testing.T) {
+		assert.False(t, qc.DQLUsageBasedDBLimitEnabled.GetAsBool())
+		assert.Equal(t, 2.0, qc.DQLUsageBasedDBLimitMultiplier.GetAsFloat())
+		assert.Equal(t, 0.3, qc.DQLUsageBasedDBLimitSmoothing.GetAsFloat())
+		assert.Equal(t, 100.0, qc.DQLUsageBasedDBLimitMinRate.GetAsFloat())
+
+		// test invalid config
+		baseParams.Save(qc.DQLUsageBasedDBLimitMultiplier.Key, "0.5")
+		assert.Equal(t, 2.0, qc.DQLUsageBasedDBLimitMultiplier.GetAsFloat())
+		baseParams.Save(qc.DQLUsageBasedDBLimitSmoothing.Key, "0")
+		assert.Equal(t, 0.3, qc.DQLUsageBasedDBLimitSmoothing.GetAsFloat())
+		baseParams.Save(qc.DQLUsageBasedDBLimitMinRate.Key, "-1")
+		assert.Equal(t, 100.0, qc.DQLUsageBasedDBLimitMinRate.GetAsFloat())
+		baseParams.Reset(qc.DQLUsageBasedDBLimitMultiplier.Key)
+		baseParams.Reset(qc.DQLUsageBasedDBLimitSmoothing.Key)
+		baseParams.Reset(qc.DQLUsageBasedDBLimitMinRate.Key)
+	})
+
 	t.Run("test disk quota", func(t *testing.T) {
 		assert.Equal(t, defaultMax, qc.DiskQuota.GetAsFloat())
 		assert.Equal(t, defaultMax, qc.DiskQuotaPerCollection.GetAsFloat())
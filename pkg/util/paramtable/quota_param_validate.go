@@ -0,0 +1,97 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramtable
+
+import (
+	"strconv"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+)
+
+// quotaConfigRange is a pair of the quota configs, the low one must not be greater than the high one
+// once the protection switching them is enabled.
+type quotaConfigRange struct {
+	enabled *ParamItem
+	low     *ParamItem
+	high    *ParamItem
+}
+
+func (p *quotaConfig) configRanges() []quotaConfigRange {
+	return []quotaConfigRange{
+		{&p.DMLLimitEnabled, &p.DMLMinInsertRate, &p.DMLMaxInsertRate},
+		{&p.DMLLimitEnabled, &p.DMLMinDeleteRate, &p.DMLMaxDeleteRate},
+		{&p.DMLLimitEnabled, &p.DMLMinBulkLoadRate, &p.DMLMaxBulkLoadRate},
+		{&p.DMLLimitEnabled, &p.DMLMinInsertRatePerDB, &p.DMLMaxInsertRatePerDB},
+		{&p.DMLLimitEnabled, &p.DMLMinDeleteRatePerDB, &p.DMLMaxDeleteRatePerDB},
+		{&p.DMLLimitEnabled, &p.DMLMinBulkLoadRatePerDB, &p.DMLMaxBulkLoadRatePerDB},
+		{&p.DMLLimitEnabled, &p.DMLMinInsertRatePerCollection, &p.DMLMaxInsertRatePerCollection},
+		{&p.DMLLimitEnabled, &p.DMLMinDeleteRatePerCollection, &p.DMLMaxDeleteRatePerCollection},
+		{&p.DMLLimitEnabled, &p.DMLMinBulkLoadRatePerCollection, &p.DMLMaxBulkLoadRatePerCollection},
+		{&p.DMLLimitEnabled, &p.DMLMinInsertRatePerPartition, &p.DMLMaxInsertRatePerPartition},
+		{&p.DMLLimitEnabled, &p.DMLMinDeleteRatePerPartition, &p.DMLMaxDeleteRatePerPartition},
+		{&p.DMLLimitEnabled, &p.DMLMinBulkLoadRatePerPartition, &p.DMLMaxBulkLoadRatePerPartition},
+		{&p.DQLLimitEnabled, &p.DQLMinSearchRate, &p.DQLMaxSearchRate},
+		{&p.DQLLimitEnabled, &p.DQLMinQueryRate, &p.DQLMaxQueryRate},
+		{&p.DQLLimitEnabled, &p.DQLMinSearchRatePerDB, &p.DQLMaxSearchRatePerDB},
+		{&p.DQLLimitEnabled, &p.DQLMinQueryRatePerDB, &p.DQLMaxQueryRatePerDB},
+		{&p.DQLLimitEnabled, &p.DQLMinSearchRatePerCollection, &p.DQLMaxSearchRatePerCollection},
+		{&p.DQLLimitEnabled, &p.DQLMinQueryRatePerCollection, &p.DQLMaxQueryRatePerCollection},
+		{&p.DQLLimitEnabled, &p.DQLMinSearchRatePerPartition, &p.DQLMaxSearchRatePerPartition},
+		{&p.DQLLimitEnabled, &p.DQLMinQueryRatePerPartition, &p.DQLMaxQueryRatePerPartition},
+		{&p.DQLLimitEnabled, &p.DQLMinGetRate, &p.DQLMaxGetRate},
+		{&p.DQLLimitEnabled, &p.DQLMinGetRatePerDB, &p.DQLMaxGetRatePerDB},
+		{&p.DQLLimitEnabled, &p.DQLMinGetRatePerCollection, &p.DQLMaxGetRatePerCollection},
+		{&p.MemProtectionEnabled, &p.DataNodeMemoryLowWaterLevel, &p.DataNodeMemoryHighWaterLevel},
+		{&p.MemProtectionEnabled, &p.QueryNodeMemoryLowWaterLevel, &p.QueryNodeMemoryHighWaterLevel},
+		{&p.GrowingSegmentsSizeProtectionEnabled, &p.GrowingSegmentsSizeLowWaterLevel, &p.GrowingSegmentsSizeHighWaterLevel},
+		{&p.L0SegmentRowCountProtectionEnabled, &p.L0SegmentRowCountLowWaterLevel, &p.L0SegmentRowCountHighWaterLevel},
+		{&p.DeleteBufferRowCountProtectionEnabled, &p.DeleteBufferRowCountLowWaterLevel, &p.DeleteBufferRowCountHighWaterLevel},
+		{&p.DeleteBufferSizeProtectionEnabled, &p.DeleteBufferSizeLowWaterLevel, &p.DeleteBufferSizeHighWaterLevel},
+	}
+}
+
+// Validate checks the min and max rates and the low and high water levels configured,
+// it returns the pairs whose low value is greater than the high value. The formatters of
+// the configs fall back to the defaults for such pairs, which are reported as the effective values.
+// The negative high values mean unlimited and are never reported.
+func (p *quotaConfig) Validate() []*metricsinfo.QuotaConfigIssue {
+	var issues []*metricsinfo.QuotaConfigIssue
+	for _, r := range p.configRanges() {
+		if !r.enabled.GetAsBool() {
+			continue
+		}
+		low, _, _ := r.low.getUnformatted()
+		high, _, _ := r.high.getUnformatted()
+		lowValue, err := strconv.ParseFloat(low, 64)
+		if err != nil {
+			continue
+		}
+		highValue, err := strconv.ParseFloat(high, 64)
+		if err != nil || highValue < 0 || lowValue <= highValue {
+			continue
+		}
+		issues = append(issues, &metricsinfo.QuotaConfigIssue{
+			LowKey:        r.low.Key,
+			LowValue:      low,
+			HighKey:       r.high.Key,
+			HighValue:     high,
+			EffectiveLow:  r.low.GetValue(),
+			EffectiveHigh: r.high.GetValue(),
+		})
+	}
+	return issues
+}
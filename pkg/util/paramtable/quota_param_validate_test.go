@@ -0,0 +1,76 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaConfigValidate(t *testing.T) {
+	bt := NewBaseTable(SkipRemote(true))
+	qc := &quotaConfig{}
+	qc.init(bt)
+	assert.Empty(t, qc.Validate())
+
+	t.Run("disabled protection is not validated", func(t *testing.T) {
+		bt.Save(qc.L0SegmentRowCountLowWaterLevel.Key, "100")
+		bt.Save(qc.L0SegmentRowCountHighWaterLevel.Key, "10")
+		defer bt.Reset(qc.L0SegmentRowCountLowWaterLevel.Key)
+		defer bt.Reset(qc.L0SegmentRowCountHighWaterLevel.Key)
+		assert.Empty(t, qc.Validate())
+	})
+
+	t.Run("water levels", func(t *testing.T) {
+		bt.Save(qc.L0SegmentRowCountProtectionEnabled.Key, "true")
+		bt.Save(qc.L0SegmentRowCountLowWaterLevel.Key, "100")
+		bt.Save(qc.L0SegmentRowCountHighWaterLevel.Key, "10")
+		defer bt.Reset(qc.L0SegmentRowCountProtectionEnabled.Key)
+		defer bt.Reset(qc.L0SegmentRowCountLowWaterLevel.Key)
+		defer bt.Reset(qc.L0SegmentRowCountHighWaterLevel.Key)
+
+		issues := qc.Validate()
+		assert.Len(t, issues, 1)
+		assert.Equal(t, qc.L0SegmentRowCountLowWaterLevel.Key, issues[0].LowKey)
+		assert.Equal(t, "100", issues[0].LowValue)
+		assert.Equal(t, qc.L0SegmentRowCountHighWaterLevel.Key, issues[0].HighKey)
+		assert.Equal(t, "10", issues[0].HighValue)
+		// the high water level falls back to the default
+		assert.Equal(t, "50000000", issues[0].EffectiveHigh)
+		assert.Equal(t, int64(50000000), qc.L0SegmentRowCountHighWaterLevel.GetAsInt64())
+	})
+
+	t.Run("rates", func(t *testing.T) {
+		bt.Save(qc.DQLLimitEnabled.Key, "true")
+		bt.Save(qc.DQLMinSearchRate.Key, "100")
+		bt.Save(qc.DQLMaxSearchRate.Key, "10")
+		defer bt.Reset(qc.DQLLimitEnabled.Key)
+		defer bt.Reset(qc.DQLMinSearchRate.Key)
+		defer bt.Reset(qc.DQLMaxSearchRate.Key)
+
+		issues := qc.Validate()
+		assert.Len(t, issues, 1)
+		assert.Equal(t, qc.DQLMinSearchRate.Key, issues[0].LowKey)
+		// the min rate falls back to the default
+		assert.Equal(t, float64(0), qc.DQLMinSearchRate.GetAsFloat())
+
+		// negative max rate means unlimited
+		bt.Save(qc.DQLMaxSearchRate.Key, "-1")
+		assert.Empty(t, qc.Validate())
+	})
+}
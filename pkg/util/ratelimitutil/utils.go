@@ -39,3 +39,34 @@ func GetQuotaErrorStringWithReason(errCode commonpb.ErrorCode, reason string) st
 	}
 	return baseMsg
 }
+
+// The keys of the machine-readable detail in the ExtraInfo of the status returned for the quota denials,
+// so that the SDKs can tell which limiter rejects the request and back off accordingly.
+const (
+	// QuotaScopeKey is the scope of the limiter rejecting the request, e.g. Cluster, Database, Collection or Partition.
+	QuotaScopeKey = "quota_scope"
+	// QuotaResourceIDKey is the id of the database, collection or partition of the limiter, 0 for the cluster.
+	QuotaResourceIDKey = "quota_resource_id"
+	// QuotaRateTypeKey is the rate type of the request.
+	QuotaRateTypeKey = "quota_rate_type"
+	// QuotaStateKey is the quota state denying the request, absent if the request is rejected by the rate limit.
+	QuotaStateKey = "quota_state"
+	// QuotaMetricKey is the metric triggering the quota state, see QuotaErrorMetric.
+	QuotaMetricKey = "quota_metric"
+)
+
+// QuotaErrorMetric maps the error code of the quota state to the metric triggering it.
+var QuotaErrorMetric = map[commonpb.ErrorCode]string{
+	commonpb.ErrorCode_ForceDeny:            "force_deny",
+	commonpb.ErrorCode_MemoryQuotaExhausted: "memory",
+	commonpb.ErrorCode_DiskQuotaExhausted:   "disk",
+	commonpb.ErrorCode_TimeTickLongDelay:    "time_tick_delay",
+}
+
+// GetQuotaErrorMetric returns the metric triggering the quota state with the error code.
+func GetQuotaErrorMetric(errCode commonpb.ErrorCode) string {
+	if metric, ok := QuotaErrorMetric[errCode]; ok {
+		return metric
+	}
+	return "unknown"
+}